@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/cache"
+	"github.com/tmc/nlm/internal/config"
+	"github.com/tmc/nlm/internal/rest"
+)
+
+// accountHeader names the account a request to `nlm serve -accounts` is
+// made for. The account query parameter does the same for clients that
+// cannot set headers.
+const accountHeader = "X-NLM-Account"
+
+// serveAccount is a config profile served by `nlm serve -accounts`. Each
+// account has its own client, rate limiter and cache, so one account's
+// traffic and throttling do not affect another's.
+type serveAccount struct {
+	Name    string
+	Client  *api.Client
+	profile *config.Profile
+	cache   *cache.Cache
+}
+
+// loadServeAccounts makes a client for each of the named config profiles,
+// sending requests no closer together than interval.
+func loadServeAccounts(names []string, interval time.Duration) ([]*serveAccount, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	var accounts []*serveAccount
+	for _, name := range names {
+		p := cfg.Lookup(name)
+		if p == nil {
+			return nil, fmt.Errorf("account %q: no such config profile", name)
+		}
+		if p.AuthToken == "" || p.Cookies == "" {
+			return nil, fmt.Errorf("account %q is not signed in; run 'nlm -config-profile %s auth'", name, name)
+		}
+		c, err := api.New(context.Background(),
+			api.WithAuthProfile(name),
+			api.WithRateLimit(interval),
+			api.WithBatchExecuteOptions(clientOpts...))
+		if err != nil {
+			return nil, fmt.Errorf("account %q: %w", name, err)
+		}
+		if useDirectRPC {
+			c.SetUseDirectRPC(true)
+		}
+		accounts = append(accounts, &serveAccount{
+			Name:    name,
+			Client:  c,
+			profile: p,
+			cache:   cache.Open(filepath.Join(dir, "accounts", name)),
+		})
+	}
+	return accounts, nil
+}
+
+// resolveNotebook is resolveNotebook for the account: its own aliases
+// are used, and the notebook list is cached with the account.
+func (a *serveAccount) resolveNotebook(ref string) (string, error) {
+	if id := a.profile.Alias(ref); id != "" {
+		return id, nil
+	}
+	if notebookIDPattern.MatchString(ref) {
+		return ref, nil
+	}
+	notebooks, err := a.Client.ListRecentlyViewedProjects()
+	if err != nil {
+		return ref, nil
+	}
+	if data, err := marshalList(notebooks); err == nil {
+		if err := a.cache.Put(notebooksKey(), data); err != nil {
+			verbosef(1, "nlm: warning: failed to cache %s: %v\n", notebooksKey(), err)
+		}
+	}
+	return matchNotebook(notebooks, ref)
+}
+
+// accountRouter sends each request to the handler of the account it
+// names, or to the first account's if it names none.
+type accountRouter struct {
+	apiKey   string
+	names    []string
+	handlers map[string]http.Handler
+}
+
+func (ar *accountRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.Header.Get(accountHeader)
+	if name == "" {
+		name = r.URL.Query().Get("account")
+	}
+	if name == "" {
+		name = ar.names[0]
+	}
+	h, ok := ar.handlers[name]
+	if !ok {
+		// Only say which accounts exist to clients with the key.
+		if !rest.Authorized(r, ar.apiKey) {
+			h = ar.handlers[ar.names[0]]
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": fmt.Sprintf("unknown account %q; serving %s", name, strings.Join(ar.names, ", ")),
+			})
+			return
+		}
+	}
+	h.ServeHTTP(w, r)
+}
+
+// accountsHandler serves each account with a server of its own, routed
+// by the X-NLM-Account header or account query parameter.
+func accountsHandler(accounts []*serveAccount, opts *serveOptions, m *serverMetrics) (http.Handler, error) {
+	if len(accounts) == 0 {
+		return nil, errors.New("no accounts to serve")
+	}
+	ar := &accountRouter{apiKey: opts.APIKey, handlers: make(map[string]http.Handler)}
+	for _, a := range accounts {
+		srv := newRESTServer(a.Client, opts, m, a.resolveNotebook)
+		ar.names = append(ar.names, a.Name)
+		ar.handlers[a.Name] = srv.Handler()
+	}
+	return ar, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAccountRouter(t *testing.T) {
+	handler := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, name)
+		})
+	}
+	ar := &accountRouter{
+		apiKey:   "key",
+		names:    []string{"work", "home"},
+		handlers: map[string]http.Handler{"work": handler("work"), "home": handler("home")},
+	}
+	tests := []struct {
+		header, target string
+		key            bool
+		wantStatus     int
+		wantBody       string
+	}{
+		{"", "/v1/notebooks", true, 200, "work"},
+		{"home", "/v1/notebooks", true, 200, "home"},
+		{"", "/v1/notebooks?account=home", true, 200, "home"},
+		{"work", "/v1/notebooks?account=home", true, 200, "work"},
+		{"other", "/v1/notebooks", true, 404, `unknown account \"other\"; serving work, home`},
+		// Without the key, the default account's server refuses the request.
+		{"other", "/v1/notebooks", false, 200, "work"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", tt.target, nil)
+		if tt.header != "" {
+			r.Header.Set(accountHeader, tt.header)
+		}
+		if tt.key {
+			r.Header.Set("Authorization", "Bearer key")
+		}
+		w := httptest.NewRecorder()
+		ar.ServeHTTP(w, r)
+		if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+			t.Errorf("%s %s: got %d %q, want %d %q", tt.header, tt.target, w.Code, w.Body, tt.wantStatus, tt.wantBody)
+		}
+	}
+}
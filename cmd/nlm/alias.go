@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/tmc/nlm/internal/config"
+)
+
+// aliasUsage lists the `nlm alias` subcommands.
+const aliasUsage = "usage: nlm alias <set|rm|list> ...\n"
+
+func validateAliasArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, aliasUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	switch args[0] {
+	case "set":
+		if len(args) != 3 {
+			fmt.Fprintf(os.Stderr, "usage: nlm alias set <name> <notebook-id>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+		if notebookIDPattern.MatchString(args[1]) {
+			fmt.Fprintf(os.Stderr, "nlm: alias %q looks like a notebook ID\n", args[1])
+			return fmt.Errorf("invalid arguments")
+		}
+	case "rm":
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "usage: nlm alias rm <name>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+	case "list", "ls":
+		if len(args) != 1 {
+			fmt.Fprintf(os.Stderr, "usage: nlm alias list\n")
+			return fmt.Errorf("invalid arguments")
+		}
+	default:
+		fmt.Fprint(os.Stderr, aliasUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	return nil
+}
+
+// runAlias manages notebook aliases, which are stored in the active
+// config profile.
+func runAlias(args []string) error {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	name := cfg.ActiveName(configProfile)
+
+	switch args[0] {
+	case "set", "rm":
+		alias, id := args[1], ""
+		if args[0] == "set" {
+			id = args[2]
+		}
+		err := config.Update(path, func(cfg *config.Config) error {
+			if id == "" && cfg.Lookup(name).Alias(alias) == "" {
+				return fmt.Errorf("no alias %q in profile %q", alias, name)
+			}
+			p := cfg.Ensure(name)
+			p.SetAlias(alias, id)
+			if id == "" {
+				p.SetAliasPersona(alias, "")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "nlm: updated %s\n", path)
+		return nil
+	default:
+		aliases := []notebookAlias{}
+		if p := cfg.Lookup(name); p != nil {
+			for a, id := range p.Aliases {
+				aliases = append(aliases, notebookAlias{Name: a, NotebookID: id})
+			}
+		}
+		sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+		return render(aliases, func(out io.Writer) error {
+			return writeAliases(out, aliases)
+		})
+	}
+}
+
+// notebookAlias is the machine-readable form of an alias.
+type notebookAlias struct {
+	Name       string `json:"name"`
+	NotebookID string `json:"notebook_id"`
+}
+
+func writeAliases(out io.Writer, aliases []notebookAlias) error {
+	if len(aliases) == 0 {
+		fmt.Fprintln(out, "No aliases defined.")
+		return nil
+	}
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "ALIAS\tNOTEBOOK")
+	for _, a := range aliases {
+		fmt.Fprintf(w, "%s\t%s\n", a.Name, a.NotebookID)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,948 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/gdrive"
+	"github.com/tmc/nlm/internal/jobs"
+	"github.com/tmc/nlm/internal/notify"
+	"github.com/tmc/nlm/internal/provenance"
+	"github.com/tmc/nlm/internal/richtext"
+)
+
+// artifactUsage lists the `nlm artifact` subcommands.
+const artifactUsage = "usage: nlm artifact <create|cat|inspect|download|export|update|refresh|save-as-note|rm> ...\n"
+
+func validateArtifactArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, artifactUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	switch args[0] {
+	case "create":
+		_, err := parseArtifactCreateFlags(args[1:])
+		return err
+	case "cat":
+		_, err := parseArtifactCatFlags(args[1:])
+		return err
+	case "inspect":
+		_, err := parseArtifactInspectFlags(args[1:])
+		return err
+	case "download":
+		_, err := parseArtifactDownloadFlags(args[1:])
+		return err
+	case "export":
+		_, err := parseArtifactExportFlags(args[1:])
+		return err
+	case "update":
+		_, err := parseArtifactUpdateFlags(args[1:])
+		return err
+	case "refresh":
+		_, err := parseArtifactRefreshFlags(args[1:])
+		return err
+	case "save-as-note":
+		_, err := parseArtifactSaveFlags(args[1:])
+		return err
+	case "rm":
+		_, err := parseArtifactRmFlags(args[1:])
+		return err
+	default:
+		fmt.Fprint(os.Stderr, artifactUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+func runArtifact(c *api.Client, args []string) error {
+	switch args[0] {
+	case "create":
+		opts, err := parseArtifactCreateFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactCreate(c, opts)
+	case "cat":
+		opts, err := parseArtifactCatFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactCat(c, opts)
+	case "inspect":
+		opts, err := parseArtifactInspectFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactInspect(c, opts)
+	case "download":
+		opts, err := parseArtifactDownloadFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactDownload(c, opts)
+	case "export":
+		opts, err := parseArtifactExportFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactExport(c, opts)
+	case "update":
+		opts, err := parseArtifactUpdateFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactUpdate(c, opts)
+	case "refresh":
+		opts, err := parseArtifactRefreshFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactRefresh(c, opts)
+	case "save-as-note":
+		opts, err := parseArtifactSaveFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactSave(c, opts)
+	case "rm":
+		opts, err := parseArtifactRmFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return artifactRm(c, opts)
+	default:
+		fmt.Fprint(os.Stderr, artifactUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+// parseInterspersed parses fs from args, allowing flags to follow
+// positional arguments as in `nlm artifact create <id> -type faq`. It
+// returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return pos, nil
+		}
+		if n := len(args) - len(rest); n > 0 && args[n-1] == "--" {
+			return append(pos, rest...), nil
+		}
+		pos = append(pos, rest[0])
+		args = rest[1:]
+	}
+}
+
+// artifactCreateArgs contains the CLI options for `artifact create`
+type artifactCreateArgs struct {
+	NotebookID string
+	Kind       api.ArtifactKind
+	Options    api.CreateArtifactOptions
+	Notify     string
+	Pick       bool // choose sources interactively
+}
+
+func parseArtifactCreateFlags(args []string) (*artifactCreateArgs, error) {
+	var kind, sources string
+	opts := &artifactCreateArgs{}
+	fs := flag.NewFlagSet("artifact create", flag.ContinueOnError)
+	fs.StringVar(&kind, "type", "", "artifact type: study-guide, briefing-doc, faq, timeline, mind-map, slide-deck or infographic")
+	fs.StringVar(&sources, "sources", "", "comma-separated source IDs to use (default: all sources)")
+	fs.BoolVar(&opts.Pick, "pick", false, "choose the sources interactively")
+	fs.StringVar(&opts.Options.Language, "language", os.Getenv("NLM_LANGUAGE"), "output language code (default: en, or the config profile's language)")
+	fs.StringVar(&opts.Options.Instructions, "instructions", "", "custom instructions, e.g. \"focus on chapter 3\"")
+	addNotifyFlag(fs, &opts.Notify)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact create <notebook-id> -type <type> [-sources ids | -pick] [-language code] [-instructions text]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 || kind == "" {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+	if opts.Kind, err = api.ParseArtifactKind(kind); err != nil {
+		fs.Usage()
+		return nil, err
+	}
+	opts.Options.SourceIDs = splitIDs(sources)
+	if opts.Notify != "" {
+		if err := notify.Validate(opts.Notify); err != nil {
+			fs.Usage()
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
+func artifactCreate(c *api.Client, opts *artifactCreateArgs) error {
+	if f, ok := artifactFeatures[opts.Kind]; ok {
+		if err := requireFeature(c, f); err != nil {
+			return err
+		}
+	}
+	if opts.Pick {
+		ids, err := pickSources(c, opts.NotebookID, opts.Options.SourceIDs)
+		if err != nil {
+			return err
+		}
+		opts.Options.SourceIDs = ids
+	}
+	fmt.Fprintf(os.Stderr, "Creating %s in notebook %s...\n", opts.Kind, opts.NotebookID)
+	artifact, err := c.CreateArtifact(opts.NotebookID, opts.Kind, &opts.Options)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Created artifact: %s\n", artifact.ID)
+	fmt.Printf("  Type: %s\n", artifact.TypeName())
+	fmt.Printf("  State: %s\n", artifact.StateName())
+	recordProvenance(opts, artifact.ID, "")
+	copyResult(artifact.ID)
+	if artifact.State != pb.ArtifactState_ARTIFACT_STATE_READY {
+		return recordJob(jobs.KindArtifact, opts.NotebookID, artifact.ID, opts.Notify)
+	}
+	return nil
+}
+
+// recordProvenance saves the parameters an artifact was created with so
+// `artifact refresh` can regenerate it. Failing to record them is not fatal.
+func recordProvenance(opts *artifactCreateArgs, artifactID, refreshedFrom string) {
+	store, err := provenance.OpenDefault()
+	if err == nil {
+		err = store.Add(provenance.Record{
+			ArtifactID:    artifactID,
+			NotebookID:    opts.NotebookID,
+			Kind:          string(opts.Kind),
+			SourceIDs:     opts.Options.SourceIDs,
+			Language:      opts.Options.Language,
+			Instructions:  opts.Options.Instructions,
+			RefreshedFrom: refreshedFrom,
+		})
+	}
+	if err != nil && debug {
+		fmt.Fprintf(os.Stderr, "nlm: warning: failed to record provenance: %v\n", err)
+	}
+}
+
+// artifactCatArgs contains the CLI options for `artifact cat`
+type artifactCatArgs struct {
+	NotebookID string
+	ArtifactID string
+	Out        string
+}
+
+func parseArtifactCatFlags(args []string) (*artifactCatArgs, error) {
+	opts := &artifactCatArgs{}
+	fs := flag.NewFlagSet("artifact cat", flag.ContinueOnError)
+	fs.StringVar(&opts.Out, "out", "", "write the Markdown to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact cat <notebook-id> <artifact-id> [-out file.md]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func artifactCat(c *api.Client, opts *artifactCatArgs) error {
+	content, err := c.GetArtifactContent(opts.NotebookID, opts.ArtifactID)
+	if err != nil {
+		return err
+	}
+	cachePut(artifactKey(opts.NotebookID, opts.ArtifactID), []byte(content.Markdown))
+	return writeArtifactContent(opts, content.ID, content.Markdown)
+}
+
+// writeArtifactContent shows an artifact's Markdown or saves it to -out.
+func writeArtifactContent(opts *artifactCatArgs, id, markdown string) error {
+	if opts.Out == "" {
+		return showMarkdown(markdown)
+	}
+	if err := os.WriteFile(opts.Out, []byte(markdown), 0644); err != nil {
+		return fmt.Errorf("write artifact: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Saved %s to %s\n", id, opts.Out)
+	return nil
+}
+
+// artifactInspectArgs contains the CLI options for `artifact inspect`
+type artifactInspectArgs struct {
+	NotebookID string
+	ArtifactID string
+	Raw        bool
+}
+
+func parseArtifactInspectFlags(args []string) (*artifactInspectArgs, error) {
+	opts := &artifactInspectArgs{}
+	fs := flag.NewFlagSet("artifact inspect", flag.ContinueOnError)
+	fs.BoolVar(&opts.Raw, "raw", false, "print the raw positional JSON instead of the labelled fields")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact inspect <notebook-id> <artifact-id> [-raw]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+// artifactInspect prints the positional structure of an artifact with
+// inferred field labels. It works for any artifact type, which makes it
+// the starting point for supporting new kinds.
+func artifactInspect(c *api.Client, opts *artifactInspectArgs) error {
+	inspection, err := c.InspectArtifact(opts.NotebookID, opts.ArtifactID)
+	if err != nil {
+		return err
+	}
+	if opts.Raw {
+		out, err := json.MarshalIndent(inspection.Entry, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode artifact: %w", err)
+		}
+		fmt.Printf("%s\n", out)
+		return nil
+	}
+	writeInspection(os.Stdout, inspection)
+	return nil
+}
+
+func writeInspection(out io.Writer, inspection *api.ArtifactInspection) {
+	known := "known"
+	if !inspection.KnownType() {
+		known = "not decoded by this version of nlm"
+	}
+	fmt.Fprintf(out, "Artifact: %s\n", inspection.ID)
+	fmt.Fprintf(out, "Type:     %s (%s)\n\n", inspection.TypeName(), known)
+
+	w := newTable(out, 2)
+	fmt.Fprintln(w, "PATH\tKIND\tVALUE\tLABEL")
+	for _, f := range inspection.Fields {
+		indent := strings.Repeat("  ", f.Depth-1)
+		fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\n", indent, f.Path, f.Kind, f.Value, f.Label)
+	}
+	w.Flush()
+}
+
+// artifactDownloadArgs contains the CLI options for `artifact download`
+type artifactDownloadArgs struct {
+	NotebookID string
+	ArtifactID string
+	Dir        string
+}
+
+func parseArtifactDownloadFlags(args []string) (*artifactDownloadArgs, error) {
+	opts := &artifactDownloadArgs{}
+	fs := flag.NewFlagSet("artifact download", flag.ContinueOnError)
+	fs.StringVar(&opts.Dir, "dir", ".", "directory to save the files in")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact download <notebook-id> <artifact-id> [-dir d]\n\n")
+		fmt.Fprintf(os.Stderr, "Downloads the rendered files of a slide deck or infographic.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func artifactDownload(c *api.Client, opts *artifactDownloadArgs) error {
+	assets, err := c.GetArtifactAssets(opts.NotebookID, opts.ArtifactID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+	bar := newProgressBar("Downloading", int64(len(assets)))
+	defer bar.Finish()
+	for i, asset := range assets {
+		var buf bytes.Buffer
+		contentType, err := c.DownloadArtifactAsset(asset, &buf)
+		if err != nil {
+			return fmt.Errorf("asset %d of %d: %w", i+1, len(assets), err)
+		}
+		bar.Add(1)
+		name := opts.ArtifactID
+		if len(assets) > 1 {
+			name = fmt.Sprintf("%s-%02d", name, i+1)
+		}
+		ext := asset.Ext
+		if ext == "" {
+			ext = extensionForType(contentType)
+		}
+		filename := filepath.Join(opts.Dir, name+ext)
+		if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("write asset: %w", err)
+		}
+		fmt.Printf("✅ Saved %s\n", filename)
+	}
+	return nil
+}
+
+// extensionForType picks a file extension for a downloaded asset's
+// content type.
+func extensionForType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/svg+xml":
+		return ".svg"
+	case "application/vnd.openxmlformats-officedocument.presentationml.presentation":
+		return ".pptx"
+	}
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
+
+// artifactExportArgs contains the CLI options for `artifact export`
+type artifactExportArgs struct {
+	NotebookID string
+	ArtifactID string
+	ToGDoc     bool
+	Title      string
+	Folder     string
+	Token      string
+}
+
+func parseArtifactExportFlags(args []string) (*artifactExportArgs, error) {
+	opts := &artifactExportArgs{}
+	fs := flag.NewFlagSet("artifact export", flag.ContinueOnError)
+	fs.BoolVar(&opts.ToGDoc, "to-gdoc", false, "create a Google Doc in your Drive")
+	fs.StringVar(&opts.Title, "title", "", "title for the doc (default: the artifact title)")
+	fs.StringVar(&opts.Folder, "folder", "", "Drive folder `id` to create the doc in (default: My Drive)")
+	fs.StringVar(&opts.Token, "token", os.Getenv("NLM_GOOGLE_TOKEN"), "OAuth access `token` with the drive.file scope (or set NLM_GOOGLE_TOKEN; default: the browser session)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact export <notebook-id> <artifact-id> -to-gdoc [-title t] [-folder id]\n\n")
+		fmt.Fprintf(os.Stderr, "Creates a Google Doc from a study guide, briefing doc, FAQ, timeline or\n")
+		fmt.Fprintf(os.Stderr, "other report, like the web app's Export to Docs, and prints its URL.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 || !opts.ToGDoc {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func artifactExport(c *api.Client, opts *artifactExportArgs) error {
+	content, err := c.GetArtifactContent(opts.NotebookID, opts.ArtifactID)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content.Markdown) == "" {
+		return fmt.Errorf("artifact %s has no text to export; only reports can be exported to Docs", opts.ArtifactID)
+	}
+	title := opts.Title
+	if title == "" {
+		title = strings.TrimSpace(content.Title)
+	}
+	if title == "" {
+		title = content.TypeName()
+	}
+	sp := startSpinner("Creating Google Doc %q", title)
+	doc, err := gdrive.New(opts.Token, cookies).CreateDoc(title, []byte(gdocHTML(title, content.Markdown)), opts.Folder)
+	sp.Stop()
+	if err != nil {
+		if opts.Token == "" && exitCode(err) == exitAuth {
+			return fmt.Errorf("%w; if your session cannot use Drive, pass -token or set NLM_GOOGLE_TOKEN", err)
+		}
+		return err
+	}
+	fmt.Println(doc.WebViewLink)
+	fmt.Fprintf(os.Stderr, "✅ Exported %s to Google Docs as %q\n", opts.ArtifactID, doc.Name)
+	copyResult(doc.WebViewLink)
+	return nil
+}
+
+// gdocHTML wraps an artifact's Markdown, converted to HTML, in the
+// document Drive converts to a Google Doc.
+func gdocHTML(title, markdown string) string {
+	return "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>" + html.EscapeString(title) +
+		"</title></head><body>\n" + richtext.ToHTML(markdown) + "</body></html>\n"
+}
+
+// artifactUpdateArgs contains the CLI options for `artifact update`
+type artifactUpdateArgs struct {
+	NotebookID  string
+	ArtifactID  string
+	Title       string
+	ContentFile string
+}
+
+func parseArtifactUpdateFlags(args []string) (*artifactUpdateArgs, error) {
+	opts := &artifactUpdateArgs{}
+	fs := flag.NewFlagSet("artifact update", flag.ContinueOnError)
+	fs.StringVar(&opts.Title, "title", "", "new title")
+	fs.StringVar(&opts.ContentFile, "content-file", "", "file with the new Markdown content (- for stdin)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact update <notebook-id> <artifact-id> [-title title] [-content-file file.md]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 || (opts.Title == "" && opts.ContentFile == "") {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func artifactUpdate(c *api.Client, opts *artifactUpdateArgs) error {
+	update := api.ArtifactUpdate{Title: opts.Title}
+	if opts.ContentFile != "" {
+		var data []byte
+		var err error
+		if opts.ContentFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(opts.ContentFile)
+		}
+		if err != nil {
+			return fmt.Errorf("read content: %w", err)
+		}
+		if len(data) == 0 {
+			return fmt.Errorf("content file %s is empty", opts.ContentFile)
+		}
+		update.Content = trimBOM(string(data))
+	}
+
+	var artifact *api.Artifact
+	var err error
+	if update.Content == "" {
+		// Title-only changes go through the dedicated rename RPC, which
+		// works for every artifact type.
+		artifact, err = c.RenameArtifact(opts.ArtifactID, update.Title)
+	} else {
+		artifact, err = c.UpdateArtifact(opts.NotebookID, opts.ArtifactID, update)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Updated artifact %s\n", artifact.ID)
+	if artifact.Title != "" {
+		fmt.Printf("  Title: %s\n", artifact.Title)
+	}
+	return nil
+}
+
+// artifactRefreshArgs contains the CLI options for `artifact refresh`
+type artifactRefreshArgs struct {
+	NotebookID string
+	ArtifactID string
+	Diff       bool
+	Replace    bool
+	Timeout    time.Duration
+	Notify     string
+}
+
+func parseArtifactRefreshFlags(args []string) (*artifactRefreshArgs, error) {
+	opts := &artifactRefreshArgs{}
+	fs := flag.NewFlagSet("artifact refresh", flag.ContinueOnError)
+	fs.BoolVar(&opts.Diff, "diff", false, "wait for the new artifact and diff its content against the old one")
+	fs.BoolVar(&opts.Replace, "replace", false, "wait for the new artifact and delete the old one")
+	fs.DurationVar(&opts.Timeout, "timeout", 10*time.Minute, "how long to wait with -diff or -replace")
+	addNotifyFlag(fs, &opts.Notify)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact refresh <notebook-id> <artifact-id> [-diff] [-replace]\n\n")
+		fmt.Fprintf(os.Stderr, "Regenerates an artifact made with 'nlm artifact create' using the same\n")
+		fmt.Fprintf(os.Stderr, "parameters, picking up any changes to its sources.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if opts.Notify != "" {
+		if err := notify.Validate(opts.Notify); err != nil {
+			fs.Usage()
+			return nil, err
+		}
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func artifactRefresh(c *api.Client, opts *artifactRefreshArgs) error {
+	store, err := provenance.OpenDefault()
+	if err != nil {
+		return err
+	}
+	rec, err := store.Get(opts.ArtifactID)
+	if errors.Is(err, provenance.ErrNotFound) {
+		return fmt.Errorf("%w; only artifacts created with 'nlm artifact create' can be refreshed", err)
+	}
+	if err != nil {
+		return err
+	}
+	if rec.NotebookID != opts.NotebookID {
+		return fmt.Errorf("artifact %s belongs to notebook %s, not %s", opts.ArtifactID, rec.NotebookID, opts.NotebookID)
+	}
+	kind, err := api.ParseArtifactKind(rec.Kind)
+	if err != nil {
+		return err
+	}
+	// Ask before generating anything; a dry run stops here.
+	if opts.Replace {
+		if ok, err := confirmAction("replace artifact %s", opts.ArtifactID); !ok {
+			return err
+		}
+	}
+
+	// Fetch the old content first; -replace deletes it.
+	var old *api.ArtifactContent
+	if opts.Diff {
+		if old, err = c.GetArtifactContent(opts.NotebookID, opts.ArtifactID); err != nil {
+			return err
+		}
+	}
+
+	create := &artifactCreateArgs{
+		NotebookID: rec.NotebookID,
+		Kind:       kind,
+		Options: api.CreateArtifactOptions{
+			SourceIDs:    rec.SourceIDs,
+			Language:     rec.Language,
+			Instructions: rec.Instructions,
+		},
+		Notify: opts.Notify,
+	}
+	fmt.Fprintf(os.Stderr, "Regenerating %s %s in notebook %s...\n", kind, opts.ArtifactID, opts.NotebookID)
+	artifact, err := c.CreateArtifact(create.NotebookID, create.Kind, &create.Options)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Created artifact: %s (refreshes %s)\n", artifact.ID, opts.ArtifactID)
+	recordProvenance(create, artifact.ID, opts.ArtifactID)
+
+	if !opts.Diff && !opts.Replace {
+		if artifact.State != pb.ArtifactState_ARTIFACT_STATE_READY {
+			return recordJob(jobs.KindArtifact, create.NotebookID, artifact.ID, create.Notify)
+		}
+		return nil
+	}
+
+	if artifact.State != pb.ArtifactState_ARTIFACT_STATE_READY {
+		sp := startSpinner("Waiting for %s", artifact.ID)
+		ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+		defer cancel()
+		_, err := c.WaitForArtifact(ctx, create.NotebookID, artifact.ID, &api.WaitOptions{OnPoll: sp.onPoll})
+		sp.Stop()
+		if err != nil {
+			return err
+		}
+	}
+	if old != nil {
+		updated, err := c.GetArtifactContent(create.NotebookID, artifact.ID)
+		if err != nil {
+			return err
+		}
+		if d := unifiedDiff(opts.ArtifactID, artifact.ID, old.Markdown, updated.Markdown); d != "" {
+			fmt.Print(d)
+		} else {
+			fmt.Println("No changes.")
+		}
+	}
+	if opts.Replace {
+		if err := c.DeleteArtifact(opts.ArtifactID); err != nil {
+			return fmt.Errorf("delete old artifact: %w", err)
+		}
+		fmt.Printf("✅ Deleted old artifact: %s\n", opts.ArtifactID)
+	}
+	return nil
+}
+
+// artifactSaveArgs contains the CLI options for `artifact save-as-note`
+type artifactSaveArgs struct {
+	NotebookID string
+	ArtifactID string
+	Title      string
+	AsSource   bool
+}
+
+func parseArtifactSaveFlags(args []string) (*artifactSaveArgs, error) {
+	opts := &artifactSaveArgs{}
+	fs := flag.NewFlagSet("artifact save-as-note", flag.ContinueOnError)
+	fs.StringVar(&opts.Title, "title", "", "title for the note or source (default: the artifact title)")
+	fs.BoolVar(&opts.AsSource, "as-source", false, "add the content as a notebook source instead of a note")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact save-as-note <notebook-id> <artifact-id> [-title t] [-as-source]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func artifactSave(c *api.Client, opts *artifactSaveArgs) error {
+	if opts.AsSource {
+		sourceID, err := c.SaveArtifactAsSource(opts.NotebookID, opts.ArtifactID, opts.Title)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Added artifact %s as source: %s\n", opts.ArtifactID, sourceID)
+		copyResult(sourceID)
+		return nil
+	}
+	note, err := c.SaveArtifactAsNote(opts.NotebookID, opts.ArtifactID, opts.Title)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Saved artifact %s as note: %s\n", opts.ArtifactID, note.GetSourceId().GetSourceId())
+	copyResult(note.GetSourceId().GetSourceId())
+	return nil
+}
+
+// artifactRmArgs contains the CLI options for `artifact rm`
+type artifactRmArgs struct {
+	NotebookID  string
+	ArtifactIDs []string
+	Type        pb.ArtifactType
+	OlderThan   time.Duration
+	Yes         bool
+}
+
+func parseArtifactRmFlags(args []string) (*artifactRmArgs, error) {
+	var typ, olderThan string
+	opts := &artifactRmArgs{}
+	fs := flag.NewFlagSet("artifact rm", flag.ContinueOnError)
+	fs.StringVar(&typ, "type", "", "only delete artifacts of this type (e.g. report, audio, mind-map)")
+	fs.StringVar(&olderThan, "older-than", "", "only delete artifacts last updated longer ago than this (e.g. 36h, 7d, 2w)")
+	fs.BoolVar(&opts.Yes, "y", false, "delete without asking for confirmation (same as -force)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm artifact rm <notebook-id> [artifact-id...] [-type type] [-older-than age] [-y]\n\n")
+		fmt.Fprintf(os.Stderr, "With no artifact IDs, every artifact in the notebook matching the filters is deleted.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	// Require IDs or a filter so a bare `rm <nb>` cannot empty a notebook.
+	if len(pos) == 0 || (len(pos) == 1 && typ == "" && olderThan == "") {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.ArtifactIDs = pos[0], pos[1:]
+	if typ != "" {
+		if opts.Type, err = api.ParseArtifactType(typ); err != nil {
+			fs.Usage()
+			return nil, err
+		}
+	}
+	if olderThan != "" {
+		if opts.OlderThan, err = parseAge(olderThan); err != nil {
+			fs.Usage()
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
+// parseAge parses a duration, additionally accepting whole days ("7d") and
+// weeks ("2w").
+func parseAge(s string) (time.Duration, error) {
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(strings.TrimSpace(s[:len(s)-1]))
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	return d, nil
+}
+
+// selectArtifacts returns the artifacts matching opts. Explicit IDs limit
+// the candidates; the type and age filters then narrow them further.
+// Artifacts without a known update time never match an age filter.
+func selectArtifacts(artifacts []*api.Artifact, opts *artifactRmArgs, now time.Time) []*api.Artifact {
+	want := make(map[string]bool, len(opts.ArtifactIDs))
+	for _, id := range opts.ArtifactIDs {
+		want[id] = true
+	}
+	var selected []*api.Artifact
+	for _, a := range artifacts {
+		if len(want) > 0 && !want[a.ID] {
+			continue
+		}
+		if opts.Type != pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED && a.Type != opts.Type {
+			continue
+		}
+		if opts.OlderThan > 0 && (a.UpdatedAt.IsZero() || now.Sub(a.UpdatedAt) < opts.OlderThan) {
+			continue
+		}
+		selected = append(selected, a)
+	}
+	return selected
+}
+
+func artifactRm(c *api.Client, opts *artifactRmArgs) error {
+	var targets []*api.Artifact
+	if opts.Type == pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED && opts.OlderThan == 0 {
+		// Plain deletion by ID needs no listing.
+		for _, id := range opts.ArtifactIDs {
+			targets = append(targets, &api.Artifact{ID: id})
+		}
+	} else {
+		artifacts, err := c.ListArtifacts(opts.NotebookID)
+		if err != nil {
+			return fmt.Errorf("list artifacts: %w", err)
+		}
+		targets = selectArtifacts(artifacts, opts, time.Now())
+	}
+	if len(targets) == 0 {
+		fmt.Println("No matching artifacts.")
+		return nil
+	}
+
+	if opts.Yes {
+		// -y predates the global -force flag.
+		force = true
+	}
+	if dryRun || !assumeYes() {
+		w := newTable(os.Stdout, 4)
+		fmt.Fprintln(w, "ID\tTYPE\tTITLE\tUPDATED")
+		for _, a := range targets {
+			updated := ""
+			if !a.UpdatedAt.IsZero() {
+				updated = formatTime(a.UpdatedAt)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.ID, a.TypeName(), a.Title, updated)
+		}
+		w.Flush()
+	}
+	if ok, err := confirmAction("delete %d artifact(s)", len(targets)); !ok {
+		return err
+	}
+
+	var failed int
+	for _, a := range targets {
+		if err := c.DeleteArtifact(a.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "nlm: %s: %v\n", a.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Deleted artifact: %s\n", a.ID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d artifacts could not be deleted", failed, len(targets))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantPos  []string
+		wantType string
+	}{
+		{name: "flags first", args: []string{"-type", "faq", "nb"}, wantPos: []string{"nb"}, wantType: "faq"},
+		{name: "flags last", args: []string{"nb", "--type", "faq"}, wantPos: []string{"nb"}, wantType: "faq"},
+		{name: "flags between", args: []string{"a", "-type=faq", "b"}, wantPos: []string{"a", "b"}, wantType: "faq"},
+		{name: "terminator", args: []string{"a", "--", "-type", "faq"}, wantPos: []string{"a", "-type", "faq"}},
+		{name: "no args", args: nil, wantPos: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var typ string
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.StringVar(&typ, "type", "", "")
+			pos, err := parseInterspersed(fs, tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantPos, pos); diff != "" {
+				t.Errorf("positional args mismatch (-want +got):\n%s", diff)
+			}
+			if typ != tt.wantType {
+				t.Errorf("type = %q, want %q", typ, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "7d", want: 7 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "90m", want: 90 * time.Minute},
+		{in: "0d", wantErr: true},
+		{in: "-1h", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "week", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseAge(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAge(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectArtifacts(t *testing.T) {
+	now := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	artifacts := []*api.Artifact{
+		{ID: "old-report", Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT, UpdatedAt: now.Add(-10 * 24 * time.Hour)},
+		{ID: "new-report", Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT, UpdatedAt: now.Add(-time.Hour)},
+		{ID: "old-audio", Type: pb.ArtifactType_ARTIFACT_TYPE_AUDIO_OVERVIEW, UpdatedAt: now.Add(-30 * 24 * time.Hour)},
+		{ID: "undated", Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT},
+	}
+	tests := []struct {
+		name string
+		opts artifactRmArgs
+		want []string
+	}{
+		{
+			name: "by type",
+			opts: artifactRmArgs{Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT},
+			want: []string{"old-report", "new-report", "undated"},
+		},
+		{
+			name: "by age",
+			opts: artifactRmArgs{OlderThan: 7 * 24 * time.Hour},
+			want: []string{"old-report", "old-audio"},
+		},
+		{
+			name: "type and age",
+			opts: artifactRmArgs{Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT, OlderThan: 7 * 24 * time.Hour},
+			want: []string{"old-report"},
+		},
+		{
+			name: "ids narrowed by type",
+			opts: artifactRmArgs{ArtifactIDs: []string{"old-audio", "new-report"}, Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT},
+			want: []string{"new-report"},
+		},
+		{
+			name: "no match",
+			opts: artifactRmArgs{Type: pb.ArtifactType_ARTIFACT_TYPE_MIND_MAP},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, a := range selectArtifacts(artifacts, &tt.opts, now) {
+				got = append(got, a.ID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("selectArtifacts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtensionForType(t *testing.T) {
+	tests := map[string]string{
+		"image/png":                ".png",
+		"image/jpeg":               ".jpg",
+		"application/pdf":          ".pdf",
+		"image/svg+xml":            ".svg",
+		"image/webp; charset=utf8": ".webp",
+		"":                         ".bin",
+	}
+	for in, want := range tests {
+		if got := extensionForType(in); got != want {
+			t.Errorf("extensionForType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGDocHTML(t *testing.T) {
+	got := gdocHTML("Q&A", "# FAQ\n\n- **One**")
+	for _, want := range []string{"<title>Q&amp;A</title>", "<h1>FAQ</h1>", "<strong>One</strong>", `<meta charset="utf-8">`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("gdocHTML() lacks %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// askArgs contains the CLI options for `nlm ask`.
+type askArgs struct {
+	NotebookID string
+	Question   string
+	SourceIDs  []string
+	Pick       bool
+}
+
+func parseAskFlags(args []string) (*askArgs, error) {
+	var sources string
+	opts := &askArgs{}
+	fs := flag.NewFlagSet("ask", flag.ContinueOnError)
+	fs.StringVar(&sources, "sources", "", "comma-separated source IDs to ask about (default: all sources)")
+	fs.BoolVar(&opts.Pick, "pick", false, "choose the sources interactively")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm ask [notebook-id] <question> [-sources ids] [-pick]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.Question = pos[0], pos[1]
+	opts.SourceIDs = splitIDs(sources)
+	return opts, nil
+}
+
+// splitIDs splits a comma-separated list of IDs, dropping blanks.
+func splitIDs(s string) []string {
+	var ids []string
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+// audioBatchStateFile is the name of the resume file kept in the output directory.
+const audioBatchStateFile = ".nlm-audio-batch.json"
+
+// Audio batch item states.
+const (
+	batchPending    = "pending"
+	batchRequested  = "requested"
+	batchDownloaded = "downloaded"
+	batchFailed     = "failed"
+)
+
+// AudioBatchOptions contains the CLI options for the audio-batch command
+type AudioBatchOptions struct {
+	NotebooksFile string
+	Concurrency   int
+	OutDir        string
+	Audio         api.AudioOverviewOptions
+	Interval      time.Duration
+	PollInterval  time.Duration
+	Timeout       time.Duration
+}
+
+func parseAudioBatchFlags(args []string) (*AudioBatchOptions, error) {
+	fs := flag.NewFlagSet("audio-batch", flag.ContinueOnError)
+	opts := &AudioBatchOptions{}
+	fs.StringVar(&opts.NotebooksFile, "notebooks", "", "file with one notebook ID per line (- for stdin)")
+	fs.IntVar(&opts.Concurrency, "concurrency", 2, "number of notebooks to process at once")
+	fs.StringVar(&opts.OutDir, "out-dir", ".", "directory to write audio files and resume state to")
+	fs.StringVar(&opts.Audio.Instructions, "instructions", "Create an engaging audio overview of the sources", "instructions for the audio overview")
+	resolve := audioPresetFlags(fs, &opts.Audio)
+	fs.DurationVar(&opts.Interval, "interval", 5*time.Second, "minimum delay between generation requests")
+	fs.DurationVar(&opts.PollInterval, "poll", 30*time.Second, "how often to check whether audio is ready")
+	fs.DurationVar(&opts.Timeout, "timeout", 30*time.Minute, "maximum time to wait for each notebook")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm audio-batch -notebooks <file> [-concurrency n] [-out-dir dir] [-instructions text]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if opts.NotebooksFile == "" || fs.NArg() != 0 || opts.Concurrency < 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if err := resolve(); err != nil {
+		fs.Usage()
+		return nil, err
+	}
+	return opts, nil
+}
+
+// readNotebookIDs reads notebook IDs, one per line, skipping blanks,
+// '#' comments and duplicates.
+func readNotebookIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for first := true; scanner.Scan(); first = false {
+		line := scanner.Text()
+		if first {
+			line = trimBOM(line)
+		}
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		id := strings.TrimSpace(line)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// audioBatchItem tracks the progress of a single notebook in a batch
+type audioBatchItem struct {
+	Status    string    `json:"status"`
+	AudioID   string    `json:"audio_id,omitempty"`
+	File      string    `json:"file,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// audioBatchState is persisted to the output directory after every
+// transition so an interrupted batch can pick up where it left off.
+type audioBatchState struct {
+	mu    sync.Mutex
+	path  string
+	Items map[string]*audioBatchItem `json:"items"`
+}
+
+func loadAudioBatchState(path string) (*audioBatchState, error) {
+	state := &audioBatchState{path: path, Items: make(map[string]*audioBatchItem)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read batch state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse batch state: %w", err)
+	}
+	if state.Items == nil {
+		state.Items = make(map[string]*audioBatchItem)
+	}
+	return state, nil
+}
+
+// get returns a copy of the item for id, creating a pending entry if needed.
+func (s *audioBatchState) get(id string) audioBatchItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.Items[id]
+	if !ok {
+		item = &audioBatchItem{Status: batchPending}
+		s.Items[id] = item
+	}
+	return *item
+}
+
+// set records the item for id and writes the state file.
+func (s *audioBatchState) set(id string, item audioBatchItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item.UpdatedAt = time.Now()
+	s.Items[id] = &item
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode batch state: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write batch state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write batch state: %w", err)
+	}
+	return nil
+}
+
+// requestLimiter spaces out generation requests so a batch does not
+// trip the server's rate limits.
+type requestLimiter struct {
+	mu       sync.Mutex
+	next     time.Time
+	interval time.Duration
+}
+
+// wait blocks until the next request slot is available or ctx is done.
+func (l *requestLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+	return sleepContext(ctx, time.Until(start))
+}
+
+// backoff pushes the next available slot out by d.
+func (l *requestLimiter) backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if next := time.Now().Add(d); next.After(l.next) {
+		l.next = next
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// isRateLimitError reports whether err indicates the server is throttling us.
+func isRateLimitError(err error) bool {
+	var apiErr *batchexecute.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.ErrorCode != nil && apiErr.ErrorCode.Type == batchexecute.ErrorTypeRateLimit {
+			return true
+		}
+		if apiErr.HTTPStatus == 429 {
+			return true
+		}
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "rate limit") || strings.Contains(msg, "too many requests")
+}
+
+func audioBatch(c *api.Client, args []string) error {
+	opts, err := parseAudioBatchFlags(args)
+	if err != nil {
+		return err
+	}
+
+	in := os.Stdin
+	if opts.NotebooksFile != "-" {
+		f, err := os.Open(opts.NotebooksFile)
+		if err != nil {
+			return fmt.Errorf("open notebooks file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	ids, err := readNotebookIDs(in)
+	if err != nil {
+		return fmt.Errorf("read notebooks file: %w", err)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no notebook IDs in %s", opts.NotebooksFile)
+	}
+
+	if err := os.MkdirAll(opts.OutDir, 0755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+	state, err := loadAudioBatchState(filepath.Join(opts.OutDir, audioBatchStateFile))
+	if err != nil {
+		return err
+	}
+
+	// Downloading audio is only supported through direct RPC.
+	c.SetUseDirectRPC(true)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	limiter := &requestLimiter{interval: opts.Interval}
+	bar := newProgressBar("Notebooks", int64(len(ids)))
+	work := make(chan string)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var done, failed int
+
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range work {
+				err := processAudioBatchItem(ctx, c, state, limiter, bar, opts, id)
+				mu.Lock()
+				if err != nil {
+					failed++
+					bar.Logf("nlm: %s: %v\n", id, err)
+				} else {
+					done++
+				}
+				mu.Unlock()
+				bar.Add(1)
+			}
+		}()
+	}
+
+	statusf("Processing %d notebooks (concurrency %d)...\n", len(ids), opts.Concurrency)
+feed:
+	for _, id := range ids {
+		select {
+		case work <- id:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	bar.Finish()
+
+	if ctx.Err() != nil {
+		fmt.Fprintf(os.Stderr, "\nInterrupted. Re-run the same command to resume.\n")
+		return fmt.Errorf("interrupted")
+	}
+	fmt.Printf("✅ %d of %d notebooks downloaded to %s\n", done, len(ids), opts.OutDir)
+	if failed > 0 {
+		return fmt.Errorf("%d notebooks failed; re-run to retry", failed)
+	}
+	return nil
+}
+
+// processAudioBatchItem drives one notebook from pending to downloaded,
+// persisting each step so the batch can be resumed.
+func processAudioBatchItem(ctx context.Context, c *api.Client, state *audioBatchState, limiter *requestLimiter, bar *progressBar, opts *AudioBatchOptions, id string) error {
+	item := state.get(id)
+	filename := filepath.Join(opts.OutDir, id+".wav")
+
+	if item.Status == batchDownloaded {
+		if _, err := os.Stat(item.File); err == nil {
+			bar.Statusf("  %s: already downloaded, skipping\n", id)
+			return nil
+		}
+	}
+
+	if item.Status != batchRequested {
+		for attempt := 0; ; attempt++ {
+			if err := limiter.wait(ctx); err != nil {
+				return err
+			}
+			result, err := c.CreateAudioOverviewWithOptions(id, opts.Audio)
+			if err == nil {
+				item = audioBatchItem{Status: batchRequested, AudioID: result.AudioID}
+				break
+			}
+			if !isRateLimitError(err) || attempt >= 5 {
+				item.Status, item.Error = batchFailed, err.Error()
+				if serr := state.set(id, item); serr != nil {
+					return serr
+				}
+				return err
+			}
+			delay := opts.Interval * time.Duration(1<<attempt)
+			bar.Statusf("  %s: rate limited, backing off %v\n", id, delay)
+			limiter.backoff(delay)
+		}
+		if err := state.set(id, item); err != nil {
+			return err
+		}
+		bar.Statusf("  %s: generation requested\n", id)
+	}
+
+	deadline := time.Now().Add(opts.Timeout)
+	for {
+		result, err := c.DownloadAudioOverview(id)
+		if err == nil {
+			if err := result.SaveAudioToFile(filename); err != nil {
+				return err
+			}
+			item.Status, item.File, item.Error = batchDownloaded, filename, ""
+			if err := state.set(id, item); err != nil {
+				return err
+			}
+			bar.Statusf("  %s: saved %s\n", id, filename)
+			return nil
+		}
+		if !api.IsTransient(err) {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("audio not ready after %v", opts.Timeout)
+		}
+		if err := sleepContext(ctx, opts.PollInterval); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadNotebookIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "one per line",
+			input: "nb1\nnb2\n",
+			want:  []string{"nb1", "nb2"},
+		},
+		{
+			name:  "blanks comments and whitespace",
+			input: "# notebooks\n\n  nb1  \nnb2 # second\n",
+			want:  []string{"nb1", "nb2"},
+		},
+		{
+			name:  "duplicates",
+			input: "nb1\nnb2\nnb1\n",
+			want:  []string{"nb1", "nb2"},
+		},
+		{
+			name:  "windows line endings and byte order mark",
+			input: "\uFEFFnb1\r\nnb2\r\n",
+			want:  []string{"nb1", "nb2"},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readNotebookIDs(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("readNotebookIDs() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("readNotebookIDs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAudioBatchStateResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), audioBatchStateFile)
+
+	state, err := loadAudioBatchState(path)
+	if err != nil {
+		t.Fatalf("loadAudioBatchState() error = %v", err)
+	}
+	if got := state.get("nb1").Status; got != batchPending {
+		t.Errorf("new item status = %q, want %q", got, batchPending)
+	}
+	if err := state.set("nb1", audioBatchItem{Status: batchRequested, AudioID: "a1"}); err != nil {
+		t.Fatalf("set() error = %v", err)
+	}
+
+	resumed, err := loadAudioBatchState(path)
+	if err != nil {
+		t.Fatalf("loadAudioBatchState() error = %v", err)
+	}
+	item := resumed.get("nb1")
+	if item.Status != batchRequested || item.AudioID != "a1" {
+		t.Errorf("resumed item = %+v, want status %q audio ID %q", item, batchRequested, "a1")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/notify"
+)
+
+var audioLengths = map[string]pb.AudioOverviewLength{
+	"":        pb.AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_UNSPECIFIED,
+	"short":   pb.AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_SHORT,
+	"default": pb.AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_DEFAULT,
+	"long":    pb.AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_LONG,
+}
+
+var audioStyles = map[string]pb.AudioOverviewStyle{
+	"":          pb.AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_UNSPECIFIED,
+	"deep-dive": pb.AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_DEEP_DIVE,
+	"brief":     pb.AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_BRIEF,
+	"critique":  pb.AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_CRITIQUE,
+	"debate":    pb.AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_DEBATE,
+}
+
+// audioPresetFlags registers -length and -style on fs and returns a
+// function that resolves them into opts once fs has been parsed.
+func audioPresetFlags(fs *flag.FlagSet, opts *api.AudioOverviewOptions) func() error {
+	var length, style string
+	fs.StringVar(&length, "length", "", "audio length: short, default, or long")
+	fs.StringVar(&style, "style", "", "host style: deep-dive, brief, critique, or debate")
+	return func() error {
+		l, ok := audioLengths[strings.ToLower(length)]
+		if !ok {
+			return fmt.Errorf("unknown audio length %q (want short, default, or long)", length)
+		}
+		s, ok := audioStyles[strings.ToLower(style)]
+		if !ok {
+			return fmt.Errorf("unknown audio style %q (want deep-dive, brief, critique, or debate)", style)
+		}
+		opts.Length, opts.Style = l, s
+		return nil
+	}
+}
+
+// audioCreateArgs holds the parsed arguments of audio-create.
+type audioCreateArgs struct {
+	NotebookID string
+	Audio      api.AudioOverviewOptions
+	Notify     string
+}
+
+// parseAudioCreateFlags parses `audio-create [flags] <notebook-id> <instructions>`.
+func parseAudioCreateFlags(args []string) (*audioCreateArgs, error) {
+	a := &audioCreateArgs{}
+	fs := flag.NewFlagSet("audio-create", flag.ContinueOnError)
+	resolve := audioPresetFlags(fs, &a.Audio)
+	addNotifyFlag(fs, &a.Notify)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm audio-create <notebook-id> <instructions>\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos := withDefaultNotebook(fs.Args(), 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if err := resolve(); err != nil {
+		fs.Usage()
+		return nil, err
+	}
+	if a.Notify != "" {
+		if err := notify.Validate(a.Notify); err != nil {
+			fs.Usage()
+			return nil, err
+		}
+	}
+	a.NotebookID, a.Audio.Instructions = pos[0], pos[1]
+	return a, nil
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+// auditArgs contains the CLI options for `nlm audit`.
+type auditArgs struct {
+	Out         string
+	Sizes       bool
+	Concurrency int
+}
+
+func parseAuditFlags(args []string) (*auditArgs, error) {
+	opts := &auditArgs{}
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	fs.StringVar(&opts.Out, "out", "", "write the report to `file` instead of stdout")
+	fs.BoolVar(&opts.Sizes, "sizes", true, "fetch the text of every source to report its size")
+	fs.IntVar(&opts.Concurrency, "concurrency", defaultConcurrency, "number of notebooks to audit at once")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm audit [-out file] [-sizes=false] [-concurrency n]\n\n")
+		fmt.Fprintf(os.Stderr, "Walks every notebook in the account and writes a JSON inventory of its\n")
+		fmt.Fprintf(os.Stderr, "sources and where they came from, notes, artifacts, sharing state, sizes\n")
+		fmt.Fprintf(os.Stderr, "and last activity, for compliance reviews and account migrations. A\n")
+		fmt.Fprintf(os.Stderr, "notebook that fails is recorded with its error and does not stop the others.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) > 0 || opts.Concurrency < 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+// auditReport is the inventory `nlm audit` writes.
+type auditReport struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Notebooks   []auditNotebook `json:"notebooks"`
+	Totals      auditTotals     `json:"totals"`
+}
+
+type auditTotals struct {
+	Notebooks  int `json:"notebooks"`
+	Sources    int `json:"sources"`
+	Notes      int `json:"notes"`
+	Artifacts  int `json:"artifacts"`
+	Shared     int `json:"shared"` // notebooks with collaborators or an open link
+	Characters int `json:"characters"`
+	Failed     int `json:"failed"`
+}
+
+type auditNotebook struct {
+	ID           string          `json:"id"`
+	Title        string          `json:"title"`
+	Emoji        string          `json:"emoji,omitempty"`
+	CreatedAt    *time.Time      `json:"created_at,omitempty"`
+	ModifiedAt   *time.Time      `json:"modified_at,omitempty"`
+	LastActivity *time.Time      `json:"last_activity,omitempty"`
+	Sources      []auditSource   `json:"sources"`
+	Notes        []auditNote     `json:"notes"`
+	Artifacts    []auditArtifact `json:"artifacts"`
+	Sharing      *auditSharing   `json:"sharing,omitempty"`
+	Characters   int             `json:"characters"` // total text of the sources
+	Warnings     []string        `json:"warnings,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+type auditSource struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Type       string     `json:"type"`
+	Status     string     `json:"status"`
+	Origin     string     `json:"origin,omitempty"` // the URL the source was added from, if known
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+	Characters *int       `json:"characters,omitempty"`
+	Words      *int       `json:"words,omitempty"`
+}
+
+type auditNote struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+}
+
+type auditArtifact struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Type      string     `json:"type"`
+	State     string     `json:"state"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	SourceIDs []string   `json:"source_ids,omitempty"`
+}
+
+type auditSharing struct {
+	Link          string              `json:"link"`
+	Domain        string              `json:"domain,omitempty"`
+	Owner         string              `json:"owner,omitempty"`
+	Collaborators []auditCollaborator `json:"collaborators"`
+}
+
+type auditCollaborator struct {
+	Email string        `json:"email"`
+	Name  string        `json:"name,omitempty"`
+	Role  api.ShareRole `json:"role"`
+}
+
+func runAudit(c *api.Client, args []string) error {
+	opts, err := parseAuditFlags(args)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	projects, err := c.ListRecentlyViewedProjects()
+	if err != nil {
+		return fmt.Errorf("list notebooks: %w", err)
+	}
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ProjectId
+	}
+	report := &auditReport{
+		GeneratedAt: time.Now().UTC(),
+		Notebooks:   make([]auditNotebook, len(projects)),
+	}
+	var mu sync.Mutex
+	started := 0
+	auditErr := fanOut(ctx, ids, opts.Concurrency, func(i int, id string) error {
+		p := projects[i]
+		mu.Lock()
+		started++
+		statusf("Auditing %s (%d/%d)...\n", strings.TrimSpace(p.Title), started, len(projects))
+		mu.Unlock()
+		nb, err := auditNotebookContents(c, p, opts.Sizes)
+		report.Notebooks[i] = nb
+		return err
+	})
+	if ctx.Err() != nil {
+		return auditErr
+	}
+	report.Totals = auditTotalsOf(report.Notebooks)
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+	out = append(out, '\n')
+	if opts.Out == "" {
+		if _, err := os.Stdout.Write(out); err != nil {
+			return err
+		}
+	} else if err := os.WriteFile(opts.Out, out, 0600); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	t := report.Totals
+	statusf("Audited %d notebooks: %d sources, %d notes, %d artifacts, %d shared\n",
+		t.Notebooks-t.Failed, t.Sources, t.Notes, t.Artifacts, t.Shared)
+	if opts.Out != "" {
+		fmt.Fprintf(os.Stderr, "✅ Saved the audit of %d notebooks to %s\n", t.Notebooks, opts.Out)
+	}
+	var fe *fanOutError
+	if errors.As(auditErr, &fe) {
+		return auditErr
+	}
+	return nil
+}
+
+// auditNotebookContents inventories one notebook. Failing to list its
+// notes or artifacts fails the notebook, which is still returned, with the
+// error recorded. Sharing and source text that cannot be fetched are only
+// warned about, as they may be withheld from notebooks the account does
+// not own.
+func auditNotebookContents(c *api.Client, p *api.Notebook, withSizes bool) (auditNotebook, error) {
+	nb := auditNotebook{
+		ID:         p.ProjectId,
+		Title:      strings.TrimSpace(p.Title),
+		Emoji:      strings.TrimSpace(p.Emoji),
+		CreatedAt:  auditTime(asTime(p.GetMetadata().GetCreateTime())),
+		ModifiedAt: auditTime(asTime(p.GetMetadata().GetModifiedTime())),
+		Sources:    []auditSource{},
+		Notes:      []auditNote{},
+		Artifacts:  []auditArtifact{},
+	}
+	last := asTime(p.GetMetadata().GetModifiedTime())
+	seen := func(t time.Time) {
+		if t.After(last) {
+			last = t
+		}
+	}
+	fail := func(err error) (auditNotebook, error) {
+		nb.Error = err.Error()
+		return nb, err
+	}
+
+	for _, src := range p.Sources {
+		s := auditSource{
+			ID:         src.GetSourceId().GetSourceId(),
+			Title:      strings.TrimSpace(src.Title),
+			Type:       enumString(src.GetMetadata().GetSourceType().String(), "SOURCE_TYPE_"),
+			Status:     enumString(src.GetMetadata().GetStatus().String(), "SOURCE_STATUS_"),
+			Origin:     sourceOrigin(src),
+			ModifiedAt: auditTime(asTime(src.GetMetadata().GetLastModifiedTime())),
+		}
+		seen(asTime(src.GetMetadata().GetLastModifiedTime()))
+		if withSizes {
+			if text, err := c.GetSourceText(s.ID); err != nil {
+				nb.Warnings = append(nb.Warnings, fmt.Sprintf("size of source %s: %v", s.ID, err))
+			} else {
+				chars, words := len([]rune(text)), len(strings.Fields(text))
+				s.Characters, s.Words = &chars, &words
+				nb.Characters += chars
+			}
+		}
+		nb.Sources = append(nb.Sources, s)
+	}
+
+	notes, err := c.GetNotes(p.ProjectId)
+	if err != nil {
+		return fail(fmt.Errorf("list notes: %w", err))
+	}
+	for _, n := range notes {
+		modified := asTime(n.GetMetadata().GetLastModifiedTime())
+		seen(modified)
+		nb.Notes = append(nb.Notes, auditNote{
+			ID:         n.GetSourceId().GetSourceId(),
+			Title:      strings.TrimSpace(n.Title),
+			ModifiedAt: auditTime(modified),
+		})
+	}
+
+	artifacts, err := c.ListArtifacts(p.ProjectId)
+	if err != nil {
+		return fail(fmt.Errorf("list artifacts: %w", err))
+	}
+	for _, a := range artifacts {
+		seen(a.UpdatedAt)
+		nb.Artifacts = append(nb.Artifacts, auditArtifact{
+			ID:        a.ID,
+			Title:     a.Title,
+			Type:      a.TypeName(),
+			State:     a.StateName(),
+			UpdatedAt: auditTime(a.UpdatedAt),
+			SourceIDs: a.SourceIDs,
+		})
+	}
+
+	if info, err := c.GetShareInfo(p.ProjectId); err != nil {
+		nb.Warnings = append(nb.Warnings, fmt.Sprintf("sharing: %v", err))
+	} else {
+		nb.Sharing = auditSharingOf(info)
+		seen(info.LastModified)
+	}
+	nb.LastActivity = auditTime(last)
+	return nb, nil
+}
+
+// sourceOrigin returns the URL a source was added from, if NotebookLM
+// reports it. Uploaded files and pasted text have none.
+func sourceOrigin(src *pb.Source) string {
+	md := src.GetMetadata()
+	if u := md.GetYoutube().GetYoutubeUrl(); u != "" {
+		return u
+	}
+	if id := md.GetYoutube().GetVideoId(); id != "" {
+		return "https://www.youtube.com/watch?v=" + id
+	}
+	if id := md.GetGoogleDocs().GetDocumentId(); id != "" {
+		return "https://docs.google.com/document/d/" + id
+	}
+	return ""
+}
+
+func auditSharingOf(info *api.ShareInfo) *auditSharing {
+	s := &auditSharing{
+		Link:          info.Access.String(),
+		Domain:        info.Domain,
+		Owner:         info.Owner.String(),
+		Collaborators: []auditCollaborator{},
+	}
+	for _, c := range info.Collaborators {
+		s.Collaborators = append(s.Collaborators, auditCollaborator{Email: c.Email, Name: c.Name, Role: c.Role})
+	}
+	return s
+}
+
+// shared reports whether anyone besides the owner can open the notebook.
+func (s *auditSharing) shared() bool {
+	if s == nil {
+		return false
+	}
+	if s.Link != api.LinkRestricted.String() {
+		return true
+	}
+	for _, c := range s.Collaborators {
+		if c.Role != api.ShareRoleOwner {
+			return true
+		}
+	}
+	return false
+}
+
+func auditTotalsOf(notebooks []auditNotebook) auditTotals {
+	t := auditTotals{Notebooks: len(notebooks)}
+	for _, nb := range notebooks {
+		t.Sources += len(nb.Sources)
+		t.Notes += len(nb.Notes)
+		t.Artifacts += len(nb.Artifacts)
+		t.Characters += nb.Characters
+		if nb.Sharing.shared() {
+			t.Shared++
+		}
+		if nb.Error != "" {
+			t.Failed++
+		}
+	}
+	return t
+}
+
+// auditTime returns t for a JSON field that is left out when unknown.
+func auditTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// enumString returns the short lowercase name of a protobuf enum value.
+func enumString(s, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(s, prefix))
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestSourceOrigin(t *testing.T) {
+	tests := []struct {
+		name string
+		md   *pb.SourceMetadata
+		want string
+	}{
+		{"youtube url", &pb.SourceMetadata{MetadataType: &pb.SourceMetadata_Youtube{Youtube: &pb.YoutubeSourceMetadata{YoutubeUrl: "https://youtu.be/abc"}}}, "https://youtu.be/abc"},
+		{"youtube id", &pb.SourceMetadata{MetadataType: &pb.SourceMetadata_Youtube{Youtube: &pb.YoutubeSourceMetadata{VideoId: "abc"}}}, "https://www.youtube.com/watch?v=abc"},
+		{"google doc", &pb.SourceMetadata{MetadataType: &pb.SourceMetadata_GoogleDocs{GoogleDocs: &pb.GoogleDocsSourceMetadata{DocumentId: "doc1"}}}, "https://docs.google.com/document/d/doc1"},
+		{"upload", &pb.SourceMetadata{SourceType: pb.SourceType_SOURCE_TYPE_LOCAL_FILE}, ""},
+		{"no metadata", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := sourceOrigin(&pb.Source{Metadata: tt.md}); got != tt.want {
+			t.Errorf("%s: sourceOrigin() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAuditTotals(t *testing.T) {
+	owner := auditCollaborator{Email: "me@example.com", Role: api.ShareRoleOwner}
+	notebooks := []auditNotebook{
+		{
+			Sources:    []auditSource{{ID: "s1"}, {ID: "s2"}},
+			Notes:      []auditNote{{ID: "n1"}},
+			Characters: 120,
+			Sharing:    &auditSharing{Link: "restricted", Collaborators: []auditCollaborator{owner}},
+		},
+		{
+			Sources:   []auditSource{{ID: "s3"}},
+			Artifacts: []auditArtifact{{ID: "a1"}, {ID: "a2"}},
+			Sharing:   &auditSharing{Link: "restricted", Collaborators: []auditCollaborator{owner, {Email: "you@example.com", Role: api.ShareRoleViewer}}},
+		},
+		{Sharing: &auditSharing{Link: "anyone"}},
+		{Error: "list notes: not found"},
+	}
+	want := auditTotals{Notebooks: 4, Sources: 3, Notes: 1, Artifacts: 2, Shared: 2, Characters: 120, Failed: 1}
+	if got := auditTotalsOf(notebooks); got != want {
+		t.Errorf("auditTotalsOf() = %+v, want %+v", got, want)
+	}
+}
@@ -2,16 +2,20 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tmc/nlm/internal/auth"
+	"github.com/tmc/nlm/internal/filelock"
 	"golang.org/x/term"
 )
 
@@ -51,8 +55,8 @@ func parseAuthFlags(args []string) (*AuthOptions, []string, error) {
 
 	authFlags.BoolVar(&opts.TryAllProfiles, "all", false, "Try all available browser profiles")
 	authFlags.BoolVar(&opts.TryAllProfiles, "a", false, "Try all available browser profiles (shorthand)")
-	authFlags.StringVar(&opts.ProfileName, "profile", opts.ProfileName, "Specific Chrome profile to use")
-	authFlags.StringVar(&opts.ProfileName, "p", opts.ProfileName, "Specific Chrome profile to use (shorthand)")
+	authFlags.StringVar(&opts.ProfileName, "profile", opts.ProfileName, "Specific browser profile to use (Chrome, Brave, Edge or Firefox)")
+	authFlags.StringVar(&opts.ProfileName, "p", opts.ProfileName, "Specific browser profile to use (shorthand)")
 	authFlags.StringVar(&opts.TargetURL, "url", opts.TargetURL, "Target URL to authenticate against")
 	authFlags.StringVar(&opts.TargetURL, "u", opts.TargetURL, "Target URL to authenticate against (shorthand)")
 	authFlags.BoolVar(&opts.CheckNotebooks, "notebooks", false, "Check notebook count for profiles")
@@ -66,9 +70,11 @@ func parseAuthFlags(args []string) (*AuthOptions, []string, error) {
 
 	// Set custom usage
 	authFlags.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: nlm auth [login] [options] [profile-name]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: nlm auth [login] [options] [profile-name]\n")
+		fmt.Fprintf(os.Stderr, "       nlm auth refresh [-daemon] [-interval d] [-profile name]\n\n")
 		fmt.Fprintf(os.Stderr, "Commands:\n")
-		fmt.Fprintf(os.Stderr, "  login            Explicitly use browser authentication (recommended)\n\n")
+		fmt.Fprintf(os.Stderr, "  login            Explicitly use browser authentication (recommended)\n")
+		fmt.Fprintf(os.Stderr, "  refresh          Re-read credentials from the browser profile\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		authFlags.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExample: nlm auth login -all -notebooks\n")
@@ -233,6 +239,74 @@ func handleAuth(args []string, debug bool) (string, string, error) {
 	return persistAuthToDisk(cookies, token, opts.ProfileName)
 }
 
+// authRefreshOptions contains the CLI options for `nlm auth refresh`.
+type authRefreshOptions struct {
+	Profile  string
+	Daemon   bool
+	Interval time.Duration
+}
+
+func parseAuthRefreshFlags(args []string) (*authRefreshOptions, error) {
+	opts := &authRefreshOptions{}
+	fs := flag.NewFlagSet("auth refresh", flag.ContinueOnError)
+	fs.StringVar(&opts.Profile, "profile", chromeProfile, "`name` of the browser profile to read (default: the one nlm auth used)")
+	fs.BoolVar(&opts.Daemon, "daemon", false, "keep running and re-read the credentials every interval")
+	fs.DurationVar(&opts.Interval, "interval", 20*time.Minute, "how often to re-read the credentials with -daemon")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm auth refresh [-daemon] [-interval d] [-profile name]\n\n")
+		fmt.Fprintf(os.Stderr, "Reads fresh credentials from the browser profile, in a headless browser,\n")
+		fmt.Fprintf(os.Stderr, "and stores them as `nlm auth` does. With -daemon it keeps doing so while\n")
+		fmt.Fprintf(os.Stderr, "the browser stays signed in, so long-running servers never see the session\n")
+		fmt.Fprintf(os.Stderr, "expire.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) > 0 || opts.Interval < time.Minute {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if opts.Profile == "" {
+		loadStoredEnv()
+		opts.Profile = os.Getenv("NLM_BROWSER_PROFILE")
+	}
+	if opts.Profile == "" {
+		opts.Profile = "Default"
+	}
+	return opts, nil
+}
+
+// runAuthRefresh re-reads the credentials from the browser profile once,
+// or with -daemon until interrupted.
+func runAuthRefresh(args []string) error {
+	opts, err := parseAuthRefreshFlags(args)
+	if err != nil {
+		return err
+	}
+	src := auth.ProfileSource(opts.Profile, debug)
+	save := func(token, cookies string) error {
+		_, _, err := persistAuthToDisk(cookies, token, opts.Profile)
+		return err
+	}
+	if !opts.Daemon {
+		token, cookies, err := src()
+		if err != nil {
+			return fmt.Errorf("refresh from browser profile: %w", err)
+		}
+		return save(token, cookies)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	statusf("nlm: refreshing credentials from profile %s every %v\n", maskProfileName(opts.Profile), opts.Interval)
+	logf := func(format string, args ...any) {
+		fmt.Fprintf(os.Stderr, "nlm: "+format+"\n", args...)
+	}
+	return auth.KeepFresh(ctx, src, opts.Interval, save, logf)
+}
+
 func detectAuthInfo(cmd string) (string, string, error) {
 	// Extract cookies
 	cookieRe := regexp.MustCompile(`-H ['"]cookie: ([^'"]+)['"]`)
@@ -254,6 +328,12 @@ func detectAuthInfo(cmd string) (string, string, error) {
 }
 
 func persistAuthToDisk(cookies, authToken, profileName string) (string, string, error) {
+	if saved, err := saveAuthToProfile(authToken, cookies, profileName); err != nil {
+		return "", "", err
+	} else if saved {
+		return authToken, cookies, nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", "", fmt.Errorf("get home dir: %w", err)
@@ -273,7 +353,7 @@ func persistAuthToDisk(cookies, authToken, profileName string) (string, string,
 		profileName,
 	)
 
-	if err := os.WriteFile(envFile, []byte(content), 0600); err != nil {
+	if err := writeEnvFile(envFile, content); err != nil {
 		return "", "", fmt.Errorf("write env file: %w", err)
 	}
 
@@ -281,6 +361,19 @@ func persistAuthToDisk(cookies, authToken, profileName string) (string, string,
 	return authToken, cookies, nil
 }
 
+// writeEnvFile replaces the stored credentials file. The new file is
+// renamed into place while holding the file's lock, so concurrent nlm
+// processes never read a partly written file or lose an update.
+func writeEnvFile(path, content string) error {
+	return filelock.With(path, func() error {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(content), 0600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, path)
+	})
+}
+
 func loadStoredEnv() {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -292,7 +385,7 @@ func loadStoredEnv() {
 		return
 	}
 
-	s := bufio.NewScanner(strings.NewReader(string(data)))
+	s := bufio.NewScanner(strings.NewReader(trimBOM(string(data))))
 	for s.Scan() {
 		line := strings.TrimSpace(s.Text())
 		if line == "" || strings.HasPrefix(line, "#") {
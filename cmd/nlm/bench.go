@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+// benchOptions contains the CLI options for `nlm bench`, a hidden
+// command for checking throughput on the user's network.
+type benchOptions struct {
+	Requests    int
+	Concurrency int
+	Compress    bool
+}
+
+func parseBenchFlags(args []string) (*benchOptions, error) {
+	opts := &benchOptions{}
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.IntVar(&opts.Requests, "n", 20, "number of requests")
+	fs.IntVar(&opts.Concurrency, "c", defaultConcurrency, "number of requests in flight at once")
+	fs.BoolVar(&opts.Compress, "compress", compressEnabled(), "gzip request bodies (default from NLM_COMPRESS)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm bench [-n requests] [-c concurrency] [-compress]\n\n")
+		fmt.Fprintf(os.Stderr, "Lists notebooks n times, c at a time, and reports throughput, latency,\n")
+		fmt.Fprintf(os.Stderr, "the HTTP version used and how many connections were reused.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) > 0 || opts.Requests < 1 || opts.Concurrency < 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+// benchTransport records the protocol and connection of each request.
+type benchTransport struct {
+	next http.RoundTripper
+
+	mu        sync.Mutex
+	protocols map[string]int
+	newConns  int
+	reused    int
+}
+
+func (t *benchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			if info.Reused {
+				t.reused++
+			} else {
+				t.newConns++
+			}
+			t.mu.Unlock()
+		},
+	}
+	resp, err := t.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err == nil {
+		t.mu.Lock()
+		t.protocols[resp.Proto]++
+		t.mu.Unlock()
+	}
+	return resp, err
+}
+
+// compressEnabled reports whether NLM_COMPRESS=1 asks for gzipped
+// request bodies.
+func compressEnabled() bool {
+	return os.Getenv("NLM_COMPRESS") == "1"
+}
+
+// runBench measures requests made directly, not through the daemon, with
+// the command's other client options.
+func runBench(args []string) error {
+	opts, err := parseBenchFlags(args)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	tr := &benchTransport{next: batchexecute.DefaultTransport, protocols: map[string]int{}}
+	stats := new(batchexecute.Stats)
+	bopts := append(slices.Clip(clientOpts),
+		batchexecute.WithHTTPClient(&http.Client{Transport: tr}),
+		batchexecute.WithCompression(opts.Compress),
+		batchexecute.WithStats(stats))
+	c, err := api.New(ctx, api.WithAuth(authToken, cookies), api.WithBatchExecuteOptions(bopts...))
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, opts.Requests)
+	latencies := make([]time.Duration, opts.Requests)
+	start := time.Now()
+	benchErr := fanOut(ctx, ids, opts.Concurrency, func(i int, _ string) error {
+		t := time.Now()
+		_, err := c.ListRecentlyViewedProjects()
+		latencies[i] = time.Since(t)
+		return err
+	})
+	elapsed := time.Since(start)
+
+	failed := 0
+	if fe, ok := benchErr.(*fanOutError); ok {
+		failed = len(fe.errs)
+		fmt.Fprintf(os.Stderr, "first failure: %v\n", fe.errs[0])
+	} else if benchErr != nil {
+		return benchErr
+	}
+	slices.Sort(latencies)
+	percentile := func(p int) time.Duration {
+		return latencies[(len(latencies)-1)*p/100].Round(time.Millisecond)
+	}
+	snap := stats.Snapshot()
+	fmt.Printf("requests:    %d (%d failed) in %v, %.1f req/s\n", opts.Requests, failed, elapsed.Round(time.Millisecond), float64(opts.Requests)/elapsed.Seconds())
+	fmt.Printf("latency:     p50 %v, p95 %v, max %v\n", percentile(50), percentile(95), percentile(100))
+	var protocols []string
+	for p, n := range tr.protocols {
+		protocols = append(protocols, fmt.Sprintf("%s %d", p, n))
+	}
+	slices.Sort(protocols)
+	fmt.Printf("protocol:    %s\n", strings.Join(protocols, ", "))
+	fmt.Printf("connections: %d new, %d reused\n", tr.newConns, tr.reused)
+	fmt.Printf("transferred: %s sent, %s received\n", formatSize(snap.BytesSent), formatSize(snap.BytesReceived))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d requests failed", failed, opts.Requests)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/cache"
+)
+
+// capabilitiesKey is the cache key probed capabilities are kept under.
+const capabilitiesKey = "capabilities"
+
+// capabilitiesTTL is how long probed capabilities are trusted. Features
+// roll out over days, so a day old answer is fresh enough.
+const capabilitiesTTL = 24 * time.Hour
+
+// featureNames describe features in messages.
+var featureNames = map[api.Feature]string{
+	api.FeatureVideoOverviews:   "Video overviews",
+	api.FeatureInteractiveAudio: "Interactive audio",
+	api.FeatureSlideDecks:       "Slide decks",
+	api.FeatureInfographics:     "Infographics",
+}
+
+// artifactFeatures are the features that artifact kinds need.
+var artifactFeatures = map[api.ArtifactKind]api.Feature{
+	api.ArtifactSlideDeck:   api.FeatureSlideDecks,
+	api.ArtifactInfographic: api.FeatureInfographics,
+}
+
+func parseCapabilitiesFlags(args []string) (refresh bool, err error) {
+	fs := flag.NewFlagSet("capabilities", flag.ContinueOnError)
+	fs.BoolVar(&refresh, "refresh", false, "probe again instead of using the cached result")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm capabilities [-refresh]\n\n")
+		fmt.Fprintf(os.Stderr, "Shows the account's plan, its limits and which optional features it has.\n")
+		fmt.Fprintf(os.Stderr, "Results are cached for a day.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return false, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) > 0 {
+		fs.Usage()
+		return false, fmt.Errorf("invalid arguments")
+	}
+	return refresh, nil
+}
+
+func showCapabilities(c *api.Client, args []string) error {
+	refresh, err := parseCapabilitiesFlags(args)
+	if err != nil {
+		return err
+	}
+	var caps *api.Capabilities
+	if !refresh {
+		caps = cachedCapabilities()
+	}
+	if caps == nil {
+		if caps, err = probeCapabilities(c); err != nil {
+			return err
+		}
+	}
+	return render(caps, func(w io.Writer) error {
+		return writeCapabilities(w, caps)
+	})
+}
+
+// writeCapabilities prints caps as a table.
+func writeCapabilities(w io.Writer, caps *api.Capabilities) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	plan := "Free"
+	if caps.Plus {
+		plan = "Plus"
+	}
+	fmt.Fprintf(tw, "Plan\t%s\n", plan)
+	fmt.Fprintf(tw, "Notebooks\t%d\n", caps.Limits.Notebooks)
+	fmt.Fprintf(tw, "Sources per notebook\t%d\n", caps.Limits.SourcesPerNotebook)
+	fmt.Fprintf(tw, "Chats per day\t%d\n", caps.Limits.DailyChats)
+	fmt.Fprintf(tw, "Audio overviews per day\t%d\n", caps.Limits.DailyAudioOverviews)
+	for _, f := range api.Features() {
+		status := "unknown"
+		if has, ok := caps.Features[f]; ok {
+			status = "no"
+			if has {
+				status = "yes"
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", featureNames[f], status)
+	}
+	return tw.Flush()
+}
+
+// probeCapabilities probes the account and caches the result.
+func probeCapabilities(c *api.Client) (*api.Capabilities, error) {
+	statusf("Probing account capabilities...\n")
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(caps); err == nil {
+		cachePut(capabilitiesKey, data)
+	}
+	return caps, nil
+}
+
+// cachedCapabilities returns the capabilities probed within the last
+// capabilitiesTTL, or nil.
+func cachedCapabilities() *api.Capabilities {
+	c, err := cache.OpenDefault()
+	if err != nil {
+		return nil
+	}
+	data, stored, err := c.Get(capabilitiesKey)
+	if err != nil || time.Since(stored) > capabilitiesTTL {
+		return nil
+	}
+	var caps api.Capabilities
+	if err := json.Unmarshal(data, &caps); err != nil {
+		return nil
+	}
+	return &caps
+}
+
+// requireFeature returns an error if the account is known not to have f,
+// so that commands fail with a plain message rather than the server's
+// error. When the capabilities cannot be probed the command goes ahead.
+func requireFeature(c *api.Client, f api.Feature) error {
+	caps := cachedCapabilities()
+	if caps == nil {
+		var err error
+		if caps, err = probeCapabilities(c); err != nil {
+			verbosef(1, "nlm: warning: failed to probe capabilities: %v\n", err)
+			return nil
+		}
+	}
+	if caps.Has(f) {
+		return nil
+	}
+	return fmt.Errorf("%s are %w (see 'nlm capabilities')", featureNames[f], api.ErrFeatureUnavailable)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// copyOutput is the -copy flag: also put results on the clipboard.
+var copyOutput bool
+
+// copyResult puts s on the system clipboard when -copy is set. Failing to
+// copy is reported but does not fail the command, whose result has already
+// been printed.
+func copyResult(s string) {
+	if !copyOutput {
+		return
+	}
+	if err := copyToClipboard(s); err != nil {
+		fmt.Fprintf(os.Stderr, "nlm: could not copy to clipboard: %v\n", err)
+		return
+	}
+	statusf("Copied to clipboard\n")
+}
+
+// copyToClipboard writes s to the clipboard with the command in
+// NLM_CLIPBOARD, run by the shell, or else the platform's clipboard tool:
+// pbcopy, clip, wl-copy, xclip or xsel. Without a tool, a terminal is asked
+// to set the clipboard with an OSC 52 escape, which also works over SSH.
+func copyToClipboard(s string) error {
+	var cmd *exec.Cmd
+	if c := os.Getenv("NLM_CLIPBOARD"); c != "" {
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", c)
+		} else {
+			cmd = exec.Command("sh", "-c", c)
+		}
+	} else if name, args := clipboardTool(); name != "" {
+		cmd = exec.Command(name, args...)
+	}
+	if cmd == nil {
+		if !term.IsTerminal(int(os.Stderr.Fd())) {
+			return fmt.Errorf("no clipboard tool found (install xclip or wl-clipboard, or set NLM_CLIPBOARD)")
+		}
+		_, err := fmt.Fprintf(os.Stderr, "\033]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(s)))
+		return err
+	}
+	cmd.Stdin = strings.NewReader(s)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", cmd.Args[0], err, msg)
+		}
+		return fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	return nil
+}
+
+// clipboardTool returns the first available clipboard command for this
+// platform, or "" if there is none.
+func clipboardTool() (string, []string) {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard"},
+			[]string{"xsel", "--clipboard", "--input"},
+		)
+	}
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err == nil {
+			return c[0], c[1:]
+		}
+	}
+	return "", nil
+}
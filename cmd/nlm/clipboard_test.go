@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	out := filepath.Join(t.TempDir(), "clip")
+	t.Setenv("NLM_CLIPBOARD", "cat > "+out)
+	if err := copyToClipboard("# Answer\n\nsome *text*\n"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "# Answer\n\nsome *text*\n" {
+		t.Errorf("clipboard = %q", got)
+	}
+
+	t.Setenv("NLM_CLIPBOARD", "echo no display >&2; exit 1")
+	err = copyToClipboard("x")
+	if err == nil || !strings.Contains(err.Error(), "no display") {
+		t.Errorf("copyToClipboard error = %v, want the command's output", err)
+	}
+}
+
+func TestCopyResultNeedsFlag(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	out := filepath.Join(t.TempDir(), "clip")
+	t.Setenv("NLM_CLIPBOARD", "cat > "+out)
+	defer func(v bool) { copyOutput = v }(copyOutput)
+
+	copyOutput = false
+	copyResult("id")
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Fatalf("copied without -copy: %v", err)
+	}
+	copyOutput = true
+	copyResult("id")
+	if got, _ := os.ReadFile(out); string(got) != "id" {
+		t.Errorf("clipboard = %q, want %q", got, "id")
+	}
+}
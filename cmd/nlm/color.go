@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/term"
+)
+
+// verbosity is the level set with -v (1) and -vv (2); -quiet sets -1.
+var verbosity verbosityFlag
+
+// verbosityFlag is a flag.Value that counts how often it is given.
+type verbosityFlag int
+
+func (v *verbosityFlag) String() string   { return strconv.Itoa(int(*v)) }
+func (v *verbosityFlag) IsBoolFlag() bool { return true }
+
+func (v *verbosityFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if on {
+		*v++
+	}
+	return nil
+}
+
+// applyVerbosity reconciles -v, -vv, -quiet and -debug: -vv implies
+// -debug, and -quiet wins over -v.
+func applyVerbosity() {
+	if quiet {
+		verbosity = -1
+		return
+	}
+	if verbosity >= 2 {
+		debug = true
+	}
+}
+
+// verbosef prints a message to stderr when the verbosity is at least level.
+func verbosef(level int, format string, args ...interface{}) {
+	if int(verbosity) >= level {
+		fmt.Fprintf(os.Stderr, format, args...)
+	}
+}
+
+// ANSI styles.
+const (
+	styleBold   = "1"
+	styleDim    = "2"
+	styleItalic = "3"
+	styleRed    = "31"
+	styleGreen  = "32"
+	styleYellow = "33"
+)
+
+// useColor reports whether output written to w should be colored.
+// NLM_COLOR=always or never overrides detection; otherwise color is used
+// on terminals unless NO_COLOR is set (https://no-color.org) or TERM is
+// "dumb".
+func useColor(w io.Writer) bool {
+	switch strings.ToLower(os.Getenv("NLM_COLOR")) {
+	case "always", "1", "true", "yes":
+		return true
+	case "never", "0", "false", "no":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && ansiTerminal(f)
+}
+
+// ansiTerminal reports whether f is a terminal that understands ANSI
+// escapes, enabling them first on Windows consoles.
+func ansiTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd())) && enableVirtualTerminal(f)
+}
+
+// paint wraps s in an ANSI style if output to w is colored.
+func paint(w io.Writer, style, s string) string {
+	if s == "" || !useColor(w) {
+		return s
+	}
+	return "\033[" + style + "m" + s + "\033[0m"
+}
+
+// table aligns tab-separated columns like tabwriter and, on a color
+// terminal, shows the first line as a bold header. Styling is applied
+// after alignment so escape codes do not skew the columns.
+type table struct {
+	*tabwriter.Writer
+	out io.Writer
+	buf bytes.Buffer
+}
+
+// newTable returns a table writing to out with padding spaces between
+// columns. Call Flush when done.
+func newTable(out io.Writer, padding int) *table {
+	t := &table{out: out}
+	t.Writer = tabwriter.NewWriter(&t.buf, 0, 4, padding, ' ', 0)
+	return t
+}
+
+// Flush aligns the buffered rows and writes them out.
+func (t *table) Flush() error {
+	if err := t.Writer.Flush(); err != nil {
+		return err
+	}
+	defer t.buf.Reset()
+	if !useColor(t.out) {
+		_, err := t.buf.WriteTo(t.out)
+		return err
+	}
+	header, rest, _ := bytes.Cut(t.buf.Bytes(), []byte("\n"))
+	if _, err := fmt.Fprintf(t.out, "%s\n", paint(t.out, styleBold, string(header))); err != nil {
+		return err
+	}
+	_, err := t.out.Write(rest)
+	return err
+}
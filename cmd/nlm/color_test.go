@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestUseColor(t *testing.T) {
+	tests := []struct {
+		nlmColor, noColor, term string
+		want                    bool
+	}{
+		{want: false}, // a buffer is not a terminal
+		{nlmColor: "always", want: true},
+		{nlmColor: "always", noColor: "1", want: true},
+		{nlmColor: "never", want: false},
+		{nlmColor: "auto", noColor: "1", want: false},
+		{term: "dumb", want: false},
+	}
+	for _, tt := range tests {
+		t.Setenv("NLM_COLOR", tt.nlmColor)
+		t.Setenv("NO_COLOR", tt.noColor)
+		t.Setenv("TERM", tt.term)
+		if got := useColor(&bytes.Buffer{}); got != tt.want {
+			t.Errorf("useColor() with NLM_COLOR=%q NO_COLOR=%q TERM=%q = %v, want %v", tt.nlmColor, tt.noColor, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	write := func() string {
+		var out bytes.Buffer
+		w := newTable(&out, 1)
+		fmt.Fprintln(w, "ID\tTITLE")
+		fmt.Fprintln(w, "abc\tFirst")
+		fmt.Fprintln(w, "d\tSecond")
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	t.Setenv("NLM_COLOR", "never")
+	if got, want := write(), "ID  TITLE\nabc First\nd   Second\n"; got != want {
+		t.Errorf("plain table = %q, want %q", got, want)
+	}
+	t.Setenv("NLM_COLOR", "always")
+	if got, want := write(), "\033[1mID  TITLE\033[0m\nabc First\nd   Second\n"; got != want {
+		t.Errorf("colored table = %q, want %q", got, want)
+	}
+}
+
+func TestVerbosity(t *testing.T) {
+	defer func(v verbosityFlag, q, d bool) { verbosity, quiet, debug = v, q, d }(verbosity, quiet, debug)
+
+	verbosity, quiet, debug = 0, false, false
+	verbosity.Set("true")
+	verbosity.Set("true")
+	applyVerbosity()
+	if verbosity != 2 || !debug {
+		t.Errorf("-v -v: verbosity = %d, debug = %v; want 2, true", verbosity, debug)
+	}
+
+	verbosity, quiet, debug = 1, true, false
+	applyVerbosity()
+	if verbosity != -1 || debug {
+		t.Errorf("-v -quiet: verbosity = %d, debug = %v; want -1, false", verbosity, debug)
+	}
+}
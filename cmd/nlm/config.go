@@ -0,0 +1,315 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/config"
+)
+
+// configProfile selects a profile from the configuration file.
+var configProfile string
+
+// configUsage lists the `nlm config` subcommands.
+const configUsage = "usage: nlm config <get|set|list> ...\n"
+
+// applyConfig exports the active profile's settings as the environment
+// variables they correspond to. Variables already set in the environment
+// win, and the profile wins over ~/.nlm/env, which is loaded afterwards.
+func applyConfig() {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nlm: %v\n", err)
+		return
+	}
+	name := cfg.ActiveName(configProfile)
+	profile := cfg.Lookup(name)
+	if profile != nil {
+		verbosef(1, "nlm: using config profile %q from %s\n", name, path)
+	}
+	if profile == nil {
+		if configProfile != "" && flag.Arg(0) != "config" && flag.Arg(0) != "auth" {
+			fmt.Fprintf(os.Stderr, "nlm: config profile %q not found in %s\n", name, path)
+		}
+		return
+	}
+	for _, k := range config.Keys {
+		v, _ := profile.Get(k.Name)
+		if v == "" {
+			continue
+		}
+		if _, isSet := os.LookupEnv(k.Env); !isSet {
+			os.Setenv(k.Env, v)
+		}
+	}
+	// The -profile flag took its default before the profile was loaded.
+	if chromeProfile == "" {
+		chromeProfile = os.Getenv("NLM_BROWSER_PROFILE")
+	}
+}
+
+// retryOptions returns the retry policy set by NLM_MAX_RETRIES and
+// NLM_RETRY_DELAY, which a config profile may provide.
+func retryOptions() []batchexecute.Option {
+	var maxRetries int
+	var delay time.Duration
+	if s := os.Getenv("NLM_MAX_RETRIES"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			fmt.Fprintf(os.Stderr, "nlm: ignoring invalid NLM_MAX_RETRIES %q\n", s)
+		} else {
+			maxRetries = n
+		}
+	}
+	if s := os.Getenv("NLM_RETRY_DELAY"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "nlm: ignoring invalid NLM_RETRY_DELAY %q\n", s)
+		} else {
+			delay = d
+		}
+	}
+	if maxRetries == 0 && delay == 0 {
+		return nil
+	}
+	return []batchexecute.Option{batchexecute.WithRetry(maxRetries, delay, 0)}
+}
+
+// saveAuthToProfile stores credentials in the profile selected with
+// -config-profile or NLM_CONFIG_PROFILE. It reports false when no profile
+// was selected, in which case credentials go to ~/.nlm/env as before.
+func saveAuthToProfile(authToken, cookies, browserProfile string) (bool, error) {
+	if configProfile == "" {
+		return false, nil
+	}
+	path, err := config.DefaultPath()
+	if err != nil {
+		return false, err
+	}
+	err = config.Update(path, func(cfg *config.Config) error {
+		p := cfg.Ensure(configProfile)
+		p.AuthToken = authToken
+		p.Cookies = cookies
+		if browserProfile != "" {
+			p.BrowserProfile = browserProfile
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintf(os.Stderr, "nlm: auth info written to profile %q in %s\n", configProfile, path)
+	return true, nil
+}
+
+// configArgs contains the CLI options for `config get/set/list`
+type configArgs struct {
+	Profile string
+	Args    []string
+}
+
+func parseConfigFlags(sub, usage string, nargs int, args []string) (*configArgs, error) {
+	opts := &configArgs{}
+	fs := flag.NewFlagSet("config "+sub, flag.ContinueOnError)
+	fs.StringVar(&opts.Profile, "profile", "", "profile to use (default: the active profile)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm config %s [-profile name] %s\n\n", sub, usage)
+		fmt.Fprintf(os.Stderr, "Keys:\n")
+		for _, k := range config.Keys {
+			fmt.Fprintf(os.Stderr, "  %-16s %s (%s)\n", k.Name, k.Help, k.Env)
+		}
+		fmt.Fprintf(os.Stderr, "  %-16s the active profile (set only)\n\n", "profile")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != nargs {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.Args = pos
+	return opts, nil
+}
+
+func validateConfigArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, configUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	var err error
+	switch args[0] {
+	case "get":
+		_, err = parseConfigFlags("get", "<key>", 1, args[1:])
+	case "set":
+		_, err = parseConfigFlags("set", "<key> <value>", 2, args[1:])
+	case "list":
+		_, err = parseConfigFlags("list", "", 0, args[1:])
+	default:
+		fmt.Fprint(os.Stderr, configUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	return err
+}
+
+func runConfig(args []string) error {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "get":
+		opts, err := parseConfigFlags("get", "<key>", 1, args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.Args[0] == "profile" {
+			fmt.Println(cfg.ActiveName(configProfile))
+			return nil
+		}
+		v, err := cfg.Lookup(cfg.ActiveName(profileOrSelected(opts.Profile))).Get(opts.Args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(v)
+		return nil
+	case "set":
+		opts, err := parseConfigFlags("set", "<key> <value>", 2, args[1:])
+		if err != nil {
+			return err
+		}
+		key, value := opts.Args[0], opts.Args[1]
+		err = config.Update(path, func(cfg *config.Config) error {
+			if key == "profile" {
+				cfg.Profile = value
+				cfg.Ensure(cfg.ActiveName(""))
+				return nil
+			}
+			return cfg.Ensure(cfg.ActiveName(profileOrSelected(opts.Profile))).Set(key, value)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "nlm: updated %s\n", path)
+		return nil
+	case "list":
+		opts, err := parseConfigFlags("list", "", 0, args[1:])
+		if err != nil {
+			return err
+		}
+		name := cfg.ActiveName(profileOrSelected(opts.Profile))
+		return render(newConfigListing(cfg, name), func(out io.Writer) error {
+			return writeConfig(out, cfg, name)
+		})
+	default:
+		fmt.Fprint(os.Stderr, configUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+// profileOrSelected returns name, or the profile selected with
+// -config-profile when name is empty.
+func profileOrSelected(name string) string {
+	if name != "" {
+		return name
+	}
+	return configProfile
+}
+
+// configListing is the machine-readable form of `config list`.
+type configListing struct {
+	Profile  string          `json:"profile"`
+	Active   bool            `json:"active"`
+	Settings []configSetting `json:"settings"`
+	Profiles []string        `json:"profiles"`
+}
+
+type configSetting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Env   string `json:"env"`
+}
+
+func newConfigListing(cfg *config.Config, name string) configListing {
+	l := configListing{
+		Profile:  name,
+		Active:   name == cfg.ActiveName(""),
+		Settings: []configSetting{},
+		Profiles: cfg.Names(),
+	}
+	if l.Profiles == nil {
+		l.Profiles = []string{}
+	}
+	profile := cfg.Lookup(name)
+	for _, k := range config.Keys {
+		v, _ := profile.Get(k.Name)
+		if k.Secret && v != "" {
+			v = maskSecret(v)
+		}
+		l.Settings = append(l.Settings, configSetting{Key: k.Name, Value: v, Env: k.Env})
+	}
+	return l
+}
+
+// writeConfig prints a profile's settings, masking credentials, followed
+// by the other profiles in the file.
+func writeConfig(out io.Writer, cfg *config.Config, name string) error {
+	active := ""
+	if name == cfg.ActiveName("") {
+		active = " (active)"
+	}
+	fmt.Fprintf(out, "Profile: %s%s\n\n", name, active)
+
+	profile := cfg.Lookup(name)
+	w := newTable(out, 4)
+	fmt.Fprintln(w, "KEY\tVALUE\tENV")
+	for _, k := range config.Keys {
+		v, _ := profile.Get(k.Name)
+		switch {
+		case v == "":
+			v = "-"
+		case k.Secret:
+			v = maskSecret(v)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", k.Name, v, k.Env)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var others []string
+	for _, n := range cfg.Names() {
+		if n != name {
+			others = append(others, n)
+		}
+	}
+	if len(others) > 0 {
+		fmt.Fprintf(out, "\nOther profiles: %s\n", strings.Join(others, ", "))
+	}
+	return nil
+}
+
+// maskSecret shows only the ends of a credential.
+func maskSecret(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:2] + strings.Repeat("*", 6) + s[len(s)-2:]
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/nlm/internal/i18n"
+)
+
+// Flags shared by destructive commands.
+var (
+	dryRun bool // describe destructive actions instead of performing them
+	force  bool // skip confirmation prompts
+)
+
+// errCancelled is returned when the user declines a confirmation prompt.
+var errCancelled = errors.New("operation cancelled")
+
+// assumeYes reports whether confirmation prompts are skipped, either with
+// -force or by setting NLM_YES to a true value.
+func assumeYes() bool {
+	if force {
+		return true
+	}
+	yes, _ := strconv.ParseBool(os.Getenv("NLM_YES"))
+	return yes
+}
+
+// confirmAction guards a destructive action, given as a verb phrase such
+// as "delete notebook abc". It reports whether the action should go ahead:
+// with -dry-run it prints what would be done and reports false with a nil
+// error; with -force or NLM_YES=1 it reports true; otherwise it asks on the
+// terminal and returns errCancelled unless the answer is yes. Callers use
+//
+//	if ok, err := confirmAction(...); !ok {
+//		return err
+//	}
+func confirmAction(format string, args ...interface{}) (bool, error) {
+	action := i18n.Sprintf(format, args...)
+	if dryRun {
+		fmt.Println(paint(os.Stdout, styleYellow, i18n.Sprintf("Would %s (dry run)", action)))
+		return false, nil
+	}
+	if assumeYes() {
+		return true, nil
+	}
+	fmt.Print(i18n.Sprintf("Are you sure you want to %s? [y/N] ", action))
+	var response string
+	fmt.Scanln(&response)
+	// Accept "y" as well as the translated answer, such as "j" in German.
+	response = strings.ToLower(response)
+	if !strings.HasPrefix(response, "y") && !strings.HasPrefix(response, i18n.T("y")) {
+		return false, errCancelled
+	}
+	return true, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestConfirmAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		dryRun bool
+		force  bool
+		yesEnv string
+		want   bool
+	}{
+		{name: "dry run", dryRun: true, want: false},
+		{name: "dry run wins over force", dryRun: true, force: true, want: false},
+		{name: "force", force: true, want: true},
+		{name: "NLM_YES", yesEnv: "1", want: true},
+		{name: "NLM_YES true", yesEnv: "true", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(d, f bool) { dryRun, force = d, f }(dryRun, force)
+			dryRun, force = tt.dryRun, tt.force
+			t.Setenv("NLM_YES", tt.yesEnv)
+			got, err := confirmAction("delete notebook %s", "nb1")
+			if err != nil || got != tt.want {
+				t.Errorf("confirmAction() = %v, %v; want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssumeYes(t *testing.T) {
+	for _, v := range []string{"", "0", "false", "no"} {
+		t.Setenv("NLM_YES", v)
+		if assumeYes() {
+			t.Errorf("assumeYes() with NLM_YES=%q = true, want false", v)
+		}
+	}
+}
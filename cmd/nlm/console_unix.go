@@ -0,0 +1,13 @@
+//go:build !windows
+
+package main
+
+import "os"
+
+// setupConsole prepares the terminal for output; Unix terminals need
+// nothing.
+func setupConsole() {}
+
+// enableVirtualTerminal reports whether the terminal behind f understands
+// ANSI escapes, which Unix terminals do.
+func enableVirtualTerminal(f *os.File) bool { return true }
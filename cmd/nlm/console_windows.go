@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// cpUTF8 is the UTF-8 console code page.
+const cpUTF8 = 65001
+
+// setupConsole switches the console to UTF-8 output so emoji and box
+// drawing characters print instead of mojibake.
+func setupConsole() {
+	windows.SetConsoleOutputCP(cpUTF8)
+}
+
+// enableVirtualTerminal turns on ANSI escape processing for the console
+// behind f. Windows 10 and later support it but leave it off by default;
+// it reports whether escapes will be understood.
+func enableVirtualTerminal(f *os.File) bool {
+	h := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(h, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/daemon"
+)
+
+// daemonOptions contains the CLI options for `nlm daemon`.
+type daemonOptions struct {
+	Action      string // "", "status" or "stop"
+	Socket      string
+	IdleTimeout time.Duration
+}
+
+func parseDaemonFlags(args []string) (*daemonOptions, error) {
+	opts := &daemonOptions{}
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	fs.StringVar(&opts.Socket, "socket", "", "unix socket `path` (or set NLM_DAEMON_SOCKET; default ~/.nlm/daemon.sock)")
+	fs.DurationVar(&opts.IdleTimeout, "idle-timeout", 0, "exit after forwarding no request for this long (default: never)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm daemon [-socket path] [-idle-timeout d]\n")
+		fmt.Fprintf(os.Stderr, "       nlm daemon status|stop [-socket path]\n\n")
+		fmt.Fprintf(os.Stderr, "Runs a background daemon that keeps connections to NotebookLM open.\n")
+		fmt.Fprintf(os.Stderr, "While it runs, other nlm commands send their requests through its\n")
+		fmt.Fprintf(os.Stderr, "socket and skip connection setup; without it they connect directly.\n")
+		fmt.Fprintf(os.Stderr, "NLM_DAEMON=off makes a command ignore the daemon.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) > 0 {
+		opts.Action = pos[0]
+	}
+	if len(pos) > 1 || opts.Action != "" && opts.Action != "status" && opts.Action != "stop" || opts.IdleTimeout < 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+// daemonSocket returns the socket given with -socket or
+// NLM_DAEMON_SOCKET, or the default.
+func daemonSocket(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if s := os.Getenv("NLM_DAEMON_SOCKET"); s != "" {
+		return s, nil
+	}
+	return daemon.DefaultSocket()
+}
+
+func runDaemon(args []string) error {
+	opts, err := parseDaemonFlags(args)
+	if err != nil {
+		return err
+	}
+	socket, err := daemonSocket(opts.Socket)
+	if err != nil {
+		return err
+	}
+	switch opts.Action {
+	case "status":
+		st, err := daemon.Ping(socket)
+		if err != nil {
+			return fmt.Errorf("nlm daemon: not running on %s", socket)
+		}
+		fmt.Printf("nlm daemon running on %s (pid %d) since %s, %d requests forwarded\n",
+			socket, st.PID, formatTime(st.Started), st.Requests)
+		return nil
+	case "stop":
+		return daemon.Stop(socket)
+	}
+
+	l, err := daemon.Listen(socket)
+	if err != nil {
+		return fmt.Errorf("nlm daemon: %w", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	statusf("nlm daemon listening on %s\n", socket)
+	srv := &daemon.Server{IdleTimeout: opts.IdleTimeout}
+	return srv.Serve(ctx, l)
+}
+
+// daemonClientOptions sends the command's requests through the daemon
+// when its socket exists, unless NLM_DAEMON=off. The client falls back
+// to connecting directly if the daemon is gone.
+func daemonClientOptions() []batchexecute.Option {
+	if v := os.Getenv("NLM_DAEMON"); v == "off" || v == "0" || v == "false" {
+		return nil
+	}
+	socket, err := daemonSocket("")
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(socket); err != nil {
+		return nil
+	}
+	if debug {
+		fmt.Fprintf(os.Stderr, "DEBUG: Sending requests through the daemon on %s\n", socket)
+	}
+	return []batchexecute.Option{batchexecute.WithHTTPClient(&http.Client{Transport: daemon.Transport(socket, batchexecute.DefaultTransport)})}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// unifiedDiff returns a unified diff from a to b, or "" if they are equal.
+// It is a plain longest-common-subsequence diff over lines, which is fine
+// for documents the size of generated artifacts. Lines common to the start
+// and end of both are matched first, so that long documents with a few
+// changes stay cheap.
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	x, y := splitLines(a), splitLines(b)
+	pre := 0
+	for pre < len(x) && pre < len(y) && x[pre] == y[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(x)-pre && suf < len(y)-pre && x[len(x)-1-suf] == y[len(y)-1-suf] {
+		suf++
+	}
+	mx, my := x[pre:len(x)-suf], y[pre:len(y)-suf]
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// mx[i:] and my[j:].
+	lcs := make([][]int, len(mx)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(my)+1)
+	}
+	for i := len(mx) - 1; i >= 0; i-- {
+		for j := len(my) - 1; j >= 0; j-- {
+			if mx[i] == my[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		op   byte // ' ', '-' or '+'
+		text string
+		i, j int // line numbers in x and y before this line
+	}
+	var lines []line
+	for k := 0; k < pre; k++ {
+		lines = append(lines, line{' ', x[k], k, k})
+	}
+	i, j := 0, 0
+	for i < len(mx) || j < len(my) {
+		switch {
+		case i < len(mx) && j < len(my) && mx[i] == my[j]:
+			lines = append(lines, line{' ', mx[i], pre + i, pre + j})
+			i++
+			j++
+		case i < len(mx) && (j == len(my) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{'-', mx[i], pre + i, pre + j})
+			i++
+		default:
+			lines = append(lines, line{'+', my[j], pre + i, pre + j})
+			j++
+		}
+	}
+	for k := 0; k < suf; k++ {
+		i, j := len(x)-suf+k, len(y)-suf+k
+		lines = append(lines, line{' ', x[i], i, j})
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+	for start := 0; start < len(lines); {
+		// Find the next change and the end of its hunk.
+		for start < len(lines) && lines[start].op == ' ' {
+			start++
+		}
+		if start == len(lines) {
+			break
+		}
+		end := start
+		for k := start; k < len(lines); k++ {
+			if lines[k].op != ' ' {
+				end = k + 1
+			} else if k-end >= 2*diffContext {
+				break
+			}
+		}
+		lo, hi := max(start-diffContext, 0), min(end+diffContext, len(lines))
+
+		var na, nb int
+		for _, l := range lines[lo:hi] {
+			if l.op != '+' {
+				na++
+			}
+			if l.op != '-' {
+				nb++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(lines[lo].i, na), hunkRange(lines[lo].j, nb))
+		for _, l := range lines[lo:hi] {
+			fmt.Fprintf(&out, "%c%s\n", l.op, l.text)
+		}
+		start = hi
+	}
+	return out.String()
+}
+
+// hunkRange formats a hunk's start line and length. An empty range is
+// numbered after the line it follows, as diff does.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
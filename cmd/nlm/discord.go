@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/discordbot"
+)
+
+// discordOptions contains the CLI options for `nlm discord`.
+type discordOptions struct {
+	Token  string
+	Guild  string
+	Admins []string
+}
+
+func parseDiscordFlags(args []string) (*discordOptions, error) {
+	opts := &discordOptions{}
+	var admins string
+	fs := flag.NewFlagSet("discord", flag.ContinueOnError)
+	fs.StringVar(&opts.Token, "token", os.Getenv("NLM_DISCORD_TOKEN"), "Discord bot `token` (or set NLM_DISCORD_TOKEN)")
+	fs.StringVar(&opts.Guild, "guild", "", "register the slash commands in this server `id` only, where they appear at once")
+	fs.StringVar(&admins, "admins", "", "comma-separated user `ids` allowed admin commands besides Manage Server holders")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm discord [-token token] [-guild id] [-admins ids]\n\n")
+		fmt.Fprintf(os.Stderr, "Runs a Discord bot that answers questions with the notebook linked to each\n")
+		fmt.Fprintf(os.Stderr, "channel, streaming answers into embeds that cite the notebook's sources.\n")
+		fmt.Fprintf(os.Stderr, "Members ask with /nlm ask or by mentioning the bot; admins link channels\n")
+		fmt.Fprintf(os.Stderr, "with /nlm notebook and add sources with /nlm add-source.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	for _, id := range strings.Split(admins, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			opts.Admins = append(opts.Admins, id)
+		}
+	}
+	return opts, nil
+}
+
+func runDiscord(c *api.Client, args []string) error {
+	opts, err := parseDiscordFlags(args)
+	if err != nil {
+		return err
+	}
+	if opts.Token == "" {
+		return fmt.Errorf("nlm discord: no bot token; pass -token or set NLM_DISCORD_TOKEN")
+	}
+	path, err := discordbot.DefaultChannelsPath()
+	if err != nil {
+		return err
+	}
+	bot := discordbot.New(c, discordbot.NewChannels(path))
+	bot.Admins = opts.Admins
+	bot.ResolveNotebook = func(ref string) (string, error) {
+		return resolveNotebook(c, ref)
+	}
+	bot.Logf = func(format string, args ...any) {
+		fmt.Fprintf(os.Stderr, "nlm: discord: "+format+"\n", args...)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return bot.Run(ctx, opts.Token, opts.Guild)
+}
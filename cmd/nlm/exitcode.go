@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/cache"
+	"github.com/tmc/nlm/internal/gdrive"
+	"github.com/tmc/nlm/internal/jobs"
+	"github.com/tmc/nlm/internal/provenance"
+	"github.com/tmc/nlm/internal/upload"
+)
+
+// Exit codes. They are stable so that scripts can branch on the class of
+// failure; new classes get new codes rather than reusing these.
+const (
+	exitOK          = 0
+	exitError       = 1 // any failure not covered below
+	exitUsage       = 2 // invalid command-line arguments
+	exitAuth        = 3 // missing, expired or rejected credentials
+	exitNotFound    = 4 // notebook, source, artifact or job does not exist
+	exitRateLimited = 5 // rate limited or quota exhausted; retry later
+	exitProtocol    = 6 // response could not be parsed; the API changed
+	exitOffline     = 7 // the command needs the network but -offline is set
+)
+
+// errorClasses names the exit codes in -error-format json output.
+var errorClasses = map[int]string{
+	exitError:       "error",
+	exitUsage:       "usage",
+	exitAuth:        "auth",
+	exitNotFound:    "not_found",
+	exitRateLimited: "rate_limited",
+	exitProtocol:    "protocol",
+	exitOffline:     "offline",
+}
+
+// errorFormat is the -error-format flag: "text" or "json".
+var errorFormat string
+
+// protocolKeywords mark errors decoding a response whose shape is not
+// what the client expects.
+var protocolKeywords = []string{
+	"parse response",
+	"decode response",
+	"failed to parse",
+	"failed to unmarshal",
+	"no valid responses found",
+	"unexpected response",
+	"failed to find message type",
+	"expected number, got",
+	"expected string, got",
+}
+
+// exitCode classifies err into one of the exit codes.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var apiErr *batchexecute.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.ErrorCode != nil {
+			switch apiErr.ErrorCode.Type {
+			case batchexecute.ErrorTypeAuthentication, batchexecute.ErrorTypeAuthorization, batchexecute.ErrorTypePermissionDenied:
+				return exitAuth
+			case batchexecute.ErrorTypeNotFound:
+				return exitNotFound
+			case batchexecute.ErrorTypeRateLimit, batchexecute.ErrorTypeResourceExhausted:
+				return exitRateLimited
+			}
+		}
+		if code := httpExitCode(apiErr.HTTPStatus); code != exitError {
+			return code
+		}
+	}
+	var batchErr *batchexecute.BatchExecuteError
+	if errors.As(err, &batchErr) {
+		if code := httpExitCode(batchErr.StatusCode); code != exitError {
+			return code
+		}
+	}
+	var driveErr *gdrive.Error
+	if errors.As(err, &driveErr) {
+		if code := httpExitCode(driveErr.StatusCode); code != exitError {
+			return code
+		}
+	}
+	var uploadErr *upload.Error
+	if errors.As(err, &uploadErr) {
+		if code := httpExitCode(uploadErr.StatusCode); code != exitError {
+			return code
+		}
+	}
+	var valErr *api.ValidationError
+	if errors.As(err, &valErr) {
+		return exitUsage
+	}
+	switch {
+	case errors.Is(err, errOffline):
+		return exitOffline
+	case errors.Is(err, errNoNotebook), errors.Is(err, jobs.ErrNotFound), errors.Is(err, provenance.ErrNotFound), errors.Is(err, cache.ErrNotCached):
+		return exitNotFound
+	case isAuthenticationError(err):
+		return exitAuth
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return exitProtocol
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case msg == "invalid arguments":
+		return exitUsage
+	case strings.Contains(msg, "rate limit"), strings.Contains(msg, "status: 429"):
+		return exitRateLimited
+	case strings.Contains(msg, "not found"):
+		return exitNotFound
+	}
+	for _, keyword := range protocolKeywords {
+		if strings.Contains(msg, keyword) {
+			return exitProtocol
+		}
+	}
+	return exitError
+}
+
+// httpExitCode classifies an HTTP status, returning exitError for
+// statuses with no class of their own.
+func httpExitCode(status int) int {
+	switch status {
+	case 401, 403:
+		return exitAuth
+	case 404:
+		return exitNotFound
+	case 429:
+		return exitRateLimited
+	}
+	return exitError
+}
+
+// cliError is the -error-format json form of an error.
+type cliError struct {
+	Error     string `json:"error"`
+	Class     string `json:"class"`
+	ExitCode  int    `json:"exit_code"`
+	APICode   int    `json:"api_code,omitempty"`
+	Retryable bool   `json:"retryable,omitempty"`
+}
+
+// reportError writes err to w in the -error-format format and returns the
+// process exit code.
+func reportError(w io.Writer, err error) int {
+	code := exitCode(err)
+	if format := errorFormatSetting(); format != "json" {
+		fmt.Fprintf(w, "%s %v\n", paint(w, styleRed, "nlm:"), err)
+		return code
+	}
+	e := cliError{Error: err.Error(), Class: errorClasses[code], ExitCode: code}
+	var apiErr *batchexecute.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.ErrorCode != nil {
+			e.APICode = apiErr.ErrorCode.Code
+		}
+		e.Retryable = apiErr.IsRetryable()
+	}
+	if code == exitRateLimited {
+		e.Retryable = true
+	}
+	json.NewEncoder(w).Encode(e)
+	return code
+}
+
+// errorFormatSetting returns the error format from -error-format or
+// NLM_ERROR_FORMAT.
+func errorFormatSetting() string {
+	if errorFormat != "" {
+		return errorFormat
+	}
+	return os.Getenv("NLM_ERROR_FORMAT")
+}
+
+// validateErrorFormat checks the -error-format setting.
+func validateErrorFormat() error {
+	switch f := errorFormatSetting(); f {
+	case "", "text", "json":
+		return nil
+	default:
+		return fmt.Errorf("unknown error format %q (want text or json)", f)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/gdrive"
+	"github.com/tmc/nlm/internal/jobs"
+)
+
+func TestExitCode(t *testing.T) {
+	rateLimit, _ := batchexecute.GetErrorCode(324934)
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, exitOK},
+		{"generic", errors.New("boom"), exitError},
+		{"usage", fmt.Errorf("invalid arguments"), exitUsage},
+		{"invalid input", &api.Error{Op: "GetProject", Err: &api.ValidationError{What: "notebook ID", Value: "a b", Reason: "bad"}}, exitUsage},
+		{"unauthorized", fmt.Errorf("list: %w", &batchexecute.BatchExecuteError{StatusCode: 401}), exitAuth},
+		{"auth required", fmt.Errorf("authentication required"), exitAuth},
+		{"api rate limit", fmt.Errorf("create: %w", &batchexecute.APIError{ErrorCode: rateLimit}), exitRateLimited},
+		{"http 429", &batchexecute.APIError{HTTPStatus: 429, Message: "slow down"}, exitRateLimited},
+		{"http 404", &batchexecute.BatchExecuteError{StatusCode: 404}, exitNotFound},
+		{"drive forbidden", fmt.Errorf("export: %w", &gdrive.Error{StatusCode: 403}), exitAuth},
+		{"no notebook", fmt.Errorf("%w %q", errNoNotebook, "ml"), exitNotFound},
+		{"job", fmt.Errorf("cancel: %w", jobs.ErrNotFound), exitNotFound},
+		{"json syntax", fmt.Errorf("list: %w", json.Unmarshal([]byte("{"), new(any))), exitProtocol},
+		{"parse keyword", errors.New("parse response JSON: bad shape"), exitProtocol},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("%s: exitCode(%v) = %d, want %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestReportErrorJSON(t *testing.T) {
+	defer func(f string) { errorFormat = f }(errorFormat)
+	errorFormat = "json"
+
+	rateLimit, _ := batchexecute.GetErrorCode(324934)
+	var buf bytes.Buffer
+	code := reportError(&buf, fmt.Errorf("create: %w", &batchexecute.APIError{ErrorCode: rateLimit}))
+	if code != exitRateLimited {
+		t.Errorf("reportError() = %d, want %d", code, exitRateLimited)
+	}
+	var got cliError
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	want := cliError{
+		Error:     "create: API error 324934 (RateLimit): Rate limit exceeded",
+		Class:     "rate_limited",
+		ExitCode:  exitRateLimited,
+		APICode:   324934,
+		Retryable: true,
+	}
+	if got != want {
+		t.Errorf("reportError() wrote %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultConcurrency is how many notebooks account-wide commands work on
+// at once unless told otherwise.
+const defaultConcurrency = 4
+
+// fanOut calls fn for each notebook ID with up to concurrency calls at
+// once. Every notebook is tried; the failures are returned together as a
+// *fanOutError. Once ctx is done no further calls are started, and its
+// error is returned.
+func fanOut(ctx context.Context, ids []string, concurrency int, fn func(i int, id string) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(ids))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(ids)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				if ctx.Err() == nil {
+					errs[i] = fn(i, ids[i])
+				}
+			}
+		}()
+	}
+feed:
+	for i := range ids {
+		select {
+		case work <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	fe := &fanOutError{total: len(ids)}
+	for i, err := range errs {
+		if err != nil {
+			fe.ids = append(fe.ids, ids[i])
+			fe.errs = append(fe.errs, err)
+		}
+	}
+	if len(fe.errs) == 0 {
+		return nil
+	}
+	return fe
+}
+
+// fanOutError reports the notebooks an account-wide command failed on.
+// It matches what any of the failures match, so the exit code follows
+// their class.
+type fanOutError struct {
+	total int
+	ids   []string
+	errs  []error
+}
+
+func (e *fanOutError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d notebooks failed:", len(e.errs), e.total)
+	for i, err := range e.errs {
+		fmt.Fprintf(&b, "\n  %s: %v", e.ids[i], err)
+	}
+	return b.String()
+}
+
+func (e *fanOutError) Unwrap() []error {
+	return e.errs
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFanOut(t *testing.T) {
+	ids := []string{"nb1", "nb2", "nb3", "nb4", "nb5", "nb6"}
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	seen := make([]string, len(ids))
+	err := fanOut(context.Background(), ids, 3, func(i int, id string) error {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		seen[i] = id
+		if id == "nb2" || id == "nb5" {
+			return fmt.Errorf("get notebook: %w", errNotFound)
+		}
+		return nil
+	})
+	if fmt.Sprint(seen) != fmt.Sprint(ids) {
+		t.Errorf("called for %v, want %v", seen, ids)
+	}
+	if peak != 3 {
+		t.Errorf("peak concurrency = %d, want 3", peak)
+	}
+	want := "2 of 6 notebooks failed:\n  nb2: get notebook: not found\n  nb5: get notebook: not found"
+	if err == nil || err.Error() != want {
+		t.Fatalf("fanOut() = %v, want %q", err, want)
+	}
+	if !errors.Is(err, errNotFound) {
+		t.Errorf("fanOut() error does not match its failures")
+	}
+
+	if err := fanOut(context.Background(), ids, 2, func(int, string) error { return nil }); err != nil {
+		t.Errorf("fanOut() = %v, want nil", err)
+	}
+}
+
+func TestFanOutCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := fanOut(ctx, []string{"nb1", "nb2", "nb3"}, 1, func(int, string) error {
+		calls++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("fanOut() = %v after %d calls, want context.Canceled after 1", err, calls)
+	}
+}
+
+var errNotFound = errors.New("not found")
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// utf8BOM is the byte order mark Windows editors such as Notepad put at
+// the start of UTF-8 files.
+const utf8BOM = "\uFEFF"
+
+// trimBOM removes a leading UTF-8 byte order mark so text from files saved
+// on Windows is read, and written back out, as plain UTF-8.
+func trimBOM(s string) string {
+	return strings.TrimPrefix(s, utf8BOM)
+}
+
+// expandInput expands a wildcard pattern in an add input into the files it
+// matches. Unix shells do this before nlm runs, but cmd.exe and PowerShell
+// pass patterns such as docs\*.pdf through unexpanded. Inputs that are not
+// patterns, name an existing file, or match no files are returned as is.
+func expandInput(input string) []string {
+	if !strings.ContainsAny(input, "*?[") || strings.Contains(input, "://") {
+		return []string{input}
+	}
+	if _, err := os.Stat(input); err == nil {
+		return []string{input}
+	}
+	matches, err := filepath.Glob(input)
+	if err != nil {
+		return []string{input}
+	}
+	var files []string
+	for _, m := range matches {
+		if fi, err := os.Stat(m); err == nil && !fi.IsDir() {
+			files = append(files, m)
+		}
+	}
+	if len(files) == 0 {
+		return []string{input}
+	}
+	return files
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandInput(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.md", "b.md", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "d.md"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{filepath.Join(dir, "*.md"), []string{filepath.Join(dir, "a.md"), filepath.Join(dir, "b.md")}},
+		{filepath.Join(dir, "?.txt"), []string{filepath.Join(dir, "c.txt")}},
+		{filepath.Join(dir, "*.pdf"), []string{filepath.Join(dir, "*.pdf")}},
+		{"https://example.com/?q=*", []string{"https://example.com/?q=*"}},
+		{"why? because", []string{"why? because"}},
+		{filepath.Join(dir, "a.md"), []string{filepath.Join(dir, "a.md")}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, expandInput(tt.input)); diff != "" {
+			t.Errorf("expandInput(%q) mismatch (-want +got):\n%s", tt.input, diff)
+		}
+	}
+}
+
+func TestTrimBOM(t *testing.T) {
+	if got := trimBOM("\uFEFFnb1\n"); got != "nb1\n" {
+		t.Errorf("trimBOM() = %q, want %q", got, "nb1\n")
+	}
+	if got := trimBOM("nb1 \uFEFF"); got != "nb1 \uFEFF" {
+		t.Errorf("trimBOM() changed text without a leading BOM: %q", got)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/anki"
+	"github.com/tmc/nlm/internal/api"
+)
+
+const flashcardsUsage = "usage: nlm flashcards export <notebook-id> <artifact-id> [-format anki-tsv|apkg] [-out file]\n"
+
+func validateFlashcardsArgs(args []string) error {
+	if len(args) == 0 || args[0] != "export" {
+		fmt.Fprint(os.Stderr, flashcardsUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	_, err := parseFlashcardsExportFlags(args[1:])
+	return err
+}
+
+// flashcardsExportArgs contains the CLI options for `flashcards export`
+type flashcardsExportArgs struct {
+	NotebookID string
+	ArtifactID string
+	Format     string
+	Out        string
+}
+
+func parseFlashcardsExportFlags(args []string) (*flashcardsExportArgs, error) {
+	opts := &flashcardsExportArgs{}
+	fs := flag.NewFlagSet("flashcards export", flag.ContinueOnError)
+	fs.StringVar(&opts.Format, "format", "anki-tsv", "output format: anki-tsv, or apkg for an Anki deck package")
+	fs.StringVar(&opts.Out, "out", "", "write to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, flashcardsUsage+"\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	switch opts.Format {
+	case "anki-tsv", "apkg":
+	default:
+		fs.Usage()
+		return nil, fmt.Errorf("unknown format %q", opts.Format)
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func runFlashcards(c *api.Client, args []string) error {
+	opts, err := parseFlashcardsExportFlags(args[1:])
+	if err != nil {
+		return err
+	}
+	if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+		return err
+	}
+	cards, err := c.GetFlashcards(opts.NotebookID, opts.ArtifactID)
+	if err != nil {
+		return err
+	}
+
+	tags := []string{"nlm"}
+	deck := "NotebookLM flashcards"
+	if nb, err := c.GetProject(opts.NotebookID); err == nil && nb.GetTitle() != "" {
+		tags = append(tags, ankiTag(nb.GetTitle()))
+		deck = nb.GetTitle()
+	} else if debug {
+		fmt.Fprintf(os.Stderr, "nlm: warning: could not get notebook title for tags: %v\n", err)
+	}
+
+	var buf bytes.Buffer
+	if opts.Format == "apkg" {
+		if err := anki.WriteAPKG(&buf, ankiDeck(deck, cards.Cards, tags)); err != nil {
+			return err
+		}
+	} else {
+		writeAnkiTSV(&buf, cards.Cards, tags)
+	}
+	if opts.Out == "" {
+		_, err := io.Copy(os.Stdout, &buf)
+		return err
+	}
+	if err := os.WriteFile(opts.Out, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write flashcards: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Saved %d cards to %s\n", len(cards.Cards), opts.Out)
+	return nil
+}
+
+// writeAnkiTSV writes cards in Anki's tab-separated import format. The
+// header lines tell Anki the field separator, that fields hold HTML, and
+// which column carries the tags, so the file imports without any manual
+// mapping.
+func writeAnkiTSV(w io.Writer, cards []api.Flashcard, tags []string) {
+	fmt.Fprintf(w, "#separator:tab\n#html:true\n#tags column:3\n")
+	tagField := strings.Join(tags, " ")
+	for _, card := range cards {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", ankiField(card.Front), ankiField(card.Back), tagField)
+	}
+}
+
+// ankiDeck returns cards as an Anki deck package's deck.
+func ankiDeck(name string, cards []api.Flashcard, tags []string) anki.Deck {
+	d := anki.Deck{Name: name, Tags: tags}
+	for _, card := range cards {
+		d.Cards = append(d.Cards, anki.Card{Front: ankiField(card.Front), Back: ankiField(card.Back)})
+	}
+	return d
+}
+
+// ankiField escapes text for an HTML field, keeping line breaks.
+func ankiField(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+	return strings.ReplaceAll(s, "\t", " ")
+}
+
+// ankiTag turns a notebook title into a single Anki tag; tags cannot
+// contain spaces.
+func ankiTag(title string) string {
+	return strings.Join(strings.Fields(title), "_")
+}
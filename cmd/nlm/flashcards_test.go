@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/nlm/internal/anki"
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestWriteAnkiTSV(t *testing.T) {
+	cards := []api.Flashcard{
+		{Front: "What is <b>Go</b>?", Back: "A language\nfrom Google"},
+		{Front: "Tabs\there", Back: "A & B"},
+	}
+	var buf bytes.Buffer
+	writeAnkiTSV(&buf, cards, []string{"nlm", ankiTag("  Intro to   Go ")})
+	want := "#separator:tab\n#html:true\n#tags column:3\n" +
+		"What is &lt;b&gt;Go&lt;/b&gt;?\tA language<br>from Google\tnlm Intro_to_Go\n" +
+		"Tabs here\tA &amp; B\tnlm Intro_to_Go\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeAnkiTSV() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestAnkiDeck(t *testing.T) {
+	cards := []api.Flashcard{{Front: "What is <b>Go</b>?", Back: "A language\nfrom Google"}}
+	got := ankiDeck("Intro to Go", cards, []string{"nlm"})
+	want := anki.Deck{
+		Name:  "Intro to Go",
+		Tags:  []string{"nlm"},
+		Cards: []anki.Card{{Front: "What is &lt;b&gt;Go&lt;/b&gt;?", Back: "A language<br>from Google"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ankiDeck() (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/notify"
+)
+
+// generateUsage lists the outputs of `nlm generate`.
+const generateUsage = "usage: nlm generate <study-guide|faq|briefing|timeline|mindmap|slides|infographic|audio|video> [notebook-id] [options]\n"
+
+// generateArtifacts maps the outputs of `nlm generate` that are artifacts
+// to their kinds. Audio and video overviews have their own flows.
+var generateArtifacts = map[string]api.ArtifactKind{
+	"study-guide":  api.ArtifactStudyGuide,
+	"faq":          api.ArtifactFAQ,
+	"briefing":     api.ArtifactBriefingDoc,
+	"briefing-doc": api.ArtifactBriefingDoc,
+	"timeline":     api.ArtifactTimeline,
+	"mindmap":      api.ArtifactMindMap,
+	"mind-map":     api.ArtifactMindMap,
+	"slides":       api.ArtifactSlideDeck,
+	"slide-deck":   api.ArtifactSlideDeck,
+	"infographic":  api.ArtifactInfographic,
+}
+
+// generateArgs contains the CLI options for `nlm generate`. Exactly one of
+// Artifact and Audio is set for artifact and audio outputs; video uses
+// only the common fields.
+type generateArgs struct {
+	Output       string
+	NotebookID   string
+	Instructions string
+	Notify       string
+	Artifact     *artifactCreateArgs
+	Audio        *api.AudioOverviewOptions
+}
+
+func validateGenerateArgs(args []string) error {
+	_, err := parseGenerateFlags(args)
+	return err
+}
+
+// parseGenerateFlags parses `nlm generate <output> [notebook-id] [options]`.
+// Artifact outputs take the options of `artifact create` and audio the
+// -length and -style presets of audio-create; all take -instructions.
+func parseGenerateFlags(args []string) (*generateArgs, error) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, generateUsage)
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts := &generateArgs{Output: strings.ToLower(args[0])}
+	if kind, ok := generateArtifacts[opts.Output]; ok {
+		a, err := parseArtifactCreateFlags(append([]string{"-type", string(kind)}, args[1:]...))
+		if err != nil {
+			return nil, err
+		}
+		opts.NotebookID, opts.Notify, opts.Artifact = a.NotebookID, a.Notify, a
+		return opts, nil
+	}
+	if opts.Output != "audio" && opts.Output != "video" {
+		fmt.Fprint(os.Stderr, generateUsage)
+		return nil, fmt.Errorf("unknown output %q (want %s)", args[0], strings.Join(generateOutputs(), ", "))
+	}
+
+	fs := flag.NewFlagSet("generate "+opts.Output, flag.ContinueOnError)
+	fs.StringVar(&opts.Instructions, "instructions", "", "custom instructions, e.g. \"focus on chapter 3\"")
+	resolve := func() error { return nil }
+	if opts.Output == "audio" {
+		opts.Audio = &api.AudioOverviewOptions{}
+		resolve = audioPresetFlags(fs, opts.Audio)
+	}
+	addNotifyFlag(fs, &opts.Notify)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm generate %s [notebook-id] [options]\n\n", opts.Output)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if err := resolve(); err != nil {
+		fs.Usage()
+		return nil, err
+	}
+	if opts.Notify != "" {
+		if err := notify.Validate(opts.Notify); err != nil {
+			fs.Usage()
+			return nil, err
+		}
+	}
+	opts.NotebookID = pos[0]
+	if opts.Audio != nil {
+		opts.Audio.Instructions = opts.Instructions
+	}
+	return opts, nil
+}
+
+// generateOutputs returns the outputs `nlm generate` accepts, sorted.
+func generateOutputs() []string {
+	outputs := []string{"audio", "video"}
+	for name := range generateArtifacts {
+		outputs = append(outputs, name)
+	}
+	sort.Strings(outputs)
+	return outputs
+}
+
+// runGenerate creates an output with the flow its type needs: artifacts
+// through `artifact create`, audio and video overviews through their own
+// RPCs.
+func runGenerate(c *api.Client, args []string) error {
+	opts, err := parseGenerateFlags(args)
+	if err != nil {
+		return err
+	}
+	if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+		return err
+	}
+	switch {
+	case opts.Artifact != nil:
+		opts.Artifact.NotebookID = opts.NotebookID
+		return artifactCreate(c, opts.Artifact)
+	case opts.Audio != nil:
+		return createAudioOverview(c, opts.NotebookID, *opts.Audio, opts.Notify)
+	default:
+		return createVideoOverview(c, opts.NotebookID, opts.Instructions, opts.Notify)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestParseGenerateFlags(t *testing.T) {
+	t.Setenv("NLM_NOTEBOOK", "")
+
+	opts, err := parseGenerateFlags([]string{"briefing", "nb1", "-instructions", "for executives"})
+	if err != nil {
+		t.Fatalf("parseGenerateFlags(briefing) error = %v", err)
+	}
+	if opts.Artifact == nil || opts.Artifact.Kind != api.ArtifactBriefingDoc || opts.NotebookID != "nb1" ||
+		opts.Artifact.Options.Instructions != "for executives" {
+		t.Errorf("parseGenerateFlags(briefing) = %+v, artifact %+v", opts, opts.Artifact)
+	}
+
+	opts, err = parseGenerateFlags([]string{"audio", "nb1", "-length", "short", "-instructions", "keep it light"})
+	if err != nil {
+		t.Fatalf("parseGenerateFlags(audio) error = %v", err)
+	}
+	if opts.Audio == nil || opts.Audio.Length != audioLengths["short"] || opts.Audio.Instructions != "keep it light" {
+		t.Errorf("parseGenerateFlags(audio) audio = %+v", opts.Audio)
+	}
+
+	opts, err = parseGenerateFlags([]string{"video", "nb1"})
+	if err != nil {
+		t.Fatalf("parseGenerateFlags(video) error = %v", err)
+	}
+	if opts.Artifact != nil || opts.Audio != nil || opts.NotebookID != "nb1" {
+		t.Errorf("parseGenerateFlags(video) = %+v", opts)
+	}
+
+	for _, args := range [][]string{
+		nil,
+		{"podcast", "nb1"},
+		{"faq"},
+		{"video", "nb1", "extra"},
+		{"video", "nb1", "-length", "short"},
+		{"audio", "nb1", "-style", "shouting"},
+	} {
+		if _, err := parseGenerateFlags(args); err == nil {
+			t.Errorf("parseGenerateFlags(%q) succeeded, want error", args)
+		}
+	}
+}
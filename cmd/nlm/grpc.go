@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/tmc/nlm/gen/service"
+	"github.com/tmc/nlm/internal/batchexecute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// serveGRPC serves the notebooklm.v1alpha1 services, translating each call
+// to the batchexecute protocol, until ctx is done.
+func serveGRPC(ctx context.Context, opts *serveOptions, m *serverMetrics) error {
+	lis, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(m.unaryInterceptor(), grpcUnaryAuth(opts.APIKey), grpcUnaryStatus(m)),
+		grpc.ChainStreamInterceptor(m.streamInterceptor(), grpcStreamAuth(opts.APIKey), grpcStreamStatus(m)),
+	)
+	rpcOpts := retryOptions()
+	if rpcStats != nil {
+		rpcOpts = append(rpcOpts, batchexecute.WithStats(rpcStats))
+	}
+	service.NewLabsTailwindOrchestrationServiceServer(service.NewLabsTailwindOrchestrationServiceClient(authToken, cookies, rpcOpts...)).Register(s)
+	service.NewLabsTailwindSharingServiceServer(service.NewLabsTailwindSharingServiceClient(authToken, cookies, rpcOpts...)).Register(s)
+	service.NewLabsTailwindGuidebooksServiceServer(service.NewLabsTailwindGuidebooksServiceClient(authToken, cookies, rpcOpts...)).Register(s)
+	reflection.Register(s)
+
+	go func() {
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+	statusf("Serving NotebookLM gRPC services on %s\n", lis.Addr())
+	if err := s.Serve(lis); err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
+
+// grpcAuthorized checks the API key in a call's "authorization: Bearer"
+// or "x-api-key" metadata.
+func grpcAuthorized(ctx context.Context, apiKey string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var key string
+	if v := md.Get("x-api-key"); len(v) > 0 {
+		key = v[0]
+	}
+	if v := md.Get("authorization"); len(v) > 0 && strings.HasPrefix(v[0], "Bearer ") {
+		key = strings.TrimPrefix(v[0], "Bearer ")
+	}
+	if apiKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+		return status.Error(codes.Unauthenticated, "missing or invalid API key")
+	}
+	return nil
+}
+
+// grpcStatus converts an API error to a gRPC status error, using the same
+// classes as the exit codes.
+func grpcStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codes.Unavailable
+	switch exitCode(err) {
+	case exitUsage:
+		code = codes.InvalidArgument
+	case exitAuth:
+		code = codes.PermissionDenied
+	case exitNotFound:
+		code = codes.NotFound
+	case exitRateLimited:
+		code = codes.ResourceExhausted
+	case exitProtocol:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
+
+func grpcUnaryAuth(apiKey string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := grpcAuthorized(ctx, apiKey); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func grpcStreamAuth(apiKey string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := grpcAuthorized(ss.Context(), apiKey); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// grpcUnaryStatus converts the errors of unary calls to gRPC statuses,
+// counting them in m.
+func grpcUnaryStatus(m *serverMetrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		m.recordError(err)
+		return resp, grpcStatus(err)
+	}
+}
+
+// grpcStreamStatus converts the errors of streaming calls to gRPC
+// statuses, counting them in m.
+func grpcStreamStatus(m *serverMetrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		m.recordError(err)
+		return grpcStatus(err)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeOrchestration struct {
+	pb.UnimplementedLabsTailwindOrchestrationServiceServer
+}
+
+func (fakeOrchestration) ListRecentlyViewedProjects(context.Context, *pb.ListRecentlyViewedProjectsRequest) (*pb.ListRecentlyViewedProjectsResponse, error) {
+	return &pb.ListRecentlyViewedProjectsResponse{Projects: []*pb.Project{{ProjectId: "nb1"}}}, nil
+}
+
+func (fakeOrchestration) GetProject(_ context.Context, req *pb.GetProjectRequest) (*pb.Project, error) {
+	return nil, fmt.Errorf("get project %s: %w", req.GetProjectId(), errNoNotebook)
+}
+
+func (fakeOrchestration) GenerateFreeFormStreamed(req *pb.GenerateFreeFormStreamedRequest, stream pb.LabsTailwindOrchestrationService_GenerateFreeFormStreamedServer) error {
+	return stream.Send(&pb.GenerateFreeFormStreamedResponse{Chunk: "answer to " + req.GetPrompt()})
+}
+
+func TestGRPCServer(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcUnaryAuth("secret"), grpcUnaryStatus(nil)),
+		grpc.ChainStreamInterceptor(grpcStreamAuth("secret"), grpcStreamStatus(nil)),
+	)
+	pb.RegisterLabsTailwindOrchestrationServiceServer(s, fakeOrchestration{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewLabsTailwindOrchestrationServiceClient(conn)
+	bg := context.Background()
+	authed := metadata.AppendToOutgoingContext(bg, "authorization", "Bearer secret")
+
+	if _, err := client.ListRecentlyViewedProjects(bg, &pb.ListRecentlyViewedProjectsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call without a key: %v, want Unauthenticated", err)
+	}
+	wrong := metadata.AppendToOutgoingContext(bg, "x-api-key", "wrong")
+	if _, err := client.ListRecentlyViewedProjects(wrong, &pb.ListRecentlyViewedProjectsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call with a wrong key: %v, want Unauthenticated", err)
+	}
+	resp, err := client.ListRecentlyViewedProjects(authed, &pb.ListRecentlyViewedProjectsRequest{})
+	if err != nil || len(resp.GetProjects()) != 1 {
+		t.Errorf("ListRecentlyViewedProjects() = %v, %v", resp, err)
+	}
+	if _, err := client.GetProject(authed, &pb.GetProjectRequest{ProjectId: "nope"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetProject() error = %v, want NotFound", err)
+	}
+	if _, err := client.CreateNote(authed, &pb.CreateNoteRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("CreateNote() error = %v, want Unimplemented", err)
+	}
+
+	stream, err := client.GenerateFreeFormStreamed(authed, &pb.GenerateFreeFormStreamedRequest{Prompt: "why"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg, err := stream.Recv(); err != nil || msg.GetChunk() != "answer to why" {
+		t.Errorf("stream.Recv() = %v, %v", msg, err)
+	}
+	stream, _ = client.GenerateFreeFormStreamed(bg, &pb.GenerateFreeFormStreamedRequest{Prompt: "why"})
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("stream without a key: %v, want Unauthenticated", err)
+	}
+}
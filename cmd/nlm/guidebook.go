@@ -0,0 +1,269 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// guidebookUsage lists the `nlm guidebook` subcommands.
+const guidebookUsage = "usage: nlm guidebook <ask|create-from|stats> ...\n"
+
+func validateGuidebookArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, guidebookUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	switch args[0] {
+	case "ask":
+		if len(args) < 3 {
+			fmt.Fprintf(os.Stderr, "usage: nlm guidebook ask <guidebook-id> <question>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+		return nil
+	case "create-from":
+		_, err := parseGuidebookCreateFlags(args[1:])
+		return err
+	case "stats":
+		_, err := parseGuidebookStatsFlags(args[1:])
+		return err
+	default:
+		fmt.Fprint(os.Stderr, guidebookUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+func runGuidebook(c *api.Client, args []string) error {
+	switch args[0] {
+	case "ask":
+		return guidebookAsk(c, args[1], strings.Join(args[2:], " "))
+	case "create-from":
+		opts, err := parseGuidebookCreateFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return guidebookCreateFrom(c, opts)
+	case "stats":
+		opts, err := parseGuidebookStatsFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		return guidebookStatsCmd(c, opts)
+	default:
+		fmt.Fprint(os.Stderr, guidebookUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+func guidebookAsk(c *api.Client, guidebookID, question string) error {
+	resp, err := c.AskGuidebookStream(guidebookID, question, func(chunk string) bool {
+		fmt.Print(chunk)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if resp.GetAnswer() == "" {
+		return fmt.Errorf("guidebook returned no answer")
+	}
+	fmt.Println()
+
+	if len(resp.GetSources()) > 0 {
+		fmt.Println("\nSources:")
+		for i, src := range resp.GetSources() {
+			title := src.GetTitle()
+			if title == "" {
+				title = src.GetSourceId()
+			}
+			fmt.Printf("  [%d] %s\n", i+1, title)
+			if excerpt := strings.TrimSpace(src.GetExcerpt()); excerpt != "" {
+				fmt.Printf("      %q\n", excerpt)
+			}
+		}
+	}
+	return nil
+}
+
+// guidebookCreateArgs contains the CLI options for `guidebook create-from`
+type guidebookCreateArgs struct {
+	NotebookID string
+	Options    api.PublishGuidebookOptions
+}
+
+func parseGuidebookCreateFlags(args []string) (*guidebookCreateArgs, error) {
+	var tags string
+	opts := &guidebookCreateArgs{}
+	fs := flag.NewFlagSet("guidebook create-from", flag.ContinueOnError)
+	fs.BoolVar(&opts.Options.Public, "public", false, "make the guidebook visible to anyone with the link")
+	fs.StringVar(&tags, "tags", "", "comma-separated tags for the guidebook")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm guidebook create-from <notebook-id> [-public] [-tags a,b]\n\n")
+		fmt.Fprintf(os.Stderr, "Publishes a notebook as a guidebook.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			opts.Options.Tags = append(opts.Options.Tags, tag)
+		}
+	}
+	return opts, nil
+}
+
+func guidebookCreateFrom(c *api.Client, opts *guidebookCreateArgs) error {
+	fmt.Fprintf(os.Stderr, "Publishing notebook %s as a guidebook...\n", opts.NotebookID)
+	resp, err := c.CreateGuidebookFromNotebook(opts.NotebookID, opts.Options)
+	if err != nil {
+		return err
+	}
+	guidebook := resp.GetGuidebook()
+	fmt.Printf("✅ Published guidebook: %s\n", guidebook.GetGuidebookId())
+	if guidebook.GetTitle() != "" {
+		fmt.Printf("  Title: %s\n", guidebook.GetTitle())
+	}
+	if resp.GetPublicUrl() != "" {
+		fmt.Printf("  URL: %s\n", resp.GetPublicUrl())
+	}
+	return nil
+}
+
+// guidebookStatsArgs contains the CLI options for `guidebook stats`
+type guidebookStatsArgs struct {
+	GuidebookID string
+	JSON        bool
+}
+
+func parseGuidebookStatsFlags(args []string) (*guidebookStatsArgs, error) {
+	opts := &guidebookStatsArgs{}
+	fs := flag.NewFlagSet("guidebook stats", flag.ContinueOnError)
+	fs.BoolVar(&opts.JSON, "json", false, "print the stats as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm guidebook stats <guidebook-id> [-json]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.GuidebookID = pos[0]
+	return opts, nil
+}
+
+// guidebookStats is the reader analytics of a published guidebook.
+type guidebookStats struct {
+	ID            string          `json:"id"`
+	Title         string          `json:"title,omitempty"`
+	Views         int32           `json:"views"`
+	Shares        int32           `json:"shares"`
+	LastViewed    *time.Time      `json:"last_viewed,omitempty"`
+	QuestionCount int32           `json:"question_count"`
+	Questions     []questionStats `json:"questions,omitempty"`
+}
+
+type questionStats struct {
+	Question  string     `json:"question"`
+	Count     int32      `json:"count"`
+	LastAsked *time.Time `json:"last_asked,omitempty"`
+}
+
+func newGuidebookStats(guidebookID string, details *pb.GuidebookDetails) guidebookStats {
+	a := details.GetAnalytics()
+	stats := guidebookStats{
+		ID:            guidebookID,
+		Title:         details.GetGuidebook().GetTitle(),
+		Views:         a.GetViewCount(),
+		Shares:        a.GetShareCount(),
+		LastViewed:    timeOrNil(a.GetLastViewed()),
+		QuestionCount: a.GetQuestionCount(),
+	}
+	for _, q := range a.GetQuestions() {
+		stats.Questions = append(stats.Questions, questionStats{
+			Question:  q.GetQuestion(),
+			Count:     q.GetCount(),
+			LastAsked: timeOrNil(q.GetLastAsked()),
+		})
+	}
+	if stats.QuestionCount == 0 {
+		for _, q := range stats.Questions {
+			stats.QuestionCount += q.Count
+		}
+	}
+	return stats
+}
+
+func timeOrNil(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func guidebookStatsCmd(c *api.Client, opts *guidebookStatsArgs) error {
+	details, err := c.GetGuidebookDetails(opts.GuidebookID)
+	if err != nil {
+		return err
+	}
+	stats := newGuidebookStats(opts.GuidebookID, details)
+	if opts.JSON {
+		return (&outputSpec{Format: "json"}).render(os.Stdout, stats, nil)
+	}
+	return render(stats, func(out io.Writer) error {
+		writeGuidebookStats(out, stats)
+		return nil
+	})
+}
+
+func writeGuidebookStats(out io.Writer, stats guidebookStats) {
+	if stats.Title != "" {
+		fmt.Fprintf(out, "Guidebook: %s (%s)\n", stats.Title, stats.ID)
+	} else {
+		fmt.Fprintf(out, "Guidebook: %s\n", stats.ID)
+	}
+	fmt.Fprintf(out, "Views:     %d\n", stats.Views)
+	fmt.Fprintf(out, "Shares:    %d\n", stats.Shares)
+	if stats.LastViewed != nil {
+		fmt.Fprintf(out, "Last view: %s\n", formatTime(*stats.LastViewed))
+	}
+	fmt.Fprintf(out, "Questions: %d\n", stats.QuestionCount)
+	if len(stats.Questions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	w := newTable(out, 2)
+	fmt.Fprintln(w, "COUNT\tLAST ASKED\tQUESTION")
+	for _, q := range stats.Questions {
+		last := "-"
+		if q.LastAsked != nil {
+			last = formatTime(*q.LastAsked)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", q.Count, last, q.Question)
+	}
+	w.Flush()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewGuidebookStats(t *testing.T) {
+	asked := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	details := &pb.GuidebookDetails{
+		Guidebook: &pb.Guidebook{GuidebookId: "gb1", Title: "Course Notes"},
+		Analytics: &pb.GuidebookAnalytics{
+			ViewCount:  120,
+			ShareCount: 4,
+			Questions: []*pb.GuidebookQuestionStat{
+				{Question: "When is the exam?", Count: 9, LastAsked: timestamppb.New(asked)},
+				{Question: "What is on it?", Count: 3},
+			},
+		},
+	}
+	got := newGuidebookStats("gb1", details)
+	want := guidebookStats{
+		ID:            "gb1",
+		Title:         "Course Notes",
+		Views:         120,
+		Shares:        4,
+		QuestionCount: 12,
+		Questions: []questionStats{
+			{Question: "When is the exam?", Count: 9, LastAsked: &asked},
+			{Question: "What is on it?", Count: 3},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newGuidebookStats() mismatch (-want +got):\n%s", diff)
+	}
+
+	var out strings.Builder
+	writeGuidebookStats(&out, got)
+	for _, s := range []string{"Guidebook: Course Notes (gb1)", "Views:     120", "Questions: 12", "COUNT", "When is the exam?"} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("writeGuidebookStats() output missing %q:\n%s", s, out.String())
+		}
+	}
+}
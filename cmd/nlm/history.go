@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/history"
+)
+
+// mutatingCommands are the commands that change notebooks and are recorded
+// in the history log. Commands with subcommands are handled in isMutating.
+var mutatingCommands = map[string]bool{
+	"create": true, "rm": true,
+	"add": true, "import": true, "rm-source": true, "rename-source": true, "refresh-source": true,
+	"new-note": true, "update-note": true, "rm-note": true,
+	"audio-create": true, "audio-rm": true, "audio-share": true, "audio-batch": true, "video-create": true,
+	"generate": true, "create-artifact": true, "rename-artifact": true, "delete-artifact": true,
+	"share-private": true,
+}
+
+// The notebook and the IDs created by the running command, noted for its
+// history entry.
+var (
+	historyNotebook string
+	historyIDs      []string
+)
+
+// noteNotebook records the notebook the running command acts on. The first
+// notebook noted wins.
+func noteNotebook(id string) {
+	if historyNotebook == "" {
+		historyNotebook = id
+	}
+}
+
+// noteAffected records IDs the running command created.
+func noteAffected(ids ...string) {
+	historyIDs = append(historyIDs, ids...)
+}
+
+// isMutating reports whether cmd with args changes a notebook.
+func isMutating(cmd string, args []string) bool {
+	if mutatingCommands[cmd] {
+		return true
+	}
+	if cmd == "retry" {
+		return !isLocalRetry(args)
+	}
+	if len(args) == 0 {
+		return false
+	}
+	switch cmd {
+	case "artifact":
+		switch args[0] {
+		case "create", "update", "refresh", "save-as-note", "rm":
+			return true
+		}
+	case "share":
+		return args[0] != "list"
+	case "guidebook":
+		return args[0] == "create-from"
+	}
+	return false
+}
+
+// recordHistory appends the outcome of a mutating command to the history
+// log. Dry runs, declined prompts and authentication failures changed
+// nothing and are not recorded. Failing to write the log is not fatal.
+func recordHistory(cmd string, args []string, cmdErr error) {
+	if !isMutating(cmd, args) || dryRun || errors.Is(cmdErr, errCancelled) || isAuthenticationError(cmdErr) {
+		return
+	}
+	e := history.Entry{
+		Command:    cmd,
+		NotebookID: historyNotebook,
+		IDs:        historyIDs,
+		Profile:    configProfile,
+		Result:     history.ResultOK,
+	}
+	for _, a := range args {
+		e.Args = append(e.Args, truncateArg(a))
+	}
+	if cmdErr != nil {
+		e.Result = history.ResultError
+		e.Error = cmdErr.Error()
+	}
+	log, err := history.OpenDefault()
+	if err == nil {
+		err = log.Append(e)
+	}
+	if err != nil {
+		verbosef(1, "nlm: warning: failed to record history: %v\n", err)
+	}
+}
+
+// truncateArg shortens long arguments, such as note contents, for the log.
+func truncateArg(s string) string {
+	const max = 80
+	if r := []rune(s); len(r) > max {
+		return string(r[:max]) + "..."
+	}
+	return s
+}
+
+// historyArgs contains the CLI options for `nlm history`.
+type historyArgs struct {
+	NotebookID string
+	Limit      int
+}
+
+func parseHistoryFlags(args []string) (*historyArgs, error) {
+	opts := &historyArgs{}
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.StringVar(&opts.NotebookID, "notebook", "", "only show changes to notebook `id` or alias")
+	fs.IntVar(&opts.Limit, "n", 0, "show only the last `count` entries")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm history [-notebook id] [-n count]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 0 || opts.Limit < 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+// runHistory lists the recorded changes, oldest first.
+func runHistory(args []string) error {
+	opts, err := parseHistoryFlags(args)
+	if err != nil {
+		return err
+	}
+	if id := lookupAlias(opts.NotebookID); id != "" {
+		opts.NotebookID = id
+	}
+	log, err := history.OpenDefault()
+	if err != nil {
+		return err
+	}
+	entries, err := log.List(opts.NotebookID)
+	if err != nil {
+		return err
+	}
+	if opts.Limit > 0 && len(entries) > opts.Limit {
+		entries = entries[len(entries)-opts.Limit:]
+	}
+	if entries == nil {
+		entries = []history.Entry{}
+	}
+	return render(entries, func(out io.Writer) error {
+		return writeHistory(out, entries)
+	})
+}
+
+// writeHistory prints history entries as a table.
+func writeHistory(out io.Writer, entries []history.Entry) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No history recorded.")
+		return nil
+	}
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "TIME\tCOMMAND\tNOTEBOOK\tRESULT\tARGS")
+	for _, e := range entries {
+		result := e.Result
+		if e.Error != "" {
+			result += ": " + truncateArg(e.Error)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			formatTime(e.Time),
+			e.Command,
+			e.NotebookID,
+			result,
+			strings.Join(e.Args, " "),
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tmc/nlm/internal/history"
+)
+
+func TestIsMutating(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		args []string
+		want bool
+	}{
+		{"rm-source", []string{"nb", "src"}, true},
+		{"sources", []string{"nb"}, false},
+		{"artifact", []string{"rm", "art"}, true},
+		{"artifact", []string{"cat", "nb", "art"}, false},
+		{"share", []string{"nb", "-link", "anyone"}, true},
+		{"share", []string{"list", "nb"}, false},
+		{"guidebook", []string{"create-from", "nb"}, true},
+		{"guidebook", nil, false},
+	}
+	for _, tt := range tests {
+		if got := isMutating(tt.cmd, tt.args); got != tt.want {
+			t.Errorf("isMutating(%q, %q) = %v, want %v", tt.cmd, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func() { historyNotebook, historyIDs = "", nil }()
+
+	historyNotebook, historyIDs = "nb1", []string{"src1"}
+	recordHistory("add", []string{"nb1", "paper.pdf"}, nil)
+	recordHistory("rm-note", []string{"nb1", "note1"}, errors.New("note not found"))
+	// Not recorded: read-only, declined and dry-run commands.
+	recordHistory("sources", []string{"nb1"}, nil)
+	recordHistory("rm", []string{"nb1"}, errCancelled)
+	dryRun = true
+	recordHistory("rm", []string{"nb1"}, nil)
+	dryRun = false
+
+	log, err := history.OpenDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := log.List("")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []history.Entry{
+		{Command: "add", Args: []string{"nb1", "paper.pdf"}, NotebookID: "nb1", IDs: []string{"src1"}, Result: history.ResultOK},
+		{Command: "rm-note", Args: []string{"nb1", "note1"}, NotebookID: "nb1", IDs: []string{"src1"}, Result: history.ResultError, Error: "note not found"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(history.Entry{}, "Time")); diff != "" {
+		t.Errorf("history mismatch (-want +got):\n%s", diff)
+	}
+}
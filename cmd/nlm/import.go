@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/retry"
+	"github.com/tmc/nlm/internal/zotero"
+)
+
+const importUsage = "usage: nlm import zotero [notebook-id] [-collection name] [-library path]\n"
+
+func validateImportArgs(args []string) error {
+	if len(args) == 0 || args[0] != "zotero" {
+		fmt.Fprint(os.Stderr, importUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	_, err := parseImportZoteroFlags(args[1:])
+	return err
+}
+
+func runImport(c *api.Client, args []string) error {
+	switch args[0] {
+	case "zotero":
+		return importZotero(c, args[1:])
+	default:
+		return fmt.Errorf("unknown import source %q", args[0])
+	}
+}
+
+// importZoteroArgs contains the CLI options for `nlm import zotero`.
+type importZoteroArgs struct {
+	NotebookID string
+	Collection string
+	Library    string
+}
+
+func parseImportZoteroFlags(args []string) (*importZoteroArgs, error) {
+	opts := &importZoteroArgs{}
+	library, _ := zotero.DefaultPath()
+	fs := flag.NewFlagSet("import zotero", flag.ContinueOnError)
+	fs.StringVar(&opts.Collection, "collection", "", "import only this collection and its subcollections")
+	fs.StringVar(&opts.Library, "library", library, "Zotero database or BibTeX export (`path` to zotero.sqlite or a .bib file)")
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, importUsage, "\n")
+		fmt.Fprintf(os.Stderr, "Uploads the PDFs attached to the items of a local Zotero library as\n")
+		fmt.Fprintf(os.Stderr, "sources titled \"Title (Author et al., Year)\". Items without a PDF are\n")
+		fmt.Fprintf(os.Stderr, "added by URL or DOI, and items already in the notebook are skipped, so\n")
+		fmt.Fprintf(os.Stderr, "the import can be run again as the collection grows.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 || opts.Library == "" {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+	return opts, nil
+}
+
+// zoteroSource is a source to add for a Zotero item.
+type zoteroSource struct {
+	Title string
+	PDF   string
+	URL   string
+}
+
+// zoteroSources returns the sources for an item: each of its PDFs, or
+// else its URL or DOI.
+func zoteroSources(it *zotero.Item) []zoteroSource {
+	title := it.SourceTitle()
+	var sources []zoteroSource
+	for i, pdf := range it.PDFs {
+		s := zoteroSource{Title: title, PDF: pdf}
+		if i > 0 {
+			s.Title = fmt.Sprintf("%s [%s]", title, filepath.Base(pdf))
+		}
+		sources = append(sources, s)
+	}
+	switch {
+	case len(sources) > 0:
+	case it.URL != "":
+		sources = append(sources, zoteroSource{Title: title, URL: it.URL})
+	case it.DOI != "":
+		sources = append(sources, zoteroSource{Title: title, URL: "https://doi.org/" + it.DOI})
+	}
+	return sources
+}
+
+func importZotero(c *api.Client, args []string) error {
+	opts, err := parseImportZoteroFlags(args)
+	if err != nil {
+		return err
+	}
+	items, err := zotero.Read(opts.Library, opts.Collection)
+	if err != nil {
+		return err
+	}
+	id, err := resolveNotebook(c, opts.NotebookID)
+	if err != nil {
+		return err
+	}
+	p, err := c.GetProject(id)
+	if err != nil {
+		return fmt.Errorf("get notebook: %w", err)
+	}
+	existing := make(map[string]bool)
+	for _, src := range p.Sources {
+		existing[strings.TrimSpace(src.Title)] = true
+	}
+
+	var added, skipped int
+	var failed []retry.Item
+	for i := range items {
+		it := &items[i]
+		sources := zoteroSources(it)
+		if len(sources) == 0 {
+			fmt.Fprintf(os.Stderr, "nlm: warning: %s has no PDF, URL or DOI; skipped\n", it.SourceTitle())
+			skipped++
+			continue
+		}
+		for _, s := range sources {
+			if existing[s.Title] {
+				statusf("Skipping %s (already in the notebook)\n", s.Title)
+				skipped++
+				continue
+			}
+			if dryRun {
+				fmt.Fprintf(os.Stderr, "Would add %s\n", s.Title)
+				continue
+			}
+			srcID, err := addZoteroSource(c, id, s)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "nlm: %s: %v\n", s.Title, err)
+				input := s.PDF
+				if input == "" {
+					input = s.URL
+				}
+				failed = append(failed, retry.Item{Op: retry.OpZotero, NotebookID: id, Input: input, Title: s.Title, Error: err.Error()})
+				continue
+			}
+			noteAffected(srcID)
+			existing[s.Title] = true
+			fmt.Println(srcID)
+			added++
+		}
+	}
+	if dryRun {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "✅ Imported %d sources from %d Zotero items (%d skipped, %d failed)\n", added, len(items), skipped, len(failed))
+	if len(failed) > 0 {
+		queueFailed(failed)
+		return fmt.Errorf("%d sources failed to import", len(failed))
+	}
+	return nil
+}
+
+// addZoteroSource adds s to a notebook and titles it from the item's
+// metadata.
+func addZoteroSource(c *api.Client, notebookID string, s zoteroSource) (string, error) {
+	var id string
+	if s.PDF != "" {
+		fi, err := os.Stat(s.PDF)
+		if err != nil {
+			return "", fmt.Errorf("attachment missing: %w", err)
+		}
+		sp := startSpinner("Uploading %s (%s)", s.Title, formatSize(fi.Size()))
+		id, err = c.AddSourceFromFile(notebookID, s.PDF, "application/pdf")
+		sp.Stop()
+		if err != nil {
+			return "", err
+		}
+	} else {
+		sp := startSpinner("Adding %s", s.URL)
+		var err error
+		id, err = c.AddSourceFromURL(notebookID, s.URL)
+		sp.Stop()
+		if err != nil {
+			return "", err
+		}
+	}
+	if _, err := c.MutateSource(id, &pb.Source{Title: s.Title}); err != nil {
+		// The source is added; only its title is off.
+		fmt.Fprintf(os.Stderr, "nlm: warning: rename %s to %q: %v\n", id, s.Title, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tmc/nlm/internal/zotero"
+)
+
+func TestZoteroSources(t *testing.T) {
+	tests := []struct {
+		item zotero.Item
+		want []zoteroSource
+	}{
+		{
+			zotero.Item{Title: "Paper", Year: "2020", URL: "https://example.com", PDFs: []string{"/a/main.pdf", "/a/supplement.pdf"}},
+			[]zoteroSource{{Title: "Paper (2020)", PDF: "/a/main.pdf"}, {Title: "Paper (2020) [supplement.pdf]", PDF: "/a/supplement.pdf"}},
+		},
+		{
+			zotero.Item{Title: "Page", URL: "https://example.com/page", DOI: "10.1/x"},
+			[]zoteroSource{{Title: "Page", URL: "https://example.com/page"}},
+		},
+		{
+			zotero.Item{Title: "Article", DOI: "10.1000/182"},
+			[]zoteroSource{{Title: "Article", URL: "https://doi.org/10.1000/182"}},
+		},
+		{zotero.Item{Title: "Note"}, nil},
+	}
+	for _, tt := range tests {
+		if got := zoteroSources(&tt.item); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("zoteroSources(%+v) = %+v, want %+v", tt.item, got, tt.want)
+		}
+	}
+}
+
+func TestParseImportZoteroFlags(t *testing.T) {
+	t.Setenv("NLM_NOTEBOOK", "")
+	opts, err := parseImportZoteroFlags([]string{"nb1", "-collection", "PhD", "-library", "lib.bib"})
+	if err != nil || opts.NotebookID != "nb1" || opts.Collection != "PhD" || opts.Library != "lib.bib" {
+		t.Errorf("parseImportZoteroFlags = %+v, %v", opts, err)
+	}
+	if _, err := parseImportZoteroFlags([]string{"-collection", "PhD"}); err == nil {
+		t.Error("parseImportZoteroFlags without a notebook succeeded")
+	}
+}
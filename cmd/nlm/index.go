@@ -0,0 +1,465 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/index"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// indexArgs contains the CLI options for `nlm index`.
+type indexArgs struct {
+	NotebookIDs []string
+	Text        bool
+	Concurrency int
+}
+
+func parseIndexFlags(args []string) (*indexArgs, error) {
+	opts := &indexArgs{}
+	fs := flag.NewFlagSet("index", flag.ContinueOnError)
+	fs.BoolVar(&opts.Text, "text", true, "fetch the text of report and note artifacts")
+	fs.IntVar(&opts.Concurrency, "concurrency", defaultConcurrency, "number of notebooks to index at once")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm index [notebook-id...] [-text=false] [-concurrency n]\n\n")
+		fmt.Fprintf(os.Stderr, "Mirrors notebooks, source metadata, notes and artifact text into the local\n")
+		fmt.Fprintf(os.Stderr, "index used by 'nlm search' and -offline, and prints what changed since the\n")
+		fmt.Fprintf(os.Stderr, "last run. Without notebooks the whole account is indexed. A notebook that\n")
+		fmt.Fprintf(os.Stderr, "fails does not stop the others; the failures are reported at the end.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if opts.Concurrency < 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookIDs = pos
+	return opts, nil
+}
+
+func runIndex(c *api.Client, args []string) error {
+	opts, err := parseIndexFlags(args)
+	if err != nil {
+		return err
+	}
+	ix, err := index.OpenDefault()
+	if err != nil {
+		return err
+	}
+	defer ix.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var projects []*api.Notebook
+	var changes []index.Change
+	if len(opts.NotebookIDs) == 0 {
+		if projects, err = c.ListRecentlyViewedProjects(); err != nil {
+			return fmt.Errorf("list notebooks: %w", err)
+		}
+		ids := make([]string, len(projects))
+		for i, p := range projects {
+			ids[i] = p.ProjectId
+		}
+		if changes, err = ix.Prune(ids); err != nil {
+			return err
+		}
+	} else {
+		ids := make([]string, len(opts.NotebookIDs))
+		for i, ref := range opts.NotebookIDs {
+			if ids[i], err = resolveNotebook(c, ref); err != nil {
+				return err
+			}
+		}
+		projects = make([]*api.Notebook, len(ids))
+		err := fanOut(ctx, ids, opts.Concurrency, func(i int, id string) error {
+			p, err := c.GetProject(id)
+			if err != nil {
+				return fmt.Errorf("get notebook: %w", err)
+			}
+			projects[i] = p
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Notebooks are indexed in any order, but their changes are printed
+	// in the order of the listing.
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ProjectId
+	}
+	perNotebook := make([][]index.Change, len(projects))
+	var mu sync.Mutex
+	started := 0
+	indexErr := fanOut(ctx, ids, opts.Concurrency, func(i int, id string) error {
+		p := projects[i]
+		mu.Lock()
+		started++
+		statusf("Indexing %s (%d/%d)...\n", strings.TrimSpace(p.Title), started, len(projects))
+		mu.Unlock()
+		nbChanges, err := ix.PutNotebook(indexNotebook(p))
+		if err != nil {
+			return err
+		}
+		contentChanges, err := indexContents(c, ix, id, opts.Text)
+		if err != nil {
+			return err
+		}
+		perNotebook[i] = append(nbChanges, contentChanges...)
+		return nil
+	})
+	if ctx.Err() != nil {
+		return indexErr
+	}
+	for _, nbChanges := range perNotebook {
+		changes = append(changes, nbChanges...)
+	}
+	if changes == nil {
+		changes = []index.Change{}
+	}
+	if err := render(changes, func(out io.Writer) error {
+		return writeChanges(out, changes)
+	}); err != nil {
+		return err
+	}
+	indexed := len(projects)
+	var fe *fanOutError
+	if errors.As(indexErr, &fe) {
+		indexed -= len(fe.errs)
+	}
+	statusf("Indexed %d notebooks, %d changes\n", indexed, len(changes))
+	return indexErr
+}
+
+// indexContents indexes the notes and artifacts of a notebook. The text of
+// artifacts that have not changed since they were last indexed is kept
+// rather than fetched again.
+func indexContents(c *api.Client, ix *index.Index, notebookID string, withText bool) ([]index.Change, error) {
+	notes, err := c.GetNotes(notebookID)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	artifacts, err := c.ListArtifacts(notebookID)
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts: %w", err)
+	}
+	indexed, err := ix.Artifacts(notebookID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]index.Artifact, len(indexed))
+	for _, a := range indexed {
+		known[a.ID] = a
+	}
+
+	var inotes []index.Note
+	for _, n := range notes {
+		inotes = append(inotes, index.Note{
+			ID:         n.GetSourceId().GetSourceId(),
+			Title:      strings.TrimSpace(n.Title),
+			ModifiedAt: asTime(n.GetMetadata().GetLastModifiedTime()),
+		})
+	}
+	var iartifacts []index.Artifact
+	for _, a := range artifacts {
+		ia := index.Artifact{ID: a.ID, Title: a.Title, Type: a.TypeName(), State: a.StateName(), UpdatedAt: a.UpdatedAt}
+		if withText && textArtifactTypes[a.Type] && a.State == pb.ArtifactState_ARTIFACT_STATE_READY {
+			if prev, ok := known[a.ID]; ok && prev.Text != "" && !a.UpdatedAt.IsZero() && prev.UpdatedAt.Equal(a.UpdatedAt) {
+				ia.Text = prev.Text
+			} else if content, err := c.GetArtifactContent(notebookID, a.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "nlm: warning: skipping the text of %s: %v\n", a.ID, err)
+			} else {
+				ia.Text = content.Markdown
+			}
+		}
+		iartifacts = append(iartifacts, ia)
+	}
+	return ix.PutContents(notebookID, inotes, iartifacts)
+}
+
+// writeChanges prints index changes as a table.
+func writeChanges(out io.Writer, changes []index.Change) error {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No changes.")
+		return nil
+	}
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "CHANGE\tKIND\tNOTEBOOK\tID\tTITLE")
+	for _, c := range changes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Op, c.Kind, c.NotebookID, c.ID, c.Title)
+	}
+	return w.Flush()
+}
+
+// indexNotebook converts a notebook for the index.
+func indexNotebook(p *pb.Project) index.Notebook {
+	nb := index.Notebook{
+		ID:         p.ProjectId,
+		Title:      strings.TrimSpace(p.Title),
+		Emoji:      strings.TrimSpace(p.Emoji),
+		CreatedAt:  asTime(p.GetMetadata().GetCreateTime()),
+		ModifiedAt: asTime(p.GetMetadata().GetModifiedTime()),
+	}
+	for _, src := range p.Sources {
+		nb.Sources = append(nb.Sources, index.Source{
+			ID:         src.GetSourceId().GetSourceId(),
+			Title:      strings.TrimSpace(src.Title),
+			Type:       src.GetMetadata().GetSourceType().String(),
+			Status:     src.GetMetadata().GetStatus().String(),
+			URL:        src.GetMetadata().GetYoutube().GetYoutubeUrl(),
+			ModifiedAt: asTime(src.GetMetadata().GetLastModifiedTime()),
+		})
+	}
+	return nb
+}
+
+// indexedProject converts an indexed notebook back to the form the listing
+// commands print.
+func indexedProject(nb index.Notebook) *pb.Project {
+	p := &pb.Project{
+		ProjectId: nb.ID,
+		Title:     nb.Title,
+		Emoji:     nb.Emoji,
+		Metadata:  &pb.ProjectMetadata{CreateTime: asTimestamp(nb.CreatedAt), ModifiedTime: asTimestamp(nb.ModifiedAt)},
+	}
+	for _, s := range nb.Sources {
+		md := &pb.SourceMetadata{
+			SourceType:       pb.SourceType(pb.SourceType_value[s.Type]),
+			Status:           pb.SourceSettings_SourceStatus(pb.SourceSettings_SourceStatus_value[s.Status]),
+			LastModifiedTime: asTimestamp(s.ModifiedAt),
+		}
+		if s.URL != "" {
+			md.MetadataType = &pb.SourceMetadata_Youtube{Youtube: &pb.YoutubeSourceMetadata{YoutubeUrl: s.URL}}
+		}
+		p.Sources = append(p.Sources, &pb.Source{SourceId: &pb.SourceId{SourceId: s.ID}, Title: s.Title, Metadata: md})
+	}
+	return p
+}
+
+func asTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func asTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// searchArgs contains the CLI options for `nlm search`.
+type searchArgs struct {
+	Query      string
+	NotebookID string
+	Limit      int
+}
+
+func parseSearchFlags(args []string) (*searchArgs, error) {
+	opts := &searchArgs{}
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	fs.StringVar(&opts.NotebookID, "notebook", "", "only search notebook `id` or alias")
+	fs.IntVar(&opts.Limit, "n", 20, "show at most `count` results (0 for all)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm search <query> [-notebook id] [-n count]\n\n")
+		fmt.Fprintf(os.Stderr, "Searches the titles of notebooks, sources and notes and the text of\n")
+		fmt.Fprintf(os.Stderr, "artifacts in the local index, without the network. Results contain every\n")
+		fmt.Fprintf(os.Stderr, "word of the query; end a word with * to match prefixes. Run 'nlm index'\n")
+		fmt.Fprintf(os.Stderr, "to build and refresh the index.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.Query = strings.TrimSpace(strings.Join(pos, " "))
+	if opts.Query == "" || opts.Limit < 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+// runSearch searches the local index.
+func runSearch(args []string) error {
+	opts, err := parseSearchFlags(args)
+	if err != nil {
+		return err
+	}
+	ix, err := openIndex()
+	if err != nil {
+		return err
+	}
+	defer ix.Close()
+	if opts.NotebookID != "" {
+		if opts.NotebookID, err = indexedNotebook(ix, opts.NotebookID); err != nil {
+			return err
+		}
+	}
+	results, err := ix.Search(opts.Query, opts.NotebookID, opts.Limit)
+	if err != nil {
+		return err
+	}
+	if results == nil {
+		results = []index.Result{}
+	}
+	return render(results, func(out io.Writer) error {
+		return writeSearchResults(out, results)
+	})
+}
+
+// writeSearchResults prints search results as a table.
+func writeSearchResults(out io.Writer, results []index.Result) error {
+	if len(results) == 0 {
+		fmt.Fprintln(out, "No matches.")
+		return nil
+	}
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "NOTEBOOK\tKIND\tID\tTITLE\tMATCH")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			truncateArg(r.NotebookTitle), r.Kind, r.ID, truncateArg(r.Title),
+			truncateArg(strings.Join(strings.Fields(r.Snippet), " ")))
+	}
+	return w.Flush()
+}
+
+// errNoIndex is returned when the index has not been built.
+var errNoIndex = fmt.Errorf("nothing is %w yet; run 'nlm index' first", index.ErrNotIndexed)
+
+// openIndex opens the index for reading, without creating it.
+func openIndex() (*index.Index, error) {
+	path, err := index.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, errNoIndex
+	}
+	return index.Open(path)
+}
+
+// indexedNotebook resolves a notebook reference against the index.
+func indexedNotebook(ix *index.Index, ref string) (string, error) {
+	if id := lookupAlias(ref); id != "" {
+		return id, nil
+	}
+	if notebookIDPattern.MatchString(ref) {
+		return ref, nil
+	}
+	nbs, err := ix.Notebooks()
+	if err != nil {
+		return "", err
+	}
+	var projects []*api.Notebook
+	for _, nb := range nbs {
+		projects = append(projects, indexedProject(nb))
+	}
+	return matchNotebook(projects, ref)
+}
+
+// runOfflineIndexed answers offline commands from the index when the cache
+// has nothing. It fails with index.ErrNotIndexed if the index cannot help.
+func runOfflineIndexed(cmd string, args []string) error {
+	ix, err := openIndex()
+	if err != nil {
+		return err
+	}
+	defer ix.Close()
+	indexedAt := func(what string, t time.Time) {
+		statusf("nlm: offline: showing %s indexed %s\n", what, formatTime(t))
+	}
+	switch cmd {
+	case "list", "ls":
+		nbs, err := ix.Notebooks()
+		if err != nil {
+			return err
+		}
+		if len(nbs) == 0 {
+			return errNoIndex
+		}
+		var notebooks []*api.Notebook
+		var synced time.Time
+		for _, nb := range nbs {
+			notebooks = append(notebooks, indexedProject(nb))
+			if nb.SyncedAt.After(synced) {
+				synced = nb.SyncedAt
+			}
+		}
+		indexedAt("notebooks", synced)
+		return render(notebooks, func(out io.Writer) error {
+			return writeNotebooks(out, notebooks)
+		})
+	case "sources", "notes":
+		id, err := indexedNotebook(ix, args[0])
+		if err != nil {
+			return err
+		}
+		nb, err := ix.Notebook(id)
+		if err != nil {
+			return err
+		}
+		indexedAt(cmd+" of notebook "+id, nb.SyncedAt)
+		if cmd == "sources" {
+			p := indexedProject(*nb)
+			return render(p.Sources, func(out io.Writer) error {
+				return writeSources(out, p.Sources)
+			})
+		}
+		list, err := ix.Notes(id)
+		if err != nil {
+			return err
+		}
+		notes := make([]*pb.Source, 0, len(list))
+		for _, n := range list {
+			notes = append(notes, &pb.Source{
+				SourceId: &pb.SourceId{SourceId: n.ID},
+				Title:    n.Title,
+				Metadata: &pb.SourceMetadata{LastModifiedTime: asTimestamp(n.ModifiedAt)},
+			})
+		}
+		return render(notes, func(out io.Writer) error {
+			return writeNotes(out, notes)
+		})
+	case "artifact":
+		opts, err := parseArtifactCatFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = indexedNotebook(ix, opts.NotebookID); err != nil {
+			return err
+		}
+		a, err := ix.Artifact(opts.NotebookID, opts.ArtifactID)
+		if err != nil {
+			return err
+		}
+		if a.Text == "" {
+			return fmt.Errorf("text of artifact %s %w", a.ID, index.ErrNotIndexed)
+		}
+		if nb, err := ix.Notebook(opts.NotebookID); err == nil {
+			indexedAt("artifact "+a.ID, nb.SyncedAt)
+		}
+		return writeArtifactContent(opts, a.ID, a.Text)
+	}
+	return index.ErrNotIndexed
+}
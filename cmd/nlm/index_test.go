@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/index"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestIndexedProjectRoundTrip(t *testing.T) {
+	when := timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	p := &pb.Project{
+		ProjectId: "nb1",
+		Title:     "Physics",
+		Emoji:     "🔭",
+		Metadata:  &pb.ProjectMetadata{CreateTime: when, ModifiedTime: when},
+		Sources: []*pb.Source{
+			{SourceId: &pb.SourceId{SourceId: "s1"}, Title: "Paper", Metadata: &pb.SourceMetadata{
+				SourceType: pb.SourceType_SOURCE_TYPE_LOCAL_FILE, LastModifiedTime: when,
+			}},
+			{SourceId: &pb.SourceId{SourceId: "s2"}, Title: "Talk", Metadata: &pb.SourceMetadata{
+				SourceType:   pb.SourceType_SOURCE_TYPE_YOUTUBE_VIDEO,
+				MetadataType: &pb.SourceMetadata_Youtube{Youtube: &pb.YoutubeSourceMetadata{YoutubeUrl: "https://youtube.com/watch?v=x"}},
+			}},
+		},
+	}
+	if diff := cmp.Diff(p, indexedProject(indexNotebook(p)), protocmp.Transform()); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunOfflineIndexed(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := runOfflineIndexed("list", nil); !errors.Is(err, index.ErrNotIndexed) {
+		t.Fatalf("without an index: err = %v, want ErrNotIndexed", err)
+	}
+
+	ix, err := index.OpenDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ix.PutNotebook(index.Notebook{ID: "nb1", Title: "Physics"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ix.PutContents("nb1", nil, []index.Artifact{{ID: "a1", Title: "Audio"}}); err != nil {
+		t.Fatal(err)
+	}
+	ix.Close()
+
+	if err := runOfflineIndexed("artifact", []string{"cat", "nb1", "a1"}); !errors.Is(err, index.ErrNotIndexed) {
+		t.Errorf("artifact without text: err = %v, want ErrNotIndexed", err)
+	}
+	if err := runOfflineIndexed("sources", []string{"nb2"}); !errors.Is(err, errNoNotebook) {
+		t.Errorf("unknown notebook: err = %v, want errNoNotebook", err)
+	}
+	if err := runOfflineIndexed("audio-list", []string{"nb1"}); !errors.Is(err, index.ErrNotIndexed) {
+		t.Errorf("audio-list: err = %v, want ErrNotIndexed", err)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/auth"
+	"github.com/tmc/nlm/internal/config"
+)
+
+// initArgs contains the CLI options for `nlm init`.
+type initArgs struct {
+	Profile  string
+	SkipAuth bool
+}
+
+func parseInitFlags(args []string) (*initArgs, error) {
+	opts := &initArgs{}
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.StringVar(&opts.Profile, "profile", "", "config profile to write (default: the active profile)")
+	fs.BoolVar(&opts.SkipAuth, "skip-auth", false, "do not offer to sign in")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm init [-profile name] [-skip-auth]\n\n")
+		fmt.Fprintf(os.Stderr, "Walks through choosing a browser profile, signing in, picking a default\n")
+		fmt.Fprintf(os.Stderr, "notebook and output preferences, and writes them to the config file.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+// initSettings are the answers collected by the setup wizard.
+type initSettings struct {
+	BrowserProfile string
+	Notebook       string
+	Output         string
+	Language       string
+}
+
+// initWizard asks the setup questions. The browser, sign-in and notebook
+// steps are functions so the questions can be exercised without them.
+type initWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	skipAuth  bool
+	signedIn  bool
+	eof       bool // input ended; remaining questions take their defaults
+	profiles  func() ([]auth.ProfileInfo, error)
+	login     func(browserProfile string) (token, cookies string, err error)
+	notebooks func(token, cookies string) ([]*api.Notebook, error)
+}
+
+// runInit runs the setup wizard and saves the answers in a config profile.
+func runInit(args []string) error {
+	opts, err := parseInitFlags(args)
+	if err != nil {
+		return err
+	}
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	name := opts.Profile
+	if name == "" {
+		name = cfg.ActiveName(configProfile)
+	}
+	current := initSettings{BrowserProfile: os.Getenv("NLM_BROWSER_PROFILE"), Output: "table"}
+	if p := cfg.Lookup(name); p != nil {
+		current = initSettings{BrowserProfile: p.BrowserProfile, Notebook: p.Notebook, Output: p.Output, Language: p.Language}
+		if current.BrowserProfile == "" {
+			current.BrowserProfile = os.Getenv("NLM_BROWSER_PROFILE")
+		}
+	}
+
+	w := &initWizard{
+		in:       bufio.NewReader(os.Stdin),
+		out:      os.Stdout,
+		skipAuth: opts.SkipAuth,
+		signedIn: authToken != "" && cookies != "",
+		profiles: auth.ListProfiles,
+		login: func(browserProfile string) (string, string, error) {
+			return handleAuth([]string{"login", "-profile", browserProfile}, debug)
+		},
+		notebooks: func(token, cookies string) ([]*api.Notebook, error) {
+			c, err := api.New(context.Background(), api.WithAuth(token, cookies), api.WithBatchExecuteOptions(retryOptions()...))
+			if err != nil {
+				return nil, err
+			}
+			return c.ListRecentlyViewedProjects()
+		},
+	}
+	fmt.Fprintf(w.out, "This sets up nlm and saves your choices to profile %q in %s.\n", name, path)
+	fmt.Fprintf(w.out, "Press Enter to keep the value in brackets.\n")
+	s := w.run(current, authToken, cookies)
+	err = config.Update(path, func(cfg *config.Config) error {
+		p := cfg.Ensure(name)
+		p.BrowserProfile = s.BrowserProfile
+		p.Notebook = s.Notebook
+		p.Output = s.Output
+		p.Language = s.Language
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w.out, "\n✅ Saved profile %q to %s\n", name, path)
+	fmt.Fprintf(w.out, "Try 'nlm list' to see your notebooks, or 'nlm config list' to review the settings.\n")
+	return nil
+}
+
+// run asks each question in turn, starting from the current settings.
+func (w *initWizard) run(s initSettings, token, cookies string) initSettings {
+	fmt.Fprintf(w.out, "\n1. Browser profile\n")
+	s.BrowserProfile = w.chooseBrowserProfile(s.BrowserProfile)
+
+	fmt.Fprintf(w.out, "\n2. Sign in\n")
+	switch {
+	case w.skipAuth:
+		fmt.Fprintf(w.out, "Skipped; run 'nlm auth' later to sign in.\n")
+	case w.signedIn && !w.confirm("You are already signed in. Sign in again?", false):
+	case !w.signedIn && !w.confirm("Open the browser to sign in to NotebookLM now?", true):
+		fmt.Fprintf(w.out, "Run 'nlm auth' later to sign in.\n")
+	default:
+		t, c, err := w.login(s.BrowserProfile)
+		if err != nil {
+			fmt.Fprintf(w.out, "Sign-in failed: %v\nRun 'nlm auth' to try again.\n", err)
+			break
+		}
+		token, cookies = t, c
+	}
+
+	fmt.Fprintf(w.out, "\n3. Default notebook\n")
+	s.Notebook = w.chooseNotebook(s.Notebook, token, cookies)
+
+	fmt.Fprintf(w.out, "\n4. Output\n")
+	for {
+		s.Output = w.ask("Format for listings (table, json, yaml)", s.Output)
+		if _, err := parseOutput(s.Output); err == nil {
+			break
+		}
+		if w.eof {
+			s.Output = "table"
+			break
+		}
+		fmt.Fprintf(w.out, "Please enter table, json, yaml or template=<go-template>.\n")
+	}
+	s.Language = w.ask("Language for generated content, e.g. en or de (- for NotebookLM's default)", s.Language)
+	if s.Language == "-" {
+		s.Language = ""
+	}
+	return s
+}
+
+// chooseBrowserProfile lists the browser profiles found on this machine
+// and asks for one by number or name.
+func (w *initWizard) chooseBrowserProfile(current string) string {
+	profiles, err := w.profiles()
+	if err != nil || len(profiles) == 0 {
+		fmt.Fprintf(w.out, "No Chrome, Chrome Canary or Brave profiles were found.\n")
+		if current == "" {
+			current = "Default"
+		}
+		return w.ask("Chrome profile name", current)
+	}
+	def := 1
+	for i, p := range profiles {
+		note := ""
+		if p.HasTargetCookies {
+			note = "  (signed in to NotebookLM)"
+		}
+		fmt.Fprintf(w.out, "  %d) %s: %s%s\n", i+1, p.Browser, p.Name, note)
+		if p.Name == current {
+			def = i + 1
+		}
+	}
+	for {
+		answer := w.ask("Browser profile (number or name)", strconv.Itoa(def))
+		n, err := strconv.Atoi(answer)
+		if err != nil {
+			return answer
+		}
+		if n >= 1 && n <= len(profiles) {
+			return profiles[n-1].Name
+		}
+		fmt.Fprintf(w.out, "Please enter a number from 1 to %d.\n", len(profiles))
+	}
+}
+
+// chooseNotebook lists the account's notebooks, when signed in, and asks
+// for the notebook commands use when none is given.
+func (w *initWizard) chooseNotebook(current, token, cookies string) string {
+	const shown = 10
+	var notebooks []*api.Notebook
+	if token != "" && cookies != "" {
+		var err error
+		if notebooks, err = w.notebooks(token, cookies); err != nil {
+			fmt.Fprintf(w.out, "Could not list notebooks: %v\n", err)
+		}
+	}
+	if len(notebooks) > shown {
+		notebooks = notebooks[:shown]
+	}
+	for i, nb := range notebooks {
+		fmt.Fprintf(w.out, "  %d) %s  %s\n", i+1, nb.GetProjectId(), strings.TrimSpace(nb.GetTitle()))
+	}
+	prompt := "Notebook ID (- for none)"
+	if len(notebooks) > 0 {
+		prompt = "Notebook (number or ID, - for none)"
+	}
+	for {
+		answer := w.ask(prompt, current)
+		n, err := strconv.Atoi(answer)
+		switch {
+		case answer == "" || answer == "-":
+			return ""
+		case err != nil || w.eof:
+			return answer
+		case n >= 1 && n <= len(notebooks):
+			return notebooks[n-1].GetProjectId()
+		}
+		fmt.Fprintf(w.out, "Please enter a number from the list or a notebook ID.\n")
+	}
+}
+
+// ask prints a question and returns the answer, or def if it is blank or
+// input has ended.
+func (w *initWizard) ask(question, def string) string {
+	if def != "" {
+		fmt.Fprintf(w.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(w.out, "%s: ", question)
+	}
+	line, err := w.in.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if err != nil {
+		w.eof = true
+		if line == "" {
+			fmt.Fprintln(w.out)
+		}
+	}
+	if line == "" {
+		return def
+	}
+	return line
+}
+
+// confirm asks a yes or no question. Once input has ended the answer is
+// no, so a script never opens a browser by accident.
+func (w *initWizard) confirm(question string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	fmt.Fprintf(w.out, "%s [%s] ", question, hint)
+	line, err := w.in.ReadString('\n')
+	if err != nil && strings.TrimSpace(line) == "" {
+		w.eof = true
+		fmt.Fprintln(w.out)
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "":
+		return def
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/auth"
+)
+
+func TestInitWizard(t *testing.T) {
+	notebooks := []*api.Notebook{
+		{ProjectId: "11111111-2222-3333-4444-555555555555", Title: "Research"},
+		{ProjectId: "66666666-7777-8888-9999-000000000000", Title: "Reading"},
+	}
+	newWizard := func(input string) (*initWizard, *[]string) {
+		var logins []string
+		return &initWizard{
+			in:  bufio.NewReader(strings.NewReader(input)),
+			out: io.Discard,
+			profiles: func() ([]auth.ProfileInfo, error) {
+				return []auth.ProfileInfo{
+					{Browser: "Chrome", Name: "Default"},
+					{Browser: "Chrome", Name: "Profile 1", HasTargetCookies: true},
+				}, nil
+			},
+			login: func(browserProfile string) (string, string, error) {
+				logins = append(logins, browserProfile)
+				return "token", "cookies", nil
+			},
+			notebooks: func(token, cookies string) ([]*api.Notebook, error) {
+				if token != "token" {
+					return nil, errors.New("not signed in")
+				}
+				return notebooks, nil
+			},
+		}, &logins
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		current    initSettings
+		want       initSettings
+		wantLogins []string
+	}{
+		{
+			name:       "answers",
+			input:      "2\n\n9\n2\nyaml\nde\n",
+			current:    initSettings{Output: "table"},
+			want:       initSettings{BrowserProfile: "Profile 1", Notebook: notebooks[1].ProjectId, Output: "yaml", Language: "de"},
+			wantLogins: []string{"Profile 1"},
+		},
+		{
+			name:    "defaults kept",
+			input:   "\nn\n\n\n\n",
+			current: initSettings{BrowserProfile: "Profile 1", Notebook: "nb", Output: "json", Language: "en"},
+			want:    initSettings{BrowserProfile: "Profile 1", Notebook: "nb", Output: "json", Language: "en"},
+		},
+		{
+			name:    "cleared and invalid output retried",
+			input:   "Work\nn\n-\nxml\ntable\n-\n",
+			current: initSettings{Notebook: "nb", Output: "json", Language: "en"},
+			want:    initSettings{BrowserProfile: "Work", Output: "table"},
+		},
+		{
+			name:    "input ends",
+			input:   "",
+			current: initSettings{Output: "table"},
+			want:    initSettings{BrowserProfile: "Default", Output: "table"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, logins := newWizard(tt.input)
+			got := w.run(tt.current, "", "")
+			if got != tt.want {
+				t.Errorf("run() = %+v, want %+v", got, tt.want)
+			}
+			if strings.Join(*logins, ",") != strings.Join(tt.wantLogins, ",") {
+				t.Errorf("logins = %q, want %q", *logins, tt.wantLogins)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/jobs"
+)
+
+// recordJob notes a started generation in the local job store so it can be
+// followed with `nlm jobs`. When notifyTarget is set a background watcher
+// is started to deliver the notification. Failing to record a job without a
+// notification is not fatal to the command.
+func recordJob(kind jobs.Kind, notebookID, resourceID, notifyTarget string) error {
+	store, err := jobs.OpenDefault()
+	if err == nil {
+		var job jobs.Job
+		job, err = store.Add(jobs.Job{Kind: kind, NotebookID: notebookID, ResourceID: resourceID, Notify: notifyTarget})
+		if err == nil {
+			fmt.Printf("  Job: %s (track with 'nlm jobs wait %s')\n", job.ID, job.ID)
+			if notifyTarget == "" {
+				return nil
+			}
+			if err = startJobWatcher(job); err == nil {
+				fmt.Printf("  Will notify %s when it finishes\n", notifyTarget)
+				return nil
+			}
+		}
+	}
+	if notifyTarget != "" {
+		return fmt.Errorf("set up notification: %w", err)
+	}
+	if debug {
+		fmt.Fprintf(os.Stderr, "nlm: warning: failed to record job: %v\n", err)
+	}
+	return nil
+}
+
+// isLocalCommand reports whether cmd with args only touches local state
+// and so can run without credentials.
+func isLocalCommand(cmd string, args []string) bool {
+	switch cmd {
+	case "jobs":
+		return len(args) == 0 || args[0] == "list"
+	case "retry":
+		return isLocalRetry(args)
+	}
+	return false
+}
+
+func validateJobsArgs(args []string) error {
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+	switch sub {
+	case "list":
+		if len(args) > 1 {
+			fmt.Fprintf(os.Stderr, "usage: nlm jobs list\n")
+			return fmt.Errorf("invalid arguments")
+		}
+	case "wait":
+		if _, _, err := parseJobsWaitFlags(args[1:]); err != nil {
+			return err
+		}
+	case "cancel":
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "usage: nlm jobs cancel <job-id>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "usage: nlm jobs [list|wait|cancel]\n")
+		return fmt.Errorf("invalid arguments")
+	}
+	return nil
+}
+
+// jobsWaitOptions contains the CLI options for `jobs wait`
+type jobsWaitOptions struct {
+	Timeout     time.Duration
+	Interval    time.Duration
+	MaxInterval time.Duration
+}
+
+func parseJobsWaitFlags(args []string) (*jobsWaitOptions, []string, error) {
+	opts := &jobsWaitOptions{}
+	fs := flag.NewFlagSet("jobs wait", flag.ContinueOnError)
+	fs.DurationVar(&opts.Timeout, "timeout", 30*time.Minute, "give up after this long")
+	fs.DurationVar(&opts.Interval, "interval", 5*time.Second, "delay before the first status check")
+	fs.DurationVar(&opts.MaxInterval, "max-interval", time.Minute, "longest delay between status checks")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm jobs wait [-timeout d] [-interval d] [job-id...]\n\n")
+		fmt.Fprintf(os.Stderr, "Waits for the given jobs, or every pending job when none are given.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, fs.Args(), nil
+}
+
+func runJobs(c *api.Client, args []string) error {
+	if len(args) == 0 {
+		return listJobs()
+	}
+	switch args[0] {
+	case "list":
+		return listJobs()
+	case "wait":
+		opts, ids, err := parseJobsWaitFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		return waitJobs(c, opts, ids)
+	case "cancel":
+		return cancelJob(c, args[1])
+	}
+	return fmt.Errorf("unknown jobs command: %s", args[0])
+}
+
+func listJobs() error {
+	store, err := jobs.OpenDefault()
+	if err != nil {
+		return err
+	}
+	list, err := store.List()
+	if err != nil {
+		return fmt.Errorf("list jobs: %w", err)
+	}
+	if list == nil {
+		list = []jobs.Job{}
+	}
+	return render(list, func(out io.Writer) error {
+		return writeJobs(out, list)
+	})
+}
+
+// writeJobs prints jobs as a table.
+func writeJobs(out io.Writer, list []jobs.Job) error {
+	if len(list) == 0 {
+		fmt.Fprintln(out, "No jobs found.")
+		return nil
+	}
+
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "ID\tKIND\tNOTEBOOK\tSTATUS\tSTARTED")
+	for _, j := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			j.ID,
+			j.Kind,
+			j.NotebookID,
+			j.Status,
+			formatTime(j.CreatedAt),
+		)
+	}
+	return w.Flush()
+}
+
+func waitJobs(c *api.Client, opts *jobsWaitOptions, ids []string) error {
+	store, err := jobs.OpenDefault()
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		list, err := store.List()
+		if err != nil {
+			return fmt.Errorf("list jobs: %w", err)
+		}
+		for _, j := range list {
+			if !j.Finished() {
+				ids = append(ids, j.ID)
+			}
+		}
+		if len(ids) == 0 {
+			if !jsonLines() {
+				fmt.Println("No pending jobs.")
+			}
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	waitOpts := &api.WaitOptions{Interval: opts.Interval, MaxInterval: opts.MaxInterval}
+
+	for _, id := range ids {
+		job, err := store.Get(id)
+		if err != nil {
+			return err
+		}
+		if !job.Finished() {
+			sp := startSpinner("Waiting for job %s (%s for %s)", job.ID, job.Kind, job.NotebookID)
+			waitOpts.OnPoll = sp.onPoll
+			err := waitForJob(ctx, c, job, waitOpts)
+			sp.Stop()
+			if ctx.Err() != nil {
+				return fmt.Errorf("timed out waiting for job %s: %w", job.ID, err)
+			}
+			status, msg := jobs.StatusDone, ""
+			if err != nil {
+				status, msg = jobs.StatusFailed, err.Error()
+			}
+			job, err = store.Update(job.ID, func(j *jobs.Job) {
+				j.Status, j.Error = status, msg
+			})
+			if err != nil {
+				return fmt.Errorf("update job: %w", err)
+			}
+		}
+		notifyJob(store, job)
+		if jsonLines() {
+			// Stream each finished job as an event.
+			if err := writeJSONLines(os.Stdout, job); err != nil {
+				return err
+			}
+			continue
+		}
+		if job.Status == jobs.StatusDone {
+			fmt.Printf("✅ Job %s (%s for %s) is ready\n", job.ID, job.Kind, job.NotebookID)
+		} else {
+			fmt.Printf("Job %s (%s for %s) %s %s\n", job.ID, job.Kind, job.NotebookID, job.Status, job.Error)
+		}
+	}
+	return nil
+}
+
+// waitForJob blocks until the server reports the job's generation is
+// finished, failed, or ctx expires.
+func waitForJob(ctx context.Context, c *api.Client, job jobs.Job, opts *api.WaitOptions) error {
+	var err error
+	switch job.Kind {
+	case jobs.KindAudio:
+		_, err = c.WaitForAudio(ctx, job.NotebookID, job.ResourceID, opts)
+	case jobs.KindVideo:
+		_, err = c.WaitForVideo(ctx, job.NotebookID, job.ResourceID, opts)
+	case jobs.KindArtifact:
+		_, err = c.WaitForArtifact(ctx, job.NotebookID, job.ResourceID, opts)
+	default:
+		err = fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+	return err
+}
+
+func cancelJob(c *api.Client, id string) error {
+	store, err := jobs.OpenDefault()
+	if err != nil {
+		return err
+	}
+	job, err := store.Get(id)
+	if err != nil {
+		return err
+	}
+	if job.Finished() {
+		return fmt.Errorf("job %s is already %s", job.ID, job.Status)
+	}
+	if ok, err := confirmAction("cancel job %s (%s for %s)", job.ID, job.Kind, job.NotebookID); !ok {
+		return err
+	}
+
+	switch job.Kind {
+	case jobs.KindAudio:
+		err = c.DeleteAudioOverview(job.NotebookID)
+	case jobs.KindArtifact:
+		err = c.DeleteArtifact(job.ResourceID)
+	case jobs.KindVideo:
+		// There is no known RPC to stop a video generation; stop tracking it.
+		fmt.Fprintf(os.Stderr, "nlm: video generation cannot be stopped server-side; no longer tracking it\n")
+	}
+	if err != nil {
+		return fmt.Errorf("cancel job: %w", err)
+	}
+
+	if _, err := store.Update(job.ID, func(j *jobs.Job) { j.Status = jobs.StatusCanceled }); err != nil {
+		return fmt.Errorf("update job: %w", err)
+	}
+	fmt.Printf("✅ Canceled job %s\n", job.ID)
+	return nil
+}
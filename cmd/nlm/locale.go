@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tmc/nlm/internal/i18n"
+)
+
+// setupLocale selects the language of status messages, progress and
+// prompts from NLM_LOCALE, which the config profile's locale setting
+// provides, or else the system locale. Output meant for scripts, such as
+// IDs, tables and JSON, and error messages stay in English.
+func setupLocale() {
+	if err := i18n.Set(i18n.Detect(os.Getenv)); err != nil && os.Getenv("NLM_LOCALE") != "" {
+		fmt.Fprintf(os.Stderr, "nlm: %v; using English\n", err)
+	}
+}
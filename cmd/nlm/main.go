@@ -7,10 +7,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
@@ -19,6 +19,10 @@ import (
 	"github.com/tmc/nlm/internal/auth"
 	"github.com/tmc/nlm/internal/batchexecute"
 	"github.com/tmc/nlm/internal/beprotojson"
+	"github.com/tmc/nlm/internal/filelock"
+	"github.com/tmc/nlm/internal/jobs"
+	"github.com/tmc/nlm/internal/redact"
+	"github.com/tmc/nlm/internal/retry"
 	"github.com/tmc/nlm/internal/rpc"
 )
 
@@ -35,6 +39,11 @@ var (
 	chunkedResponse   bool // Control rt=c parameter for chunked vs JSON array response
 	useDirectRPC      bool // Use direct RPC calls instead of orchestration service
 	skipSources       bool // Skip fetching sources for chat (useful when project is inaccessible)
+	debugCurl         bool // Print each RPC as a curl command
+	withSecrets       bool // Include credentials in -debug-curl output
+
+	// clientOpts are the options the command's client is made with.
+	clientOpts []batchexecute.Option
 )
 
 // ChatSession represents a persistent chat conversation
@@ -57,13 +66,39 @@ func init() {
 	flag.BoolVar(&debugDumpPayload, "debug-dump-payload", false, "dump raw JSON payload and exit (unix-friendly)")
 	flag.BoolVar(&debugParsing, "debug-parsing", false, "show detailed protobuf parsing information")
 	flag.BoolVar(&debugFieldMapping, "debug-field-mapping", false, "show how JSON array positions map to protobuf fields")
+	flag.BoolVar(&debugCurl, "debug-curl", false, "print each RPC as an equivalent curl command on stderr, with credentials redacted")
+	flag.BoolVar(&withSecrets, "with-secrets", false, "include the real auth token and cookies in -debug-curl output")
 	flag.BoolVar(&chunkedResponse, "chunked", false, "use chunked response format (rt=c)")
 	flag.BoolVar(&useDirectRPC, "direct-rpc", false, "use direct RPC calls for audio/video (bypasses orchestration service)")
 	flag.BoolVar(&skipSources, "skip-sources", false, "skip fetching sources for chat (useful for testing)")
 	flag.StringVar(&chromeProfile, "profile", os.Getenv("NLM_BROWSER_PROFILE"), "Chrome profile to use")
 	flag.StringVar(&authToken, "auth", os.Getenv("NLM_AUTH_TOKEN"), "auth token (or set NLM_AUTH_TOKEN)")
 	flag.StringVar(&cookies, "cookies", os.Getenv("NLM_COOKIES"), "cookies for authentication (or set NLM_COOKIES)")
+	flag.StringVar(&configProfile, "config-profile", os.Getenv("NLM_CONFIG_PROFILE"), "config file profile to use (or set NLM_CONFIG_PROFILE)")
+	flag.StringVar(&notebookFlag, "notebook", "", "working notebook for commands run without one (or set NLM_NOTEBOOK)")
+	flag.StringVar(&outputFlag, "o", "", outputHelp)
+	flag.StringVar(&outputFlag, "output", "", outputHelp)
+	flag.BoolFunc("jsonl", "shorthand for -o jsonl: print one JSON record per line", func(string) error {
+		outputFlag = "jsonl"
+		return nil
+	})
+	flag.Var(&verbosity, "v", "verbose output; repeat (-v -v) or use -vv for debug output")
+	flag.BoolFunc("vv", "very verbose output, same as -v -v", func(string) error {
+		verbosity += 2
+		return nil
+	})
+	flag.BoolVar(&quiet, "quiet", false, "suppress progress indicators and status messages")
+	flag.BoolVar(&quiet, "q", false, "shorthand for -quiet")
+	flag.BoolVar(&dryRun, "dry-run", false, "show what destructive commands would do without doing it")
+	flag.BoolVar(&force, "force", false, "do not ask before destructive commands (or set NLM_YES=1)")
+	flag.BoolVar(&raw, "raw", false, "print generated markdown as is, without styling or a pager")
+	flag.BoolVar(&offline, "offline", false, "serve list, sources, notes and artifact cat from the local cache (or set NLM_OFFLINE=1)")
+	flag.BoolVar(&copyOutput, "copy", false, "also copy answers, artifact contents and new IDs to the clipboard")
+	flag.BoolVar(&showStats, "stats", false, "print RPC count, latency, bytes transferred and retries when the command finishes")
+	flag.StringVar(&errorFormat, "error-format", "", "error output format: text or json (or set NLM_ERROR_FORMAT)")
 	flag.StringVar(&mimeType, "mime", "", "specify MIME type for content (e.g. 'text/xml', 'application/json')")
+	flag.StringVar(&pdfPages, "pages", "", "upload only this `range` of PDF pages, such as 10-45 (needs qpdf)")
+	flag.BoolVar(&ocrPDFs, "ocr", false, "OCR image-only PDFs before uploading (needs poppler and tesseract)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: nlm <command> [arguments]\n\n")
@@ -72,16 +107,23 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  create <title>    Create a new notebook\n")
 		fmt.Fprintf(os.Stderr, "  rm <id>           Delete a notebook\n")
 		fmt.Fprintf(os.Stderr, "  analytics <id>    Show notebook analytics\n")
-		fmt.Fprintf(os.Stderr, "  list-featured     List featured notebooks\n\n")
+		fmt.Fprintf(os.Stderr, "  list-featured     List featured notebooks\n")
+		fmt.Fprintf(os.Stderr, "  use [notebook]    Show or set the working notebook\n")
+		fmt.Fprintf(os.Stderr, "  open [notebook]   Open a notebook in the browser\n\n")
 
 		fmt.Fprintf(os.Stderr, "Source Commands:\n")
 		fmt.Fprintf(os.Stderr, "  sources <id>      List sources in notebook\n")
 		fmt.Fprintf(os.Stderr, "  add <id> <input>  Add source to notebook\n")
+		fmt.Fprintf(os.Stderr, "  -pages N-M -ocr add <id> <pdf>  Add part of a PDF, OCRing scanned pages\n")
 		fmt.Fprintf(os.Stderr, "  rm-source <id> <source-id>  Remove source\n")
 		fmt.Fprintf(os.Stderr, "  rename-source <source-id> <new-name>  Rename source\n")
 		fmt.Fprintf(os.Stderr, "  refresh-source <source-id>  Refresh source content\n")
 		fmt.Fprintf(os.Stderr, "  check-source <source-id>  Check source freshness\n")
-		fmt.Fprintf(os.Stderr, "  discover-sources <id> <query>  Discover relevant sources\n\n")
+		fmt.Fprintf(os.Stderr, "  source diff <id> <source-id> <file> [-w]  Diff a local file against the source's extracted text\n")
+		fmt.Fprintf(os.Stderr, "  discover-sources <id> <query>  Discover relevant sources\n")
+		fmt.Fprintf(os.Stderr, "  import zotero [id] [-collection name] [-library path]  Upload a Zotero library's PDFs as sources\n")
+		fmt.Fprintf(os.Stderr, "  retry [item-id...]  Retry the sources a bulk add or import failed on\n")
+		fmt.Fprintf(os.Stderr, "  retry list | clear [item-id...]  Show or drop the failed sources\n\n")
 
 		fmt.Fprintf(os.Stderr, "Note Commands:\n")
 		fmt.Fprintf(os.Stderr, "  notes <id>        List notes in notebook\n")
@@ -91,31 +133,44 @@ func init() {
 
 		fmt.Fprintf(os.Stderr, "Audio Commands:\n")
 		fmt.Fprintf(os.Stderr, "  audio-list <id>   List all audio overviews for a notebook with status\n")
-		fmt.Fprintf(os.Stderr, "  audio-create <id> <instructions>  Create audio overview\n")
+		fmt.Fprintf(os.Stderr, "  audio-create [-length l] [-style s] [-notify t] <id> <instructions>  Create audio overview\n")
 		fmt.Fprintf(os.Stderr, "  audio-get <id>    Get audio overview\n")
 		fmt.Fprintf(os.Stderr, "  audio-download <id> [filename]  Download audio file (requires --direct-rpc)\n")
 		fmt.Fprintf(os.Stderr, "  audio-rm <id>     Delete audio overview\n")
-		fmt.Fprintf(os.Stderr, "  audio-share <id>  Share audio overview\n\n")
+		fmt.Fprintf(os.Stderr, "  audio-share <id>  Share audio overview\n")
+		fmt.Fprintf(os.Stderr, "  audio-batch -notebooks <file>  Generate and download audio for many notebooks\n\n")
 
 		fmt.Fprintf(os.Stderr, "Video Commands:\n")
 		fmt.Fprintf(os.Stderr, "  video-list <id>   List all video overviews for a notebook with status\n")
-		fmt.Fprintf(os.Stderr, "  video-create <id> <instructions>  Create video overview\n")
+		fmt.Fprintf(os.Stderr, "  video-create [-notify t] <id> <instructions>  Create video overview\n")
 		fmt.Fprintf(os.Stderr, "  video-download <id> [filename]  Download video file (requires --direct-rpc)\n\n")
 
 		fmt.Fprintf(os.Stderr, "Artifact Commands:\n")
-		fmt.Fprintf(os.Stderr, "  create-artifact <id> <type>  Create artifact (note|audio|report|app)\n")
+		fmt.Fprintf(os.Stderr, "  artifact create <id> -type <type> [-pick]  Create study-guide, briefing-doc, faq, timeline, mind-map, slide-deck or infographic\n")
+		fmt.Fprintf(os.Stderr, "  artifact cat <id> <artifact-id> [-out file.md]  Print artifact content as Markdown\n")
+		fmt.Fprintf(os.Stderr, "  artifact inspect <id> <artifact-id> [-raw]  Show an artifact's raw fields with inferred labels\n")
+		fmt.Fprintf(os.Stderr, "  artifact download <id> <artifact-id> [-dir d]  Download rendered slides or infographics\n")
+		fmt.Fprintf(os.Stderr, "  artifact export <id> <artifact-id> -to-gdoc  Export a report to Google Docs\n")
+		fmt.Fprintf(os.Stderr, "  artifact update <id> <artifact-id> [-title t] [-content-file f]  Edit artifact\n")
+		fmt.Fprintf(os.Stderr, "  artifact refresh <id> <artifact-id> [-diff] [-replace]  Regenerate with the original parameters\n")
+		fmt.Fprintf(os.Stderr, "  artifact save-as-note <id> <artifact-id> [-as-source]  Copy an artifact into a note or source\n")
+		fmt.Fprintf(os.Stderr, "  artifact rm <id> [artifact-id...] [-type t] [-older-than 7d]  Delete artifacts\n")
+		fmt.Fprintf(os.Stderr, "  create-artifact [-notify t] <id> <type>  Create artifact (note|audio|report|app)\n")
 		fmt.Fprintf(os.Stderr, "  get-artifact <artifact-id>  Get artifact details\n")
-		fmt.Fprintf(os.Stderr, "  artifacts <id>       List artifacts in notebook\n")
+		fmt.Fprintf(os.Stderr, "  artifacts [-json] <id>  List artifacts in notebook\n")
 		fmt.Fprintf(os.Stderr, "  list-artifacts <id>  List artifacts in notebook (alias)\n")
 		fmt.Fprintf(os.Stderr, "  rename-artifact <artifact-id> <new-title>  Rename artifact\n")
 		fmt.Fprintf(os.Stderr, "  delete-artifact <artifact-id>  Delete artifact\n\n")
 
 		fmt.Fprintf(os.Stderr, "Generation Commands:\n")
+		fmt.Fprintf(os.Stderr, "  generate <output> <id>  Create a study-guide, faq, briefing, timeline, mindmap,\n")
+		fmt.Fprintf(os.Stderr, "                    slides, infographic, audio or video overview\n")
 		fmt.Fprintf(os.Stderr, "  generate-guide <id>  Generate notebook guide\n")
 		fmt.Fprintf(os.Stderr, "  generate-outline <id>  Generate content outline\n")
 		fmt.Fprintf(os.Stderr, "  generate-section <id>  Generate new section\n")
 		fmt.Fprintf(os.Stderr, "  generate-chat <id> <prompt>  Free-form chat generation\n")
-		fmt.Fprintf(os.Stderr, "  generate-magic <id> <source-ids...>  Generate magic view from sources\n")
+		fmt.Fprintf(os.Stderr, "  ask [id] <question> [-sources ids] [-pick]  Ask the working notebook a question\n")
+		fmt.Fprintf(os.Stderr, "  generate-magic <id> <source-ids...>  Generate magic view from sources (picked on a terminal if omitted)\n")
 		fmt.Fprintf(os.Stderr, "  chat <id>               Interactive chat session\n")
 		fmt.Fprintf(os.Stderr, "  chat-list               List all saved chat sessions\n\n")
 
@@ -132,24 +187,101 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  faq <id> <source-ids...>          Generate FAQ from sources\n")
 		fmt.Fprintf(os.Stderr, "  briefing-doc <id> <source-ids...> Create briefing document\n")
 		fmt.Fprintf(os.Stderr, "  mindmap <id> <source-ids...>      Generate interactive mindmap\n")
+		fmt.Fprintf(os.Stderr, "  mindmap export <id> <artifact-id> [-format mermaid|dot|opml]  Export a mind map\n")
+		fmt.Fprintf(os.Stderr, "  flashcards export <id> <artifact-id> [-format anki-tsv|apkg]  Export flashcards for Anki\n")
+		fmt.Fprintf(os.Stderr, "  quiz export <id> <artifact-id> [-format json|gift]  Export a quiz (GIFT imports into Moodle)\n")
+		fmt.Fprintf(os.Stderr, "  quiz take <id> <artifact-id>      Take a quiz in the terminal\n")
 		fmt.Fprintf(os.Stderr, "  timeline <id> <source-ids...>     Create timeline from sources\n")
 		fmt.Fprintf(os.Stderr, "  toc <id> <source-ids...>          Generate table of contents\n\n")
 
+		fmt.Fprintf(os.Stderr, "Job Commands:\n")
+		fmt.Fprintf(os.Stderr, "  jobs [list]       List tracked audio, video and artifact generations\n")
+		fmt.Fprintf(os.Stderr, "  jobs wait [job-id...]  Wait for generations to finish\n")
+		fmt.Fprintf(os.Stderr, "  jobs cancel <job-id>  Cancel a generation\n")
+		fmt.Fprintf(os.Stderr, "  history [-notebook id]  Show changes made to notebooks\n\n")
+
+		fmt.Fprintf(os.Stderr, "Agent Commands:\n")
+		fmt.Fprintf(os.Stderr, "  mcp serve [-sse] [-addr host:port]  Serve notebooks to agents over the Model Context Protocol\n")
+		fmt.Fprintf(os.Stderr, "  serve [-addr host:port] [-api-key k] [-grpc] [-metrics host:port] [-accounts profiles] [-mail-allow senders]  Serve notebooks as a JSON or gRPC API, and take email\n")
+		fmt.Fprintf(os.Stderr, "  discord [-guild id] [-admins ids]  Answer questions about notebooks in Discord channels\n")
+		fmt.Fprintf(os.Stderr, "  quick -list-notebooks|-ask [query]  Print Script Filter JSON for Alfred and Raycast\n\n")
+
+		fmt.Fprintf(os.Stderr, "Guidebook Commands:\n")
+		fmt.Fprintf(os.Stderr, "  guidebook ask <guidebook-id> <question>  Ask a published guidebook\n")
+		fmt.Fprintf(os.Stderr, "  guidebook create-from <id> [-public] [-tags a,b]  Publish a notebook as a guidebook\n")
+		fmt.Fprintf(os.Stderr, "  guidebook stats <guidebook-id> [-json]  Show views and questions asked\n\n")
+
 		fmt.Fprintf(os.Stderr, "Sharing Commands:\n")
 		fmt.Fprintf(os.Stderr, "  share <id>        Share notebook publicly\n")
+		fmt.Fprintf(os.Stderr, "  share <id> -email <addr> [-role viewer|editor]  Invite collaborators\n")
+		fmt.Fprintf(os.Stderr, "  share <id> -link anyone|domain|restricted  Set who can open the share link\n")
+		fmt.Fprintf(os.Stderr, "  share list <id>   List collaborators and link access\n")
+		fmt.Fprintf(os.Stderr, "  share revoke <id> -email <addr> | -public  Remove access\n")
 		fmt.Fprintf(os.Stderr, "  share-private <id>  Share notebook privately\n")
-		fmt.Fprintf(os.Stderr, "  share-details <share-id>  Get details of shared project\n\n")
+		fmt.Fprintf(os.Stderr, "  share-details <share-id>  Get details of shared project\n")
+		fmt.Fprintf(os.Stderr, "  publish-site [id] [-out dir] [-qa]  Export the notebook as a static HTML site\n")
+		fmt.Fprintf(os.Stderr, "  mirror [id] [-repo dir]  Commit the notebook's content to a git repository\n\n")
 
 		fmt.Fprintf(os.Stderr, "Other Commands:\n")
+		fmt.Fprintf(os.Stderr, "  init              Guided first-run setup: browser, sign-in, defaults\n")
 		fmt.Fprintf(os.Stderr, "  auth [profile]    Setup authentication\n")
+		fmt.Fprintf(os.Stderr, "  auth refresh [-daemon] [-interval d]  Re-read credentials from the browser profile\n")
 		fmt.Fprintf(os.Stderr, "  refresh           Refresh authentication credentials\n")
+		fmt.Fprintf(os.Stderr, "  self-update [-check]  Install the latest release of nlm\n")
+		fmt.Fprintf(os.Stderr, "  capabilities [-refresh]  Show the account's plan, limits and features\n")
+		fmt.Fprintf(os.Stderr, "  daemon [-socket path] [status|stop]  Keep connections warm for faster commands\n")
+		fmt.Fprintf(os.Stderr, "  index [id...]     Mirror notebooks into the local search index\n")
+		fmt.Fprintf(os.Stderr, "  search <query> [-notebook id]  Search the local index, offline\n")
+		fmt.Fprintf(os.Stderr, "  audit [-out file]  Write a JSON inventory of every notebook in the account\n")
 		fmt.Fprintf(os.Stderr, "  feedback <msg>    Submit feedback\n")
+		fmt.Fprintf(os.Stderr, "  config list       Show the active config profile\n")
+		fmt.Fprintf(os.Stderr, "  config get <key>  Print a config setting\n")
+		fmt.Fprintf(os.Stderr, "  config set <key> <value>  Change a config setting\n")
+		fmt.Fprintf(os.Stderr, "  alias set <name> <id>  Name a notebook; aliases work wherever an ID does\n")
+		fmt.Fprintf(os.Stderr, "  alias list        List notebook aliases\n")
+		fmt.Fprintf(os.Stderr, "  alias rm <name>   Remove a notebook alias\n")
+		fmt.Fprintf(os.Stderr, "  persona attach <alias> <persona>  Prepend a config persona to questions for a notebook\n")
+		fmt.Fprintf(os.Stderr, "  persona detach <alias>  Stop using a notebook's persona\n")
+		fmt.Fprintf(os.Stderr, "  persona list      List personas and the aliases using them\n")
+		fmt.Fprintf(os.Stderr, "  state export <file> [-credentials]  Bundle config, jobs, history and caches\n")
+		fmt.Fprintf(os.Stderr, "  state import <file> [-replace]  Restore a bundle on another machine\n")
 		fmt.Fprintf(os.Stderr, "  hb                Send heartbeat\n\n")
+
+		fmt.Fprintf(os.Stderr, "Notebooks can be given by ID, alias, or a unique part of their title. Commands\n")
+		fmt.Fprintf(os.Stderr, "run without one use the working notebook (-notebook, NLM_NOTEBOOK or 'nlm use').\n\n")
+
+		fmt.Fprintf(os.Stderr, "Destructive commands (rm, rm-source, rm-note, audio-rm, artifact rm, ...) ask\n")
+		fmt.Fprintf(os.Stderr, "first; -force or NLM_YES=1 skips the question and -dry-run only shows the plan.\n\n")
+
+		fmt.Fprintf(os.Stderr, "Long uploads, downloads and waits show progress on a terminal; -quiet hides it.\n")
+		fmt.Fprintf(os.Stderr, "-v and -vv print more detail. Color follows NO_COLOR and NLM_COLOR=auto|always|never.\n")
+		fmt.Fprintf(os.Stderr, "Answers and guides are styled and paged ($PAGER) on a terminal; -raw prints markdown as is.\n")
+		fmt.Fprintf(os.Stderr, "-offline answers list, sources, notes and artifact cat from the local cache.\n")
+		fmt.Fprintf(os.Stderr, "-copy also puts answers, artifact contents and new note or artifact IDs on the clipboard.\n")
+		fmt.Fprintf(os.Stderr, "-stats prints the RPCs, retries, latency and bytes a command used on stderr.\n\n")
+
+		fmt.Fprintf(os.Stderr, "Output Options:\n")
+		fmt.Fprintf(os.Stderr, "  -o table|json|yaml  Output format for listings (or set NLM_OUTPUT)\n")
+		fmt.Fprintf(os.Stderr, "  -o jsonl, -jsonl    One JSON record per line, for jq and xargs; jobs wait streams events\n")
+		fmt.Fprintf(os.Stderr, "  -o template='{{.ProjectId}} {{.Title}}'  Format each item with a Go template\n\n")
+
+		fmt.Fprintf(os.Stderr, "Exit Codes:\n")
+		fmt.Fprintf(os.Stderr, "  1 error  2 usage  3 auth  4 not found  5 rate limited  6 protocol drift  7 offline\n")
+		fmt.Fprintf(os.Stderr, "  -error-format json prints errors to stderr as JSON (or set NLM_ERROR_FORMAT)\n\n")
 	}
 }
 
 func main() {
+	setupConsole()
 	flag.Parse()
+	applyVerbosity()
+
+	if outputFlag != "" {
+		if _, err := parseOutput(outputFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "nlm: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
 
 	if debug {
 		fmt.Fprintf(os.Stderr, "nlm: debug mode enabled\n")
@@ -165,8 +297,14 @@ func main() {
 		}
 	}
 
-	// Load stored environment variables
+	// Load the config profile, then stored environment variables
+	applyConfig()
 	loadStoredEnv()
+	setupLocale()
+	if err := validateErrorFormat(); err != nil {
+		fmt.Fprintf(os.Stderr, "nlm: %v\n", err)
+		os.Exit(exitUsage)
+	}
 
 	// Set skip sources flag if specified
 	if skipSources {
@@ -184,9 +322,11 @@ func main() {
 	// Start auto-refresh manager if credentials exist
 	startAutoRefreshIfEnabled()
 
-	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "nlm: %v\n", err)
-		os.Exit(1)
+	printStats := startStats(os.Stderr)
+	err := run()
+	printStats()
+	if err != nil {
+		os.Exit(reportError(os.Stderr, err))
 	}
 }
 
@@ -260,9 +400,8 @@ func validateArgs(cmd string, args []string) error {
 			return fmt.Errorf("invalid arguments")
 		}
 	case "audio-create":
-		if len(args) != 2 {
-			fmt.Fprintf(os.Stderr, "usage: nlm audio-create <notebook-id> <instructions>\n")
-			return fmt.Errorf("invalid arguments")
+		if _, err := parseAudioCreateFlags(args); err != nil {
+			return err
 		}
 	case "audio-get":
 		if len(args) != 1 {
@@ -279,16 +418,34 @@ func validateArgs(cmd string, args []string) error {
 			fmt.Fprintf(os.Stderr, "usage: nlm audio-share <notebook-id>\n")
 			return fmt.Errorf("invalid arguments")
 		}
+	case "audio-batch":
+		if _, err := parseAudioBatchFlags(args); err != nil {
+			return err
+		}
 	case "video-create":
-		if len(args) != 2 {
-			fmt.Fprintf(os.Stderr, "usage: nlm video-create <notebook-id> <instructions>\n")
-			return fmt.Errorf("invalid arguments")
+		if _, _, err := parseGenerationArgs(cmd, "<notebook-id> <instructions>", 2, args); err != nil {
+			return err
 		}
+	case "generate":
+		return validateGenerateArgs(args)
 	case "share":
-		if len(args) != 1 {
-			fmt.Fprintf(os.Stderr, "usage: nlm share <notebook-id>\n")
-			return fmt.Errorf("invalid arguments")
-		}
+		return validateShareArgs(args)
+	case "init":
+		_, err := parseInitFlags(args)
+		return err
+	case "self-update":
+		_, err := parseSelfUpdateFlags(args)
+		return err
+	case "config":
+		return validateConfigArgs(args)
+	case "alias":
+		return validateAliasArgs(args)
+	case "persona":
+		return validatePersonaArgs(args)
+	case "state":
+		return validateStateArgs(args)
+	case "retry":
+		return validateRetryArgs(args)
 	case "share-private":
 		if len(args) != 1 {
 			fmt.Fprintf(os.Stderr, "usage: nlm share-private <notebook-id>\n")
@@ -318,17 +475,21 @@ func validateArgs(cmd string, args []string) error {
 			return fmt.Errorf("invalid arguments")
 		}
 	case "generate-magic":
-		if len(args) < 2 {
+		if len(args) < 2 && !(len(args) == 1 && canPick()) {
 			fmt.Fprintf(os.Stderr, "usage: nlm generate-magic <notebook-id> <source-id> [source-id...]\n")
 			return fmt.Errorf("invalid arguments")
 		}
 	case "generate-mindmap":
-		if len(args) < 2 {
+		if len(args) < 2 && !(len(args) == 1 && canPick()) {
 			fmt.Fprintf(os.Stderr, "usage: nlm generate-mindmap <notebook-id> <source-id> [source-id...]\n")
 			return fmt.Errorf("invalid arguments")
 		}
 	case "rephrase", "expand", "summarize", "critique", "brainstorm", "verify", "explain", "outline", "study-guide", "faq", "briefing-doc", "mindmap", "timeline", "toc":
-		if len(args) < 2 {
+		if cmd == "mindmap" && len(args) > 0 && args[0] == "export" {
+			_, err := parseMindmapExportFlags(args[1:])
+			return err
+		}
+		if len(args) < 2 && !(len(args) == 1 && canPick()) {
 			fmt.Fprintf(os.Stderr, "usage: nlm %s <notebook-id> <source-id> [source-id...]\n", cmd)
 			return fmt.Errorf("invalid arguments")
 		}
@@ -337,6 +498,14 @@ func validateArgs(cmd string, args []string) error {
 			fmt.Fprintf(os.Stderr, "usage: nlm generate-chat <notebook-id> <prompt>\n")
 			return fmt.Errorf("invalid arguments")
 		}
+	case "ask":
+		_, err := parseAskFlags(args)
+		return err
+	case "use":
+		return validateUseArgs(args)
+	case "open":
+		_, err := parseOpenFlags(args)
+		return err
 	case "chat":
 		if len(args) != 1 {
 			fmt.Fprintf(os.Stderr, "usage: nlm chat <notebook-id>\n")
@@ -347,10 +516,15 @@ func validateArgs(cmd string, args []string) error {
 			fmt.Fprintf(os.Stderr, "usage: nlm chat-list\n")
 			return fmt.Errorf("invalid arguments")
 		}
+	case "artifact":
+		return validateArtifactArgs(args)
+	case "source":
+		return validateSourceArgs(args)
+	case "guidebook":
+		return validateGuidebookArgs(args)
 	case "create-artifact":
-		if len(args) != 2 {
-			fmt.Fprintf(os.Stderr, "usage: nlm create-artifact <notebook-id> <type>\n")
-			return fmt.Errorf("invalid arguments")
+		if _, _, err := parseGenerationArgs(cmd, "<notebook-id> <type>", 2, args); err != nil {
+			return err
 		}
 	case "get-artifact":
 		if len(args) != 1 {
@@ -358,13 +532,8 @@ func validateArgs(cmd string, args []string) error {
 			return fmt.Errorf("invalid arguments")
 		}
 	case "list-artifacts", "artifacts":
-		if len(args) != 1 {
-			if cmd == "artifacts" {
-				fmt.Fprintf(os.Stderr, "usage: nlm artifacts <notebook-id>\n")
-			} else {
-				fmt.Fprintf(os.Stderr, "usage: nlm list-artifacts <notebook-id>\n")
-			}
-			return fmt.Errorf("invalid arguments")
+		if _, _, err := parseListArtifactsFlags(cmd, args); err != nil {
+			return err
 		}
 	case "rename-artifact":
 		if len(args) != 2 {
@@ -401,6 +570,53 @@ func validateArgs(cmd string, args []string) error {
 			fmt.Fprintf(os.Stderr, "usage: nlm notes <notebook-id>\n")
 			return fmt.Errorf("invalid arguments")
 		}
+	case "jobs":
+		return validateJobsArgs(args)
+	case "mcp":
+		_, err := parseMCPFlags(args)
+		return err
+	case "serve":
+		_, err := parseServeFlags(args)
+		return err
+	case "discord":
+		_, err := parseDiscordFlags(args)
+		return err
+	case "daemon":
+		_, err := parseDaemonFlags(args)
+		return err
+	case "bench":
+		_, err := parseBenchFlags(args)
+		return err
+	case "capabilities":
+		_, err := parseCapabilitiesFlags(args)
+		return err
+	case "quick":
+		_, err := parseQuickFlags(args)
+		return err
+	case "history":
+		_, err := parseHistoryFlags(args)
+		return err
+	case "publish-site":
+		_, err := parsePublishSiteFlags(args)
+		return err
+	case "mirror":
+		_, err := parseMirrorFlags(args)
+		return err
+	case "index":
+		_, err := parseIndexFlags(args)
+		return err
+	case "search":
+		_, err := parseSearchFlags(args)
+		return err
+	case "audit":
+		_, err := parseAuditFlags(args)
+		return err
+	case "flashcards":
+		return validateFlashcardsArgs(args)
+	case "quiz":
+		return validateQuizArgs(args)
+	case "import":
+		return validateImportArgs(args)
 	case "feedback":
 		if len(args) != 1 {
 			fmt.Fprintf(os.Stderr, "usage: nlm feedback <message>\n")
@@ -415,13 +631,15 @@ func isValidCommand(cmd string) bool {
 	validCommands := []string{
 		"help", "-h", "--help",
 		"list", "ls", "create", "rm", "analytics", "list-featured",
-		"sources", "add", "rm-source", "rename-source", "refresh-source", "check-source", "discover-sources",
+		"sources", "source", "add", "rm-source", "rename-source", "refresh-source", "check-source", "discover-sources", "import",
 		"notes", "new-note", "update-note", "rm-note",
-		"audio-create", "audio-get", "audio-rm", "audio-share", "audio-list", "audio-download", "video-create", "video-list", "video-download",
-		"create-artifact", "get-artifact", "list-artifacts", "artifacts", "rename-artifact", "delete-artifact",
-		"generate-guide", "generate-outline", "generate-section", "generate-magic", "generate-mindmap", "generate-chat", "chat", "chat-list",
-		"rephrase", "expand", "summarize", "critique", "brainstorm", "verify", "explain", "outline", "study-guide", "faq", "briefing-doc", "mindmap", "timeline", "toc",
-		"auth", "refresh", "hb", "share", "share-private", "share-details", "feedback",
+		"audio-create", "audio-get", "audio-rm", "audio-share", "audio-list", "audio-download", "audio-batch", "video-create", "video-list", "video-download",
+		"artifact", "create-artifact", "get-artifact", "list-artifacts", "artifacts", "rename-artifact", "delete-artifact",
+		"generate", "generate-guide", "generate-outline", "generate-section", "generate-magic", "generate-mindmap", "generate-chat", "ask", "chat", "chat-list", "use", "open",
+		"rephrase", "expand", "summarize", "critique", "brainstorm", "verify", "explain", "outline", "study-guide", "faq", "briefing-doc", "mindmap", "timeline", "toc", "flashcards", "quiz",
+		"guidebook",
+		"auth", "refresh", "hb", "share", "share-private", "share-details", "publish-site", "mirror", "feedback", "jobs", "history", "mcp", "serve", "discord", "daemon", "quick", "index", "search", "audit", "config", "alias", "persona", "state", "retry", "init", "self-update", "capabilities",
+		"bench", // hidden: measures throughput for tuning
 	}
 
 	for _, valid := range validCommands {
@@ -445,8 +663,13 @@ func isAuthCommand(cmd string) bool {
 	if cmd == "refresh" {
 		return false
 	}
-	// Chat-list just lists local sessions, no auth needed
-	if cmd == "chat-list" {
+	// Config, aliases, personas, local state, setup, self-update and the
+	// daemon need no NotebookLM credentials
+	if cmd == "config" || cmd == "alias" || cmd == "persona" || cmd == "state" || cmd == "init" || cmd == "self-update" || cmd == "daemon" {
+		return false
+	}
+	// Chat-list and search only read local state, no auth needed
+	if cmd == "chat-list" || cmd == "search" {
 		return false
 	}
 	return true
@@ -464,16 +687,7 @@ func run() error {
 		fmt.Printf("DEBUG: Auth token loaded: %v\n", authToken != "")
 		fmt.Printf("DEBUG: Cookies loaded: %v\n", cookies != "")
 		if authToken != "" {
-			// Mask token for security - show only first 2 and last 2 chars for tokens > 8 chars
-			var tokenDisplay string
-			if len(authToken) <= 8 {
-				tokenDisplay = strings.Repeat("*", len(authToken))
-			} else {
-				start := authToken[:2]
-				end := authToken[len(authToken)-2:]
-				tokenDisplay = start + strings.Repeat("*", len(authToken)-4) + end
-			}
-			fmt.Printf("DEBUG: Token: %s\n", tokenDisplay)
+			fmt.Printf("DEBUG: Token: %s\n", redact.Value(authToken))
 		}
 	}
 
@@ -491,13 +705,16 @@ func run() error {
 		os.Exit(1)
 	}
 
+	// Supply the working notebook to commands run without one
+	args = fillNotebookArg(cmd, args)
+
 	// Validate arguments first (before authentication check)
 	if err := validateArgs(cmd, args); err != nil {
 		return err
 	}
 
 	// Check if this command needs authentication
-	if isAuthCommand(cmd) && (authToken == "" || cookies == "") {
+	if isAuthCommand(cmd) && !runsLocally(cmd, args) && !offlineEnabled() && (authToken == "" || cookies == "") {
 		fmt.Fprintf(os.Stderr, "Authentication required for '%s'. Run 'nlm auth' first.\n", cmd)
 		return fmt.Errorf("authentication required")
 	}
@@ -510,6 +727,9 @@ func run() error {
 
 	// Handle auth command
 	if cmd == "auth" {
+		if len(args) > 0 && args[0] == "refresh" {
+			return runAuthRefresh(args[1:])
+		}
 		_, _, err := handleAuth(args, debug)
 		return err
 	}
@@ -519,13 +739,102 @@ func run() error {
 		return refreshCredentials(debug)
 	}
 
-	var opts []batchexecute.Option
+	// Handle config command
+	if cmd == "config" {
+		return runConfig(args)
+	}
+
+	// Handle the setup wizard
+	if cmd == "init" {
+		return runInit(args)
+	}
+
+	// Handle self-update, which only talks to GitHub
+	if cmd == "self-update" {
+		return runSelfUpdate(args)
+	}
+
+	// Handle the connection daemon, which forwards other commands' requests
+	if cmd == "daemon" {
+		return runDaemon(args)
+	}
+
+	// Handle alias command
+	if cmd == "alias" {
+		return runAlias(args)
+	}
+
+	// Handle persona command
+	if cmd == "persona" {
+		return runPersona(args)
+	}
+
+	// Handle state export and import, which only touch local files
+	if cmd == "state" {
+		return runState(args)
+	}
+
+	// Handle history command
+	if cmd == "history" {
+		return runHistory(args)
+	}
+
+	// Handle search, which reads only the local index
+	if cmd == "search" {
+		return runSearch(args)
+	}
+
+	// Handle use when it needs no notebook lookup
+	if cmd == "use" && isLocalUse(args) {
+		if len(args) == 0 {
+			return showWorkingNotebook()
+		}
+		return useNotebook(nil, args[0])
+	}
+
+	// Handle open when it needs no notebook lookup
+	if cmd == "open" && isLocalOpen(args) {
+		return runOpen(nil, args)
+	}
+
+	// Handle commands that only read local state
+	if isLocalCommand(cmd, args) {
+		if cmd == "retry" {
+			return runRetry(nil, args)
+		}
+		return runJobs(nil, args)
+	}
+
+	// Serve what the cache can answer and refuse the rest
+	if offlineEnabled() {
+		return runOffline(cmd, args)
+	}
+
+	opts := append(daemonClientOptions(), retryOptions()...)
 
 	// Add debug option if enabled
 	if debug {
 		opts = append(opts, batchexecute.WithDebug(true))
 	}
 
+	// Gzip large request bodies where the server takes them
+	if compressEnabled() {
+		opts = append(opts, batchexecute.WithCompression(true))
+	}
+
+	// Print each RPC as a curl command for bug reports
+	if debugCurl {
+		opts = append(opts, batchexecute.WithCurl(os.Stderr, withSecrets))
+	}
+
+	// Count requests for the -stats footer, and for serve's metrics
+	if rpcStats == nil && cmd == "serve" {
+		rpcStats = new(batchexecute.Stats)
+	}
+	if rpcStats != nil {
+		opts = append(opts, batchexecute.WithStats(rpcStats))
+	}
+
 	// Add rt=c parameter if chunked response format is requested
 	if chunkedResponse {
 		opts = append(opts, batchexecute.WithURLParams(map[string]string{
@@ -547,6 +856,8 @@ func run() error {
 		}
 	}
 
+	clientOpts = opts
+
 	for i := 0; i < 3; i++ {
 		if i > 0 {
 			if i == 1 {
@@ -557,7 +868,15 @@ func run() error {
 			debug = true
 		}
 
-		client := api.New(authToken, cookies, opts...)
+		apiOpts := []api.Option{api.WithAuth(authToken, cookies), api.WithBatchExecuteOptions(opts...)}
+		// Fetch current page parameters while the command gets going
+		if path, err := api.DefaultPageParamsCache(); err == nil {
+			apiOpts = append(apiOpts, api.WithPageParams(path))
+		}
+		client, err := api.New(context.Background(), apiOpts...)
+		if err != nil {
+			return err
+		}
 		// Set direct RPC flag if specified
 		if useDirectRPC {
 			client.SetUseDirectRPC(true)
@@ -596,6 +915,19 @@ func run() error {
 	return fmt.Errorf("nlm: authentication failed after 3 attempts")
 }
 
+// runsLocally reports whether cmd can run without credentials given args.
+func runsLocally(cmd string, args []string) bool {
+	switch cmd {
+	case "use":
+		return isLocalUse(args)
+	case "open":
+		return isLocalOpen(args)
+	case "history":
+		return true
+	}
+	return isLocalCommand(cmd, args)
+}
+
 // isAuthenticationError checks if an error is related to authentication
 func isAuthenticationError(err error) bool {
 	if err == nil {
@@ -637,6 +969,10 @@ func isAuthenticationError(err error) bool {
 
 // saveCredentials saves authentication credentials to environment file
 func saveCredentials(authToken, cookies string) error {
+	if saved, err := saveAuthToProfile(authToken, cookies, chromeProfile); saved || err != nil {
+		return err
+	}
+
 	// Get home directory
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -660,7 +996,7 @@ NLM_BROWSER_PROFILE=%q
 		chromeProfile,
 	)
 
-	if err := os.WriteFile(envFile, []byte(content), 0600); err != nil {
+	if err := writeEnvFile(envFile, content); err != nil {
 		return fmt.Errorf("write env file: %w", err)
 	}
 
@@ -669,6 +1005,19 @@ NLM_BROWSER_PROFILE=%q
 
 func runCmd(client *api.Client, cmd string, args ...string) error {
 	var err error
+	if notebookArgCommands[cmd] && !(cmd == "mindmap" && args[0] == "export") {
+		if args[0], err = resolveNotebook(client, args[0]); err != nil {
+			return err
+		}
+	}
+	// Commands run on a terminal without source IDs let the user pick them
+	if sourceArgCommands[cmd] && len(args) == 1 && !(cmd == "mindmap" && args[0] == "export") {
+		ids, err := pickSources(client, args[0], nil)
+		if err != nil {
+			return err
+		}
+		args = append(args, ids...)
+	}
 	switch cmd {
 	// Notebook operations
 	case "list", "ls":
@@ -686,9 +1035,7 @@ func runCmd(client *api.Client, cmd string, args ...string) error {
 	case "sources":
 		err = listSources(client, args[0])
 	case "add":
-		var id string
-		id, err = addSource(client, args[0], args[1])
-		fmt.Println(id)
+		err = addSources(client, args[0], expandInput(args[1]))
 	case "rm-source":
 		err = removeSource(client, args[0], args[1])
 	case "rename-source":
@@ -699,6 +1046,10 @@ func runCmd(client *api.Client, cmd string, args ...string) error {
 		err = checkSourceFreshness(client, args[0])
 	case "discover-sources":
 		err = discoverSources(client, args[0], args[1])
+	case "import":
+		err = runImport(client, args)
+	case "retry":
+		err = runRetry(client, args)
 
 	// Note operations
 	case "notes":
@@ -712,7 +1063,14 @@ func runCmd(client *api.Client, cmd string, args ...string) error {
 
 		// Audio operations
 	case "audio-create":
-		err = createAudioOverview(client, args[0], args[1])
+		a, perr := parseAudioCreateFlags(args)
+		if perr != nil {
+			return perr
+		}
+		if a.NotebookID, err = resolveNotebook(client, a.NotebookID); err != nil {
+			return err
+		}
+		err = createAudioOverview(client, a.NotebookID, a.Audio, a.Notify)
 	case "audio-get":
 		err = getAudioOverview(client, args[0])
 	case "audio-rm":
@@ -727,8 +1085,19 @@ func runCmd(client *api.Client, cmd string, args ...string) error {
 			filename = args[1]
 		}
 		err = downloadAudioOverview(client, args[0], filename)
+	case "audio-batch":
+		err = audioBatch(client, args)
+	case "generate":
+		err = runGenerate(client, args)
 	case "video-create":
-		err = createVideoOverview(client, args[0], args[1])
+		pos, target, perr := parseGenerationArgs(cmd, "<notebook-id> <instructions>", 2, args)
+		if perr != nil {
+			return perr
+		}
+		if pos[0], err = resolveNotebook(client, pos[0]); err != nil {
+			return err
+		}
+		err = createVideoOverview(client, pos[0], pos[1], target)
 	case "video-list":
 		err = listVideoOverviews(client, args[0])
 	case "video-download":
@@ -739,12 +1108,30 @@ func runCmd(client *api.Client, cmd string, args ...string) error {
 		err = downloadVideoOverview(client, args[0], filename)
 
 	// Artifact operations
+	case "artifact":
+		err = runArtifact(client, args)
+	case "source":
+		err = runSource(client, args)
 	case "create-artifact":
-		err = createArtifact(client, args[0], args[1])
+		pos, target, perr := parseGenerationArgs(cmd, "<notebook-id> <type>", 2, args)
+		if perr != nil {
+			return perr
+		}
+		if pos[0], err = resolveNotebook(client, pos[0]); err != nil {
+			return err
+		}
+		err = createArtifact(client, pos[0], pos[1], target)
 	case "get-artifact":
 		err = getArtifact(client, args[0])
 	case "list-artifacts", "artifacts":
-		err = listArtifacts(client, args[0])
+		projectID, jsonOutput, perr := parseListArtifactsFlags(cmd, args)
+		if perr != nil {
+			return perr
+		}
+		if projectID, err = resolveNotebook(client, projectID); err != nil {
+			return err
+		}
+		err = listArtifacts(client, projectID, jsonOutput)
 	case "rename-artifact":
 		err = renameArtifact(client, args[0], args[1])
 	case "delete-artifact":
@@ -784,25 +1171,87 @@ func runCmd(client *api.Client, cmd string, args ...string) error {
 	case "briefing-doc":
 		err = actOnSources(client, args[0], "briefing_doc", args[1:])
 	case "mindmap":
+		if args[0] == "export" {
+			opts, perr := parseMindmapExportFlags(args[1:])
+			if perr != nil {
+				return perr
+			}
+			err = mindmapExport(client, opts)
+			break
+		}
 		err = actOnSources(client, args[0], "interactive_mindmap", args[1:])
 	case "timeline":
 		err = actOnSources(client, args[0], "timeline", args[1:])
 	case "toc":
 		err = actOnSources(client, args[0], "table_of_contents", args[1:])
 	case "generate-chat":
-		err = generateFreeFormChat(client, args[0], args[1])
+		err = generateFreeFormChat(client, args[0], args[1], nil)
+	case "ask":
+		a, perr := parseAskFlags(args)
+		if perr != nil {
+			return perr
+		}
+		if a.NotebookID, err = resolveNotebook(client, a.NotebookID); err != nil {
+			return err
+		}
+		if a.Pick {
+			if a.SourceIDs, err = pickSources(client, a.NotebookID, a.SourceIDs); err != nil {
+				return err
+			}
+		}
+		err = generateFreeFormChat(client, a.NotebookID, a.Question, a.SourceIDs)
+	case "use":
+		err = useNotebook(client, args[0])
+	case "open":
+		err = runOpen(client, args)
 	case "chat":
 		err = interactiveChat(client, args[0])
 	case "chat-list":
 		err = listChatSessions()
 
+	// Study aid exports
+	case "flashcards":
+		err = runFlashcards(client, args)
+	case "quiz":
+		err = runQuiz(client, args)
+
+	// Guidebook operations
+	case "guidebook":
+		err = runGuidebook(client, args)
+
 	// Sharing operations
 	case "share":
-		err = shareNotebook(client, args[0])
+		err = runShare(client, args)
 	case "share-private":
 		err = shareNotebookPrivate(client, args[0])
 	case "share-details":
 		err = getShareDetails(client, args[0])
+	case "publish-site":
+		err = runPublishSite(client, args)
+	case "mirror":
+		err = runMirror(client, args)
+
+	// Job operations
+	case "jobs":
+		err = runJobs(client, args)
+
+	// Agent operations
+	case "mcp":
+		err = runMCP(client, args)
+	case "serve":
+		err = runServe(client, args)
+	case "discord":
+		err = runDiscord(client, args)
+	case "quick":
+		err = runQuick(client, args)
+	case "index":
+		err = runIndex(client, args)
+	case "audit":
+		err = runAudit(client, args)
+	case "bench":
+		err = runBench(args)
+	case "capabilities":
+		err = showCapabilities(client, args)
 
 	// Other operations
 	case "feedback":
@@ -814,6 +1263,7 @@ func runCmd(client *api.Client, cmd string, args ...string) error {
 		os.Exit(1)
 	}
 
+	recordHistory(cmd, args, err)
 	return err
 }
 
@@ -823,10 +1273,19 @@ func list(c *api.Client) error {
 	if err != nil {
 		return err
 	}
+	cacheList(notebooksKey(), notebooks)
+
+	// Machine-readable formats get every notebook.
+	return render(notebooks, func(out io.Writer) error {
+		return writeNotebooks(out, notebooks)
+	})
+}
 
+// writeNotebooks prints the first ten notebooks as a table.
+func writeNotebooks(out io.Writer, notebooks []*api.Notebook) error {
 	// Display total count
 	total := len(notebooks)
-	fmt.Printf("Total notebooks: %d (showing first 10)\n\n", total)
+	fmt.Fprintf(out, "Total notebooks: %d (showing first 10)\n\n", total)
 
 	// Limit to first 10 entries
 	limit := 10
@@ -834,7 +1293,7 @@ func list(c *api.Client) error {
 		limit = len(notebooks)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := newTable(out, 1)
 	fmt.Fprintln(w, "ID\tTITLE\tSOURCES\tLAST UPDATED")
 	for i := 0; i < limit; i++ {
 		nb := notebooks[i]
@@ -853,7 +1312,7 @@ func list(c *api.Client) error {
 		sourceCount := len(nb.Sources)
 		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n",
 			nb.ProjectId, title, sourceCount,
-			nb.GetMetadata().GetCreateTime().AsTime().Format(time.RFC3339),
+			formatTime(asTime(nb.GetMetadata().GetCreateTime())),
 		)
 	}
 	return w.Flush()
@@ -864,16 +1323,16 @@ func create(c *api.Client, title string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(notebook.ProjectId)
-	return nil
+	noteNotebook(notebook.ProjectId)
+	return render(notebook, func(out io.Writer) error {
+		_, err := fmt.Fprintln(out, notebook.ProjectId)
+		return err
+	})
 }
 
 func remove(c *api.Client, id string) error {
-	fmt.Printf("Are you sure you want to delete notebook %s? [y/N] ", id)
-	var response string
-	fmt.Scanln(&response)
-	if !strings.HasPrefix(strings.ToLower(response), "y") {
-		return fmt.Errorf("operation cancelled")
+	if ok, err := confirmAction("delete notebook %s", id); !ok {
+		return err
 	}
 	return c.DeleteProjects([]string{id})
 }
@@ -884,10 +1343,17 @@ func listSources(c *api.Client, notebookID string) error {
 	if err != nil {
 		return fmt.Errorf("list sources: %w", err)
 	}
+	cacheMessage(projectKey(notebookID), p)
+	return render(p.Sources, func(out io.Writer) error {
+		return writeSources(out, p.Sources)
+	})
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+// writeSources prints sources as a table.
+func writeSources(out io.Writer, sources []*pb.Source) error {
+	w := newTable(out, 1)
 	fmt.Fprintln(w, "ID\tTITLE\tTYPE\tSTATUS\tLAST UPDATED")
-	for _, src := range p.Sources {
+	for _, src := range sources {
 		status := "enabled"
 		if src.Metadata != nil {
 			status = src.Metadata.Status.String()
@@ -895,7 +1361,7 @@ func listSources(c *api.Client, notebookID string) error {
 
 		lastUpdated := "unknown"
 		if src.Metadata != nil && src.Metadata.LastModifiedTime != nil {
-			lastUpdated = src.Metadata.LastModifiedTime.AsTime().Format(time.RFC3339)
+			lastUpdated = formatTime(src.Metadata.LastModifiedTime.AsTime())
 		}
 
 		sourceType := "unknown"
@@ -914,11 +1380,49 @@ func listSources(c *api.Client, notebookID string) error {
 	return w.Flush()
 }
 
+// addSources adds each input to a notebook. When there are several, a
+// failure does not stop the rest; the inputs that failed are queued for
+// `nlm retry`.
+func addSources(c *api.Client, notebookID string, inputs []string) error {
+	var failed []retry.Item
+	for _, input := range inputs {
+		id, err := addSource(c, notebookID, input)
+		if id != "" {
+			noteAffected(id)
+		}
+		fmt.Println(id)
+		if err == nil {
+			continue
+		}
+		if len(inputs) == 1 {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "nlm: %s: %v\n", input, err)
+		if abs, aerr := filepath.Abs(input); aerr == nil && !strings.Contains(input, "://") {
+			input = abs
+		}
+		failed = append(failed, retry.Item{
+			Op:         retry.OpAdd,
+			NotebookID: notebookID,
+			Input:      input,
+			MIMEType:   mimeType,
+			Pages:      pdfPages,
+			OCR:        ocrPDFs,
+			Error:      err.Error(),
+		})
+	}
+	if len(failed) > 0 {
+		queueFailed(failed)
+		return fmt.Errorf("%d of %d sources failed to add", len(failed), len(inputs))
+	}
+	return nil
+}
+
 func addSource(c *api.Client, notebookID, input string) (string, error) {
 	// Handle special input designators
 	switch input {
 	case "-": // stdin
-		fmt.Fprintln(os.Stderr, "Reading from stdin...")
+		statusf("Reading from stdin...\n")
 		if mimeType != "" {
 			fmt.Fprintf(os.Stderr, "Using specified MIME type: %s\n", mimeType)
 			return c.AddSourceFromReader(notebookID, os.Stdin, "Pasted Text", mimeType)
@@ -930,13 +1434,28 @@ func addSource(c *api.Client, notebookID, input string) (string, error) {
 
 	// Check if input is a URL
 	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
-		fmt.Printf("Adding source from URL: %s\n", input)
+		sp := startSpinner("Adding source from URL: %s", input)
+		defer sp.Stop()
 		return c.AddSourceFromURL(notebookID, input)
 	}
 
 	// Try as local file
-	if _, err := os.Stat(input); err == nil {
-		fmt.Printf("Adding source from file: %s\n", input)
+	if _, err := os.Stat(input); err == nil && (pdfPages != "" || ocrPDFs) {
+		pdf, err := preparePDF(input)
+		if err != nil {
+			return "", err
+		}
+		defer pdf.Close()
+		if pdf.Text != "" {
+			sp := startSpinner("Adding recognized text of %s", filepath.Base(input))
+			defer sp.Stop()
+			return c.AddSourceFromText(notebookID, pdf.Text, filepath.Base(input))
+		}
+		input = pdf.Path
+	}
+	if fi, err := os.Stat(input); err == nil {
+		sp := startSpinner("Uploading %s (%s)", filepath.Base(input), formatSize(fi.Size()))
+		defer sp.Stop()
 		if mimeType != "" {
 			fmt.Fprintf(os.Stderr, "Using specified MIME type: %s\n", mimeType)
 			// Read the file and use AddSourceFromReader with the specified MIME type
@@ -951,16 +1470,14 @@ func addSource(c *api.Client, notebookID, input string) (string, error) {
 	}
 
 	// If it's not a URL or file, treat as direct text content
-	fmt.Println("Adding text content as source...")
+	sp := startSpinner("Adding text content as source")
+	defer sp.Stop()
 	return c.AddSourceFromText(notebookID, input, "Text Source")
 }
 
 func removeSource(c *api.Client, notebookID, sourceID string) error {
-	fmt.Printf("Are you sure you want to remove source %s? [y/N] ", sourceID)
-	var response string
-	fmt.Scanln(&response)
-	if !strings.HasPrefix(strings.ToLower(response), "y") {
-		return fmt.Errorf("operation cancelled")
+	if ok, err := confirmAction("remove source %s from notebook %s", sourceID, notebookID); !ok {
+		return err
 	}
 
 	if err := c.DeleteSources(notebookID, []string{sourceID}); err != nil {
@@ -985,10 +1502,13 @@ func renameSource(c *api.Client, sourceID, newName string) error {
 // Note operations
 func createNote(c *api.Client, notebookID, title string) error {
 	fmt.Printf("Creating note in notebook %s...\n", notebookID)
-	if _, err := c.CreateNote(notebookID, title, ""); err != nil {
+	note, err := c.CreateNote(notebookID, title, "")
+	if err != nil {
 		return fmt.Errorf("create note: %w", err)
 	}
+	noteAffected(note.GetSourceId().GetSourceId())
 	fmt.Printf("✅ Created note: %s\n", title)
+	copyResult(note.GetSourceId().GetSourceId())
 	return nil
 }
 
@@ -1002,11 +1522,8 @@ func updateNote(c *api.Client, notebookID, noteID, content, title string) error
 }
 
 func removeNote(c *api.Client, notebookID, noteID string) error {
-	fmt.Printf("Are you sure you want to remove note %s? [y/N] ", noteID)
-	var response string
-	fmt.Scanln(&response)
-	if !strings.HasPrefix(strings.ToLower(response), "y") {
-		return fmt.Errorf("operation cancelled")
+	if ok, err := confirmAction("remove note %s", noteID); !ok {
+		return err
 	}
 
 	if err := c.DeleteNotes(notebookID, []string{noteID}); err != nil {
@@ -1022,14 +1539,21 @@ func listNotes(c *api.Client, notebookID string) error {
 	if err != nil {
 		return fmt.Errorf("list notes: %w", err)
 	}
+	cacheList(notesKey(notebookID), notes)
+	return render(notes, func(out io.Writer) error {
+		return writeNotes(out, notes)
+	})
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+// writeNotes prints notes as a table.
+func writeNotes(out io.Writer, notes []*pb.Source) error {
+	w := newTable(out, 1)
 	fmt.Fprintln(w, "ID\tTITLE\tLAST MODIFIED")
 	for _, note := range notes {
 		fmt.Fprintf(w, "%s\t%s\t%s\n",
 			note.GetSourceId(),
 			note.Title,
-			note.GetMetadata().LastModifiedTime.AsTime().Format(time.RFC3339),
+			formatTime(asTime(note.GetMetadata().GetLastModifiedTime())),
 		)
 	}
 	return w.Flush()
@@ -1072,11 +1596,8 @@ func getAudioOverview(c *api.Client, projectID string) error {
 }
 
 func deleteAudioOverview(c *api.Client, notebookID string) error {
-	fmt.Printf("Are you sure you want to delete the audio overview? [y/N] ")
-	var response string
-	fmt.Scanln(&response)
-	if !strings.HasPrefix(strings.ToLower(response), "y") {
-		return fmt.Errorf("operation cancelled")
+	if ok, err := confirmAction("delete the audio overview of notebook %s", notebookID); !ok {
+		return err
 	}
 
 	if err := c.DeleteAudioOverview(notebookID); err != nil {
@@ -1103,8 +1624,7 @@ func generateNotebookGuide(c *api.Client, notebookID string) error {
 	if err != nil {
 		return fmt.Errorf("generate guide: %w", err)
 	}
-	fmt.Printf("Guide:\n%s\n", guide.Content)
-	return nil
+	return showMarkdown("# Guide\n\n" + guide.Content)
 }
 
 func generateOutline(c *api.Client, notebookID string) error {
@@ -1113,8 +1633,7 @@ func generateOutline(c *api.Client, notebookID string) error {
 	if err != nil {
 		return fmt.Errorf("generate outline: %w", err)
 	}
-	fmt.Printf("Outline:\n%s\n", outline.Content)
-	return nil
+	return showMarkdown("# Outline\n\n" + outline.Content)
 }
 
 func generateSection(c *api.Client, notebookID string) error {
@@ -1123,8 +1642,7 @@ func generateSection(c *api.Client, notebookID string) error {
 	if err != nil {
 		return fmt.Errorf("generate section: %w", err)
 	}
-	fmt.Printf("Section:\n%s\n", section.Content)
-	return nil
+	return showMarkdown("# Section\n\n" + section.Content)
 }
 
 func generateMagicView(c *api.Client, notebookID string, sourceIDs []string) error {
@@ -1204,18 +1722,20 @@ func actOnSources(c *api.Client, notebookID string, action string, sourceIDs []s
 // }
 
 // Other operations
-func createAudioOverview(c *api.Client, projectID string, instructions string) error {
+func createAudioOverview(c *api.Client, projectID string, opts api.AudioOverviewOptions, notifyTarget string) error {
 	fmt.Printf("Creating audio overview for notebook %s...\n", projectID)
-	fmt.Printf("Instructions: %s\n", instructions)
+	if opts.Instructions != "" {
+		fmt.Printf("Instructions: %s\n", opts.Instructions)
+	}
 
-	result, err := c.CreateAudioOverview(projectID, instructions)
+	result, err := c.CreateAudioOverviewWithOptions(projectID, opts)
 	if err != nil {
 		return fmt.Errorf("create audio overview: %w", err)
 	}
 
 	if !result.IsReady {
 		fmt.Println("✅ Audio overview creation started. Use 'nlm audio-get' to check status.")
-		return nil
+		return recordJob(jobs.KindAudio, projectID, result.AudioID, notifyTarget)
 	}
 
 	// If the result is immediately ready (unlikely but possible)
@@ -1265,7 +1785,7 @@ func getAnalytics(c *api.Client, projectID string) error {
 	fmt.Printf("  Notes: %d\n", analytics.NoteCount)
 	fmt.Printf("  Audio Overviews: %d\n", analytics.AudioOverviewCount)
 	if analytics.LastAccessed != nil {
-		fmt.Printf("  Last Accessed: %s\n", analytics.LastAccessed.AsTime().Format(time.RFC3339))
+		fmt.Printf("  Last Accessed: %s\n", formatTime(analytics.LastAccessed.AsTime()))
 	}
 
 	return nil
@@ -1283,11 +1803,17 @@ func listFeaturedProjects(c *api.Client) error {
 	if err != nil {
 		return fmt.Errorf("list featured projects: %w", err)
 	}
+	return render(resp.Projects, func(out io.Writer) error {
+		return writeFeaturedProjects(out, resp.Projects)
+	})
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+// writeFeaturedProjects prints featured notebooks as a table.
+func writeFeaturedProjects(out io.Writer, projects []*pb.Project) error {
+	w := newTable(out, 1)
 	fmt.Fprintln(w, "ID\tTITLE\tDESCRIPTION")
 
-	for _, project := range resp.Projects {
+	for _, project := range projects {
 		description := ""
 		if len(project.Sources) > 0 {
 			description = fmt.Sprintf("%d sources", len(project.Sources))
@@ -1340,7 +1866,7 @@ func checkSourceFreshness(c *api.Client, sourceID string) error {
 	}
 
 	if resp.LastChecked != nil {
-		fmt.Printf(" (last checked: %s)", resp.LastChecked.AsTime().Format(time.RFC3339))
+		fmt.Printf(" (last checked: %s)", formatTime(resp.LastChecked.AsTime()))
 	}
 	fmt.Println()
 
@@ -1367,7 +1893,7 @@ func discoverSources(c *api.Client, projectID, query string) error {
 		return nil
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := newTable(os.Stdout, 1)
 	fmt.Fprintln(w, "ID\tTITLE\tTYPE\tRELEVANCE")
 
 	for _, source := range resp.Sources {
@@ -1385,8 +1911,29 @@ func discoverSources(c *api.Client, projectID, query string) error {
 	return w.Flush()
 }
 
+// parseListArtifactsFlags parses `artifacts [-json] <notebook-id>`.
+func parseListArtifactsFlags(cmd string, args []string) (string, bool, error) {
+	var jsonOutput bool
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.BoolVar(&jsonOutput, "json", false, "print artifacts as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm %s <notebook-id>\n\n", cmd)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return "", false, fmt.Errorf("invalid arguments")
+	}
+	pos := withDefaultNotebook(fs.Args(), 1)
+	if len(pos) != 1 {
+		fs.Usage()
+		return "", false, fmt.Errorf("invalid arguments")
+	}
+	return pos[0], jsonOutput, nil
+}
+
 // Artifact management
-func createArtifact(c *api.Client, projectID, artifactType string) error {
+func createArtifact(c *api.Client, projectID, artifactType, notifyTarget string) error {
 	// Create orchestration service client
 	orchClient := service.NewLabsTailwindOrchestrationServiceClient(authToken, cookies)
 
@@ -1423,6 +1970,9 @@ func createArtifact(c *api.Client, projectID, artifactType string) error {
 	fmt.Printf("✅ Created artifact: %s\n", artifact.ArtifactId)
 	fmt.Printf("  Type: %s\n", artifact.Type.String())
 	fmt.Printf("  State: %s\n", artifact.State.String())
+	if artifact.State == pb.ArtifactState_ARTIFACT_STATE_CREATING {
+		return recordJob(jobs.KindArtifact, projectID, artifact.ArtifactId, notifyTarget)
+	}
 
 	return nil
 }
@@ -1456,7 +2006,7 @@ func getArtifact(c *api.Client, artifactID string) error {
 	return nil
 }
 
-func listArtifacts(c *api.Client, projectID string) error {
+func listArtifacts(c *api.Client, projectID string, jsonOutput bool) error {
 	// The orchestration service returns 400 Bad Request for list-artifacts
 	// Use direct RPC instead
 	if debug {
@@ -1468,34 +2018,45 @@ func listArtifacts(c *api.Client, projectID string) error {
 		return fmt.Errorf("list artifacts: %w", err)
 	}
 
-	return displayArtifacts(artifacts)
-}
-
-// listArtifactsDirectRPC uses direct RPC to list artifacts
-func listArtifactsDirectRPC(c *api.Client, projectID string) ([]*pb.Artifact, error) {
-	// Use the client's RPC capabilities
-	return c.ListArtifacts(projectID)
+	if artifacts == nil {
+		artifacts = []*api.Artifact{}
+	}
+	if jsonOutput {
+		// -json predates -output and is kept as a shorthand for it.
+		return (&outputSpec{Format: "json"}).render(os.Stdout, artifacts, nil)
+	}
+	return render(artifacts, func(out io.Writer) error {
+		return displayArtifacts(out, artifacts)
+	})
 }
 
 // displayArtifacts shows artifacts in a formatted table
-func displayArtifacts(artifacts []*pb.Artifact) error {
+func displayArtifacts(out io.Writer, artifacts []*api.Artifact) error {
 
 	if len(artifacts) == 0 {
-		fmt.Println("No artifacts found in project.")
+		fmt.Fprintln(out, "No artifacts found in project.")
 		return nil
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintln(w, "ID\tTYPE\tSTATE\tSOURCES")
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "ID\tTYPE\tTITLE\tSTATE\tUPDATED")
 
 	for _, artifact := range artifacts {
-		sourceCount := fmt.Sprintf("%d", len(artifact.Sources))
+		title := artifact.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+		updated := "-"
+		if !artifact.UpdatedAt.IsZero() {
+			updated = formatTime(artifact.UpdatedAt)
+		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			artifact.ArtifactId,
-			artifact.Type.String(),
-			artifact.State.String(),
-			sourceCount)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			artifact.ID,
+			artifact.TypeName(),
+			title,
+			artifact.StateName(),
+			updated)
 	}
 	return w.Flush()
 }
@@ -1509,30 +2070,19 @@ func renameArtifact(c *api.Client, artifactID, newTitle string) error {
 	}
 
 	fmt.Printf("✅ Artifact renamed successfully\n")
-	fmt.Printf("ID: %s\n", artifact.ArtifactId)
-	fmt.Printf("New Title: %s\n", newTitle)
+	fmt.Printf("ID: %s\n", artifact.ID)
+	fmt.Printf("New Title: %s\n", artifact.Title)
 
 	return nil
 }
 
 func deleteArtifact(c *api.Client, artifactID string) error {
-	fmt.Printf("Are you sure you want to delete artifact %s? [y/N] ", artifactID)
-	var response string
-	fmt.Scanln(&response)
-	if !strings.HasPrefix(strings.ToLower(response), "y") {
-		return fmt.Errorf("operation cancelled")
-	}
-
-	// Create orchestration service client
-	orchClient := service.NewLabsTailwindOrchestrationServiceClient(authToken, cookies)
-
-	req := &pb.DeleteArtifactRequest{
-		ArtifactId: artifactID,
+	if ok, err := confirmAction("delete artifact %s", artifactID); !ok {
+		return err
 	}
 
-	_, err := orchClient.DeleteArtifact(context.Background(), req)
-	if err != nil {
-		return fmt.Errorf("delete artifact: %w", err)
+	if err := c.DeleteArtifact(artifactID); err != nil {
+		return err
 	}
 
 	fmt.Printf("✅ Deleted artifact: %s\n", artifactID)
@@ -1540,65 +2090,26 @@ func deleteArtifact(c *api.Client, artifactID string) error {
 }
 
 // Generation operations
-func generateFreeFormChat(c *api.Client, projectID, prompt string) error {
+func generateFreeFormChat(c *api.Client, projectID, prompt string, sourceIDs []string) error {
 	fmt.Fprintf(os.Stderr, "Generating response for: %s\n", prompt)
 
-	// Use the API client's GenerateFreeFormStreamed method
-	response, err := c.GenerateFreeFormStreamed(projectID, prompt, nil)
+	prompt, err := withPersona(projectID, prompt)
+	if err != nil {
+		return err
+	}
+	// Use the API client's GenerateFreeFormStreamed method; no source IDs
+	// means all sources
+	response, err := c.GenerateFreeFormStreamed(projectID, prompt, sourceIDs)
 	if err != nil {
 		return fmt.Errorf("generate chat: %w", err)
 	}
 
 	// Display the response
-	if response != nil && response.Chunk != "" {
-		fmt.Println(response.Chunk)
-	} else {
+	if response == nil || response.Chunk == "" {
 		fmt.Println("(No response received)")
+		return nil
 	}
-
-	return nil
-}
-
-// Utility functions for commented-out operations
-func shareNotebook(c *api.Client, notebookID string) error {
-	fmt.Fprintf(os.Stderr, "Generating public share link...\n")
-
-	// Create RPC client directly for sharing project
-	rpcClient := rpc.New(authToken, cookies)
-	call := rpc.Call{
-		ID: "QDyure", // ShareProject RPC ID
-		Args: []interface{}{
-			notebookID,
-			map[string]interface{}{
-				"is_public":       true,
-				"allow_comments":  true,
-				"allow_downloads": false,
-			},
-		},
-	}
-
-	resp, err := rpcClient.Do(call)
-	if err != nil {
-		return fmt.Errorf("share project: %w", err)
-	}
-
-	// Parse response to extract share URL
-	var data []interface{}
-	if err := json.Unmarshal(resp, &data); err != nil {
-		return fmt.Errorf("parse response: %w", err)
-	}
-
-	if len(data) > 0 {
-		if shareData, ok := data[0].([]interface{}); ok && len(shareData) > 0 {
-			if shareURL, ok := shareData[0].(string); ok {
-				fmt.Printf("Share URL: %s\n", shareURL)
-				return nil
-			}
-		}
-	}
-
-	fmt.Printf("Project shared successfully (URL format not recognized)\n")
-	return nil
+	return showMarkdown(response.Chunk)
 }
 
 func submitFeedback(c *api.Client, message string) error {
@@ -1620,43 +2131,12 @@ func submitFeedback(c *api.Client, message string) error {
 }
 
 func shareNotebookPrivate(c *api.Client, notebookID string) error {
-	fmt.Fprintf(os.Stderr, "Generating private share link...\n")
-
-	// Create RPC client directly for sharing project
-	rpcClient := rpc.New(authToken, cookies)
-	call := rpc.Call{
-		ID: "QDyure", // ShareProject RPC ID
-		Args: []interface{}{
-			notebookID,
-			map[string]interface{}{
-				"is_public":       false,
-				"allow_comments":  false,
-				"allow_downloads": false,
-			},
-		},
-	}
-
-	resp, err := rpcClient.Do(call)
+	fmt.Fprintf(os.Stderr, "Restricting link access...\n")
+	info, err := c.SetLinkAccess(notebookID, api.LinkRestricted)
 	if err != nil {
 		return fmt.Errorf("share project privately: %w", err)
 	}
-
-	// Parse response to extract share URL
-	var data []interface{}
-	if err := json.Unmarshal(resp, &data); err != nil {
-		return fmt.Errorf("parse response: %w", err)
-	}
-
-	if len(data) > 0 {
-		if shareData, ok := data[0].([]interface{}); ok && len(shareData) > 0 {
-			if shareURL, ok := shareData[0].(string); ok {
-				fmt.Printf("Private Share URL: %s\n", shareURL)
-				return nil
-			}
-		}
-	}
-
-	fmt.Printf("Project shared privately (URL format not recognized)\n")
+	fmt.Printf("Private Share URL: %s\n", info.ShareURL)
 	return nil
 }
 
@@ -1731,7 +2211,13 @@ func saveChatSession(session *ChatSession) error {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return filelock.With(path, func() error {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, path)
+	})
 }
 
 func listChatSessions() error {
@@ -1773,7 +2259,7 @@ func listChatSessions() error {
 	fmt.Printf("📚 Chat Sessions (%d total)\n", len(sessions))
 	fmt.Println("=" + strings.Repeat("=", 40))
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := newTable(os.Stdout, 2)
 	fmt.Fprintln(w, "NOTEBOOK\tMESSAGES\tLAST UPDATED\tCREATED")
 	fmt.Fprintln(w, "--------\t--------\t------------\t-------")
 
@@ -1899,6 +2385,12 @@ func getFallbackResponse(input, notebookID string) string {
 
 // Interactive chat interface with history and streaming support
 func interactiveChat(c *api.Client, notebookID string) error {
+	// The notebook's persona, if any, goes ahead of every message
+	persona, err := withPersona(notebookID, "")
+	if err != nil {
+		return err
+	}
+
 	// Load or create chat session
 	session, err := loadChatSession(notebookID)
 	if err != nil {
@@ -2047,7 +2539,7 @@ func interactiveChat(c *api.Client, notebookID string) error {
 		fmt.Println("\n🤔 Thinking...")
 
 		// Build context from recent messages for better responses
-		contextualPrompt := buildContextualPrompt(session, input)
+		contextualPrompt := persona + buildContextualPrompt(session, input)
 
 		// Try the GenerateFreeFormStreamed API with streaming
 		response, err := generateStreamedResponse(c, notebookID, contextualPrompt)
@@ -2143,9 +2635,14 @@ func startAutoRefreshIfEnabled() {
 	}
 }
 
-func createVideoOverview(c *api.Client, projectID string, instructions string) error {
+func createVideoOverview(c *api.Client, projectID string, instructions string, notifyTarget string) error {
+	if err := requireFeature(c, api.FeatureVideoOverviews); err != nil {
+		return err
+	}
 	fmt.Printf("Creating video overview for notebook %s...\n", projectID)
-	fmt.Printf("Instructions: %s\n", instructions)
+	if instructions != "" {
+		fmt.Printf("Instructions: %s\n", instructions)
+	}
 
 	result, err := c.CreateVideoOverview(projectID, instructions)
 	if err != nil {
@@ -2155,7 +2652,7 @@ func createVideoOverview(c *api.Client, projectID string, instructions string) e
 	if !result.IsReady {
 		fmt.Println("✅ Video overview creation started. Video generation may take several minutes.")
 		fmt.Printf("  Project ID: %s\n", result.ProjectID)
-		return nil
+		return recordJob(jobs.KindVideo, projectID, result.VideoID, notifyTarget)
 	}
 
 	// If the result is immediately ready (unlikely but possible)
@@ -2183,7 +2680,7 @@ func listAudioOverviews(c *api.Client, notebookID string) error {
 		return nil
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := newTable(os.Stdout, 1)
 	fmt.Fprintln(w, "PROJECT\tTITLE\tSTATUS")
 	for _, audio := range audioOverviews {
 		status := "pending"
@@ -2216,7 +2713,7 @@ func listVideoOverviews(c *api.Client, notebookID string) error {
 		return nil
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := newTable(os.Stdout, 1)
 	fmt.Fprintln(w, "VIDEO_ID\tTITLE\tSTATUS")
 	for _, video := range videoOverviews {
 		status := "pending"
@@ -2237,7 +2734,8 @@ func listVideoOverviews(c *api.Client, notebookID string) error {
 }
 
 func downloadAudioOverview(c *api.Client, notebookID string, filename string) error {
-	fmt.Printf("Downloading audio overview for notebook %s...\n", notebookID)
+	sp := startSpinner("Downloading audio overview for notebook %s", notebookID)
+	defer sp.Stop()
 
 	// Generate default filename if not provided
 	if filename == "" {
@@ -2254,6 +2752,7 @@ func downloadAudioOverview(c *api.Client, notebookID string, filename string) er
 	if err := audioResult.SaveAudioToFile(filename); err != nil {
 		return fmt.Errorf("save audio file: %w", err)
 	}
+	sp.Stop()
 
 	fmt.Printf("✅ Audio saved to: %s\n", filename)
 
@@ -2266,7 +2765,8 @@ func downloadAudioOverview(c *api.Client, notebookID string, filename string) er
 }
 
 func downloadVideoOverview(c *api.Client, notebookID string, filename string) error {
-	fmt.Printf("Downloading video overview for notebook %s...\n", notebookID)
+	sp := startSpinner("Downloading video overview for notebook %s", notebookID)
+	defer sp.Stop()
 
 	// Generate default filename if not provided
 	if filename == "" {
@@ -2291,6 +2791,7 @@ func downloadVideoOverview(c *api.Client, notebookID string, filename string) er
 			return fmt.Errorf("save video file: %w", err)
 		}
 	}
+	sp.Stop()
 
 	fmt.Printf("✅ Video saved to: %s\n", filename)
 
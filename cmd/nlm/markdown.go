@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// raw is the -raw flag: print markdown as received, without styling or a
+// pager.
+var raw bool
+
+// Inline markdown spans, in the order they are styled.
+var (
+	mdCode   = regexp.MustCompile("`([^`]+)`")
+	mdBold   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalic = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*)\*|(^|[^_\w])_([^_\s][^_]*)_`)
+	mdLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBullet = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+	mdRule   = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+)
+
+// showMarkdown prints generated markdown to stdout. On a terminal it is
+// styled and, if taller than the screen, shown in a pager; with -raw or
+// when stdout is not a terminal it is printed unchanged. With -copy the
+// markdown is also put on the clipboard.
+func showMarkdown(md string) error {
+	copyResult(md)
+	if !strings.HasSuffix(md, "\n") {
+		md += "\n"
+	}
+	if raw || !term.IsTerminal(int(os.Stdout.Fd())) {
+		_, err := fmt.Print(md)
+		return err
+	}
+	out := renderMarkdown(md, useColor(os.Stdout))
+	if _, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil && strings.Count(out, "\n") >= height {
+		return page(out)
+	}
+	_, err := fmt.Print(out)
+	return err
+}
+
+// renderMarkdown styles markdown for a terminal: headings and bold text
+// are bold, code is dimmed, list bullets and rules are drawn with box
+// characters, and links show their target. Without color only the
+// markup is rewritten.
+func renderMarkdown(md string, color bool) string {
+	style := func(code, s string) string {
+		if !color || s == "" {
+			return s
+		}
+		return "\033[" + code + "m" + s + "\033[0m"
+	}
+	inline := func(s string) string {
+		// Code spans are styled last so their contents are left alone.
+		var spans []string
+		s = mdCode.ReplaceAllStringFunc(s, func(m string) string {
+			spans = append(spans, style(styleDim, mdCode.FindStringSubmatch(m)[1]))
+			return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+		})
+		s = mdLink.ReplaceAllStringFunc(s, func(m string) string {
+			sub := mdLink.FindStringSubmatch(m)
+			return sub[1] + " " + style(styleDim, "("+sub[2]+")")
+		})
+		s = mdBold.ReplaceAllStringFunc(s, func(m string) string {
+			sub := mdBold.FindStringSubmatch(m)
+			return style(styleBold, sub[1]+sub[2])
+		})
+		s = mdItalic.ReplaceAllStringFunc(s, func(m string) string {
+			sub := mdItalic.FindStringSubmatch(m)
+			return sub[1] + sub[3] + style(styleItalic, sub[2]+sub[4])
+		})
+		for i, span := range spans {
+			s = strings.Replace(s, fmt.Sprintf("\x00%d\x00", i), span, 1)
+		}
+		return s
+	}
+
+	var b strings.Builder
+	inFence := false
+	for _, line := range strings.SplitAfter(md, "\n") {
+		text := strings.TrimSuffix(line, "\n")
+		nl := line[len(text):]
+		trimmed := strings.TrimSpace(text)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			inFence = !inFence
+			continue
+		case inFence:
+			b.WriteString("    " + style(styleDim, text))
+		case strings.HasPrefix(trimmed, "#"):
+			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			b.WriteString(style(styleBold, inline(heading)))
+		case mdRule.MatchString(text):
+			b.WriteString(style(styleDim, strings.Repeat("─", 40)))
+		case strings.HasPrefix(trimmed, ">"):
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			b.WriteString(style(styleDim, "│ ") + style(styleItalic, inline(quote)))
+		case mdBullet.MatchString(text):
+			indent := mdBullet.FindStringSubmatch(text)[1]
+			b.WriteString(indent + "• " + inline(text[len(mdBullet.FindString(text)):]))
+		default:
+			b.WriteString(inline(text))
+		}
+		b.WriteString(nl)
+	}
+	return b.String()
+}
+
+// page shows s in the pager named by $NLM_PAGER or $PAGER, defaulting to
+// less, and prints it directly if the pager cannot be started. less is run
+// with -FRX, unless $LESS is set, so that color passes through and short
+// output is printed directly.
+func page(s string) error {
+	pager := os.Getenv("NLM_PAGER")
+	if pager == "" {
+		pager = os.Getenv("PAGER")
+	}
+	if pager == "" {
+		pager = "less"
+	}
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		_, err := fmt.Print(s)
+		return err
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	cmd.Stdin = strings.NewReader(s)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		_, err := fmt.Print(s)
+		return err
+	}
+	return cmd.Wait()
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		md    string
+		color bool
+		want  string
+	}{
+		{
+			name: "plain structure",
+			md:   "## Key Points\n\n- **Fast** and *simple*\n  * see [docs](https://example.com)\n---\n> quoted\n",
+			want: "Key Points\n\n• Fast and simple\n  • see docs (https://example.com)\n" +
+				"────────────────────────────────────────\n│ quoted\n",
+		},
+		{
+			name: "code",
+			md:   "Run `nlm *ls*`:\n```sh\nnlm **ls**\n```\n",
+			want: "Run nlm *ls*:\n    nlm **ls**\n",
+		},
+		{
+			name:  "color",
+			md:    "# Title\nsome **bold** and `code`",
+			color: true,
+			want:  "\033[1mTitle\033[0m\nsome \033[1mbold\033[0m and \033[2mcode\033[0m",
+		},
+		{
+			name: "snake_case and arithmetic are not emphasis",
+			md:   "use file_name_here and 2 * 3 * 4\n",
+			want: "use file_name_here and 2 * 3 * 4\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := renderMarkdown(tt.md, tt.color); got != tt.want {
+			t.Errorf("%s: renderMarkdown(%q) =\n%q\nwant\n%q", tt.name, tt.md, got, tt.want)
+		}
+	}
+}
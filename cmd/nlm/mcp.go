@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/mcp"
+)
+
+// mcpOptions contains the CLI options for `nlm mcp serve`.
+type mcpOptions struct {
+	SSE  bool
+	Addr string
+}
+
+func parseMCPFlags(args []string) (*mcpOptions, error) {
+	opts := &mcpOptions{}
+	fs := flag.NewFlagSet("mcp serve", flag.ContinueOnError)
+	fs.BoolVar(&opts.SSE, "sse", false, "serve HTTP with server-sent events instead of stdio")
+	fs.StringVar(&opts.Addr, "addr", "localhost:8931", "address to listen on with -sse")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm mcp serve [-sse] [-addr host:port]\n\n")
+		fmt.Fprintf(os.Stderr, "Serves NotebookLM tools to agents over the Model Context Protocol.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "serve" {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+func runMCP(c *api.Client, args []string) error {
+	opts, err := parseMCPFlags(args)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	srv := mcp.NewServer("nlm", currentVersion(), mcpTools(c)...)
+
+	if opts.SSE {
+		hs := &http.Server{Addr: opts.Addr, Handler: srv.Handler()}
+		go func() {
+			<-ctx.Done()
+			hs.Close()
+		}()
+		statusf("MCP server listening on http://%s/sse\n", opts.Addr)
+		if err := hs.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("mcp serve: %w", err)
+		}
+		return nil
+	}
+
+	// Stdout carries the protocol, so anything else the client prints
+	// while handling a call goes to stderr instead.
+	out := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = out }()
+	return srv.ServeStdio(ctx, os.Stdin, out)
+}
+
+// mcpTools returns the tools `nlm mcp serve` offers. Notebooks can be
+// given by ID, alias or title, as on the command line.
+func mcpTools(c *api.Client) []mcp.Tool {
+	notebook := mcp.Param{Name: "notebook", Description: "notebook ID, alias or title", Required: true}
+	return []mcp.Tool{
+		{
+			Name:        "list_notebooks",
+			Description: "List the NotebookLM notebooks in the account.",
+			InputSchema: mcp.Schema(),
+			Handler: func(context.Context, json.RawMessage) (string, error) {
+				notebooks, err := c.ListRecentlyViewedProjects()
+				if err != nil {
+					return "", err
+				}
+				type item struct {
+					ID      string `json:"id"`
+					Title   string `json:"title"`
+					Sources int    `json:"source_count"`
+				}
+				items := []item{}
+				for _, nb := range notebooks {
+					items = append(items, item{nb.GetProjectId(), nb.GetTitle(), len(nb.GetSources())})
+				}
+				return toolJSON(items)
+			},
+		},
+		{
+			Name:        "list_sources",
+			Description: "List the sources in a notebook.",
+			InputSchema: mcp.Schema(notebook),
+			Handler: func(_ context.Context, raw json.RawMessage) (string, error) {
+				var args struct{ Notebook string }
+				id, err := toolNotebook(c, raw, &args, &args.Notebook)
+				if err != nil {
+					return "", err
+				}
+				p, err := c.GetProject(id)
+				if err != nil {
+					return "", err
+				}
+				type item struct {
+					ID    string `json:"id"`
+					Title string `json:"title"`
+				}
+				items := []item{}
+				for _, s := range p.GetSources() {
+					items = append(items, item{s.GetSourceId().GetSourceId(), s.GetTitle()})
+				}
+				return toolJSON(items)
+			},
+		},
+		{
+			Name:        "add_source",
+			Description: "Add a source to a notebook from a URL or from text. Returns the new source ID.",
+			InputSchema: mcp.Schema(notebook,
+				mcp.Param{Name: "url", Description: "web page or YouTube URL to add"},
+				mcp.Param{Name: "text", Description: "text to add, instead of a URL"},
+				mcp.Param{Name: "title", Description: "title for a text source"}),
+			Handler: func(_ context.Context, raw json.RawMessage) (string, error) {
+				var args struct{ Notebook, URL, Text, Title string }
+				id, err := toolNotebook(c, raw, &args, &args.Notebook)
+				if err != nil {
+					return "", err
+				}
+				switch {
+				case (args.URL == "") == (args.Text == ""):
+					return "", fmt.Errorf("give exactly one of url or text")
+				case args.URL != "":
+					return c.AddSourceFromURL(id, args.URL)
+				}
+				if args.Title == "" {
+					args.Title = "Pasted Text"
+				}
+				return c.AddSourceFromText(id, args.Text, args.Title)
+			},
+		},
+		{
+			Name:        "ask_notebook",
+			Description: "Ask a question about a notebook's sources and return the answer in Markdown.",
+			InputSchema: mcp.Schema(notebook,
+				mcp.Param{Name: "question", Description: "the question to ask", Required: true},
+				mcp.Param{Name: "source_ids", Description: "sources to ask about (default: all)", List: true}),
+			Handler: func(_ context.Context, raw json.RawMessage) (string, error) {
+				var args struct {
+					Notebook  string
+					Question  string
+					SourceIDs []string `json:"source_ids"`
+				}
+				id, err := toolNotebook(c, raw, &args, &args.Notebook)
+				if err != nil {
+					return "", err
+				}
+				if strings.TrimSpace(args.Question) == "" {
+					return "", fmt.Errorf("question is required")
+				}
+				prompt, err := withPersona(id, args.Question)
+				if err != nil {
+					return "", err
+				}
+				resp, err := c.GenerateFreeFormStreamed(id, prompt, args.SourceIDs)
+				if err != nil {
+					return "", err
+				}
+				if resp == nil || resp.Chunk == "" {
+					return "(No response received)", nil
+				}
+				return resp.Chunk, nil
+			},
+		},
+		{
+			Name:        "create_note",
+			Description: "Create a note in a notebook. Returns the new note ID.",
+			InputSchema: mcp.Schema(notebook,
+				mcp.Param{Name: "title", Description: "note title", Required: true},
+				mcp.Param{Name: "content", Description: "note content"}),
+			Handler: func(_ context.Context, raw json.RawMessage) (string, error) {
+				var args struct{ Notebook, Title, Content string }
+				id, err := toolNotebook(c, raw, &args, &args.Notebook)
+				if err != nil {
+					return "", err
+				}
+				note, err := c.CreateNote(id, args.Title, args.Content)
+				if err != nil {
+					return "", err
+				}
+				return note.GetSourceId().GetSourceId(), nil
+			},
+		},
+		{
+			Name:        "list_artifacts",
+			Description: "List the artifacts (study guides, briefings, FAQs, timelines, ...) in a notebook.",
+			InputSchema: mcp.Schema(notebook),
+			Handler: func(_ context.Context, raw json.RawMessage) (string, error) {
+				var args struct{ Notebook string }
+				id, err := toolNotebook(c, raw, &args, &args.Notebook)
+				if err != nil {
+					return "", err
+				}
+				artifacts, err := c.ListArtifacts(id)
+				if err != nil {
+					return "", err
+				}
+				if artifacts == nil {
+					artifacts = []*api.Artifact{}
+				}
+				return toolJSON(artifacts)
+			},
+		},
+		{
+			Name:        "get_artifact",
+			Description: "Return the content of an artifact as Markdown.",
+			InputSchema: mcp.Schema(notebook,
+				mcp.Param{Name: "artifact_id", Description: "artifact ID from list_artifacts", Required: true}),
+			Handler: func(_ context.Context, raw json.RawMessage) (string, error) {
+				var args struct {
+					Notebook   string
+					ArtifactID string `json:"artifact_id"`
+				}
+				id, err := toolNotebook(c, raw, &args, &args.Notebook)
+				if err != nil {
+					return "", err
+				}
+				if args.ArtifactID == "" {
+					return "", fmt.Errorf("artifact_id is required")
+				}
+				content, err := c.GetArtifactContent(id, args.ArtifactID)
+				if err != nil {
+					return "", err
+				}
+				return content.Markdown, nil
+			},
+		},
+	}
+}
+
+// toolNotebook decodes a tool call's arguments into args and resolves the
+// notebook reference they hold in ref.
+func toolNotebook(c *api.Client, raw json.RawMessage, args interface{}, ref *string) (string, error) {
+	if err := json.Unmarshal(raw, args); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	if *ref == "" {
+		return "", fmt.Errorf("notebook is required")
+	}
+	return resolveNotebook(c, *ref)
+}
+
+func toolJSON(v interface{}) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	return string(b), err
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/cache"
+	"github.com/tmc/nlm/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// serverMetrics are the metrics `nlm serve -metrics` exposes. A nil
+// *serverMetrics records nothing.
+type serverMetrics struct {
+	registry     *metrics.Registry
+	httpRequests *metrics.CounterVec
+	httpLatency  *metrics.HistogramVec
+	grpcRequests *metrics.CounterVec
+	grpcLatency  *metrics.HistogramVec
+	errors       *metrics.CounterVec
+}
+
+// newServerMetrics registers the server's metrics, reading the cost of
+// NotebookLM requests from stats.
+func newServerMetrics(stats *batchexecute.Stats) *serverMetrics {
+	r := metrics.NewRegistry()
+	m := &serverMetrics{
+		registry:     r,
+		httpRequests: r.Counter("nlm_http_requests_total", "HTTP API requests served, by route and status code.", "route", "code"),
+		httpLatency:  r.Histogram("nlm_http_request_duration_seconds", "Time to answer HTTP API requests, by route.", metrics.DefBuckets, "route"),
+		grpcRequests: r.Counter("nlm_grpc_requests_total", "gRPC calls served, by method and status code.", "method", "code"),
+		grpcLatency:  r.Histogram("nlm_grpc_request_duration_seconds", "Time to answer gRPC calls, by method.", metrics.DefBuckets, "method"),
+		errors:       r.Counter("nlm_errors_total", "NotebookLM errors, by class; rate_limited counts exhausted quota.", "class"),
+	}
+	snapshot := func(field func(batchexecute.StatsSnapshot) float64) func() float64 {
+		return func() float64 { return field(stats.Snapshot()) }
+	}
+	r.CounterFunc("nlm_rpc_calls_total", "RPCs made to NotebookLM.",
+		snapshot(func(s batchexecute.StatsSnapshot) float64 { return float64(s.RPCs) }))
+	r.CounterFunc("nlm_rpc_requests_total", "HTTP requests sent to NotebookLM, including retries.",
+		snapshot(func(s batchexecute.StatsSnapshot) float64 { return float64(s.Requests) }))
+	r.CounterFunc("nlm_rpc_retries_total", "Requests to NotebookLM repeated after a retryable failure.",
+		snapshot(func(s batchexecute.StatsSnapshot) float64 { return float64(s.Retries) }))
+	r.CounterFunc("nlm_rpc_failures_total", "RPCs to NotebookLM that failed.",
+		snapshot(func(s batchexecute.StatsSnapshot) float64 { return float64(s.Failures) }))
+	r.CounterFunc("nlm_rpc_sent_bytes_total", "Request bytes sent to NotebookLM.",
+		snapshot(func(s batchexecute.StatsSnapshot) float64 { return float64(s.BytesSent) }))
+	r.CounterFunc("nlm_rpc_received_bytes_total", "Response bytes received from NotebookLM.",
+		snapshot(func(s batchexecute.StatsSnapshot) float64 { return float64(s.BytesReceived) }))
+	r.CounterFunc("nlm_rpc_wait_seconds_total", "Time spent waiting on NotebookLM.",
+		snapshot(func(s batchexecute.StatsSnapshot) float64 { return s.Latency.Seconds() }))
+	r.CounterFunc("nlm_cache_hits_total", "Local cache lookups that found an entry.", func() float64 {
+		hit, _ := cache.Lookups()
+		return float64(hit)
+	})
+	r.CounterFunc("nlm_cache_misses_total", "Local cache lookups that found nothing.", func() float64 {
+		_, miss := cache.Lookups()
+		return float64(miss)
+	})
+	return m
+}
+
+// recordError counts a NotebookLM error under its exit code class.
+func (m *serverMetrics) recordError(err error) {
+	if m == nil || err == nil {
+		return
+	}
+	m.errors.Inc(errorClasses[exitCode(err)])
+}
+
+// instrumentHTTP counts and times the requests h serves.
+func (m *serverMetrics) instrumentHTTP(h http.Handler) http.Handler {
+	if m == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		h.ServeHTTP(rec, r)
+		// The mux fills in the pattern it matched; requests it did not
+		// route, such as those refused for a bad key, have none.
+		route := r.Pattern
+		if route == "" {
+			route = "other"
+		}
+		m.httpRequests.Inc(route, strconv.Itoa(rec.code))
+		m.httpLatency.Observe(time.Since(start).Seconds(), route)
+	})
+}
+
+// statusRecorder notes the status code of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	code        int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.code, r.wroteHeader = code, true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets streamed answers through.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// unaryInterceptor counts and times unary gRPC calls.
+func (m *serverMetrics) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.recordGRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// streamInterceptor counts and times streaming gRPC calls.
+func (m *serverMetrics) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.recordGRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (m *serverMetrics) recordGRPC(method string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	m.grpcRequests.Inc(method, status.Code(err).String())
+	m.grpcLatency.Observe(time.Since(start).Seconds(), method)
+}
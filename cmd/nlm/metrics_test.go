@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+func TestServerMetrics(t *testing.T) {
+	m := newServerMetrics(new(batchexecute.Stats))
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/notebooks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.(http.Flusher).Flush()
+	})
+	h := m.instrumentHTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	for _, auth := range []string{"", "Bearer k", "Bearer k"} {
+		req := httptest.NewRequest("GET", "/v1/notebooks/abc", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	m.recordError(fmt.Errorf("get: %w", errNoNotebook))
+	m.recordError(nil)
+
+	var out strings.Builder
+	m.registry.WriteTo(&out)
+	for _, want := range []string{
+		`nlm_http_requests_total{route="other",code="401"} 1`,
+		`nlm_http_requests_total{route="GET /v1/notebooks/{id}",code="404"} 2`,
+		`nlm_http_request_duration_seconds_count{route="GET /v1/notebooks/{id}"} 2`,
+		`nlm_errors_total{class="not_found"} 1`,
+		`nlm_rpc_calls_total 0`,
+		"# TYPE nlm_cache_hits_total counter",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("metrics missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestNilServerMetrics(t *testing.T) {
+	var m *serverMetrics
+	h := http.NotFoundHandler()
+	if got := m.instrumentHTTP(h); fmt.Sprint(got) != fmt.Sprint(h) {
+		t.Errorf("nil instrumentHTTP wrapped the handler")
+	}
+	m.recordError(errNoNotebook)
+	m.recordGRPC("/x", time.Now(), nil)
+}
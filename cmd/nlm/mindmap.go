@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// mindmapExportArgs contains the CLI options for `mindmap export`
+type mindmapExportArgs struct {
+	NotebookID string
+	ArtifactID string
+	Format     string
+	Out        string
+}
+
+func parseMindmapExportFlags(args []string) (*mindmapExportArgs, error) {
+	opts := &mindmapExportArgs{}
+	fs := flag.NewFlagSet("mindmap export", flag.ContinueOnError)
+	fs.StringVar(&opts.Format, "format", "mermaid", "output format: mermaid, dot or opml")
+	fs.StringVar(&opts.Out, "out", "", "write to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm mindmap export <notebook-id> <artifact-id> [-format mermaid|dot|opml] [-out file]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	switch opts.Format {
+	case "mermaid", "dot", "opml":
+	default:
+		fs.Usage()
+		return nil, fmt.Errorf("unknown format %q", opts.Format)
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func mindmapExport(c *api.Client, opts *mindmapExportArgs) error {
+	var err error
+	if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+		return err
+	}
+	m, err := c.GetMindMap(opts.NotebookID, opts.ArtifactID)
+	if err != nil {
+		return err
+	}
+
+	var out string
+	switch opts.Format {
+	case "dot":
+		out = m.DOT()
+	case "opml":
+		if out, err = m.OPML(); err != nil {
+			return err
+		}
+	default:
+		out = m.Mermaid()
+	}
+
+	if opts.Out == "" {
+		fmt.Print(out)
+		return nil
+	}
+	if err := os.WriteFile(opts.Out, []byte(out), 0644); err != nil {
+		return fmt.Errorf("write mind map: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Saved %s to %s\n", m.ID, opts.Out)
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/mirror"
+)
+
+// mirrorArgs contains the CLI options for `nlm mirror`.
+type mirrorArgs struct {
+	NotebookID string
+	Repo       string
+}
+
+func parseMirrorFlags(args []string) (*mirrorArgs, error) {
+	opts := &mirrorArgs{}
+	fs := flag.NewFlagSet("mirror", flag.ContinueOnError)
+	fs.StringVar(&opts.Repo, "repo", "nb-mirror", "mirror into the git working tree `dir`")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm mirror [notebook-id] [-repo dir]\n\n")
+		fmt.Fprintf(os.Stderr, "Exports the notebook's source list, notes and artifact text into a git\n")
+		fmt.Fprintf(os.Stderr, "working tree and commits them with a summary of what changed, so that\n")
+		fmt.Fprintf(os.Stderr, "each run adds to a versioned history. The repository is created if needed.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 || opts.Repo == "" {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+	return opts, nil
+}
+
+func runMirror(c *api.Client, args []string) error {
+	opts, err := parseMirrorFlags(args)
+	if err != nil {
+		return err
+	}
+	id, err := resolveNotebook(c, opts.NotebookID)
+	if err != nil {
+		return err
+	}
+	nb, err := mirrorNotebook(c, id)
+	if err != nil {
+		return err
+	}
+	changes, err := mirror.Export(opts.Repo, nb)
+	if err != nil {
+		return err
+	}
+	hash, err := mirror.Commit(opts.Repo, mirror.Message(nb, changes))
+	if err != nil {
+		return err
+	}
+	if hash == "" {
+		fmt.Fprintf(os.Stderr, "No changes to %s since the last mirror\n", id)
+		return nil
+	}
+	for _, ch := range changes {
+		statusf("  %s\n", ch)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Mirrored %s to %s (commit %s, %d changes)\n", id, opts.Repo, hash, len(changes))
+	return nil
+}
+
+// mirrorNotebook fetches the content of a notebook to mirror.
+func mirrorNotebook(c *api.Client, id string) (*mirror.Notebook, error) {
+	p, err := c.GetProject(id)
+	if err != nil {
+		return nil, fmt.Errorf("get notebook: %w", err)
+	}
+	nb := &mirror.Notebook{
+		ID:    id,
+		Title: strings.TrimSpace(p.Title),
+		Emoji: strings.TrimSpace(p.Emoji),
+	}
+	for _, src := range p.Sources {
+		s := siteSource(src)
+		nb.Sources = append(nb.Sources, mirror.Source{ID: s.ID, Title: s.Title, Type: s.Type, URL: s.URL})
+	}
+
+	notes, err := c.GetNotes(id)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	for _, n := range notes {
+		nb.Notes = append(nb.Notes, mirror.Note{ID: n.GetSourceId().GetSourceId(), Title: strings.TrimSpace(n.Title)})
+	}
+
+	artifacts, err := c.ListArtifacts(id)
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts: %w", err)
+	}
+	for _, a := range artifacts {
+		ma := mirror.Artifact{ID: a.ID, Title: a.Title, Type: a.TypeName(), State: a.StateName()}
+		if textArtifactTypes[a.Type] && a.State == pb.ArtifactState_ARTIFACT_STATE_READY {
+			statusf("Fetching %s...\n", a.Title)
+			content, err := c.GetArtifactContent(id, a.ID)
+			if err != nil {
+				// Without its text the artifact would look deleted; fail
+				// rather than commit that.
+				return nil, fmt.Errorf("get content of %s: %w", a.ID, err)
+			}
+			ma.Markdown = content.Markdown
+		}
+		nb.Artifacts = append(nb.Artifacts, ma)
+	}
+	return nb, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/tmc/nlm/internal/jobs"
+	"github.com/tmc/nlm/internal/notify"
+)
+
+// watcherTimeout bounds how long a background job watcher keeps polling.
+const watcherTimeout = 2 * time.Hour
+
+func addNotifyFlag(fs *flag.FlagSet, target *string) {
+	fs.StringVar(target, "notify", "", "when generation finishes, run cmd://<command> or POST to an http(s) webhook")
+}
+
+// parseGenerationArgs parses the flags shared by generation commands that
+// otherwise take exactly nargs positional arguments.
+func parseGenerationArgs(cmd, usage string, nargs int, args []string) ([]string, string, error) {
+	var target string
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	addNotifyFlag(fs, &target)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm %s %s\n\n", cmd, usage)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, "", fmt.Errorf("invalid arguments")
+	}
+	pos := withDefaultNotebook(fs.Args(), nargs)
+	if len(pos) != nargs {
+		fs.Usage()
+		return nil, "", fmt.Errorf("invalid arguments")
+	}
+	if target != "" {
+		if err := notify.Validate(target); err != nil {
+			fs.Usage()
+			return nil, "", err
+		}
+	}
+	return pos, target, nil
+}
+
+// startJobWatcher re-runs nlm in the background as `nlm jobs wait <id>` so
+// the job's notification fires after this process has exited. Output goes
+// to ~/.nlm/job-<id>.log.
+func startJobWatcher(job jobs.Job) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("find executable: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("get home directory: %w", err)
+	}
+	logPath := filepath.Join(home, ".nlm", "job-"+job.ID+".log")
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("open watcher log: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(exe, "jobs", "wait", "-timeout", watcherTimeout.String(), job.ID)
+	cmd.Env = append(os.Environ(),
+		"NLM_AUTH_TOKEN="+authToken,
+		"NLM_COOKIES="+cookies,
+	)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start watcher: %w", err)
+	}
+	return cmd.Process.Release()
+}
+
+// notifyJob fires the job's notification if it has one that has not been
+// delivered yet.
+func notifyJob(store *jobs.Store, job jobs.Job) {
+	if job.Notify == "" || job.Notified || !job.Finished() {
+		return
+	}
+	ev := notify.Event{
+		JobID:      job.ID,
+		Kind:       string(job.Kind),
+		NotebookID: job.NotebookID,
+		ResourceID: job.ResourceID,
+		Status:     string(job.Status),
+		Error:      job.Error,
+		FinishedAt: job.UpdatedAt,
+	}
+	if err := notify.Send(context.Background(), job.Notify, ev); err != nil {
+		fmt.Fprintf(os.Stderr, "nlm: notify job %s: %v\n", job.ID, err)
+		return
+	}
+	if _, err := store.Update(job.ID, func(j *jobs.Job) { j.Notified = true }); err != nil {
+		fmt.Fprintf(os.Stderr, "nlm: update job: %v\n", err)
+	}
+}
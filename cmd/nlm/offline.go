@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/cache"
+	"github.com/tmc/nlm/internal/index"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// offline is the -offline flag: answer from the local cache instead of
+// NotebookLM.
+var offline bool
+
+// errOffline is returned for commands that need the network when run with
+// -offline.
+var errOffline = errors.New("not available offline")
+
+// Cache keys. Listing commands store what they fetch under these keys so
+// that -offline can show it again.
+func notebooksKey() string                { return "notebooks" }
+func projectKey(notebookID string) string { return "notebook/" + notebookID + "/project" }
+func notesKey(notebookID string) string   { return "notebook/" + notebookID + "/notes" }
+func artifactKey(notebookID, id string) string {
+	return "notebook/" + notebookID + "/artifacts/" + id + ".md"
+}
+
+// offlineEnabled reports whether -offline or NLM_OFFLINE=1 is set.
+func offlineEnabled() bool {
+	return offline || os.Getenv("NLM_OFFLINE") == "1"
+}
+
+// runOffline serves cmd from the cache, or failing that from the index
+// built by `nlm index`. Only the listing commands and `artifact cat` can be
+// answered; everything else fails with errOffline.
+func runOffline(cmd string, args []string) error {
+	err := runOfflineCached(cmd, args)
+	if errors.Is(err, cache.ErrNotCached) {
+		if ierr := runOfflineIndexed(cmd, args); !errors.Is(ierr, index.ErrNotIndexed) {
+			return ierr
+		}
+	}
+	return err
+}
+
+// runOfflineCached serves cmd from the cache.
+func runOfflineCached(cmd string, args []string) error {
+	switch cmd {
+	case "list", "ls":
+		var notebooks []*api.Notebook
+		if err := loadCachedList(notebooksKey(), &notebooks, "notebooks"); err != nil {
+			return err
+		}
+		return render(notebooks, func(out io.Writer) error {
+			return writeNotebooks(out, notebooks)
+		})
+	case "sources":
+		id, err := offlineNotebook(args[0])
+		if err != nil {
+			return err
+		}
+		p := &pb.Project{}
+		if err := loadCached(projectKey(id), p, "sources of notebook "+id); err != nil {
+			return err
+		}
+		return render(p.Sources, func(out io.Writer) error {
+			return writeSources(out, p.Sources)
+		})
+	case "notes":
+		id, err := offlineNotebook(args[0])
+		if err != nil {
+			return err
+		}
+		var notes []*pb.Source
+		if err := loadCachedList(notesKey(id), &notes, "notes of notebook "+id); err != nil {
+			return err
+		}
+		return render(notes, func(out io.Writer) error {
+			return writeNotes(out, notes)
+		})
+	case "artifact":
+		if args[0] != "cat" {
+			break
+		}
+		opts, err := parseArtifactCatFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = offlineNotebook(opts.NotebookID); err != nil {
+			return err
+		}
+		data, err := cacheGet(artifactKey(opts.NotebookID, opts.ArtifactID), "artifact "+opts.ArtifactID)
+		if err != nil {
+			return err
+		}
+		return writeArtifactContent(opts, opts.ArtifactID, string(data))
+	}
+	return fmt.Errorf("nlm %s: %w (-offline serves list, sources, notes and artifact cat from the cache and index)", cmd, errOffline)
+}
+
+// offlineNotebook resolves a notebook reference like resolveNotebook,
+// matching titles against the cached notebook list.
+func offlineNotebook(ref string) (string, error) {
+	if id := lookupAlias(ref); id != "" {
+		return id, nil
+	}
+	if notebookIDPattern.MatchString(ref) {
+		return ref, nil
+	}
+	var notebooks []*api.Notebook
+	if err := loadCachedList(notebooksKey(), &notebooks, "notebooks"); err != nil {
+		return "", err
+	}
+	return matchNotebook(notebooks, ref)
+}
+
+// cacheMessage stores m under key. Failing to cache is not fatal.
+func cacheMessage(key string, m proto.Message) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		verbosef(1, "nlm: warning: failed to cache %s: %v\n", key, err)
+		return
+	}
+	cachePut(key, data)
+}
+
+// cacheList stores msgs under key as a JSON array. Failing to cache is not
+// fatal.
+func cacheList[M proto.Message](key string, msgs []M) {
+	data, err := marshalList(msgs)
+	if err != nil {
+		verbosef(1, "nlm: warning: failed to cache %s: %v\n", key, err)
+		return
+	}
+	cachePut(key, data)
+}
+
+// marshalList encodes msgs as the JSON array cacheList stores.
+func marshalList[M proto.Message](msgs []M) ([]byte, error) {
+	items := make([]json.RawMessage, 0, len(msgs))
+	for _, m := range msgs {
+		data, err := protojson.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, data)
+	}
+	return json.Marshal(items)
+}
+
+// cachePut stores data under key. Failing to cache is not fatal.
+func cachePut(key string, data []byte) {
+	c, err := cache.OpenDefault()
+	if err == nil {
+		err = c.Put(key, data)
+	}
+	if err != nil {
+		verbosef(1, "nlm: warning: failed to cache %s: %v\n", key, err)
+	}
+}
+
+// cacheGet returns the data cached under key, describing it as what in
+// messages, and reports when it was cached.
+func cacheGet(key, what string) ([]byte, error) {
+	c, err := cache.OpenDefault()
+	if err != nil {
+		return nil, err
+	}
+	data, stored, err := c.Get(key)
+	if errors.Is(err, cache.ErrNotCached) {
+		return nil, fmt.Errorf("%s %w; run the command once while online", what, cache.ErrNotCached)
+	}
+	if err != nil {
+		return nil, err
+	}
+	statusf("nlm: offline: showing %s cached %s\n", what, formatTime(stored))
+	return data, nil
+}
+
+// loadCached decodes the message cached under key into m.
+func loadCached(key string, m proto.Message, what string) error {
+	data, err := cacheGet(key, what)
+	if err != nil {
+		return err
+	}
+	if err := protojson.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("read cached %s: %w", what, err)
+	}
+	return nil
+}
+
+// loadCachedList decodes the list cached under key into msgs.
+func loadCachedList[M proto.Message](key string, msgs *[]M, what string) error {
+	data, err := cacheGet(key, what)
+	if err != nil {
+		return err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("read cached %s: %w", what, err)
+	}
+	var zero M
+	list := make([]M, 0, len(items))
+	for _, item := range items {
+		m := zero.ProtoReflect().Type().New().Interface().(M)
+		if err := protojson.Unmarshal(item, m); err != nil {
+			return fmt.Errorf("read cached %s: %w", what, err)
+		}
+		list = append(list, m)
+	}
+	*msgs = list
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/cache"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	notebooks := []*api.Notebook{
+		{ProjectId: "11111111-2222-3333-4444-555555555555", Title: "Flight Reading"},
+		{ProjectId: "66666666-7777-8888-9999-000000000000", Title: "Demo Day"},
+	}
+	cacheList(notebooksKey(), notebooks)
+	var got []*api.Notebook
+	if err := loadCachedList(notebooksKey(), &got, "notebooks"); err != nil {
+		t.Fatalf("loadCachedList() error = %v", err)
+	}
+	if diff := cmp.Diff(notebooks, got, protocmp.Transform()); diff != "" {
+		t.Errorf("cached notebooks mismatch (-want +got):\n%s", diff)
+	}
+
+	project := &pb.Project{
+		ProjectId: notebooks[0].ProjectId,
+		Sources:   []*pb.Source{{SourceId: &pb.SourceId{SourceId: "src-1"}, Title: "Chapter One"}},
+	}
+	cacheMessage(projectKey(project.ProjectId), project)
+	gotProject := &pb.Project{}
+	if err := loadCached(projectKey(project.ProjectId), gotProject, "sources"); err != nil {
+		t.Fatalf("loadCached() error = %v", err)
+	}
+	if diff := cmp.Diff(project, gotProject, protocmp.Transform()); diff != "" {
+		t.Errorf("cached project mismatch (-want +got):\n%s", diff)
+	}
+
+	// Titles resolve against the cached notebook list.
+	if id, err := offlineNotebook("demo"); err != nil || id != notebooks[1].ProjectId {
+		t.Errorf("offlineNotebook(%q) = %q, %v; want %q", "demo", id, err, notebooks[1].ProjectId)
+	}
+	if _, err := offlineNotebook("missing"); !errors.Is(err, errNoNotebook) {
+		t.Errorf("offlineNotebook(%q) error = %v, want errNoNotebook", "missing", err)
+	}
+}
+
+func TestRunOfflineErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := runOffline("notes", []string{"11111111-2222-3333-4444-555555555555"}); !errors.Is(err, cache.ErrNotCached) || exitCode(err) != exitNotFound {
+		t.Errorf("runOffline(notes) error = %v, want ErrNotCached with exit code %d", err, exitNotFound)
+	}
+	if err := runOffline("add", []string{"nb", "paper.pdf"}); !errors.Is(err, errOffline) || exitCode(err) != exitOffline {
+		t.Errorf("runOffline(add) error = %v, want errOffline with exit code %d", err, exitOffline)
+	}
+}
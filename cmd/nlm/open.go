@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// openArgs contains the CLI options for `nlm open`.
+type openArgs struct {
+	NotebookID string
+	SourceID   string
+	NoteID     string
+	Print      bool // print the URL instead of opening it
+}
+
+func parseOpenFlags(args []string) (*openArgs, error) {
+	opts := &openArgs{}
+	fs := flag.NewFlagSet("open", flag.ContinueOnError)
+	fs.StringVar(&opts.SourceID, "source", "", "open the notebook at source `id`")
+	fs.StringVar(&opts.NoteID, "note", "", "open the notebook at note `id`")
+	fs.BoolVar(&opts.Print, "print", false, "print the URL instead of opening a browser")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm open [notebook] [-source id | -note id] [-print]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 || (opts.SourceID != "" && opts.NoteID != "") {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+	return opts, nil
+}
+
+// isLocalOpen reports whether `open` can run without authentication
+// because its notebook needs no lookup.
+func isLocalOpen(args []string) bool {
+	opts, err := parseOpenFlags(args)
+	return err == nil && isKnownNotebook(opts.NotebookID)
+}
+
+// runOpen opens a notebook in the web app. The client is only used to
+// resolve notebook titles and may be nil otherwise.
+func runOpen(c *api.Client, args []string) error {
+	opts, err := parseOpenFlags(args)
+	if err != nil {
+		return err
+	}
+	id := opts.NotebookID
+	if c != nil {
+		if id, err = resolveNotebook(c, id); err != nil {
+			return err
+		}
+	} else if aliased := lookupAlias(id); aliased != "" {
+		id = aliased
+	}
+	u := openURL(id, opts.SourceID, opts.NoteID)
+	if opts.Print {
+		fmt.Println(u)
+		return nil
+	}
+	statusf("Opening %s\n", u)
+	return openBrowser(u)
+}
+
+// openURL returns the web app URL of a notebook, optionally selecting one
+// of its sources or notes.
+func openURL(notebookID, sourceID, noteID string) string {
+	u := api.NotebookURL(notebookID)
+	q := url.Values{}
+	if sourceID != "" {
+		q.Set("source", sourceID)
+	}
+	if noteID != "" {
+		q.Set("note", noteID)
+	}
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	return u
+}
+
+// openBrowser opens u with $BROWSER if set, otherwise with the platform's
+// default handler.
+func openBrowser(u string) error {
+	var cmd *exec.Cmd
+	switch {
+	case os.Getenv("BROWSER") != "":
+		cmd = exec.Command(os.Getenv("BROWSER"), u)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("open", u)
+	case runtime.GOOS == "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("open browser: %w (use -print to show the URL)", err)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestOpenURL(t *testing.T) {
+	tests := []struct {
+		source, note string
+		want         string
+	}{
+		{want: "https://notebooklm.google.com/notebook/nb1"},
+		{source: "s 1", want: "https://notebooklm.google.com/notebook/nb1?source=s+1"},
+		{note: "n1", want: "https://notebooklm.google.com/notebook/nb1?note=n1"},
+	}
+	for _, tt := range tests {
+		if got := openURL("nb1", tt.source, tt.note); got != tt.want {
+			t.Errorf("openURL(nb1, %q, %q) = %q, want %q", tt.source, tt.note, got, tt.want)
+		}
+	}
+}
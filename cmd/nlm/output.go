@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// outputFlag is the -o/-output global flag. When it is empty the
+// NLM_OUTPUT environment variable, which a config profile may set, is
+// used.
+var outputFlag string
+
+const outputHelp = "output format: table, json, jsonl, yaml, or template=<go-template> (or set NLM_OUTPUT)"
+
+// outputSpec is a parsed output format.
+type outputSpec struct {
+	Format string // "table", "json", "jsonl", "yaml" or "template"
+	Tmpl   *template.Template
+}
+
+// parseOutput parses an output format. Templates are written as
+// template=<text> and are executed once per item for lists.
+func parseOutput(s string) (*outputSpec, error) {
+	name, text, hasText := strings.Cut(s, "=")
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "table":
+		if hasText {
+			break
+		}
+		return &outputSpec{Format: "table"}, nil
+	case "json", "jsonl", "yaml":
+		if hasText {
+			break
+		}
+		return &outputSpec{Format: strings.ToLower(name)}, nil
+	case "template", "go-template":
+		if text == "" {
+			return nil, fmt.Errorf("output template is empty")
+		}
+		tmpl, err := template.New("output").Funcs(outputFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parse output template: %w", err)
+		}
+		return &outputSpec{Format: "template", Tmpl: tmpl}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q (want table, json, jsonl, yaml or template=...)", s)
+}
+
+var outputFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(v interface{}) (string, error) {
+		b, err := marshalJSON(v)
+		return string(b), err
+	},
+}
+
+// currentOutput returns the output format selected for this invocation.
+func currentOutput() (*outputSpec, error) {
+	s := outputFlag
+	if s == "" {
+		s = os.Getenv("NLM_OUTPUT")
+	}
+	return parseOutput(s)
+}
+
+// jsonLines reports whether the selected output format is JSON lines.
+func jsonLines() bool {
+	spec, err := currentOutput()
+	return err == nil && spec.Format == "jsonl"
+}
+
+// render writes v to stdout in the selected output format. table writes
+// the human-readable form and is used for the table format.
+func render(v interface{}, table func(io.Writer) error) error {
+	spec, err := currentOutput()
+	if err != nil {
+		return err
+	}
+	return spec.render(os.Stdout, v, table)
+}
+
+func (s *outputSpec) render(out io.Writer, v interface{}, table func(io.Writer) error) error {
+	switch s.Format {
+	case "json":
+		b, err := marshalJSON(v)
+		if err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, b, "", "  "); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+		indented.WriteByte('\n')
+		_, err = indented.WriteTo(out)
+		return err
+	case "jsonl":
+		return writeJSONLines(out, v)
+	case "yaml":
+		b, err := marshalYAML(v)
+		if err != nil {
+			return fmt.Errorf("encode yaml: %w", err)
+		}
+		_, err = out.Write(b)
+		return err
+	case "template":
+		return s.execute(out, v)
+	default:
+		return table(out)
+	}
+}
+
+// listItems returns the elements of v if it is a slice, or v alone.
+func listItems(v interface{}) []interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return []interface{}{v}
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items
+}
+
+// execute runs the template once per element of a list, or once for any
+// other value, ending each result with a newline.
+func (s *outputSpec) execute(out io.Writer, v interface{}) error {
+	for _, item := range listItems(v) {
+		var b bytes.Buffer
+		if err := s.Tmpl.Execute(&b, item); err != nil {
+			return fmt.Errorf("execute output template: %w", err)
+		}
+		if !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+			b.WriteByte('\n')
+		}
+		if _, err := out.Write(b.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSONLines writes each element of a list, or any other value, as
+// one line of compact JSON. Lines are written as they are encoded, so a
+// consumer such as jq can start before the list ends.
+func writeJSONLines(out io.Writer, v interface{}) error {
+	for _, item := range listItems(v) {
+		b, err := marshalJSON(item)
+		if err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+		var line bytes.Buffer
+		if err := json.Compact(&line, b); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+		line.WriteByte('\n')
+		if _, err := line.WriteTo(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatTime renders t for people, in local time, or "-" if it is
+// unknown. JSON and YAML output keep times in RFC 3339.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// marshalJSON encodes v as compact JSON. Protocol buffer messages, alone
+// or in a slice, use their JSON mapping; other values use encoding/json.
+func marshalJSON(v interface{}) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.Marshal(m)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Implements(protoMessageType) {
+		list := make([]json.RawMessage, rv.Len())
+		for i := range list {
+			b, err := protojson.Marshal(rv.Index(i).Interface().(proto.Message))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = b
+		}
+		return json.Marshal(list)
+	}
+	return json.Marshal(v)
+}
+
+// marshalYAML encodes v as YAML with the same fields, in the same order,
+// as its JSON form.
+func marshalYAML(v interface{}) ([]byte, error) {
+	b, err := marshalJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML in flow style; reset the styles to get block style.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	resetStyle(&doc)
+	return yaml.Marshal(&doc)
+}
+
+func resetStyle(n *yaml.Node) {
+	// The encoder still quotes strings that would parse as another type.
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "", want: "table"},
+		{in: "table", want: "table"},
+		{in: "JSON", want: "json"},
+		{in: "yaml", want: "yaml"},
+		{in: "jsonl", want: "jsonl"},
+		{in: "template={{.Title}}", want: "template"},
+		{in: "go-template={{.Title}}", want: "template"},
+		{in: "template=", wantErr: true},
+		{in: "template={{.Title", wantErr: true},
+		{in: "json=x", wantErr: true},
+		{in: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			spec, err := parseOutput(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseOutput(%q) = %q, want error", tt.in, spec.Format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOutput(%q): %v", tt.in, err)
+			}
+			if spec.Format != tt.want {
+				t.Errorf("parseOutput(%q) = %q, want %q", tt.in, spec.Format, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	notebooks := []*api.Notebook{
+		{ProjectId: "nb1", Title: "First"},
+		{ProjectId: "nb2", Title: "Second", Sources: []*pb.Source{{Title: "a.txt"}}},
+	}
+	share := &api.ShareInfo{
+		ProjectID:     "nb1",
+		Access:        api.LinkAnyone,
+		Collaborators: []api.Collaborator{{Email: "a@example.com", Role: api.ShareRoleEditor}},
+	}
+	tests := []struct {
+		name   string
+		format string
+		v      interface{}
+		want   string
+	}{
+		{
+			name:   "json proto slice",
+			format: "json",
+			v:      notebooks,
+			want: `[
+  {
+    "title": "First",
+    "projectId": "nb1"
+  },
+  {
+    "title": "Second",
+    "sources": [
+      {
+        "title": "a.txt"
+      }
+    ],
+    "projectId": "nb2"
+  }
+]
+`,
+		},
+		{
+			name:   "yaml proto slice",
+			format: "yaml",
+			v:      notebooks,
+			want: `- title: First
+  projectId: nb1
+- title: Second
+  sources:
+    - title: a.txt
+  projectId: nb2
+`,
+		},
+		{
+			name:   "json enums by name",
+			format: "json",
+			v:      share.Collaborators,
+			want: `[
+  {
+    "Email": "a@example.com",
+    "Name": "",
+    "Role": "editor"
+  }
+]
+`,
+		},
+		{
+			name:   "jsonl proto slice",
+			format: "jsonl",
+			v:      notebooks,
+			want: `{"title":"First","projectId":"nb1"}
+{"title":"Second","sources":[{"title":"a.txt"}],"projectId":"nb2"}
+`,
+		},
+		{
+			name:   "jsonl single value",
+			format: "jsonl",
+			v:      share.Collaborators[0],
+			want:   `{"Email":"a@example.com","Name":"","Role":"editor"}` + "\n",
+		},
+		{
+			name:   "template per item",
+			format: "template={{.ProjectId}} {{.Title}}",
+			v:      notebooks,
+			want:   "nb1 First\nnb2 Second\n",
+		},
+		{
+			name:   "template single value",
+			format: "template={{.ProjectID}} {{.Access}} {{len .Collaborators}}",
+			v:      share,
+			want:   "nb1 anyone 1\n",
+		},
+		{
+			name:   "template json func",
+			format: `template={{json .Sources}}`,
+			v:      notebooks[1:],
+			want:   `[{"title":"a.txt"}]` + "\n",
+		},
+		{
+			name:   "yaml quotes numeric strings",
+			format: "yaml",
+			v:      map[string]string{"id": "123"},
+			want:   "id: \"123\"\n",
+		},
+		{
+			name:   "table",
+			format: "table",
+			v:      notebooks,
+			want:   "TABLE\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseOutput(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out bytes.Buffer
+			err = spec.render(&out, tt.v, func(w io.Writer) error {
+				_, err := io.WriteString(w, "TABLE\n")
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, out.String()); diff != "" {
+				t.Errorf("render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// pdfPages is the -pages flag: upload only this page range of PDFs.
+var pdfPages string
+
+// ocrPDFs is the -ocr flag: OCR image-only PDFs locally and upload the
+// recognized text.
+var ocrPDFs bool
+
+// minTextPerPage is how many letters and digits a page must average for a
+// PDF to count as having a text layer. Scans often carry a few stray
+// characters, such as a page number stamp, and no more.
+const minTextPerPage = 20
+
+// pageRange is a 1-based, inclusive range of pages. Last is 0 when the
+// range runs to the end of the document.
+type pageRange struct {
+	First, Last int
+}
+
+// parsePageRange parses "10-45", "10-" or "7".
+func parsePageRange(s string) (pageRange, error) {
+	first, last, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	var r pageRange
+	var err error
+	if r.First, err = strconv.Atoi(strings.TrimSpace(first)); err != nil || r.First < 1 {
+		return pageRange{}, fmt.Errorf("invalid page range %q (want N, N-M or N-)", s)
+	}
+	switch {
+	case !isRange:
+		r.Last = r.First
+	case strings.TrimSpace(last) != "":
+		if r.Last, err = strconv.Atoi(strings.TrimSpace(last)); err != nil || r.Last < r.First {
+			return pageRange{}, fmt.Errorf("invalid page range %q (want N, N-M or N-)", s)
+		}
+	}
+	return r, nil
+}
+
+// qpdfSpec returns the range in qpdf's page syntax.
+func (r pageRange) qpdfSpec() string {
+	switch {
+	case r.Last == 0:
+		return fmt.Sprintf("%d-z", r.First)
+	case r.Last == r.First:
+		return strconv.Itoa(r.First)
+	}
+	return fmt.Sprintf("%d-%d", r.First, r.Last)
+}
+
+// preparedPDF is a PDF made ready for upload by -pages and -ocr: either
+// a file to upload, or recognized text to add in its place.
+type preparedPDF struct {
+	Path    string
+	Text    string // set if the PDF was OCRed
+	cleanup func()
+}
+
+func (p *preparedPDF) Close() {
+	if p.cleanup != nil {
+		p.cleanup()
+	}
+}
+
+// preparePDF applies -pages and -ocr to the PDF at path. Splitting uses
+// qpdf; OCR uses pdftotext and pdftoppm from poppler, and tesseract.
+// PDFs that already have a text layer are uploaded as they are.
+func preparePDF(path string) (*preparedPDF, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".pdf") {
+		return nil, fmt.Errorf("-pages and -ocr apply only to PDF files, not %s", filepath.Base(path))
+	}
+	dir, err := os.MkdirTemp("", "nlm-pdf-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	p := &preparedPDF{Path: path, cleanup: func() { os.RemoveAll(dir) }}
+	if pdfPages != "" {
+		r, err := parsePageRange(pdfPages)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		out := filepath.Join(dir, filepath.Base(path))
+		if err := runTool("qpdf", "--empty", "--pages", path, r.qpdfSpec(), "--", out); err != nil {
+			p.Close()
+			return nil, fmt.Errorf("extract pages %s: %w", pdfPages, err)
+		}
+		p.Path = out
+	}
+	if !ocrPDFs {
+		return p, nil
+	}
+	text, err := toolOutput("pdftotext", "-layout", p.Path, "-")
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("check for a text layer: %w", err)
+	}
+	pages := strings.Count(text, "\f")
+	if hasTextLayer(text, pages) {
+		statusf("%s has a text layer; uploading it without OCR\n", filepath.Base(path))
+		return p, nil
+	}
+	if p.Text, err = ocrPDF(p.Path, dir); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// hasTextLayer reports whether pdftotext output for a PDF of the given
+// number of pages holds real text rather than scanning artifacts.
+func hasTextLayer(text string, pages int) bool {
+	if pages < 1 {
+		pages = 1
+	}
+	n := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			n++
+		}
+	}
+	return n >= minTextPerPage*pages
+}
+
+// ocrPDF renders each page of the PDF to an image in dir and returns the
+// text tesseract recognizes in them, pages separated by blank lines.
+func ocrPDF(path, dir string) (string, error) {
+	sp := startSpinner("Running OCR on %s", filepath.Base(path))
+	defer sp.Stop()
+	prefix := filepath.Join(dir, "page")
+	if err := runTool("pdftoppm", "-r", "300", "-png", path, prefix); err != nil {
+		return "", fmt.Errorf("render pages: %w", err)
+	}
+	images, err := filepath.Glob(prefix + "-*.png")
+	if err != nil || len(images) == 0 {
+		return "", fmt.Errorf("render pages: no pages rendered")
+	}
+	// pdftoppm pads page numbers to the same width, so names sort in
+	// page order.
+	sort.Strings(images)
+	var pages []string
+	for _, img := range images {
+		text, err := toolOutput("tesseract", img, "stdout")
+		if err != nil {
+			return "", fmt.Errorf("OCR %s: %w", filepath.Base(img), err)
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			pages = append(pages, text)
+		}
+	}
+	if len(pages) == 0 {
+		return "", fmt.Errorf("OCR found no text in %s", filepath.Base(path))
+	}
+	return strings.Join(pages, "\n\n"), nil
+}
+
+// runTool runs an external program, reporting its stderr on failure.
+func runTool(name string, args ...string) error {
+	_, err := toolOutput(name, args...)
+	return err
+}
+
+// toolOutput runs an external program and returns its stdout.
+func toolOutput(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%s not found; install it to use -pages or -ocr", name)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePageRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    pageRange
+		spec    string
+		wantErr bool
+	}{
+		{in: "10-45", want: pageRange{10, 45}, spec: "10-45"},
+		{in: "10-", want: pageRange{10, 0}, spec: "10-z"},
+		{in: "7", want: pageRange{7, 7}, spec: "7"},
+		{in: " 3 - 4 ", want: pageRange{3, 4}, spec: "3-4"},
+		{in: "", wantErr: true},
+		{in: "0-5", wantErr: true},
+		{in: "45-10", wantErr: true},
+		{in: "a-b", wantErr: true},
+		{in: "-5", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parsePageRange(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parsePageRange(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePageRange(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePageRange(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+		if spec := got.qpdfSpec(); spec != tt.spec {
+			t.Errorf("parsePageRange(%q).qpdfSpec() = %q, want %q", tt.in, spec, tt.spec)
+		}
+	}
+}
+
+func TestHasTextLayer(t *testing.T) {
+	page := strings.Repeat("The quick brown fox. ", 5)
+	if !hasTextLayer(page+"\f"+page+"\f", 2) {
+		t.Error("hasTextLayer = false for pages of text")
+	}
+	if hasTextLayer("  12 \f \f  13\f", 3) {
+		t.Error("hasTextLayer = true for pages holding only page numbers")
+	}
+	if hasTextLayer("", 0) {
+		t.Error("hasTextLayer = true for empty output")
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tmc/nlm/internal/config"
+)
+
+// personaUsage lists the `nlm persona` subcommands.
+const personaUsage = "usage: nlm persona <list|attach|detach> ...\n"
+
+func validatePersonaArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, personaUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	switch args[0] {
+	case "attach":
+		if len(args) != 3 {
+			fmt.Fprintf(os.Stderr, "usage: nlm persona attach <alias> <persona>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+	case "detach":
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "usage: nlm persona detach <alias>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+	case "list", "ls":
+		if len(args) != 1 {
+			fmt.Fprintf(os.Stderr, "usage: nlm persona list\n")
+			return fmt.Errorf("invalid arguments")
+		}
+	default:
+		fmt.Fprint(os.Stderr, personaUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	return nil
+}
+
+// runPersona attaches the personas defined in the active config profile
+// to aliases, and lists them. Personas themselves are written in the
+// config file.
+func runPersona(args []string) error {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	name := cfg.ActiveName(configProfile)
+
+	switch args[0] {
+	case "attach", "detach":
+		alias, persona := args[1], ""
+		if args[0] == "attach" {
+			persona = args[2]
+		}
+		err := config.Update(path, func(cfg *config.Config) error {
+			p := cfg.Lookup(name)
+			switch {
+			case p.Alias(alias) == "":
+				return fmt.Errorf("no alias %q in profile %q", alias, name)
+			case persona != "" && p.Personas[persona] == nil:
+				return fmt.Errorf("no persona %q in profile %q; define it under personas in %s", persona, name, path)
+			case persona == "" && p.AliasPersonas[alias] == "":
+				return fmt.Errorf("alias %q has no persona", alias)
+			}
+			p.SetAliasPersona(alias, persona)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "nlm: updated %s\n", path)
+		return nil
+	default:
+		personas := []personaListing{}
+		if p := cfg.Lookup(name); p != nil {
+			for n, persona := range p.Personas {
+				l := personaListing{
+					Name:     n,
+					Preamble: persona.Preamble,
+					Length:   persona.Length,
+					Tone:     persona.Tone,
+					Avoid:    persona.Avoid,
+					Aliases:  []string{},
+				}
+				for alias, attached := range p.AliasPersonas {
+					if attached == n {
+						l.Aliases = append(l.Aliases, alias)
+					}
+				}
+				sort.Strings(l.Aliases)
+				personas = append(personas, l)
+			}
+		}
+		sort.Slice(personas, func(i, j int) bool { return personas[i].Name < personas[j].Name })
+		return render(personas, func(out io.Writer) error {
+			return writePersonas(out, personas)
+		})
+	}
+}
+
+// personaListing is the machine-readable form of a persona.
+type personaListing struct {
+	Name     string   `json:"name"`
+	Preamble string   `json:"preamble,omitempty"`
+	Length   string   `json:"length,omitempty"`
+	Tone     string   `json:"tone,omitempty"`
+	Avoid    []string `json:"avoid,omitempty"`
+	Aliases  []string `json:"aliases"`
+}
+
+func writePersonas(out io.Writer, personas []personaListing) error {
+	if len(personas) == 0 {
+		fmt.Fprintln(out, "No personas defined.")
+		return nil
+	}
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "PERSONA\tALIASES\tPREAMBLE")
+	for _, p := range personas {
+		aliases := strings.Join(p.Aliases, ",")
+		if aliases == "" {
+			aliases = "-"
+		}
+		preamble := strings.Join(strings.Fields(p.Preamble), " ")
+		if r := []rune(preamble); len(r) > 50 {
+			preamble = string(r[:49]) + "…"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.Name, aliases, preamble)
+	}
+	return w.Flush()
+}
+
+// withPersona prepends the instructions of the persona attached to the
+// notebook, if any, to a prompt.
+func withPersona(notebookID, prompt string) (string, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return prompt, nil
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return "", err
+	}
+	name, persona, err := cfg.Lookup(cfg.ActiveName(configProfile)).PersonaFor(notebookID)
+	if err != nil || persona == nil {
+		return prompt, err
+	}
+	verbosef(1, "nlm: asking notebook %s as persona %q\n", notebookID, name)
+	return personaInstructions(persona) + prompt, nil
+}
+
+// personaInstructions renders a persona as the lines that precede a
+// question, ending with a blank line.
+func personaInstructions(p *config.Persona) string {
+	var b strings.Builder
+	if s := strings.TrimSpace(p.Preamble); s != "" {
+		b.WriteString(s + "\n")
+	}
+	if p.Length != "" {
+		fmt.Fprintf(&b, "Answer length: %s\n", p.Length)
+	}
+	if p.Tone != "" {
+		fmt.Fprintf(&b, "Tone: %s\n", p.Tone)
+	}
+	if len(p.Avoid) > 0 {
+		fmt.Fprintf(&b, "Do not discuss: %s\n", strings.Join(p.Avoid, ", "))
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return b.String() + "\n"
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tmc/nlm/internal/config"
+)
+
+func TestPersonaInstructions(t *testing.T) {
+	tests := []struct {
+		persona config.Persona
+		want    string
+	}{
+		{config.Persona{}, ""},
+		{config.Persona{Preamble: "  You are a tutor.\n"}, "You are a tutor.\n\n"},
+		{
+			config.Persona{Preamble: "You are a reviewer.", Length: "one paragraph", Tone: "formal", Avoid: []string{"pricing", "politics"}},
+			"You are a reviewer.\nAnswer length: one paragraph\nTone: formal\nDo not discuss: pricing, politics\n\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := personaInstructions(&tt.persona); got != tt.want {
+			t.Errorf("personaInstructions(%+v) = %q, want %q", tt.persona, got, tt.want)
+		}
+	}
+}
+
+func TestWithPersona(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("NLM_CONFIG", path)
+	const data = `profiles:
+  default:
+    aliases:
+      book: nb1
+      other: nb2
+    personas:
+      tutor:
+        length: short
+    alias_personas:
+      book: tutor
+      other: missing
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := withPersona("nb1", "Why?"); err != nil || got != "Answer length: short\n\nWhy?" {
+		t.Errorf("withPersona(nb1) = %q, %v", got, err)
+	}
+	if got, err := withPersona("nb3", "Why?"); err != nil || got != "Why?" {
+		t.Errorf("withPersona(nb3) = %q, %v; want the prompt unchanged", got, err)
+	}
+	if _, err := withPersona("nb2", "Why?"); err == nil {
+		t.Error("withPersona(nb2) with an undefined persona: want error")
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/i18n"
+	"golang.org/x/term"
+)
+
+// pickerRows is how many items the picker shows at once.
+const pickerRows = 10
+
+// sourceArgCommands are the commands that take a notebook followed by one
+// or more source IDs. Run on a terminal without source IDs, they let the
+// user pick the sources instead.
+var sourceArgCommands = map[string]bool{
+	"generate-magic": true, "generate-mindmap": true,
+	"rephrase": true, "expand": true, "summarize": true, "critique": true, "brainstorm": true,
+	"verify": true, "explain": true, "outline": true, "study-guide": true, "faq": true,
+	"briefing-doc": true, "mindmap": true, "timeline": true, "toc": true,
+}
+
+// canPick reports whether an interactive picker can be shown: stdin and
+// stderr, where it is drawn, are both terminals.
+func canPick() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && ansiTerminal(os.Stderr)
+}
+
+// pickSources asks the user to choose sources of the notebook, starting
+// with the given ones selected, and returns their IDs.
+func pickSources(c *api.Client, notebookID string, selected []string) ([]string, error) {
+	if !canPick() {
+		return nil, fmt.Errorf("choosing sources needs a terminal; pass source IDs instead")
+	}
+	sp := startSpinner("Loading sources")
+	p, err := c.GetProject(notebookID)
+	sp.Stop()
+	if err != nil {
+		return nil, fmt.Errorf("list sources: %w", err)
+	}
+	var items []pickItem
+	for _, s := range p.GetSources() {
+		if id := s.GetSourceId().GetSourceId(); id != "" {
+			items = append(items, pickItem{ID: id, Label: s.GetTitle()})
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("notebook %s has no sources", notebookID)
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("set up terminal: %w", err)
+	}
+	defer term.Restore(fd, state)
+	return newPicker(i18n.T("Sources"), items, selected).run(os.Stdin, os.Stderr)
+}
+
+// pickItem is one choice in a picker.
+type pickItem struct {
+	ID    string
+	Label string
+}
+
+// picker is a multi-select list narrowed by a fuzzy filter as the user
+// types. It is drawn in place on a terminal in raw mode.
+type picker struct {
+	title    string
+	items    []pickItem
+	selected map[int]bool // by index into items
+	query    []rune
+	visible  []int // indexes into items matching the query
+	cursor   int   // index into visible
+	top      int   // first visible row shown
+	drawn    int   // lines drawn last time, to redraw over
+}
+
+func newPicker(title string, items []pickItem, selected []string) *picker {
+	p := &picker{title: title, items: items, selected: make(map[int]bool)}
+	for i, it := range items {
+		for _, id := range selected {
+			if it.ID == id {
+				p.selected[i] = true
+			}
+		}
+	}
+	p.filter()
+	return p
+}
+
+// filter recomputes the items matching the query.
+func (p *picker) filter() {
+	p.visible = p.visible[:0]
+	for i, it := range p.items {
+		if fuzzyMatch(string(p.query), it.Label+" "+it.ID) {
+			p.visible = append(p.visible, i)
+		}
+	}
+	p.cursor, p.top = 0, 0
+}
+
+// fuzzyMatch reports whether the characters of query appear in s in
+// order, ignoring case and spaces in the query.
+func fuzzyMatch(query, s string) bool {
+	rs := []rune(strings.ToLower(s))
+	i := 0
+	for _, q := range strings.ToLower(query) {
+		if unicode.IsSpace(q) {
+			continue
+		}
+		for i < len(rs) && rs[i] != q {
+			i++
+		}
+		if i == len(rs) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// Keys the picker reacts to besides printable characters.
+const (
+	keyNone rune = -iota - 1
+	keyUp
+	keyDown
+	keyEnter
+	keyCancel
+	keyToggle
+	keyToggleAll
+	keyBackspace
+)
+
+// readKey reads one key press from a terminal in raw mode.
+func readKey(r *bufio.Reader) (rune, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	switch c {
+	case '\r', '\n':
+		return keyEnter, nil
+	case '\t':
+		return keyToggle, nil
+	case 0x01: // Ctrl-A
+		return keyToggleAll, nil
+	case 0x03, 0x07: // Ctrl-C, Ctrl-G
+		return keyCancel, nil
+	case 0x0e: // Ctrl-N
+		return keyDown, nil
+	case 0x10: // Ctrl-P
+		return keyUp, nil
+	case 0x7f, 0x08:
+		return keyBackspace, nil
+	case 0x1b:
+		// A lone Escape arrives by itself; arrow keys arrive as one
+		// sequence such as ESC [ A.
+		if r.Buffered() == 0 {
+			return keyCancel, nil
+		}
+		seq := make([]byte, 2)
+		if _, err := io.ReadFull(r, seq); err != nil {
+			return 0, err
+		}
+		switch string(seq) {
+		case "[A", "OA":
+			return keyUp, nil
+		case "[B", "OB":
+			return keyDown, nil
+		}
+		return keyNone, nil
+	}
+	if !unicode.IsPrint(c) {
+		return keyNone, nil
+	}
+	return c, nil
+}
+
+// handle applies a key press. It reports whether the user is done, and
+// returns errCancelled if they gave up.
+func (p *picker) handle(k rune) (bool, error) {
+	switch k {
+	case keyNone:
+	case keyCancel:
+		return true, errCancelled
+	case keyEnter:
+		// With nothing selected, Enter takes the highlighted item.
+		if len(p.selected) == 0 && len(p.visible) > 0 {
+			p.selected[p.visible[p.cursor]] = true
+		}
+		return len(p.selected) > 0, nil
+	case keyUp:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case keyDown:
+		if p.cursor < len(p.visible)-1 {
+			p.cursor++
+		}
+	case keyToggle:
+		if len(p.visible) > 0 {
+			p.toggle(p.visible[p.cursor])
+			if p.cursor < len(p.visible)-1 {
+				p.cursor++
+			}
+		}
+	case keyToggleAll:
+		all := true
+		for _, i := range p.visible {
+			all = all && p.selected[i]
+		}
+		for _, i := range p.visible {
+			if all {
+				delete(p.selected, i)
+			} else {
+				p.selected[i] = true
+			}
+		}
+	case keyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.filter()
+		}
+	default:
+		p.query = append(p.query, k)
+		p.filter()
+	}
+	if p.cursor < p.top {
+		p.top = p.cursor
+	} else if p.cursor >= p.top+pickerRows {
+		p.top = p.cursor - pickerRows + 1
+	}
+	return false, nil
+}
+
+func (p *picker) toggle(i int) {
+	if p.selected[i] {
+		delete(p.selected, i)
+	} else {
+		p.selected[i] = true
+	}
+}
+
+// chosen returns the IDs of the selected items in list order.
+func (p *picker) chosen() []string {
+	var ids []string
+	for i, it := range p.items {
+		if p.selected[i] {
+			ids = append(ids, it.ID)
+		}
+	}
+	return ids
+}
+
+// draw redraws the picker over its previous drawing.
+func (p *picker) draw(w io.Writer) {
+	var b strings.Builder
+	if p.drawn > 1 {
+		fmt.Fprintf(&b, "\033[%dA", p.drawn-1)
+	}
+	b.WriteString("\r\033[J")
+	fmt.Fprintf(&b, "%s (%d/%d, %s)\r\n", paint(w, styleBold, p.title), len(p.selected), len(p.items),
+		paint(w, styleDim, i18n.T("Tab selects, Enter accepts, Esc cancels")))
+	lines := 1
+	for row := p.top; row < len(p.visible) && row < p.top+pickerRows; row++ {
+		i := p.visible[row]
+		mark, pointer := "[ ]", "  "
+		if p.selected[i] {
+			mark = paint(w, styleGreen, "[x]")
+		}
+		if row == p.cursor {
+			pointer = paint(w, styleBold, "> ")
+		}
+		fmt.Fprintf(&b, "%s%s %s %s\r\n", pointer, mark, p.items[i].Label, paint(w, styleDim, p.items[i].ID))
+		lines++
+	}
+	if len(p.visible) == 0 {
+		fmt.Fprintf(&b, "  %s\r\n", paint(w, styleDim, i18n.T("no matches")))
+		lines++
+	}
+	fmt.Fprintf(&b, "> %s", string(p.query))
+	p.drawn = lines + 1
+	io.WriteString(w, b.String())
+}
+
+// run shows the picker until the user accepts or cancels, then clears it.
+func (p *picker) run(in io.Reader, out io.Writer) ([]string, error) {
+	r := bufio.NewReader(in)
+	for {
+		p.draw(out)
+		k, err := readKey(r)
+		if err == io.EOF {
+			err = errCancelled
+		}
+		if err == nil {
+			var done bool
+			done, err = p.handle(k)
+			if !done && err == nil {
+				continue
+			}
+		}
+		fmt.Fprintf(out, "\033[%dA\r\033[J", p.drawn-1)
+		if err != nil {
+			return nil, err
+		}
+		return p.chosen(), nil
+	}
+}
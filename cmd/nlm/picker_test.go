@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, s string
+		want     bool
+	}{
+		{"", "anything", true},
+		{"qm", "Quantum Mechanics", true},
+		{"quant mech", "Quantum Mechanics", true},
+		{"MECH", "quantum mechanics", true},
+		{"mq", "Quantum Mechanics", false},
+		{"über", "Über Physik", true},
+		{"src9", "Paper src1", false},
+	}
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.query, tt.s); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.query, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestPicker(t *testing.T) {
+	items := []pickItem{
+		{ID: "s1", Label: "Quantum Mechanics"},
+		{ID: "s2", Label: "Relativity"},
+		{ID: "s3", Label: "Quantum Field Theory"},
+	}
+	tests := []struct {
+		name     string
+		keys     string
+		selected []string
+		want     []string
+		wantErr  error
+	}{
+		{name: "enter takes the highlighted item", keys: "\r", want: []string{"s1"}},
+		{name: "arrow keys move", keys: "\x1b[B\x1b[B\r", want: []string{"s3"}},
+		{name: "tab selects several", keys: "\t\t\r", want: []string{"s1", "s2"}},
+		{name: "filter then select all", keys: "qua\x01\r", want: []string{"s1", "s3"}},
+		{name: "backspace widens the filter", keys: "rel\x7f\x7f\x7f\x1b[B\r", want: []string{"s2"}},
+		{name: "preselected", keys: "\r", selected: []string{"s3"}, want: []string{"s3"}},
+		{name: "ctrl-a toggles off", keys: "\x01\x01\x0e\r", want: []string{"s2"}},
+		{name: "ctrl-c cancels", keys: "\t\x03", wantErr: errCancelled},
+		{name: "end of input cancels", keys: "q", wantErr: errCancelled},
+		{name: "no match accepts nothing", keys: "zzz\r\x1b", wantErr: errCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := newPicker("Sources", items, tt.selected).run(strings.NewReader(tt.keys), &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("run() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("run() mismatch (-want +got):\n%s", diff)
+			}
+			if !strings.Contains(out.String(), "Quantum Mechanics") {
+				t.Errorf("picker did not draw the items:\n%q", out.String())
+			}
+		})
+	}
+}
+
+func TestParseAskFlags(t *testing.T) {
+	defer func(nb string) { notebookFlag = nb }(notebookFlag)
+	notebookFlag = "nb1"
+	got, err := parseAskFlags([]string{"why?", "-sources", "s1, s2", "-pick"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &askArgs{NotebookID: "nb1", Question: "why?", SourceIDs: []string{"s1", "s2"}, Pick: true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseAskFlags() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmc/nlm/internal/i18n"
+)
+
+// quiet suppresses progress indicators and status messages.
+var quiet bool
+
+// progressOut is where spinners, progress bars and status messages go.
+var progressOut io.Writer = os.Stderr
+
+// progressLive reports whether progress can be redrawn in place: stderr
+// is a terminal and neither -quiet nor -debug, whose output would
+// interleave with it, is set.
+func progressLive() bool {
+	if quiet || debug {
+		return false
+	}
+	f, ok := progressOut.(*os.File)
+	return ok && ansiTerminal(f)
+}
+
+// statusf prints a status message to stderr unless -quiet is set.
+func statusf(format string, args ...interface{}) {
+	if quiet {
+		return
+	}
+	fmt.Fprintf(progressOut, i18n.T(format), args...)
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinner shows that an operation of unknown length is still running.
+// On a terminal it animates with the elapsed time; elsewhere the message
+// is printed once so logs are not flooded.
+type spinner struct {
+	mu    sync.Mutex
+	msg   string
+	start time.Time
+	live  bool
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// startSpinner starts a spinner with the given message. Call Stop when
+// the operation ends.
+func startSpinner(format string, args ...interface{}) *spinner {
+	s := &spinner{
+		msg:   i18n.Sprintf(format, args...),
+		start: time.Now(),
+		live:  progressLive(),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if !s.live {
+		statusf("%s...\n", s.msg)
+		close(s.done)
+		return s
+	}
+	go s.run()
+	return s
+}
+
+func (s *spinner) run() {
+	defer close(s.done)
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		elapsed := time.Since(s.start).Truncate(time.Second)
+		fmt.Fprintf(progressOut, "\r\033[K%s %s (%v)", spinnerFrames[i%len(spinnerFrames)], s.msg, elapsed)
+		s.mu.Unlock()
+		select {
+		case <-s.stop:
+			fmt.Fprint(progressOut, "\r\033[K")
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Update changes the spinner's message. It is not printed when the
+// spinner is not animated.
+func (s *spinner) Update(format string, args ...interface{}) {
+	s.mu.Lock()
+	s.msg = fmt.Sprintf(format, args...)
+	s.mu.Unlock()
+}
+
+// Stop stops the spinner and clears its line. It is safe to call more
+// than once.
+func (s *spinner) Stop() {
+	if s.live {
+		select {
+		case <-s.stop:
+		default:
+			close(s.stop)
+		}
+	}
+	<-s.done
+}
+
+// onPoll is an api.WaitOptions.OnPoll callback that shows the poll count
+// next to the spinner's message.
+func (s *spinner) onPoll(attempt int, next time.Duration) {
+	s.mu.Lock()
+	base, _, _ := strings.Cut(s.msg, " [")
+	s.msg = fmt.Sprintf("%s [poll %d, next in %v]", base, attempt, next.Round(time.Second))
+	s.mu.Unlock()
+}
+
+// progressBar shows progress through a known number of steps.
+type progressBar struct {
+	mu    sync.Mutex
+	label string
+	n     int64
+	total int64
+	live  bool
+	drawn time.Time
+}
+
+// newProgressBar returns a bar for total steps. On anything but a
+// terminal it draws nothing.
+func newProgressBar(label string, total int64) *progressBar {
+	return &progressBar{label: i18n.T(label), total: total, live: progressLive()}
+}
+
+// Add advances the bar by n.
+func (b *progressBar) Add(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.n += n
+	// Redraw at most ten times a second, and always at the end.
+	if b.live && (b.n >= b.total || time.Since(b.drawn) >= 100*time.Millisecond) {
+		b.draw()
+	}
+}
+
+// Logf prints a line above the bar.
+func (b *progressBar) Logf(format string, args ...interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.live {
+		fmt.Fprint(progressOut, "\r\033[K")
+	}
+	fmt.Fprintf(progressOut, format, args...)
+	if b.live {
+		b.draw()
+	}
+}
+
+// Statusf is Logf for status messages, which -quiet suppresses.
+func (b *progressBar) Statusf(format string, args ...interface{}) {
+	if quiet {
+		return
+	}
+	b.Logf(format, args...)
+}
+
+// Finish clears the bar.
+func (b *progressBar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.live {
+		fmt.Fprint(progressOut, "\r\033[K")
+	}
+}
+
+func (b *progressBar) draw() {
+	fmt.Fprintf(progressOut, "\r\033[K%s", formatProgress(b.label, b.n, b.total, 30))
+	b.drawn = time.Now()
+}
+
+// formatProgress renders a progress line with a bar width cells wide.
+func formatProgress(label string, n, total int64, width int) string {
+	if total <= 0 {
+		return fmt.Sprintf("%s %d", label, n)
+	}
+	if n > total {
+		n = total
+	}
+	filled := int(int64(width) * n / total)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("%s %s %3d%% %d/%d", label, bar, 100*n/total, n, total)
+}
+
+// formatSize formats a byte count for display.
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFormatProgress(t *testing.T) {
+	tests := []struct {
+		n, total int64
+		want     string
+	}{
+		{0, 4, "Notebooks ░░░░░░░░   0% 0/4"},
+		{1, 4, "Notebooks ██░░░░░░  25% 1/4"},
+		{4, 4, "Notebooks ████████ 100% 4/4"},
+		{5, 4, "Notebooks ████████ 100% 4/4"},
+		{3, 0, "Notebooks 3"},
+	}
+	for _, tt := range tests {
+		if got := formatProgress("Notebooks", tt.n, tt.total, 8); got != tt.want {
+			t.Errorf("formatProgress(%d, %d) = %q, want %q", tt.n, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		1536:            "1.5 KB",
+		3 * 1024 * 1024: "3.0 MB",
+	}
+	for n, want := range tests {
+		if got := formatSize(n); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestProgressNotLive(t *testing.T) {
+	var out bytes.Buffer
+	defer func(q bool) { progressOut, quiet = os.Stderr, q }(quiet)
+	progressOut = &out
+
+	// Off a terminal the spinner prints its message once and the bar
+	// draws nothing, but log lines still appear.
+	sp := startSpinner("Uploading %s", "a.pdf")
+	sp.onPoll(1, 2*time.Second)
+	sp.Stop()
+	bar := newProgressBar("Notebooks", 2)
+	bar.Add(1)
+	bar.Logf("nlm: nb1: failed\n")
+	bar.Statusf("  nb2: saved\n")
+	bar.Finish()
+	want := "Uploading a.pdf...\nnlm: nb1: failed\n  nb2: saved\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	quiet = true
+	startSpinner("Uploading").Stop()
+	statusf("status\n")
+	newProgressBar("Notebooks", 1).Statusf("  nb1: saved\n")
+	if got := out.String(); got != "" {
+		t.Errorf("quiet output = %q, want none", got)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// quickArgs contains the CLI options for `nlm quick`.
+type quickArgs struct {
+	ListNotebooks bool
+	Ask           bool
+	NotebookID    string
+	Query         string
+}
+
+func parseQuickFlags(args []string) (*quickArgs, error) {
+	opts := &quickArgs{}
+	fs := flag.NewFlagSet("quick", flag.ContinueOnError)
+	fs.BoolVar(&opts.ListNotebooks, "list-notebooks", false, "list the notebooks whose titles contain the query")
+	fs.BoolVar(&opts.Ask, "ask", false, "ask a notebook the query")
+	fs.StringVar(&opts.NotebookID, "notebook", "", "notebook to ask (default: the working notebook)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm quick -list-notebooks [query]\n")
+		fmt.Fprintf(os.Stderr, "       nlm quick -ask [-notebook id] <question>\n\n")
+		fmt.Fprintf(os.Stderr, "Prints Alfred Script Filter JSON, which Raycast extensions can read too,\n")
+		fmt.Fprintf(os.Stderr, "for building launcher workflows on nlm. Errors are shown as an item.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if opts.ListNotebooks == opts.Ask || (opts.NotebookID != "" && !opts.Ask) {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if opts.Ask && opts.NotebookID == "" {
+		if opts.NotebookID = defaultNotebook(); opts.NotebookID == "" {
+			fs.Usage()
+			return nil, fmt.Errorf("invalid arguments")
+		}
+	}
+	// Launchers may pass the query as one argument or as several words.
+	opts.Query = strings.TrimSpace(strings.Join(pos, " "))
+	return opts, nil
+}
+
+// scriptFilter is the JSON an Alfred Script Filter prints.
+type scriptFilter struct {
+	Cache *scriptFilterCache `json:"cache,omitempty"`
+	Items []scriptFilterItem `json:"items"`
+}
+
+// scriptFilterCache asks Alfred to reuse results for a while.
+type scriptFilterCache struct {
+	Seconds     int  `json:"seconds"`
+	LooseReload bool `json:"loosereload,omitempty"`
+}
+
+type scriptFilterItem struct {
+	UID          string                     `json:"uid,omitempty"`
+	Title        string                     `json:"title"`
+	Subtitle     string                     `json:"subtitle,omitempty"`
+	Arg          string                     `json:"arg,omitempty"`
+	Autocomplete string                     `json:"autocomplete,omitempty"`
+	Valid        bool                       `json:"valid"`
+	QuicklookURL string                     `json:"quicklookurl,omitempty"`
+	Text         *scriptFilterText          `json:"text,omitempty"`
+	Mods         map[string]scriptFilterMod `json:"mods,omitempty"`
+}
+
+type scriptFilterText struct {
+	Copy      string `json:"copy,omitempty"`
+	LargeType string `json:"largetype,omitempty"`
+}
+
+type scriptFilterMod struct {
+	Arg      string `json:"arg"`
+	Subtitle string `json:"subtitle"`
+	Valid    bool   `json:"valid"`
+}
+
+func runQuick(c *api.Client, args []string) error {
+	opts, err := parseQuickFlags(args)
+	if err != nil {
+		return err
+	}
+	var sf *scriptFilter
+	if opts.ListNotebooks {
+		sf, err = quickNotebooks(c, opts.Query)
+	} else {
+		sf, err = quickAsk(c, opts.NotebookID, opts.Query)
+	}
+	if err != nil {
+		// Launchers show nothing for a failed script, so the error is
+		// shown as an item instead.
+		sf = &scriptFilter{Items: []scriptFilterItem{{Title: "nlm: " + err.Error(), Subtitle: quickErrorHint(err)}}}
+	}
+	return writeScriptFilter(os.Stdout, sf)
+}
+
+func writeScriptFilter(w io.Writer, sf *scriptFilter) error {
+	if sf.Items == nil {
+		sf.Items = []scriptFilterItem{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(sf)
+}
+
+// quickNotebooks lists notebooks whose titles contain query. Enter passes
+// the notebook ID on; ⌘-Enter passes its NotebookLM URL.
+func quickNotebooks(c *api.Client, query string) (*scriptFilter, error) {
+	notebooks, err := c.ListRecentlyViewedProjects()
+	if err != nil {
+		return nil, err
+	}
+	sf := &scriptFilter{Cache: &scriptFilterCache{Seconds: 60, LooseReload: true}}
+	for _, nb := range notebooks {
+		title := strings.TrimSpace(nb.Title)
+		if query != "" && !strings.Contains(strings.ToLower(title), strings.ToLower(query)) {
+			continue
+		}
+		u := api.NotebookURL(nb.ProjectId)
+		sf.Items = append(sf.Items, scriptFilterItem{
+			UID:          nb.ProjectId,
+			Title:        strings.TrimSpace(strings.TrimSpace(nb.Emoji) + " " + title),
+			Subtitle:     fmt.Sprintf("%d sources · %s", len(nb.Sources), nb.ProjectId),
+			Arg:          nb.ProjectId,
+			Autocomplete: title,
+			Valid:        true,
+			QuicklookURL: u,
+			Text:         &scriptFilterText{Copy: nb.ProjectId},
+			Mods:         map[string]scriptFilterMod{"cmd": {Arg: u, Subtitle: "Open in NotebookLM", Valid: true}},
+		})
+	}
+	if len(sf.Items) == 0 {
+		sf.Items = append(sf.Items, scriptFilterItem{Title: "No matching notebooks", Subtitle: query})
+	}
+	return sf, nil
+}
+
+// quickAsk asks a notebook a question. The answer is the item's argument,
+// so that Enter can copy or paste it.
+func quickAsk(c *api.Client, ref, question string) (*scriptFilter, error) {
+	id, err := resolveNotebook(c, ref)
+	if err != nil {
+		return nil, err
+	}
+	if question == "" {
+		return &scriptFilter{Items: []scriptFilterItem{{Title: "Ask a question…", Subtitle: "Type a question for notebook " + id}}}, nil
+	}
+	prompt, err := withPersona(id, question)
+	if err != nil {
+		return nil, err
+	}
+	var answer strings.Builder
+	if err := c.GenerateFreeFormStreamedWithCallback(id, prompt, nil, func(chunk string) bool {
+		answer.WriteString(chunk)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(answer.String())
+	if text == "" {
+		return nil, fmt.Errorf("the notebook returned no answer")
+	}
+	return &scriptFilter{Items: []scriptFilterItem{{
+		Title:        quickTitle(text),
+		Subtitle:     question,
+		Arg:          text,
+		Valid:        true,
+		QuicklookURL: api.NotebookURL(id),
+		Text:         &scriptFilterText{Copy: text, LargeType: text},
+		Mods:         map[string]scriptFilterMod{"cmd": {Arg: api.NotebookURL(id), Subtitle: "Open the notebook in NotebookLM", Valid: true}},
+	}}}, nil
+}
+
+// quickTitle returns the first line of an answer, short enough for a
+// launcher row.
+func quickTitle(answer string) string {
+	line, _, _ := strings.Cut(answer, "\n")
+	return truncateArg(strings.TrimSpace(strings.TrimLeft(line, "#*- ")))
+}
+
+// quickErrorHint suggests a fix for an error shown as an item.
+func quickErrorHint(err error) string {
+	switch exitCode(err) {
+	case exitAuth:
+		return "Run 'nlm auth' in a terminal"
+	case exitNotFound:
+		return "Check the notebook ID or alias"
+	case exitRateLimited:
+		return "Rate limited; try again shortly"
+	}
+	return "See 'nlm quick -help'"
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParseQuickFlags(t *testing.T) {
+	t.Setenv("NLM_NOTEBOOK", "")
+	opts, err := parseQuickFlags([]string{"-list-notebooks", "quantum", "physics"})
+	if err != nil || !opts.ListNotebooks || opts.Query != "quantum physics" {
+		t.Errorf("parseQuickFlags(-list-notebooks) = %+v, %v", opts, err)
+	}
+	opts, err = parseQuickFlags([]string{"-ask", "-notebook", "nb1", "what is it? "})
+	if err != nil || !opts.Ask || opts.NotebookID != "nb1" || opts.Query != "what is it?" {
+		t.Errorf("parseQuickFlags(-ask) = %+v, %v", opts, err)
+	}
+	for _, args := range [][]string{
+		{},
+		{"-ask", "-list-notebooks"},
+		{"-ask", "question"}, // no working notebook
+		{"-list-notebooks", "-notebook", "nb1"},
+	} {
+		if _, err := parseQuickFlags(args); err == nil {
+			t.Errorf("parseQuickFlags(%q) succeeded", args)
+		}
+	}
+	t.Setenv("NLM_NOTEBOOK", "nb2")
+	if opts, err := parseQuickFlags([]string{"-ask", "why?"}); err != nil || opts.NotebookID != "nb2" {
+		t.Errorf("parseQuickFlags with a working notebook = %+v, %v", opts, err)
+	}
+}
+
+func TestWriteScriptFilter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeScriptFilter(&buf, &scriptFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "{\"items\":[]}\n" {
+		t.Errorf("empty script filter = %q", got)
+	}
+	buf.Reset()
+	sf := &scriptFilter{Items: []scriptFilterItem{{
+		Title: "A <b> & c",
+		Arg:   "nb1",
+		Valid: true,
+		Mods:  map[string]scriptFilterMod{"cmd": {Arg: "https://x", Subtitle: "Open", Valid: true}},
+	}}}
+	if err := writeScriptFilter(&buf, sf); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"items":[{"title":"A <b> & c","arg":"nb1","valid":true,"mods":{"cmd":{"arg":"https://x","subtitle":"Open","valid":true}}}]}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("script filter =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestQuickTitle(t *testing.T) {
+	for in, want := range map[string]string{
+		"## Entanglement\n\nIt links particles.": "Entanglement",
+		"**Short answer**: yes":                  "Short answer**: yes",
+		"plain":                                  "plain",
+	} {
+		if got := quickTitle(in); got != want {
+			t.Errorf("quickTitle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestQuickErrorHint(t *testing.T) {
+	if got := quickErrorHint(errors.New("boom")); got != "See 'nlm quick -help'" {
+		t.Errorf("quickErrorHint = %q", got)
+	}
+	if got := quickErrorHint(errNoNotebook); got != "Check the notebook ID or alias" {
+		t.Errorf("quickErrorHint(errNoNotebook) = %q", got)
+	}
+}
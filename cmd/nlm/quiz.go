@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+const quizUsage = "usage: nlm quiz <export|take> <notebook-id> <artifact-id> [options]\n"
+
+func validateQuizArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, quizUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	switch args[0] {
+	case "export":
+		_, err := parseQuizExportFlags(args[1:])
+		return err
+	case "take":
+		if len(args) != 3 {
+			fmt.Fprint(os.Stderr, "usage: nlm quiz take <notebook-id> <artifact-id>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, quizUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+// quizExportArgs contains the CLI options for `quiz export`
+type quizExportArgs struct {
+	NotebookID string
+	ArtifactID string
+	Format     string
+	Out        string
+}
+
+func parseQuizExportFlags(args []string) (*quizExportArgs, error) {
+	opts := &quizExportArgs{}
+	fs := flag.NewFlagSet("quiz export", flag.ContinueOnError)
+	fs.StringVar(&opts.Format, "format", "json", "output format: json, gift")
+	fs.StringVar(&opts.Out, "out", "", "write to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, "usage: nlm quiz export <notebook-id> <artifact-id> [-format json|gift] [-out file]\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 2)
+	if len(pos) != 2 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	switch opts.Format {
+	case "json", "gift":
+	default:
+		fs.Usage()
+		return nil, fmt.Errorf("unknown format %q", opts.Format)
+	}
+	opts.NotebookID, opts.ArtifactID = pos[0], pos[1]
+	return opts, nil
+}
+
+func runQuiz(c *api.Client, args []string) error {
+	switch args[0] {
+	case "export":
+		return quizExport(c, args[1:])
+	case "take":
+		notebookID, err := resolveNotebook(c, args[1])
+		if err != nil {
+			return err
+		}
+		quiz, err := c.GetQuiz(notebookID, args[2])
+		if err != nil {
+			return err
+		}
+		takeQuiz(os.Stdin, os.Stdout, quiz)
+		return nil
+	default:
+		return fmt.Errorf("unknown quiz command %q", args[0])
+	}
+}
+
+func quizExport(c *api.Client, args []string) error {
+	opts, err := parseQuizExportFlags(args)
+	if err != nil {
+		return err
+	}
+	if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+		return err
+	}
+	quiz, err := c.GetQuiz(opts.NotebookID, opts.ArtifactID)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	switch opts.Format {
+	case "gift":
+		out = []byte(quiz.GIFT())
+	default:
+		doc := struct {
+			ID        string             `json:"id"`
+			Title     string             `json:"title,omitempty"`
+			Questions []api.QuizQuestion `json:"questions"`
+		}{quiz.ID, quiz.Title, quiz.Questions}
+		out, err = json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode quiz: %w", err)
+		}
+		out = append(out, '\n')
+	}
+	if opts.Out == "" {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(opts.Out, out, 0644); err != nil {
+		return fmt.Errorf("write quiz: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Saved %d questions to %s\n", len(quiz.Questions), opts.Out)
+	return nil
+}
+
+// takeQuiz runs the quiz interactively: each question is shown with
+// lettered options, the answer is read from in, and the correct answer and
+// rationale are shown before moving on. Entering "?" shows the hint and
+// "q" stops early. It returns the number of correct answers.
+func takeQuiz(in io.Reader, out io.Writer, quiz *api.Quiz) int {
+	scanner := bufio.NewScanner(in)
+	score, asked := 0, 0
+	total := len(quiz.Questions)
+	if quiz.Title != "" {
+		fmt.Fprintf(out, "%s\n\n", quiz.Title)
+	}
+
+questions:
+	for i, q := range quiz.Questions {
+		fmt.Fprintf(out, "Question %d/%d: %s\n", i+1, total, q.Question)
+		for j, opt := range q.Options {
+			fmt.Fprintf(out, "  %c) %s\n", 'a'+j, opt.Text)
+		}
+
+		var choice int
+		for {
+			fmt.Fprint(out, "Answer: ")
+			if !scanner.Scan() {
+				fmt.Fprintln(out)
+				break questions
+			}
+			answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+			switch {
+			case answer == "q":
+				break questions
+			case answer == "?":
+				if q.Hint != "" {
+					fmt.Fprintf(out, "Hint: %s\n", q.Hint)
+				} else {
+					fmt.Fprintln(out, "No hint for this question.")
+				}
+				continue
+			case len(answer) == 1 && answer[0] >= 'a' && int(answer[0]-'a') < len(q.Options):
+				choice = int(answer[0] - 'a')
+			default:
+				fmt.Fprintf(out, "Enter a letter from a to %c, ? for a hint, or q to quit.\n", 'a'+len(q.Options)-1)
+				continue
+			}
+			break
+		}
+
+		asked++
+		picked := q.Options[choice]
+		if picked.Correct {
+			score++
+			fmt.Fprintln(out, "✅ Correct")
+		} else {
+			fmt.Fprint(out, "❌ Incorrect")
+			for j, opt := range q.Options {
+				if opt.Correct {
+					fmt.Fprintf(out, ", the answer is %c) %s", 'a'+j, opt.Text)
+					break
+				}
+			}
+			fmt.Fprintln(out)
+		}
+		if picked.Rationale != "" {
+			fmt.Fprintf(out, "   %s\n", picked.Rationale)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if asked > 0 {
+		fmt.Fprintf(out, "Score: %d/%d (%d%%)\n", score, asked, score*100/asked)
+	}
+	return score
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestTakeQuiz(t *testing.T) {
+	quiz := &api.Quiz{
+		Artifact: &api.Artifact{Title: "Go Quiz"},
+		Questions: []api.QuizQuestion{
+			{
+				Question: "What does go fmt do?",
+				Hint:     "Think style",
+				Options: []api.QuizOption{
+					{Text: "Runs tests", Rationale: "That is go test"},
+					{Text: "Formats code", Correct: true},
+				},
+			},
+			{
+				Question: "Which keyword starts a goroutine?",
+				Options: []api.QuizOption{
+					{Text: "go", Correct: true, Rationale: "go f() runs f concurrently"},
+					{Text: "async"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantScore int
+		wantOut   []string
+	}{
+		{
+			name:      "all correct",
+			input:     "b\na\n",
+			wantScore: 2,
+			wantOut:   []string{"Question 1/2", "✅ Correct", "go f() runs f concurrently", "Score: 2/2 (100%)"},
+		},
+		{
+			name:      "hint, invalid and wrong answer",
+			input:     "?\nz\na\na\n",
+			wantScore: 1,
+			wantOut:   []string{"Hint: Think style", "Enter a letter from a to b", "❌ Incorrect, the answer is b) Formats code", "That is go test", "Score: 1/2 (50%)"},
+		},
+		{
+			name:      "quit early",
+			input:     "b\nq\n",
+			wantScore: 1,
+			wantOut:   []string{"Score: 1/1 (100%)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			got := takeQuiz(strings.NewReader(tt.input), &out, quiz)
+			if got != tt.wantScore {
+				t.Errorf("takeQuiz() = %d, want %d", got, tt.wantScore)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
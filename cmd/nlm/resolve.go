@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/config"
+)
+
+// notebookIDPattern matches NotebookLM notebook IDs, which are UUIDs.
+var notebookIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// errNoNotebook is returned when a notebook reference matches nothing.
+var errNoNotebook = errors.New("no notebook matches")
+
+// notebookArgCommands are the commands whose first argument is a notebook.
+// Commands that parse flags resolve their notebook argument themselves.
+var notebookArgCommands = map[string]bool{
+	"rm": true, "analytics": true,
+	"sources": true, "add": true, "rm-source": true, "discover-sources": true,
+	"notes": true, "new-note": true, "update-note": true, "rm-note": true,
+	"audio-get": true, "audio-rm": true, "audio-share": true, "audio-list": true, "audio-download": true,
+	"video-list": true, "video-download": true,
+	"generate-guide": true, "generate-outline": true, "generate-section": true,
+	"generate-magic": true, "generate-mindmap": true, "generate-chat": true, "chat": true,
+	"rephrase": true, "expand": true, "summarize": true, "critique": true, "brainstorm": true,
+	"verify": true, "explain": true, "outline": true, "study-guide": true, "faq": true,
+	"briefing-doc": true, "mindmap": true, "timeline": true, "toc": true,
+	"share-private": true,
+}
+
+// resolveNotebook turns a notebook reference into a notebook ID. A
+// reference is an alias defined with `nlm alias set`, a notebook ID, or a
+// case-insensitive substring of a notebook title. Titles are matched
+// against the account's notebooks; if they cannot be listed the reference
+// is passed through unchanged and the command reports any error. The
+// notebook is noted for the command's history entry.
+func resolveNotebook(c *api.Client, ref string) (string, error) {
+	id, err := lookupNotebook(c, ref)
+	if err == nil {
+		noteNotebook(id)
+	}
+	return id, err
+}
+
+func lookupNotebook(c *api.Client, ref string) (string, error) {
+	if id := lookupAlias(ref); id != "" {
+		verbosef(1, "nlm: alias %q is notebook %s\n", ref, id)
+		return id, nil
+	}
+	if notebookIDPattern.MatchString(ref) {
+		return ref, nil
+	}
+	notebooks, err := c.ListRecentlyViewedProjects()
+	if err != nil {
+		verbosef(1, "nlm: cannot resolve notebook %q: %v\n", ref, err)
+		return ref, nil
+	}
+	cacheList(notebooksKey(), notebooks)
+	id, err := matchNotebook(notebooks, ref)
+	if err == nil && id != ref {
+		verbosef(1, "nlm: %q is notebook %s\n", ref, id)
+	}
+	return id, err
+}
+
+// matchNotebook finds the notebook ref refers to. An exact ID or title
+// match wins over a title substring match; more than one match of the
+// same kind is an error listing the candidates.
+func matchNotebook(notebooks []*api.Notebook, ref string) (string, error) {
+	var exact, partial []*api.Notebook
+	needle := strings.ToLower(strings.TrimSpace(ref))
+	for _, nb := range notebooks {
+		title := strings.ToLower(strings.TrimSpace(nb.GetTitle()))
+		switch {
+		case nb.GetProjectId() == ref:
+			return ref, nil
+		case title == needle:
+			exact = append(exact, nb)
+		case needle != "" && strings.Contains(title, needle):
+			partial = append(partial, nb)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w %q", errNoNotebook, ref)
+	case 1:
+		return matches[0].GetProjectId(), nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d notebooks:", ref, len(matches))
+	for _, nb := range matches {
+		fmt.Fprintf(&b, "\n  %s  %s", nb.GetProjectId(), strings.TrimSpace(nb.GetTitle()))
+	}
+	b.WriteString("\nuse a notebook ID or a longer part of the title")
+	return "", fmt.Errorf("%s", b.String())
+}
+
+// isKnownNotebook reports whether ref is a notebook ID or alias, which
+// resolve without listing notebooks.
+func isKnownNotebook(ref string) bool {
+	return notebookIDPattern.MatchString(ref) || lookupAlias(ref) != ""
+}
+
+// lookupAlias returns the notebook ID for an alias in the active config
+// profile, or "" if there is none.
+func lookupAlias(name string) string {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return ""
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return ""
+	}
+	return cfg.Lookup(cfg.ActiveName(configProfile)).Alias(name)
+}
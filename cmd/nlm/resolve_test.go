@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestMatchNotebook(t *testing.T) {
+	notebooks := []*api.Notebook{
+		{ProjectId: "id-1", Title: "Machine Learning Papers"},
+		{ProjectId: "id-2", Title: "Machine Learning"},
+		{ProjectId: "id-3", Title: "Recipes"},
+		{ProjectId: "id-4", Title: " Travel Notes "},
+		{ProjectId: "id-5", Title: "Work Notes"},
+	}
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "id-3", want: "id-3"},
+		{ref: "recipes", want: "id-3"},
+		{ref: "cipe", want: "id-3"},
+		{ref: "travel notes", want: "id-4"},
+		{ref: "machine learning", want: "id-2"},
+		{ref: "papers", want: "id-1"},
+		{ref: "notes", wantErr: `"notes" matches 2 notebooks`},
+		{ref: "gardening", wantErr: `no notebook matches "gardening"`},
+		{ref: "", wantErr: "no notebook matches"},
+	}
+	for _, tt := range tests {
+		got, err := matchNotebook(notebooks, tt.ref)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("matchNotebook(%q) = %q, %v; want error containing %q", tt.ref, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("matchNotebook(%q) = %q, %v; want %q", tt.ref, got, err, tt.want)
+		}
+	}
+}
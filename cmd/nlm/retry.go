@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/retry"
+)
+
+// retryUsage lists the forms of `nlm retry`.
+const retryUsage = "usage: nlm retry [item-id...] | retry list | retry clear [item-id...]\n"
+
+func validateRetryArgs(args []string) error {
+	if len(args) > 0 && args[0] == "list" && len(args) > 1 {
+		fmt.Fprint(os.Stderr, retryUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	return nil
+}
+
+// isLocalRetry reports whether `nlm retry` with args only touches the
+// queue and so needs no credentials.
+func isLocalRetry(args []string) bool {
+	return len(args) > 0 && (args[0] == "list" || args[0] == "clear")
+}
+
+func runRetry(c *api.Client, args []string) error {
+	store, err := retry.OpenDefault()
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			return listRetryQueue(store)
+		case "clear":
+			return clearRetryQueue(store, args[1:])
+		}
+	}
+	return retryItems(c, store, args)
+}
+
+// queueFailed adds the inputs a bulk operation failed on to the retry
+// queue and tells the user how to retry them. Failing to queue them is
+// reported but does not change the command's result.
+func queueFailed(items []retry.Item) {
+	if len(items) == 0 {
+		return
+	}
+	store, err := retry.OpenDefault()
+	if err == nil {
+		for i := range items {
+			items[i].Attempts = 1
+		}
+		_, err = store.Add(items...)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nlm: warning: failed to queue failed items for retry: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "nlm: queued %d failed items; run 'nlm retry' to try them again\n", len(items))
+}
+
+// retryItems attempts the queued items with the given IDs, or every
+// queued item when none are given. Items that succeed leave the queue.
+func retryItems(c *api.Client, store *retry.Store, ids []string) error {
+	var items []retry.Item
+	if len(ids) == 0 {
+		var err error
+		if items, err = store.List(); err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			fmt.Fprintln(os.Stderr, "Nothing to retry.")
+			return nil
+		}
+	}
+	for _, id := range ids {
+		it, err := store.Get(id)
+		if err != nil {
+			return err
+		}
+		items = append(items, it)
+	}
+
+	var failed int
+	for _, it := range items {
+		if dryRun {
+			fmt.Fprintf(os.Stderr, "Would retry %s %s in %s\n", it.Op, retryLabel(it), it.NotebookID)
+			continue
+		}
+		srcID, err := retryItem(c, it)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "nlm: %s: %v\n", retryLabel(it), err)
+			failed++
+			if _, uerr := store.Update(it.ID, func(it *retry.Item) {
+				it.Attempts++
+				it.Error = err.Error()
+			}); uerr != nil {
+				return uerr
+			}
+			continue
+		}
+		noteNotebook(it.NotebookID)
+		noteAffected(srcID)
+		fmt.Println(srcID)
+		if err := store.Remove(it.ID); err != nil {
+			return err
+		}
+	}
+	if dryRun {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Retried %d items: %d succeeded, %d still failing\n", len(items), len(items)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d items failed again; they stay queued", failed)
+	}
+	return nil
+}
+
+// retryItem performs the operation a queued item failed in, with the
+// options of the original command.
+func retryItem(c *api.Client, it retry.Item) (string, error) {
+	switch it.Op {
+	case retry.OpAdd:
+		defer func(m, p string, ocr bool) { mimeType, pdfPages, ocrPDFs = m, p, ocr }(mimeType, pdfPages, ocrPDFs)
+		mimeType, pdfPages, ocrPDFs = it.MIMEType, it.Pages, it.OCR
+		return addSource(c, it.NotebookID, it.Input)
+	case retry.OpZotero:
+		s := zoteroSource{Title: it.Title, PDF: it.Input}
+		if strings.Contains(it.Input, "://") {
+			s = zoteroSource{Title: it.Title, URL: it.Input}
+		}
+		return addZoteroSource(c, it.NotebookID, s)
+	default:
+		return "", fmt.Errorf("unknown operation %q; clear it with 'nlm retry clear %s'", it.Op, it.ID)
+	}
+}
+
+// retryLabel names a queued item's input for messages.
+func retryLabel(it retry.Item) string {
+	if it.Title != "" {
+		return it.Title
+	}
+	return it.Input
+}
+
+func listRetryQueue(store *retry.Store) error {
+	items, err := store.List()
+	if err != nil {
+		return err
+	}
+	if items == nil {
+		items = []retry.Item{}
+	}
+	return render(items, func(out io.Writer) error {
+		return writeRetryQueue(out, items)
+	})
+}
+
+func writeRetryQueue(out io.Writer, items []retry.Item) error {
+	if len(items) == 0 {
+		fmt.Fprintln(out, "Nothing to retry.")
+		return nil
+	}
+	w := newTable(out, 1)
+	fmt.Fprintln(w, "ID\tOP\tNOTEBOOK\tINPUT\tATTEMPTS\tLAST ERROR")
+	for _, it := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			it.ID,
+			it.Op,
+			it.NotebookID,
+			retryLabel(it),
+			it.Attempts,
+			truncateArg(it.Error),
+		)
+	}
+	return w.Flush()
+}
+
+// clearRetryQueue drops the given items, or the whole queue, without
+// retrying them.
+func clearRetryQueue(store *retry.Store, ids []string) error {
+	if len(ids) == 0 {
+		items, err := store.List()
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			fmt.Fprintln(os.Stderr, "Nothing to retry.")
+			return nil
+		}
+		for _, it := range items {
+			ids = append(ids, it.ID)
+		}
+	}
+	for _, id := range ids {
+		if _, err := store.Get(id); err != nil {
+			return err
+		}
+	}
+	if ok, err := confirmAction("drop %d items from the retry queue", len(ids)); !ok {
+		return err
+	}
+	if err := store.Remove(ids...); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Dropped %d items from the retry queue\n", len(ids))
+	return nil
+}
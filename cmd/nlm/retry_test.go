@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tmc/nlm/internal/retry"
+)
+
+func TestQueueFailedAndClear(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func(f bool) { force = f }(force)
+	force = true
+
+	queueFailed([]retry.Item{
+		{Op: retry.OpAdd, NotebookID: "nb1", Input: "/docs/a.pdf", Error: "upload failed"},
+		{Op: retry.OpZotero, NotebookID: "nb1", Input: "https://doi.org/10.1/x", Title: "Paper (Doe, 2020)", Error: "timeout"},
+	})
+	// Failing the same source again updates its entry.
+	queueFailed([]retry.Item{{Op: retry.OpAdd, NotebookID: "nb1", Input: "/docs/a.pdf", Error: "upload failed again"}})
+
+	store, err := retry.OpenDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("queued %d items, want 2", len(items))
+	}
+	if items[0].Attempts != 2 || items[0].Error != "upload failed again" {
+		t.Errorf("requeued item = %+v, want 2 attempts and the last error", items[0])
+	}
+
+	var buf bytes.Buffer
+	if err := writeRetryQueue(&buf, items); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"LAST ERROR", "/docs/a.pdf", "Paper (Doe, 2020)", "timeout"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("writeRetryQueue() output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	if err := clearRetryQueue(store, []string{items[0].ID}); err != nil {
+		t.Fatalf("clearRetryQueue() error = %v", err)
+	}
+	if items, _ = store.List(); len(items) != 1 || items[0].Op != retry.OpZotero {
+		t.Errorf("after clearing one, queue = %+v", items)
+	}
+	if err := clearRetryQueue(store, nil); err != nil {
+		t.Fatalf("clearRetryQueue() error = %v", err)
+	}
+	if items, _ = store.List(); len(items) != 0 {
+		t.Errorf("after clearing all, queue = %+v", items)
+	}
+}
+
+func TestRetryIsLocal(t *testing.T) {
+	tests := []struct {
+		args     []string
+		local    bool
+		mutating bool
+	}{
+		{args: nil, local: false, mutating: true},
+		{args: []string{"ab12"}, local: false, mutating: true},
+		{args: []string{"list"}, local: true, mutating: false},
+		{args: []string{"clear"}, local: true, mutating: false},
+	}
+	for _, tt := range tests {
+		if got := isLocalCommand("retry", tt.args); got != tt.local {
+			t.Errorf("isLocalCommand(retry, %q) = %v, want %v", tt.args, got, tt.local)
+		}
+		if got := isMutating("retry", tt.args); got != tt.mutating {
+			t.Errorf("isMutating(retry, %q) = %v, want %v", tt.args, got, tt.mutating)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	runtimedebug "runtime/debug"
+
+	"github.com/tmc/nlm/internal/update"
+)
+
+// version is the release this binary was built from, set at build time
+// with -ldflags "-X main.version=v1.2.3". Builds installed with `go
+// install` fall back to the module version.
+var version string
+
+// releaseKey is the base64 Ed25519 public key release checksums are
+// signed with, set at build time with -ldflags "-X main.releaseKey=...".
+// Without it self-update verifies checksums only.
+var releaseKey string
+
+// currentVersion returns the version of the running binary, or "devel".
+func currentVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := runtimedebug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "devel"
+}
+
+// selfUpdateArgs contains the CLI options for `nlm self-update`.
+type selfUpdateArgs struct {
+	Check   bool
+	Version string
+	Force   bool
+}
+
+func parseSelfUpdateFlags(args []string) (*selfUpdateArgs, error) {
+	opts := &selfUpdateArgs{}
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	fs.BoolVar(&opts.Check, "check", false, "only report whether a newer release exists")
+	fs.StringVar(&opts.Version, "version", "", "install this release `tag` instead of the latest, e.g. v0.4.0")
+	fs.BoolVar(&opts.Force, "force", false, "reinstall even if this binary is up to date")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm self-update [-check] [-version tag] [-force]\n\n")
+		fmt.Fprintf(os.Stderr, "Replaces this binary with the latest GitHub release after verifying its checksum.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+// newUpdater returns an updater for the release repository. NLM_UPDATE_URL
+// points it at a mirror of the GitHub API.
+func newUpdater() (*update.Updater, error) {
+	u := &update.Updater{APIURL: os.Getenv("NLM_UPDATE_URL")}
+	if releaseKey != "" {
+		key, err := base64.StdEncoding.DecodeString(releaseKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid release key built into this binary")
+		}
+		u.PublicKey = key
+	}
+	return u, nil
+}
+
+// runSelfUpdate installs the latest release, or the one named with
+// -version, over the running binary.
+func runSelfUpdate(args []string) error {
+	opts, err := parseSelfUpdateFlags(args)
+	if err != nil {
+		return err
+	}
+	u, err := newUpdater()
+	if err != nil {
+		return err
+	}
+	current := currentVersion()
+
+	sp := startSpinner("Checking for a newer release")
+	var r *update.Release
+	if opts.Version != "" {
+		r, err = u.Tagged(opts.Version)
+	} else {
+		r, err = u.Latest()
+	}
+	sp.Stop()
+	if err != nil {
+		return err
+	}
+	if opts.Version == "" && !opts.Force && !update.Newer(current, r.Tag) {
+		fmt.Printf("nlm %s is up to date\n", current)
+		return nil
+	}
+	if opts.Check {
+		fmt.Printf("nlm %s is available (this is %s); run 'nlm self-update' to install it\n", r.Tag, current)
+		return nil
+	}
+
+	name := update.ArchiveName(r.Tag, runtime.GOOS, runtime.GOARCH)
+	asset, ok := r.Asset(name)
+	if !ok {
+		return fmt.Errorf("release %s has no build for %s/%s (%s)", r.Tag, runtime.GOOS, runtime.GOARCH, name)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("find this binary: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("find this binary: %w", err)
+	}
+
+	sp = startSpinner("Downloading %s", name)
+	archive, err := u.Download(asset)
+	sp.Stop()
+	if err != nil {
+		return err
+	}
+	if err := u.Verify(r, name, archive); err != nil {
+		return fmt.Errorf("verify %s: %w", name, err)
+	}
+	if u.PublicKey == nil {
+		statusf("nlm: checksum verified; this build has no release key to check the signature\n")
+	} else {
+		statusf("nlm: checksum and signature verified\n")
+	}
+	binary, err := update.Extract(name, archive)
+	if err != nil {
+		return err
+	}
+	if err := update.Replace(exe, binary); err != nil {
+		return fmt.Errorf("%w (try again with permission to write %s)", err, filepath.Dir(exe))
+	}
+	fmt.Printf("✅ Updated %s from %s to %s\n", exe, current, r.Tag)
+	return nil
+}
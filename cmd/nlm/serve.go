@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/mailin"
+	"github.com/tmc/nlm/internal/rest"
+)
+
+// serveOptions contains the CLI options for `nlm serve`.
+type serveOptions struct {
+	Addr    string
+	APIKey  string
+	GRPC    bool
+	Metrics string
+	Poll    time.Duration
+
+	MailAllow    []string
+	MailAddress  string
+	MailNotebook string
+	SMTP         string
+	MailFrom     string
+
+	Accounts        []string
+	RequestInterval time.Duration
+}
+
+func parseServeFlags(args []string) (*serveOptions, error) {
+	opts := &serveOptions{}
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.StringVar(&opts.Addr, "addr", "localhost:8080", "address to listen on")
+	fs.StringVar(&opts.APIKey, "api-key", os.Getenv("NLM_API_KEY"), "key clients must send (or set NLM_API_KEY; default: a random key)")
+	fs.BoolVar(&opts.GRPC, "grpc", false, "serve the v1alpha1 gRPC services instead of the JSON API")
+	fs.StringVar(&opts.Metrics, "metrics", "", "serve Prometheus metrics at http://`host:port`/metrics")
+	fs.DurationVar(&opts.Poll, "poll-interval", rest.DefaultPollInterval, "how often to poll notebooks streamed at /v1/events")
+	var mailAllow string
+	fs.StringVar(&mailAllow, "mail-allow", "", "accept email at /v1/inbound-email from these comma-separated `senders` (addresses or @domains)")
+	fs.StringVar(&opts.MailAddress, "mail-address", "", "the gateway's `address`; only its plus-addresses name notebooks")
+	fs.StringVar(&opts.MailNotebook, "mail-notebook", "", "notebook for email without a tag (default: such email is refused)")
+	fs.StringVar(&opts.SMTP, "smtp", "", "reply with summaries through this SMTP server `host:port` (login from NLM_SMTP_USER and NLM_SMTP_PASSWORD)")
+	fs.StringVar(&opts.MailFrom, "mail-from", "", "sender `address` of replies (default: the address mailed)")
+	var accounts string
+	fs.StringVar(&accounts, "accounts", "", "serve these comma-separated config `profiles`, chosen per request with the X-NLM-Account header")
+	fs.DurationVar(&opts.RequestInterval, "request-interval", 0, "with -accounts, space each account's requests at least this far apart")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm serve [-addr host:port] [-api-key key] [-grpc] [-metrics host:port]\n")
+		fmt.Fprintf(os.Stderr, "                 [-accounts profiles [-request-interval d]]\n")
+		fmt.Fprintf(os.Stderr, "                 [-mail-allow senders [-mail-address addr] [-mail-notebook id] [-smtp host:port] [-mail-from addr]]\n\n")
+		fmt.Fprintf(os.Stderr, "Serves notebooks, sources, notes, chat and artifacts as a JSON API, and\n")
+		fmt.Fprintf(os.Stderr, "notebooks as models at the OpenAI-compatible /v1/chat/completions. With\n")
+		fmt.Fprintf(os.Stderr, "-grpc it serves the notebooklm.v1alpha1 gRPC services instead.\n\n")
+		fmt.Fprintf(os.Stderr, "GET /v1/events?notebook=<id> streams a notebook's changes as server-sent\n")
+		fmt.Fprintf(os.Stderr, "events, found by polling it every -poll-interval.\n\n")
+		fmt.Fprintf(os.Stderr, "With -mail-allow, emails posted to /v1/inbound-email by a mail service\n")
+		fmt.Fprintf(os.Stderr, "have their links and attachments added to the notebook named by their\n")
+		fmt.Fprintf(os.Stderr, "plus-address (nlm+<notebook>@...) or subject tag ([<notebook>] ...).\n\n")
+		fmt.Fprintf(os.Stderr, "With -accounts, each request is served by the config profile named by\n")
+		fmt.Fprintf(os.Stderr, "its X-NLM-Account header or account query parameter, or else the first\n")
+		fmt.Fprintf(os.Stderr, "listed. Accounts have their own clients, rate limits and caches.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	for _, a := range strings.Split(mailAllow, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			opts.MailAllow = append(opts.MailAllow, a)
+		}
+	}
+	for _, a := range strings.Split(accounts, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			opts.Accounts = append(opts.Accounts, a)
+		}
+	}
+	mailOnly := opts.MailAddress != "" || opts.MailNotebook != "" || opts.SMTP != "" || opts.MailFrom != ""
+	if len(pos) != 0 || mailOnly && len(opts.MailAllow) == 0 || opts.GRPC && (len(opts.MailAllow) != 0 || len(opts.Accounts) != 0) ||
+		opts.RequestInterval < 0 || opts.Poll <= 0 || opts.RequestInterval != 0 && len(opts.Accounts) == 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	return opts, nil
+}
+
+func runServe(c *api.Client, args []string) error {
+	opts, err := parseServeFlags(args)
+	if err != nil {
+		return err
+	}
+	if opts.APIKey == "" {
+		b := make([]byte, 24)
+		if _, err := rand.Read(b); err != nil {
+			return fmt.Errorf("generate API key: %w", err)
+		}
+		opts.APIKey = hex.EncodeToString(b)
+		fmt.Fprintf(os.Stderr, "nlm: no -api-key given; clients must send \"Authorization: Bearer %s\"\n", opts.APIKey)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var m *serverMetrics
+	if opts.Metrics != "" {
+		m = newServerMetrics(rpcStats)
+		if err := serveMetrics(ctx, opts.Metrics, m); err != nil {
+			return err
+		}
+	}
+	if opts.GRPC {
+		return serveGRPC(ctx, opts, m)
+	}
+
+	var h http.Handler
+	if len(opts.Accounts) != 0 {
+		accounts, err := loadServeAccounts(opts.Accounts, opts.RequestInterval)
+		if err != nil {
+			return err
+		}
+		if h, err = accountsHandler(accounts, opts, m); err != nil {
+			return err
+		}
+		statusf("Serving accounts %s, chosen with the %s header\n", strings.Join(opts.Accounts, ", "), accountHeader)
+	} else {
+		// Not resolveNotebook: requests are served concurrently, and it
+		// notes the notebook for a CLI history entry.
+		h = newRESTServer(c, opts, m, func(ref string) (string, error) {
+			return lookupNotebook(c, ref)
+		}).Handler()
+	}
+	if len(opts.MailAllow) != 0 {
+		statusf("Accepting email at http://%s/v1/inbound-email\n", opts.Addr)
+	}
+	hs := &http.Server{Addr: opts.Addr, Handler: m.instrumentHTTP(h)}
+	go func() {
+		<-ctx.Done()
+		hs.Shutdown(context.Background())
+	}()
+	statusf("Serving the NotebookLM API on http://%s/v1/\n", opts.Addr)
+	if err := hs.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
+
+// newRESTServer returns the JSON API server for c, resolving notebook
+// names with resolve.
+func newRESTServer(c *api.Client, opts *serveOptions, m *serverMetrics, resolve func(string) (string, error)) *rest.Server {
+	srv := rest.NewServer(c, opts.APIKey)
+	srv.ErrorStatus = func(err error) int {
+		m.recordError(err)
+		return restStatus(err)
+	}
+	srv.ResolveModel = resolve
+	srv.PollInterval = opts.Poll
+	if len(opts.MailAllow) != 0 {
+		srv.Handle("POST /v1/inbound-email", newMailGateway(c, opts, resolve))
+	}
+	return srv
+}
+
+// newMailGateway returns the email-in gateway configured by opts.
+func newMailGateway(c *api.Client, opts *serveOptions, resolve func(string) (string, error)) *mailin.Gateway {
+	g := mailin.New(c, opts.MailAllow)
+	g.Address = opts.MailAddress
+	g.DefaultNotebook = opts.MailNotebook
+	g.Resolve = resolve
+	if opts.SMTP != "" {
+		g.Mailer = &mailin.Mailer{
+			Addr:     opts.SMTP,
+			Username: os.Getenv("NLM_SMTP_USER"),
+			Password: os.Getenv("NLM_SMTP_PASSWORD"),
+			From:     opts.MailFrom,
+		}
+	}
+	return g
+}
+
+// serveMetrics starts serving m's metrics on addr until ctx is done.
+func serveMetrics(ctx context.Context, addr string, m *serverMetrics) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("serve metrics: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", m.registry.Handler())
+	hs := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		hs.Close()
+	}()
+	go hs.Serve(lis)
+	statusf("Serving metrics on http://%s/metrics\n", lis.Addr())
+	return nil
+}
+
+// restStatus maps an API error to the HTTP status `nlm serve` answers
+// with, using the same classes as the exit codes. Other errors are
+// answered with 502 Bad Gateway.
+func restStatus(err error) int {
+	switch exitCode(err) {
+	case exitUsage:
+		return http.StatusBadRequest
+	case exitNotFound:
+		return http.StatusNotFound
+	case exitRateLimited:
+		return http.StatusTooManyRequests
+	}
+	return 0
+}
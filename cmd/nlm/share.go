@@ -0,0 +1,290 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// shareUsage lists the forms of `nlm share`.
+const shareUsage = "usage: nlm share <notebook-id> [-email addr]... [-role viewer|editor]\n" +
+	"       nlm share list <notebook-id>\n" +
+	"       nlm share revoke <notebook-id> -email addr... | -public\n"
+
+func validateShareArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, shareUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	switch args[0] {
+	case "list":
+		if len(withDefaultNotebook(args[1:], 1)) != 1 {
+			fmt.Fprintf(os.Stderr, "usage: nlm share list <notebook-id>\n")
+			return fmt.Errorf("invalid arguments")
+		}
+		return nil
+	case "revoke":
+		_, err := parseShareRevokeFlags(args[1:])
+		return err
+	default:
+		_, err := parseShareFlags(args)
+		return err
+	}
+}
+
+func runShare(c *api.Client, args []string) error {
+	switch args[0] {
+	case "list":
+		notebookID, err := resolveNotebook(c, withDefaultNotebook(args[1:], 1)[0])
+		if err != nil {
+			return err
+		}
+		return shareList(c, notebookID)
+	case "revoke":
+		opts, err := parseShareRevokeFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return shareRevoke(c, opts)
+	default:
+		opts, err := parseShareFlags(args)
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return shareInvite(c, opts)
+	}
+}
+
+// shareArgs contains the CLI options for `share`
+type shareArgs struct {
+	NotebookID string
+	Invites    []api.ShareInvite
+	Invite     api.InviteOptions
+	// Link is the link access to set, or nil to leave it unchanged.
+	Link *api.LinkAccess
+}
+
+func parseShareFlags(args []string) (*shareArgs, error) {
+	var emails []string
+	var link string
+	var public bool
+	role := "viewer"
+	opts := &shareArgs{}
+	fs := flag.NewFlagSet("share", flag.ContinueOnError)
+	fs.Func("email", "invite `address` (repeatable, or comma-separated)", func(s string) error {
+		for _, e := range strings.Split(s, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				emails = append(emails, e)
+			}
+		}
+		return nil
+	})
+	fs.StringVar(&role, "role", role, "role for invited collaborators (viewer, editor)")
+	fs.StringVar(&opts.Invite.Message, "message", "", "message to include in the invitation email")
+	fs.BoolVar(&opts.Invite.SkipNotify, "no-notify", false, "invite without emailing the collaborators")
+	fs.StringVar(&link, "link", "", "who can open the notebook from its link (anyone, domain, restricted)")
+	fs.BoolVar(&public, "public", false, "shorthand for -link anyone")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm share <notebook-id> [-email addr]... [-role viewer|editor] [-link anyone|domain|restricted]\n\n")
+		fmt.Fprintf(os.Stderr, "Without -email or -link, lets anyone with the link view the notebook.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if len(emails) == 0 {
+		for _, name := range []string{"role", "message", "no-notify"} {
+			if set[name] {
+				fmt.Fprintf(os.Stderr, "nlm: -%s requires -email\n", name)
+				return nil, fmt.Errorf("invalid arguments")
+			}
+		}
+	}
+	r, err := api.ParseShareRole(role)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nlm: %v\n", err)
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	for _, e := range emails {
+		opts.Invites = append(opts.Invites, api.ShareInvite{Email: e, Role: r})
+	}
+
+	switch {
+	case public && link != "":
+		fmt.Fprintf(os.Stderr, "nlm: -public and -link are mutually exclusive\n")
+		return nil, fmt.Errorf("invalid arguments")
+	case public:
+		link = "anyone"
+	case link == "" && len(emails) == 0:
+		// A bare `share` publishes the notebook, as it always has.
+		link = "anyone"
+	}
+	if link != "" {
+		access, err := api.ParseLinkAccess(link)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "nlm: %v\n", err)
+			return nil, fmt.Errorf("invalid arguments")
+		}
+		opts.Link = &access
+	}
+	return opts, nil
+}
+
+func shareInvite(c *api.Client, opts *shareArgs) error {
+	var info *api.ShareInfo
+	if opts.Link != nil {
+		var err error
+		if info, err = c.SetLinkAccess(opts.NotebookID, *opts.Link); err != nil {
+			return err
+		}
+	}
+	if len(opts.Invites) > 0 {
+		fmt.Fprintf(os.Stderr, "Sharing notebook with %d collaborator(s)...\n", len(opts.Invites))
+		shared, err := c.ShareNotebookWithOptions(opts.NotebookID, opts.Invites, opts.Invite)
+		if err != nil {
+			return err
+		}
+		if info != nil {
+			shared.Access = info.Access
+		}
+		info = shared
+	}
+	fmt.Printf("✅ Shared %s\n", opts.NotebookID)
+	return writeShareInfo(os.Stdout, info)
+}
+
+func shareList(c *api.Client, notebookID string) error {
+	info, err := c.GetShareInfo(notebookID)
+	if err != nil {
+		return err
+	}
+	return render(info, func(out io.Writer) error {
+		return writeShareInfo(out, info)
+	})
+}
+
+// shareRevokeArgs contains the CLI options for `share revoke`
+type shareRevokeArgs struct {
+	NotebookID string
+	Emails     []string
+	Public     bool
+}
+
+func parseShareRevokeFlags(args []string) (*shareRevokeArgs, error) {
+	opts := &shareRevokeArgs{}
+	fs := flag.NewFlagSet("share revoke", flag.ContinueOnError)
+	fs.Func("email", "remove `address` (repeatable, or comma-separated)", func(s string) error {
+		for _, e := range strings.Split(s, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				opts.Emails = append(opts.Emails, e)
+			}
+		}
+		return nil
+	})
+	fs.BoolVar(&opts.Public, "public", false, "turn off access for anyone with the link")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm share revoke <notebook-id> -email addr... | -public\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 || (len(opts.Emails) == 0) == !opts.Public {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+	return opts, nil
+}
+
+func shareRevoke(c *api.Client, opts *shareRevokeArgs) error {
+	if opts.Public {
+		if ok, err := confirmAction("turn off link access for %s", opts.NotebookID); !ok {
+			return err
+		}
+		if _, err := c.SetLinkAccess(opts.NotebookID, api.LinkRestricted); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Turned off link access for %s\n", opts.NotebookID)
+		return nil
+	}
+	if ok, err := confirmAction("revoke access to %s for %s", opts.NotebookID, strings.Join(opts.Emails, ", ")); !ok {
+		return err
+	}
+	if _, err := c.RevokeAccess(opts.NotebookID, opts.Emails); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Revoked access for %s\n", strings.Join(opts.Emails, ", "))
+	return nil
+}
+
+// writeShareInfo prints a notebook's link access, share URL and
+// collaborators.
+func writeShareInfo(out io.Writer, info *api.ShareInfo) error {
+	access := "restricted to collaborators"
+	switch info.Access {
+	case api.LinkAnyone:
+		access = "anyone with the link can view"
+	case api.LinkDomain:
+		access = "anyone in your organization with the link can view"
+		if info.Domain != "" {
+			access = fmt.Sprintf("anyone at %s with the link can view", info.Domain)
+		}
+	}
+	fmt.Fprintf(out, "Link access: %s\n", access)
+	if info.ShareURL != "" {
+		fmt.Fprintf(out, "Share URL: %s\n", info.ShareURL)
+	}
+	if info.Owner != nil {
+		fmt.Fprintf(out, "Owner: %s\n", info.Owner)
+	}
+	if info.CreatedBy != nil {
+		fmt.Fprintf(out, "Created by: %s\n", info.CreatedBy)
+	}
+	if info.LastModifiedBy != nil {
+		modified := info.LastModifiedBy.String()
+		if !info.LastModified.IsZero() {
+			modified += " on " + formatTime(info.LastModified)
+		}
+		fmt.Fprintf(out, "Last modified by: %s\n", modified)
+	}
+	if len(info.Collaborators) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	w := newTable(out, 4)
+	fmt.Fprintln(w, "EMAIL\tNAME\tROLE")
+	for _, collab := range info.Collaborators {
+		name := collab.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", collab.Email, name, collab.Role)
+	}
+	return w.Flush()
+}
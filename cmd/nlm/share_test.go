@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/nlm/internal/api"
+)
+
+func TestParseShareFlags(t *testing.T) {
+	anyone, domain, restricted := api.LinkAnyone, api.LinkDomain, api.LinkRestricted
+	tests := []struct {
+		name    string
+		args    []string
+		want    *shareArgs
+		wantErr bool
+	}{
+		{
+			name: "bare share publishes",
+			args: []string{"nb1"},
+			want: &shareArgs{NotebookID: "nb1", Link: &anyone},
+		},
+		{
+			name: "invites only",
+			args: []string{"nb1", "-email", "ada@example.com,bob@example.com", "-role", "editor", "-no-notify"},
+			want: &shareArgs{
+				NotebookID: "nb1",
+				Invites: []api.ShareInvite{
+					{Email: "ada@example.com", Role: api.ShareRoleEditor},
+					{Email: "bob@example.com", Role: api.ShareRoleEditor},
+				},
+				Invite: api.InviteOptions{SkipNotify: true},
+			},
+		},
+		{
+			name: "invites with link access",
+			args: []string{"-email", "ada@example.com", "nb1", "-link", "restricted", "-message", "hi"},
+			want: &shareArgs{
+				NotebookID: "nb1",
+				Invites:    []api.ShareInvite{{Email: "ada@example.com", Role: api.ShareRoleViewer}},
+				Invite:     api.InviteOptions{Message: "hi"},
+				Link:       &restricted,
+			},
+		},
+		{
+			name: "public shorthand",
+			args: []string{"nb1", "-public"},
+			want: &shareArgs{NotebookID: "nb1", Link: &anyone},
+		},
+		{
+			name: "organization only",
+			args: []string{"nb1", "-link", "org"},
+			want: &shareArgs{NotebookID: "nb1", Link: &domain},
+		},
+		{name: "public and link", args: []string{"nb1", "-public", "-link", "anyone"}, wantErr: true},
+		{name: "unknown link access", args: []string{"nb1", "-link", "everyone"}, wantErr: true},
+		{name: "message without email", args: []string{"nb1", "-message", "hi"}, wantErr: true},
+		{name: "no notebook", args: []string{"-public"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseShareFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseShareFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseShareFlags() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteShareInfo(t *testing.T) {
+	info := &api.ShareInfo{
+		ShareURL: "https://notebooklm.google.com/notebook/nb1",
+		Access:   api.LinkDomain,
+		Domain:   "corp.example",
+		Collaborators: []api.Collaborator{
+			{Email: "owner@corp.example", Name: "Owner Name", Role: api.ShareRoleOwner},
+			{Email: "ada@corp.example", Role: api.ShareRoleEditor},
+		},
+		Owner:          &api.Person{Email: "owner@corp.example", Name: "Owner Name"},
+		CreatedBy:      &api.Person{Email: "owner@corp.example", Name: "Owner Name"},
+		LastModifiedBy: &api.Person{Email: "ada@corp.example"},
+	}
+	var b strings.Builder
+	if err := writeShareInfo(&b, info); err != nil {
+		t.Fatal(err)
+	}
+	want := `Link access: anyone at corp.example with the link can view
+Share URL: https://notebooklm.google.com/notebook/nb1
+Owner: Owner Name <owner@corp.example>
+Created by: Owner Name <owner@corp.example>
+Last modified by: ada@corp.example
+
+EMAIL                 NAME          ROLE
+owner@corp.example    Owner Name    owner
+ada@corp.example      -             editor
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeShareInfo() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/site"
+)
+
+// publishSiteArgs contains the CLI options for `nlm publish-site`.
+type publishSiteArgs struct {
+	NotebookID string
+	Out        string
+	Templates  string
+	QA         bool
+}
+
+func parsePublishSiteFlags(args []string) (*publishSiteArgs, error) {
+	opts := &publishSiteArgs{}
+	fs := flag.NewFlagSet("publish-site", flag.ContinueOnError)
+	fs.StringVar(&opts.Out, "out", "site", "write the site to `dir`")
+	fs.StringVar(&opts.Templates, "templates", "", "replace the default templates with those in `dir`")
+	fs.BoolVar(&opts.QA, "qa", false, "include the questions and answers of the saved chat session")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm publish-site [notebook-id] [-out dir] [-templates dir] [-qa]\n\n")
+		fmt.Fprintf(os.Stderr, "Renders the notebook's artifacts, notes and source list as a static HTML\n")
+		fmt.Fprintf(os.Stderr, "site that can be shared without NotebookLM access. Files in -templates\n")
+		fmt.Fprintf(os.Stderr, "replace the default template of the same name: %s.\n\n", strings.Join(site.Templates, ", "))
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 1)
+	if len(pos) != 1 || opts.Out == "" {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID = pos[0]
+	return opts, nil
+}
+
+// textArtifactTypes are the artifact types with text content, which is
+// published and indexed. Other artifacts, such as audio overviews, are
+// only listed.
+var textArtifactTypes = map[pb.ArtifactType]bool{
+	pb.ArtifactType_ARTIFACT_TYPE_NOTE:   true,
+	pb.ArtifactType_ARTIFACT_TYPE_REPORT: true,
+}
+
+func runPublishSite(c *api.Client, args []string) error {
+	opts, err := parsePublishSiteFlags(args)
+	if err != nil {
+		return err
+	}
+	id, err := resolveNotebook(c, opts.NotebookID)
+	if err != nil {
+		return err
+	}
+	p, err := c.GetProject(id)
+	if err != nil {
+		return fmt.Errorf("get notebook: %w", err)
+	}
+	s := &site.Site{
+		NotebookID: id,
+		Title:      strings.TrimSpace(p.Title),
+		Emoji:      strings.TrimSpace(p.Emoji),
+		Generated:  time.Now(),
+	}
+	for _, src := range p.Sources {
+		s.Sources = append(s.Sources, siteSource(src))
+	}
+
+	notes, err := c.GetNotes(id)
+	if err != nil {
+		return fmt.Errorf("list notes: %w", err)
+	}
+	for _, n := range notes {
+		note := site.Note{ID: n.GetSourceId().GetSourceId(), Title: strings.TrimSpace(n.Title)}
+		if t := n.GetMetadata().GetLastModifiedTime(); t != nil {
+			note.UpdatedAt = t.AsTime()
+		}
+		s.Notes = append(s.Notes, note)
+	}
+
+	artifacts, err := c.ListArtifacts(id)
+	if err != nil {
+		return fmt.Errorf("list artifacts: %w", err)
+	}
+	for _, a := range artifacts {
+		sa := site.Artifact{ID: a.ID, Title: a.Title, Type: a.TypeName(), UpdatedAt: a.UpdatedAt}
+		if textArtifactTypes[a.Type] && a.State == pb.ArtifactState_ARTIFACT_STATE_READY {
+			statusf("Fetching %s...\n", a.Title)
+			content, err := c.GetArtifactContent(id, a.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "nlm: warning: skipping the content of %s: %v\n", a.ID, err)
+			} else {
+				sa.Markdown = content.Markdown
+			}
+		}
+		s.Artifacts = append(s.Artifacts, sa)
+	}
+
+	if opts.QA {
+		s.QA = chatQA(id)
+	}
+	if err := site.Write(opts.Out, s, opts.Templates); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "✅ Published %s to %s\n", id, opts.Out)
+	return nil
+}
+
+// siteSource describes a source for the site. Only YouTube sources have a
+// public URL.
+func siteSource(src *pb.Source) site.Source {
+	s := site.Source{
+		ID:    src.GetSourceId().GetSourceId(),
+		Title: strings.TrimSpace(src.Title),
+		Type:  strings.ToLower(strings.TrimPrefix(src.GetMetadata().GetSourceType().String(), "SOURCE_TYPE_")),
+		URL:   src.GetMetadata().GetYoutube().GetYoutubeUrl(),
+	}
+	if t := src.GetMetadata().GetLastModifiedTime(); t != nil {
+		s.UpdatedAt = t.AsTime()
+	}
+	return s
+}
+
+// chatQA returns the questions and answers of the notebook's saved chat
+// session, if any.
+func chatQA(notebookID string) []site.QA {
+	session, err := loadChatSession(notebookID)
+	if err != nil {
+		return nil
+	}
+	var qa []site.QA
+	for i, m := range session.Messages {
+		if m.Role != "user" || i+1 >= len(session.Messages) || session.Messages[i+1].Role != "assistant" {
+			continue
+		}
+		qa = append(qa, site.QA{Question: m.Content, Answer: session.Messages[i+1].Content, Time: m.Timestamp})
+	}
+	return qa
+}
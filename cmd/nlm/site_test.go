@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/nlm/internal/site"
+)
+
+func TestChatQA(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if qa := chatQA("nb1"); qa != nil {
+		t.Errorf("chatQA without a session = %v, want nil", qa)
+	}
+	when := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := saveChatSession(&ChatSession{NotebookID: "nb1", Messages: []ChatMessage{
+		{Role: "user", Content: "What is it?", Timestamp: when},
+		{Role: "assistant", Content: "A notebook."},
+		{Role: "user", Content: "Unanswered"},
+		{Role: "user", Content: "And then?", Timestamp: when},
+		{Role: "assistant", Content: "Nothing."},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []site.QA{
+		{Question: "What is it?", Answer: "A notebook.", Time: when},
+		{Question: "And then?", Answer: "Nothing.", Time: when},
+	}
+	if diff := cmp.Diff(want, chatQA("nb1")); diff != "" {
+		t.Errorf("chatQA mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// sourceUsage lists the `nlm source` subcommands.
+const sourceUsage = "usage: nlm source <diff> ...\n"
+
+func validateSourceArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, sourceUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	switch args[0] {
+	case "diff":
+		_, err := parseSourceDiffFlags(args[1:])
+		return err
+	default:
+		fmt.Fprint(os.Stderr, sourceUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+func runSource(c *api.Client, args []string) error {
+	switch args[0] {
+	case "diff":
+		opts, err := parseSourceDiffFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		if opts.NotebookID, err = resolveNotebook(c, opts.NotebookID); err != nil {
+			return err
+		}
+		return sourceDiff(c, opts)
+	default:
+		return fmt.Errorf("unknown source command %q", args[0])
+	}
+}
+
+// sourceDiffArgs contains the CLI options for `nlm source diff`.
+type sourceDiffArgs struct {
+	NotebookID  string
+	SourceID    string
+	File        string
+	IgnoreSpace bool
+}
+
+func parseSourceDiffFlags(args []string) (*sourceDiffArgs, error) {
+	opts := &sourceDiffArgs{}
+	fs := flag.NewFlagSet("source diff", flag.ContinueOnError)
+	fs.BoolVar(&opts.IgnoreSpace, "w", false, "compare paragraph by paragraph, ignoring line wrapping and runs of whitespace")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm source diff <notebook-id> <source-id> <file> [-w]\n\n")
+		fmt.Fprintf(os.Stderr, "Compares a local file with the text NotebookLM extracted from a source,\n")
+		fmt.Fprintf(os.Stderr, "to check what survived conversion. Lines marked - are only in the file,\n")
+		fmt.Fprintf(os.Stderr, "lines marked + only in what NotebookLM sees.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	pos = withDefaultNotebook(pos, 3)
+	if len(pos) != 3 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.NotebookID, opts.SourceID, opts.File = pos[0], pos[1], pos[2]
+	return opts, nil
+}
+
+func sourceDiff(c *api.Client, opts *sourceDiffArgs) error {
+	local, err := os.ReadFile(opts.File)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", opts.File, err)
+	}
+	nb, err := c.GetProject(opts.NotebookID)
+	if err != nil {
+		return fmt.Errorf("get notebook: %w", err)
+	}
+	title := ""
+	for _, src := range nb.Sources {
+		if src.GetSourceId().GetSourceId() == opts.SourceID {
+			title = strings.TrimSpace(src.Title)
+			break
+		}
+	}
+	if title == "" {
+		return fmt.Errorf("source %s is not in notebook %s", opts.SourceID, opts.NotebookID)
+	}
+	sp := startSpinner("Fetching the text of %s", title)
+	ingested, err := c.GetSourceText(opts.SourceID)
+	sp.Stop()
+	if err != nil {
+		return err
+	}
+
+	a, b := strings.ReplaceAll(string(local), "\r\n", "\n"), ingested
+	if opts.IgnoreSpace {
+		a, b = reflowParagraphs(a), collapseSpace(b)
+	}
+	if d := unifiedDiff(opts.File, fmt.Sprintf("%s (%s)", title, opts.SourceID), a, b); d != "" {
+		fmt.Print(d)
+	} else {
+		fmt.Println("No changes.")
+	}
+	return nil
+}
+
+// reflowParagraphs puts each paragraph of a local file on one line with
+// its runs of whitespace collapsed, so that a diff shows changes of
+// wording rather than of wrapping. Paragraphs are separated by blank lines.
+func reflowParagraphs(s string) string {
+	var paras []string
+	for _, p := range strings.Split(s, "\n\n") {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			paras = append(paras, p)
+		}
+	}
+	return strings.Join(paras, "\n")
+}
+
+// collapseSpace collapses the runs of whitespace in each line of a
+// source's text and drops blank lines. NotebookLM already puts each
+// paragraph it extracts on a line of its own.
+func collapseSpace(s string) string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.Join(strings.Fields(l), " "); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,14 @@
+package main
+
+import "testing"
+
+func TestReflowParagraphs(t *testing.T) {
+	local := "# Title\n\nA paragraph   wrapped\nover two lines.\n\n\n- item\n"
+	ingested := "# Title\n  A paragraph wrapped over two lines. \n\n- item"
+	if got, want := reflowParagraphs(local), "# Title\nA paragraph wrapped over two lines.\n- item"; got != want {
+		t.Errorf("reflowParagraphs() = %q, want %q", got, want)
+	}
+	if d := unifiedDiff("a", "b", reflowParagraphs(local), collapseSpace(ingested)); d != "" {
+		t.Errorf("text differing only in wrapping has a diff:\n%s", d)
+	}
+}
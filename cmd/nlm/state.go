@@ -0,0 +1,474 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/config"
+	"github.com/tmc/nlm/internal/filelock"
+	"github.com/tmc/nlm/internal/state"
+	"golang.org/x/term"
+)
+
+// stateUsage lists the `nlm state` subcommands.
+const stateUsage = "usage: nlm state <export|import> ...\n"
+
+// Names of the bundle entries that are not files under ~/.nlm.
+const (
+	stateConfigName      = "config.yaml"
+	stateCredentialsName = "credentials.enc"
+	stateDirPrefix       = "nlm/"
+)
+
+// stateFiles are the files in ~/.nlm that a state bundle carries, as glob
+// patterns, and whether each is a cache that -no-cache leaves out.
+// Credentials in ~/.nlm/env travel only encrypted, with -credentials.
+// params.json is left out: it holds the session ID, f.sid, and is
+// fetched again on the next run.
+var stateFiles = []struct {
+	pattern string
+	cache   bool
+}{
+	{"jobs.json", false},
+	{"job-*.log", false},
+	{"history.jsonl", false},
+	{"provenance.json", false},
+	{"retry.json", false},
+	{"discord-channels.json", false},
+	{"chat-*.json", false},
+	{"cache", true},
+	{"index.db", true},
+	{"index.db-wal", true},
+}
+
+// stateCredentials are the credentials a bundle carries encrypted.
+type stateCredentials struct {
+	Profiles map[string]profileCredentials `json:"profiles,omitempty"`
+	Env      string                        `json:"env,omitempty"` // contents of ~/.nlm/env
+}
+
+type profileCredentials struct {
+	AuthToken string `json:"auth_token,omitempty"`
+	Cookies   string `json:"cookies,omitempty"`
+}
+
+func validateStateArgs(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, stateUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	var err error
+	switch args[0] {
+	case "export":
+		_, err = parseStateExportFlags(args[1:])
+	case "import":
+		_, err = parseStateImportFlags(args[1:])
+	default:
+		fmt.Fprint(os.Stderr, stateUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+	return err
+}
+
+func runState(args []string) error {
+	switch args[0] {
+	case "export":
+		opts, err := parseStateExportFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		return stateExport(opts)
+	case "import":
+		opts, err := parseStateImportFlags(args[1:])
+		if err != nil {
+			return err
+		}
+		return stateImport(opts)
+	default:
+		fmt.Fprint(os.Stderr, stateUsage)
+		return fmt.Errorf("invalid arguments")
+	}
+}
+
+// stateExportArgs contains the CLI options for `nlm state export`.
+type stateExportArgs struct {
+	File        string
+	NoCache     bool
+	Credentials bool
+}
+
+func parseStateExportFlags(args []string) (*stateExportArgs, error) {
+	opts := &stateExportArgs{}
+	fs := flag.NewFlagSet("state export", flag.ContinueOnError)
+	fs.BoolVar(&opts.NoCache, "no-cache", false, "leave out the response cache and the search index")
+	fs.BoolVar(&opts.Credentials, "credentials", false, "include credentials, encrypted with a passphrase (NLM_STATE_PASSPHRASE or prompted)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm state export <file> [-no-cache] [-credentials]\n\n")
+		fmt.Fprintf(os.Stderr, "Bundles the config file with its profiles, aliases and personas, the job\n")
+		fmt.Fprintf(os.Stderr, "queue, the retry queue, history, provenance records, chat sessions and caches\n")
+		fmt.Fprintf(os.Stderr, "into <file>\n")
+		fmt.Fprintf(os.Stderr, "(- for stdout), for `nlm state import` on another machine. Credentials are\n")
+		fmt.Fprintf(os.Stderr, "left out unless -credentials is given.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.File = pos[0]
+	return opts, nil
+}
+
+// stateImportArgs contains the CLI options for `nlm state import`.
+type stateImportArgs struct {
+	File    string
+	Replace bool
+}
+
+func parseStateImportFlags(args []string) (*stateImportArgs, error) {
+	opts := &stateImportArgs{}
+	fs := flag.NewFlagSet("state import", flag.ContinueOnError)
+	fs.BoolVar(&opts.Replace, "replace", false, "replace files and config profiles that already exist")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nlm state import <file> [-replace]\n\n")
+		fmt.Fprintf(os.Stderr, "Restores a bundle written by `nlm state export`. Files and config profiles\n")
+		fmt.Fprintf(os.Stderr, "that already exist are kept unless -replace is given.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	if len(pos) != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("invalid arguments")
+	}
+	opts.File = pos[0]
+	return opts, nil
+}
+
+// nlmStateDir returns ~/.nlm.
+func nlmStateDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm"), nil
+}
+
+func stateExport(opts *stateExportArgs) error {
+	dir, err := nlmStateDir()
+	if err != nil {
+		return err
+	}
+	var entries []state.Entry
+
+	cfgPath, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return err
+	}
+	creds := stripCredentials(cfg)
+	if len(cfg.Profiles) > 0 || cfg.Profile != "" {
+		data, err := cfg.Marshal()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, state.Entry{Name: stateConfigName, Data: data})
+	}
+
+	for _, f := range stateFiles {
+		if f.cache && opts.NoCache {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(dir, f.pattern))
+		for _, m := range matches {
+			entries = append(entries, state.Entry{Name: stateDirPrefix + filepath.Base(m), Path: m})
+		}
+	}
+
+	if opts.Credentials {
+		if env, err := os.ReadFile(filepath.Join(dir, "env")); err == nil {
+			creds.Env = string(env)
+		}
+		passphrase, err := readPassphrase(true)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(creds)
+		if err != nil {
+			return err
+		}
+		sealed, err := state.Seal(passphrase, data)
+		if err != nil {
+			return fmt.Errorf("encrypt credentials: %w", err)
+		}
+		entries = append(entries, state.Entry{Name: stateCredentialsName, Data: sealed})
+	}
+
+	host, _ := os.Hostname()
+	m := state.Manifest{CreatedAt: time.Now().UTC(), Host: host, Credentials: opts.Credentials}
+	var buf bytes.Buffer
+	written, err := state.Write(&buf, m, entries)
+	if err != nil {
+		return err
+	}
+	if opts.File == "-" {
+		if term.IsTerminal(int(os.Stdout.Fd())) {
+			return fmt.Errorf("refusing to write a bundle to the terminal; redirect stdout or name a file")
+		}
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(opts.File, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	note := "credentials left out"
+	if opts.Credentials {
+		note = "credentials encrypted"
+	}
+	fmt.Fprintf(os.Stderr, "nlm: wrote %d files to %s (%s)\n", len(written.Files), opts.File, note)
+	return nil
+}
+
+// stripCredentials removes the credentials from every profile of cfg and
+// returns them.
+func stripCredentials(cfg *config.Config) stateCredentials {
+	creds := stateCredentials{Profiles: make(map[string]profileCredentials)}
+	for name, p := range cfg.Profiles {
+		if p.AuthToken != "" || p.Cookies != "" {
+			creds.Profiles[name] = profileCredentials{AuthToken: p.AuthToken, Cookies: p.Cookies}
+		}
+		p.AuthToken, p.Cookies = "", ""
+	}
+	return creds
+}
+
+func stateImport(opts *stateImportArgs) error {
+	dir, err := nlmStateDir()
+	if err != nil {
+		return err
+	}
+	cfgPath, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	data, err := readBundle(opts.File)
+	if err != nil {
+		return err
+	}
+	m, err := state.ReadManifest(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	verbosef(1, "nlm: bundle written %s on %s with %d files\n", m.CreatedAt.Local().Format(time.DateTime), m.Host, len(m.Files))
+
+	var passphrase string
+	if m.Credentials {
+		if passphrase, err = readPassphrase(false); err != nil {
+			return err
+		}
+	}
+
+	var bundleCfg *config.Config
+	var creds stateCredentials
+	var imported, kept int
+	_, err = state.Read(bytes.NewReader(data), func(name string, mode fs.FileMode, r io.Reader) error {
+		switch {
+		case name == stateConfigName:
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			if bundleCfg, err = config.Parse(data); err != nil {
+				return fmt.Errorf("parse bundled config: %w", err)
+			}
+			return nil
+		case name == stateCredentialsName:
+			sealed, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			plain, err := state.Unseal(passphrase, sealed)
+			if err != nil {
+				return fmt.Errorf("decrypt credentials: %w", err)
+			}
+			return json.Unmarshal(plain, &creds)
+		case strings.HasPrefix(name, stateDirPrefix):
+			path := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(name, stateDirPrefix)))
+			ok, err := restoreFile(path, mode, r, opts.Replace)
+			if err != nil {
+				return err
+			}
+			if ok {
+				imported++
+			} else {
+				kept++
+				verbosef(1, "nlm: kept existing %s\n", path)
+			}
+			return nil
+		default:
+			verbosef(1, "nlm: ignoring unknown bundle entry %s\n", name)
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if creds.Env != "" {
+		ok, err := restoreFile(filepath.Join(dir, "env"), 0600, strings.NewReader(creds.Env), opts.Replace)
+		if err != nil {
+			return err
+		}
+		if ok {
+			imported++
+		} else {
+			kept++
+		}
+	}
+	var merged, skipped []string
+	if bundleCfg != nil || len(creds.Profiles) > 0 {
+		if bundleCfg == nil {
+			bundleCfg = &config.Config{}
+		}
+		err := config.Update(cfgPath, func(cfg *config.Config) error {
+			merged, skipped = mergeConfig(cfg, bundleCfg, creds, opts.Replace)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "nlm: imported %d files", imported)
+	if len(merged) > 0 {
+		fmt.Fprintf(os.Stderr, " and profiles %s", strings.Join(merged, ", "))
+	}
+	fmt.Fprintf(os.Stderr, " from %s\n", opts.File)
+	if kept > 0 || len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "nlm: kept %d existing files", kept)
+		if len(skipped) > 0 {
+			fmt.Fprintf(os.Stderr, " and profiles %s", strings.Join(skipped, ", "))
+		}
+		fmt.Fprintf(os.Stderr, "; use -replace to overwrite them\n")
+	}
+	if !m.Credentials {
+		fmt.Fprintf(os.Stderr, "nlm: the bundle has no credentials; run 'nlm auth' to sign in\n")
+	}
+	return nil
+}
+
+// readBundle reads a bundle from a file, or from stdin if name is "-".
+func readBundle(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("read bundle: %w", err)
+	}
+	return data, nil
+}
+
+// restoreFile writes a bundled file to path, holding the lock the file's
+// owner uses. It reports false, writing nothing, if path exists and
+// replace is not set.
+func restoreFile(path string, mode fs.FileMode, r io.Reader, replace bool) (bool, error) {
+	if _, err := os.Stat(path); err == nil && !replace {
+		return false, nil
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return false, fmt.Errorf("read bundle: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, err
+	}
+	err = filelock.With(path, func() error {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, mode|0600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, path)
+	})
+	if err != nil {
+		return false, fmt.Errorf("restore %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// mergeConfig adds the profiles of a bundled config, with their
+// credentials, to cfg. A profile cfg already has is kept unless replace is
+// set. The bundle's active profile becomes active if cfg names none. It
+// returns the names of the profiles added and of those kept.
+func mergeConfig(cfg, bundled *config.Config, creds stateCredentials, replace bool) (merged, kept []string) {
+	for name, c := range creds.Profiles {
+		p := bundled.Ensure(name)
+		p.AuthToken, p.Cookies = c.AuthToken, c.Cookies
+	}
+	for _, name := range bundled.Names() {
+		if cfg.Lookup(name) != nil && !replace {
+			kept = append(kept, name)
+			continue
+		}
+		*cfg.Ensure(name) = *bundled.Lookup(name)
+		merged = append(merged, name)
+	}
+	if cfg.Profile == "" {
+		cfg.Profile = bundled.Profile
+	}
+	return merged, kept
+}
+
+// readPassphrase returns the passphrase for a bundle's credentials, from
+// NLM_STATE_PASSPHRASE or else asked for on the terminal, twice if confirm
+// is set.
+func readPassphrase(confirm bool) (string, error) {
+	if p := os.Getenv("NLM_STATE_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("the bundle's credentials need a passphrase: set NLM_STATE_PASSPHRASE")
+	}
+	fmt.Fprint(os.Stderr, "Passphrase for credentials: ")
+	p, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	if len(p) == 0 {
+		return "", errors.New("empty passphrase")
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase: %w", err)
+		}
+		if !bytes.Equal(p, again) {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return string(p), nil
+}
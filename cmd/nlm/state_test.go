@@ -0,0 +1,127 @@
+package main
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/tmc/nlm/internal/config"
+	"github.com/tmc/nlm/internal/state"
+)
+
+func TestStateExportImport(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "state.tar.gz")
+	t.Setenv("NLM_STATE_PASSPHRASE", "correct horse")
+
+	// Export from one machine.
+	src := t.TempDir()
+	t.Setenv("HOME", src)
+	t.Setenv("NLM_CONFIG", filepath.Join(src, "config.yaml"))
+	err := config.Update(os.Getenv("NLM_CONFIG"), func(cfg *config.Config) error {
+		p := cfg.Ensure("work")
+		p.AuthToken, p.Cookies = "token", "SID=1"
+		p.SetAlias("book", "nb1")
+		cfg.Profile = "work"
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nlmDir := filepath.Join(src, ".nlm")
+	for name, data := range map[string]string{"jobs.json": "[]", "env": "NLM_AUTH_TOKEN=\"token\"\n", "cache/nb1": "cached", "params.json": `{"f.sid":"123"}`} {
+		path := filepath.Join(nlmDir, name)
+		os.MkdirAll(filepath.Dir(path), 0700)
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stateExport(&stateExportArgs{File: bundle, NoCache: true, Credentials: true}); err != nil {
+		t.Fatalf("stateExport() error = %v", err)
+	}
+
+	// Import on another, where a jobs file already exists.
+	dst := t.TempDir()
+	t.Setenv("HOME", dst)
+	t.Setenv("NLM_CONFIG", filepath.Join(dst, "config.yaml"))
+	os.MkdirAll(filepath.Join(dst, ".nlm"), 0700)
+	os.WriteFile(filepath.Join(dst, ".nlm", "jobs.json"), []byte("[1]"), 0600)
+	if err := stateImport(&stateImportArgs{File: bundle}); err != nil {
+		t.Fatalf("stateImport() error = %v", err)
+	}
+
+	cfg, err := config.Load(os.Getenv("NLM_CONFIG"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := cfg.Lookup("work")
+	if cfg.Profile != "work" || p == nil || p.AuthToken != "token" || p.Alias("book") != "nb1" {
+		t.Errorf("imported config = %+v, profile %+v", cfg, p)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, ".nlm", "jobs.json")); string(data) != "[1]" {
+		t.Errorf("existing jobs.json = %q, want it kept", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, ".nlm", "env")); string(data) != "NLM_AUTH_TOKEN=\"token\"\n" {
+		t.Errorf("env = %q, want the exported credentials", data)
+	}
+	if _, err := os.Stat(filepath.Join(dst, ".nlm", "cache")); err == nil {
+		t.Error("cache imported despite -no-cache")
+	}
+
+	if err := stateImport(&stateImportArgs{File: bundle, Replace: true}); err != nil {
+		t.Fatalf("stateImport(-replace) error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, ".nlm", "jobs.json")); string(data) != "[]" {
+		t.Errorf("jobs.json after -replace = %q, want the bundled one", data)
+	}
+
+	// The page parameters hold the session ID and are never bundled,
+	// even with the caches.
+	t.Setenv("HOME", src)
+	full := filepath.Join(t.TempDir(), "full.tar.gz")
+	if err := stateExport(&stateExportArgs{File: full}); err != nil {
+		t.Fatalf("stateExport() error = %v", err)
+	}
+	f, err := os.Open(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var names []string
+	if _, err := state.Read(f, func(name string, _ fs.FileMode, _ io.Reader) error {
+		names = append(names, name)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(names, "nlm/cache/nb1") || slices.Contains(names, "nlm/params.json") {
+		t.Errorf("bundle entries = %v, want the cache without params.json", names)
+	}
+
+	t.Setenv("NLM_STATE_PASSPHRASE", "wrong")
+	if err := stateImport(&stateImportArgs{File: bundle, Replace: true}); err == nil {
+		t.Error("stateImport() with the wrong passphrase: want error")
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	cfg := &config.Config{Profiles: map[string]*config.Profile{"default": {Language: "en"}}}
+	bundled := &config.Config{
+		Profile: "work",
+		Profiles: map[string]*config.Profile{
+			"default": {Language: "de"},
+			"work":    {Notebook: "nb1"},
+		},
+	}
+	creds := stateCredentials{Profiles: map[string]profileCredentials{"work": {AuthToken: "token"}}}
+	merged, kept := mergeConfig(cfg, bundled, creds, false)
+	if !reflect.DeepEqual(merged, []string{"work"}) || !reflect.DeepEqual(kept, []string{"default"}) {
+		t.Errorf("mergeConfig() = %v, %v; want [work], [default]", merged, kept)
+	}
+	if cfg.Lookup("default").Language != "en" || cfg.Lookup("work").AuthToken != "token" || cfg.Profile != "work" {
+		t.Errorf("merged config = %+v", cfg)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+// showStats is the -stats flag: print a cost summary after the command.
+var showStats bool
+
+// rpcStats collects the cost of every RPC the command makes when -stats
+// is set.
+var rpcStats *batchexecute.Stats
+
+// startStats begins collecting request costs if -stats is set. The
+// returned function prints the summary to w.
+func startStats(w io.Writer) func() {
+	if !showStats {
+		return func() {}
+	}
+	rpcStats = new(batchexecute.Stats)
+	start := time.Now()
+	return func() {
+		fmt.Fprintln(w, formatStats(rpcStats.Snapshot(), time.Since(start)))
+	}
+}
+
+// formatStats returns the one-line -stats footer.
+func formatStats(s batchexecute.StatsSnapshot, total time.Duration) string {
+	return fmt.Sprintf("nlm: %s in %s (%s, %d failed), %s waiting, %s sent, %s received, %s total",
+		plural(s.RPCs, "RPC"), plural(s.Requests, "request"), plural(s.Retries, "retry"), s.Failures,
+		roundDuration(s.Latency), formatSize(s.BytesSent), formatSize(s.BytesReceived), roundDuration(total))
+}
+
+// plural formats n with noun, pluralized when n is not 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	if noun == "retry" {
+		return fmt.Sprintf("%d retries", n)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// roundDuration rounds d to a precision fit for display.
+func roundDuration(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(100 * time.Millisecond)
+	}
+	return d.Round(time.Millisecond)
+}
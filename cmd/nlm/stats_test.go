@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+func TestFormatStats(t *testing.T) {
+	s := batchexecute.StatsSnapshot{
+		RPCs:          3,
+		Requests:      4,
+		Retries:       1,
+		BytesSent:     2150,
+		BytesReceived: 46387,
+		Latency:       1234 * time.Millisecond,
+	}
+	want := "nlm: 3 RPCs in 4 requests (1 retry, 0 failed), 1.2s waiting, 2.1 KB sent, 45.3 KB received, 1.5s total"
+	if got := formatStats(s, 1487*time.Millisecond); got != want {
+		t.Errorf("formatStats() = %q, want %q", got, want)
+	}
+	want = "nlm: 1 RPC in 1 request (0 retries, 1 failed), 12ms waiting, 300 B sent, 0 B received, 20ms total"
+	if got := formatStats(batchexecute.StatsSnapshot{RPCs: 1, Requests: 1, Failures: 1, BytesSent: 300, Latency: 12 * time.Millisecond}, 20*time.Millisecond); got != want {
+		t.Errorf("formatStats() = %q, want %q", got, want)
+	}
+}
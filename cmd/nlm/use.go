@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/config"
+)
+
+// notebookFlag is the -notebook global flag, the working notebook for
+// commands run without a notebook argument.
+var notebookFlag string
+
+// notebookArity gives the number of arguments, including the leading
+// notebook, of commands that can fall back to the working notebook.
+// Commands with optional or variadic arguments are left out since a
+// missing notebook cannot be told apart from a missing later argument.
+var notebookArity = map[string]int{
+	"analytics": 1,
+	"sources":   1, "add": 2, "rm-source": 2, "discover-sources": 2,
+	"notes": 1, "new-note": 2, "update-note": 4, "rm-note": 2,
+	"audio-get": 1, "audio-rm": 1, "audio-share": 1, "audio-list": 1,
+	"video-list":     1,
+	"generate-guide": 1, "generate-outline": 1, "generate-section": 1,
+	"generate-chat": 2, "chat": 1,
+	"share-private": 1,
+}
+
+// defaultNotebook returns the working notebook: -notebook if given,
+// otherwise NLM_NOTEBOOK, which the config profile's notebook setting
+// provides.
+func defaultNotebook() string {
+	if notebookFlag != "" {
+		return notebookFlag
+	}
+	return os.Getenv("NLM_NOTEBOOK")
+}
+
+// withDefaultNotebook prepends the working notebook to args when they are
+// one short of n, the count including the notebook.
+func withDefaultNotebook(args []string, n int) []string {
+	nb := defaultNotebook()
+	if nb == "" || len(args) != n-1 {
+		return args
+	}
+	return append([]string{nb}, args...)
+}
+
+// fillNotebookArg supplies the working notebook to commands that take a
+// notebook as their first argument and were run without one.
+func fillNotebookArg(cmd string, args []string) []string {
+	if n, ok := notebookArity[cmd]; ok {
+		return withDefaultNotebook(args, n)
+	}
+	return args
+}
+
+func validateUseArgs(args []string) error {
+	if len(args) > 1 {
+		fmt.Fprintf(os.Stderr, "usage: nlm use [notebook]\n")
+		return fmt.Errorf("invalid arguments")
+	}
+	return nil
+}
+
+// isLocalUse reports whether `use` can run without authentication: it
+// only prints the working notebook, or is given an ID or alias that needs
+// no lookup.
+func isLocalUse(args []string) bool {
+	return len(args) == 0 || isKnownNotebook(args[0])
+}
+
+// showWorkingNotebook prints the working notebook.
+func showWorkingNotebook() error {
+	nb := defaultNotebook()
+	if nb == "" {
+		return fmt.Errorf("no working notebook; set one with 'nlm use <notebook>'")
+	}
+	fmt.Println(nb)
+	return nil
+}
+
+// useNotebook makes ref the working notebook of the active config profile.
+// Titles are resolved to IDs first so later commands need no lookup.
+func useNotebook(c *api.Client, ref string) error {
+	id, err := resolveNotebook(c, ref)
+	if err != nil {
+		return err
+	}
+	path, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	var name string
+	err = config.Update(path, func(cfg *config.Config) error {
+		name = cfg.ActiveName(configProfile)
+		return cfg.Ensure(name).Set("notebook", id)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "nlm: working notebook for profile %q is now %s\n", name, id)
+	return nil
+}
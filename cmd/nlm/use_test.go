@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFillNotebookArg(t *testing.T) {
+	defer func(nb string) { notebookFlag = nb }(notebookFlag)
+	notebookFlag = "nb1"
+	t.Setenv("NLM_NOTEBOOK", "")
+
+	tests := []struct {
+		cmd  string
+		args []string
+		want []string
+	}{
+		{cmd: "sources", args: nil, want: []string{"nb1"}},
+		{cmd: "sources", args: []string{"nb2"}, want: []string{"nb2"}},
+		{cmd: "add", args: []string{"paper.pdf"}, want: []string{"nb1", "paper.pdf"}},
+		{cmd: "generate-chat", args: []string{"why?"}, want: []string{"nb1", "why?"}},
+		{cmd: "update-note", args: []string{"note1", "text", "title"}, want: []string{"nb1", "note1", "text", "title"}},
+		// Too few arguments even with a notebook are left for validation.
+		{cmd: "update-note", args: []string{"note1"}, want: []string{"note1"}},
+		// Destructive notebook commands never default.
+		{cmd: "rm", args: nil, want: nil},
+		{cmd: "summarize", args: []string{"src1"}, want: []string{"src1"}},
+	}
+	for _, tt := range tests {
+		got := fillNotebookArg(tt.cmd, tt.args)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("fillNotebookArg(%q, %q) mismatch (-want +got):\n%s", tt.cmd, tt.args, diff)
+		}
+	}
+}
@@ -9,10 +9,16 @@ import (
 
 // EncodeCreateAudioOverviewArgs encodes arguments for LabsTailwindOrchestrationService.CreateAudioOverview
 // RPC ID: AHyHrd
-// Argument format: [%project_id%, %instructions%]
+// Argument format: [%project_id%, %instructions%, %style%, %length%]
 func EncodeCreateAudioOverviewArgs(req *notebooklmv1alpha1.CreateAudioOverviewRequest) []interface{} {
+	// Only send the customization presets when one is set so default
+	// requests keep the original two-argument shape.
+	format := "[%project_id%, %instructions%]"
+	if req.GetStyle() != 0 || req.GetLength() != 0 {
+		format = "[%project_id%, %instructions%, %style%, %length%]"
+	}
 	// Using generalized argument encoder
-	args, err := argbuilder.EncodeRPCArgs(req, "[%project_id%, %instructions%]")
+	args, err := argbuilder.EncodeRPCArgs(req, format)
 	if err != nil {
 		// Log error and return empty args as fallback
 		// In production, this should be handled better
@@ -35,6 +35,12 @@ const (
 	ArtifactType_ARTIFACT_TYPE_AUDIO_OVERVIEW ArtifactType = 2
 	ArtifactType_ARTIFACT_TYPE_REPORT         ArtifactType = 3
 	ArtifactType_ARTIFACT_TYPE_APP            ArtifactType = 4
+	ArtifactType_ARTIFACT_TYPE_MIND_MAP       ArtifactType = 5
+	ArtifactType_ARTIFACT_TYPE_FLASHCARDS     ArtifactType = 6
+	ArtifactType_ARTIFACT_TYPE_QUIZ           ArtifactType = 7
+	// Visual outputs, not yet available on every account.
+	ArtifactType_ARTIFACT_TYPE_SLIDE_DECK  ArtifactType = 8
+	ArtifactType_ARTIFACT_TYPE_INFOGRAPHIC ArtifactType = 9
 )
 
 // Enum value maps for ArtifactType.
@@ -45,6 +51,11 @@ var (
 		2: "ARTIFACT_TYPE_AUDIO_OVERVIEW",
 		3: "ARTIFACT_TYPE_REPORT",
 		4: "ARTIFACT_TYPE_APP",
+		5: "ARTIFACT_TYPE_MIND_MAP",
+		6: "ARTIFACT_TYPE_FLASHCARDS",
+		7: "ARTIFACT_TYPE_QUIZ",
+		8: "ARTIFACT_TYPE_SLIDE_DECK",
+		9: "ARTIFACT_TYPE_INFOGRAPHIC",
 	}
 	ArtifactType_value = map[string]int32{
 		"ARTIFACT_TYPE_UNSPECIFIED":    0,
@@ -52,6 +63,11 @@ var (
 		"ARTIFACT_TYPE_AUDIO_OVERVIEW": 2,
 		"ARTIFACT_TYPE_REPORT":         3,
 		"ARTIFACT_TYPE_APP":            4,
+		"ARTIFACT_TYPE_MIND_MAP":       5,
+		"ARTIFACT_TYPE_FLASHCARDS":     6,
+		"ARTIFACT_TYPE_QUIZ":           7,
+		"ARTIFACT_TYPE_SLIDE_DECK":     8,
+		"ARTIFACT_TYPE_INFOGRAPHIC":    9,
 	}
 )
 
@@ -134,6 +150,115 @@ func (ArtifactState) EnumDescriptor() ([]byte, []int) {
 	return file_notebooklm_v1alpha1_orchestration_proto_rawDescGZIP(), []int{1}
 }
 
+// Length presets offered by the audio overview customization dialog.
+type AudioOverviewLength int32
+
+const (
+	AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_UNSPECIFIED AudioOverviewLength = 0
+	AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_SHORT       AudioOverviewLength = 1
+	AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_DEFAULT     AudioOverviewLength = 2
+	AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_LONG        AudioOverviewLength = 3
+)
+
+// Enum value maps for AudioOverviewLength.
+var (
+	AudioOverviewLength_name = map[int32]string{
+		0: "AUDIO_OVERVIEW_LENGTH_UNSPECIFIED",
+		1: "AUDIO_OVERVIEW_LENGTH_SHORT",
+		2: "AUDIO_OVERVIEW_LENGTH_DEFAULT",
+		3: "AUDIO_OVERVIEW_LENGTH_LONG",
+	}
+	AudioOverviewLength_value = map[string]int32{
+		"AUDIO_OVERVIEW_LENGTH_UNSPECIFIED": 0,
+		"AUDIO_OVERVIEW_LENGTH_SHORT":       1,
+		"AUDIO_OVERVIEW_LENGTH_DEFAULT":     2,
+		"AUDIO_OVERVIEW_LENGTH_LONG":        3,
+	}
+)
+
+func (x AudioOverviewLength) Enum() *AudioOverviewLength {
+	p := new(AudioOverviewLength)
+	*p = x
+	return p
+}
+
+func (x AudioOverviewLength) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AudioOverviewLength) Descriptor() protoreflect.EnumDescriptor {
+	return file_notebooklm_v1alpha1_orchestration_proto_enumTypes[2].Descriptor()
+}
+
+func (AudioOverviewLength) Type() protoreflect.EnumType {
+	return &file_notebooklm_v1alpha1_orchestration_proto_enumTypes[2]
+}
+
+func (x AudioOverviewLength) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AudioOverviewLength.Descriptor instead.
+func (AudioOverviewLength) EnumDescriptor() ([]byte, []int) {
+	return file_notebooklm_v1alpha1_orchestration_proto_rawDescGZIP(), []int{2}
+}
+
+// Host-style presets offered by the audio overview customization dialog.
+type AudioOverviewStyle int32
+
+const (
+	AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_UNSPECIFIED AudioOverviewStyle = 0
+	AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_DEEP_DIVE   AudioOverviewStyle = 1
+	AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_BRIEF       AudioOverviewStyle = 2
+	AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_CRITIQUE    AudioOverviewStyle = 3
+	AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_DEBATE      AudioOverviewStyle = 4
+)
+
+// Enum value maps for AudioOverviewStyle.
+var (
+	AudioOverviewStyle_name = map[int32]string{
+		0: "AUDIO_OVERVIEW_STYLE_UNSPECIFIED",
+		1: "AUDIO_OVERVIEW_STYLE_DEEP_DIVE",
+		2: "AUDIO_OVERVIEW_STYLE_BRIEF",
+		3: "AUDIO_OVERVIEW_STYLE_CRITIQUE",
+		4: "AUDIO_OVERVIEW_STYLE_DEBATE",
+	}
+	AudioOverviewStyle_value = map[string]int32{
+		"AUDIO_OVERVIEW_STYLE_UNSPECIFIED": 0,
+		"AUDIO_OVERVIEW_STYLE_DEEP_DIVE":   1,
+		"AUDIO_OVERVIEW_STYLE_BRIEF":       2,
+		"AUDIO_OVERVIEW_STYLE_CRITIQUE":    3,
+		"AUDIO_OVERVIEW_STYLE_DEBATE":      4,
+	}
+)
+
+func (x AudioOverviewStyle) Enum() *AudioOverviewStyle {
+	p := new(AudioOverviewStyle)
+	*p = x
+	return p
+}
+
+func (x AudioOverviewStyle) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AudioOverviewStyle) Descriptor() protoreflect.EnumDescriptor {
+	return file_notebooklm_v1alpha1_orchestration_proto_enumTypes[3].Descriptor()
+}
+
+func (AudioOverviewStyle) Type() protoreflect.EnumType {
+	return &file_notebooklm_v1alpha1_orchestration_proto_enumTypes[3]
+}
+
+func (x AudioOverviewStyle) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AudioOverviewStyle.Descriptor instead.
+func (AudioOverviewStyle) EnumDescriptor() ([]byte, []int) {
+	return file_notebooklm_v1alpha1_orchestration_proto_rawDescGZIP(), []int{3}
+}
+
 type Context struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -608,6 +733,9 @@ type CreateArtifactRequest struct {
 	Context   *Context  `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
 	ProjectId string    `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	Artifact  *Artifact `protobuf:"bytes,3,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	// Custom instructions from the "Customize" box, such as
+	// "focus on chapter 3". Applies to every artifact type.
+	Instructions string `protobuf:"bytes,4,opt,name=instructions,proto3" json:"instructions,omitempty"`
 }
 
 func (x *CreateArtifactRequest) Reset() {
@@ -663,6 +791,13 @@ func (x *CreateArtifactRequest) GetArtifact() *Artifact {
 	return nil
 }
 
+func (x *CreateArtifactRequest) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
 type GetArtifactRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1053,9 +1188,11 @@ type CreateAudioOverviewRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProjectId    string   `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	AudioType    int32    `protobuf:"varint,2,opt,name=audio_type,json=audioType,proto3" json:"audio_type,omitempty"`
-	Instructions []string `protobuf:"bytes,3,rep,name=instructions,proto3" json:"instructions,omitempty"`
+	ProjectId    string              `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	AudioType    int32               `protobuf:"varint,2,opt,name=audio_type,json=audioType,proto3" json:"audio_type,omitempty"`
+	Instructions []string            `protobuf:"bytes,3,rep,name=instructions,proto3" json:"instructions,omitempty"`
+	Length       AudioOverviewLength `protobuf:"varint,4,opt,name=length,proto3,enum=notebooklm.v1alpha1.AudioOverviewLength" json:"length,omitempty"`
+	Style        AudioOverviewStyle  `protobuf:"varint,5,opt,name=style,proto3,enum=notebooklm.v1alpha1.AudioOverviewStyle" json:"style,omitempty"`
 }
 
 func (x *CreateAudioOverviewRequest) Reset() {
@@ -1111,6 +1248,20 @@ func (x *CreateAudioOverviewRequest) GetInstructions() []string {
 	return nil
 }
 
+func (x *CreateAudioOverviewRequest) GetLength() AudioOverviewLength {
+	if x != nil {
+		return x.Length
+	}
+	return AudioOverviewLength_AUDIO_OVERVIEW_LENGTH_UNSPECIFIED
+}
+
+func (x *CreateAudioOverviewRequest) GetStyle() AudioOverviewStyle {
+	if x != nil {
+		return x.Style
+	}
+	return AudioOverviewStyle_AUDIO_OVERVIEW_STYLE_UNSPECIFIED
+}
+
 type GetAudioOverviewRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -3474,7 +3625,7 @@ var file_notebooklm_v1alpha1_orchestration_proto_rawDesc = []byte{
 	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
 	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
 	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa9, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xcd, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41,
 	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36,
 	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
@@ -3485,446 +3636,489 @@ var file_notebooklm_v1alpha1_orchestration_proto_rawDesc = []byte{
 	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
 	0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72,
 	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
-	0x22, 0x35, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52,
+	0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x35, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x15,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
+	0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73,
+	0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x55, 0x0a,
+	0x15, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
 	0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74,
-	0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x39, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x3b, 0x0a, 0x0b,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x55, 0x0a, 0x15, 0x52, 0x65, 0x6e,
-	0x61, 0x6d, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
-	0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x54, 0x69, 0x74, 0x6c, 0x65,
-	0x22, 0x38, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x74,
-	0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x22, 0x71, 0x0a, 0x14, 0x4c, 0x69,
-	0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
-	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d,
-	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x7c, 0x0a,
-	0x15, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
-	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
-	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
-	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6b, 0x0a, 0x13, 0x41,
-	0x63, 0x74, 0x4f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x7e, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x54,
+	0x69, 0x74, 0x6c, 0x65, 0x22, 0x38, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x22, 0x71,
+	0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
 	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74,
-	0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5b, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41,
-	0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x3b, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41,
-	0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x49, 0x64, 0x22, 0x4d, 0x0a, 0x16, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
-	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x71,
-	0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x22, 0x50, 0x0a, 0x17, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x7c, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x09, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
 	0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x22, 0x77, 0x0a, 0x1f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x46,
-	0x72, 0x65, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x52,
+	0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0x6b, 0x0a, 0x13, 0x41, 0x63, 0x74, 0x4f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
 	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12, 0x1d, 0x0a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
 	0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x53, 0x0a, 0x20,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x46, 0x72, 0x65, 0x65, 0x46, 0x6f, 0x72, 0x6d,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x66, 0x69, 0x6e,
-	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x46, 0x69, 0x6e, 0x61,
-	0x6c, 0x22, 0x41, 0x0a, 0x20, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x21, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x67,
-	0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
-	0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3b, 0x0a, 0x1a, 0x47,
-	0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69,
-	0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
+	0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0xff, 0x01, 0x0a,
+	0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72,
+	0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75,
+	0x64, 0x69, 0x6f, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x61, 0x75, 0x64, 0x69, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x40, 0x0a,
+	0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e,
+	0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65,
+	0x77, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x3d, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27,
+	0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69,
+	0x65, 0x77, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x22, 0x5b,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
 	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x10, 0x50, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x12, 0x21, 0x0a,
-	0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x30, 0x0a, 0x14, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65,
-	0x77, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x61,
-	0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x3f, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x65, 0x64, 0x22, 0x59, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d,
-	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x80, 0x01,
-	0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x64, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38,
-	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x08,
-	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
-	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x22, 0x6d, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
-	0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
-	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22,
-	0x9b, 0x02, 0x0a, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12,
-	0x25, 0x0a, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
-	0x6c, 0x12, 0x28, 0x0a, 0x10, 0x79, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x5f, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x79, 0x6f, 0x75,
-	0x74, 0x75, 0x62, 0x65, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x0b, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x7f, 0x0a,
-	0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
-	0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
-	0x6f, 0x74, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08,
-	0x6e, 0x6f, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x2f,
-	0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4e, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x73, 0x22,
-	0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x3b, 0x0a, 0x1a, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x16, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
-	0x64, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12,
-	0x39, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x6f, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x0a, 0x4e, 0x6f,
-	0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x1b, 0x0a, 0x19,
-	0x47, 0x65, 0x74, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8b, 0x01, 0x0a, 0x14, 0x4d, 0x75,
-	0x74, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x80, 0x01, 0x0a, 0x07, 0x41, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x40, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74,
-	0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x6f, 0x74,
-	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
-	0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x76, 0x0a, 0x0f, 0x41, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2f, 0x0a,
-	0x13, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x65, 0x6d, 0x61, 0x69,
-	0x6c, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32,
-	0x0a, 0x15, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x5f, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x64,
-	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x45, 0x6d, 0x6f,
-	0x6a, 0x69, 0x22, 0x42, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x22, 0x38, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x73,
-	0x22, 0x35, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x32, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x50, 0x0a, 0x17, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x77, 0x0a, 0x1f, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x46, 0x72, 0x65, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
 	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0xda, 0x01, 0x0a, 0x21,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x56, 0x69, 0x65, 0x77,
-	0x65, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x31, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33,
-	0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52,
-	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6d, 0x0a, 0x14, 0x4d, 0x75, 0x74, 0x61,
-	0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12,
-	0x36, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x07,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x69, 0x0a, 0x13, 0x4d, 0x75, 0x74, 0x61, 0x74,
-	0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x07, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e,
-	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x73, 0x22, 0x43, 0x0a, 0x22, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x63, 0x65,
-	0x6e, 0x74, 0x6c, 0x79, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x64, 0x73, 0x22, 0x53, 0x0a, 0x20, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x46, 0x72,
+	0x65, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x19, 0x0a, 0x08,
+	0x69, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x69, 0x73, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0x41, 0x0a, 0x20, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x21, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x3b, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x41,
+	0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0xc7,
+	0x01, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x74,
+	0x69, 0x63, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x6f,
+	0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x12, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69,
+	0x65, 0x77, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x22, 0x59, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74,
+	0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6d, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x07, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x6f,
+	0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x07, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x9b, 0x02, 0x0a, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62,
+	0x61, 0x73, 0x65, 0x36, 0x34, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x79, 0x6f, 0x75, 0x74, 0x75,
+	0x62, 0x65, 0x5f, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x79, 0x6f, 0x75, 0x74, 0x75, 0x62, 0x65, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x49,
+	0x64, 0x12, 0x40, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x22, 0x7f, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
 	0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x1b, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x49, 0x64, 0x22, 0x78, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x46, 0x72, 0x65, 0x73, 0x68, 0x12, 0x3d,
-	0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x22, 0x30, 0x0a,
-	0x11, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x22,
-	0x33, 0x0a, 0x14, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x49, 0x64, 0x22, 0x3e, 0x0a, 0x1d, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x75, 0x69, 0x64, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x49, 0x64, 0x22, 0x3d, 0x0a, 0x1c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x75, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x49, 0x64, 0x22, 0x37, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4f,
-	0x75, 0x74, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x22, 0x2f, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4e, 0x6f,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f,
+	0x74, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f,
+	0x74, 0x65, 0x49, 0x64, 0x73, 0x22, 0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x4d, 0x75, 0x74, 0x61,
+	0x74, 0x65, 0x4e, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
 	0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x37, 0x0a, 0x16,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e,
+	0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x6f, 0x74,
+	0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x22, 0x50, 0x0a, 0x0a, 0x4e, 0x6f, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x8b, 0x01, 0x0a, 0x14, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73,
+	0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x80, 0x01,
+	0x0a, 0x07, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x40,
+	0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x22, 0x76, 0x0a, 0x0f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x6e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x13, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x45, 0x6d, 0x6f, 0x6a, 0x69, 0x22, 0x42, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x22, 0x38, 0x0a, 0x15,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x73, 0x22, 0x35, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x32, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x64, 0x22, 0xda, 0x01, 0x0a, 0x21, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74,
+	0x6c, 0x79, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74,
+	0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6d,
+	0x0a, 0x14, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
 	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74, 0x44, 0x72,
-	0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x69, 0x0a,
+	0x13, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x35, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x43, 0x0a, 0x22, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64,
+	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x3a, 0x0a,
+	0x1b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0x78, 0x0a, 0x1c, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x46,
+	0x72, 0x65, 0x73, 0x68, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x65, 0x64, 0x22, 0x30, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x14, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0x3e, 0x0a, 0x1d, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x75,
+	0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x3d, 0x0a, 0x1c, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x75,
+	0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
 	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x34, 0x0a, 0x13, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x37, 0x0a, 0x16, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x22, 0x37, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x11, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x44, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22,
-	0x80, 0x01, 0x0a, 0x15, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x46, 0x65, 0x65, 0x64, 0x62, 0x61,
-	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x65, 0x65, 0x64,
-	0x62, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a,
-	0x0d, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x54, 0x65,
-	0x78, 0x74, 0x2a, 0x98, 0x01, 0x0a, 0x0c, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
-	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x45, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x52,
-	0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x55, 0x44, 0x49,
-	0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14,
-	0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45,
-	0x50, 0x4f, 0x52, 0x54, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41,
-	0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x50, 0x50, 0x10, 0x04, 0x2a, 0x81, 0x01,
-	0x0a, 0x0d, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
-	0x1e, 0x0a, 0x1a, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
-	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x1b, 0x0a, 0x17, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
-	0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14,
-	0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52,
-	0x45, 0x41, 0x44, 0x59, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41,
-	0x43, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10,
-	0x03, 0x32, 0xe2, 0x2d, 0x0a, 0x20, 0x4c, 0x61, 0x62, 0x73, 0x54, 0x61, 0x69, 0x6c, 0x77, 0x69,
-	0x6e, 0x64, 0x4f, 0x72, 0x63, 0x68, 0x65, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x90, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
-	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
-	0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69,
-	0x66, 0x61, 0x63, 0x74, 0x22, 0x33, 0xc2, 0xf3, 0x18, 0x06, 0x78, 0x70, 0x57, 0x47, 0x4c, 0x66,
-	0xca, 0xf3, 0x18, 0x25, 0x5b, 0x25, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x25, 0x2c, 0x20,
-	0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x61,
-	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x25, 0x5d, 0x12, 0x74, 0x0a, 0x0b, 0x47, 0x65, 0x74,
-	0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x27, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
-	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47,
-	0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
-	0x22, 0x1d, 0xc2, 0xf3, 0x18, 0x06, 0x42, 0x6e, 0x4c, 0x79, 0x75, 0x66, 0xca, 0xf3, 0x18, 0x0f,
-	0x5b, 0x25, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12,
-	0x86, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41,
+	0x34, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x80, 0x01, 0x0a, 0x15, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
+	0x46, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x5f,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x65, 0x65, 0x64,
+	0x62, 0x61, 0x63, 0x6b, 0x54, 0x65, 0x78, 0x74, 0x2a, 0xa7, 0x02, 0x0a, 0x0c, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x52, 0x54,
+	0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x52, 0x54, 0x49,
+	0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x45, 0x10, 0x01,
+	0x12, 0x20, 0x0a, 0x1c, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x41, 0x55, 0x44, 0x49, 0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57,
+	0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11,
+	0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x50,
+	0x50, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x49, 0x4e, 0x44, 0x5f, 0x4d, 0x41, 0x50, 0x10, 0x05, 0x12,
+	0x1c, 0x0a, 0x18, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x46, 0x4c, 0x41, 0x53, 0x48, 0x43, 0x41, 0x52, 0x44, 0x53, 0x10, 0x06, 0x12, 0x16, 0x0a,
+	0x12, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x51,
+	0x55, 0x49, 0x5a, 0x10, 0x07, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4c, 0x49, 0x44, 0x45, 0x5f, 0x44, 0x45, 0x43,
+	0x4b, 0x10, 0x08, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x47, 0x52, 0x41, 0x50, 0x48, 0x49, 0x43,
+	0x10, 0x09, 0x2a, 0x81, 0x01, 0x0a, 0x0d, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x41,
+	0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xa0, 0x01, 0x0a, 0x13, 0x41, 0x75, 0x64, 0x69, 0x6f,
+	0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x25,
+	0x0a, 0x21, 0x41, 0x55, 0x44, 0x49, 0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57,
+	0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x55, 0x44, 0x49, 0x4f, 0x5f, 0x4f,
+	0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x5f, 0x53,
+	0x48, 0x4f, 0x52, 0x54, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x41, 0x55, 0x44, 0x49, 0x4f, 0x5f,
+	0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x5f,
+	0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x44,
+	0x49, 0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x4c, 0x45, 0x4e, 0x47,
+	0x54, 0x48, 0x5f, 0x4c, 0x4f, 0x4e, 0x47, 0x10, 0x03, 0x2a, 0xc2, 0x01, 0x0a, 0x12, 0x41, 0x75,
+	0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x53, 0x74, 0x79, 0x6c, 0x65,
+	0x12, 0x24, 0x0a, 0x20, 0x41, 0x55, 0x44, 0x49, 0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49,
+	0x45, 0x57, 0x5f, 0x53, 0x54, 0x59, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x41, 0x55, 0x44, 0x49, 0x4f, 0x5f,
+	0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x53, 0x54, 0x59, 0x4c, 0x45, 0x5f, 0x44,
+	0x45, 0x45, 0x50, 0x5f, 0x44, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55,
+	0x44, 0x49, 0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x53, 0x54, 0x59,
+	0x4c, 0x45, 0x5f, 0x42, 0x52, 0x49, 0x45, 0x46, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x41, 0x55,
+	0x44, 0x49, 0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x53, 0x54, 0x59,
+	0x4c, 0x45, 0x5f, 0x43, 0x52, 0x49, 0x54, 0x49, 0x51, 0x55, 0x45, 0x10, 0x03, 0x12, 0x1f, 0x0a,
+	0x1b, 0x41, 0x55, 0x44, 0x49, 0x4f, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x56, 0x49, 0x45, 0x57, 0x5f,
+	0x53, 0x54, 0x59, 0x4c, 0x45, 0x5f, 0x44, 0x45, 0x42, 0x41, 0x54, 0x45, 0x10, 0x04, 0x32, 0xf5,
+	0x2d, 0x0a, 0x20, 0x4c, 0x61, 0x62, 0x73, 0x54, 0x61, 0x69, 0x6c, 0x77, 0x69, 0x6e, 0x64, 0x4f,
+	0x72, 0x63, 0x68, 0x65, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x90, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x22, 0x33, 0xc2, 0xf3, 0x18, 0x06, 0x78, 0x70, 0x57, 0x47, 0x4c, 0x66, 0xca, 0xf3, 0x18,
+	0x25, 0x5b, 0x25, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x61, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x25, 0x5d, 0x12, 0x74, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x27, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
+	0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41,
 	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
 	0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x22, 0x29, 0xc2,
-	0xf3, 0x18, 0x06, 0x44, 0x4a, 0x65, 0x7a, 0x42, 0x63, 0xca, 0xf3, 0x18, 0x1b, 0x5b, 0x25, 0x61,
-	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x25, 0x2c, 0x20, 0x25, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x25, 0x5d, 0x12, 0x67, 0x0a, 0x0e, 0x52, 0x65, 0x6e, 0x61,
-	0x6d, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74,
-	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74,
-	0x69, 0x66, 0x61, 0x63, 0x74, 0x22, 0x0a, 0xc2, 0xf3, 0x18, 0x06, 0x72, 0x63, 0x33, 0x64, 0x38,
-	0x64, 0x12, 0x73, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66,
-	0x61, 0x63, 0x74, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1d, 0xc2, 0xf3, 0x18, 0x06, 0x57, 0x78, 0x42,
-	0x5a, 0x74, 0x62, 0xca, 0xf3, 0x18, 0x0f, 0x5b, 0x25, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
-	0x74, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x9f, 0x01, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x41,
-	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x29, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
-	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72,
-	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x37, 0xc2, 0xf3, 0x18, 0x06, 0x4c, 0x66, 0x54, 0x58, 0x6f, 0x65, 0xca, 0xf3, 0x18, 0x29, 0x5b,
-	0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x70,
-	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x61, 0x67, 0x65,
-	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x25, 0x5d, 0x12, 0x86, 0x01, 0x0a, 0x0c, 0x41, 0x63, 0x74,
-	0x4f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
-	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x41, 0x63, 0x74, 0x4f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x34, 0xc2, 0xf3, 0x18,
-	0x06, 0x79, 0x79, 0x72, 0x79, 0x4a, 0x65, 0xca, 0xf3, 0x18, 0x26, 0x5b, 0x25, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x25,
-	0x5d, 0x12, 0x7a, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
-	0x25, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x22, 0x27, 0xc2, 0xf3, 0x18, 0x06, 0x69, 0x7a, 0x41, 0x6f, 0x44, 0x64,
-	0xca, 0xf3, 0x18, 0x19, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x25, 0x2c, 0x20,
-	0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x98, 0x01,
-	0x0a, 0x14, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65,
-	0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x30, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
-	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x22, 0x1d, 0xc2,
+	0xf3, 0x18, 0x06, 0x42, 0x6e, 0x4c, 0x79, 0x75, 0x66, 0xca, 0xf3, 0x18, 0x0f, 0x5b, 0x25, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x86, 0x01, 0x0a,
+	0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12,
+	0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x6f,
+	0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x22, 0x29, 0xc2, 0xf3, 0x18, 0x06,
+	0x44, 0x4a, 0x65, 0x7a, 0x42, 0x63, 0xca, 0xf3, 0x18, 0x1b, 0x5b, 0x25, 0x61, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x25, 0x2c, 0x20, 0x25, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x25, 0x5d, 0x12, 0x67, 0x0a, 0x0e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
+	0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x22, 0x0a, 0xc2, 0xf3, 0x18, 0x06, 0x72, 0x63, 0x33, 0x64, 0x38, 0x64, 0x12, 0x73,
+	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x1d, 0xc2, 0xf3, 0x18, 0x06, 0x57, 0x78, 0x42, 0x5a, 0x74, 0x62,
+	0xca, 0xf3, 0x18, 0x0f, 0x5b, 0x25, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69,
+	0x64, 0x25, 0x5d, 0x12, 0x9f, 0x01, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x29, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
+	0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x37, 0xc2, 0xf3,
+	0x18, 0x06, 0x4c, 0x66, 0x54, 0x58, 0x6f, 0x65, 0xca, 0xf3, 0x18, 0x29, 0x5b, 0x25, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x25, 0x5d, 0x12, 0x86, 0x01, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x4f, 0x6e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x74,
+	0x4f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x34, 0xc2, 0xf3, 0x18, 0x06, 0x79, 0x79,
+	0x72, 0x79, 0x4a, 0x65, 0xca, 0xf3, 0x18, 0x26, 0x5b, 0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x25, 0x2c,
+	0x20, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x25, 0x5d, 0x12, 0x7a,
+	0x0a, 0x0a, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x6e,
+	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x22, 0x27, 0xc2, 0xf3, 0x18, 0x06, 0x69, 0x7a, 0x41, 0x6f, 0x44, 0x64, 0xca, 0xf3, 0x18,
+	0x19, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x98, 0x01, 0x0a, 0x14, 0x43,
 	0x68, 0x65, 0x63, 0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0xc2, 0xf3, 0x18,
-	0x06, 0x79, 0x52, 0x39, 0x59, 0x6f, 0x66, 0xca, 0xf3, 0x18, 0x0d, 0x5b, 0x25, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x71, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x29, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
-	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1d, 0xc2, 0xf3,
-	0x18, 0x05, 0x74, 0x47, 0x4d, 0x42, 0x4a, 0xca, 0xf3, 0x18, 0x10, 0x5b, 0x5b, 0x25, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x25, 0x5d, 0x5d, 0x12, 0x93, 0x01, 0x0a, 0x0f,
-	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
-	0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e,
+	0x65, 0x73, 0x73, 0x12, 0x30, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
+	0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0xc2, 0xf3, 0x18, 0x06, 0x79, 0x52,
+	0x39, 0x59, 0x6f, 0x66, 0xca, 0xf3, 0x18, 0x0d, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x71, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x29, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1d, 0xc2, 0xf3, 0x18, 0x05, 0x74,
+	0x47, 0x4d, 0x42, 0x4a, 0xca, 0xf3, 0x18, 0x10, 0x5b, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x73, 0x25, 0x5d, 0x5d, 0x12, 0x93, 0x01, 0x0a, 0x0f, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2b, 0x2e, 0x6e,
 	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
 	0x61, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0xc2, 0xf3, 0x18, 0x06,
-	0x71, 0x58, 0x79, 0x61, 0x4e, 0x65, 0xca, 0xf3, 0x18, 0x17, 0x5b, 0x25, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x71, 0x75, 0x65, 0x72, 0x79, 0x25,
-	0x5d, 0x12, 0x6e, 0x0a, 0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
-	0x26, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
-	0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x22, 0x1b, 0xc2, 0xf3, 0x18, 0x06, 0x68, 0x69, 0x7a, 0x6f, 0x4a, 0x63,
-	0xca, 0xf3, 0x18, 0x0d, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x25,
-	0x5d, 0x12, 0x7d, 0x0a, 0x0c, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x12, 0x28, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6e, 0x6f,
-	0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x26, 0xc2, 0xf3, 0x18, 0x06, 0x62, 0x37,
-	0x57, 0x66, 0x6a, 0x65, 0xca, 0xf3, 0x18, 0x18, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x25, 0x5d,
-	0x12, 0x74, 0x0a, 0x0d, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x12, 0x29, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6e,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0xc2, 0xf3, 0x18, 0x06, 0x71, 0x58, 0x79,
+	0x61, 0x4e, 0x65, 0xca, 0xf3, 0x18, 0x17, 0x5b, 0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x71, 0x75, 0x65, 0x72, 0x79, 0x25, 0x5d, 0x12, 0x6e,
+	0x0a, 0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x26, 0x2e, 0x6e,
 	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x1b, 0xc2, 0xf3, 0x18, 0x06, 0x46,
-	0x4c, 0x6d, 0x4a, 0x71, 0x65, 0xca, 0xf3, 0x18, 0x0d, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x98, 0x01, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x12, 0x2f,
+	0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c,
+	0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x22, 0x1b, 0xc2, 0xf3, 0x18, 0x06, 0x68, 0x69, 0x7a, 0x6f, 0x4a, 0x63, 0xca, 0xf3, 0x18,
+	0x0d, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x7d,
+	0x0a, 0x0c, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x28,
+	0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
+	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x26, 0xc2, 0xf3, 0x18, 0x06, 0x62, 0x37, 0x57, 0x66, 0x6a,
+	0x65, 0xca, 0xf3, 0x18, 0x18, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x25, 0x2c, 0x20, 0x25, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x25, 0x5d, 0x12, 0x74, 0x0a,
+	0x0d, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x29,
 	0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f,
-	0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x22, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76,
-	0x69, 0x65, 0x77, 0x22, 0x2c, 0xc2, 0xf3, 0x18, 0x06, 0x41, 0x48, 0x79, 0x48, 0x72, 0x64, 0xca,
-	0xf3, 0x18, 0x1e, 0x5b, 0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25,
-	0x2c, 0x20, 0x25, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x25,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x1b, 0xc2, 0xf3, 0x18, 0x06, 0x46, 0x4c, 0x6d, 0x4a,
+	0x71, 0x65, 0xca, 0xf3, 0x18, 0x0d, 0x5b, 0x25, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x25, 0x5d, 0x12, 0xab, 0x01, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x75,
+	0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x12, 0x2f, 0x2e, 0x6e, 0x6f,
+	0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65,
+	0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6e,
+	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77,
+	0x22, 0x3f, 0xc2, 0xf3, 0x18, 0x06, 0x41, 0x48, 0x79, 0x48, 0x72, 0x64, 0xca, 0xf3, 0x18, 0x31,
+	0x5b, 0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25,
+	0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x25, 0x2c, 0x20, 0x25,
+	0x73, 0x74, 0x79, 0x6c, 0x65, 0x25, 0x2c, 0x20, 0x25, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x25,
 	0x5d, 0x12, 0x82, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x76,
 	0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x12, 0x2c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
 	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74,
@@ -4194,207 +4388,211 @@ func file_notebooklm_v1alpha1_orchestration_proto_rawDescGZIP() []byte {
 	return file_notebooklm_v1alpha1_orchestration_proto_rawDescData
 }
 
-var file_notebooklm_v1alpha1_orchestration_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_notebooklm_v1alpha1_orchestration_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
 var file_notebooklm_v1alpha1_orchestration_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_notebooklm_v1alpha1_orchestration_proto_goTypes = []interface{}{
 	(ArtifactType)(0),                          // 0: notebooklm.v1alpha1.ArtifactType
 	(ArtifactState)(0),                         // 1: notebooklm.v1alpha1.ArtifactState
-	(*Context)(nil),                            // 2: notebooklm.v1alpha1.Context
-	(*Artifact)(nil),                           // 3: notebooklm.v1alpha1.Artifact
-	(*ArtifactSource)(nil),                     // 4: notebooklm.v1alpha1.ArtifactSource
-	(*TextFragment)(nil),                       // 5: notebooklm.v1alpha1.TextFragment
-	(*Report)(nil),                             // 6: notebooklm.v1alpha1.Report
-	(*Section)(nil),                            // 7: notebooklm.v1alpha1.Section
-	(*App)(nil),                                // 8: notebooklm.v1alpha1.App
-	(*CreateArtifactRequest)(nil),              // 9: notebooklm.v1alpha1.CreateArtifactRequest
-	(*GetArtifactRequest)(nil),                 // 10: notebooklm.v1alpha1.GetArtifactRequest
-	(*UpdateArtifactRequest)(nil),              // 11: notebooklm.v1alpha1.UpdateArtifactRequest
-	(*RenameArtifactRequest)(nil),              // 12: notebooklm.v1alpha1.RenameArtifactRequest
-	(*DeleteArtifactRequest)(nil),              // 13: notebooklm.v1alpha1.DeleteArtifactRequest
-	(*ListArtifactsRequest)(nil),               // 14: notebooklm.v1alpha1.ListArtifactsRequest
-	(*ListArtifactsResponse)(nil),              // 15: notebooklm.v1alpha1.ListArtifactsResponse
-	(*ActOnSourcesRequest)(nil),                // 16: notebooklm.v1alpha1.ActOnSourcesRequest
-	(*CreateAudioOverviewRequest)(nil),         // 17: notebooklm.v1alpha1.CreateAudioOverviewRequest
-	(*GetAudioOverviewRequest)(nil),            // 18: notebooklm.v1alpha1.GetAudioOverviewRequest
-	(*DeleteAudioOverviewRequest)(nil),         // 19: notebooklm.v1alpha1.DeleteAudioOverviewRequest
-	(*DiscoverSourcesRequest)(nil),             // 20: notebooklm.v1alpha1.DiscoverSourcesRequest
-	(*DiscoverSourcesResponse)(nil),            // 21: notebooklm.v1alpha1.DiscoverSourcesResponse
-	(*GenerateFreeFormStreamedRequest)(nil),    // 22: notebooklm.v1alpha1.GenerateFreeFormStreamedRequest
-	(*GenerateFreeFormStreamedResponse)(nil),   // 23: notebooklm.v1alpha1.GenerateFreeFormStreamedResponse
-	(*GenerateReportSuggestionsRequest)(nil),   // 24: notebooklm.v1alpha1.GenerateReportSuggestionsRequest
-	(*GenerateReportSuggestionsResponse)(nil),  // 25: notebooklm.v1alpha1.GenerateReportSuggestionsResponse
-	(*GetProjectAnalyticsRequest)(nil),         // 26: notebooklm.v1alpha1.GetProjectAnalyticsRequest
-	(*ProjectAnalytics)(nil),                   // 27: notebooklm.v1alpha1.ProjectAnalytics
-	(*ListFeaturedProjectsRequest)(nil),        // 28: notebooklm.v1alpha1.ListFeaturedProjectsRequest
-	(*ListFeaturedProjectsResponse)(nil),       // 29: notebooklm.v1alpha1.ListFeaturedProjectsResponse
-	(*AddSourceRequest)(nil),                   // 30: notebooklm.v1alpha1.AddSourceRequest
-	(*SourceInput)(nil),                        // 31: notebooklm.v1alpha1.SourceInput
-	(*CreateNoteRequest)(nil),                  // 32: notebooklm.v1alpha1.CreateNoteRequest
-	(*DeleteNotesRequest)(nil),                 // 33: notebooklm.v1alpha1.DeleteNotesRequest
-	(*GetNotesRequest)(nil),                    // 34: notebooklm.v1alpha1.GetNotesRequest
-	(*MutateNoteRequest)(nil),                  // 35: notebooklm.v1alpha1.MutateNoteRequest
-	(*NoteUpdate)(nil),                         // 36: notebooklm.v1alpha1.NoteUpdate
-	(*GetOrCreateAccountRequest)(nil),          // 37: notebooklm.v1alpha1.GetOrCreateAccountRequest
-	(*MutateAccountRequest)(nil),               // 38: notebooklm.v1alpha1.MutateAccountRequest
-	(*Account)(nil),                            // 39: notebooklm.v1alpha1.Account
-	(*AccountSettings)(nil),                    // 40: notebooklm.v1alpha1.AccountSettings
-	(*CreateProjectRequest)(nil),               // 41: notebooklm.v1alpha1.CreateProjectRequest
-	(*DeleteProjectsRequest)(nil),              // 42: notebooklm.v1alpha1.DeleteProjectsRequest
-	(*DeleteSourcesRequest)(nil),               // 43: notebooklm.v1alpha1.DeleteSourcesRequest
-	(*GetProjectRequest)(nil),                  // 44: notebooklm.v1alpha1.GetProjectRequest
-	(*ListRecentlyViewedProjectsRequest)(nil),  // 45: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest
-	(*MutateProjectRequest)(nil),               // 46: notebooklm.v1alpha1.MutateProjectRequest
-	(*MutateSourceRequest)(nil),                // 47: notebooklm.v1alpha1.MutateSourceRequest
-	(*RemoveRecentlyViewedProjectRequest)(nil), // 48: notebooklm.v1alpha1.RemoveRecentlyViewedProjectRequest
-	(*CheckSourceFreshnessRequest)(nil),        // 49: notebooklm.v1alpha1.CheckSourceFreshnessRequest
-	(*CheckSourceFreshnessResponse)(nil),       // 50: notebooklm.v1alpha1.CheckSourceFreshnessResponse
-	(*LoadSourceRequest)(nil),                  // 51: notebooklm.v1alpha1.LoadSourceRequest
-	(*RefreshSourceRequest)(nil),               // 52: notebooklm.v1alpha1.RefreshSourceRequest
-	(*GenerateDocumentGuidesRequest)(nil),      // 53: notebooklm.v1alpha1.GenerateDocumentGuidesRequest
-	(*GenerateNotebookGuideRequest)(nil),       // 54: notebooklm.v1alpha1.GenerateNotebookGuideRequest
-	(*GenerateOutlineRequest)(nil),             // 55: notebooklm.v1alpha1.GenerateOutlineRequest
-	(*GenerateSectionRequest)(nil),             // 56: notebooklm.v1alpha1.GenerateSectionRequest
-	(*StartDraftRequest)(nil),                  // 57: notebooklm.v1alpha1.StartDraftRequest
-	(*StartSectionRequest)(nil),                // 58: notebooklm.v1alpha1.StartSectionRequest
-	(*SubmitFeedbackRequest)(nil),              // 59: notebooklm.v1alpha1.SubmitFeedbackRequest
-	(*Source)(nil),                             // 60: notebooklm.v1alpha1.Source
-	(*AudioOverview)(nil),                      // 61: notebooklm.v1alpha1.AudioOverview
-	(*SourceId)(nil),                           // 62: notebooklm.v1alpha1.SourceId
-	(*fieldmaskpb.FieldMask)(nil),              // 63: google.protobuf.FieldMask
-	(*timestamppb.Timestamp)(nil),              // 64: google.protobuf.Timestamp
-	(*Project)(nil),                            // 65: notebooklm.v1alpha1.Project
-	(SourceType)(0),                            // 66: notebooklm.v1alpha1.SourceType
-	(*wrapperspb.Int32Value)(nil),              // 67: google.protobuf.Int32Value
-	(*GenerateMagicViewRequest)(nil),           // 68: notebooklm.v1alpha1.GenerateMagicViewRequest
-	(*emptypb.Empty)(nil),                      // 69: google.protobuf.Empty
-	(*GetNotesResponse)(nil),                   // 70: notebooklm.v1alpha1.GetNotesResponse
-	(*ListRecentlyViewedProjectsResponse)(nil), // 71: notebooklm.v1alpha1.ListRecentlyViewedProjectsResponse
-	(*GenerateDocumentGuidesResponse)(nil),     // 72: notebooklm.v1alpha1.GenerateDocumentGuidesResponse
-	(*GenerateNotebookGuideResponse)(nil),      // 73: notebooklm.v1alpha1.GenerateNotebookGuideResponse
-	(*GenerateOutlineResponse)(nil),            // 74: notebooklm.v1alpha1.GenerateOutlineResponse
-	(*GenerateSectionResponse)(nil),            // 75: notebooklm.v1alpha1.GenerateSectionResponse
-	(*StartDraftResponse)(nil),                 // 76: notebooklm.v1alpha1.StartDraftResponse
-	(*StartSectionResponse)(nil),               // 77: notebooklm.v1alpha1.StartSectionResponse
-	(*GenerateMagicViewResponse)(nil),          // 78: notebooklm.v1alpha1.GenerateMagicViewResponse
+	(AudioOverviewLength)(0),                   // 2: notebooklm.v1alpha1.AudioOverviewLength
+	(AudioOverviewStyle)(0),                    // 3: notebooklm.v1alpha1.AudioOverviewStyle
+	(*Context)(nil),                            // 4: notebooklm.v1alpha1.Context
+	(*Artifact)(nil),                           // 5: notebooklm.v1alpha1.Artifact
+	(*ArtifactSource)(nil),                     // 6: notebooklm.v1alpha1.ArtifactSource
+	(*TextFragment)(nil),                       // 7: notebooklm.v1alpha1.TextFragment
+	(*Report)(nil),                             // 8: notebooklm.v1alpha1.Report
+	(*Section)(nil),                            // 9: notebooklm.v1alpha1.Section
+	(*App)(nil),                                // 10: notebooklm.v1alpha1.App
+	(*CreateArtifactRequest)(nil),              // 11: notebooklm.v1alpha1.CreateArtifactRequest
+	(*GetArtifactRequest)(nil),                 // 12: notebooklm.v1alpha1.GetArtifactRequest
+	(*UpdateArtifactRequest)(nil),              // 13: notebooklm.v1alpha1.UpdateArtifactRequest
+	(*RenameArtifactRequest)(nil),              // 14: notebooklm.v1alpha1.RenameArtifactRequest
+	(*DeleteArtifactRequest)(nil),              // 15: notebooklm.v1alpha1.DeleteArtifactRequest
+	(*ListArtifactsRequest)(nil),               // 16: notebooklm.v1alpha1.ListArtifactsRequest
+	(*ListArtifactsResponse)(nil),              // 17: notebooklm.v1alpha1.ListArtifactsResponse
+	(*ActOnSourcesRequest)(nil),                // 18: notebooklm.v1alpha1.ActOnSourcesRequest
+	(*CreateAudioOverviewRequest)(nil),         // 19: notebooklm.v1alpha1.CreateAudioOverviewRequest
+	(*GetAudioOverviewRequest)(nil),            // 20: notebooklm.v1alpha1.GetAudioOverviewRequest
+	(*DeleteAudioOverviewRequest)(nil),         // 21: notebooklm.v1alpha1.DeleteAudioOverviewRequest
+	(*DiscoverSourcesRequest)(nil),             // 22: notebooklm.v1alpha1.DiscoverSourcesRequest
+	(*DiscoverSourcesResponse)(nil),            // 23: notebooklm.v1alpha1.DiscoverSourcesResponse
+	(*GenerateFreeFormStreamedRequest)(nil),    // 24: notebooklm.v1alpha1.GenerateFreeFormStreamedRequest
+	(*GenerateFreeFormStreamedResponse)(nil),   // 25: notebooklm.v1alpha1.GenerateFreeFormStreamedResponse
+	(*GenerateReportSuggestionsRequest)(nil),   // 26: notebooklm.v1alpha1.GenerateReportSuggestionsRequest
+	(*GenerateReportSuggestionsResponse)(nil),  // 27: notebooklm.v1alpha1.GenerateReportSuggestionsResponse
+	(*GetProjectAnalyticsRequest)(nil),         // 28: notebooklm.v1alpha1.GetProjectAnalyticsRequest
+	(*ProjectAnalytics)(nil),                   // 29: notebooklm.v1alpha1.ProjectAnalytics
+	(*ListFeaturedProjectsRequest)(nil),        // 30: notebooklm.v1alpha1.ListFeaturedProjectsRequest
+	(*ListFeaturedProjectsResponse)(nil),       // 31: notebooklm.v1alpha1.ListFeaturedProjectsResponse
+	(*AddSourceRequest)(nil),                   // 32: notebooklm.v1alpha1.AddSourceRequest
+	(*SourceInput)(nil),                        // 33: notebooklm.v1alpha1.SourceInput
+	(*CreateNoteRequest)(nil),                  // 34: notebooklm.v1alpha1.CreateNoteRequest
+	(*DeleteNotesRequest)(nil),                 // 35: notebooklm.v1alpha1.DeleteNotesRequest
+	(*GetNotesRequest)(nil),                    // 36: notebooklm.v1alpha1.GetNotesRequest
+	(*MutateNoteRequest)(nil),                  // 37: notebooklm.v1alpha1.MutateNoteRequest
+	(*NoteUpdate)(nil),                         // 38: notebooklm.v1alpha1.NoteUpdate
+	(*GetOrCreateAccountRequest)(nil),          // 39: notebooklm.v1alpha1.GetOrCreateAccountRequest
+	(*MutateAccountRequest)(nil),               // 40: notebooklm.v1alpha1.MutateAccountRequest
+	(*Account)(nil),                            // 41: notebooklm.v1alpha1.Account
+	(*AccountSettings)(nil),                    // 42: notebooklm.v1alpha1.AccountSettings
+	(*CreateProjectRequest)(nil),               // 43: notebooklm.v1alpha1.CreateProjectRequest
+	(*DeleteProjectsRequest)(nil),              // 44: notebooklm.v1alpha1.DeleteProjectsRequest
+	(*DeleteSourcesRequest)(nil),               // 45: notebooklm.v1alpha1.DeleteSourcesRequest
+	(*GetProjectRequest)(nil),                  // 46: notebooklm.v1alpha1.GetProjectRequest
+	(*ListRecentlyViewedProjectsRequest)(nil),  // 47: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest
+	(*MutateProjectRequest)(nil),               // 48: notebooklm.v1alpha1.MutateProjectRequest
+	(*MutateSourceRequest)(nil),                // 49: notebooklm.v1alpha1.MutateSourceRequest
+	(*RemoveRecentlyViewedProjectRequest)(nil), // 50: notebooklm.v1alpha1.RemoveRecentlyViewedProjectRequest
+	(*CheckSourceFreshnessRequest)(nil),        // 51: notebooklm.v1alpha1.CheckSourceFreshnessRequest
+	(*CheckSourceFreshnessResponse)(nil),       // 52: notebooklm.v1alpha1.CheckSourceFreshnessResponse
+	(*LoadSourceRequest)(nil),                  // 53: notebooklm.v1alpha1.LoadSourceRequest
+	(*RefreshSourceRequest)(nil),               // 54: notebooklm.v1alpha1.RefreshSourceRequest
+	(*GenerateDocumentGuidesRequest)(nil),      // 55: notebooklm.v1alpha1.GenerateDocumentGuidesRequest
+	(*GenerateNotebookGuideRequest)(nil),       // 56: notebooklm.v1alpha1.GenerateNotebookGuideRequest
+	(*GenerateOutlineRequest)(nil),             // 57: notebooklm.v1alpha1.GenerateOutlineRequest
+	(*GenerateSectionRequest)(nil),             // 58: notebooklm.v1alpha1.GenerateSectionRequest
+	(*StartDraftRequest)(nil),                  // 59: notebooklm.v1alpha1.StartDraftRequest
+	(*StartSectionRequest)(nil),                // 60: notebooklm.v1alpha1.StartSectionRequest
+	(*SubmitFeedbackRequest)(nil),              // 61: notebooklm.v1alpha1.SubmitFeedbackRequest
+	(*Source)(nil),                             // 62: notebooklm.v1alpha1.Source
+	(*AudioOverview)(nil),                      // 63: notebooklm.v1alpha1.AudioOverview
+	(*SourceId)(nil),                           // 64: notebooklm.v1alpha1.SourceId
+	(*fieldmaskpb.FieldMask)(nil),              // 65: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil),              // 66: google.protobuf.Timestamp
+	(*Project)(nil),                            // 67: notebooklm.v1alpha1.Project
+	(SourceType)(0),                            // 68: notebooklm.v1alpha1.SourceType
+	(*wrapperspb.Int32Value)(nil),              // 69: google.protobuf.Int32Value
+	(*GenerateMagicViewRequest)(nil),           // 70: notebooklm.v1alpha1.GenerateMagicViewRequest
+	(*emptypb.Empty)(nil),                      // 71: google.protobuf.Empty
+	(*GetNotesResponse)(nil),                   // 72: notebooklm.v1alpha1.GetNotesResponse
+	(*ListRecentlyViewedProjectsResponse)(nil), // 73: notebooklm.v1alpha1.ListRecentlyViewedProjectsResponse
+	(*GenerateDocumentGuidesResponse)(nil),     // 74: notebooklm.v1alpha1.GenerateDocumentGuidesResponse
+	(*GenerateNotebookGuideResponse)(nil),      // 75: notebooklm.v1alpha1.GenerateNotebookGuideResponse
+	(*GenerateOutlineResponse)(nil),            // 76: notebooklm.v1alpha1.GenerateOutlineResponse
+	(*GenerateSectionResponse)(nil),            // 77: notebooklm.v1alpha1.GenerateSectionResponse
+	(*StartDraftResponse)(nil),                 // 78: notebooklm.v1alpha1.StartDraftResponse
+	(*StartSectionResponse)(nil),               // 79: notebooklm.v1alpha1.StartSectionResponse
+	(*GenerateMagicViewResponse)(nil),          // 80: notebooklm.v1alpha1.GenerateMagicViewResponse
 }
 var file_notebooklm_v1alpha1_orchestration_proto_depIdxs = []int32{
 	0,  // 0: notebooklm.v1alpha1.Artifact.type:type_name -> notebooklm.v1alpha1.ArtifactType
-	4,  // 1: notebooklm.v1alpha1.Artifact.sources:type_name -> notebooklm.v1alpha1.ArtifactSource
+	6,  // 1: notebooklm.v1alpha1.Artifact.sources:type_name -> notebooklm.v1alpha1.ArtifactSource
 	1,  // 2: notebooklm.v1alpha1.Artifact.state:type_name -> notebooklm.v1alpha1.ArtifactState
-	60, // 3: notebooklm.v1alpha1.Artifact.note:type_name -> notebooklm.v1alpha1.Source
-	61, // 4: notebooklm.v1alpha1.Artifact.audio_overview:type_name -> notebooklm.v1alpha1.AudioOverview
-	6,  // 5: notebooklm.v1alpha1.Artifact.tailored_report:type_name -> notebooklm.v1alpha1.Report
-	8,  // 6: notebooklm.v1alpha1.Artifact.app:type_name -> notebooklm.v1alpha1.App
-	62, // 7: notebooklm.v1alpha1.ArtifactSource.source_id:type_name -> notebooklm.v1alpha1.SourceId
-	5,  // 8: notebooklm.v1alpha1.ArtifactSource.text_fragments:type_name -> notebooklm.v1alpha1.TextFragment
-	7,  // 9: notebooklm.v1alpha1.Report.sections:type_name -> notebooklm.v1alpha1.Section
-	2,  // 10: notebooklm.v1alpha1.CreateArtifactRequest.context:type_name -> notebooklm.v1alpha1.Context
-	3,  // 11: notebooklm.v1alpha1.CreateArtifactRequest.artifact:type_name -> notebooklm.v1alpha1.Artifact
-	3,  // 12: notebooklm.v1alpha1.UpdateArtifactRequest.artifact:type_name -> notebooklm.v1alpha1.Artifact
-	63, // 13: notebooklm.v1alpha1.UpdateArtifactRequest.update_mask:type_name -> google.protobuf.FieldMask
-	3,  // 14: notebooklm.v1alpha1.ListArtifactsResponse.artifacts:type_name -> notebooklm.v1alpha1.Artifact
-	60, // 15: notebooklm.v1alpha1.DiscoverSourcesResponse.sources:type_name -> notebooklm.v1alpha1.Source
-	64, // 16: notebooklm.v1alpha1.ProjectAnalytics.last_accessed:type_name -> google.protobuf.Timestamp
-	65, // 17: notebooklm.v1alpha1.ListFeaturedProjectsResponse.projects:type_name -> notebooklm.v1alpha1.Project
-	31, // 18: notebooklm.v1alpha1.AddSourceRequest.sources:type_name -> notebooklm.v1alpha1.SourceInput
-	66, // 19: notebooklm.v1alpha1.SourceInput.source_type:type_name -> notebooklm.v1alpha1.SourceType
-	36, // 20: notebooklm.v1alpha1.MutateNoteRequest.updates:type_name -> notebooklm.v1alpha1.NoteUpdate
-	39, // 21: notebooklm.v1alpha1.MutateAccountRequest.account:type_name -> notebooklm.v1alpha1.Account
-	63, // 22: notebooklm.v1alpha1.MutateAccountRequest.update_mask:type_name -> google.protobuf.FieldMask
-	40, // 23: notebooklm.v1alpha1.Account.settings:type_name -> notebooklm.v1alpha1.AccountSettings
-	67, // 24: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest.limit:type_name -> google.protobuf.Int32Value
-	67, // 25: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest.offset:type_name -> google.protobuf.Int32Value
-	67, // 26: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest.filter:type_name -> google.protobuf.Int32Value
-	65, // 27: notebooklm.v1alpha1.MutateProjectRequest.updates:type_name -> notebooklm.v1alpha1.Project
-	60, // 28: notebooklm.v1alpha1.MutateSourceRequest.updates:type_name -> notebooklm.v1alpha1.Source
-	64, // 29: notebooklm.v1alpha1.CheckSourceFreshnessResponse.last_checked:type_name -> google.protobuf.Timestamp
-	9,  // 30: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateArtifact:input_type -> notebooklm.v1alpha1.CreateArtifactRequest
-	10, // 31: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetArtifact:input_type -> notebooklm.v1alpha1.GetArtifactRequest
-	11, // 32: notebooklm.v1alpha1.LabsTailwindOrchestrationService.UpdateArtifact:input_type -> notebooklm.v1alpha1.UpdateArtifactRequest
-	12, // 33: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RenameArtifact:input_type -> notebooklm.v1alpha1.RenameArtifactRequest
-	13, // 34: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteArtifact:input_type -> notebooklm.v1alpha1.DeleteArtifactRequest
-	14, // 35: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListArtifacts:input_type -> notebooklm.v1alpha1.ListArtifactsRequest
-	16, // 36: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ActOnSources:input_type -> notebooklm.v1alpha1.ActOnSourcesRequest
-	30, // 37: notebooklm.v1alpha1.LabsTailwindOrchestrationService.AddSources:input_type -> notebooklm.v1alpha1.AddSourceRequest
-	49, // 38: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CheckSourceFreshness:input_type -> notebooklm.v1alpha1.CheckSourceFreshnessRequest
-	43, // 39: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteSources:input_type -> notebooklm.v1alpha1.DeleteSourcesRequest
-	20, // 40: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DiscoverSources:input_type -> notebooklm.v1alpha1.DiscoverSourcesRequest
-	51, // 41: notebooklm.v1alpha1.LabsTailwindOrchestrationService.LoadSource:input_type -> notebooklm.v1alpha1.LoadSourceRequest
-	47, // 42: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateSource:input_type -> notebooklm.v1alpha1.MutateSourceRequest
-	52, // 43: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RefreshSource:input_type -> notebooklm.v1alpha1.RefreshSourceRequest
-	17, // 44: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateAudioOverview:input_type -> notebooklm.v1alpha1.CreateAudioOverviewRequest
-	18, // 45: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetAudioOverview:input_type -> notebooklm.v1alpha1.GetAudioOverviewRequest
-	19, // 46: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteAudioOverview:input_type -> notebooklm.v1alpha1.DeleteAudioOverviewRequest
-	32, // 47: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateNote:input_type -> notebooklm.v1alpha1.CreateNoteRequest
-	33, // 48: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteNotes:input_type -> notebooklm.v1alpha1.DeleteNotesRequest
-	34, // 49: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetNotes:input_type -> notebooklm.v1alpha1.GetNotesRequest
-	35, // 50: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateNote:input_type -> notebooklm.v1alpha1.MutateNoteRequest
-	41, // 51: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateProject:input_type -> notebooklm.v1alpha1.CreateProjectRequest
-	42, // 52: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteProjects:input_type -> notebooklm.v1alpha1.DeleteProjectsRequest
-	44, // 53: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProject:input_type -> notebooklm.v1alpha1.GetProjectRequest
-	28, // 54: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListFeaturedProjects:input_type -> notebooklm.v1alpha1.ListFeaturedProjectsRequest
-	45, // 55: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListRecentlyViewedProjects:input_type -> notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest
-	46, // 56: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateProject:input_type -> notebooklm.v1alpha1.MutateProjectRequest
-	48, // 57: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RemoveRecentlyViewedProject:input_type -> notebooklm.v1alpha1.RemoveRecentlyViewedProjectRequest
-	53, // 58: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateDocumentGuides:input_type -> notebooklm.v1alpha1.GenerateDocumentGuidesRequest
-	22, // 59: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateFreeFormStreamed:input_type -> notebooklm.v1alpha1.GenerateFreeFormStreamedRequest
-	54, // 60: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateNotebookGuide:input_type -> notebooklm.v1alpha1.GenerateNotebookGuideRequest
-	55, // 61: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateOutline:input_type -> notebooklm.v1alpha1.GenerateOutlineRequest
-	24, // 62: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateReportSuggestions:input_type -> notebooklm.v1alpha1.GenerateReportSuggestionsRequest
-	56, // 63: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateSection:input_type -> notebooklm.v1alpha1.GenerateSectionRequest
-	57, // 64: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartDraft:input_type -> notebooklm.v1alpha1.StartDraftRequest
-	58, // 65: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartSection:input_type -> notebooklm.v1alpha1.StartSectionRequest
-	68, // 66: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateMagicView:input_type -> notebooklm.v1alpha1.GenerateMagicViewRequest
-	26, // 67: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProjectAnalytics:input_type -> notebooklm.v1alpha1.GetProjectAnalyticsRequest
-	59, // 68: notebooklm.v1alpha1.LabsTailwindOrchestrationService.SubmitFeedback:input_type -> notebooklm.v1alpha1.SubmitFeedbackRequest
-	37, // 69: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetOrCreateAccount:input_type -> notebooklm.v1alpha1.GetOrCreateAccountRequest
-	38, // 70: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateAccount:input_type -> notebooklm.v1alpha1.MutateAccountRequest
-	3,  // 71: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateArtifact:output_type -> notebooklm.v1alpha1.Artifact
-	3,  // 72: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetArtifact:output_type -> notebooklm.v1alpha1.Artifact
-	3,  // 73: notebooklm.v1alpha1.LabsTailwindOrchestrationService.UpdateArtifact:output_type -> notebooklm.v1alpha1.Artifact
-	3,  // 74: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RenameArtifact:output_type -> notebooklm.v1alpha1.Artifact
-	69, // 75: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteArtifact:output_type -> google.protobuf.Empty
-	15, // 76: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListArtifacts:output_type -> notebooklm.v1alpha1.ListArtifactsResponse
-	69, // 77: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ActOnSources:output_type -> google.protobuf.Empty
-	65, // 78: notebooklm.v1alpha1.LabsTailwindOrchestrationService.AddSources:output_type -> notebooklm.v1alpha1.Project
-	50, // 79: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CheckSourceFreshness:output_type -> notebooklm.v1alpha1.CheckSourceFreshnessResponse
-	69, // 80: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteSources:output_type -> google.protobuf.Empty
-	21, // 81: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DiscoverSources:output_type -> notebooklm.v1alpha1.DiscoverSourcesResponse
-	60, // 82: notebooklm.v1alpha1.LabsTailwindOrchestrationService.LoadSource:output_type -> notebooklm.v1alpha1.Source
-	60, // 83: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateSource:output_type -> notebooklm.v1alpha1.Source
-	60, // 84: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RefreshSource:output_type -> notebooklm.v1alpha1.Source
-	61, // 85: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateAudioOverview:output_type -> notebooklm.v1alpha1.AudioOverview
-	61, // 86: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetAudioOverview:output_type -> notebooklm.v1alpha1.AudioOverview
-	69, // 87: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteAudioOverview:output_type -> google.protobuf.Empty
-	60, // 88: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateNote:output_type -> notebooklm.v1alpha1.Source
-	69, // 89: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteNotes:output_type -> google.protobuf.Empty
-	70, // 90: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetNotes:output_type -> notebooklm.v1alpha1.GetNotesResponse
-	60, // 91: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateNote:output_type -> notebooklm.v1alpha1.Source
-	65, // 92: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateProject:output_type -> notebooklm.v1alpha1.Project
-	69, // 93: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteProjects:output_type -> google.protobuf.Empty
-	65, // 94: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProject:output_type -> notebooklm.v1alpha1.Project
-	29, // 95: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListFeaturedProjects:output_type -> notebooklm.v1alpha1.ListFeaturedProjectsResponse
-	71, // 96: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListRecentlyViewedProjects:output_type -> notebooklm.v1alpha1.ListRecentlyViewedProjectsResponse
-	65, // 97: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateProject:output_type -> notebooklm.v1alpha1.Project
-	69, // 98: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RemoveRecentlyViewedProject:output_type -> google.protobuf.Empty
-	72, // 99: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateDocumentGuides:output_type -> notebooklm.v1alpha1.GenerateDocumentGuidesResponse
-	23, // 100: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateFreeFormStreamed:output_type -> notebooklm.v1alpha1.GenerateFreeFormStreamedResponse
-	73, // 101: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateNotebookGuide:output_type -> notebooklm.v1alpha1.GenerateNotebookGuideResponse
-	74, // 102: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateOutline:output_type -> notebooklm.v1alpha1.GenerateOutlineResponse
-	25, // 103: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateReportSuggestions:output_type -> notebooklm.v1alpha1.GenerateReportSuggestionsResponse
-	75, // 104: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateSection:output_type -> notebooklm.v1alpha1.GenerateSectionResponse
-	76, // 105: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartDraft:output_type -> notebooklm.v1alpha1.StartDraftResponse
-	77, // 106: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartSection:output_type -> notebooklm.v1alpha1.StartSectionResponse
-	78, // 107: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateMagicView:output_type -> notebooklm.v1alpha1.GenerateMagicViewResponse
-	27, // 108: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProjectAnalytics:output_type -> notebooklm.v1alpha1.ProjectAnalytics
-	69, // 109: notebooklm.v1alpha1.LabsTailwindOrchestrationService.SubmitFeedback:output_type -> google.protobuf.Empty
-	39, // 110: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetOrCreateAccount:output_type -> notebooklm.v1alpha1.Account
-	39, // 111: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateAccount:output_type -> notebooklm.v1alpha1.Account
-	71, // [71:112] is the sub-list for method output_type
-	30, // [30:71] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	62, // 3: notebooklm.v1alpha1.Artifact.note:type_name -> notebooklm.v1alpha1.Source
+	63, // 4: notebooklm.v1alpha1.Artifact.audio_overview:type_name -> notebooklm.v1alpha1.AudioOverview
+	8,  // 5: notebooklm.v1alpha1.Artifact.tailored_report:type_name -> notebooklm.v1alpha1.Report
+	10, // 6: notebooklm.v1alpha1.Artifact.app:type_name -> notebooklm.v1alpha1.App
+	64, // 7: notebooklm.v1alpha1.ArtifactSource.source_id:type_name -> notebooklm.v1alpha1.SourceId
+	7,  // 8: notebooklm.v1alpha1.ArtifactSource.text_fragments:type_name -> notebooklm.v1alpha1.TextFragment
+	9,  // 9: notebooklm.v1alpha1.Report.sections:type_name -> notebooklm.v1alpha1.Section
+	4,  // 10: notebooklm.v1alpha1.CreateArtifactRequest.context:type_name -> notebooklm.v1alpha1.Context
+	5,  // 11: notebooklm.v1alpha1.CreateArtifactRequest.artifact:type_name -> notebooklm.v1alpha1.Artifact
+	5,  // 12: notebooklm.v1alpha1.UpdateArtifactRequest.artifact:type_name -> notebooklm.v1alpha1.Artifact
+	65, // 13: notebooklm.v1alpha1.UpdateArtifactRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 14: notebooklm.v1alpha1.ListArtifactsResponse.artifacts:type_name -> notebooklm.v1alpha1.Artifact
+	2,  // 15: notebooklm.v1alpha1.CreateAudioOverviewRequest.length:type_name -> notebooklm.v1alpha1.AudioOverviewLength
+	3,  // 16: notebooklm.v1alpha1.CreateAudioOverviewRequest.style:type_name -> notebooklm.v1alpha1.AudioOverviewStyle
+	62, // 17: notebooklm.v1alpha1.DiscoverSourcesResponse.sources:type_name -> notebooklm.v1alpha1.Source
+	66, // 18: notebooklm.v1alpha1.ProjectAnalytics.last_accessed:type_name -> google.protobuf.Timestamp
+	67, // 19: notebooklm.v1alpha1.ListFeaturedProjectsResponse.projects:type_name -> notebooklm.v1alpha1.Project
+	33, // 20: notebooklm.v1alpha1.AddSourceRequest.sources:type_name -> notebooklm.v1alpha1.SourceInput
+	68, // 21: notebooklm.v1alpha1.SourceInput.source_type:type_name -> notebooklm.v1alpha1.SourceType
+	38, // 22: notebooklm.v1alpha1.MutateNoteRequest.updates:type_name -> notebooklm.v1alpha1.NoteUpdate
+	41, // 23: notebooklm.v1alpha1.MutateAccountRequest.account:type_name -> notebooklm.v1alpha1.Account
+	65, // 24: notebooklm.v1alpha1.MutateAccountRequest.update_mask:type_name -> google.protobuf.FieldMask
+	42, // 25: notebooklm.v1alpha1.Account.settings:type_name -> notebooklm.v1alpha1.AccountSettings
+	69, // 26: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest.limit:type_name -> google.protobuf.Int32Value
+	69, // 27: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest.offset:type_name -> google.protobuf.Int32Value
+	69, // 28: notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest.filter:type_name -> google.protobuf.Int32Value
+	67, // 29: notebooklm.v1alpha1.MutateProjectRequest.updates:type_name -> notebooklm.v1alpha1.Project
+	62, // 30: notebooklm.v1alpha1.MutateSourceRequest.updates:type_name -> notebooklm.v1alpha1.Source
+	66, // 31: notebooklm.v1alpha1.CheckSourceFreshnessResponse.last_checked:type_name -> google.protobuf.Timestamp
+	11, // 32: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateArtifact:input_type -> notebooklm.v1alpha1.CreateArtifactRequest
+	12, // 33: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetArtifact:input_type -> notebooklm.v1alpha1.GetArtifactRequest
+	13, // 34: notebooklm.v1alpha1.LabsTailwindOrchestrationService.UpdateArtifact:input_type -> notebooklm.v1alpha1.UpdateArtifactRequest
+	14, // 35: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RenameArtifact:input_type -> notebooklm.v1alpha1.RenameArtifactRequest
+	15, // 36: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteArtifact:input_type -> notebooklm.v1alpha1.DeleteArtifactRequest
+	16, // 37: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListArtifacts:input_type -> notebooklm.v1alpha1.ListArtifactsRequest
+	18, // 38: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ActOnSources:input_type -> notebooklm.v1alpha1.ActOnSourcesRequest
+	32, // 39: notebooklm.v1alpha1.LabsTailwindOrchestrationService.AddSources:input_type -> notebooklm.v1alpha1.AddSourceRequest
+	51, // 40: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CheckSourceFreshness:input_type -> notebooklm.v1alpha1.CheckSourceFreshnessRequest
+	45, // 41: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteSources:input_type -> notebooklm.v1alpha1.DeleteSourcesRequest
+	22, // 42: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DiscoverSources:input_type -> notebooklm.v1alpha1.DiscoverSourcesRequest
+	53, // 43: notebooklm.v1alpha1.LabsTailwindOrchestrationService.LoadSource:input_type -> notebooklm.v1alpha1.LoadSourceRequest
+	49, // 44: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateSource:input_type -> notebooklm.v1alpha1.MutateSourceRequest
+	54, // 45: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RefreshSource:input_type -> notebooklm.v1alpha1.RefreshSourceRequest
+	19, // 46: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateAudioOverview:input_type -> notebooklm.v1alpha1.CreateAudioOverviewRequest
+	20, // 47: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetAudioOverview:input_type -> notebooklm.v1alpha1.GetAudioOverviewRequest
+	21, // 48: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteAudioOverview:input_type -> notebooklm.v1alpha1.DeleteAudioOverviewRequest
+	34, // 49: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateNote:input_type -> notebooklm.v1alpha1.CreateNoteRequest
+	35, // 50: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteNotes:input_type -> notebooklm.v1alpha1.DeleteNotesRequest
+	36, // 51: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetNotes:input_type -> notebooklm.v1alpha1.GetNotesRequest
+	37, // 52: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateNote:input_type -> notebooklm.v1alpha1.MutateNoteRequest
+	43, // 53: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateProject:input_type -> notebooklm.v1alpha1.CreateProjectRequest
+	44, // 54: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteProjects:input_type -> notebooklm.v1alpha1.DeleteProjectsRequest
+	46, // 55: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProject:input_type -> notebooklm.v1alpha1.GetProjectRequest
+	30, // 56: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListFeaturedProjects:input_type -> notebooklm.v1alpha1.ListFeaturedProjectsRequest
+	47, // 57: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListRecentlyViewedProjects:input_type -> notebooklm.v1alpha1.ListRecentlyViewedProjectsRequest
+	48, // 58: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateProject:input_type -> notebooklm.v1alpha1.MutateProjectRequest
+	50, // 59: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RemoveRecentlyViewedProject:input_type -> notebooklm.v1alpha1.RemoveRecentlyViewedProjectRequest
+	55, // 60: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateDocumentGuides:input_type -> notebooklm.v1alpha1.GenerateDocumentGuidesRequest
+	24, // 61: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateFreeFormStreamed:input_type -> notebooklm.v1alpha1.GenerateFreeFormStreamedRequest
+	56, // 62: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateNotebookGuide:input_type -> notebooklm.v1alpha1.GenerateNotebookGuideRequest
+	57, // 63: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateOutline:input_type -> notebooklm.v1alpha1.GenerateOutlineRequest
+	26, // 64: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateReportSuggestions:input_type -> notebooklm.v1alpha1.GenerateReportSuggestionsRequest
+	58, // 65: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateSection:input_type -> notebooklm.v1alpha1.GenerateSectionRequest
+	59, // 66: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartDraft:input_type -> notebooklm.v1alpha1.StartDraftRequest
+	60, // 67: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartSection:input_type -> notebooklm.v1alpha1.StartSectionRequest
+	70, // 68: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateMagicView:input_type -> notebooklm.v1alpha1.GenerateMagicViewRequest
+	28, // 69: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProjectAnalytics:input_type -> notebooklm.v1alpha1.GetProjectAnalyticsRequest
+	61, // 70: notebooklm.v1alpha1.LabsTailwindOrchestrationService.SubmitFeedback:input_type -> notebooklm.v1alpha1.SubmitFeedbackRequest
+	39, // 71: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetOrCreateAccount:input_type -> notebooklm.v1alpha1.GetOrCreateAccountRequest
+	40, // 72: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateAccount:input_type -> notebooklm.v1alpha1.MutateAccountRequest
+	5,  // 73: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateArtifact:output_type -> notebooklm.v1alpha1.Artifact
+	5,  // 74: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetArtifact:output_type -> notebooklm.v1alpha1.Artifact
+	5,  // 75: notebooklm.v1alpha1.LabsTailwindOrchestrationService.UpdateArtifact:output_type -> notebooklm.v1alpha1.Artifact
+	5,  // 76: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RenameArtifact:output_type -> notebooklm.v1alpha1.Artifact
+	71, // 77: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteArtifact:output_type -> google.protobuf.Empty
+	17, // 78: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListArtifacts:output_type -> notebooklm.v1alpha1.ListArtifactsResponse
+	71, // 79: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ActOnSources:output_type -> google.protobuf.Empty
+	67, // 80: notebooklm.v1alpha1.LabsTailwindOrchestrationService.AddSources:output_type -> notebooklm.v1alpha1.Project
+	52, // 81: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CheckSourceFreshness:output_type -> notebooklm.v1alpha1.CheckSourceFreshnessResponse
+	71, // 82: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteSources:output_type -> google.protobuf.Empty
+	23, // 83: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DiscoverSources:output_type -> notebooklm.v1alpha1.DiscoverSourcesResponse
+	62, // 84: notebooklm.v1alpha1.LabsTailwindOrchestrationService.LoadSource:output_type -> notebooklm.v1alpha1.Source
+	62, // 85: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateSource:output_type -> notebooklm.v1alpha1.Source
+	62, // 86: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RefreshSource:output_type -> notebooklm.v1alpha1.Source
+	63, // 87: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateAudioOverview:output_type -> notebooklm.v1alpha1.AudioOverview
+	63, // 88: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetAudioOverview:output_type -> notebooklm.v1alpha1.AudioOverview
+	71, // 89: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteAudioOverview:output_type -> google.protobuf.Empty
+	62, // 90: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateNote:output_type -> notebooklm.v1alpha1.Source
+	71, // 91: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteNotes:output_type -> google.protobuf.Empty
+	72, // 92: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetNotes:output_type -> notebooklm.v1alpha1.GetNotesResponse
+	62, // 93: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateNote:output_type -> notebooklm.v1alpha1.Source
+	67, // 94: notebooklm.v1alpha1.LabsTailwindOrchestrationService.CreateProject:output_type -> notebooklm.v1alpha1.Project
+	71, // 95: notebooklm.v1alpha1.LabsTailwindOrchestrationService.DeleteProjects:output_type -> google.protobuf.Empty
+	67, // 96: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProject:output_type -> notebooklm.v1alpha1.Project
+	31, // 97: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListFeaturedProjects:output_type -> notebooklm.v1alpha1.ListFeaturedProjectsResponse
+	73, // 98: notebooklm.v1alpha1.LabsTailwindOrchestrationService.ListRecentlyViewedProjects:output_type -> notebooklm.v1alpha1.ListRecentlyViewedProjectsResponse
+	67, // 99: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateProject:output_type -> notebooklm.v1alpha1.Project
+	71, // 100: notebooklm.v1alpha1.LabsTailwindOrchestrationService.RemoveRecentlyViewedProject:output_type -> google.protobuf.Empty
+	74, // 101: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateDocumentGuides:output_type -> notebooklm.v1alpha1.GenerateDocumentGuidesResponse
+	25, // 102: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateFreeFormStreamed:output_type -> notebooklm.v1alpha1.GenerateFreeFormStreamedResponse
+	75, // 103: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateNotebookGuide:output_type -> notebooklm.v1alpha1.GenerateNotebookGuideResponse
+	76, // 104: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateOutline:output_type -> notebooklm.v1alpha1.GenerateOutlineResponse
+	27, // 105: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateReportSuggestions:output_type -> notebooklm.v1alpha1.GenerateReportSuggestionsResponse
+	77, // 106: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateSection:output_type -> notebooklm.v1alpha1.GenerateSectionResponse
+	78, // 107: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartDraft:output_type -> notebooklm.v1alpha1.StartDraftResponse
+	79, // 108: notebooklm.v1alpha1.LabsTailwindOrchestrationService.StartSection:output_type -> notebooklm.v1alpha1.StartSectionResponse
+	80, // 109: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GenerateMagicView:output_type -> notebooklm.v1alpha1.GenerateMagicViewResponse
+	29, // 110: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetProjectAnalytics:output_type -> notebooklm.v1alpha1.ProjectAnalytics
+	71, // 111: notebooklm.v1alpha1.LabsTailwindOrchestrationService.SubmitFeedback:output_type -> google.protobuf.Empty
+	41, // 112: notebooklm.v1alpha1.LabsTailwindOrchestrationService.GetOrCreateAccount:output_type -> notebooklm.v1alpha1.Account
+	41, // 113: notebooklm.v1alpha1.LabsTailwindOrchestrationService.MutateAccount:output_type -> notebooklm.v1alpha1.Account
+	73, // [73:114] is the sub-list for method output_type
+	32, // [32:73] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_notebooklm_v1alpha1_orchestration_proto_init() }
@@ -5107,7 +5305,7 @@ func file_notebooklm_v1alpha1_orchestration_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_notebooklm_v1alpha1_orchestration_proto_rawDesc,
-			NumEnums:      2,
+			NumEnums:      4,
 			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
@@ -1225,9 +1225,12 @@ type GuidebookAnalytics struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ViewCount  int32                  `protobuf:"varint,1,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
-	ShareCount int32                  `protobuf:"varint,2,opt,name=share_count,json=shareCount,proto3" json:"share_count,omitempty"`
-	LastViewed *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_viewed,json=lastViewed,proto3" json:"last_viewed,omitempty"`
+	ViewCount     int32                  `protobuf:"varint,1,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	ShareCount    int32                  `protobuf:"varint,2,opt,name=share_count,json=shareCount,proto3" json:"share_count,omitempty"`
+	LastViewed    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_viewed,json=lastViewed,proto3" json:"last_viewed,omitempty"`
+	QuestionCount int32                  `protobuf:"varint,4,opt,name=question_count,json=questionCount,proto3" json:"question_count,omitempty"`
+	// Questions readers asked, most frequent first.
+	Questions []*GuidebookQuestionStat `protobuf:"bytes,5,rep,name=questions,proto3" json:"questions,omitempty"`
 }
 
 func (x *GuidebookAnalytics) Reset() {
@@ -1283,6 +1286,83 @@ func (x *GuidebookAnalytics) GetLastViewed() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GuidebookAnalytics) GetQuestionCount() int32 {
+	if x != nil {
+		return x.QuestionCount
+	}
+	return 0
+}
+
+func (x *GuidebookAnalytics) GetQuestions() []*GuidebookQuestionStat {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+type GuidebookQuestionStat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Question  string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Count     int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	LastAsked *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_asked,json=lastAsked,proto3" json:"last_asked,omitempty"`
+}
+
+func (x *GuidebookQuestionStat) Reset() {
+	*x = GuidebookQuestionStat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuidebookQuestionStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuidebookQuestionStat) ProtoMessage() {}
+
+func (x *GuidebookQuestionStat) ProtoReflect() protoreflect.Message {
+	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuidebookQuestionStat.ProtoReflect.Descriptor instead.
+func (*GuidebookQuestionStat) Descriptor() ([]byte, []int) {
+	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GuidebookQuestionStat) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *GuidebookQuestionStat) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GuidebookQuestionStat) GetLastAsked() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAsked
+	}
+	return nil
+}
+
 type ShareGuidebookRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1295,7 +1375,7 @@ type ShareGuidebookRequest struct {
 func (x *ShareGuidebookRequest) Reset() {
 	*x = ShareGuidebookRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[20]
+		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1308,7 +1388,7 @@ func (x *ShareGuidebookRequest) String() string {
 func (*ShareGuidebookRequest) ProtoMessage() {}
 
 func (x *ShareGuidebookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[20]
+	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1321,7 +1401,7 @@ func (x *ShareGuidebookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShareGuidebookRequest.ProtoReflect.Descriptor instead.
 func (*ShareGuidebookRequest) Descriptor() ([]byte, []int) {
-	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{20}
+	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ShareGuidebookRequest) GetGuidebookId() string {
@@ -1350,7 +1430,7 @@ type ShareGuidebookResponse struct {
 func (x *ShareGuidebookResponse) Reset() {
 	*x = ShareGuidebookResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[21]
+		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1363,7 +1443,7 @@ func (x *ShareGuidebookResponse) String() string {
 func (*ShareGuidebookResponse) ProtoMessage() {}
 
 func (x *ShareGuidebookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[21]
+	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1376,7 +1456,7 @@ func (x *ShareGuidebookResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShareGuidebookResponse.ProtoReflect.Descriptor instead.
 func (*ShareGuidebookResponse) Descriptor() ([]byte, []int) {
-	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{21}
+	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ShareGuidebookResponse) GetShareUrl() string {
@@ -1406,7 +1486,7 @@ type GuidebookGenerateAnswerRequest struct {
 func (x *GuidebookGenerateAnswerRequest) Reset() {
 	*x = GuidebookGenerateAnswerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[22]
+		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1419,7 +1499,7 @@ func (x *GuidebookGenerateAnswerRequest) String() string {
 func (*GuidebookGenerateAnswerRequest) ProtoMessage() {}
 
 func (x *GuidebookGenerateAnswerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[22]
+	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1432,7 +1512,7 @@ func (x *GuidebookGenerateAnswerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuidebookGenerateAnswerRequest.ProtoReflect.Descriptor instead.
 func (*GuidebookGenerateAnswerRequest) Descriptor() ([]byte, []int) {
-	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{22}
+	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GuidebookGenerateAnswerRequest) GetGuidebookId() string {
@@ -1469,7 +1549,7 @@ type GenerateAnswerSettings struct {
 func (x *GenerateAnswerSettings) Reset() {
 	*x = GenerateAnswerSettings{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[23]
+		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1482,7 +1562,7 @@ func (x *GenerateAnswerSettings) String() string {
 func (*GenerateAnswerSettings) ProtoMessage() {}
 
 func (x *GenerateAnswerSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[23]
+	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1495,7 +1575,7 @@ func (x *GenerateAnswerSettings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateAnswerSettings.ProtoReflect.Descriptor instead.
 func (*GenerateAnswerSettings) Descriptor() ([]byte, []int) {
-	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{23}
+	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GenerateAnswerSettings) GetMaxLength() int32 {
@@ -1532,7 +1612,7 @@ type GuidebookGenerateAnswerResponse struct {
 func (x *GuidebookGenerateAnswerResponse) Reset() {
 	*x = GuidebookGenerateAnswerResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[24]
+		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1545,7 +1625,7 @@ func (x *GuidebookGenerateAnswerResponse) String() string {
 func (*GuidebookGenerateAnswerResponse) ProtoMessage() {}
 
 func (x *GuidebookGenerateAnswerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[24]
+	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1558,7 +1638,7 @@ func (x *GuidebookGenerateAnswerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuidebookGenerateAnswerResponse.ProtoReflect.Descriptor instead.
 func (*GuidebookGenerateAnswerResponse) Descriptor() ([]byte, []int) {
-	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{24}
+	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GuidebookGenerateAnswerResponse) GetAnswer() string {
@@ -1595,7 +1675,7 @@ type SourceReference struct {
 func (x *SourceReference) Reset() {
 	*x = SourceReference{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[25]
+		mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1608,7 +1688,7 @@ func (x *SourceReference) String() string {
 func (*SourceReference) ProtoMessage() {}
 
 func (x *SourceReference) ProtoReflect() protoreflect.Message {
-	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[25]
+	mi := &file_notebooklm_v1alpha1_sharing_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1621,7 +1701,7 @@ func (x *SourceReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SourceReference.ProtoReflect.Descriptor instead.
 func (*SourceReference) Descriptor() ([]byte, []int) {
-	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{25}
+	return file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *SourceReference) GetSourceId() string {
@@ -1814,7 +1894,7 @@ var file_notebooklm_v1alpha1_sharing_proto_rawDesc = []byte{
 	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
 	0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
 	0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x91, 0x01, 0x0a, 0x12, 0x47, 0x75,
+	0x28, 0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x82, 0x02, 0x0a, 0x12, 0x47, 0x75,
 	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73,
 	0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x76, 0x69, 0x65, 0x77, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
@@ -1823,176 +1903,192 @@ var file_notebooklm_v1alpha1_sharing_proto_rawDesc = []byte{
 	0x12, 0x3b, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x18,
 	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x22, 0x7a, 0x0a,
-	0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62,
-	0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x75,
-	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x6f,
-	0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
-	0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x50, 0x0a, 0x16, 0x53, 0x68, 0x61,
-	0x72, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x75, 0x72, 0x6c,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x61, 0x72, 0x65, 0x55, 0x72, 0x6c,
-	0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x73, 0x68, 0x61, 0x72, 0x65, 0x49, 0x64, 0x22, 0xa8, 0x01, 0x0a, 0x1e,
-	0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21,
+	0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x48, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
+	0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x75,
+	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x52, 0x09, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x84,
+	0x01, 0x0a, 0x15, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x41, 0x73, 0x6b, 0x65, 0x64, 0x22, 0x7a, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x47, 0x75,
+	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21,
 	0x0a, 0x0c, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x49,
-	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a,
-	0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e,
-	0x73, 0x77, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65,
-	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x82, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
-	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x1f,
-	0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
-	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x07,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x64, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x63, 0x6f,
-	0x72, 0x65, 0x22, 0x5e, 0x0a, 0x0f, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x65,
-	0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63, 0x65,
-	0x72, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63, 0x65, 0x72,
-	0x70, 0x74, 0x2a, 0x8e, 0x01, 0x0a, 0x0f, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x1c, 0x47, 0x55, 0x49, 0x44, 0x45, 0x42,
-	0x4f, 0x4f, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x47, 0x55, 0x49, 0x44,
-	0x45, 0x42, 0x4f, 0x4f, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x52, 0x41,
-	0x46, 0x54, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x47, 0x55, 0x49, 0x44, 0x45, 0x42, 0x4f, 0x4f,
-	0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48,
-	0x45, 0x44, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x47, 0x55, 0x49, 0x44, 0x45, 0x42, 0x4f, 0x4f,
-	0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45,
-	0x44, 0x10, 0x03, 0x32, 0xc1, 0x03, 0x0a, 0x1a, 0x4c, 0x61, 0x62, 0x73, 0x54, 0x61, 0x69, 0x6c,
-	0x77, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x8c, 0x01, 0x0a, 0x0a, 0x53, 0x68, 0x61, 0x72, 0x65, 0x41, 0x75, 0x64, 0x69,
-	0x6f, 0x12, 0x26, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
+	0x64, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x22, 0x50, 0x0a, 0x16, 0x53, 0x68, 0x61, 0x72, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62,
+	0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x49, 0x64, 0x22, 0xa8, 0x01, 0x0a, 0x1e, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62,
+	0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x75,
+	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
+	0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x82,
+	0x01, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d,
+	0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x1f, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
+	0x3e, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x5e, 0x0a, 0x0f, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63, 0x65, 0x72, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x78, 0x63, 0x65, 0x72, 0x70, 0x74, 0x2a, 0x8e, 0x01, 0x0a, 0x0f, 0x47,
+	0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20,
+	0x0a, 0x1c, 0x47, 0x55, 0x49, 0x44, 0x45, 0x42, 0x4f, 0x4f, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1a, 0x0a, 0x16, 0x47, 0x55, 0x49, 0x44, 0x45, 0x42, 0x4f, 0x4f, 0x4b, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x52, 0x41, 0x46, 0x54, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a,
+	0x47, 0x55, 0x49, 0x44, 0x45, 0x42, 0x4f, 0x4f, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19,
+	0x47, 0x55, 0x49, 0x44, 0x45, 0x42, 0x4f, 0x4f, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x44, 0x10, 0x03, 0x32, 0xc1, 0x03, 0x0a, 0x1a,
+	0x4c, 0x61, 0x62, 0x73, 0x54, 0x61, 0x69, 0x6c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x61, 0x72,
+	0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x8c, 0x01, 0x0a, 0x0a, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x26, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x27, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
 	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x41, 0x75, 0x64,
-	0x69, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x69, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2d, 0xc2, 0xf3, 0x18, 0x06,
+	0x52, 0x47, 0x50, 0x39, 0x37, 0x62, 0xca, 0xf3, 0x18, 0x1f, 0x5b, 0x25, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x83, 0x01, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x2d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x22, 0x1a, 0xc2, 0xf3, 0x18, 0x06, 0x4a, 0x46, 0x4d, 0x44, 0x47, 0x64, 0xca,
+	0xf3, 0x18, 0x0c, 0x5b, 0x25, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12,
+	0x8d, 0x01, 0x0a, 0x0c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x12, 0x28, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6e, 0x6f, 0x74,
+	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0xc2, 0xf3, 0x18, 0x06, 0x51, 0x44, 0x79, 0x75, 0x72,
+	0x65, 0xca, 0xf3, 0x18, 0x1a, 0x5b, 0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69,
+	0x64, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x25, 0x5d, 0x32,
+	0xd5, 0x08, 0x0a, 0x1d, 0x4c, 0x61, 0x62, 0x73, 0x54, 0x61, 0x69, 0x6c, 0x77, 0x69, 0x6e, 0x64,
+	0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x76, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c,
+	0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1e, 0xc2, 0xf3, 0x18, 0x06, 0x41,
+	0x52, 0x47, 0x6b, 0x56, 0x63, 0xca, 0xf3, 0x18, 0x10, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x77, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x28, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
 	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x53, 0x68, 0x61, 0x72, 0x65, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x2d, 0xc2, 0xf3, 0x18, 0x06, 0x52, 0x47, 0x50, 0x39, 0x37, 0x62, 0xca, 0xf3,
-	0x18, 0x1f, 0x5b, 0x25, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25,
-	0x5d, 0x12, 0x83, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
-	0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x1a, 0xc2, 0xf3, 0x18,
-	0x06, 0x4a, 0x46, 0x4d, 0x44, 0x47, 0x64, 0xca, 0xf3, 0x18, 0x0c, 0x5b, 0x25, 0x73, 0x68, 0x61,
-	0x72, 0x65, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x8d, 0x01, 0x0a, 0x0c, 0x53, 0x68, 0x61, 0x72,
-	0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x28, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
-	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
-	0x68, 0x61, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0xc2,
-	0xf3, 0x18, 0x06, 0x51, 0x44, 0x79, 0x75, 0x72, 0x65, 0xca, 0xf3, 0x18, 0x1a, 0x5b, 0x25, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x73, 0x25, 0x5d, 0x32, 0xd5, 0x08, 0x0a, 0x1d, 0x4c, 0x61, 0x62, 0x73,
-	0x54, 0x61, 0x69, 0x6c, 0x77, 0x69, 0x6e, 0x64, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x76, 0x0a, 0x0f, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x2b, 0x2e, 0x6e,
-	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f,
-	0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x22, 0x1e, 0xc2, 0xf3, 0x18, 0x06, 0x41, 0x52, 0x47, 0x6b, 0x56, 0x63, 0xca, 0xf3, 0x18,
+	0x47, 0x65, 0x74, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62,
+	0x6f, 0x6f, 0x6b, 0x22, 0x1d, 0xc2, 0xf3, 0x18, 0x05, 0x45, 0x59, 0x71, 0x74, 0x55, 0xca, 0xf3,
+	0x18, 0x10, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64,
+	0x25, 0x5d, 0x12, 0xbe, 0x01, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x6c, 0x79, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f,
+	0x6f, 0x6b, 0x73, 0x12, 0x38, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x47, 0x75, 0x69, 0x64,
+	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e,
+	0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79,
+	0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x29, 0xc2, 0xf3, 0x18, 0x06, 0x59, 0x4a,
+	0x42, 0x70, 0x48, 0x63, 0xca, 0xf3, 0x18, 0x1b, 0x5b, 0x25, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x25, 0x2c, 0x20, 0x25, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x25, 0x5d, 0x12, 0x9b, 0x01, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x47,
+	0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x2c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
+	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0xc2, 0xf3, 0x18, 0x06, 0x52, 0x36, 0x73, 0x6d, 0x61,
+	0x65, 0xca, 0xf3, 0x18, 0x1c, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
+	0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x25,
+	0x5d, 0x12, 0x8d, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f,
+	0x6f, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2f, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6e, 0x6f, 0x74,
+	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x22, 0x1e, 0xc2, 0xf3, 0x18, 0x06, 0x4c, 0x4a, 0x79, 0x7a, 0x65, 0x62, 0xca, 0xf3, 0x18,
 	0x10, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25,
-	0x5d, 0x12, 0x77, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x12, 0x28, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x75, 0x69, 0x64, 0x65,
-	0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6e, 0x6f,
-	0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x22, 0x1d, 0xc2, 0xf3, 0x18,
-	0x05, 0x45, 0x59, 0x71, 0x74, 0x55, 0xca, 0xf3, 0x18, 0x10, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64,
-	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0xbe, 0x01, 0x0a, 0x1c, 0x4c,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x56, 0x69, 0x65, 0x77, 0x65,
-	0x64, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x38, 0x2e, 0x6e, 0x6f,
-	0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x56, 0x69,
-	0x65, 0x77, 0x65, 0x64, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
-	0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x47, 0x75,
-	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x29, 0xc2, 0xf3, 0x18, 0x06, 0x59, 0x4a, 0x42, 0x70, 0x48, 0x63, 0xca, 0xf3, 0x18, 0x1b,
-	0x5b, 0x25, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x25, 0x2c, 0x20, 0x25, 0x70,
-	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x25, 0x5d, 0x12, 0x9b, 0x01, 0x0a, 0x10,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
-	0x12, 0x2c, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x47, 0x75,
-	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d,
-	0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x47, 0x75, 0x69, 0x64,
-	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0xc2,
-	0xf3, 0x18, 0x06, 0x52, 0x36, 0x73, 0x6d, 0x61, 0x65, 0xca, 0xf3, 0x18, 0x1c, 0x5b, 0x25, 0x67,
-	0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x73,
-	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x25, 0x5d, 0x12, 0x8d, 0x01, 0x0a, 0x13, 0x47, 0x65,
-	0x74, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x73, 0x12, 0x2f, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x75, 0x69, 0x64, 0x65,
-	0x62, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f,
-	0x6f, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x1e, 0xc2, 0xf3, 0x18, 0x06, 0x4c,
-	0x4a, 0x79, 0x7a, 0x65, 0x62, 0xca, 0xf3, 0x18, 0x10, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64, 0x65,
-	0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25, 0x5d, 0x12, 0x94, 0x01, 0x0a, 0x0e, 0x53, 0x68,
-	0x61, 0x72, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x2a, 0x2e, 0x6e,
-	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62,
-	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
-	0x68, 0x61, 0x72, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x29, 0xc2, 0xf3, 0x18, 0x05, 0x4f, 0x54, 0x6c, 0x30, 0x4b,
-	0xca, 0xf3, 0x18, 0x1c, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f,
-	0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x25, 0x5d,
-	0x12, 0xbc, 0x01, 0x0a, 0x17, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x33, 0x2e, 0x6e,
-	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x34, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x36, 0xc2, 0xf3, 0x18, 0x06, 0x69, 0x74, 0x41,
-	0x30, 0x70, 0x63, 0xca, 0xf3, 0x18, 0x28, 0x5b, 0x25, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f,
-	0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x25, 0x5d, 0x42,
-	0xd3, 0x01, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
-	0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x42, 0x0c, 0x53, 0x68, 0x61,
-	0x72, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x6d, 0x63, 0x2f, 0x6e, 0x6c, 0x6d, 0x2f,
-	0x67, 0x65, 0x6e, 0x2f, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2f, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
-	0x6c, 0x6d, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x4e, 0x58, 0x58,
-	0xaa, 0x02, 0x13, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x56, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x13, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
-	0x6b, 0x6c, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x1f, 0x4e,
-	0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
-	0x14, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x3a, 0x3a, 0x56, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x5d, 0x12, 0x94, 0x01, 0x0a, 0x0e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x47, 0x75, 0x69, 0x64, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c,
+	0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x47, 0x75, 0x69, 0x64,
+	0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x29, 0xc2,
+	0xf3, 0x18, 0x05, 0x4f, 0x54, 0x6c, 0x30, 0x4b, 0xca, 0xf3, 0x18, 0x1c, 0x5b, 0x25, 0x67, 0x75,
+	0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x25, 0x5d, 0x12, 0xbc, 0x01, 0x0a, 0x17, 0x47, 0x75, 0x69,
+	0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x12, 0x33, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c,
+	0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x75, 0x69, 0x64, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x47, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x36, 0xc2, 0xf3, 0x18, 0x06, 0x69, 0x74, 0x41, 0x30, 0x70, 0x63, 0xca, 0xf3, 0x18, 0x28, 0x5b,
+	0x25, 0x67, 0x75, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x25, 0x2c, 0x20,
+	0x25, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x25, 0x2c, 0x20, 0x25, 0x73, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x25, 0x5d, 0x42, 0xd3, 0x01, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x2e,
+	0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x42, 0x0c, 0x53, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x74, 0x6d, 0x63, 0x2f, 0x6e, 0x6c, 0x6d, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b,
+	0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0xa2, 0x02, 0x03, 0x4e, 0x58, 0x58, 0xaa, 0x02, 0x13, 0x4e, 0x6f, 0x74, 0x65, 0x62,
+	0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02,
+	0x13, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x1f, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x6c,
+	0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x14, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6c, 0x6d, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2008,7 +2104,7 @@ func file_notebooklm_v1alpha1_sharing_proto_rawDescGZIP() []byte {
 }
 
 var file_notebooklm_v1alpha1_sharing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_notebooklm_v1alpha1_sharing_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_notebooklm_v1alpha1_sharing_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_notebooklm_v1alpha1_sharing_proto_goTypes = []interface{}{
 	(GuidebookStatus)(0),                         // 0: notebooklm.v1alpha1.GuidebookStatus
 	(*ShareAudioRequest)(nil),                    // 1: notebooklm.v1alpha1.ShareAudioRequest
@@ -2031,60 +2127,63 @@ var file_notebooklm_v1alpha1_sharing_proto_goTypes = []interface{}{
 	(*GuidebookDetails)(nil),                     // 18: notebooklm.v1alpha1.GuidebookDetails
 	(*GuidebookSection)(nil),                     // 19: notebooklm.v1alpha1.GuidebookSection
 	(*GuidebookAnalytics)(nil),                   // 20: notebooklm.v1alpha1.GuidebookAnalytics
-	(*ShareGuidebookRequest)(nil),                // 21: notebooklm.v1alpha1.ShareGuidebookRequest
-	(*ShareGuidebookResponse)(nil),               // 22: notebooklm.v1alpha1.ShareGuidebookResponse
-	(*GuidebookGenerateAnswerRequest)(nil),       // 23: notebooklm.v1alpha1.GuidebookGenerateAnswerRequest
-	(*GenerateAnswerSettings)(nil),               // 24: notebooklm.v1alpha1.GenerateAnswerSettings
-	(*GuidebookGenerateAnswerResponse)(nil),      // 25: notebooklm.v1alpha1.GuidebookGenerateAnswerResponse
-	(*SourceReference)(nil),                      // 26: notebooklm.v1alpha1.SourceReference
-	(*timestamppb.Timestamp)(nil),                // 27: google.protobuf.Timestamp
-	(SourceType)(0),                              // 28: notebooklm.v1alpha1.SourceType
-	(*emptypb.Empty)(nil),                        // 29: google.protobuf.Empty
+	(*GuidebookQuestionStat)(nil),                // 21: notebooklm.v1alpha1.GuidebookQuestionStat
+	(*ShareGuidebookRequest)(nil),                // 22: notebooklm.v1alpha1.ShareGuidebookRequest
+	(*ShareGuidebookResponse)(nil),               // 23: notebooklm.v1alpha1.ShareGuidebookResponse
+	(*GuidebookGenerateAnswerRequest)(nil),       // 24: notebooklm.v1alpha1.GuidebookGenerateAnswerRequest
+	(*GenerateAnswerSettings)(nil),               // 25: notebooklm.v1alpha1.GenerateAnswerSettings
+	(*GuidebookGenerateAnswerResponse)(nil),      // 26: notebooklm.v1alpha1.GuidebookGenerateAnswerResponse
+	(*SourceReference)(nil),                      // 27: notebooklm.v1alpha1.SourceReference
+	(*timestamppb.Timestamp)(nil),                // 28: google.protobuf.Timestamp
+	(SourceType)(0),                              // 29: notebooklm.v1alpha1.SourceType
+	(*emptypb.Empty)(nil),                        // 30: google.protobuf.Empty
 }
 var file_notebooklm_v1alpha1_sharing_proto_depIdxs = []int32{
-	27, // 0: notebooklm.v1alpha1.ProjectDetails.shared_at:type_name -> google.protobuf.Timestamp
+	28, // 0: notebooklm.v1alpha1.ProjectDetails.shared_at:type_name -> google.protobuf.Timestamp
 	5,  // 1: notebooklm.v1alpha1.ProjectDetails.sources:type_name -> notebooklm.v1alpha1.SourceSummary
-	28, // 2: notebooklm.v1alpha1.SourceSummary.source_type:type_name -> notebooklm.v1alpha1.SourceType
+	29, // 2: notebooklm.v1alpha1.SourceSummary.source_type:type_name -> notebooklm.v1alpha1.SourceType
 	7,  // 3: notebooklm.v1alpha1.ShareProjectRequest.settings:type_name -> notebooklm.v1alpha1.ShareSettings
-	27, // 4: notebooklm.v1alpha1.ShareSettings.expiry_time:type_name -> google.protobuf.Timestamp
+	28, // 4: notebooklm.v1alpha1.ShareSettings.expiry_time:type_name -> google.protobuf.Timestamp
 	7,  // 5: notebooklm.v1alpha1.ShareProjectResponse.settings:type_name -> notebooklm.v1alpha1.ShareSettings
 	0,  // 6: notebooklm.v1alpha1.Guidebook.status:type_name -> notebooklm.v1alpha1.GuidebookStatus
-	27, // 7: notebooklm.v1alpha1.Guidebook.published_at:type_name -> google.protobuf.Timestamp
+	28, // 7: notebooklm.v1alpha1.Guidebook.published_at:type_name -> google.protobuf.Timestamp
 	9,  // 8: notebooklm.v1alpha1.ListRecentlyViewedGuidebooksResponse.guidebooks:type_name -> notebooklm.v1alpha1.Guidebook
 	15, // 9: notebooklm.v1alpha1.PublishGuidebookRequest.settings:type_name -> notebooklm.v1alpha1.PublishSettings
 	9,  // 10: notebooklm.v1alpha1.PublishGuidebookResponse.guidebook:type_name -> notebooklm.v1alpha1.Guidebook
 	9,  // 11: notebooklm.v1alpha1.GuidebookDetails.guidebook:type_name -> notebooklm.v1alpha1.Guidebook
 	19, // 12: notebooklm.v1alpha1.GuidebookDetails.sections:type_name -> notebooklm.v1alpha1.GuidebookSection
 	20, // 13: notebooklm.v1alpha1.GuidebookDetails.analytics:type_name -> notebooklm.v1alpha1.GuidebookAnalytics
-	27, // 14: notebooklm.v1alpha1.GuidebookAnalytics.last_viewed:type_name -> google.protobuf.Timestamp
-	7,  // 15: notebooklm.v1alpha1.ShareGuidebookRequest.settings:type_name -> notebooklm.v1alpha1.ShareSettings
-	24, // 16: notebooklm.v1alpha1.GuidebookGenerateAnswerRequest.settings:type_name -> notebooklm.v1alpha1.GenerateAnswerSettings
-	26, // 17: notebooklm.v1alpha1.GuidebookGenerateAnswerResponse.sources:type_name -> notebooklm.v1alpha1.SourceReference
-	1,  // 18: notebooklm.v1alpha1.LabsTailwindSharingService.ShareAudio:input_type -> notebooklm.v1alpha1.ShareAudioRequest
-	3,  // 19: notebooklm.v1alpha1.LabsTailwindSharingService.GetProjectDetails:input_type -> notebooklm.v1alpha1.GetProjectDetailsRequest
-	6,  // 20: notebooklm.v1alpha1.LabsTailwindSharingService.ShareProject:input_type -> notebooklm.v1alpha1.ShareProjectRequest
-	10, // 21: notebooklm.v1alpha1.LabsTailwindGuidebooksService.DeleteGuidebook:input_type -> notebooklm.v1alpha1.DeleteGuidebookRequest
-	11, // 22: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebook:input_type -> notebooklm.v1alpha1.GetGuidebookRequest
-	12, // 23: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ListRecentlyViewedGuidebooks:input_type -> notebooklm.v1alpha1.ListRecentlyViewedGuidebooksRequest
-	14, // 24: notebooklm.v1alpha1.LabsTailwindGuidebooksService.PublishGuidebook:input_type -> notebooklm.v1alpha1.PublishGuidebookRequest
-	17, // 25: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebookDetails:input_type -> notebooklm.v1alpha1.GetGuidebookDetailsRequest
-	21, // 26: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ShareGuidebook:input_type -> notebooklm.v1alpha1.ShareGuidebookRequest
-	23, // 27: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GuidebookGenerateAnswer:input_type -> notebooklm.v1alpha1.GuidebookGenerateAnswerRequest
-	2,  // 28: notebooklm.v1alpha1.LabsTailwindSharingService.ShareAudio:output_type -> notebooklm.v1alpha1.ShareAudioResponse
-	4,  // 29: notebooklm.v1alpha1.LabsTailwindSharingService.GetProjectDetails:output_type -> notebooklm.v1alpha1.ProjectDetails
-	8,  // 30: notebooklm.v1alpha1.LabsTailwindSharingService.ShareProject:output_type -> notebooklm.v1alpha1.ShareProjectResponse
-	29, // 31: notebooklm.v1alpha1.LabsTailwindGuidebooksService.DeleteGuidebook:output_type -> google.protobuf.Empty
-	9,  // 32: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebook:output_type -> notebooklm.v1alpha1.Guidebook
-	13, // 33: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ListRecentlyViewedGuidebooks:output_type -> notebooklm.v1alpha1.ListRecentlyViewedGuidebooksResponse
-	16, // 34: notebooklm.v1alpha1.LabsTailwindGuidebooksService.PublishGuidebook:output_type -> notebooklm.v1alpha1.PublishGuidebookResponse
-	18, // 35: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebookDetails:output_type -> notebooklm.v1alpha1.GuidebookDetails
-	22, // 36: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ShareGuidebook:output_type -> notebooklm.v1alpha1.ShareGuidebookResponse
-	25, // 37: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GuidebookGenerateAnswer:output_type -> notebooklm.v1alpha1.GuidebookGenerateAnswerResponse
-	28, // [28:38] is the sub-list for method output_type
-	18, // [18:28] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	28, // 14: notebooklm.v1alpha1.GuidebookAnalytics.last_viewed:type_name -> google.protobuf.Timestamp
+	21, // 15: notebooklm.v1alpha1.GuidebookAnalytics.questions:type_name -> notebooklm.v1alpha1.GuidebookQuestionStat
+	28, // 16: notebooklm.v1alpha1.GuidebookQuestionStat.last_asked:type_name -> google.protobuf.Timestamp
+	7,  // 17: notebooklm.v1alpha1.ShareGuidebookRequest.settings:type_name -> notebooklm.v1alpha1.ShareSettings
+	25, // 18: notebooklm.v1alpha1.GuidebookGenerateAnswerRequest.settings:type_name -> notebooklm.v1alpha1.GenerateAnswerSettings
+	27, // 19: notebooklm.v1alpha1.GuidebookGenerateAnswerResponse.sources:type_name -> notebooklm.v1alpha1.SourceReference
+	1,  // 20: notebooklm.v1alpha1.LabsTailwindSharingService.ShareAudio:input_type -> notebooklm.v1alpha1.ShareAudioRequest
+	3,  // 21: notebooklm.v1alpha1.LabsTailwindSharingService.GetProjectDetails:input_type -> notebooklm.v1alpha1.GetProjectDetailsRequest
+	6,  // 22: notebooklm.v1alpha1.LabsTailwindSharingService.ShareProject:input_type -> notebooklm.v1alpha1.ShareProjectRequest
+	10, // 23: notebooklm.v1alpha1.LabsTailwindGuidebooksService.DeleteGuidebook:input_type -> notebooklm.v1alpha1.DeleteGuidebookRequest
+	11, // 24: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebook:input_type -> notebooklm.v1alpha1.GetGuidebookRequest
+	12, // 25: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ListRecentlyViewedGuidebooks:input_type -> notebooklm.v1alpha1.ListRecentlyViewedGuidebooksRequest
+	14, // 26: notebooklm.v1alpha1.LabsTailwindGuidebooksService.PublishGuidebook:input_type -> notebooklm.v1alpha1.PublishGuidebookRequest
+	17, // 27: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebookDetails:input_type -> notebooklm.v1alpha1.GetGuidebookDetailsRequest
+	22, // 28: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ShareGuidebook:input_type -> notebooklm.v1alpha1.ShareGuidebookRequest
+	24, // 29: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GuidebookGenerateAnswer:input_type -> notebooklm.v1alpha1.GuidebookGenerateAnswerRequest
+	2,  // 30: notebooklm.v1alpha1.LabsTailwindSharingService.ShareAudio:output_type -> notebooklm.v1alpha1.ShareAudioResponse
+	4,  // 31: notebooklm.v1alpha1.LabsTailwindSharingService.GetProjectDetails:output_type -> notebooklm.v1alpha1.ProjectDetails
+	8,  // 32: notebooklm.v1alpha1.LabsTailwindSharingService.ShareProject:output_type -> notebooklm.v1alpha1.ShareProjectResponse
+	30, // 33: notebooklm.v1alpha1.LabsTailwindGuidebooksService.DeleteGuidebook:output_type -> google.protobuf.Empty
+	9,  // 34: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebook:output_type -> notebooklm.v1alpha1.Guidebook
+	13, // 35: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ListRecentlyViewedGuidebooks:output_type -> notebooklm.v1alpha1.ListRecentlyViewedGuidebooksResponse
+	16, // 36: notebooklm.v1alpha1.LabsTailwindGuidebooksService.PublishGuidebook:output_type -> notebooklm.v1alpha1.PublishGuidebookResponse
+	18, // 37: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GetGuidebookDetails:output_type -> notebooklm.v1alpha1.GuidebookDetails
+	23, // 38: notebooklm.v1alpha1.LabsTailwindGuidebooksService.ShareGuidebook:output_type -> notebooklm.v1alpha1.ShareGuidebookResponse
+	26, // 39: notebooklm.v1alpha1.LabsTailwindGuidebooksService.GuidebookGenerateAnswer:output_type -> notebooklm.v1alpha1.GuidebookGenerateAnswerResponse
+	30, // [30:40] is the sub-list for method output_type
+	20, // [20:30] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_notebooklm_v1alpha1_sharing_proto_init() }
@@ -2336,7 +2435,7 @@ func file_notebooklm_v1alpha1_sharing_proto_init() {
 			}
 		}
 		file_notebooklm_v1alpha1_sharing_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShareGuidebookRequest); i {
+			switch v := v.(*GuidebookQuestionStat); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2348,7 +2447,7 @@ func file_notebooklm_v1alpha1_sharing_proto_init() {
 			}
 		}
 		file_notebooklm_v1alpha1_sharing_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShareGuidebookResponse); i {
+			switch v := v.(*ShareGuidebookRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2360,7 +2459,7 @@ func file_notebooklm_v1alpha1_sharing_proto_init() {
 			}
 		}
 		file_notebooklm_v1alpha1_sharing_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GuidebookGenerateAnswerRequest); i {
+			switch v := v.(*ShareGuidebookResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2372,7 +2471,7 @@ func file_notebooklm_v1alpha1_sharing_proto_init() {
 			}
 		}
 		file_notebooklm_v1alpha1_sharing_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateAnswerSettings); i {
+			switch v := v.(*GuidebookGenerateAnswerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2384,7 +2483,7 @@ func file_notebooklm_v1alpha1_sharing_proto_init() {
 			}
 		}
 		file_notebooklm_v1alpha1_sharing_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GuidebookGenerateAnswerResponse); i {
+			switch v := v.(*GenerateAnswerSettings); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2396,6 +2495,18 @@ func file_notebooklm_v1alpha1_sharing_proto_init() {
 			}
 		}
 		file_notebooklm_v1alpha1_sharing_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuidebookGenerateAnswerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notebooklm_v1alpha1_sharing_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SourceReference); i {
 			case 0:
 				return &v.state
@@ -2414,7 +2525,7 @@ func file_notebooklm_v1alpha1_sharing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_notebooklm_v1alpha1_sharing_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   26,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
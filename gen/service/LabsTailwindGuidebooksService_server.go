@@ -0,0 +1,65 @@
+// GENERATION_BEHAVIOR: overwrite
+// Code generated by protoc-gen-anything. DO NOT EDIT.
+// source: notebooklm/v1alpha1/sharing.proto
+
+package service
+
+import (
+	"context"
+
+	notebooklmv1alpha1 "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// LabsTailwindGuidebooksServiceServer implements the LabsTailwindGuidebooksService gRPC service by
+// forwarding each call to a LabsTailwindGuidebooksServiceClient.
+type LabsTailwindGuidebooksServiceServer struct {
+	notebooklmv1alpha1.UnimplementedLabsTailwindGuidebooksServiceServer
+	client *LabsTailwindGuidebooksServiceClient
+}
+
+// NewLabsTailwindGuidebooksServiceServer returns a server that forwards calls to client.
+func NewLabsTailwindGuidebooksServiceServer(client *LabsTailwindGuidebooksServiceClient) *LabsTailwindGuidebooksServiceServer {
+	return &LabsTailwindGuidebooksServiceServer{client: client}
+}
+
+// Register registers the server with s.
+func (srv *LabsTailwindGuidebooksServiceServer) Register(s grpc.ServiceRegistrar) {
+	notebooklmv1alpha1.RegisterLabsTailwindGuidebooksServiceServer(s, srv)
+}
+
+// DeleteGuidebook forwards the DeleteGuidebook RPC method.
+func (srv *LabsTailwindGuidebooksServiceServer) DeleteGuidebook(ctx context.Context, req *notebooklmv1alpha1.DeleteGuidebookRequest) (*emptypb.Empty, error) {
+	return srv.client.DeleteGuidebook(ctx, req)
+}
+
+// GetGuidebook forwards the GetGuidebook RPC method.
+func (srv *LabsTailwindGuidebooksServiceServer) GetGuidebook(ctx context.Context, req *notebooklmv1alpha1.GetGuidebookRequest) (*notebooklmv1alpha1.Guidebook, error) {
+	return srv.client.GetGuidebook(ctx, req)
+}
+
+// ListRecentlyViewedGuidebooks forwards the ListRecentlyViewedGuidebooks RPC method.
+func (srv *LabsTailwindGuidebooksServiceServer) ListRecentlyViewedGuidebooks(ctx context.Context, req *notebooklmv1alpha1.ListRecentlyViewedGuidebooksRequest) (*notebooklmv1alpha1.ListRecentlyViewedGuidebooksResponse, error) {
+	return srv.client.ListRecentlyViewedGuidebooks(ctx, req)
+}
+
+// PublishGuidebook forwards the PublishGuidebook RPC method.
+func (srv *LabsTailwindGuidebooksServiceServer) PublishGuidebook(ctx context.Context, req *notebooklmv1alpha1.PublishGuidebookRequest) (*notebooklmv1alpha1.PublishGuidebookResponse, error) {
+	return srv.client.PublishGuidebook(ctx, req)
+}
+
+// GetGuidebookDetails forwards the GetGuidebookDetails RPC method.
+func (srv *LabsTailwindGuidebooksServiceServer) GetGuidebookDetails(ctx context.Context, req *notebooklmv1alpha1.GetGuidebookDetailsRequest) (*notebooklmv1alpha1.GuidebookDetails, error) {
+	return srv.client.GetGuidebookDetails(ctx, req)
+}
+
+// ShareGuidebook forwards the ShareGuidebook RPC method.
+func (srv *LabsTailwindGuidebooksServiceServer) ShareGuidebook(ctx context.Context, req *notebooklmv1alpha1.ShareGuidebookRequest) (*notebooklmv1alpha1.ShareGuidebookResponse, error) {
+	return srv.client.ShareGuidebook(ctx, req)
+}
+
+// GuidebookGenerateAnswer forwards the GuidebookGenerateAnswer RPC method.
+func (srv *LabsTailwindGuidebooksServiceServer) GuidebookGenerateAnswer(ctx context.Context, req *notebooklmv1alpha1.GuidebookGenerateAnswerRequest) (*notebooklmv1alpha1.GuidebookGenerateAnswerResponse, error) {
+	return srv.client.GuidebookGenerateAnswer(ctx, req)
+}
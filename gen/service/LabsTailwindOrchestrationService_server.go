@@ -0,0 +1,240 @@
+// GENERATION_BEHAVIOR: overwrite
+// Code generated by protoc-gen-anything. DO NOT EDIT.
+// source: notebooklm/v1alpha1/orchestration.proto
+
+package service
+
+import (
+	"context"
+
+	notebooklmv1alpha1 "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// LabsTailwindOrchestrationServiceServer implements the LabsTailwindOrchestrationService gRPC service by
+// forwarding each call to a LabsTailwindOrchestrationServiceClient.
+type LabsTailwindOrchestrationServiceServer struct {
+	notebooklmv1alpha1.UnimplementedLabsTailwindOrchestrationServiceServer
+	client *LabsTailwindOrchestrationServiceClient
+}
+
+// NewLabsTailwindOrchestrationServiceServer returns a server that forwards calls to client.
+func NewLabsTailwindOrchestrationServiceServer(client *LabsTailwindOrchestrationServiceClient) *LabsTailwindOrchestrationServiceServer {
+	return &LabsTailwindOrchestrationServiceServer{client: client}
+}
+
+// Register registers the server with s.
+func (srv *LabsTailwindOrchestrationServiceServer) Register(s grpc.ServiceRegistrar) {
+	notebooklmv1alpha1.RegisterLabsTailwindOrchestrationServiceServer(s, srv)
+}
+
+// CreateArtifact forwards the CreateArtifact RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) CreateArtifact(ctx context.Context, req *notebooklmv1alpha1.CreateArtifactRequest) (*notebooklmv1alpha1.Artifact, error) {
+	return srv.client.CreateArtifact(ctx, req)
+}
+
+// GetArtifact forwards the GetArtifact RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GetArtifact(ctx context.Context, req *notebooklmv1alpha1.GetArtifactRequest) (*notebooklmv1alpha1.Artifact, error) {
+	return srv.client.GetArtifact(ctx, req)
+}
+
+// UpdateArtifact forwards the UpdateArtifact RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) UpdateArtifact(ctx context.Context, req *notebooklmv1alpha1.UpdateArtifactRequest) (*notebooklmv1alpha1.Artifact, error) {
+	return srv.client.UpdateArtifact(ctx, req)
+}
+
+// RenameArtifact forwards the RenameArtifact RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) RenameArtifact(ctx context.Context, req *notebooklmv1alpha1.RenameArtifactRequest) (*notebooklmv1alpha1.Artifact, error) {
+	return srv.client.RenameArtifact(ctx, req)
+}
+
+// DeleteArtifact forwards the DeleteArtifact RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) DeleteArtifact(ctx context.Context, req *notebooklmv1alpha1.DeleteArtifactRequest) (*emptypb.Empty, error) {
+	return srv.client.DeleteArtifact(ctx, req)
+}
+
+// ListArtifacts forwards the ListArtifacts RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) ListArtifacts(ctx context.Context, req *notebooklmv1alpha1.ListArtifactsRequest) (*notebooklmv1alpha1.ListArtifactsResponse, error) {
+	return srv.client.ListArtifacts(ctx, req)
+}
+
+// ActOnSources forwards the ActOnSources RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) ActOnSources(ctx context.Context, req *notebooklmv1alpha1.ActOnSourcesRequest) (*emptypb.Empty, error) {
+	return srv.client.ActOnSources(ctx, req)
+}
+
+// AddSources forwards the AddSources RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) AddSources(ctx context.Context, req *notebooklmv1alpha1.AddSourceRequest) (*notebooklmv1alpha1.Project, error) {
+	return srv.client.AddSources(ctx, req)
+}
+
+// CheckSourceFreshness forwards the CheckSourceFreshness RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) CheckSourceFreshness(ctx context.Context, req *notebooklmv1alpha1.CheckSourceFreshnessRequest) (*notebooklmv1alpha1.CheckSourceFreshnessResponse, error) {
+	return srv.client.CheckSourceFreshness(ctx, req)
+}
+
+// DeleteSources forwards the DeleteSources RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) DeleteSources(ctx context.Context, req *notebooklmv1alpha1.DeleteSourcesRequest) (*emptypb.Empty, error) {
+	return srv.client.DeleteSources(ctx, req)
+}
+
+// DiscoverSources forwards the DiscoverSources RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) DiscoverSources(ctx context.Context, req *notebooklmv1alpha1.DiscoverSourcesRequest) (*notebooklmv1alpha1.DiscoverSourcesResponse, error) {
+	return srv.client.DiscoverSources(ctx, req)
+}
+
+// LoadSource forwards the LoadSource RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) LoadSource(ctx context.Context, req *notebooklmv1alpha1.LoadSourceRequest) (*notebooklmv1alpha1.Source, error) {
+	return srv.client.LoadSource(ctx, req)
+}
+
+// MutateSource forwards the MutateSource RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) MutateSource(ctx context.Context, req *notebooklmv1alpha1.MutateSourceRequest) (*notebooklmv1alpha1.Source, error) {
+	return srv.client.MutateSource(ctx, req)
+}
+
+// RefreshSource forwards the RefreshSource RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) RefreshSource(ctx context.Context, req *notebooklmv1alpha1.RefreshSourceRequest) (*notebooklmv1alpha1.Source, error) {
+	return srv.client.RefreshSource(ctx, req)
+}
+
+// CreateAudioOverview forwards the CreateAudioOverview RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) CreateAudioOverview(ctx context.Context, req *notebooklmv1alpha1.CreateAudioOverviewRequest) (*notebooklmv1alpha1.AudioOverview, error) {
+	return srv.client.CreateAudioOverview(ctx, req)
+}
+
+// GetAudioOverview forwards the GetAudioOverview RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GetAudioOverview(ctx context.Context, req *notebooklmv1alpha1.GetAudioOverviewRequest) (*notebooklmv1alpha1.AudioOverview, error) {
+	return srv.client.GetAudioOverview(ctx, req)
+}
+
+// DeleteAudioOverview forwards the DeleteAudioOverview RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) DeleteAudioOverview(ctx context.Context, req *notebooklmv1alpha1.DeleteAudioOverviewRequest) (*emptypb.Empty, error) {
+	return srv.client.DeleteAudioOverview(ctx, req)
+}
+
+// CreateNote forwards the CreateNote RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) CreateNote(ctx context.Context, req *notebooklmv1alpha1.CreateNoteRequest) (*notebooklmv1alpha1.Source, error) {
+	return srv.client.CreateNote(ctx, req)
+}
+
+// DeleteNotes forwards the DeleteNotes RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) DeleteNotes(ctx context.Context, req *notebooklmv1alpha1.DeleteNotesRequest) (*emptypb.Empty, error) {
+	return srv.client.DeleteNotes(ctx, req)
+}
+
+// GetNotes forwards the GetNotes RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GetNotes(ctx context.Context, req *notebooklmv1alpha1.GetNotesRequest) (*notebooklmv1alpha1.GetNotesResponse, error) {
+	return srv.client.GetNotes(ctx, req)
+}
+
+// MutateNote forwards the MutateNote RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) MutateNote(ctx context.Context, req *notebooklmv1alpha1.MutateNoteRequest) (*notebooklmv1alpha1.Source, error) {
+	return srv.client.MutateNote(ctx, req)
+}
+
+// CreateProject forwards the CreateProject RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) CreateProject(ctx context.Context, req *notebooklmv1alpha1.CreateProjectRequest) (*notebooklmv1alpha1.Project, error) {
+	return srv.client.CreateProject(ctx, req)
+}
+
+// DeleteProjects forwards the DeleteProjects RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) DeleteProjects(ctx context.Context, req *notebooklmv1alpha1.DeleteProjectsRequest) (*emptypb.Empty, error) {
+	return srv.client.DeleteProjects(ctx, req)
+}
+
+// GetProject forwards the GetProject RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GetProject(ctx context.Context, req *notebooklmv1alpha1.GetProjectRequest) (*notebooklmv1alpha1.Project, error) {
+	return srv.client.GetProject(ctx, req)
+}
+
+// ListFeaturedProjects forwards the ListFeaturedProjects RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) ListFeaturedProjects(ctx context.Context, req *notebooklmv1alpha1.ListFeaturedProjectsRequest) (*notebooklmv1alpha1.ListFeaturedProjectsResponse, error) {
+	return srv.client.ListFeaturedProjects(ctx, req)
+}
+
+// ListRecentlyViewedProjects forwards the ListRecentlyViewedProjects RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) ListRecentlyViewedProjects(ctx context.Context, req *notebooklmv1alpha1.ListRecentlyViewedProjectsRequest) (*notebooklmv1alpha1.ListRecentlyViewedProjectsResponse, error) {
+	return srv.client.ListRecentlyViewedProjects(ctx, req)
+}
+
+// MutateProject forwards the MutateProject RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) MutateProject(ctx context.Context, req *notebooklmv1alpha1.MutateProjectRequest) (*notebooklmv1alpha1.Project, error) {
+	return srv.client.MutateProject(ctx, req)
+}
+
+// RemoveRecentlyViewedProject forwards the RemoveRecentlyViewedProject RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) RemoveRecentlyViewedProject(ctx context.Context, req *notebooklmv1alpha1.RemoveRecentlyViewedProjectRequest) (*emptypb.Empty, error) {
+	return srv.client.RemoveRecentlyViewedProject(ctx, req)
+}
+
+// GenerateDocumentGuides forwards the GenerateDocumentGuides RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GenerateDocumentGuides(ctx context.Context, req *notebooklmv1alpha1.GenerateDocumentGuidesRequest) (*notebooklmv1alpha1.GenerateDocumentGuidesResponse, error) {
+	return srv.client.GenerateDocumentGuides(ctx, req)
+}
+
+// GenerateFreeFormStreamed forwards the GenerateFreeFormStreamed RPC method, sending its response as a
+// single message on the stream.
+func (srv *LabsTailwindOrchestrationServiceServer) GenerateFreeFormStreamed(req *notebooklmv1alpha1.GenerateFreeFormStreamedRequest, stream notebooklmv1alpha1.LabsTailwindOrchestrationService_GenerateFreeFormStreamedServer) error {
+	resp, err := srv.client.GenerateFreeFormStreamed(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(resp)
+}
+
+// GenerateNotebookGuide forwards the GenerateNotebookGuide RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GenerateNotebookGuide(ctx context.Context, req *notebooklmv1alpha1.GenerateNotebookGuideRequest) (*notebooklmv1alpha1.GenerateNotebookGuideResponse, error) {
+	return srv.client.GenerateNotebookGuide(ctx, req)
+}
+
+// GenerateOutline forwards the GenerateOutline RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GenerateOutline(ctx context.Context, req *notebooklmv1alpha1.GenerateOutlineRequest) (*notebooklmv1alpha1.GenerateOutlineResponse, error) {
+	return srv.client.GenerateOutline(ctx, req)
+}
+
+// GenerateReportSuggestions forwards the GenerateReportSuggestions RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GenerateReportSuggestions(ctx context.Context, req *notebooklmv1alpha1.GenerateReportSuggestionsRequest) (*notebooklmv1alpha1.GenerateReportSuggestionsResponse, error) {
+	return srv.client.GenerateReportSuggestions(ctx, req)
+}
+
+// GenerateSection forwards the GenerateSection RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GenerateSection(ctx context.Context, req *notebooklmv1alpha1.GenerateSectionRequest) (*notebooklmv1alpha1.GenerateSectionResponse, error) {
+	return srv.client.GenerateSection(ctx, req)
+}
+
+// StartDraft forwards the StartDraft RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) StartDraft(ctx context.Context, req *notebooklmv1alpha1.StartDraftRequest) (*notebooklmv1alpha1.StartDraftResponse, error) {
+	return srv.client.StartDraft(ctx, req)
+}
+
+// StartSection forwards the StartSection RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) StartSection(ctx context.Context, req *notebooklmv1alpha1.StartSectionRequest) (*notebooklmv1alpha1.StartSectionResponse, error) {
+	return srv.client.StartSection(ctx, req)
+}
+
+// GenerateMagicView forwards the GenerateMagicView RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GenerateMagicView(ctx context.Context, req *notebooklmv1alpha1.GenerateMagicViewRequest) (*notebooklmv1alpha1.GenerateMagicViewResponse, error) {
+	return srv.client.GenerateMagicView(ctx, req)
+}
+
+// GetProjectAnalytics forwards the GetProjectAnalytics RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GetProjectAnalytics(ctx context.Context, req *notebooklmv1alpha1.GetProjectAnalyticsRequest) (*notebooklmv1alpha1.ProjectAnalytics, error) {
+	return srv.client.GetProjectAnalytics(ctx, req)
+}
+
+// SubmitFeedback forwards the SubmitFeedback RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) SubmitFeedback(ctx context.Context, req *notebooklmv1alpha1.SubmitFeedbackRequest) (*emptypb.Empty, error) {
+	return srv.client.SubmitFeedback(ctx, req)
+}
+
+// GetOrCreateAccount forwards the GetOrCreateAccount RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) GetOrCreateAccount(ctx context.Context, req *notebooklmv1alpha1.GetOrCreateAccountRequest) (*notebooklmv1alpha1.Account, error) {
+	return srv.client.GetOrCreateAccount(ctx, req)
+}
+
+// MutateAccount forwards the MutateAccount RPC method.
+func (srv *LabsTailwindOrchestrationServiceServer) MutateAccount(ctx context.Context, req *notebooklmv1alpha1.MutateAccountRequest) (*notebooklmv1alpha1.Account, error) {
+	return srv.client.MutateAccount(ctx, req)
+}
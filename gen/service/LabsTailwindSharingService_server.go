@@ -0,0 +1,44 @@
+// GENERATION_BEHAVIOR: overwrite
+// Code generated by protoc-gen-anything. DO NOT EDIT.
+// source: notebooklm/v1alpha1/sharing.proto
+
+package service
+
+import (
+	"context"
+
+	notebooklmv1alpha1 "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+// LabsTailwindSharingServiceServer implements the LabsTailwindSharingService gRPC service by
+// forwarding each call to a LabsTailwindSharingServiceClient.
+type LabsTailwindSharingServiceServer struct {
+	notebooklmv1alpha1.UnimplementedLabsTailwindSharingServiceServer
+	client *LabsTailwindSharingServiceClient
+}
+
+// NewLabsTailwindSharingServiceServer returns a server that forwards calls to client.
+func NewLabsTailwindSharingServiceServer(client *LabsTailwindSharingServiceClient) *LabsTailwindSharingServiceServer {
+	return &LabsTailwindSharingServiceServer{client: client}
+}
+
+// Register registers the server with s.
+func (srv *LabsTailwindSharingServiceServer) Register(s grpc.ServiceRegistrar) {
+	notebooklmv1alpha1.RegisterLabsTailwindSharingServiceServer(s, srv)
+}
+
+// ShareAudio forwards the ShareAudio RPC method.
+func (srv *LabsTailwindSharingServiceServer) ShareAudio(ctx context.Context, req *notebooklmv1alpha1.ShareAudioRequest) (*notebooklmv1alpha1.ShareAudioResponse, error) {
+	return srv.client.ShareAudio(ctx, req)
+}
+
+// GetProjectDetails forwards the GetProjectDetails RPC method.
+func (srv *LabsTailwindSharingServiceServer) GetProjectDetails(ctx context.Context, req *notebooklmv1alpha1.GetProjectDetailsRequest) (*notebooklmv1alpha1.ProjectDetails, error) {
+	return srv.client.GetProjectDetails(ctx, req)
+}
+
+// ShareProject forwards the ShareProject RPC method.
+func (srv *LabsTailwindSharingServiceServer) ShareProject(ctx context.Context, req *notebooklmv1alpha1.ShareProjectRequest) (*notebooklmv1alpha1.ShareProjectResponse, error) {
+	return srv.client.ShareProject(ctx, req)
+}
@@ -0,0 +1,289 @@
+// Package anki writes Anki deck packages (.apkg files): zip archives that
+// hold a collection in Anki's SQLite format, which Anki imports with
+// File > Import without any field mapping.
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// Deck is a named deck of basic front and back cards.
+type Deck struct {
+	Name  string
+	Tags  []string // Anki tags cannot contain spaces
+	Cards []Card
+}
+
+// Card is a note with a front and a back field, both HTML.
+type Card struct {
+	Front, Back string
+}
+
+// modelID identifies the note type nlm's cards use. It is fixed so that
+// importing a second deck reuses the note type instead of adding another.
+const modelID = 1718114412001
+
+// schema is the collection schema of Anki 2.1's legacy .anki2 format,
+// version 11, which every Anki release since 2.0 imports.
+const schema = `
+CREATE TABLE col (
+	id     integer PRIMARY KEY,
+	crt    integer NOT NULL,
+	mod    integer NOT NULL,
+	scm    integer NOT NULL,
+	ver    integer NOT NULL,
+	dty    integer NOT NULL,
+	usn    integer NOT NULL,
+	ls     integer NOT NULL,
+	conf   text NOT NULL,
+	models text NOT NULL,
+	decks  text NOT NULL,
+	dconf  text NOT NULL,
+	tags   text NOT NULL
+);
+CREATE TABLE notes (
+	id    integer PRIMARY KEY,
+	guid  text NOT NULL,
+	mid   integer NOT NULL,
+	mod   integer NOT NULL,
+	usn   integer NOT NULL,
+	tags  text NOT NULL,
+	flds  text NOT NULL,
+	sfld  integer NOT NULL,
+	csum  integer NOT NULL,
+	flags integer NOT NULL,
+	data  text NOT NULL
+);
+CREATE TABLE cards (
+	id     integer PRIMARY KEY,
+	nid    integer NOT NULL,
+	did    integer NOT NULL,
+	ord    integer NOT NULL,
+	mod    integer NOT NULL,
+	usn    integer NOT NULL,
+	type   integer NOT NULL,
+	queue  integer NOT NULL,
+	due    integer NOT NULL,
+	ivl    integer NOT NULL,
+	factor integer NOT NULL,
+	reps   integer NOT NULL,
+	lapses integer NOT NULL,
+	left   integer NOT NULL,
+	odue   integer NOT NULL,
+	odid   integer NOT NULL,
+	flags  integer NOT NULL,
+	data   text NOT NULL
+);
+CREATE TABLE revlog (
+	id      integer PRIMARY KEY,
+	cid     integer NOT NULL,
+	usn     integer NOT NULL,
+	ease    integer NOT NULL,
+	ivl     integer NOT NULL,
+	lastIvl integer NOT NULL,
+	factor  integer NOT NULL,
+	time    integer NOT NULL,
+	type    integer NOT NULL
+);
+CREATE TABLE graves (
+	usn  integer NOT NULL,
+	oid  integer NOT NULL,
+	type integer NOT NULL
+);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+// WriteAPKG writes d to w as a .apkg file. Notes get IDs derived from the
+// deck name and their front, so importing an updated deck again updates
+// the notes instead of duplicating them.
+func WriteAPKG(w io.Writer, d Deck) error {
+	dir, err := os.MkdirTemp("", "nlm-apkg-*")
+	if err != nil {
+		return fmt.Errorf("write apkg: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "collection.anki2")
+	if err := writeCollection(path, d, time.Now()); err != nil {
+		return fmt.Errorf("write apkg: %w", err)
+	}
+	collection, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("write apkg: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{
+		{"collection.anki2", collection},
+		{"media", []byte("{}")}, // no media files
+	} {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("write apkg: %w", err)
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return fmt.Errorf("write apkg: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("write apkg: %w", err)
+	}
+	return nil
+}
+
+// writeCollection creates the collection database for d at path.
+func writeCollection(path string, d Deck, now time.Time) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(schema); err != nil {
+		return fmt.Errorf("create collection: %w", err)
+	}
+
+	ms, sec := now.UnixMilli(), now.Unix()
+	deckID := hashID(d.Name)
+	models, decks, dconf, conf, err := collectionJSON(d.Name, deckID, sec)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		sec, ms, ms, conf, models, decks, dconf); err != nil {
+		return fmt.Errorf("write collection: %w", err)
+	}
+
+	tags := ""
+	if len(d.Tags) > 0 {
+		tags = " " + strings.Join(d.Tags, " ") + " "
+	}
+	for i, c := range d.Cards {
+		id := ms + int64(i)
+		sort := stripHTML(c.Front)
+		if _, err := tx.Exec(`INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			id, guid(d.Name, c.Front), modelID, sec, tags, c.Front+"\x1f"+c.Back, sort, checksum(sort)); err != nil {
+			return fmt.Errorf("write note: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+			id, id, deckID, sec, i+1); err != nil {
+			return fmt.Errorf("write card: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// collectionJSON returns the JSON columns of the col row: the note type,
+// the decks, their options and the collection's settings.
+func collectionJSON(name string, deckID, mod int64) (models, decks, dconf, conf string, err error) {
+	field := func(name string, ord int) map[string]interface{} {
+		return map[string]interface{}{"name": name, "ord": ord, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []string{}}
+	}
+	model := map[string]interface{}{
+		"id": modelID, "name": "nlm Basic", "type": 0, "mod": mod, "usn": -1, "sortf": 0, "did": deckID,
+		"flds": []interface{}{field("Front", 0), field("Back", 1)},
+		"tmpls": []interface{}{map[string]interface{}{
+			"name": "Card 1", "ord": 0, "did": nil, "bqfmt": "", "bafmt": "",
+			"qfmt": "{{Front}}",
+			"afmt": "{{FrontSide}}\n\n<hr id=answer>\n\n{{Back}}",
+		}},
+		"css":       ".card {\n font-family: arial;\n font-size: 20px;\n text-align: center;\n color: black;\n background-color: white;\n}\n",
+		"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+		"tags":      []string{},
+		"vers":      []int{},
+		"req":       []interface{}{[]interface{}{0, "all", []int{0}}},
+	}
+	deck := func(id int64, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "mod": mod, "usn": -1, "desc": "", "dyn": 0, "conf": 1, "collapsed": false,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+			"extendNew": 10, "extendRev": 50,
+		}
+	}
+	options := map[string]interface{}{
+		"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+		"new":   map[string]interface{}{"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": 2500, "order": 1, "perDay": 20, "bury": true, "separate": true},
+		"rev":   map[string]interface{}{"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "maxIvl": 36500, "minSpace": 1, "ivlFct": 1, "bury": true},
+		"lapse": map[string]interface{}{"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0},
+	}
+	settings := map[string]interface{}{
+		"activeDecks": []int64{deckID}, "curDeck": deckID, "curModel": strconv.Itoa(modelID), "nextPos": 1,
+		"newSpread": 0, "collapseTime": 1200, "timeLim": 0, "estTimes": true, "dueCounts": true,
+		"sortType": "noteFld", "sortBackwards": false, "addToCur": true,
+	}
+	for _, v := range []struct {
+		dst *string
+		v   interface{}
+	}{
+		{&models, map[string]interface{}{strconv.Itoa(modelID): model}},
+		{&decks, map[string]interface{}{"1": deck(1, "Default"), strconv.FormatInt(deckID, 10): deck(deckID, name)}},
+		{&dconf, map[string]interface{}{"1": options}},
+		{&conf, settings},
+	} {
+		data, err := json.Marshal(v.v)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		*v.dst = string(data)
+	}
+	return models, decks, dconf, conf, nil
+}
+
+// hashID returns a positive ID for name that stays the same across
+// exports, as Anki's own IDs are: a millisecond time in the past.
+func hashID(name string) int64 {
+	sum := sha256.Sum256([]byte(name))
+	return int64(binary.BigEndian.Uint64(sum[:8])%1e12) + 1e12
+}
+
+// guid returns the note's globally unique ID, which Anki matches notes
+// by when a deck is imported again.
+func guid(deck, front string) string {
+	sum := sha256.Sum256([]byte(deck + "\x1f" + front))
+	return base64.RawStdEncoding.EncodeToString(sum[:8])
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// stripHTML returns the text of an HTML field, as Anki sorts and checks
+// for duplicates by.
+func stripHTML(s string) string {
+	return strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(s, "")))
+}
+
+// checksum is Anki's duplicate check value: the first 32 bits of the
+// SHA-1 of the sort field.
+func checksum(s string) int64 {
+	sum := sha1.Sum([]byte(s))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
@@ -0,0 +1,109 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteAPKG(t *testing.T) {
+	deck := Deck{
+		Name: "Cell Biology",
+		Tags: []string{"nlm", "Cell_Biology"},
+		Cards: []Card{
+			{Front: "What is ATP?", Back: "The cell&#39;s energy<br>currency"},
+			{Front: "<b>Mitochondria</b>", Back: "Powerhouse"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteAPKG(&buf, deck); err != nil {
+		t.Fatalf("WriteAPKG() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name], _ = io.ReadAll(r)
+		r.Close()
+	}
+	if string(files["media"]) != "{}" {
+		t.Errorf("media = %q, want {}", files["media"])
+	}
+	path := filepath.Join(t.TempDir(), "collection.anki2")
+	if err := os.WriteFile(path, files["collection.anki2"], 0600); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type note struct {
+		Flds, Sfld, Tags string
+		Mid              int64
+	}
+	rows, err := db.Query(`SELECT flds, sfld, tags, mid FROM notes ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var notes []note
+	for rows.Next() {
+		var n note
+		if err := rows.Scan(&n.Flds, &n.Sfld, &n.Tags, &n.Mid); err != nil {
+			t.Fatal(err)
+		}
+		notes = append(notes, n)
+	}
+	want := []note{
+		{"What is ATP?\x1fThe cell&#39;s energy<br>currency", "What is ATP?", " nlm Cell_Biology ", modelID},
+		{"<b>Mitochondria</b>\x1fPowerhouse", "Mitochondria", " nlm Cell_Biology ", modelID},
+	}
+	if diff := cmp.Diff(want, notes); diff != "" {
+		t.Errorf("notes (-want +got):\n%s", diff)
+	}
+
+	var cards int
+	if err := db.QueryRow(`SELECT count(*) FROM cards WHERE did = ?`, hashID(deck.Name)).Scan(&cards); err != nil || cards != 2 {
+		t.Errorf("cards in deck = %d, %v; want 2", cards, err)
+	}
+	var decksJSON, modelsJSON string
+	if err := db.QueryRow(`SELECT decks, models FROM col`).Scan(&decksJSON, &modelsJSON); err != nil {
+		t.Fatal(err)
+	}
+	var decks map[string]struct{ Name string }
+	var models map[string]struct{ Flds []struct{ Name string } }
+	if err := json.Unmarshal([]byte(decksJSON), &decks); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(modelsJSON), &models); err != nil {
+		t.Fatal(err)
+	}
+	if got := decks[strconv.FormatInt(hashID(deck.Name), 10)].Name; got != "Cell Biology" {
+		t.Errorf("deck name = %q, want %q", got, "Cell Biology")
+	}
+	if got := len(models[strconv.Itoa(modelID)].Flds); got != 2 {
+		t.Errorf("note type has %d fields, want 2", got)
+	}
+}
+
+func TestGUIDStable(t *testing.T) {
+	if guid("d", "front") != guid("d", "front") || guid("d", "front") == guid("d", "other") {
+		t.Error("guid() is not a stable function of the deck and front")
+	}
+}
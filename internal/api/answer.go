@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf16"
+
+	"github.com/tmc/nlm/internal/rpc/grpcendpoint"
+)
+
+// chatEndpoint is the streaming endpoint the web app asks questions with.
+const chatEndpoint = "/google.internal.labs.tailwind.orchestration.v1.LabsTailwindOrchestrationService/GenerateFreeFormStreamed"
+
+// Answer is a reply to a question about a notebook's sources.
+type Answer struct {
+	Text      string
+	Citations []Citation
+	// Final is set once the stream has ended, when Text and Citations
+	// are complete.
+	Final bool
+}
+
+// Citation links a span of an answer's text to the sources it came from.
+type Citation struct {
+	Index      int // 1-based, as the answer's text refers to it
+	SourceIDs  []string
+	Start, End int // byte offsets into Text; both 0 if not given
+}
+
+// errStopAnswer stops a stream when the caller's function asks to.
+var errStopAnswer = errors.New("stopped")
+
+// Ask asks a question of the notebook's sources, or of those in sourceIDs
+// if it is not empty, and streams the answer. fn, if not nil, is called
+// with the answer so far each time a frame changes it, and once more with
+// the final answer; it may stop the stream by returning false. Citations
+// arrive in later frames than the text they cite, so an answer whose text
+// is complete may still gain citations. Ask returns the last answer,
+// which is Final unless fn stopped the stream. opts override the
+// client's settings for this call.
+func (c *Client) Ask(projectID, prompt string, sourceIDs []string, fn func(*Answer) bool, opts ...CallOption) (_ *Answer, err error) {
+	defer wrapError(&err, "Ask", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if err := validateIDs("source", sourceIDs...); err != nil {
+		return nil, err
+	}
+	if err := validatePrompt(prompt); err != nil {
+		return nil, err
+	}
+	c, o, done := c.withCall(opts)
+	defer done()
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(sourceIDs) == 0 {
+		sourceIDs = o.sourceIDs
+	}
+	if len(sourceIDs) == 0 {
+		project, err := c.GetProjectWithContext(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("get sources: %w", err)
+		}
+		for _, src := range project.GetSources() {
+			if id := src.GetSourceId().GetSourceId(); id != "" {
+				sourceIDs = append(sourceIDs, id)
+			}
+		}
+	}
+
+	gopts := []grpcendpoint.Option{grpcendpoint.WithContext(ctx), grpcendpoint.WithRequestHook(c.rpc.WriteCurl)}
+	if c.httpClient != nil {
+		gopts = append(gopts, grpcendpoint.WithHTTPClient(c.httpClient))
+	}
+	gc := grpcendpoint.NewClient(c.authToken, c.cookies, gopts...)
+	var s answerStream
+	err = gc.Stream(grpcendpoint.Request{
+		Endpoint: chatEndpoint,
+		Body:     grpcendpoint.BuildChatRequest(sourceIDs, prompt),
+	}, func(frame []byte) error {
+		if s.add(frame) && fn != nil && !fn(s.snapshot()) {
+			return errStopAnswer
+		}
+		return nil
+	})
+	if errors.Is(err, errStopAnswer) {
+		return s.snapshot(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.answer.Text == "" {
+		return nil, fmt.Errorf("no answer in response")
+	}
+	s.answer.Final = true
+	a := s.snapshot()
+	if fn != nil {
+		fn(a)
+	}
+	return a, nil
+}
+
+// answerStream assembles an answer from the frames of a streamed reply.
+// Each frame holds either the text so far or the next piece of it, and
+// the citations known so far, which are merged with earlier ones by
+// index. The server gives citation spans as JavaScript string indices,
+// in UTF-16 code units, and the stream keeps them so until snapshot
+// converts them to byte offsets.
+type answerStream struct {
+	answer Answer
+}
+
+// add merges the answer in frame and reports whether it changed. Frames
+// that hold no answer, such as the stream's trailing status frames, are
+// ignored.
+func (s *answerStream) add(frame []byte) bool {
+	var rows []interface{}
+	if json.Unmarshal(frame, &rows) != nil {
+		return false
+	}
+	if _, ok := field(rows, 0).(string); ok {
+		rows = []interface{}{rows}
+	}
+	changed := false
+	for _, row := range rows {
+		row, _ := row.([]interface{})
+		if tag, _ := field(row, 0).(string); tag != "wrb.fr" {
+			continue
+		}
+		payload, _ := field(row, 2).(string)
+		var inner []interface{}
+		if json.Unmarshal([]byte(payload), &inner) != nil {
+			continue
+		}
+		first, _ := field(inner, 0).([]interface{})
+		if text, ok := field(first, 0).(string); ok && s.addText(text) {
+			changed = true
+		}
+		meta, _ := field(first, 4).([]interface{})
+		cites, _ := field(meta, 3).([]interface{})
+		for i, entry := range cites {
+			if s.addCitation(parseCitation(i+1, entry)) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// addText merges text into the answer. Text that extends the answer
+// replaces it, text the answer already begins with is stale, and
+// anything else is the next piece of it.
+func (s *answerStream) addText(text string) bool {
+	switch old := s.answer.Text; {
+	case text == "" || len(text) <= len(old) && old[:len(text)] == text:
+		return false
+	case len(text) > len(old) && text[:len(old)] == old:
+		s.answer.Text = text
+	default:
+		s.answer.Text += text
+	}
+	return true
+}
+
+// addCitation merges cite with the citation of the same index, adding
+// its sources and taking its span if it has one.
+func (s *answerStream) addCitation(cite *Citation) bool {
+	if cite == nil {
+		return false
+	}
+	i := slices.IndexFunc(s.answer.Citations, func(c Citation) bool { return c.Index == cite.Index })
+	if i < 0 {
+		s.answer.Citations = append(s.answer.Citations, *cite)
+		return true
+	}
+	old := &s.answer.Citations[i]
+	changed := false
+	for _, id := range cite.SourceIDs {
+		if !slices.Contains(old.SourceIDs, id) {
+			old.SourceIDs = append(old.SourceIDs, id)
+			changed = true
+		}
+	}
+	if cite.End > 0 && (cite.Start != old.Start || cite.End != old.End) {
+		old.Start, old.End = cite.Start, cite.End
+		changed = true
+	}
+	return changed
+}
+
+// snapshot returns a copy of the answer that later frames do not change,
+// with its citation spans as byte offsets into its text.
+func (s *answerStream) snapshot() *Answer {
+	a := s.answer
+	a.Citations = slices.Clone(a.Citations)
+	for i := range a.Citations {
+		c := &a.Citations[i]
+		c.SourceIDs = slices.Clone(c.SourceIDs)
+		c.Start, c.End = byteOffset(a.Text, c.Start), byteOffset(a.Text, c.End)
+	}
+	return &a
+}
+
+// byteOffset returns the byte offset in text of UTF-16 code unit n, or
+// len(text) if text is shorter. An offset inside a surrogate pair is
+// moved to the end of its character.
+func byteOffset(text string, n int) int {
+	for i, r := range text {
+		if n <= 0 {
+			return i
+		}
+		n -= utf16.RuneLen(r)
+	}
+	return len(text)
+}
+
+// parseCitation reads a citation entry, whose layout varies, by taking
+// the source IDs anywhere in it and the first pair of offsets. It returns
+// nil for an entry that names no source.
+func parseCitation(index int, entry interface{}) *Citation {
+	cite := &Citation{Index: index}
+	walkStrings(entry, func(s string) {
+		if uuidPattern.MatchString(s) && !slices.Contains(cite.SourceIDs, s) {
+			cite.SourceIDs = append(cite.SourceIDs, s)
+		}
+	})
+	if len(cite.SourceIDs) == 0 {
+		return nil
+	}
+	cite.Start, cite.End, _ = findSpan(entry)
+	return cite
+}
+
+// findSpan returns the first array in v that is a pair of offsets.
+func findSpan(v interface{}) (start, end int, ok bool) {
+	arr, isArr := v.([]interface{})
+	if !isArr {
+		return 0, 0, false
+	}
+	if len(arr) == 2 {
+		a, aok := arr[0].(float64)
+		b, bok := arr[1].(float64)
+		if aok && bok && 0 <= a && a < b {
+			return int(a), int(b), true
+		}
+	}
+	for _, item := range arr {
+		if start, end, ok := findSpan(item); ok {
+			return start, end, true
+		}
+	}
+	return 0, 0, false
+}
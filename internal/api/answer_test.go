@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	srcA = "11111111-1111-1111-1111-111111111111"
+	srcB = "22222222-2222-2222-2222-222222222222"
+)
+
+// answerFrame returns a stream frame holding text and citation entries.
+func answerFrame(text string, cites ...interface{}) string {
+	var meta interface{}
+	if len(cites) > 0 {
+		meta = []interface{}{nil, nil, nil, cites}
+	}
+	inner, _ := json.Marshal([]interface{}{[]interface{}{text, nil, []interface{}{"conv"}, nil, meta}})
+	frame, _ := json.Marshal([]interface{}{[]interface{}{"wrb.fr", nil, string(inner)}})
+	return string(frame)
+}
+
+// cite returns a citation entry for a span and sources, nested as the
+// server nests them.
+func cite(start, end int, ids ...string) interface{} {
+	var srcs []interface{}
+	for _, id := range ids {
+		srcs = append(srcs, []interface{}{[]interface{}{id}})
+	}
+	return []interface{}{nil, []interface{}{nil, nil, 0.9, nil, []interface{}{[]interface{}{start, end}}, srcs}}
+}
+
+func TestAnswerStream(t *testing.T) {
+	frames := []string{
+		answerFrame("The sky"),
+		answerFrame("The sky is blue [1]."),
+		answerFrame("The sky"), // stale
+		answerFrame("The sky is blue [1].", cite(0, 15, srcA)),
+		answerFrame("The sky is blue [1].", cite(0, 15, srcA, srcB), cite(4, 7, srcB)),
+		answerFrame("The sky is blue [1].", []interface{}{"no source here"}),
+		`[["di",42],["af.httprm",42,"-1",7]]`,
+	}
+	var s answerStream
+	var changed []bool
+	var texts []string
+	for _, f := range frames {
+		changed = append(changed, s.add([]byte(f)))
+		texts = append(texts, s.answer.Text)
+	}
+	if diff := cmp.Diff([]bool{true, true, false, true, true, false, false}, changed); diff != "" {
+		t.Errorf("add() changed (-want +got):\n%s", diff)
+	}
+	if texts[2] != "The sky is blue [1]." {
+		t.Errorf("text after stale frame = %q", texts[2])
+	}
+	want := &Answer{
+		Text: "The sky is blue [1].",
+		Citations: []Citation{
+			{Index: 1, SourceIDs: []string{srcA, srcB}, Start: 0, End: 15},
+			{Index: 2, SourceIDs: []string{srcB}, Start: 4, End: 7},
+		},
+	}
+	if diff := cmp.Diff(want, s.snapshot()); diff != "" {
+		t.Errorf("answer (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnswerStreamDeltas(t *testing.T) {
+	var s answerStream
+	for _, text := range []string{"Hello", ", world", "Hello, world"} {
+		s.add([]byte(answerFrame(text)))
+	}
+	if s.answer.Text != "Hello, world" {
+		t.Errorf("text = %q, want %q", s.answer.Text, "Hello, world")
+	}
+}
+
+func TestAnswerStreamMultiByte(t *testing.T) {
+	// The server counts UTF-16 code units: "café" ends at unit 7, byte
+	// 8, and the emoji takes two units, so "日本語" starts at unit 25.
+	const text = "Le café est noir [1]. 😀 日本語 [2]."
+	var s answerStream
+	s.add([]byte(answerFrame(text, cite(3, 7, srcA), cite(25, 28, srcB), cite(25, 99, srcB))))
+	a := s.snapshot()
+	var spans []string
+	for _, c := range a.Citations {
+		span := a.Text[c.Start:c.End]
+		if !utf8.ValidString(span) {
+			t.Errorf("citation %d span %q is not valid UTF-8", c.Index, span)
+		}
+		spans = append(spans, span)
+	}
+	if diff := cmp.Diff([]string{"café", "日本語", "日本語 [2]."}, spans); diff != "" {
+		t.Errorf("citation spans (-want +got):\n%s", diff)
+	}
+}
+
+// streamBody returns a chunked response body of frames.
+func streamBody(frames ...string) string {
+	var b strings.Builder
+	b.WriteString(")]}'\n")
+	for _, f := range frames {
+		fmt.Fprintf(&b, "%d\n%s\n", len(f)+2, f)
+	}
+	return b.String()
+}
+
+func TestAsk(t *testing.T) {
+	var form url.Values
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ = url.ParseQuery(string(body))
+		resp := streamBody(
+			answerFrame("Blue"),
+			answerFrame("Blue [1]."),
+			answerFrame("Blue [1].", cite(0, 4, srcA)),
+		)
+		return &http.Response{StatusCode: 200, Status: "200 OK", Body: io.NopCloser(strings.NewReader(resp)), Header: http.Header{}, Request: req}, nil
+	})
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var seen []*Answer
+	got, err := c.Ask("nb", "what colour?", []string{srcA}, func(a *Answer) bool {
+		seen = append(seen, a)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(form.Get("f.req"), "what colour?") || form.Get("at") != "tok" {
+		t.Errorf("request form = %v", form)
+	}
+	want := &Answer{
+		Text:      "Blue [1].",
+		Citations: []Citation{{Index: 1, SourceIDs: []string{srcA}, End: 4}},
+		Final:     true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Ask() (-want +got):\n%s", diff)
+	}
+	if len(seen) != 4 {
+		t.Fatalf("fn called %d times, want 4", len(seen))
+	}
+	if len(seen[1].Citations) != 0 || seen[1].Final {
+		t.Errorf("answer before the citation frame = %+v", seen[1])
+	}
+	if diff := cmp.Diff(want, seen[3]); diff != "" {
+		t.Errorf("last answer seen (-want +got):\n%s", diff)
+	}
+
+	// Stopping early returns the answer so far.
+	got, err = c.Ask("nb", "what colour?", []string{srcA}, func(a *Answer) bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != "Blue" || got.Final {
+		t.Errorf("stopped Ask() = %+v", got)
+	}
+}
@@ -0,0 +1,771 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/beprotojson"
+	"github.com/tmc/nlm/internal/richtext"
+	"github.com/tmc/nlm/internal/rpc"
+)
+
+// Artifact operations
+
+// Artifact is a generated notebook artifact such as a report or audio
+// overview, as listed in the notebook's Studio panel.
+type Artifact struct {
+	ID        string
+	Type      pb.ArtifactType
+	Title     string
+	State     pb.ArtifactState
+	UpdatedAt time.Time
+	SourceIDs []string
+}
+
+// TypeName returns the short lowercase name of the artifact's type, such as
+// "report" or "audio_overview".
+func (a *Artifact) TypeName() string {
+	return enumName(a.Type.String(), "ARTIFACT_TYPE_")
+}
+
+// StateName returns the short lowercase name of the artifact's state, such
+// as "creating" or "ready".
+func (a *Artifact) StateName() string {
+	return enumName(a.State.String(), "ARTIFACT_STATE_")
+}
+
+func enumName(s, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(s, prefix))
+}
+
+// MarshalJSON encodes the artifact with readable type and state names.
+func (a *Artifact) MarshalJSON() ([]byte, error) {
+	out := struct {
+		ID        string     `json:"id"`
+		Type      string     `json:"type"`
+		Title     string     `json:"title,omitempty"`
+		State     string     `json:"state"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+		SourceIDs []string   `json:"source_ids,omitempty"`
+	}{
+		ID:        a.ID,
+		Type:      a.TypeName(),
+		Title:     a.Title,
+		State:     a.StateName(),
+		SourceIDs: a.SourceIDs,
+	}
+	if !a.UpdatedAt.IsZero() {
+		out.UpdatedAt = &a.UpdatedAt
+	}
+	return json.Marshal(out)
+}
+
+// ListArtifacts returns artifacts for a project using direct RPC
+func (c *Client) ListArtifacts(projectID string) (_ []*Artifact, err error) {
+	defer wrapError(&err, "ListArtifacts", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	resp, err := c.rpc.Do(rpc.Call{
+		ID: rpc.RPCListArtifacts,
+		Args: []interface{}{
+			[]interface{}{2}, // filter parameter - 2 seems to be for all artifacts
+			projectID,
+		},
+		NotebookID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts RPC: %w", err)
+	}
+
+	// Parse response
+	var responseData []interface{}
+	if err := json.Unmarshal(resp, &responseData); err != nil {
+		return nil, fmt.Errorf("parse artifacts response: %w", err)
+	}
+
+	if c.config.Debug {
+		fmt.Printf("Artifacts response: %+v\n", responseData)
+	}
+
+	return parseArtifactList(responseData), nil
+}
+
+// parseArtifactList converts a list response into artifacts. The response
+// is either [[artifact1, artifact2, ...]] or [artifact1, artifact2, ...].
+func parseArtifactList(data []interface{}) []*Artifact {
+	items := data
+	if len(data) > 0 {
+		if inner, ok := data[0].([]interface{}); ok && !isArtifactEntry(inner) {
+			items = inner
+		}
+	}
+
+	var artifacts []*Artifact
+	for _, item := range items {
+		if artifact := parseArtifact(item); artifact != nil {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts
+}
+
+// isArtifactEntry reports whether v looks like a single artifact rather than
+// a list of them: artifacts start with their string ID.
+func isArtifactEntry(v []interface{}) bool {
+	if len(v) == 0 {
+		return false
+	}
+	_, ok := v[0].(string)
+	return ok
+}
+
+// parseArtifact parses a single artifact entry. Two layouts have been seen:
+//
+//	[id, type, state, [source-ids...], ...]
+//	[id, title, type, [sources...], state, ...]
+//
+// Timestamps are encoded as [seconds, nanos] pairs; the latest one found
+// in the entry is taken as the update time.
+func parseArtifact(data interface{}) *Artifact {
+	fields, ok := data.([]interface{})
+	if !ok || !isArtifactEntry(fields) {
+		return nil
+	}
+
+	artifact := &Artifact{ID: fields[0].(string)}
+	if artifact.ID == "" {
+		return nil
+	}
+
+	typeIdx, stateIdx := 1, 2
+	if title, ok := field(fields, 1).(string); ok {
+		artifact.Title = title
+		typeIdx, stateIdx = 2, 4
+	}
+	if v, ok := field(fields, typeIdx).(float64); ok {
+		artifact.Type = pb.ArtifactType(int32(v))
+	}
+	if v, ok := field(fields, stateIdx).(float64); ok {
+		artifact.State = pb.ArtifactState(int32(v))
+	}
+	if sources, ok := field(fields, 3).([]interface{}); ok {
+		artifact.SourceIDs = collectSourceIDs(sources)
+	}
+	for _, f := range fields[1:] {
+		if ts, ok := parseTimestamp(f); ok && ts.After(artifact.UpdatedAt) {
+			artifact.UpdatedAt = ts
+		}
+	}
+	return artifact
+}
+
+// field returns v[i], or nil when i is out of range.
+func field(v []interface{}, i int) interface{} {
+	if i < len(v) {
+		return v[i]
+	}
+	return nil
+}
+
+// collectSourceIDs gathers source IDs from a list that holds them either
+// directly or wrapped in nested arrays.
+func collectSourceIDs(v []interface{}) []string {
+	var ids []string
+	for _, item := range v {
+		switch item := item.(type) {
+		case string:
+			ids = append(ids, item)
+		case []interface{}:
+			ids = append(ids, collectSourceIDs(item)...)
+		}
+	}
+	return ids
+}
+
+// parseTimestamp decodes a [seconds, nanos] pair found among other
+// fields, in UTC.
+func parseTimestamp(v interface{}) (time.Time, bool) {
+	pair, ok := v.([]interface{})
+	if !ok || len(pair) != 2 {
+		return time.Time{}, false
+	}
+	sec, ok1 := pair[0].(float64)
+	_, ok2 := pair[1].(float64)
+	// Anything before 2001 is probably not a timestamp.
+	if !ok1 || !ok2 || sec < 1e9 {
+		return time.Time{}, false
+	}
+	return beprotojson.Time(pair)
+}
+
+// ArtifactKind names a kind of artifact that CreateArtifact can generate.
+// Study guides, briefing docs, FAQs and timelines are all reports that
+// differ only in their format; mind maps, slide decks and infographics are
+// separate artifact types.
+type ArtifactKind string
+
+const (
+	ArtifactStudyGuide  ArtifactKind = "study-guide"
+	ArtifactBriefingDoc ArtifactKind = "briefing-doc"
+	ArtifactFAQ         ArtifactKind = "faq"
+	ArtifactTimeline    ArtifactKind = "timeline"
+	ArtifactMindMap     ArtifactKind = "mind-map"
+	ArtifactSlideDeck   ArtifactKind = "slide-deck"
+	ArtifactInfographic ArtifactKind = "infographic"
+)
+
+// ArtifactKinds returns the kinds CreateArtifact supports.
+func ArtifactKinds() []ArtifactKind {
+	return []ArtifactKind{ArtifactStudyGuide, ArtifactBriefingDoc, ArtifactFAQ, ArtifactTimeline, ArtifactMindMap, ArtifactSlideDeck, ArtifactInfographic}
+}
+
+// reportFormat describes how a report-backed artifact kind is requested.
+type reportFormat struct {
+	title       string
+	description string
+	prompt      string
+}
+
+var reportFormats = map[ArtifactKind]reportFormat{
+	ArtifactStudyGuide: {
+		title:       "Study Guide",
+		description: "Short-answer quiz, suggested essay questions, and glossary of key terms",
+		prompt:      "Create a study guide with a short-answer quiz, an answer key, suggested essay questions, and a glossary of key terms from the sources.",
+	},
+	ArtifactBriefingDoc: {
+		title:       "Briefing Doc",
+		description: "Overview of your sources featuring key insights and quotes",
+		prompt:      "Create a briefing document that summarizes the main themes and most important ideas in the sources, with supporting quotes.",
+	},
+	ArtifactFAQ: {
+		title:       "FAQ",
+		description: "Frequently asked questions answered from your sources",
+		prompt:      "Create a list of frequently asked questions about the sources, each with a concise answer grounded in the sources.",
+	},
+	ArtifactTimeline: {
+		title:       "Timeline",
+		description: "Chronological list of main events and a cast of characters",
+		prompt:      "Create a detailed timeline of the main events in the sources, followed by a cast of characters with short bios.",
+	},
+}
+
+// ArtifactType returns the artifact type that k is stored as.
+func (k ArtifactKind) ArtifactType() pb.ArtifactType {
+	switch k {
+	case ArtifactMindMap:
+		return pb.ArtifactType_ARTIFACT_TYPE_MIND_MAP
+	case ArtifactSlideDeck:
+		return pb.ArtifactType_ARTIFACT_TYPE_SLIDE_DECK
+	case ArtifactInfographic:
+		return pb.ArtifactType_ARTIFACT_TYPE_INFOGRAPHIC
+	}
+	if _, ok := reportFormats[k]; ok {
+		return pb.ArtifactType_ARTIFACT_TYPE_REPORT
+	}
+	return pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED
+}
+
+// ParseArtifactKind parses a kind name such as "faq" or "study-guide".
+// Underscores and spaces are accepted in place of hyphens.
+func ParseArtifactKind(s string) (ArtifactKind, error) {
+	name := strings.NewReplacer("_", "-", " ", "-").Replace(strings.ToLower(strings.TrimSpace(s)))
+	switch name {
+	case "mindmap":
+		name = string(ArtifactMindMap)
+	case "slides", "slidedeck":
+		name = string(ArtifactSlideDeck)
+	}
+	for _, k := range ArtifactKinds() {
+		if name == string(k) {
+			return k, nil
+		}
+	}
+	names := make([]string, 0, len(ArtifactKinds()))
+	for _, k := range ArtifactKinds() {
+		names = append(names, string(k))
+	}
+	return "", fmt.Errorf("unknown artifact type %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// ParseArtifactType parses an artifact type name as shown by TypeName,
+// such as "report" or "audio_overview". "audio" is accepted for audio
+// overviews, and artifact kinds such as "faq" resolve to the type they
+// are stored as.
+func ParseArtifactType(s string) (pb.ArtifactType, error) {
+	name := strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToLower(strings.TrimSpace(s)))
+	switch name {
+	case "audio":
+		name = "audio_overview"
+	case "mindmap":
+		name = "mind_map"
+	}
+	if v, ok := pb.ArtifactType_value["ARTIFACT_TYPE_"+strings.ToUpper(name)]; ok && v != 0 {
+		return pb.ArtifactType(v), nil
+	}
+	if kind, err := ParseArtifactKind(s); err == nil {
+		return kind.ArtifactType(), nil
+	}
+	var names []string
+	for i := int32(1); pb.ArtifactType_name[i] != ""; i++ {
+		names = append(names, enumName(pb.ArtifactType_name[i], "ARTIFACT_TYPE_"))
+	}
+	return pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED, fmt.Errorf("unknown artifact type %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// CreateArtifactOptions adjusts how an artifact is generated.
+type CreateArtifactOptions struct {
+	// SourceIDs limits generation to these sources. When empty all of the
+	// notebook's sources are used.
+	SourceIDs []string
+	// Language is the output language code. It defaults to "en".
+	Language string
+	// Instructions customizes generation, as in the UI's "Customize" box,
+	// for example "focus on chapter 3".
+	Instructions string
+}
+
+// CreateArtifact starts generating an artifact of the given kind. The
+// returned artifact is usually still in the creating state; use
+// WaitForArtifact to wait for it to finish.
+func (c *Client) CreateArtifact(projectID string, kind ArtifactKind, opts *CreateArtifactOptions) (_ *Artifact, err error) {
+	defer wrapError(&err, "CreateArtifact", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if kind.ArtifactType() == pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED {
+		return nil, fmt.Errorf("unsupported artifact kind %q", kind)
+	}
+	if opts == nil {
+		opts = &CreateArtifactOptions{}
+	}
+
+	sourceIDs := opts.SourceIDs
+	if len(sourceIDs) == 0 {
+		project, err := c.GetProject(projectID)
+		if err != nil {
+			return nil, fmt.Errorf("create artifact: %w", err)
+		}
+		for _, src := range project.GetSources() {
+			if id := src.GetSourceId().GetSourceId(); id != "" {
+				sourceIDs = append(sourceIDs, id)
+			}
+		}
+		if len(sourceIDs) == 0 {
+			return nil, fmt.Errorf("create artifact: notebook has no sources")
+		}
+	}
+	language := opts.Language
+	if language == "" {
+		language = "en"
+	}
+
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:         rpc.RPCCreateArtifact,
+		Args:       encodeCreateArtifactArgs(projectID, kind, sourceIDs, language, strings.TrimSpace(opts.Instructions)),
+		NotebookID: projectID,
+	})
+	if err != nil {
+		if kind.isVisual() && isUnsupportedError(err) {
+			return nil, fmt.Errorf("create %s: %w", kind, ErrArtifactUnsupported)
+		}
+		return nil, fmt.Errorf("create artifact RPC: %w", err)
+	}
+
+	var responseData []interface{}
+	if err := json.Unmarshal(resp, &responseData); err != nil {
+		return nil, fmt.Errorf("parse create artifact response: %w", err)
+	}
+	if c.config.Debug {
+		fmt.Printf("Create artifact response: %+v\n", responseData)
+	}
+
+	artifacts := parseArtifactList(responseData)
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("failed to parse created artifact from response")
+	}
+	artifact := artifacts[0]
+	if artifact.Type == pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED {
+		artifact.Type = kind.ArtifactType()
+	}
+	if artifact.State == pb.ArtifactState_ARTIFACT_STATE_UNSPECIFIED {
+		artifact.State = pb.ArtifactState_ARTIFACT_STATE_CREATING
+	}
+	return artifact, nil
+}
+
+// encodeCreateArtifactArgs builds the CreateArtifact payload. Every kind
+// shares the header
+//
+//	[[2], project-id, [null, null, type, [[[source-id]]...], ...]]
+//
+// and reports append their format spec in the eighth slot:
+//
+//	[null, [title, description, null, [[source-id]...], language, prompt, null, true]]
+//
+// Custom instructions extend a report's prompt. Other kinds carry them in
+// the ninth slot, laid out as for video overviews:
+//
+//	[null, null, [[[source-id]...], language, instructions]]
+func encodeCreateArtifactArgs(projectID string, kind ArtifactKind, sourceIDs []string, language, instructions string) []interface{} {
+	nested := make([]interface{}, 0, len(sourceIDs))
+	wrapped := make([]interface{}, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		nested = append(nested, []interface{}{[]interface{}{id}})
+		wrapped = append(wrapped, []interface{}{id})
+	}
+
+	artifact := []interface{}{
+		nil,
+		nil,
+		int(kind.ArtifactType()),
+		nested,
+	}
+	if format, ok := reportFormats[kind]; ok {
+		prompt := format.prompt
+		if instructions != "" {
+			prompt += "\n\nAdditional instructions: " + instructions
+		}
+		artifact = append(artifact, nil, nil, nil, []interface{}{
+			nil,
+			[]interface{}{
+				format.title,
+				format.description,
+				nil,
+				wrapped,
+				language,
+				prompt,
+				nil,
+				true,
+			},
+		})
+	} else if instructions != "" {
+		artifact = append(artifact, nil, nil, nil, nil, []interface{}{
+			nil,
+			nil,
+			[]interface{}{nested, language, instructions},
+		})
+	}
+
+	return []interface{}{
+		[]interface{}{2},
+		projectID,
+		artifact,
+	}
+}
+
+// GetArtifact returns the artifact with the given ID.
+func (c *Client) GetArtifact(artifactID string) (_ *pb.Artifact, err error) {
+	defer wrapError(&err, "GetArtifact", "")
+	req := &pb.GetArtifactRequest{
+		ArtifactId: artifactID,
+	}
+	artifact, err := c.orchestrationService.GetArtifact(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("get artifact: %w", err)
+	}
+	return artifact, nil
+}
+
+// DeleteArtifact deletes the artifact with the given ID.
+func (c *Client) DeleteArtifact(artifactID string) (err error) {
+	defer wrapError(&err, "DeleteArtifact", "")
+	if err := validateIDs("artifact", artifactID); err != nil {
+		return err
+	}
+	req := &pb.DeleteArtifactRequest{
+		ArtifactId: artifactID,
+	}
+	if _, err := c.orchestrationService.DeleteArtifact(context.Background(), req); err != nil {
+		return fmt.Errorf("delete artifact: %w", err)
+	}
+	return nil
+}
+
+// ArtifactContent is an artifact together with its body converted to
+// Markdown.
+type ArtifactContent struct {
+	*Artifact
+	Markdown string
+}
+
+// GetArtifactContent fetches an artifact and converts its rich-text body to
+// Markdown.
+func (c *Client) GetArtifactContent(projectID, artifactID string) (_ *ArtifactContent, err error) {
+	defer wrapError(&err, "GetArtifactContent", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	data, err := c.getArtifactData(projectID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	content := decodeArtifactContent(data)
+	if content == nil {
+		return nil, fmt.Errorf("failed to parse artifact %s from response", artifactID)
+	}
+	return content, nil
+}
+
+// getArtifactData fetches the raw positional form of an artifact.
+func (c *Client) getArtifactData(projectID, artifactID string) ([]interface{}, error) {
+	if err := validateIDs("artifact", artifactID); err != nil {
+		return nil, err
+	}
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:         rpc.RPCGetArtifact,
+		Args:       []interface{}{artifactID},
+		NotebookID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get artifact RPC: %w", err)
+	}
+
+	var responseData []interface{}
+	if err := json.Unmarshal(resp, &responseData); err != nil {
+		return nil, fmt.Errorf("parse artifact response: %w", err)
+	}
+	if c.config.Debug {
+		fmt.Printf("Artifact response: %+v\n", responseData)
+	}
+	return responseData, nil
+}
+
+// artifactEntry returns the artifact entry in a GetArtifact response, which
+// is either the response itself or its first element.
+func artifactEntry(data []interface{}) []interface{} {
+	for _, v := range []interface{}{data, field(data, 0)} {
+		if fields, ok := v.([]interface{}); ok && isArtifactEntry(fields) {
+			return fields
+		}
+	}
+	return nil
+}
+
+// decodeArtifactContent finds the artifact entry in a GetArtifact response
+// and renders its body. The body is taken to be the longest string in the
+// entry that is not its ID, title or a source ID.
+func decodeArtifactContent(data []interface{}) *ArtifactContent {
+	entry := artifactEntry(data)
+	artifact := parseArtifact(entry)
+	if artifact == nil {
+		return nil
+	}
+
+	skip := map[string]bool{artifact.ID: true, artifact.Title: true}
+	for _, id := range artifact.SourceIDs {
+		skip[id] = true
+	}
+	var body string
+	walkStrings(entry[1:], func(s string) {
+		if !skip[s] && len(s) > len(body) {
+			body = s
+		}
+	})
+
+	md := richtext.ToMarkdown(body)
+	if artifact.Title != "" && !strings.HasPrefix(md, "# ") {
+		md = "# " + artifact.Title + "\n\n" + md
+	}
+	return &ArtifactContent{Artifact: artifact, Markdown: md}
+}
+
+// artifactJSONDocs returns the JSON objects embedded as strings in an
+// artifact entry. Structured artifacts such as mind maps and flashcards
+// carry their content this way.
+func artifactJSONDocs(entry []interface{}) []map[string]interface{} {
+	var docs []map[string]interface{}
+	walkStrings(entry, func(s string) {
+		if !strings.HasPrefix(strings.TrimSpace(s), "{") {
+			return
+		}
+		var doc map[string]interface{}
+		if json.Unmarshal([]byte(s), &doc) == nil {
+			docs = append(docs, doc)
+		}
+	})
+	return docs
+}
+
+// walkStrings calls fn for every string nested in v.
+func walkStrings(v interface{}, fn func(string)) {
+	switch v := v.(type) {
+	case string:
+		fn(v)
+	case []interface{}:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	}
+}
+
+// SaveArtifactAsNote copies an artifact's content into a new note, where
+// it can be edited. The note is titled after the artifact unless title is
+// set.
+func (c *Client) SaveArtifactAsNote(projectID, artifactID, title string) (_ *Note, err error) {
+	defer wrapError(&err, "SaveArtifactAsNote", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	content, err := c.GetArtifactContent(projectID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	note, err := c.CreateNote(projectID, savedArtifactTitle(content, title), content.Markdown)
+	if err != nil {
+		return nil, fmt.Errorf("save artifact %s as note: %w", artifactID, err)
+	}
+	return note, nil
+}
+
+// SaveArtifactAsSource adds an artifact's content to the notebook as a
+// text source, so that chats and later artifacts can draw on it. It
+// returns the new source ID.
+func (c *Client) SaveArtifactAsSource(projectID, artifactID, title string) (_ string, err error) {
+	defer wrapError(&err, "SaveArtifactAsSource", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
+	content, err := c.GetArtifactContent(projectID, artifactID)
+	if err != nil {
+		return "", err
+	}
+	sourceID, err := c.AddSourceFromText(projectID, content.Markdown, savedArtifactTitle(content, title))
+	if err != nil {
+		return "", fmt.Errorf("save artifact %s as source: %w", artifactID, err)
+	}
+	return sourceID, nil
+}
+
+func savedArtifactTitle(content *ArtifactContent, title string) string {
+	switch {
+	case title != "":
+		return title
+	case content.Title != "":
+		return content.Title
+	default:
+		return "Artifact " + content.ID
+	}
+}
+
+// RenameArtifact retitles an artifact using the rc3d8d RPC endpoint
+func (c *Client) RenameArtifact(artifactID, newTitle string) (_ *Artifact, err error) {
+	defer wrapError(&err, "RenameArtifact", "")
+	if err := validateIDs("artifact", artifactID); err != nil {
+		return nil, err
+	}
+	resp, err := c.rpc.Do(rpc.Call{
+		ID: rpc.RPCRenameArtifact,
+		Args: []interface{}{
+			[]interface{}{artifactID, newTitle},
+			[]interface{}{[]interface{}{"title"}},
+		},
+		NotebookID: "", // Not needed for artifact operations
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rename artifact RPC: %w", err)
+	}
+
+	artifact, err := c.decodeArtifactResponse(resp, "rename")
+	if err != nil {
+		return nil, err
+	}
+	if artifact.Title == "" {
+		artifact.Title = newTitle
+	}
+	return artifact, nil
+}
+
+// ArtifactUpdate holds the fields UpdateArtifact changes. Empty fields are
+// left as they are.
+type ArtifactUpdate struct {
+	Title   string
+	Content string // Markdown body of a report artifact
+}
+
+// updateArtifactArgs builds the UpdateArtifact payload: the artifact in
+// positional form followed by the field mask of the fields being changed.
+func updateArtifactArgs(projectID, artifactID string, update ArtifactUpdate) ([]interface{}, error) {
+	report := &pb.Report{}
+	var paths []interface{}
+	if update.Title != "" {
+		report.Title = update.Title
+		paths = append(paths, "tailored_report.title")
+	}
+	if update.Content != "" {
+		report.Content = update.Content
+		paths = append(paths, "tailored_report.content")
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("nothing to update")
+	}
+	artifact, err := beprotojson.Marshal(&pb.Artifact{
+		ArtifactId:     artifactID,
+		ProjectId:      projectID,
+		TailoredReport: report,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode artifact: %w", err)
+	}
+	return []interface{}{
+		json.RawMessage(artifact),
+		[]interface{}{paths},
+	}, nil
+}
+
+// UpdateArtifact edits a generated report's title and content, such as
+// correcting a study guide before sharing it.
+func (c *Client) UpdateArtifact(projectID, artifactID string, update ArtifactUpdate) (_ *Artifact, err error) {
+	defer wrapError(&err, "UpdateArtifact", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if err := validateIDs("artifact", artifactID); err != nil {
+		return nil, err
+	}
+	args, err := updateArtifactArgs(projectID, artifactID, update)
+	if err != nil {
+		return nil, fmt.Errorf("update artifact: %w", err)
+	}
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:         rpc.RPCUpdateArtifact,
+		Args:       args,
+		NotebookID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update artifact RPC: %w", err)
+	}
+
+	artifact, err := c.decodeArtifactResponse(resp, "update")
+	if err != nil {
+		return nil, err
+	}
+	if update.Title != "" && artifact.Title == "" {
+		artifact.Title = update.Title
+	}
+	return artifact, nil
+}
+
+// decodeArtifactResponse parses the artifact echoed back by a mutation RPC.
+func (c *Client) decodeArtifactResponse(resp []byte, op string) (*Artifact, error) {
+	var responseData []interface{}
+	if err := json.Unmarshal(resp, &responseData); err != nil {
+		return nil, fmt.Errorf("parse %s response: %w", op, err)
+	}
+
+	if c.config.Debug {
+		fmt.Printf("%s artifact response: %+v\n", op, responseData)
+	}
+
+	if artifact := parseArtifact(responseData); artifact != nil {
+		return artifact, nil
+	}
+	if artifacts := parseArtifactList(responseData); len(artifacts) > 0 {
+		return artifacts[0], nil
+	}
+	return nil, fmt.Errorf("failed to parse artifact from %s response", op)
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+)
+
+func TestParseArtifactList(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []*Artifact
+	}{
+		{
+			name:  "wrapped list without titles",
+			input: `[[["art1", 3, 2, ["src1", "src2"]], ["art2", 2, 1]]]`,
+			want: []*Artifact{
+				{ID: "art1", Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT, State: pb.ArtifactState_ARTIFACT_STATE_READY, SourceIDs: []string{"src1", "src2"}},
+				{ID: "art2", Type: pb.ArtifactType_ARTIFACT_TYPE_AUDIO_OVERVIEW, State: pb.ArtifactState_ARTIFACT_STATE_CREATING},
+			},
+		},
+		{
+			name:  "unwrapped list",
+			input: `[["art1", 3, 2]]`,
+			want: []*Artifact{
+				{ID: "art1", Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT, State: pb.ArtifactState_ARTIFACT_STATE_READY},
+			},
+		},
+		{
+			name:  "titled entries with timestamps",
+			input: `[[["art1", "Study Guide", 3, [[["src1"]]], 2, null, [1700000000, 0], [1700000500, 250]]]]`,
+			want: []*Artifact{
+				{
+					ID:        "art1",
+					Title:     "Study Guide",
+					Type:      pb.ArtifactType_ARTIFACT_TYPE_REPORT,
+					State:     pb.ArtifactState_ARTIFACT_STATE_READY,
+					SourceIDs: []string{"src1"},
+					UpdatedAt: time.Unix(1700000500, 250),
+				},
+			},
+		},
+		{
+			name:  "entries without IDs are skipped",
+			input: `[[[null, 3, 2], ["", 3], ["art1"]]]`,
+			want:  []*Artifact{{ID: "art1"}},
+		},
+		{
+			name:  "empty",
+			input: `[]`,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []interface{}
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatal(err)
+			}
+			got := parseArtifactList(data)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseArtifactList() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestArtifactMarshalJSON(t *testing.T) {
+	a := &Artifact{
+		ID:    "art1",
+		Type:  pb.ArtifactType_ARTIFACT_TYPE_AUDIO_OVERVIEW,
+		State: pb.ArtifactState_ARTIFACT_STATE_READY,
+	}
+	got, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"art1","type":"audio_overview","state":"ready"}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestParseArtifactKind(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ArtifactKind
+		wantErr bool
+	}{
+		{in: "faq", want: ArtifactFAQ},
+		{in: "study-guide", want: ArtifactStudyGuide},
+		{in: "Study_Guide", want: ArtifactStudyGuide},
+		{in: "briefing doc", want: ArtifactBriefingDoc},
+		{in: "timeline", want: ArtifactTimeline},
+		{in: "mindmap", want: ArtifactMindMap},
+		{in: "mind-map", want: ArtifactMindMap},
+		{in: "slides", want: ArtifactSlideDeck},
+		{in: "infographic", want: ArtifactInfographic},
+		{in: "podcast", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseArtifactKind(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArtifactKind(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseArtifactKind(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeCreateArtifactArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		kind         ArtifactKind
+		instructions string
+		want         string
+	}{
+		{
+			name: "mind map",
+			kind: ArtifactMindMap,
+			want: `[[2],"nb1",[null,null,5,[[["s1"]],[["s2"]]]]]`,
+		},
+		{
+			name: "infographic",
+			kind: ArtifactInfographic,
+			want: `[[2],"nb1",[null,null,9,[[["s1"]],[["s2"]]]]]`,
+		},
+		{
+			name: "faq",
+			kind: ArtifactFAQ,
+			want: `[[2],"nb1",[null,null,3,[[["s1"]],[["s2"]]],null,null,null,[null,["FAQ","Frequently asked questions answered from your sources",null,[["s1"],["s2"]],"en","Create a list of frequently asked questions about the sources, each with a concise answer grounded in the sources.",null,true]]]]`,
+		},
+		{
+			name:         "faq with instructions",
+			kind:         ArtifactFAQ,
+			instructions: "focus on chapter 3",
+			want:         `[[2],"nb1",[null,null,3,[[["s1"]],[["s2"]]],null,null,null,[null,["FAQ","Frequently asked questions answered from your sources",null,[["s1"],["s2"]],"en","Create a list of frequently asked questions about the sources, each with a concise answer grounded in the sources.\n\nAdditional instructions: focus on chapter 3",null,true]]]]`,
+		},
+		{
+			name:         "mind map with instructions",
+			kind:         ArtifactMindMap,
+			instructions: "focus on chapter 3",
+			want:         `[[2],"nb1",[null,null,5,[[["s1"]],[["s2"]]],null,null,null,null,[null,null,[[[["s1"]],[["s2"]]],"en","focus on chapter 3"]]]]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := encodeCreateArtifactArgs("nb1", tt.kind, []string{"s1", "s2"}, "en", tt.instructions)
+			got, err := json.Marshal(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("encodeCreateArtifactArgs() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArtifactKindsHaveTypes(t *testing.T) {
+	for _, k := range ArtifactKinds() {
+		if k.ArtifactType() == pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED {
+			t.Errorf("%s has no artifact type", k)
+		}
+	}
+}
+
+func TestDecodeArtifactContent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "markdown body",
+			input: `[["art1", "Briefing Doc", 3, [[["src1"]]], 2, "## Themes\n\n- One\n- Two"]]`,
+			want:  "# Briefing Doc\n\n## Themes\n\n- One\n- Two\n",
+		},
+		{
+			name:  "html body with its own heading",
+			input: `[["art1", "FAQ", 3, [[["src1"]]], 2, null, [null, "<h1>FAQ</h1><p><b>Q:</b> Why?</p>"]]]`,
+			want:  "# FAQ\n\n**Q:** Why?\n",
+		},
+		{
+			name:  "untitled",
+			input: `["art1", 3, 2, ["src1"], "Body text"]`,
+			want:  "Body text\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []interface{}
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatal(err)
+			}
+			got := decodeArtifactContent(data)
+			if got == nil {
+				t.Fatal("decodeArtifactContent() = nil")
+			}
+			if got.ID != "art1" {
+				t.Errorf("ID = %q, want art1", got.ID)
+			}
+			if got.Markdown != tt.want {
+				t.Errorf("Markdown =\n%q\nwant\n%q", got.Markdown, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateArtifactArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  ArtifactUpdate
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "title only",
+			update: ArtifactUpdate{Title: "Week 3"},
+			want:   `[["art1","nb1",null,[],null,null,[],[],["Week 3",null,[]],[]],[["tailored_report.title"]]]`,
+		},
+		{
+			name:   "title and content",
+			update: ArtifactUpdate{Title: "Week 3", Content: "# Notes"},
+			want:   `[["art1","nb1",null,[],null,null,[],[],["Week 3","# Notes",[]],[]],[["tailored_report.title","tailored_report.content"]]]`,
+		},
+		{
+			name:    "empty",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := updateArtifactArgs("nb1", "art1", tt.update)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("updateArtifactArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := json.Marshal(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("updateArtifactArgs() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeArtifactResponse(t *testing.T) {
+	c := &Client{}
+	for _, input := range []string{
+		`["art1", "New Title", 3, [], 2]`,
+		`[["art1", "New Title", 3, [], 2]]`,
+	} {
+		got, err := c.decodeArtifactResponse([]byte(input), "rename")
+		if err != nil {
+			t.Fatalf("decodeArtifactResponse(%s): %v", input, err)
+		}
+		if got.ID != "art1" || got.Title != "New Title" {
+			t.Errorf("decodeArtifactResponse(%s) = %+v", input, got)
+		}
+	}
+	if _, err := c.decodeArtifactResponse([]byte(`[]`), "rename"); err == nil {
+		t.Error("decodeArtifactResponse([]) succeeded, want error")
+	}
+}
+
+func TestParseArtifactType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    pb.ArtifactType
+		wantErr bool
+	}{
+		{in: "report", want: pb.ArtifactType_ARTIFACT_TYPE_REPORT},
+		{in: "audio", want: pb.ArtifactType_ARTIFACT_TYPE_AUDIO_OVERVIEW},
+		{in: "audio-overview", want: pb.ArtifactType_ARTIFACT_TYPE_AUDIO_OVERVIEW},
+		{in: "MIND_MAP", want: pb.ArtifactType_ARTIFACT_TYPE_MIND_MAP},
+		{in: "mindmap", want: pb.ArtifactType_ARTIFACT_TYPE_MIND_MAP},
+		{in: "faq", want: pb.ArtifactType_ARTIFACT_TYPE_REPORT},
+		{in: "slides", want: pb.ArtifactType_ARTIFACT_TYPE_SLIDE_DECK},
+		{in: "unspecified", wantErr: true},
+		{in: "podcast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseArtifactType(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArtifactType(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseArtifactType(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/rpc"
+)
+
+// ErrFeatureUnavailable is returned, wrapped, when the account does not
+// have a feature that Capabilities probed for.
+var ErrFeatureUnavailable = errors.New("not available on your account")
+
+// Feature names an optional NotebookLM feature that is rolled out to
+// accounts gradually or reserved for paid plans.
+type Feature string
+
+const (
+	FeatureVideoOverviews Feature = "video-overviews"
+	// FeatureInteractiveAudio is never probed, so Capabilities reports it
+	// as unknown: the RPCs behind interactive audio have not been
+	// captured, and having audio overviews says nothing about it.
+	FeatureInteractiveAudio Feature = "interactive-audio"
+	FeatureSlideDecks       Feature = "slide-decks"
+	FeatureInfographics     Feature = "infographics"
+)
+
+// Features returns the features Capabilities reports on.
+func Features() []Feature {
+	return []Feature{FeatureVideoOverviews, FeatureInteractiveAudio, FeatureSlideDecks, FeatureInfographics}
+}
+
+// Limits are the usage limits of a NotebookLM plan.
+type Limits struct {
+	Notebooks           int // notebooks per account
+	SourcesPerNotebook  int
+	DailyChats          int // chat questions per day
+	DailyAudioOverviews int
+}
+
+// Published limits of the free plan and of NotebookLM Plus.
+var (
+	FreeLimits = Limits{Notebooks: 100, SourcesPerNotebook: 50, DailyChats: 50, DailyAudioOverviews: 3}
+	PlusLimits = Limits{Notebooks: 500, SourcesPerNotebook: 300, DailyChats: 500, DailyAudioOverviews: 20}
+)
+
+// Capabilities describes what the signed-in account can do.
+type Capabilities struct {
+	// Plus reports whether the account is on NotebookLM Plus. No known
+	// RPC reports the plan, so it is inferred: an account that owns more
+	// notebooks than the free plan allows must be on Plus. Otherwise the
+	// account is taken to be on the free plan.
+	Plus   bool
+	Limits Limits
+	// Features records, for each probed feature, whether the account has
+	// it. A feature whose probe was inconclusive is absent.
+	Features map[Feature]bool
+}
+
+// Has reports whether the account has feature f. Features that could not
+// be probed are assumed to be present, so that the operation is tried and
+// fails with the server's own error if they are not.
+func (c *Capabilities) Has(f Feature) bool {
+	ok, probed := c.Features[f]
+	return ok || !probed
+}
+
+// Check returns an error wrapping ErrFeatureUnavailable if the account
+// does not have feature f.
+func (c *Capabilities) Check(f Feature) error {
+	if c.Has(f) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", f, ErrFeatureUnavailable)
+}
+
+// featureProbe is a request that the server refuses outright on accounts
+// without a feature. Probes are made for the empty notebook, so none can
+// create anything; on accounts with the feature they fail validation
+// instead.
+type featureProbe struct {
+	feature Feature
+	rpcID   string
+	args    []interface{}
+}
+
+var featureProbes = []featureProbe{
+	{FeatureVideoOverviews, rpc.RPCCreateVideoOverview, []interface{}{[]interface{}{2}, "", []interface{}{nil, nil, 3}}},
+	{FeatureSlideDecks, rpc.RPCCreateArtifact, encodeCreateArtifactArgs("", ArtifactSlideDeck, nil, "en", "")},
+	{FeatureInfographics, rpc.RPCCreateArtifact, encodeCreateArtifactArgs("", ArtifactInfographic, nil, "en", "")},
+}
+
+// Capabilities probes which optional features the account has and
+// infers its plan. Each feature costs one request that creates nothing.
+// It fails only if the account's notebooks cannot be listed, which
+// usually means the credentials are bad.
+func (c *Client) Capabilities(ctx context.Context) (_ *Capabilities, err error) {
+	defer wrapError(&err, "Capabilities", "")
+	notebooks, err := c.ListRecentlyViewedProjects()
+	if err != nil {
+		return nil, fmt.Errorf("probe capabilities: %w", err)
+	}
+	caps := &Capabilities{
+		Plus:     len(notebooks) > FreeLimits.Notebooks,
+		Limits:   FreeLimits,
+		Features: make(map[Feature]bool),
+	}
+	if caps.Plus {
+		caps.Limits = PlusLimits
+	}
+	for _, p := range featureProbes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		_, err := c.rpc.Do(rpc.Call{ID: p.rpcID, Args: p.args})
+		if has, ok := probeResult(err); ok {
+			caps.Features[p.feature] = has
+		}
+		if c.config.Debug {
+			fmt.Printf("DEBUG: capability probe %s: %v\n", p.feature, err)
+		}
+	}
+	return caps, nil
+}
+
+// probeResult interprets the error a feature probe returned. ok is false
+// when the error says nothing about the feature, such as a network
+// failure or rate limiting.
+func probeResult(err error) (has, ok bool) {
+	if err == nil {
+		return true, true
+	}
+	var apiErr *batchexecute.APIError
+	if !errors.As(err, &apiErr) {
+		return false, false
+	}
+	if apiErr.ErrorCode != nil {
+		switch apiErr.ErrorCode.Code {
+		case 7, 12: // permission denied, unimplemented
+			return false, true
+		case 9: // failed precondition: either refused or the empty notebook
+			return false, false
+		}
+		switch apiErr.ErrorCode.Type {
+		case batchexecute.ErrorTypeInvalidInput, batchexecute.ErrorTypeNotFound:
+			return true, true
+		}
+	}
+	switch apiErr.HTTPStatus {
+	case http.StatusForbidden, http.StatusNotImplemented:
+		return false, true
+	case http.StatusBadRequest, http.StatusNotFound:
+		return true, true
+	}
+	return false, false
+}
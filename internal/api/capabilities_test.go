@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+func TestProbeResult(t *testing.T) {
+	code := func(n int) error {
+		ec, _ := batchexecute.GetErrorCode(n)
+		return fmt.Errorf("rpc: %w", &batchexecute.APIError{ErrorCode: ec})
+	}
+	tests := []struct {
+		name    string
+		err     error
+		has, ok bool
+	}{
+		{"success", nil, true, true},
+		{"unimplemented", code(12), false, true},
+		{"permission denied", code(7), false, true},
+		{"invalid argument", code(6), true, true},
+		{"not found", code(5), true, true},
+		{"failed precondition", code(9), false, false},
+		{"rate limited", code(324934), false, false},
+		{"forbidden", &batchexecute.APIError{HTTPStatus: 403}, false, true},
+		{"bad request", &batchexecute.APIError{HTTPStatus: 400}, true, true},
+		{"server error", &batchexecute.APIError{HTTPStatus: 500}, false, false},
+		{"plain error", errors.New("boom"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			has, ok := probeResult(tt.err)
+			if has != tt.has || ok != tt.ok {
+				t.Errorf("probeResult(%v) = %v, %v, want %v, %v", tt.err, has, ok, tt.has, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesCheck(t *testing.T) {
+	caps := &Capabilities{Features: map[Feature]bool{
+		FeatureVideoOverviews: false,
+		FeatureSlideDecks:     true,
+	}}
+	if err := caps.Check(FeatureVideoOverviews); !errors.Is(err, ErrFeatureUnavailable) {
+		t.Errorf("Check(%s) = %v, want ErrFeatureUnavailable", FeatureVideoOverviews, err)
+	}
+	for _, f := range []Feature{FeatureSlideDecks, FeatureInfographics} {
+		if err := caps.Check(f); err != nil {
+			t.Errorf("Check(%s) = %v, want nil", f, err)
+		}
+	}
+}
+
+func TestInteractiveAudioNotProbed(t *testing.T) {
+	for _, p := range featureProbes {
+		if p.feature == FeatureInteractiveAudio {
+			t.Errorf("%s is probed with %s, which does not tell whether the account has it", p.feature, p.rpcID)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
 	"github.com/tmc/nlm/internal/beprotojson"
+	"github.com/tmc/nlm/internal/redact"
 )
 
 // ChunkedResponseParser is a specialized parser for NotebookLM's chunked response format
@@ -292,7 +293,7 @@ func (p *ChunkedResponseParser) parseWithRegex() ([]*pb.Project, error) {
 	projectDataStr = strings.ReplaceAll(projectDataStr, "\\\\", "\\")
 
 	// Debugging info
-	p.logDebug("Project data string (first 100 chars): %s", truncate(projectDataStr, 100))
+	p.logDebug("Project data string (first 100 chars): %s", redact.Truncate(projectDataStr, 100))
 
 	// Find projects with title, ID, and emoji
 	var projects []*pb.Project
@@ -568,7 +569,7 @@ func (p *ChunkedResponseParser) TryParseAsJSONArray() ([]interface{}, error) {
 				return result, nil
 			}
 
-			return nil, fmt.Errorf("failed to parse JSON array '%s': %w", truncate(arrayStr, 50), err)
+			return nil, fmt.Errorf("failed to parse JSON array '%s': %w", redact.Truncate(arrayStr, 50), err)
 		}
 	}
 
@@ -650,14 +651,6 @@ func (p *ChunkedResponseParser) ParseJSONArray() ([]interface{}, error) {
 	return result, nil
 }
 
-// Helper function to truncate text for display
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen] + "..."
-}
-
 // Helper function for min
 func min(a, b int) int {
 	if a < b {
@@ -697,7 +690,7 @@ func (p *ChunkedResponseParser) DebugPrint() {
 	fmt.Printf("Total chunks: %d\n", len(chunks))
 
 	for i, chunk := range chunks {
-		truncated := truncate(chunk, 100)
+		truncated := redact.Truncate(chunk, 100)
 		fmt.Printf("Chunk %d: %s\n", i, truncated)
 
 		// Detect chunk size indicators
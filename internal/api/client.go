@@ -4,8 +4,11 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
@@ -13,32 +16,52 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/tmc/nlm/gen/method"
 	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
 	"github.com/tmc/nlm/gen/service"
 	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/beprotojson"
+	"github.com/tmc/nlm/internal/redact"
 	"github.com/tmc/nlm/internal/rpc"
+	"github.com/tmc/nlm/internal/upload"
 )
 
 type Notebook = pb.Project
 type Note = pb.Source
 
 // Client handles NotebookLM API interactions.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its
+// requests share one HTTP transport, and the state they update, such as
+// request IDs, rate limits and statistics, is guarded; each call builds
+// its own URL parameters and headers. SetUseDirectRPC is the exception,
+// and must be called before the client is shared. WithMaxConcurrency
+// bounds how many requests are in flight at once.
 type Client struct {
+	authToken, cookies   string
+	ctx                  context.Context       // the context given to New
+	opts                 []batchexecute.Option // for deriving per-call clients
 	rpc                  *rpc.Client
 	orchestrationService *service.LabsTailwindOrchestrationServiceClient
 	sharingService       *service.LabsTailwindSharingServiceClient
 	guidebooksService    *service.LabsTailwindGuidebooksServiceClient
+	httpClient           *http.Client // for uploads; nil means http.DefaultClient
+	uploadURL            string       // resumable upload endpoint; tests override it
+	uploadConcurrency    int
+	pageParams           *paramsWarmer // nil without WithPageParams
 	config               struct {
 		Debug        bool
 		UseDirectRPC bool // Use direct RPC calls instead of orchestration service
 	}
 }
 
-// New creates a new NotebookLM API client.
-func New(authToken, cookies string, opts ...batchexecute.Option) *Client {
+// newClient returns a client for the given credentials and batchexecute
+// options.
+func newClient(authToken, cookies string, opts ...batchexecute.Option) *Client {
 	// Basic validation of auth parameters
 	if authToken == "" || cookies == "" {
 		fmt.Fprintf(os.Stderr, "Warning: Missing authentication credentials. Use 'nlm auth' to setup authentication.\n")
@@ -50,12 +73,7 @@ func New(authToken, cookies string, opts ...batchexecute.Option) *Client {
 	}
 
 	// Create the client
-	client := &Client{
-		rpc:                  rpc.New(authToken, cookies, opts...),
-		orchestrationService: service.NewLabsTailwindOrchestrationServiceClient(authToken, cookies, opts...),
-		sharingService:       service.NewLabsTailwindSharingServiceClient(authToken, cookies, opts...),
-		guidebooksService:    service.NewLabsTailwindGuidebooksServiceClient(authToken, cookies, opts...),
-	}
+	client := (&Client{authToken: authToken, cookies: cookies, uploadURL: defaultUploadURL}).derive(opts...)
 
 	// Get debug setting from environment for consistency
 	client.config.Debug = os.Getenv("NLM_DEBUG") == "true"
@@ -63,14 +81,47 @@ func New(authToken, cookies string, opts ...batchexecute.Option) *Client {
 	return client
 }
 
-// SetUseDirectRPC configures whether to use direct RPC calls
+// derive returns a copy of c whose requests are made with c's
+// batchexecute options followed by opts.
+func (c *Client) derive(opts ...batchexecute.Option) *Client {
+	opts = append(slices.Clip(c.opts), opts...)
+	d := &Client{
+		authToken:            c.authToken,
+		cookies:              c.cookies,
+		ctx:                  c.ctx,
+		opts:                 opts,
+		rpc:                  rpc.New(c.authToken, c.cookies, opts...),
+		orchestrationService: service.NewLabsTailwindOrchestrationServiceClient(c.authToken, c.cookies, opts...),
+		sharingService:       service.NewLabsTailwindSharingServiceClient(c.authToken, c.cookies, opts...),
+		guidebooksService:    service.NewLabsTailwindGuidebooksServiceClient(c.authToken, c.cookies, opts...),
+		httpClient:           c.httpClient,
+		uploadURL:            c.uploadURL,
+		uploadConcurrency:    c.uploadConcurrency,
+		pageParams:           c.pageParams,
+	}
+	d.config = c.config
+	return d
+}
+
+// SetUseDirectRPC configures whether to use direct RPC calls. It must not
+// be called while other goroutines use the client.
 func (c *Client) SetUseDirectRPC(use bool) {
 	c.config.UseDirectRPC = use
 }
 
+// Raw calls the RPC with the given ID and returns its undecoded
+// response. It is an escape hatch for RPCs, and response fields, the
+// typed methods do not cover yet. notebookID may be empty for RPCs that
+// are not about a notebook.
+func (c *Client) Raw(rpcID, notebookID string, args ...interface{}) (_ json.RawMessage, err error) {
+	defer wrapError(&err, "Raw", notebookID)
+	return c.rpc.Do(rpc.Call{ID: rpcID, NotebookID: notebookID, Args: args})
+}
+
 // Project/Notebook operations
 
-func (c *Client) ListRecentlyViewedProjects() ([]*Notebook, error) {
+func (c *Client) ListRecentlyViewedProjects() (_ []*Notebook, err error) {
+	defer wrapError(&err, "ListRecentlyViewedProjects", "")
 	req := &pb.ListRecentlyViewedProjectsRequest{}
 
 	response, err := c.orchestrationService.ListRecentlyViewedProjects(context.Background(), req)
@@ -81,7 +132,8 @@ func (c *Client) ListRecentlyViewedProjects() ([]*Notebook, error) {
 	return response.Projects, nil
 }
 
-func (c *Client) CreateProject(title string, emoji string) (*Notebook, error) {
+func (c *Client) CreateProject(title string, emoji string) (_ *Notebook, err error) {
+	defer wrapError(&err, "CreateProject", "")
 	req := &pb.CreateProjectRequest{
 		Title: title,
 		Emoji: emoji,
@@ -94,7 +146,11 @@ func (c *Client) CreateProject(title string, emoji string) (*Notebook, error) {
 	return project, nil
 }
 
-func (c *Client) GetProject(projectID string) (*Notebook, error) {
+func (c *Client) GetProject(projectID string) (_ *Notebook, err error) {
+	defer wrapError(&err, "GetProject", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GetProjectRequest{
 		ProjectId: projectID,
 	}
@@ -111,20 +167,55 @@ func (c *Client) GetProject(projectID string) (*Notebook, error) {
 	return project, nil
 }
 
-func (c *Client) DeleteProjects(projectIDs []string) error {
+// GetProjectIfChanged is GetProject for watch and sync loops. version
+// identifies the notebook as an earlier call returned it: if the
+// notebook has not changed since, it is not decoded again, and nil is
+// returned with the same version. NotebookLM cannot send only what
+// changed, so the notebook is still fetched, and compared by a hash of
+// the response. An empty version always returns the notebook.
+func (c *Client) GetProjectIfChanged(projectID, version string) (_ *Notebook, newVersion string, err error) {
+	defer wrapError(&err, "GetProjectIfChanged", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, "", err
+	}
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:   rpc.RPCGetProject,
+		Args: method.EncodeGetProjectArgs(&pb.GetProjectRequest{ProjectId: projectID}),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("get project: %w", err)
+	}
+	sum := sha256.Sum256(resp)
+	newVersion = hex.EncodeToString(sum[:16])
+	if newVersion == version {
+		return nil, version, nil
+	}
+	var project pb.Project
+	if err := beprotojson.Unmarshal(resp, &project); err != nil {
+		return nil, "", fmt.Errorf("get project: unmarshal response: %w", err)
+	}
+	return &project, newVersion, nil
+}
+
+func (c *Client) DeleteProjects(projectIDs []string) (err error) {
+	defer wrapError(&err, "DeleteProjects", "")
 	req := &pb.DeleteProjectsRequest{
 		ProjectIds: projectIDs,
 	}
 
 	ctx := context.Background()
-	_, err := c.orchestrationService.DeleteProjects(ctx, req)
+	_, err = c.orchestrationService.DeleteProjects(ctx, req)
 	if err != nil {
 		return fmt.Errorf("delete projects: %w", err)
 	}
 	return nil
 }
 
-func (c *Client) MutateProject(projectID string, updates *pb.Project) (*Notebook, error) {
+func (c *Client) MutateProject(projectID string, updates *pb.Project) (_ *Notebook, err error) {
+	defer wrapError(&err, "MutateProject", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.MutateProjectRequest{
 		ProjectId: projectID,
 		Updates:   updates,
@@ -138,19 +229,27 @@ func (c *Client) MutateProject(projectID string, updates *pb.Project) (*Notebook
 	return project, nil
 }
 
-func (c *Client) RemoveRecentlyViewedProject(projectID string) error {
+func (c *Client) RemoveRecentlyViewedProject(projectID string) (err error) {
+	defer wrapError(&err, "RemoveRecentlyViewedProject", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return err
+	}
 	req := &pb.RemoveRecentlyViewedProjectRequest{
 		ProjectId: projectID,
 	}
 
 	ctx := context.Background()
-	_, err := c.orchestrationService.RemoveRecentlyViewedProject(ctx, req)
+	_, err = c.orchestrationService.RemoveRecentlyViewedProject(ctx, req)
 	return err
 }
 
 // Source operations
 
-func (c *Client) AddSources(projectID string, sources []*pb.SourceInput) (*pb.Project, error) {
+func (c *Client) AddSources(projectID string, sources []*pb.SourceInput) (_ *pb.Project, err error) {
+	defer wrapError(&err, "AddSources", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.AddSourceRequest{
 		Sources:   sources,
 		ProjectId: projectID,
@@ -163,19 +262,30 @@ func (c *Client) AddSources(projectID string, sources []*pb.SourceInput) (*pb.Pr
 	return project, nil
 }
 
-func (c *Client) DeleteSources(projectID string, sourceIDs []string) error {
+func (c *Client) DeleteSources(projectID string, sourceIDs []string) (err error) {
+	defer wrapError(&err, "DeleteSources", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return err
+	}
+	if err := validateIDs("source", sourceIDs...); err != nil {
+		return err
+	}
 	req := &pb.DeleteSourcesRequest{
 		SourceIds: sourceIDs,
 	}
 	ctx := context.Background()
-	_, err := c.orchestrationService.DeleteSources(ctx, req)
+	_, err = c.orchestrationService.DeleteSources(ctx, req)
 	if err != nil {
 		return fmt.Errorf("delete sources: %w", err)
 	}
 	return nil
 }
 
-func (c *Client) MutateSource(sourceID string, updates *pb.Source) (*pb.Source, error) {
+func (c *Client) MutateSource(sourceID string, updates *pb.Source) (_ *pb.Source, err error) {
+	defer wrapError(&err, "MutateSource", "")
+	if err := validateIDs("source", sourceID); err != nil {
+		return nil, err
+	}
 	req := &pb.MutateSourceRequest{
 		SourceId: sourceID,
 		Updates:  updates,
@@ -188,7 +298,11 @@ func (c *Client) MutateSource(sourceID string, updates *pb.Source) (*pb.Source,
 	return source, nil
 }
 
-func (c *Client) RefreshSource(sourceID string) (*pb.Source, error) {
+func (c *Client) RefreshSource(sourceID string) (_ *pb.Source, err error) {
+	defer wrapError(&err, "RefreshSource", "")
+	if err := validateIDs("source", sourceID); err != nil {
+		return nil, err
+	}
 	req := &pb.RefreshSourceRequest{
 		SourceId: sourceID,
 	}
@@ -200,7 +314,11 @@ func (c *Client) RefreshSource(sourceID string) (*pb.Source, error) {
 	return source, nil
 }
 
-func (c *Client) LoadSource(sourceID string) (*pb.Source, error) {
+func (c *Client) LoadSource(sourceID string) (_ *pb.Source, err error) {
+	defer wrapError(&err, "LoadSource", "")
+	if err := validateIDs("source", sourceID); err != nil {
+		return nil, err
+	}
 	req := &pb.LoadSourceRequest{
 		SourceId: sourceID,
 	}
@@ -212,7 +330,36 @@ func (c *Client) LoadSource(sourceID string) (*pb.Source, error) {
 	return source, nil
 }
 
-func (c *Client) CheckSourceFreshness(sourceID string) (*pb.CheckSourceFreshnessResponse, error) {
+// GetSourceText returns the full text NotebookLM extracted from a
+// source, which LoadSource leaves out.
+func (c *Client) GetSourceText(sourceID string) (_ string, err error) {
+	defer wrapError(&err, "GetSourceText", "")
+	if err := validateIDs("source", sourceID); err != nil {
+		return "", err
+	}
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:   rpc.RPCLoadSource,
+		Args: []interface{}{[]interface{}{sourceID}, []int{2}, []int{2}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("load source: %w", err)
+	}
+	var data []interface{}
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return "", fmt.Errorf("parse source response: %w", err)
+	}
+	// The text is at position 3, in blocks nested in arrays with their
+	// character offsets.
+	var blocks []string
+	walkStrings(field(data, 3), func(s string) { blocks = append(blocks, s) })
+	return strings.Join(blocks, "\n"), nil
+}
+
+func (c *Client) CheckSourceFreshness(sourceID string) (_ *pb.CheckSourceFreshnessResponse, err error) {
+	defer wrapError(&err, "CheckSourceFreshness", "")
+	if err := validateIDs("source", sourceID); err != nil {
+		return nil, err
+	}
 	req := &pb.CheckSourceFreshnessRequest{
 		SourceId: sourceID,
 	}
@@ -224,14 +371,21 @@ func (c *Client) CheckSourceFreshness(sourceID string) (*pb.CheckSourceFreshness
 	return result, nil
 }
 
-func (c *Client) ActOnSources(projectID string, action string, sourceIDs []string) error {
+func (c *Client) ActOnSources(projectID string, action string, sourceIDs []string) (err error) {
+	defer wrapError(&err, "ActOnSources", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return err
+	}
+	if err := validateIDs("source", sourceIDs...); err != nil {
+		return err
+	}
 	req := &pb.ActOnSourcesRequest{
 		ProjectId: projectID,
 		Action:    action,
 		SourceIds: sourceIDs,
 	}
 	ctx := context.Background()
-	_, err := c.orchestrationService.ActOnSources(ctx, req)
+	_, err = c.orchestrationService.ActOnSources(ctx, req)
 	if err != nil {
 		return fmt.Errorf("act on sources: %w", err)
 	}
@@ -283,7 +437,11 @@ func detectMIMEType(content []byte, filename string, providedType string) string
 	return detectedType
 }
 
-func (c *Client) AddSourceFromReader(projectID string, r io.Reader, filename string, contentType ...string) (string, error) {
+func (c *Client) AddSourceFromReader(projectID string, r io.Reader, filename string, contentType ...string) (_ string, err error) {
+	defer wrapError(&err, "AddSourceFromReader", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
 	content, err := io.ReadAll(r)
 	if err != nil {
 		return "", fmt.Errorf("read content: %w", err)
@@ -298,9 +456,10 @@ func (c *Client) AddSourceFromReader(projectID string, r io.Reader, filename str
 	detectedType := detectMIMEType(content, filename, providedType)
 
 	// Treat plain text or JSON content as text source
-	if strings.HasPrefix(detectedType, "text/") ||
-		detectedType == "application/json" ||
-		strings.HasSuffix(filename, ".json") {
+	if isTextType(detectedType, filename) {
+		if err := validateSourceText(filename, string(content)); err != nil {
+			return "", err
+		}
 		// Add debug output about JSON handling for any environment
 		if strings.HasSuffix(filename, ".json") || detectedType == "application/json" {
 			fmt.Fprintf(os.Stderr, "Handling JSON file as text: %s (MIME: %s)\n", filename, detectedType)
@@ -308,11 +467,21 @@ func (c *Client) AddSourceFromReader(projectID string, r io.Reader, filename str
 		return c.AddSourceFromText(projectID, string(content), filename)
 	}
 
+	if err := validateSourceFile(filename, int64(len(content)), detectedType); err != nil {
+		return "", err
+	}
 	encoded := base64.StdEncoding.EncodeToString(content)
 	return c.AddSourceFromBase64(projectID, encoded, filename, detectedType)
 }
 
-func (c *Client) AddSourceFromText(projectID string, content, title string) (string, error) {
+func (c *Client) AddSourceFromText(projectID string, content, title string) (_ string, err error) {
+	defer wrapError(&err, "AddSourceFromText", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
+	if err := validateSourceText(title, content); err != nil {
+		return "", err
+	}
 	resp, err := c.rpc.Do(rpc.Call{
 		ID:         rpc.RPCAddSources,
 		NotebookID: projectID,
@@ -342,7 +511,14 @@ func (c *Client) AddSourceFromText(projectID string, content, title string) (str
 	return sourceID, nil
 }
 
-func (c *Client) AddSourceFromBase64(projectID string, content, filename, contentType string) (string, error) {
+func (c *Client) AddSourceFromBase64(projectID string, content, filename, contentType string) (_ string, err error) {
+	defer wrapError(&err, "AddSourceFromBase64", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
+	if err := validateSourceFile(filename, int64(base64.StdEncoding.DecodedLen(len(content))), contentType); err != nil {
+		return "", err
+	}
 	resp, err := c.rpc.Do(rpc.Call{
 		ID:         rpc.RPCAddSources,
 		NotebookID: projectID,
@@ -369,7 +545,11 @@ func (c *Client) AddSourceFromBase64(projectID string, content, filename, conten
 	return sourceID, nil
 }
 
-func (c *Client) AddSourceFromFile(projectID string, filepath string, contentType ...string) (string, error) {
+func (c *Client) AddSourceFromFile(projectID string, filepath string, contentType ...string) (_ string, err error) {
+	defer wrapError(&err, "AddSourceFromFile", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
 	f, err := os.Open(filepath)
 	if err != nil {
 		return "", fmt.Errorf("open file: %w", err)
@@ -380,10 +560,97 @@ func (c *Client) AddSourceFromFile(projectID string, filepath string, contentTyp
 	if len(contentType) > 0 {
 		providedType = contentType[0]
 	}
+	fi, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("stat file: %w", err)
+	}
+	// Checked before the file is read, as it may be very large.
+	if err := validateSourceFile(filepath, fi.Size(), ""); err != nil {
+		return "", err
+	}
+	if fi.Size() >= uploadThreshold {
+		head := make([]byte, 512)
+		n, _ := f.ReadAt(head, 0)
+		if detected := detectMIMEType(head[:n], filepath, providedType); !isTextType(detected, filepath) {
+			return c.AddSourceFromUpload(projectID, f, fi.Size(), filepath, detected)
+		}
+	}
 	return c.AddSourceFromReader(projectID, f, filepath, providedType)
 }
 
-func (c *Client) AddSourceFromURL(projectID string, url string) (string, error) {
+// uploadThreshold is the size from which AddSourceFromFile uploads a
+// binary file with AddSourceFromUpload rather than inline.
+const uploadThreshold = 16 << 20
+
+// defaultUploadURL is the endpoint of NotebookLM's resumable uploads.
+const defaultUploadURL = "https://notebooklm.google.com/upload/_/"
+
+// AddSourceFromUpload adds a file source of size bytes read from r,
+// sending the content with a resumable upload rather than inline in an
+// RPC. The content goes in parts with checksums, several at once when
+// the endpoint allows it, and a part that fails is retried on its own,
+// which suits audio and PDF files of a hundred megabytes or more.
+func (c *Client) AddSourceFromUpload(projectID string, r io.ReaderAt, size int64, filename, contentType string) (_ string, err error) {
+	defer wrapError(&err, "AddSourceFromUpload", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
+	if err := validateSourceFile(filename, size, contentType); err != nil {
+		return "", err
+	}
+	name := filepath.Base(filename)
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:         rpc.RPCRegisterFileSource,
+		NotebookID: projectID,
+		Args: []interface{}{
+			[]interface{}{[]interface{}{name}},
+			projectID,
+			[]int{2},
+			[]interface{}{1, nil, nil, nil, nil, nil, nil, nil, nil, nil, []int{1}},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("register file source: %w", err)
+	}
+	sourceID, err := extractSourceID(resp)
+	if err != nil {
+		return "", fmt.Errorf("extract source ID: %w", err)
+	}
+
+	u := &upload.Uploader{
+		HTTPClient:  c.httpClient,
+		Concurrency: c.uploadConcurrency,
+		OnRequest:   func(req *http.Request) { c.rpc.WriteCurl(req, nil) },
+		Header: http.Header{
+			"Cookie":  {c.cookies},
+			"Origin":  {"https://notebooklm.google.com"},
+			"Referer": {"https://notebooklm.google.com/"},
+		},
+	}
+	meta := map[string]string{"PROJECT_ID": projectID, "SOURCE_NAME": name, "SOURCE_ID": sourceID}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, err := u.Upload(ctx, c.uploadURL+"?authuser=0", meta, r, size); err != nil {
+		return "", fmt.Errorf("upload %s: %w", name, err)
+	}
+	return sourceID, nil
+}
+
+// isTextType reports whether content of the given MIME type is added as
+// a text source.
+func isTextType(mimeType, filename string) bool {
+	return strings.HasPrefix(mimeType, "text/") ||
+		mimeType == "application/json" ||
+		strings.HasSuffix(filename, ".json")
+}
+
+func (c *Client) AddSourceFromURL(projectID string, url string) (_ string, err error) {
+	defer wrapError(&err, "AddSourceFromURL", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
 	// Check if it's a YouTube URL first
 	if isYouTubeURL(url) {
 		videoID, err := extractYouTubeVideoID(url)
@@ -420,7 +687,11 @@ func (c *Client) AddSourceFromURL(projectID string, url string) (string, error)
 	return sourceID, nil
 }
 
-func (c *Client) AddYouTubeSource(projectID, videoID string) (string, error) {
+func (c *Client) AddYouTubeSource(projectID, videoID string) (_ string, err error) {
+	defer wrapError(&err, "AddYouTubeSource", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return "", err
+	}
 	if c.rpc.Config.Debug {
 		fmt.Printf("=== AddYouTubeSource ===\n")
 		fmt.Printf("Project ID: %s\n", projectID)
@@ -535,7 +806,11 @@ func extractSourceID(resp json.RawMessage) (string, error) {
 
 // Note operations
 
-func (c *Client) CreateNote(projectID string, title string, initialContent string) (*Note, error) {
+func (c *Client) CreateNote(projectID string, title string, initialContent string) (_ *Note, err error) {
+	defer wrapError(&err, "CreateNote", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.CreateNoteRequest{
 		ProjectId: projectID,
 		Content:   initialContent,
@@ -551,7 +826,11 @@ func (c *Client) CreateNote(projectID string, title string, initialContent strin
 	return note, nil
 }
 
-func (c *Client) MutateNote(projectID string, noteID string, content string, title string) (*Note, error) {
+func (c *Client) MutateNote(projectID string, noteID string, content string, title string) (_ *Note, err error) {
+	defer wrapError(&err, "MutateNote", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.MutateNoteRequest{
 		ProjectId: projectID,
 		NoteId:    noteID,
@@ -570,19 +849,27 @@ func (c *Client) MutateNote(projectID string, noteID string, content string, tit
 	return note, nil
 }
 
-func (c *Client) DeleteNotes(projectID string, noteIDs []string) error {
+func (c *Client) DeleteNotes(projectID string, noteIDs []string) (err error) {
+	defer wrapError(&err, "DeleteNotes", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return err
+	}
 	req := &pb.DeleteNotesRequest{
 		NoteIds: noteIDs,
 	}
 	ctx := context.Background()
-	_, err := c.orchestrationService.DeleteNotes(ctx, req)
+	_, err = c.orchestrationService.DeleteNotes(ctx, req)
 	if err != nil {
 		return fmt.Errorf("delete notes: %w", err)
 	}
 	return nil
 }
 
-func (c *Client) GetNotes(projectID string) ([]*Note, error) {
+func (c *Client) GetNotes(projectID string) (_ []*Note, err error) {
+	defer wrapError(&err, "GetNotes", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GetNotesRequest{
 		ProjectId: projectID,
 	}
@@ -596,24 +883,45 @@ func (c *Client) GetNotes(projectID string) ([]*Note, error) {
 
 // Audio operations
 
-func (c *Client) CreateAudioOverview(projectID string, instructions string) (*AudioOverviewResult, error) {
-	if projectID == "" {
-		return nil, fmt.Errorf("project ID required")
+// AudioOverviewOptions customizes a generated audio overview. Zero-valued
+// presets leave the choice to the server.
+type AudioOverviewOptions struct {
+	Instructions string
+	Length       pb.AudioOverviewLength
+	Style        pb.AudioOverviewStyle
+}
+
+func (c *Client) CreateAudioOverview(projectID string, instructions string) (_ *AudioOverviewResult, err error) {
+	defer wrapError(&err, "CreateAudioOverview", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
 	}
-	if instructions == "" {
+	return c.CreateAudioOverviewWithOptions(projectID, AudioOverviewOptions{Instructions: instructions})
+}
+
+// CreateAudioOverviewWithOptions creates an audio overview using the given
+// length and host-style presets.
+func (c *Client) CreateAudioOverviewWithOptions(projectID string, opts AudioOverviewOptions) (_ *AudioOverviewResult, err error) {
+	defer wrapError(&err, "CreateAudioOverviewWithOptions", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if opts.Instructions == "" {
 		return nil, fmt.Errorf("instructions required")
 	}
 
 	// Use direct RPC if configured
 	if c.config.UseDirectRPC {
-		return c.createAudioOverviewDirectRPC(projectID, instructions)
+		return c.createAudioOverviewDirectRPC(projectID, opts)
 	}
 
 	// Default: use orchestration service
 	req := &pb.CreateAudioOverviewRequest{
 		ProjectId:    projectID,
 		AudioType:    0,
-		Instructions: []string{instructions},
+		Instructions: []string{opts.Instructions},
+		Length:       opts.Length,
+		Style:        opts.Style,
 	}
 	ctx := context.Background()
 	audioOverview, err := c.orchestrationService.CreateAudioOverview(ctx, req)
@@ -633,14 +941,19 @@ func (c *Client) CreateAudioOverview(projectID string, instructions string) (*Au
 }
 
 // createAudioOverviewDirectRPC uses direct RPC calls (original implementation)
-func (c *Client) createAudioOverviewDirectRPC(projectID string, instructions string) (*AudioOverviewResult, error) {
+func (c *Client) createAudioOverviewDirectRPC(projectID string, opts AudioOverviewOptions) (*AudioOverviewResult, error) {
+	args := []interface{}{
+		projectID,
+		0, // audio_type
+		[]string{opts.Instructions},
+	}
+	// Presets are only sent when set so the default request is unchanged
+	if opts.Style != 0 || opts.Length != 0 {
+		args = append(args, int(opts.Style), int(opts.Length))
+	}
 	resp, err := c.rpc.Do(rpc.Call{
-		ID: rpc.RPCCreateAudioOverview,
-		Args: []interface{}{
-			projectID,
-			0, // audio_type
-			[]string{instructions},
-		},
+		ID:         rpc.RPCCreateAudioOverview,
+		Args:       args,
 		NotebookID: projectID,
 	})
 	if err != nil {
@@ -694,7 +1007,11 @@ func (c *Client) createAudioOverviewDirectRPC(projectID string, instructions str
 	return result, nil
 }
 
-func (c *Client) GetAudioOverview(projectID string) (*AudioOverviewResult, error) {
+func (c *Client) GetAudioOverview(projectID string) (_ *AudioOverviewResult, err error) {
+	defer wrapError(&err, "GetAudioOverview", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	// Try direct RPC first if enabled, as it provides more complete data
 	if c.config.UseDirectRPC {
 		return c.getAudioOverviewDirectRPC(projectID)
@@ -795,12 +1112,16 @@ func (r *AudioOverviewResult) GetAudioBytes() ([]byte, error) {
 	return base64.StdEncoding.DecodeString(r.AudioData)
 }
 
-func (c *Client) DeleteAudioOverview(projectID string) error {
+func (c *Client) DeleteAudioOverview(projectID string) (err error) {
+	defer wrapError(&err, "DeleteAudioOverview", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return err
+	}
 	req := &pb.DeleteAudioOverviewRequest{
 		ProjectId: projectID,
 	}
 	ctx := context.Background()
-	_, err := c.orchestrationService.DeleteAudioOverview(ctx, req)
+	_, err = c.orchestrationService.DeleteAudioOverview(ctx, req)
 	if err != nil {
 		return fmt.Errorf("delete audio overview: %w", err)
 	}
@@ -817,9 +1138,10 @@ type VideoOverviewResult struct {
 	IsReady   bool
 }
 
-func (c *Client) CreateVideoOverview(projectID string, instructions string) (*VideoOverviewResult, error) {
-	if projectID == "" {
-		return nil, fmt.Errorf("project ID required")
+func (c *Client) CreateVideoOverview(projectID string, instructions string) (_ *VideoOverviewResult, err error) {
+	defer wrapError(&err, "CreateVideoOverview", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
 	}
 	if instructions == "" {
 		return nil, fmt.Errorf("instructions required")
@@ -922,9 +1244,17 @@ func (c *Client) CreateVideoOverview(projectID string, instructions string) (*Vi
 	return result, nil
 }
 
+// ErrAudioNotReady is returned when downloading the audio overview of a
+// notebook whose audio overview has not finished generating.
+var ErrAudioNotReady = errors.New("audio overview not ready")
+
 // DownloadAudioOverview attempts to download the actual audio file
 // by trying different request types until it finds one with audio data
-func (c *Client) DownloadAudioOverview(projectID string) (*AudioOverviewResult, error) {
+func (c *Client) DownloadAudioOverview(projectID string) (_ *AudioOverviewResult, err error) {
+	defer wrapError(&err, "DownloadAudioOverview", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	if !c.config.UseDirectRPC {
 		return nil, fmt.Errorf("audio download requires --direct-rpc flag for now")
 	}
@@ -932,6 +1262,8 @@ func (c *Client) DownloadAudioOverview(projectID string) (*AudioOverviewResult,
 	// Try different request types to find the one that returns audio data
 	requestTypes := []int{0, 1, 2, 3, 4, 5}
 
+	var lastErr error
+	answered := false
 	for _, requestType := range requestTypes {
 		if c.config.Debug {
 			fmt.Printf("Trying request_type=%d for audio download...\n", requestType)
@@ -942,8 +1274,10 @@ func (c *Client) DownloadAudioOverview(projectID string) (*AudioOverviewResult,
 			if c.config.Debug {
 				fmt.Printf("Request type %d failed: %v\n", requestType, err)
 			}
+			lastErr = err
 			continue
 		}
+		answered = true
 
 		// Check if this request type returned audio data
 		if result.AudioData != "" {
@@ -958,7 +1292,12 @@ func (c *Client) DownloadAudioOverview(projectID string) (*AudioOverviewResult,
 		}
 	}
 
-	return nil, fmt.Errorf("no request type returned audio data - the audio may not be ready yet")
+	// If no request type got an answer, the failure is not about the
+	// audio being unfinished; report it so callers need not wait it out.
+	if !answered && lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("no request type returned audio data: %w", ErrAudioNotReady)
 }
 
 // SaveAudioToFile saves audio data to a file
@@ -980,7 +1319,11 @@ func (r *AudioOverviewResult) SaveAudioToFile(filename string) error {
 }
 
 // ListAudioOverviews returns audio overviews for a notebook
-func (c *Client) ListAudioOverviews(projectID string) ([]*AudioOverviewResult, error) {
+func (c *Client) ListAudioOverviews(projectID string) (_ []*AudioOverviewResult, err error) {
+	defer wrapError(&err, "ListAudioOverviews", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	// Try to get the audio overview for the project
 	// NotebookLM typically has at most one audio overview per notebook
 	audioOverview, err := c.GetAudioOverview(projectID)
@@ -1006,7 +1349,11 @@ func (c *Client) ListAudioOverviews(projectID string) ([]*AudioOverviewResult, e
 }
 
 // ListVideoOverviews returns video overviews for a notebook
-func (c *Client) ListVideoOverviews(projectID string) ([]*VideoOverviewResult, error) {
+func (c *Client) ListVideoOverviews(projectID string) (_ []*VideoOverviewResult, err error) {
+	defer wrapError(&err, "ListVideoOverviews", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	// Since there's no GetVideoOverview RPC endpoint, we need to use a different approach
 	// We can try to get the project and see if it has video overview metadata
 	project, err := c.GetProject(projectID)
@@ -1033,7 +1380,11 @@ func (c *Client) ListVideoOverviews(projectID string) ([]*VideoOverviewResult, e
 
 // GetVideoOverview attempts to get a video overview for a notebook
 // Since there's no official GetVideoOverview RPC endpoint, we try alternative approaches
-func (c *Client) GetVideoOverview(projectID string) (*VideoOverviewResult, error) {
+func (c *Client) GetVideoOverview(projectID string) (_ *VideoOverviewResult, err error) {
+	defer wrapError(&err, "GetVideoOverview", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	if !c.config.UseDirectRPC {
 		return nil, fmt.Errorf("video overview requires --direct-rpc flag")
 	}
@@ -1217,7 +1568,11 @@ func (c *Client) tryVideoFromCreateResponse(projectID string) (*VideoOverviewRes
 }
 
 // DownloadVideoOverview attempts to download video overview data
-func (c *Client) DownloadVideoOverview(projectID string) (*VideoOverviewResult, error) {
+func (c *Client) DownloadVideoOverview(projectID string) (_ *VideoOverviewResult, err error) {
+	defer wrapError(&err, "DownloadVideoOverview", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	if !c.config.UseDirectRPC {
 		return nil, fmt.Errorf("video download requires --direct-rpc flag")
 	}
@@ -1466,7 +1821,8 @@ func (r *VideoOverviewResult) saveBase64VideoToFile(base64Data, filename string)
 }
 
 // DownloadVideoWithAuth downloads a video using the client's authentication
-func (c *Client) DownloadVideoWithAuth(videoURL, filename string) error {
+func (c *Client) DownloadVideoWithAuth(videoURL, filename string) (err error) {
+	defer wrapError(&err, "DownloadVideoWithAuth", "")
 	// Create HTTP client with timeout
 	client := &http.Client{
 		Timeout: 300 * time.Second, // 5 minute timeout for large video downloads
@@ -1506,9 +1862,10 @@ func (c *Client) DownloadVideoWithAuth(videoURL, filename string) error {
 	}
 
 	if c.config.Debug {
-		fmt.Printf("Downloading video from: %s\n", req.URL.String())
+		fmt.Printf("Downloading video from: %s\n", redact.URL(req.URL.String()))
 		fmt.Printf("Using cookies: %v\n", cookies != "")
 	}
+	c.rpc.WriteCurl(req, nil)
 
 	// Make the request
 	resp, err := client.Do(req)
@@ -1546,143 +1903,13 @@ func (c *Client) DownloadVideoWithAuth(videoURL, filename string) error {
 	return nil
 }
 
-// ListArtifacts returns artifacts for a project using direct RPC
-func (c *Client) ListArtifacts(projectID string) ([]*pb.Artifact, error) {
-	resp, err := c.rpc.Do(rpc.Call{
-		ID: rpc.RPCListArtifacts,
-		Args: []interface{}{
-			[]interface{}{2}, // filter parameter - 2 seems to be for all artifacts
-			projectID,
-		},
-		NotebookID: projectID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("list artifacts RPC: %w", err)
-	}
-
-	// Parse response
-	var responseData []interface{}
-	if err := json.Unmarshal(resp, &responseData); err != nil {
-		return nil, fmt.Errorf("parse artifacts response: %w", err)
-	}
-
-	if c.config.Debug {
-		fmt.Printf("Artifacts response: %+v\n", responseData)
-	}
-
-	// Convert response to artifacts
-	var artifacts []*pb.Artifact
-
-	// The response format might be [[artifact1, artifact2, ...]] or [artifact1, artifact2, ...]
-	if len(responseData) > 0 {
-		// Try to parse as array of artifacts
-		if artifactArray, ok := responseData[0].([]interface{}); ok {
-			// Response is wrapped in an array
-			for _, item := range artifactArray {
-				if artifact := c.parseArtifactFromResponse(item); artifact != nil {
-					artifacts = append(artifacts, artifact)
-				}
-			}
-		} else {
-			// Response is direct array of artifacts
-			for _, item := range responseData {
-				if artifact := c.parseArtifactFromResponse(item); artifact != nil {
-					artifacts = append(artifacts, artifact)
-				}
-			}
-		}
-	}
-
-	return artifacts, nil
-}
-
-// RenameArtifact renames an artifact using the rc3d8d RPC endpoint
-func (c *Client) RenameArtifact(artifactID, newTitle string) (*pb.Artifact, error) {
-	resp, err := c.rpc.Do(rpc.Call{
-		ID: rpc.RPCRenameArtifact,
-		Args: []interface{}{
-			[]interface{}{artifactID, newTitle},
-			[]interface{}{[]interface{}{"title"}},
-		},
-		NotebookID: "", // Not needed for artifact operations
-	})
-	if err != nil {
-		return nil, fmt.Errorf("rename artifact RPC: %w", err)
-	}
-
-	// Parse response
-	var responseData []interface{}
-	if err := json.Unmarshal(resp, &responseData); err != nil {
-		return nil, fmt.Errorf("parse rename response: %w", err)
-	}
-
-	if c.config.Debug {
-		fmt.Printf("Rename artifact response: %+v\n", responseData)
-	}
-
-	// The response should contain the updated artifact data
-	if len(responseData) > 0 {
-		if artifact := c.parseArtifactFromResponse(responseData[0]); artifact != nil {
-			return artifact, nil
-		}
-	}
-
-	return nil, fmt.Errorf("failed to parse renamed artifact from response")
-}
-
-// parseArtifactFromResponse parses an artifact from RPC response data
-func (c *Client) parseArtifactFromResponse(data interface{}) *pb.Artifact {
-	artifactData, ok := data.([]interface{})
-	if !ok || len(artifactData) == 0 {
-		return nil
-	}
-
-	artifact := &pb.Artifact{}
-
-	// Parse artifact ID (usually first element)
-	if len(artifactData) > 0 {
-		if id, ok := artifactData[0].(string); ok {
-			artifact.ArtifactId = id
-		}
-	}
-
-	// Parse artifact type (usually second element)
-	if len(artifactData) > 1 {
-		if typeVal, ok := artifactData[1].(float64); ok {
-			artifact.Type = pb.ArtifactType(int32(typeVal))
-		}
-	}
-
-	// Parse artifact state (usually third element)
-	if len(artifactData) > 2 {
-		if stateVal, ok := artifactData[2].(float64); ok {
-			artifact.State = pb.ArtifactState(int32(stateVal))
-		}
-	}
-
-	// Parse sources (if available)
-	if len(artifactData) > 3 {
-		if sourcesData, ok := artifactData[3].([]interface{}); ok {
-			for _, sourceData := range sourcesData {
-				if sourceId, ok := sourceData.(string); ok {
-					artifact.Sources = append(artifact.Sources, &pb.ArtifactSource{
-						SourceId: &pb.SourceId{SourceId: sourceId},
-					})
-				}
-			}
-		}
-	}
-
-	// Only return artifact if we have at least an ID
-	if artifact.ArtifactId != "" {
-		return artifact
-	}
-	return nil
-}
-
 // Generation operations
 
-func (c *Client) GenerateDocumentGuides(projectID string) (*pb.GenerateDocumentGuidesResponse, error) {
+func (c *Client) GenerateDocumentGuides(projectID string) (_ *pb.GenerateDocumentGuidesResponse, err error) {
+	defer wrapError(&err, "GenerateDocumentGuides", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GenerateDocumentGuidesRequest{
 		ProjectId: projectID,
 	}
@@ -1694,7 +1921,11 @@ func (c *Client) GenerateDocumentGuides(projectID string) (*pb.GenerateDocumentG
 	return guides, nil
 }
 
-func (c *Client) GenerateNotebookGuide(projectID string) (*pb.GenerateNotebookGuideResponse, error) {
+func (c *Client) GenerateNotebookGuide(projectID string) (_ *pb.GenerateNotebookGuideResponse, err error) {
+	defer wrapError(&err, "GenerateNotebookGuide", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GenerateNotebookGuideRequest{
 		ProjectId: projectID,
 	}
@@ -1706,7 +1937,14 @@ func (c *Client) GenerateNotebookGuide(projectID string) (*pb.GenerateNotebookGu
 	return guide, nil
 }
 
-func (c *Client) GenerateMagicView(projectID string, sourceIDs []string) (*pb.GenerateMagicViewResponse, error) {
+func (c *Client) GenerateMagicView(projectID string, sourceIDs []string) (_ *pb.GenerateMagicViewResponse, err error) {
+	defer wrapError(&err, "GenerateMagicView", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if err := validateIDs("source", sourceIDs...); err != nil {
+		return nil, err
+	}
 	req := &pb.GenerateMagicViewRequest{
 		ProjectId: projectID,
 		SourceIds: sourceIDs,
@@ -1719,7 +1957,11 @@ func (c *Client) GenerateMagicView(projectID string, sourceIDs []string) (*pb.Ge
 	return magicView, nil
 }
 
-func (c *Client) GenerateOutline(projectID string) (*pb.GenerateOutlineResponse, error) {
+func (c *Client) GenerateOutline(projectID string) (_ *pb.GenerateOutlineResponse, err error) {
+	defer wrapError(&err, "GenerateOutline", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GenerateOutlineRequest{
 		ProjectId: projectID,
 	}
@@ -1731,7 +1973,11 @@ func (c *Client) GenerateOutline(projectID string) (*pb.GenerateOutlineResponse,
 	return outline, nil
 }
 
-func (c *Client) GenerateSection(projectID string) (*pb.GenerateSectionResponse, error) {
+func (c *Client) GenerateSection(projectID string) (_ *pb.GenerateSectionResponse, err error) {
+	defer wrapError(&err, "GenerateSection", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GenerateSectionRequest{
 		ProjectId: projectID,
 	}
@@ -1743,7 +1989,11 @@ func (c *Client) GenerateSection(projectID string) (*pb.GenerateSectionResponse,
 	return section, nil
 }
 
-func (c *Client) StartDraft(projectID string) (*pb.StartDraftResponse, error) {
+func (c *Client) StartDraft(projectID string) (_ *pb.StartDraftResponse, err error) {
+	defer wrapError(&err, "StartDraft", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.StartDraftRequest{
 		ProjectId: projectID,
 	}
@@ -1755,7 +2005,11 @@ func (c *Client) StartDraft(projectID string) (*pb.StartDraftResponse, error) {
 	return draft, nil
 }
 
-func (c *Client) StartSection(projectID string) (*pb.StartSectionResponse, error) {
+func (c *Client) StartSection(projectID string) (_ *pb.StartSectionResponse, err error) {
+	defer wrapError(&err, "StartSection", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.StartSectionRequest{
 		ProjectId: projectID,
 	}
@@ -1767,7 +2021,25 @@ func (c *Client) StartSection(projectID string) (*pb.StartSectionResponse, error
 	return section, nil
 }
 
-func (c *Client) GenerateFreeFormStreamed(projectID string, prompt string, sourceIDs []string) (*pb.GenerateFreeFormStreamedResponse, error) {
+// GenerateFreeFormStreamed asks a question of the notebook's sources, or
+// of those in sourceIDs if it is not empty, and returns the answer. opts
+// override the client's settings for this call.
+func (c *Client) GenerateFreeFormStreamed(projectID string, prompt string, sourceIDs []string, opts ...CallOption) (_ *pb.GenerateFreeFormStreamedResponse, err error) {
+	defer wrapError(&err, "GenerateFreeFormStreamed", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if err := validateIDs("source", sourceIDs...); err != nil {
+		return nil, err
+	}
+	if err := validatePrompt(prompt); err != nil {
+		return nil, err
+	}
+	c, o, done := c.withCall(opts)
+	defer done()
+	if len(sourceIDs) == 0 {
+		sourceIDs = o.sourceIDs
+	}
 	// Check if we should skip sources (useful for testing or when project is inaccessible)
 	skipSources := os.Getenv("NLM_SKIP_SOURCES") == "true"
 
@@ -1815,8 +2087,24 @@ func (c *Client) GenerateFreeFormStreamed(projectID string, prompt string, sourc
 	return response, nil
 }
 
-// GenerateFreeFormStreamedWithCallback streams the response and calls the callback for each chunk
-func (c *Client) GenerateFreeFormStreamedWithCallback(projectID string, prompt string, sourceIDs []string, callback func(chunk string) bool) error {
+// GenerateFreeFormStreamedWithCallback streams the response and calls the callback for each chunk.
+// opts override the client's settings for this call.
+func (c *Client) GenerateFreeFormStreamedWithCallback(projectID string, prompt string, sourceIDs []string, callback func(chunk string) bool, opts ...CallOption) (err error) {
+	defer wrapError(&err, "GenerateFreeFormStreamedWithCallback", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return err
+	}
+	if err := validateIDs("source", sourceIDs...); err != nil {
+		return err
+	}
+	if err := validatePrompt(prompt); err != nil {
+		return err
+	}
+	c, o, done := c.withCall(opts)
+	defer done()
+	if len(sourceIDs) == 0 {
+		sourceIDs = o.sourceIDs
+	}
 	// Check if we should skip sources (useful for testing or when project is inaccessible)
 	skipSources := os.Getenv("NLM_SKIP_SOURCES") == "true"
 
@@ -1888,13 +2176,18 @@ func (c *Client) GenerateFreeFormStreamedWithCallback(projectID string, prompt s
 	return nil
 }
 
-// GetProjectWithContext is like GetProject but accepts a context for cancellation
-func (c *Client) GetProjectWithContext(ctx context.Context, projectID string) (*Notebook, error) {
+// GetProjectWithContext is like GetProject but makes the request with
+// ctx, so that canceling ctx aborts it.
+func (c *Client) GetProjectWithContext(ctx context.Context, projectID string) (_ *Notebook, err error) {
+	defer wrapError(&err, "GetProjectWithContext", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GetProjectRequest{
 		ProjectId: projectID,
 	}
 
-	project, err := c.orchestrationService.GetProject(ctx, req)
+	project, err := c.withContext(ctx).orchestrationService.GetProject(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("get project: %w", err)
 	}
@@ -1905,7 +2198,11 @@ func (c *Client) GetProjectWithContext(ctx context.Context, projectID string) (*
 	return project, nil
 }
 
-func (c *Client) GenerateReportSuggestions(projectID string) (*pb.GenerateReportSuggestionsResponse, error) {
+func (c *Client) GenerateReportSuggestions(projectID string) (_ *pb.GenerateReportSuggestionsResponse, err error) {
+	defer wrapError(&err, "GenerateReportSuggestions", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.GenerateReportSuggestionsRequest{
 		ProjectId: projectID,
 	}
@@ -1935,7 +2232,11 @@ type ShareAudioResult struct {
 }
 
 // ShareAudio shares an audio overview with optional public access
-func (c *Client) ShareAudio(projectID string, shareOption ShareOption) (*ShareAudioResult, error) {
+func (c *Client) ShareAudio(projectID string, shareOption ShareOption) (_ *ShareAudioResult, err error) {
+	defer wrapError(&err, "ShareAudio", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.ShareAudioRequest{
 		ShareOptions: []int32{int32(shareOption)},
 		ProjectId:    projectID,
@@ -1963,7 +2264,11 @@ func (c *Client) ShareAudio(projectID string, shareOption ShareOption) (*ShareAu
 }
 
 // ShareProject shares a project with specified settings
-func (c *Client) ShareProject(projectID string, settings *pb.ShareSettings) (*pb.ShareProjectResponse, error) {
+func (c *Client) ShareProject(projectID string, settings *pb.ShareSettings) (_ *pb.ShareProjectResponse, err error) {
+	defer wrapError(&err, "ShareProject", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
 	req := &pb.ShareProjectRequest{
 		ProjectId: projectID,
 		Settings:  settings,
@@ -30,11 +30,9 @@ func TestListProjectsWithRecording(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Call the API method
@@ -106,11 +104,10 @@ func TestCreateProjectWithRecording(t *testing.T) {
 	}
 
 	// Use environment credentials for both recording and replay
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(true),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
+		WithBatchExecuteOptions(batchexecute.WithDebug(true)),
 	)
 
 	// Call the API method
@@ -146,11 +143,10 @@ func TestAddSourceFromTextWithRecording(t *testing.T) {
 	}
 
 	// Use environment credentials for both recording and replay
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(true),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
+		WithBatchExecuteOptions(batchexecute.WithDebug(true)),
 	)
 
 	// First, we need a project to add sources to
@@ -1,7 +1,15 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -45,3 +53,81 @@ func TestDetectMIMEType(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProjectIfChanged(t *testing.T) {
+	rt := &recordingTransport{status: http.StatusOK, body: ")]}'\n\n[[\"wrb.fr\",\"rLM1Ne\",\"[\\\"Research\\\",null,\\\"nb1\\\"]\",null,null,null,\"generic\"]]"}
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nb, version, err := c.GetProjectIfChanged("nb1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nb.GetTitle() != "Research" || version == "" {
+		t.Fatalf("GetProjectIfChanged() = %v, %q", nb, version)
+	}
+	// The same response is not decoded again.
+	nb, again, err := c.GetProjectIfChanged("nb1", version)
+	if err != nil || nb != nil || again != version {
+		t.Errorf("unchanged GetProjectIfChanged() = %v, %q, %v; want nil, %q", nb, again, err, version)
+	}
+	rt.body = strings.Replace(rt.body, "Research", "Notes", 1)
+	nb, changed, err := c.GetProjectIfChanged("nb1", version)
+	if err != nil || nb.GetTitle() != "Notes" || changed == version {
+		t.Errorf("changed GetProjectIfChanged() = %v, %q, %v", nb, changed, err)
+	}
+}
+
+func TestAddSourceFromUpload(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		meta     map[string]string
+		uploaded []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "batchexecute"):
+			fmt.Fprint(w, ")]}'\n\n[[\"wrb.fr\",\"o4cbdc\",\"[[[[\\\"src1\\\"],\\\"big.pdf\\\"]]]\",null,null,null,\"generic\"]]")
+		case r.URL.Path == "/upload/_/" && r.Header.Get("X-Goog-Upload-Command") == "start":
+			mu.Lock()
+			json.NewDecoder(r.Body).Decode(&meta)
+			mu.Unlock()
+			w.Header().Set("X-Goog-Upload-URL", "https://notebooklm.google.com/upload/_/session")
+		case r.URL.Path == "/upload/_/session":
+			mu.Lock()
+			uploaded, _ = io.ReadAll(r.Body)
+			mu.Unlock()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	// Send requests for NotebookLM to the test server.
+	toServer := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme, req.URL.Host = "http", strings.TrimPrefix(srv.URL, "http://")
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: toServer}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("%PDF-1.7 a large document")
+	id, err := c.AddSourceFromUpload("nb1", bytes.NewReader(content), int64(len(content)), "/tmp/big.pdf", "application/pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "src1" {
+		t.Errorf("AddSourceFromUpload() = %q, want src1", id)
+	}
+	if meta["SOURCE_ID"] != "src1" || meta["PROJECT_ID"] != "nb1" || meta["SOURCE_NAME"] != "big.pdf" {
+		t.Errorf("upload metadata = %v", meta)
+	}
+	if !bytes.Equal(uploaded, content) {
+		t.Errorf("uploaded %q, want %q", uploaded, content)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -10,7 +10,6 @@ import (
 	"testing"
 
 	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
-	"github.com/tmc/nlm/internal/batchexecute"
 	"github.com/tmc/nlm/internal/httprr"
 )
 
@@ -29,11 +28,9 @@ func TestNotebookCommands_ListProjects(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	projects, err := client.ListRecentlyViewedProjects()
@@ -62,11 +59,9 @@ func TestNotebookCommands_CreateProject(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	project, err := client.CreateProject("Test Project for Recording", "📝")
@@ -99,11 +94,9 @@ func TestNotebookCommands_DeleteProject(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// First create a project to delete
@@ -136,11 +129,9 @@ func TestSourceCommands_ListSources(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -176,11 +167,9 @@ func TestSourceCommands_AddTextSource(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -223,11 +212,9 @@ func TestSourceCommands_AddURLSource(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -270,11 +257,9 @@ func TestSourceCommands_DeleteSource(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -318,11 +303,9 @@ func TestSourceCommands_RenameSource(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -374,11 +357,9 @@ func TestAudioCommands_CreateAudioOverview(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -416,11 +397,9 @@ func TestAudioCommands_GetAudioOverview(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -462,11 +441,9 @@ func TestGenerationCommands_GenerateNotebookGuide(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -503,11 +480,9 @@ func TestGenerationCommands_GenerateOutline(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// Get a project to test with
@@ -544,11 +519,9 @@ func TestMiscCommands_Heartbeat(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	_ = New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	_ = mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// The heartbeat method might not exist or might be a no-op
@@ -570,11 +543,9 @@ func TestVideoCommands_CreateVideoOverview(t *testing.T) {
 		cookies = os.Getenv("NLM_COOKIES")
 	}
 
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
 	)
 
 	// First, we need a project to create video for
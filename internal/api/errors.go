@@ -0,0 +1,171 @@
+package api
+
+import (
+	"errors"
+	"net"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/rpc"
+)
+
+// Errors returned by Client methods, wrapped in an *Error, so callers can
+// test for them with errors.Is. They are part of the package's stable
+// API: new sentinels may be added, but these keep their meaning.
+//
+// ErrUnauthorized, ErrPermissionDenied, ErrNotFound, ErrRateLimited,
+// ErrInvalidArgument and ErrUnavailable classify the failure the server
+// reported. ErrGenerationFailed, ErrArtifactUnsupported,
+// ErrDomainSharingUnsupported, ErrAudioNotReady, ErrHostChatClosed and
+// ErrFeatureUnavailable are declared next to the methods that return them.
+var (
+	// ErrUnauthorized means the credentials are missing, expired or
+	// rejected; signing in again with `nlm auth` fixes it.
+	ErrUnauthorized = batchexecute.ErrUnauthorized
+	// ErrPermissionDenied means the account may not access the notebook
+	// or other resource.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrNotFound means the notebook, source, note or artifact does not
+	// exist.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited means the account sent too many requests or ran out
+	// of quota; retrying later may succeed.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInvalidArgument means the server rejected the request's
+	// arguments.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrUnavailable means the service is temporarily unavailable.
+	ErrUnavailable = errors.New("service unavailable")
+)
+
+// Error is the error returned by a failed Client method. It records the
+// method, the batchexecute RPC that failed, if any, and the notebook the
+// call was for, and it matches the sentinel errors above that classify
+// its cause. Its message is the message of Err.
+//
+//	var apiErr *api.Error
+//	if errors.As(err, &apiErr) && errors.Is(err, api.ErrNotFound) {
+//		log.Printf("%s: notebook %s is gone", apiErr.Op, apiErr.NotebookID)
+//	}
+type Error struct {
+	Op         string // Client method, such as "GetProject"
+	RPCID      string // RPC ID, such as "rLM1Ne"; empty if no RPC failed
+	NotebookID string // empty if the call was not for one notebook
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel that classifies e.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrPermissionDenied, ErrNotFound, ErrRateLimited, ErrInvalidArgument, ErrUnavailable, ErrUnauthorized:
+		return classify(e.Err) == target
+	}
+	return false
+}
+
+// classify returns the sentinel for the failure err reports, or nil.
+func classify(err error) error {
+	var valErr *ValidationError
+	if errors.As(err, &valErr) {
+		return ErrInvalidArgument
+	}
+	var apiErr *batchexecute.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.ErrorCode != nil {
+			switch apiErr.ErrorCode.Type {
+			case batchexecute.ErrorTypeAuthentication:
+				return ErrUnauthorized
+			case batchexecute.ErrorTypeAuthorization, batchexecute.ErrorTypePermissionDenied:
+				return ErrPermissionDenied
+			case batchexecute.ErrorTypeNotFound:
+				return ErrNotFound
+			case batchexecute.ErrorTypeRateLimit, batchexecute.ErrorTypeResourceExhausted:
+				return ErrRateLimited
+			case batchexecute.ErrorTypeInvalidInput:
+				return ErrInvalidArgument
+			case batchexecute.ErrorTypeUnavailable:
+				return ErrUnavailable
+			}
+		}
+		if s := statusError(apiErr.HTTPStatus); s != nil {
+			return s
+		}
+	}
+	var batchErr *batchexecute.BatchExecuteError
+	if errors.As(err, &batchErr) {
+		return statusError(batchErr.StatusCode)
+	}
+	return nil
+}
+
+// IsTransient reports whether err may go away by trying again later: the
+// server is throttling the account or briefly unavailable, the request
+// failed on the network, or the audio overview is still being generated.
+// Anything else, such as a missing notebook or expired credentials, will
+// not, and callers that poll or retry should give up on it at once.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrAudioNotReady) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUnavailable) {
+		return true
+	}
+	switch classify(err) {
+	case ErrRateLimited, ErrUnavailable:
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// statusError returns the sentinel for an HTTP status, or nil.
+func statusError(status int) error {
+	switch status {
+	case 400:
+		return ErrInvalidArgument
+	case 401:
+		return ErrUnauthorized
+	case 403:
+		return ErrPermissionDenied
+	case 404:
+		return ErrNotFound
+	case 429:
+		return ErrRateLimited
+	case 503:
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// wrapError wraps *errp, if it is not nil, in an *Error for method op on
+// notebook notebookID. Client methods defer it. An error that is already
+// an *Error, from a method calling another, keeps its method, gaining
+// only the notebook if it had none.
+func wrapError(errp *error, op, notebookID string) {
+	if *errp == nil {
+		return
+	}
+	var e *Error
+	if errors.As(*errp, &e) {
+		if e.NotebookID == "" {
+			e.NotebookID = notebookID
+		}
+		return
+	}
+	e = &Error{Op: op, NotebookID: notebookID, Err: *errp}
+	var rpcErr *rpc.Error
+	if errors.As(*errp, &rpcErr) {
+		e.RPCID = rpcErr.ID
+		if e.NotebookID == "" {
+			e.NotebookID = rpcErr.NotebookID
+		}
+	}
+	*errp = e
+}
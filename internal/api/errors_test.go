@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"http 401", http.StatusUnauthorized, "", ErrUnauthorized},
+		{"http 403", http.StatusForbidden, "", ErrPermissionDenied},
+		{"http 404", http.StatusNotFound, "", ErrNotFound},
+		{"http 429", http.StatusTooManyRequests, "", ErrRateLimited},
+		{"http 503", http.StatusServiceUnavailable, "", ErrUnavailable},
+		{"error code", http.StatusOK, ")]}'\n\n[[\"wrb.fr\",\"rLM1Ne\",\"143\",null,null,null,\"generic\"]]", ErrNotFound},
+	}
+	sentinels := []error{ErrUnauthorized, ErrPermissionDenied, ErrNotFound, ErrRateLimited, ErrInvalidArgument, ErrUnavailable}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(context.Background(),
+				WithAuth("tok", "SID=1"),
+				WithHTTPClient(&http.Client{Transport: &recordingTransport{status: tt.status, body: tt.body}}),
+				WithRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Millisecond}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = c.GetProject("nb1")
+			if err == nil {
+				t.Fatal("GetProject succeeded")
+			}
+			for _, s := range sentinels {
+				if got := errors.Is(err, s); got != (s == tt.want) {
+					t.Errorf("errors.Is(%v, %q) = %v", err, s, got)
+				}
+			}
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("error %T is not an *Error", err)
+			}
+			if e.Op != "GetProject" || e.RPCID != "rLM1Ne" || e.NotebookID != "nb1" {
+				t.Errorf("Error = {Op: %q, RPCID: %q, NotebookID: %q}, want GetProject, rLM1Ne, nb1", e.Op, e.RPCID, e.NotebookID)
+			}
+			var apiErr *batchexecute.APIError
+			var batchErr *batchexecute.BatchExecuteError
+			if !errors.As(err, &apiErr) && !errors.As(err, &batchErr) {
+				t.Errorf("error %v does not wrap the batchexecute error", err)
+			}
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"not ready", &Error{Op: "DownloadAudioOverview", Err: fmt.Errorf("no audio: %w", ErrAudioNotReady)}, true},
+		{"rate limited", &batchexecute.APIError{HTTPStatus: 429}, true},
+		{"unavailable", &Error{Err: &batchexecute.APIError{HTTPStatus: 503}}, true},
+		{"network", &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{"not found", &Error{Err: &batchexecute.APIError{HTTPStatus: 404}}, false},
+		{"unauthorized", batchexecute.ErrUnauthorized, false},
+		{"invalid argument", &Error{Err: &ValidationError{What: "notebook ID", Reason: "empty"}}, false},
+		{"unclassified", errors.New("audio download requires --direct-rpc flag for now"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.want {
+				t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	var err error
+	wrapError(&err, "GetProject", "nb1")
+	if err != nil {
+		t.Fatalf("nil error wrapped as %v", err)
+	}
+
+	// A method calling another keeps the inner method, adding the
+	// notebook.
+	err = &Error{Op: "GetArtifact", RPCID: "v9rmvd", Err: errors.New("boom")}
+	err = fmt.Errorf("wait for artifact: %w", err)
+	wrapError(&err, "WaitForArtifact", "nb1")
+	var e *Error
+	if !errors.As(err, &e) || e.Op != "GetArtifact" || e.NotebookID != "nb1" {
+		t.Errorf("wrapped = %+v", e)
+	}
+	if err.Error() != "wait for artifact: boom" {
+		t.Errorf("message = %q", err.Error())
+	}
+
+	// Errors with no server failure match no sentinel.
+	err = errors.New("project ID required")
+	wrapError(&err, "GetProject", "")
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("%v matches a sentinel", err)
+	}
+}
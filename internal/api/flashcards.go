@@ -0,0 +1,84 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Flashcard is a single front/back card.
+type Flashcard struct {
+	Front string `json:"front"`
+	Back  string `json:"back"`
+}
+
+// Flashcards is a decoded flashcard artifact.
+type Flashcards struct {
+	*Artifact
+	Cards []Flashcard
+}
+
+// GetFlashcards fetches a flashcard artifact and decodes its cards.
+func (c *Client) GetFlashcards(projectID, artifactID string) (_ *Flashcards, err error) {
+	defer wrapError(&err, "GetFlashcards", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	data, err := c.getArtifactData(projectID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := decodeFlashcards(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode flashcards %s: %w", artifactID, err)
+	}
+	return f, nil
+}
+
+// decodeFlashcards finds the cards in a flashcard artifact. They are
+// carried as a JSON document inside the entry holding a "flashcards" (or
+// "cards") list whose items use "f"/"b" or "front"/"back" keys.
+func decodeFlashcards(data []interface{}) (*Flashcards, error) {
+	entry := artifactEntry(data)
+	artifact := parseArtifact(entry)
+	if artifact == nil {
+		return nil, fmt.Errorf("no artifact in response")
+	}
+	for _, doc := range artifactJSONDocs(entry[1:]) {
+		if cards := flashcardsFromJSON(doc); len(cards) > 0 {
+			return &Flashcards{Artifact: artifact, Cards: cards}, nil
+		}
+	}
+	return nil, fmt.Errorf("no flashcard data found")
+}
+
+func flashcardsFromJSON(doc map[string]interface{}) []Flashcard {
+	list, ok := doc["flashcards"].([]interface{})
+	if !ok {
+		list, _ = doc["cards"].([]interface{})
+	}
+	var cards []Flashcard
+	for _, item := range list {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		card := Flashcard{
+			Front: strings.TrimSpace(firstString(obj, "f", "front")),
+			Back:  strings.TrimSpace(firstString(obj, "b", "back")),
+		}
+		if card.Front != "" || card.Back != "" {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// firstString returns the first non-empty string value in obj among keys.
+func firstString(obj map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := obj[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
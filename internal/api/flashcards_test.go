@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeFlashcards(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Flashcard
+		wantErr bool
+	}{
+		{
+			name:  "short keys",
+			input: `[["fc1", "Cards", 6, [], 2, "{\"flashcards\":[{\"f\":\"What is Go?\",\"b\":\"A language\"},{\"f\":\" Who? \",\"b\":\"Gophers\"}]}"]]`,
+			want:  []Flashcard{{Front: "What is Go?", Back: "A language"}, {Front: "Who?", Back: "Gophers"}},
+		},
+		{
+			name:  "long keys nested",
+			input: `[["fc1", "Cards", 6, [], 2, [null, ["{\"cards\":[{\"front\":\"Q\",\"back\":\"A\"},{}]}"]]]]`,
+			want:  []Flashcard{{Front: "Q", Back: "A"}},
+		},
+		{
+			name:    "no cards",
+			input:   `[["fc1", "Cards", 6, [], 2, "{\"other\":1}"]]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []interface{}
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatal(err)
+			}
+			got, err := decodeFlashcards(data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeFlashcards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got.Cards); diff != "" {
+				t.Errorf("decodeFlashcards() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+)
+
+// Guidebook operations
+
+// GetGuidebook returns the guidebook with the given ID.
+func (c *Client) GetGuidebook(guidebookID string) (_ *pb.Guidebook, err error) {
+	defer wrapError(&err, "GetGuidebook", "")
+	if err := validateIDs("guidebook", guidebookID); err != nil {
+		return nil, err
+	}
+	req := &pb.GetGuidebookRequest{GuidebookId: guidebookID}
+	ctx := context.Background()
+	guidebook, err := c.guidebooksService.GetGuidebook(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("get guidebook: %w", err)
+	}
+	return guidebook, nil
+}
+
+// GetGuidebookDetails returns a guidebook with its sections and reader
+// analytics.
+func (c *Client) GetGuidebookDetails(guidebookID string) (_ *pb.GuidebookDetails, err error) {
+	defer wrapError(&err, "GetGuidebookDetails", "")
+	if err := validateIDs("guidebook", guidebookID); err != nil {
+		return nil, err
+	}
+	req := &pb.GetGuidebookDetailsRequest{GuidebookId: guidebookID}
+	ctx := context.Background()
+	details, err := c.guidebooksService.GetGuidebookDetails(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("get guidebook details: %w", err)
+	}
+	return details, nil
+}
+
+// PublishGuidebookOptions controls how a guidebook is published.
+type PublishGuidebookOptions struct {
+	// Public makes the guidebook visible to anyone with its link.
+	Public bool
+	// Tags label the guidebook in listings.
+	Tags []string
+}
+
+// PublishGuidebook publishes a guidebook and returns it with its public
+// URL.
+func (c *Client) PublishGuidebook(guidebookID string, opts PublishGuidebookOptions) (_ *pb.PublishGuidebookResponse, err error) {
+	defer wrapError(&err, "PublishGuidebook", "")
+	if err := validateIDs("guidebook", guidebookID); err != nil {
+		return nil, err
+	}
+	req := &pb.PublishGuidebookRequest{
+		GuidebookId: guidebookID,
+		Settings:    &pb.PublishSettings{IsPublic: opts.Public, Tags: opts.Tags},
+	}
+	ctx := context.Background()
+	resp, err := c.guidebooksService.PublishGuidebook(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("publish guidebook: %w", err)
+	}
+	return resp, nil
+}
+
+// CreateGuidebookFromNotebook publishes a notebook as a guidebook, as the
+// web UI's publish flow does: the guidebook shares the notebook's ID, so
+// the notebook is checked, published under that ID, and the resulting
+// guidebook fetched if the publish response does not include it.
+func (c *Client) CreateGuidebookFromNotebook(projectID string, opts PublishGuidebookOptions) (_ *pb.PublishGuidebookResponse, err error) {
+	defer wrapError(&err, "CreateGuidebookFromNotebook", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	project, err := c.GetProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("create guidebook: %w", err)
+	}
+	if len(project.GetSources()) == 0 {
+		return nil, fmt.Errorf("create guidebook: notebook has no sources")
+	}
+
+	resp, err := c.PublishGuidebook(projectID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("create guidebook: %w", err)
+	}
+	if resp.GetGuidebook().GetGuidebookId() == "" {
+		guidebook, err := c.GetGuidebook(projectID)
+		if err != nil {
+			return nil, fmt.Errorf("create guidebook: published but %w", err)
+		}
+		resp.Guidebook = guidebook
+	}
+	if resp.Guidebook.GetTitle() == "" {
+		resp.Guidebook.Title = project.GetTitle()
+	}
+	return resp, nil
+}
+
+// AskGuidebook asks a published guidebook a question. Guidebooks answer
+// from their own sources, so no access to the underlying notebook is
+// needed.
+func (c *Client) AskGuidebook(guidebookID, question string) (_ *pb.GuidebookGenerateAnswerResponse, err error) {
+	defer wrapError(&err, "AskGuidebook", "")
+	return c.AskGuidebookStream(guidebookID, question, nil)
+}
+
+// AskGuidebookStream is like AskGuidebook but also passes the answer to fn
+// as it arrives; fn returns false to stop early. The endpoint currently
+// sends the whole answer in one frame, so fn sees a single chunk.
+func (c *Client) AskGuidebookStream(guidebookID, question string, fn func(chunk string) bool) (_ *pb.GuidebookGenerateAnswerResponse, err error) {
+	defer wrapError(&err, "AskGuidebookStream", "")
+	if err := validateIDs("guidebook", guidebookID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(question) == "" {
+		return nil, fmt.Errorf("question required")
+	}
+	req := &pb.GuidebookGenerateAnswerRequest{
+		GuidebookId: guidebookID,
+		Question:    question,
+		Settings:    &pb.GenerateAnswerSettings{IncludeSources: true},
+	}
+	ctx := context.Background()
+	resp, err := c.guidebooksService.GuidebookGenerateAnswer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("ask guidebook: %w", err)
+	}
+	if fn != nil && resp.GetAnswer() != "" {
+		fn(resp.GetAnswer())
+	}
+	return resp, nil
+}
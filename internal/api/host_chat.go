@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrHostChatClosed is returned by HostChat methods after Close.
+var ErrHostChatClosed = errors.New("host chat closed")
+
+// HostChatTurn is a single question put to the hosts and their answer.
+type HostChatTurn struct {
+	Question string
+	Answer   string
+	AskedAt  time.Time
+}
+
+// HostChat is a chat with a notebook in which the answers come in the
+// voice of its audio overview's hosts.
+//
+// It is not NotebookLM's interactive audio mode, whose RPCs have not been
+// captured: it asks the notebook's grounded chat, with a prompt that
+// frames each question as put to the hosts and replays the earlier turns
+// so follow-ups keep their context. Nothing is injected into the audio and
+// the answers are text.
+type HostChat struct {
+	ProjectID string
+
+	client *Client
+
+	mu      sync.Mutex
+	history []HostChatTurn
+	closed  bool
+}
+
+// NewHostChat starts a chat with the hosts of projectID's audio overview.
+// It makes no request.
+func (c *Client) NewHostChat(projectID string) (_ *HostChat, err error) {
+	defer wrapError(&err, "NewHostChat", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	return &HostChat{ProjectID: projectID, client: c}, nil
+}
+
+// Ask answers question in the voice of the hosts. Canceling ctx aborts
+// the request.
+func (s *HostChat) Ask(ctx context.Context, question string) (string, error) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return "", fmt.Errorf("question required")
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return "", ErrHostChatClosed
+	}
+	prompt := buildHostChatPrompt(s.history, question)
+	s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	resp, err := s.client.withContext(ctx).GenerateFreeFormStreamed(s.ProjectID, prompt, nil)
+	if err != nil {
+		return "", fmt.Errorf("ask hosts: %w", err)
+	}
+	answer := strings.TrimSpace(resp.GetChunk())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return "", ErrHostChatClosed
+	}
+	s.history = append(s.history, HostChatTurn{Question: question, Answer: answer, AskedAt: time.Now()})
+	return answer, nil
+}
+
+// History returns the questions asked so far in the chat.
+func (s *HostChat) History() []HostChatTurn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]HostChatTurn(nil), s.history...)
+}
+
+// Close ends the chat; later calls to Ask fail with ErrHostChatClosed. It
+// is safe to call more than once.
+func (s *HostChat) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// maxHostChatTurns bounds how many earlier turns are replayed into each
+// question's prompt.
+const maxHostChatTurns = 5
+
+// buildHostChatPrompt frames a listener question the way the hosts of an
+// audio overview would field it mid-conversation.
+func buildHostChatPrompt(history []HostChatTurn, question string) string {
+	var b strings.Builder
+	b.WriteString("You are the hosts of this notebook's audio overview. A listener has joined the conversation. ")
+	b.WriteString("Answer their question conversationally, grounded in the notebook's sources.\n")
+	if len(history) > maxHostChatTurns {
+		history = history[len(history)-maxHostChatTurns:]
+	}
+	for _, turn := range history {
+		fmt.Fprintf(&b, "\nListener: %s\nHosts: %s\n", turn.Question, turn.Answer)
+	}
+	fmt.Fprintf(&b, "\nListener: %s\nHosts:", question)
+	return b.String()
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildHostChatPrompt(t *testing.T) {
+	var history []HostChatTurn
+	for i := 0; i < maxHostChatTurns+2; i++ {
+		history = append(history, HostChatTurn{
+			Question: fmt.Sprintf("question %d", i),
+			Answer:   fmt.Sprintf("answer %d", i),
+		})
+	}
+
+	tests := []struct {
+		name        string
+		history     []HostChatTurn
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "first question",
+			contains: []string{"Listener: what is this about?\nHosts:"},
+		},
+		{
+			name:     "follow-up carries earlier turns",
+			history:  history[:1],
+			contains: []string{"Listener: question 0\nHosts: answer 0", "Listener: what is this about?"},
+		},
+		{
+			name:        "history is bounded",
+			history:     history,
+			contains:    []string{"question 2", fmt.Sprintf("question %d", len(history)-1)},
+			notContains: []string{"question 0", "question 1\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildHostChatPrompt(tt.history, "what is this about?")
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("prompt missing %q:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("prompt unexpectedly contains %q:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHostChatClose(t *testing.T) {
+	s := &HostChat{ProjectID: "nb1"}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+	if _, err := s.Ask(context.Background(), "hello?"); !errors.Is(err, ErrHostChatClosed) {
+		t.Errorf("Ask() after Close error = %v, want %v", err, ErrHostChatClosed)
+	}
+}
+
+func TestHostChatAskCanceled(t *testing.T) {
+	t.Setenv("NLM_SKIP_SOURCES", "true")
+	sent := make(chan struct{})
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		close(sent)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat, err := c.NewHostChat("nb1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-sent
+		cancel()
+	}()
+	done := make(chan error, 1)
+	go func() {
+		_, err := chat.Ask(ctx, "what is this about?")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Ask() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Ask() did not return after its context was canceled")
+	}
+	if len(chat.History()) != 0 {
+		t.Errorf("History() = %v, want no turns", chat.History())
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+)
+
+// prefetchConcurrency is how many contents Prefetch fetches at once.
+const prefetchConcurrency = 4
+
+// Project returns the notebook, with its sources' metadata but not their
+// text. It is fetched on the first call.
+func (n *NotebookHandle) Project(ctx context.Context) (*Notebook, error) {
+	n.mu.Lock()
+	p := n.project
+	n.mu.Unlock()
+	if p != nil {
+		return p, nil
+	}
+	p, err := n.c.GetProjectWithContext(ctx, n.ID)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	n.project = p
+	n.mu.Unlock()
+	return p, nil
+}
+
+// SourceText returns the full text of one of the notebook's sources. It
+// is fetched on the first call for the source.
+func (n *NotebookHandle) SourceText(ctx context.Context, sourceID string) (string, error) {
+	n.mu.Lock()
+	text, ok := n.sourceText[sourceID]
+	n.mu.Unlock()
+	if ok {
+		return text, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	text, err := n.c.withContext(ctx).GetSourceText(sourceID)
+	if err != nil {
+		return "", err
+	}
+	n.mu.Lock()
+	if n.sourceText == nil {
+		n.sourceText = make(map[string]string)
+	}
+	n.sourceText[sourceID] = text
+	n.mu.Unlock()
+	return text, nil
+}
+
+// ArtifactContent returns the body of one of the notebook's artifacts as
+// Markdown. It is fetched on the first call for the artifact.
+func (n *NotebookHandle) ArtifactContent(ctx context.Context, artifactID string) (*ArtifactContent, error) {
+	n.mu.Lock()
+	content, ok := n.artifacts[artifactID]
+	n.mu.Unlock()
+	if ok {
+		return content, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content, err := n.c.withContext(ctx).GetArtifactContent(n.ID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	if n.artifacts == nil {
+		n.artifacts = make(map[string]*ArtifactContent)
+	}
+	n.artifacts[artifactID] = content
+	n.mu.Unlock()
+	return content, nil
+}
+
+// Prefetch fetches the notebook, the text of all its sources and the
+// bodies of its ready notes and reports, several at once, so that later
+// accesses make no requests. Contents that fail are reported together;
+// the rest are kept. Canceling ctx aborts the fetches in flight.
+func (n *NotebookHandle) Prefetch(ctx context.Context) error {
+	p, err := n.Project(ctx)
+	if err != nil {
+		return err
+	}
+	artifacts, err := n.c.withContext(ctx).ListArtifacts(n.ID)
+	if err != nil {
+		return fmt.Errorf("list artifacts: %w", err)
+	}
+
+	var fetches []func() error
+	for _, src := range p.GetSources() {
+		id := src.GetSourceId().GetSourceId()
+		fetches = append(fetches, func() error {
+			_, err := n.SourceText(ctx, id)
+			return err
+		})
+	}
+	for _, a := range artifacts {
+		if a.State != pb.ArtifactState_ARTIFACT_STATE_READY ||
+			a.Type != pb.ArtifactType_ARTIFACT_TYPE_NOTE && a.Type != pb.ArtifactType_ARTIFACT_TYPE_REPORT {
+			continue
+		}
+		id := a.ID
+		fetches = append(fetches, func() error {
+			_, err := n.ArtifactContent(ctx, id)
+			return err
+		})
+	}
+
+	errs := make([]error, len(fetches))
+	slots := make(chan struct{}, prefetchConcurrency)
+	var wg sync.WaitGroup
+	for i, fetch := range fetches {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = fetch()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rpcServer answers RPCs with canned data by RPC ID, and counts them.
+type rpcServer struct {
+	data func(id, freq string) string
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (s *rpcServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := req.URL.Query().Get("rpcids")
+	req.ParseForm()
+	data := s.data(id, req.PostForm.Get("f.req"))
+	s.mu.Lock()
+	s.calls[id]++
+	s.mu.Unlock()
+	quoted, _ := json.Marshal(data)
+	body := fmt.Sprintf(")]}'\n\n[[\"wrb.fr\",%q,%s,null,null,null,\"generic\"]]", id, quoted)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+}
+
+func TestNotebookHandleHydration(t *testing.T) {
+	srv := &rpcServer{calls: map[string]int{}, data: func(id, freq string) string {
+		switch id {
+		case "rLM1Ne":
+			return `["Research",[[["s1"],"A"],[["s2"],"B"]],"nb1"]`
+		case "gArtLc":
+			return `[[["art1",3,2,["s1"]],["art2",3,1]]]`
+		case "hizoJc":
+			src := "s1"
+			if strings.Contains(freq, `s2`) {
+				src = "s2"
+			}
+			return `[[["` + src + `"]],null,null,[[[0,9,[[[0,9,["text of ` + src + `"]]]]]]]]`
+		case "BnLyuf":
+			return `["art1",3,2,["s1"],"Body text"]`
+		}
+		return `[]`
+	}}
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: srv}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// Contents are fetched once, on first access.
+	n := c.Notebook("nb1")
+	for i := 0; i < 2; i++ {
+		text, err := n.SourceText(ctx, "s2")
+		if err != nil || text != "text of s2" {
+			t.Fatalf("SourceText(s2) = %q, %v", text, err)
+		}
+	}
+	if srv.calls["hizoJc"] != 1 || srv.calls["rLM1Ne"] != 0 {
+		t.Errorf("calls after SourceText = %v, want one hizoJc", srv.calls)
+	}
+
+	// Prefetch fetches everything up front.
+	srv.calls = map[string]int{}
+	n = c.Notebook("nb1")
+	if err := n.Prefetch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"rLM1Ne": 1, "gArtLc": 1, "hizoJc": 2, "BnLyuf": 1}
+	if fmt.Sprint(srv.calls) != fmt.Sprint(want) {
+		t.Errorf("calls by Prefetch = %v, want %v", srv.calls, want)
+	}
+	if _, err := n.Project(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if text, _ := n.SourceText(ctx, "s1"); text != "text of s1" {
+		t.Errorf("SourceText(s1) = %q", text)
+	}
+	if content, _ := n.ArtifactContent(ctx, "art1"); content == nil || content.Markdown != "Body text\n" {
+		t.Errorf("ArtifactContent(art1) = %+v", content)
+	}
+	if fmt.Sprint(srv.calls) != fmt.Sprint(want) {
+		t.Errorf("calls after Prefetch = %v, want none more", srv.calls)
+	}
+}
+
+func TestPrefetchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := &rpcServer{calls: map[string]int{}, data: func(id, freq string) string {
+		switch id {
+		case "rLM1Ne":
+			return `["Research",[[["s1"],"A"],[["s2"],"B"]],"nb1"]`
+		case "gArtLc":
+			return `[[]]`
+		}
+		return `[]`
+	}}
+	var once sync.Once
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("rpcids") != "hizoJc" {
+			return srv.RoundTrip(req)
+		}
+		// Source text never arrives; cancel while it is in flight.
+		once.Do(cancel)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- c.Notebook("nb1").Prefetch(ctx) }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Prefetch() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Prefetch() did not return after its context was canceled")
+	}
+}
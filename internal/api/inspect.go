@@ -0,0 +1,231 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/richtext"
+)
+
+// InspectField is one value in the positional structure of an artifact.
+type InspectField struct {
+	Path  string // index path such as [3][0] or [5].quiz[0].question
+	Depth int
+	Kind  string // string, number, bool, null, array or object
+	Value string // short rendering of the value
+	Label string // inferred meaning, if any
+}
+
+// ArtifactInspection is the raw form of an artifact with inferred field
+// labels. It is meant for working out the layout of artifact types that
+// the package does not decode yet.
+type ArtifactInspection struct {
+	*Artifact
+	Entry  []interface{}
+	Fields []InspectField
+}
+
+// KnownType reports whether the artifact type is one this package knows.
+func (a *ArtifactInspection) KnownType() bool {
+	_, ok := pb.ArtifactType_name[int32(a.Type)]
+	return ok && a.Type != pb.ArtifactType_ARTIFACT_TYPE_UNSPECIFIED
+}
+
+// InspectArtifact fetches an artifact and labels its positional fields.
+func (c *Client) InspectArtifact(projectID, artifactID string) (_ *ArtifactInspection, err error) {
+	defer wrapError(&err, "InspectArtifact", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	data, err := c.getArtifactData(projectID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	inspection := inspectArtifact(data)
+	if inspection == nil {
+		return nil, fmt.Errorf("failed to parse artifact %s from response", artifactID)
+	}
+	return inspection, nil
+}
+
+// inspectValueWidth bounds the rendering of string values.
+const inspectValueWidth = 60
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	urlPattern  = regexp.MustCompile(`^https?://`)
+)
+
+func inspectArtifact(data []interface{}) *ArtifactInspection {
+	entry := artifactEntry(data)
+	artifact := parseArtifact(entry)
+	if artifact == nil {
+		return nil
+	}
+	in := &inspector{
+		artifact: artifact,
+		sources:  make(map[string]bool),
+	}
+	for _, id := range artifact.SourceIDs {
+		in.sources[id] = true
+	}
+	// Mirror the two layouts parseArtifact understands.
+	in.typeIdx, in.stateIdx = 1, 2
+	if _, ok := field(entry, 1).(string); ok {
+		in.typeIdx, in.stateIdx = 2, 4
+	}
+	for i, v := range entry {
+		in.walk(fmt.Sprintf("[%d]", i), 1, v, in.topLabel(i, v))
+	}
+	return &ArtifactInspection{Artifact: artifact, Entry: entry, Fields: in.fields}
+}
+
+type inspector struct {
+	artifact          *Artifact
+	sources           map[string]bool
+	typeIdx, stateIdx int
+	fields            []InspectField
+}
+
+// topLabel names the top-level fields whose position is known.
+func (in *inspector) topLabel(i int, v interface{}) string {
+	switch {
+	case i == 0:
+		return "artifact id"
+	case i == 1 && in.typeIdx == 2:
+		return "title"
+	case i == in.typeIdx:
+		if _, ok := v.(float64); ok {
+			return "type: " + enumValueName(pb.ArtifactType_name, v, "ARTIFACT_TYPE_")
+		}
+	case i == in.stateIdx:
+		if _, ok := v.(float64); ok {
+			return "state: " + enumValueName(pb.ArtifactState_name, v, "ARTIFACT_STATE_")
+		}
+	case i == 3:
+		if _, ok := v.([]interface{}); ok {
+			return "sources"
+		}
+	}
+	return ""
+}
+
+func enumValueName(names map[int32]string, v interface{}, prefix string) string {
+	n := int32(v.(float64))
+	if name, ok := names[n]; ok {
+		return enumName(name, prefix)
+	}
+	return fmt.Sprintf("%d (unknown)", n)
+}
+
+func (in *inspector) add(path string, depth int, kind, value, label string) {
+	in.fields = append(in.fields, InspectField{Path: path, Depth: depth, Kind: kind, Value: value, Label: label})
+}
+
+func (in *inspector) walk(path string, depth int, v interface{}, label string) {
+	switch v := v.(type) {
+	case nil:
+		in.add(path, depth, "null", "null", label)
+	case bool:
+		in.add(path, depth, "bool", strconv.FormatBool(v), label)
+	case float64:
+		in.add(path, depth, "number", formatNumber(v), label)
+	case string:
+		in.walkString(path, depth, v, label)
+	case []interface{}:
+		if ts, ok := parseTimestamp(v); ok {
+			// Timestamps are leaves; their halves mean nothing alone.
+			in.add(path, depth, "array", fmt.Sprintf("[%s, %s]", formatNumber(v[0]), formatNumber(v[1])), joinLabels(label, "timestamp "+ts.UTC().Format(time.RFC3339)))
+			return
+		}
+		in.add(path, depth, "array", fmt.Sprintf("[%d items]", len(v)), label)
+		for i, item := range v {
+			in.walk(fmt.Sprintf("%s[%d]", path, i), depth+1, item, "")
+		}
+	case map[string]interface{}:
+		in.add(path, depth, "object", fmt.Sprintf("{%d keys}", len(v)), label)
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			in.walk(path+"."+k, depth+1, v[k], "")
+		}
+	default:
+		in.add(path, depth, fmt.Sprintf("%T", v), fmt.Sprint(v), label)
+	}
+}
+
+func (in *inspector) walkString(path string, depth int, s, label string) {
+	value := strconv.Quote(shortValue(s, inspectValueWidth))
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var doc interface{}
+		if json.Unmarshal([]byte(trimmed), &doc) == nil {
+			in.add(path, depth, "string", value, joinLabels(label, fmt.Sprintf("embedded JSON (%d chars)", len(s))))
+			switch doc := doc.(type) {
+			case map[string]interface{}:
+				keys := make([]string, 0, len(doc))
+				for k := range doc {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for _, k := range keys {
+					in.walk(path+"."+k, depth+1, doc[k], "")
+				}
+			case []interface{}:
+				for i, item := range doc {
+					in.walk(fmt.Sprintf("%s[%d]", path, i), depth+1, item, "")
+				}
+			}
+			return
+		}
+	}
+	if label == "" {
+		switch {
+		case path == "[0]":
+		case s == in.artifact.Title && s != "":
+			label = "title"
+		case in.sources[s]:
+			label = "source id"
+		case richtext.IsHTML(s):
+			label = fmt.Sprintf("html body (%d chars)", len(s))
+		case urlPattern.MatchString(s):
+			label = "url"
+		case uuidPattern.MatchString(s):
+			label = "id"
+		case len(s) > inspectValueWidth:
+			label = fmt.Sprintf("text (%d chars)", len(s))
+		}
+	}
+	in.add(path, depth, "string", value, label)
+}
+
+func formatNumber(v interface{}) string {
+	return strconv.FormatFloat(v.(float64), 'f', -1, 64)
+}
+
+func joinLabels(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + ", " + b
+}
+
+// shortValue flattens s onto one line and shortens it to at most n runes,
+// marking the cut with an ellipsis.
+func shortValue(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInspectArtifact(t *testing.T) {
+	input := `[["art1", "Slides", 42, [["src1"]], 2, [1700000000, 0], "{\"slides\":[{\"title\":\"Intro\"}]}", "https://example.com/x", null]]`
+	var data []interface{}
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatal(err)
+	}
+	got := inspectArtifact(data)
+	if got == nil {
+		t.Fatal("inspectArtifact() = nil")
+	}
+	if got.KnownType() {
+		t.Errorf("KnownType() = true for type 42")
+	}
+
+	type row struct{ Path, Kind, Value, Label string }
+	var rows []row
+	for _, f := range got.Fields {
+		rows = append(rows, row{f.Path, f.Kind, f.Value, f.Label})
+	}
+	want := []row{
+		{"[0]", "string", `"art1"`, "artifact id"},
+		{"[1]", "string", `"Slides"`, "title"},
+		{"[2]", "number", "42", "type: 42 (unknown)"},
+		{"[3]", "array", "[1 items]", "sources"},
+		{"[3][0]", "array", "[1 items]", ""},
+		{"[3][0][0]", "string", `"src1"`, "source id"},
+		{"[4]", "number", "2", "state: ready"},
+		{"[5]", "array", "[1700000000, 0]", "timestamp 2023-11-14T22:13:20Z"},
+		{"[6]", "string", `"{\"slides\":[{\"title\":\"Intro\"}]}"`, "embedded JSON (30 chars)"},
+		{"[6].slides", "array", "[1 items]", ""},
+		{"[6].slides[0]", "object", "{1 keys}", ""},
+		{"[6].slides[0].title", "string", `"Intro"`, ""},
+		{"[7]", "string", `"https://example.com/x"`, "url"},
+		{"[8]", "null", "null", ""},
+	}
+	if diff := cmp.Diff(want, rows); diff != "" {
+		t.Errorf("inspectArtifact() fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestShortValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"two\nlines", 10, "two lines"},
+		{"héllo wörld", 6, "héllo…"},
+	}
+	for _, tt := range tests {
+		if got := shortValue(tt.in, tt.n); got != tt.want {
+			t.Errorf("shortValue(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+)
+
+// ItemError reports a listed item that is in a failed state, such as a
+// source NotebookLM could not process. Iterators yield it alongside the
+// item and carry on, so one bad item does not hide the rest.
+type ItemError struct {
+	Kind  string // "source" or "artifact"
+	ID    string
+	Title string
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s %s (%q) failed", e.Kind, e.ID, e.Title)
+}
+
+// page fetches the page of a list after token, returning the items and
+// the token of the next page, or "" if it is the last.
+type page[T any] func(ctx context.Context, token string) ([]T, string, error)
+
+// paginate iterates over the items of every page, fetching each with ctx
+// only when the one before it has been consumed. A failed fetch is
+// yielded as the last value.
+func paginate[T any](ctx context.Context, fetch page[T], check func(T) error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		token := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, next, err := fetch(ctx, token)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, it := range items {
+				var err error
+				if check != nil {
+					err = check(it)
+				}
+				if !yield(it, err) {
+					return
+				}
+			}
+			if next == "" || next == token {
+				return
+			}
+			token = next
+		}
+	}
+}
+
+// whole adapts a call returning a complete list to a page.
+func whole[T any](list func(ctx context.Context) ([]T, error)) page[T] {
+	return func(ctx context.Context, token string) ([]T, string, error) {
+		items, err := list(ctx)
+		return items, "", err
+	}
+}
+
+// Notebooks iterates over the notebooks the user has viewed recently:
+//
+//	for nb, err := range c.Notebooks(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(nb.GetTitle())
+//	}
+func (c *Client) Notebooks(ctx context.Context) iter.Seq2[*Notebook, error] {
+	return paginate(ctx, func(ctx context.Context, token string) ([]*Notebook, string, error) {
+		resp, err := c.withContext(ctx).orchestrationService.ListRecentlyViewedProjects(ctx, &pb.ListRecentlyViewedProjectsRequest{})
+		if err != nil {
+			return nil, "", fmt.Errorf("list projects: %w", err)
+		}
+		return resp.Projects, "", nil
+	}, nil)
+}
+
+// NotebookHandle is a notebook named by ID, whose contents are listed on
+// demand. Its heavy contents, the text of sources and the bodies of
+// artifacts, are fetched on first access and kept; see Prefetch. A
+// handle may be used from several goroutines at once.
+type NotebookHandle struct {
+	c  *Client
+	ID string
+
+	mu         sync.Mutex
+	project    *Notebook
+	sourceText map[string]string
+	artifacts  map[string]*ArtifactContent
+}
+
+// Notebook returns a handle for the notebook with the given ID. No
+// request is made until its contents are listed.
+func (c *Client) Notebook(id string) *NotebookHandle {
+	return &NotebookHandle{c: c, ID: id}
+}
+
+// Sources iterates over the notebook's sources. A source NotebookLM
+// failed to process is yielded with an *ItemError.
+func (n *NotebookHandle) Sources(ctx context.Context) iter.Seq2[*pb.Source, error] {
+	return paginate(ctx, func(ctx context.Context, token string) ([]*pb.Source, string, error) {
+		p, err := n.c.GetProjectWithContext(ctx, n.ID)
+		if err != nil {
+			return nil, "", err
+		}
+		return p.GetSources(), "", nil
+	}, func(src *pb.Source) error {
+		if src.GetMetadata().GetStatus() == pb.SourceSettings_SOURCE_STATUS_ERROR ||
+			src.GetSettings().GetStatus() == pb.SourceSettings_SOURCE_STATUS_ERROR {
+			return &ItemError{Kind: "source", ID: src.GetSourceId().GetSourceId(), Title: src.GetTitle()}
+		}
+		return nil
+	})
+}
+
+// Notes iterates over the notebook's notes.
+func (n *NotebookHandle) Notes(ctx context.Context) iter.Seq2[*Note, error] {
+	return paginate(ctx, whole(func(ctx context.Context) ([]*Note, error) { return n.c.withContext(ctx).GetNotes(n.ID) }), nil)
+}
+
+// Artifacts iterates over the notebook's artifacts. An artifact whose
+// generation failed is yielded with an *ItemError.
+func (n *NotebookHandle) Artifacts(ctx context.Context) iter.Seq2[*Artifact, error] {
+	return paginate(ctx, whole(func(ctx context.Context) ([]*Artifact, error) { return n.c.withContext(ctx).ListArtifacts(n.ID) }), func(a *Artifact) error {
+		if a.State == pb.ArtifactState_ARTIFACT_STATE_FAILED {
+			return &ItemError{Kind: "artifact", ID: a.ID, Title: a.Title}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"":   {[]int{1, 2}, "p2"},
+		"p2": {[]int{3, 4}, "p3"},
+		"p3": {[]int{5}, ""},
+	}
+	var fetched []string
+	fetch := func(ctx context.Context, token string) ([]int, string, error) {
+		fetched = append(fetched, token)
+		p := pages[token]
+		return p.items, p.next, nil
+	}
+	odd := errors.New("odd")
+	check := func(n int) error {
+		if n%2 == 1 {
+			return odd
+		}
+		return nil
+	}
+
+	var got []int
+	var errs int
+	for n, err := range paginate(context.Background(), fetch, check) {
+		got = append(got, n)
+		if errors.Is(err, odd) {
+			errs++
+		}
+	}
+	if len(got) != 5 || errs != 3 || len(fetched) != 3 {
+		t.Errorf("got %v with %d item errors from pages %q", got, errs, fetched)
+	}
+
+	// Stopping early fetches no more pages.
+	fetched = nil
+	for n := range paginate(context.Background(), fetch, nil) {
+		if n == 2 {
+			break
+		}
+	}
+	if len(fetched) != 1 {
+		t.Errorf("fetched pages %q after breaking on the first", fetched)
+	}
+}
+
+func TestPaginateErrors(t *testing.T) {
+	boom := errors.New("boom")
+	fetch := func(ctx context.Context, token string) ([]int, string, error) {
+		if token == "" {
+			return []int{1}, "p2", nil
+		}
+		return nil, "", boom
+	}
+	var got []error
+	for _, err := range paginate(context.Background(), fetch, nil) {
+		got = append(got, err)
+	}
+	if len(got) != 2 || got[0] != nil || !errors.Is(got[1], boom) {
+		t.Errorf("errors = %v, want [nil boom]", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range paginate(ctx, fetch, nil) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	}
+}
+
+func TestIteratorsCancelRequests(t *testing.T) {
+	for name, list := range map[string]func(*Client, context.Context) error{
+		"Notebooks": func(c *Client, ctx context.Context) error {
+			for _, err := range c.Notebooks(ctx) {
+				return err
+			}
+			return nil
+		},
+		"Sources": func(c *Client, ctx context.Context) error {
+			for _, err := range c.Notebook("nb1").Sources(ctx) {
+				return err
+			}
+			return nil
+		},
+		"Artifacts": func(c *Client, ctx context.Context) error {
+			for _, err := range c.Notebook("nb1").Artifacts(ctx) {
+				return err
+			}
+			return nil
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var once sync.Once
+			rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				once.Do(cancel)
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			})
+			c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}),
+				WithRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Millisecond}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			done := make(chan error, 1)
+			go func() { done <- list(c, ctx) }()
+			select {
+			case err := <-done:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("error = %v, want %v", err, context.Canceled)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("the request was not canceled with its context")
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// MindMapNode is a topic in a mind map.
+type MindMapNode struct {
+	Label    string         `json:"label"`
+	Children []*MindMapNode `json:"children,omitempty"`
+}
+
+// MindMap is a decoded mind-map artifact.
+type MindMap struct {
+	*Artifact
+	Root *MindMapNode
+}
+
+// GetMindMap fetches a mind-map artifact and decodes its node tree.
+func (c *Client) GetMindMap(projectID, artifactID string) (_ *MindMap, err error) {
+	defer wrapError(&err, "GetMindMap", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	data, err := c.getArtifactData(projectID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	m, err := decodeMindMap(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode mind map %s: %w", artifactID, err)
+	}
+	return m, nil
+}
+
+// decodeMindMap finds the node tree in a mind-map artifact. The tree is
+// carried as a JSON document inside the entry, either as nested
+// {"name", "children"} objects or as a flat {"nodes", "edges"} graph.
+func decodeMindMap(data []interface{}) (*MindMap, error) {
+	entry := artifactEntry(data)
+	artifact := parseArtifact(entry)
+	if artifact == nil {
+		return nil, fmt.Errorf("no artifact in response")
+	}
+
+	var root *MindMapNode
+	for _, doc := range artifactJSONDocs(entry[1:]) {
+		if root = mindMapFromJSON(doc); root != nil {
+			break
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no mind map data found")
+	}
+	if root.Label == "" {
+		root.Label = artifact.Title
+	}
+	return &MindMap{Artifact: artifact, Root: root}, nil
+}
+
+func mindMapFromJSON(doc map[string]interface{}) *MindMapNode {
+	if nodes, ok := doc["nodes"].([]interface{}); ok {
+		edges, _ := doc["edges"].([]interface{})
+		return mindMapFromGraph(nodes, edges)
+	}
+	return mindMapFromTree(doc)
+}
+
+// mindMapFromTree converts nested {"name", "children"} objects.
+func mindMapFromTree(doc map[string]interface{}) *MindMapNode {
+	node := &MindMapNode{Label: nodeLabel(doc)}
+	children, _ := doc["children"].([]interface{})
+	for _, c := range children {
+		if obj, ok := c.(map[string]interface{}); ok {
+			if child := mindMapFromTree(obj); child != nil {
+				node.Children = append(node.Children, child)
+			}
+		}
+	}
+	if node.Label == "" && len(node.Children) == 0 {
+		return nil
+	}
+	return node
+}
+
+// mindMapFromGraph converts a {"nodes": [{"id", "label"}], "edges":
+// [{"source", "target"}]} graph. The root is the first node that is never
+// an edge target.
+func mindMapFromGraph(nodes, edges []interface{}) *MindMapNode {
+	byID := make(map[string]*MindMapNode)
+	var order []string
+	for _, n := range nodes {
+		obj, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := fmt.Sprint(obj["id"])
+		if _, dup := byID[id]; dup {
+			continue
+		}
+		byID[id] = &MindMapNode{Label: nodeLabel(obj)}
+		order = append(order, id)
+	}
+
+	parentOf := make(map[string]string)
+	for _, e := range edges {
+		obj, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		from, to := fmt.Sprint(obj["source"]), fmt.Sprint(obj["target"])
+		parent, child := byID[from], byID[to]
+		if parent == nil || child == nil {
+			continue
+		}
+		// Each node keeps its first parent, and edges that would close a
+		// cycle are dropped, so the result is always a tree.
+		if _, ok := parentOf[to]; ok || isAncestor(parentOf, to, from) {
+			continue
+		}
+		parent.Children = append(parent.Children, child)
+		parentOf[to] = from
+	}
+
+	for _, id := range order {
+		if _, ok := parentOf[id]; !ok {
+			return byID[id]
+		}
+	}
+	return nil
+}
+
+// isAncestor reports whether a is id or one of its ancestors.
+func isAncestor(parentOf map[string]string, a, id string) bool {
+	for {
+		if id == a {
+			return true
+		}
+		next, ok := parentOf[id]
+		if !ok {
+			return false
+		}
+		id = next
+	}
+}
+
+func nodeLabel(obj map[string]interface{}) string {
+	return firstString(obj, "name", "label", "title", "text")
+}
+
+// walk calls fn for every node in depth-first order with its parent;
+// parent is nil for the root.
+func (m *MindMap) walk(fn func(node, parent *MindMapNode, depth int)) {
+	var visit func(n, parent *MindMapNode, depth int)
+	visit = func(n, parent *MindMapNode, depth int) {
+		fn(n, parent, depth)
+		for _, c := range n.Children {
+			visit(c, n, depth+1)
+		}
+	}
+	if m.Root != nil {
+		visit(m.Root, nil, 0)
+	}
+}
+
+// Mermaid renders the mind map as a Mermaid mindmap diagram.
+func (m *MindMap) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("mindmap\n")
+	n := 0
+	m.walk(func(node, parent *MindMapNode, depth int) {
+		label := strings.ReplaceAll(node.Label, `"`, "#quot;")
+		if parent == nil {
+			fmt.Fprintf(&b, "  root((\"%s\"))\n", label)
+			return
+		}
+		n++
+		fmt.Fprintf(&b, "%sn%d[\"%s\"]\n", strings.Repeat("  ", depth+1), n, label)
+	})
+	return b.String()
+}
+
+// DOT renders the mind map as a Graphviz digraph.
+func (m *MindMap) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph mindmap {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+	ids := make(map[*MindMapNode]int)
+	m.walk(func(node, parent *MindMapNode, depth int) {
+		id := len(ids)
+		ids[node] = id
+		fmt.Fprintf(&b, "  n%d [label=%s];\n", id, dotQuote(node.Label))
+		if parent != nil {
+			fmt.Fprintf(&b, "  n%d -> n%d;\n", ids[parent], id)
+		}
+	})
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+func toOPMLOutline(n *MindMapNode) opmlOutline {
+	o := opmlOutline{Text: n.Label}
+	for _, c := range n.Children {
+		o.Outlines = append(o.Outlines, toOPMLOutline(c))
+	}
+	return o
+}
+
+// OPML renders the mind map as an OPML 2.0 outline, which most
+// mind-mapping and outlining tools can import.
+func (m *MindMap) OPML() (string, error) {
+	doc := struct {
+		XMLName xml.Name      `xml:"opml"`
+		Version string        `xml:"version,attr"`
+		Title   string        `xml:"head>title"`
+		Body    []opmlOutline `xml:"body>outline"`
+	}{Version: "2.0"}
+	if m.Root != nil {
+		doc.Title = m.Root.Label
+		doc.Body = []opmlOutline{toOPMLOutline(m.Root)}
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode opml: %w", err)
+	}
+	return xml.Header + string(out) + "\n", nil
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeMindMap(t *testing.T) {
+	want := &MindMapNode{
+		Label: "Go",
+		Children: []*MindMapNode{
+			{Label: "Types", Children: []*MindMapNode{{Label: "Structs"}}},
+			{Label: "Concurrency"},
+		},
+	}
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "tree",
+			input: `[["mm1", "Go", 5, [], 2, null, "{\"name\":\"Go\",\"children\":[{\"name\":\"Types\",\"children\":[{\"name\":\"Structs\"}]},{\"name\":\"Concurrency\"}]}"]]`,
+		},
+		{
+			name:  "graph",
+			input: `[["mm1", "Go", 5, [], 2, [null, "{\"nodes\":[{\"id\":1,\"label\":\"Types\"},{\"id\":0,\"label\":\"Go\"},{\"id\":2,\"label\":\"Structs\"},{\"id\":3,\"label\":\"Concurrency\"}],\"edges\":[{\"source\":0,\"target\":1},{\"source\":1,\"target\":2},{\"source\":0,\"target\":3},{\"source\":2,\"target\":0}]}"]]]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []interface{}
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatal(err)
+			}
+			m, err := decodeMindMap(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, m.Root); diff != "" {
+				t.Errorf("decodeMindMap() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeMindMapNoData(t *testing.T) {
+	var data []interface{}
+	json.Unmarshal([]byte(`[["mm1", "Go", 5, [], 2, "not a map"]]`), &data)
+	if _, err := decodeMindMap(data); err == nil {
+		t.Error("decodeMindMap() succeeded without mind map data")
+	}
+}
+
+func testMindMap() *MindMap {
+	return &MindMap{Root: &MindMapNode{
+		Label: `Go "basics"`,
+		Children: []*MindMapNode{
+			{Label: "Types", Children: []*MindMapNode{{Label: "Structs & interfaces"}}},
+			{Label: "Concurrency"},
+		},
+	}}
+}
+
+func TestMindMapMermaid(t *testing.T) {
+	want := `mindmap
+  root(("Go #quot;basics#quot;"))
+    n1["Types"]
+      n2["Structs & interfaces"]
+    n3["Concurrency"]
+`
+	if got := testMindMap().Mermaid(); got != want {
+		t.Errorf("Mermaid() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMindMapDOT(t *testing.T) {
+	want := `digraph mindmap {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  n0 [label="Go \"basics\""];
+  n1 [label="Types"];
+  n0 -> n1;
+  n2 [label="Structs & interfaces"];
+  n1 -> n2;
+  n3 [label="Concurrency"];
+  n0 -> n3;
+}
+`
+	if got := testMindMap().DOT(); got != want {
+		t.Errorf("DOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMindMapOPML(t *testing.T) {
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>Go &#34;basics&#34;</title>
+  </head>
+  <body>
+    <outline text="Go &#34;basics&#34;">
+      <outline text="Types">
+        <outline text="Structs &amp; interfaces"></outline>
+      </outline>
+      <outline text="Concurrency"></outline>
+    </outline>
+  </body>
+</opml>
+`
+	got, err := testMindMap().OPML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("OPML() =\n%s\nwant\n%s", got, want)
+	}
+}
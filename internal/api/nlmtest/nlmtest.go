@@ -0,0 +1,1054 @@
+// Package nlmtest provides an in-memory implementation of
+// api.NotebookService for testing code that uses the NotebookLM API
+// without a network or an account.
+//
+// A Fake keeps notebooks, sources, notes, artifacts, overviews and
+// sharing state in memory. Generation happens at once: artifacts and
+// overviews are ready as soon as they are created, so the Wait methods
+// return immediately. Operations whose results it cannot make up, such
+// as notebook guides, quizzes and guidebooks, fail with ErrUnsupported.
+package nlmtest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrUnsupported is returned by operations the fake does not simulate. A
+// notebook, source, note, artifact or overview that does not exist is
+// reported with api.ErrNotFound, as Client reports it.
+var ErrUnsupported = errors.New("nlmtest: not supported by the fake")
+
+// Fake is an in-memory api.NotebookService. The zero value is ready to
+// use, and it is safe for concurrent use.
+type Fake struct {
+	// Answer returns the reply to a chat prompt. If nil, the reply
+	// repeats the prompt.
+	Answer func(notebookID, prompt string) string
+
+	mu        sync.Mutex
+	notebooks []*notebook // most recently created first
+	failures  map[string]error
+	seq       int
+}
+
+var _ api.NotebookService = (*Fake)(nil)
+
+type notebook struct {
+	project   *pb.Project
+	hidden    bool // removed from the recently viewed list
+	notes     []*pb.Source
+	artifacts []*artifact
+	audio     []*api.AudioOverviewResult
+	video     []*api.VideoOverviewResult
+	share     api.ShareInfo
+}
+
+type artifact struct {
+	api.Artifact
+	markdown string
+}
+
+// FailOn makes later calls of the named method, such as "GetProject",
+// fail with err. A nil err makes them succeed again.
+func (f *Fake) FailOn(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures == nil {
+		f.failures = make(map[string]error)
+	}
+	if err == nil {
+		delete(f.failures, method)
+		return
+	}
+	f.failures[method] = err
+}
+
+// failure returns the error set for method by FailOn. f.mu is held.
+func (f *Fake) failure(method string) error {
+	return f.failures[method]
+}
+
+func (f *Fake) newID(prefix string) string {
+	f.seq++
+	return fmt.Sprintf("%s%d", prefix, f.seq)
+}
+
+func (f *Fake) notebook(id string) (*notebook, error) {
+	for _, nb := range f.notebooks {
+		if nb.project.ProjectId == id {
+			return nb, nil
+		}
+	}
+	return nil, fmt.Errorf("notebook %s: %w", id, api.ErrNotFound)
+}
+
+// source finds a source in any notebook, since some methods name a
+// source without its notebook.
+func (f *Fake) source(id string) (*pb.Source, error) {
+	for _, nb := range f.notebooks {
+		for _, src := range nb.project.Sources {
+			if src.GetSourceId().GetSourceId() == id {
+				return src, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("source %s: %w", id, api.ErrNotFound)
+}
+
+func (f *Fake) artifact(id string) (*notebook, *artifact, error) {
+	for _, nb := range f.notebooks {
+		for _, a := range nb.artifacts {
+			if a.ID == id {
+				return nb, a, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("artifact %s: %w", id, api.ErrNotFound)
+}
+
+// Notebooks
+
+func (f *Fake) ListRecentlyViewedProjects() ([]*api.Notebook, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("ListRecentlyViewedProjects"); err != nil {
+		return nil, err
+	}
+	var list []*api.Notebook
+	for _, nb := range f.notebooks {
+		if !nb.hidden {
+			list = append(list, proto.Clone(nb.project).(*pb.Project))
+		}
+	}
+	return list, nil
+}
+
+func (f *Fake) CreateProject(title string, emoji string) (*api.Notebook, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("CreateProject"); err != nil {
+		return nil, err
+	}
+	p := &pb.Project{ProjectId: f.newID("nb"), Title: title, Emoji: emoji}
+	f.notebooks = append([]*notebook{{project: p}}, f.notebooks...)
+	return proto.Clone(p).(*pb.Project), nil
+}
+
+func (f *Fake) GetProject(projectID string) (*api.Notebook, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("GetProject"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(nb.project).(*pb.Project), nil
+}
+
+// GetProjectIfChanged versions notebooks by a hash of their encoding.
+func (f *Fake) GetProjectIfChanged(projectID, version string) (*api.Notebook, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("GetProjectIfChanged"); err != nil {
+		return nil, "", err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(nb.project)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(b)
+	if v := hex.EncodeToString(sum[:16]); v != version {
+		return proto.Clone(nb.project).(*pb.Project), v, nil
+	}
+	return nil, version, nil
+}
+
+func (f *Fake) DeleteProjects(projectIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("DeleteProjects"); err != nil {
+		return err
+	}
+	for _, id := range projectIDs {
+		if _, err := f.notebook(id); err != nil {
+			return err
+		}
+	}
+	f.notebooks = slices.DeleteFunc(f.notebooks, func(nb *notebook) bool {
+		return slices.Contains(projectIDs, nb.project.ProjectId)
+	})
+	return nil
+}
+
+func (f *Fake) MutateProject(projectID string, updates *pb.Project) (*api.Notebook, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("MutateProject"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if t := updates.GetTitle(); t != "" {
+		nb.project.Title = t
+	}
+	if e := updates.GetEmoji(); e != "" {
+		nb.project.Emoji = e
+	}
+	return proto.Clone(nb.project).(*pb.Project), nil
+}
+
+func (f *Fake) RemoveRecentlyViewedProject(projectID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("RemoveRecentlyViewedProject"); err != nil {
+		return err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return err
+	}
+	nb.hidden = true
+	return nil
+}
+
+// Sources
+
+// addSource adds a source to notebook projectID and returns its ID.
+// f.mu is held.
+func (f *Fake) addSource(method, projectID, title string, typ pb.SourceType) (string, error) {
+	if err := f.failure(method); err != nil {
+		return "", err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return "", err
+	}
+	id := f.newID("src")
+	nb.project.Sources = append(nb.project.Sources, &pb.Source{
+		SourceId: &pb.SourceId{SourceId: id},
+		Title:    title,
+		Metadata: &pb.SourceMetadata{
+			SourceType:       typ,
+			Status:           pb.SourceSettings_SOURCE_STATUS_ENABLED,
+			LastModifiedTime: timestamppb.Now(),
+		},
+	})
+	return id, nil
+}
+
+func (f *Fake) AddSources(projectID string, sources []*pb.SourceInput) (*pb.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, in := range sources {
+		title := in.GetTitle()
+		for _, alt := range []string{in.GetFilename(), in.GetUrl(), in.GetYoutubeVideoId()} {
+			if title == "" {
+				title = alt
+			}
+		}
+		if _, err := f.addSource("AddSources", projectID, title, in.GetSourceType()); err != nil {
+			return nil, err
+		}
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(nb.project).(*pb.Project), nil
+}
+
+func (f *Fake) AddSourceFromReader(projectID string, r io.Reader, filename string, contentType ...string) (string, error) {
+	if _, err := io.ReadAll(r); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addSource("AddSourceFromReader", projectID, filename, pb.SourceType_SOURCE_TYPE_LOCAL_FILE)
+}
+
+func (f *Fake) AddSourceFromText(projectID string, content, title string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addSource("AddSourceFromText", projectID, title, pb.SourceType_SOURCE_TYPE_TEXT)
+}
+
+func (f *Fake) AddSourceFromBase64(projectID string, content, filename, contentType string) (string, error) {
+	if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+		return "", fmt.Errorf("decode base64: %w", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addSource("AddSourceFromBase64", projectID, filename, pb.SourceType_SOURCE_TYPE_LOCAL_FILE)
+}
+
+func (f *Fake) AddSourceFromFile(projectID string, path string, contentType ...string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addSource("AddSourceFromFile", projectID, filepath.Base(path), pb.SourceType_SOURCE_TYPE_LOCAL_FILE)
+}
+
+func (f *Fake) AddSourceFromURL(projectID string, url string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addSource("AddSourceFromURL", projectID, url, pb.SourceType_SOURCE_TYPE_WEB_PAGE)
+}
+
+func (f *Fake) AddYouTubeSource(projectID, videoID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addSource("AddYouTubeSource", projectID, "https://www.youtube.com/watch?v="+videoID, pb.SourceType_SOURCE_TYPE_YOUTUBE_VIDEO)
+}
+
+func (f *Fake) DeleteSources(projectID string, sourceIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("DeleteSources"); err != nil {
+		return err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return err
+	}
+	nb.project.Sources = slices.DeleteFunc(nb.project.Sources, func(src *pb.Source) bool {
+		return slices.Contains(sourceIDs, src.GetSourceId().GetSourceId())
+	})
+	return nil
+}
+
+func (f *Fake) MutateSource(sourceID string, updates *pb.Source) (*pb.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("MutateSource"); err != nil {
+		return nil, err
+	}
+	src, err := f.source(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if t := updates.GetTitle(); t != "" {
+		src.Title = t
+	}
+	return proto.Clone(src).(*pb.Source), nil
+}
+
+func (f *Fake) RefreshSource(sourceID string) (*pb.Source, error) {
+	return f.getSource("RefreshSource", sourceID)
+}
+
+func (f *Fake) LoadSource(sourceID string) (*pb.Source, error) {
+	return f.getSource("LoadSource", sourceID)
+}
+
+func (f *Fake) getSource(method, sourceID string) (*pb.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure(method); err != nil {
+		return nil, err
+	}
+	src, err := f.source(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(src).(*pb.Source), nil
+}
+
+// CheckSourceFreshness reports every source as fresh.
+func (f *Fake) CheckSourceFreshness(sourceID string) (*pb.CheckSourceFreshnessResponse, error) {
+	if _, err := f.getSource("CheckSourceFreshness", sourceID); err != nil {
+		return nil, err
+	}
+	return &pb.CheckSourceFreshnessResponse{IsFresh: true, LastChecked: timestamppb.Now()}, nil
+}
+
+// ActOnSources checks that the notebook and sources exist and otherwise
+// does nothing.
+func (f *Fake) ActOnSources(projectID string, action string, sourceIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("ActOnSources"); err != nil {
+		return err
+	}
+	if _, err := f.notebook(projectID); err != nil {
+		return err
+	}
+	for _, id := range sourceIDs {
+		if _, err := f.source(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Notes
+
+func (f *Fake) GetNotes(projectID string) ([]*api.Note, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("GetNotes"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	var notes []*api.Note
+	for _, n := range nb.notes {
+		notes = append(notes, proto.Clone(n).(*pb.Source))
+	}
+	return notes, nil
+}
+
+func (f *Fake) CreateNote(projectID string, title string, initialContent string) (*api.Note, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("CreateNote"); err != nil {
+		return nil, err
+	}
+	return f.createNote(projectID, title)
+}
+
+// createNote adds a note to notebook projectID. f.mu is held.
+func (f *Fake) createNote(projectID, title string) (*api.Note, error) {
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	n := &pb.Source{
+		SourceId: &pb.SourceId{SourceId: f.newID("note")},
+		Title:    title,
+		Metadata: &pb.SourceMetadata{LastModifiedTime: timestamppb.Now()},
+	}
+	nb.notes = append(nb.notes, n)
+	return proto.Clone(n).(*pb.Source), nil
+}
+
+func (f *Fake) MutateNote(projectID string, noteID string, content string, title string) (*api.Note, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("MutateNote"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nb.notes {
+		if n.GetSourceId().GetSourceId() == noteID {
+			if title != "" {
+				n.Title = title
+			}
+			n.Metadata = &pb.SourceMetadata{LastModifiedTime: timestamppb.Now()}
+			return proto.Clone(n).(*pb.Source), nil
+		}
+	}
+	return nil, fmt.Errorf("note %s: %w", noteID, api.ErrNotFound)
+}
+
+func (f *Fake) DeleteNotes(projectID string, noteIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("DeleteNotes"); err != nil {
+		return err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return err
+	}
+	nb.notes = slices.DeleteFunc(nb.notes, func(n *pb.Source) bool {
+		return slices.Contains(noteIDs, n.GetSourceId().GetSourceId())
+	})
+	return nil
+}
+
+// Chat and generation
+
+// answer returns the reply to prompt in notebook projectID.
+func (f *Fake) answer(method, projectID, prompt string) (string, error) {
+	f.mu.Lock()
+	if err := f.failure(method); err != nil {
+		f.mu.Unlock()
+		return "", err
+	}
+	_, err := f.notebook(projectID)
+	answer := f.Answer
+	f.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if answer == nil {
+		return prompt, nil
+	}
+	return answer(projectID, prompt), nil
+}
+
+func (f *Fake) GenerateFreeFormStreamed(projectID string, prompt string, sourceIDs []string, opts ...api.CallOption) (*pb.GenerateFreeFormStreamedResponse, error) {
+	text, err := f.answer("GenerateFreeFormStreamed", projectID, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GenerateFreeFormStreamedResponse{Chunk: text, IsFinal: true}, nil
+}
+
+// GenerateFreeFormStreamedWithCallback streams the answer a word at a
+// time. Like GenerateFreeFormStreamed, it ignores call options.
+func (f *Fake) GenerateFreeFormStreamedWithCallback(projectID string, prompt string, sourceIDs []string, callback func(chunk string) bool, opts ...api.CallOption) error {
+	text, err := f.answer("GenerateFreeFormStreamedWithCallback", projectID, prompt)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range strings.SplitAfter(text, " ") {
+		if chunk != "" && !callback(chunk) {
+			break
+		}
+	}
+	return nil
+}
+
+func (f *Fake) GenerateDocumentGuides(projectID string) (*pb.GenerateDocumentGuidesResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GenerateNotebookGuide(projectID string) (*pb.GenerateNotebookGuideResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GenerateMagicView(projectID string, sourceIDs []string) (*pb.GenerateMagicViewResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GenerateOutline(projectID string) (*pb.GenerateOutlineResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GenerateSection(projectID string) (*pb.GenerateSectionResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GenerateReportSuggestions(projectID string) (*pb.GenerateReportSuggestionsResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) StartDraft(projectID string) (*pb.StartDraftResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) StartSection(projectID string) (*pb.StartSectionResponse, error) {
+	return nil, ErrUnsupported
+}
+
+// Artifacts
+
+func (f *Fake) ListArtifacts(projectID string) ([]*api.Artifact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("ListArtifacts"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	var list []*api.Artifact
+	for _, a := range nb.artifacts {
+		list = append(list, copyArtifact(a))
+	}
+	return list, nil
+}
+
+func copyArtifact(a *artifact) *api.Artifact {
+	out := a.Artifact
+	out.SourceIDs = slices.Clone(a.SourceIDs)
+	return &out
+}
+
+// CreateArtifact creates an artifact that is ready at once, with an empty
+// body.
+func (f *Fake) CreateArtifact(projectID string, kind api.ArtifactKind, opts *api.CreateArtifactOptions) (*api.Artifact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("CreateArtifact"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	a := &artifact{Artifact: api.Artifact{
+		ID:        f.newID("art"),
+		Type:      kind.ArtifactType(),
+		Title:     string(kind),
+		State:     pb.ArtifactState_ARTIFACT_STATE_READY,
+		UpdatedAt: time.Now(),
+	}}
+	if opts != nil {
+		a.SourceIDs = slices.Clone(opts.SourceIDs)
+	}
+	nb.artifacts = append(nb.artifacts, a)
+	return copyArtifact(a), nil
+}
+
+func (f *Fake) GetArtifact(artifactID string) (*pb.Artifact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("GetArtifact"); err != nil {
+		return nil, err
+	}
+	nb, a, err := f.artifact(artifactID)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.Artifact{
+		ArtifactId: a.ID,
+		ProjectId:  nb.project.ProjectId,
+		Type:       a.Type,
+		State:      a.State,
+	}
+	for _, id := range a.SourceIDs {
+		out.Sources = append(out.Sources, &pb.ArtifactSource{SourceId: &pb.SourceId{SourceId: id}})
+	}
+	return out, nil
+}
+
+func (f *Fake) GetArtifactContent(projectID, artifactID string) (*api.ArtifactContent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("GetArtifactContent"); err != nil {
+		return nil, err
+	}
+	_, a, err := f.artifact(artifactID)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ArtifactContent{Artifact: copyArtifact(a), Markdown: a.markdown}, nil
+}
+
+func (f *Fake) RenameArtifact(artifactID, newTitle string) (*api.Artifact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("RenameArtifact"); err != nil {
+		return nil, err
+	}
+	_, a, err := f.artifact(artifactID)
+	if err != nil {
+		return nil, err
+	}
+	a.Title = newTitle
+	a.UpdatedAt = time.Now()
+	return copyArtifact(a), nil
+}
+
+func (f *Fake) UpdateArtifact(projectID, artifactID string, update api.ArtifactUpdate) (*api.Artifact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("UpdateArtifact"); err != nil {
+		return nil, err
+	}
+	_, a, err := f.artifact(artifactID)
+	if err != nil {
+		return nil, err
+	}
+	if update.Title != "" {
+		a.Title = update.Title
+	}
+	if update.Content != "" {
+		a.markdown = update.Content
+	}
+	a.UpdatedAt = time.Now()
+	return copyArtifact(a), nil
+}
+
+func (f *Fake) DeleteArtifact(artifactID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("DeleteArtifact"); err != nil {
+		return err
+	}
+	nb, a, err := f.artifact(artifactID)
+	if err != nil {
+		return err
+	}
+	nb.artifacts = slices.DeleteFunc(nb.artifacts, func(x *artifact) bool { return x == a })
+	return nil
+}
+
+func (f *Fake) SaveArtifactAsNote(projectID, artifactID, title string) (*api.Note, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("SaveArtifactAsNote"); err != nil {
+		return nil, err
+	}
+	_, a, err := f.artifact(artifactID)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = a.Title
+	}
+	return f.createNote(projectID, title)
+}
+
+func (f *Fake) SaveArtifactAsSource(projectID, artifactID, title string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, a, err := f.artifact(artifactID)
+	if err != nil {
+		return "", err
+	}
+	if title == "" {
+		title = a.Title
+	}
+	return f.addSource("SaveArtifactAsSource", projectID, title, pb.SourceType_SOURCE_TYPE_TEXT)
+}
+
+func (f *Fake) GetFlashcards(projectID, artifactID string) (*api.Flashcards, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GetMindMap(projectID, artifactID string) (*api.MindMap, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GetQuiz(projectID, artifactID string) (*api.Quiz, error) {
+	return nil, ErrUnsupported
+}
+
+// WaitForArtifact returns the artifact, which is always ready.
+func (f *Fake) WaitForArtifact(ctx context.Context, notebookID, artifactID string, opts *api.WaitOptions) (*pb.Artifact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetArtifact(artifactID)
+}
+
+// Audio and video overviews
+
+func (f *Fake) CreateAudioOverview(projectID string, instructions string) (*api.AudioOverviewResult, error) {
+	return f.CreateAudioOverviewWithOptions(projectID, api.AudioOverviewOptions{Instructions: instructions})
+}
+
+// CreateAudioOverviewWithOptions creates an audio overview that is ready
+// at once, with no audio data.
+func (f *Fake) CreateAudioOverviewWithOptions(projectID string, opts api.AudioOverviewOptions) (*api.AudioOverviewResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("CreateAudioOverviewWithOptions"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	r := &api.AudioOverviewResult{ProjectID: projectID, AudioID: f.newID("audio"), Title: nb.project.Title, IsReady: true}
+	nb.audio = append(nb.audio, r)
+	out := *r
+	return &out, nil
+}
+
+func (f *Fake) GetAudioOverview(projectID string) (*api.AudioOverviewResult, error) {
+	return f.audioOverview("GetAudioOverview", projectID)
+}
+
+func (f *Fake) DownloadAudioOverview(projectID string) (*api.AudioOverviewResult, error) {
+	return f.audioOverview("DownloadAudioOverview", projectID)
+}
+
+// audioOverview returns the notebook's latest audio overview.
+func (f *Fake) audioOverview(method, projectID string) (*api.AudioOverviewResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure(method); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(nb.audio) == 0 {
+		return nil, fmt.Errorf("audio overview of %s: %w", projectID, api.ErrNotFound)
+	}
+	out := *nb.audio[len(nb.audio)-1]
+	return &out, nil
+}
+
+func (f *Fake) ListAudioOverviews(projectID string) ([]*api.AudioOverviewResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("ListAudioOverviews"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	var list []*api.AudioOverviewResult
+	for _, r := range nb.audio {
+		out := *r
+		list = append(list, &out)
+	}
+	return list, nil
+}
+
+func (f *Fake) DeleteAudioOverview(projectID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("DeleteAudioOverview"); err != nil {
+		return err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return err
+	}
+	nb.audio = nil
+	return nil
+}
+
+// WaitForAudio returns the audio overview, which is always ready.
+func (f *Fake) WaitForAudio(ctx context.Context, notebookID, audioID string, opts *api.WaitOptions) (*api.AudioOverviewResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.audioOverview("WaitForAudio", notebookID)
+}
+
+// CreateVideoOverview creates a video overview that is ready at once,
+// with no video data.
+func (f *Fake) CreateVideoOverview(projectID string, instructions string) (*api.VideoOverviewResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("CreateVideoOverview"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	r := &api.VideoOverviewResult{ProjectID: projectID, VideoID: f.newID("video"), Title: nb.project.Title, IsReady: true}
+	nb.video = append(nb.video, r)
+	out := *r
+	return &out, nil
+}
+
+func (f *Fake) GetVideoOverview(projectID string) (*api.VideoOverviewResult, error) {
+	return f.videoOverview("GetVideoOverview", projectID)
+}
+
+func (f *Fake) DownloadVideoOverview(projectID string) (*api.VideoOverviewResult, error) {
+	return f.videoOverview("DownloadVideoOverview", projectID)
+}
+
+// videoOverview returns the notebook's latest video overview.
+func (f *Fake) videoOverview(method, projectID string) (*api.VideoOverviewResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure(method); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(nb.video) == 0 {
+		return nil, fmt.Errorf("video overview of %s: %w", projectID, api.ErrNotFound)
+	}
+	out := *nb.video[len(nb.video)-1]
+	return &out, nil
+}
+
+func (f *Fake) ListVideoOverviews(projectID string) ([]*api.VideoOverviewResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("ListVideoOverviews"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	var list []*api.VideoOverviewResult
+	for _, r := range nb.video {
+		out := *r
+		list = append(list, &out)
+	}
+	return list, nil
+}
+
+// WaitForVideo returns the video overview, which is always ready.
+func (f *Fake) WaitForVideo(ctx context.Context, notebookID, videoID string, opts *api.WaitOptions) (*api.VideoOverviewResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.videoOverview("WaitForVideo", notebookID)
+}
+
+// Sharing
+
+func shareURL(projectID string) string {
+	return "https://notebooklm.google.com/notebook/" + projectID
+}
+
+func (f *Fake) ShareProject(projectID string, settings *pb.ShareSettings) (*pb.ShareProjectResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("ShareProject"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.GetIsPublic() {
+		nb.share.Access = api.LinkAnyone
+	}
+	for _, email := range settings.GetAllowedEmails() {
+		nb.invite(api.ShareInvite{Email: email, Role: api.ShareRoleViewer})
+	}
+	return &pb.ShareProjectResponse{ShareUrl: shareURL(projectID), ShareId: projectID, Settings: settings}, nil
+}
+
+func (f *Fake) ShareAudio(projectID string, shareOption api.ShareOption) (*api.ShareAudioResult, error) {
+	r, err := f.audioOverview("ShareAudio", projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ShareAudioResult{
+		ShareURL: shareURL(projectID) + "/audio",
+		ShareID:  r.AudioID,
+		IsPublic: shareOption == api.SharePublic,
+	}, nil
+}
+
+func (f *Fake) ShareNotebook(projectID string, invites []api.ShareInvite) (*api.ShareInfo, error) {
+	return f.ShareNotebookWithOptions(projectID, invites, api.InviteOptions{})
+}
+
+func (f *Fake) ShareNotebookWithOptions(projectID string, invites []api.ShareInvite, opts api.InviteOptions) (*api.ShareInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("ShareNotebookWithOptions"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invites {
+		nb.invite(inv)
+	}
+	return nb.shareInfo(), nil
+}
+
+// invite adds a collaborator, or changes the role of an existing one.
+func (nb *notebook) invite(inv api.ShareInvite) {
+	for i, c := range nb.share.Collaborators {
+		if strings.EqualFold(c.Email, inv.Email) {
+			nb.share.Collaborators[i].Role = inv.Role
+			return
+		}
+	}
+	nb.share.Collaborators = append(nb.share.Collaborators, api.Collaborator{Email: inv.Email, Role: inv.Role})
+}
+
+func (nb *notebook) shareInfo() *api.ShareInfo {
+	info := nb.share
+	info.ProjectID = nb.project.ProjectId
+	info.ShareURL = shareURL(nb.project.ProjectId)
+	info.Collaborators = slices.Clone(nb.share.Collaborators)
+	return &info
+}
+
+func (f *Fake) GetShareInfo(projectID string) (*api.ShareInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("GetShareInfo"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return nb.shareInfo(), nil
+}
+
+func (f *Fake) RevokeAccess(projectID string, emails []string) (*api.ShareInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("RevokeAccess"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	nb.share.Collaborators = slices.DeleteFunc(nb.share.Collaborators, func(c api.Collaborator) bool {
+		return slices.ContainsFunc(emails, func(e string) bool { return strings.EqualFold(e, c.Email) })
+	})
+	return nb.shareInfo(), nil
+}
+
+func (f *Fake) SetLinkAccess(projectID string, access api.LinkAccess) (*api.ShareInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failure("SetLinkAccess"); err != nil {
+		return nil, err
+	}
+	nb, err := f.notebook(projectID)
+	if err != nil {
+		return nil, err
+	}
+	nb.share.Access = access
+	return nb.shareInfo(), nil
+}
+
+// Guidebooks
+
+func (f *Fake) GetGuidebook(guidebookID string) (*pb.Guidebook, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) GetGuidebookDetails(guidebookID string) (*pb.GuidebookDetails, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) PublishGuidebook(guidebookID string, opts api.PublishGuidebookOptions) (*pb.PublishGuidebookResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) CreateGuidebookFromNotebook(projectID string, opts api.PublishGuidebookOptions) (*pb.PublishGuidebookResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) AskGuidebook(guidebookID, question string) (*pb.GuidebookGenerateAnswerResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *Fake) AskGuidebookStream(guidebookID, question string, fn func(chunk string) bool) (*pb.GuidebookGenerateAnswerResponse, error) {
+	return nil, ErrUnsupported
+}
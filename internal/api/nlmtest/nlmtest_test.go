@@ -0,0 +1,180 @@
+package nlmtest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+// summarize stands in for application code written against the
+// interface.
+func summarize(svc api.NotebookService, title, text string) (string, error) {
+	nb, err := svc.CreateProject(title, "")
+	if err != nil {
+		return "", err
+	}
+	if _, err := svc.AddSourceFromText(nb.GetProjectId(), text, "input"); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	err = svc.GenerateFreeFormStreamedWithCallback(nb.GetProjectId(), "Summarize", nil, func(chunk string) bool {
+		b.WriteString(chunk)
+		return true
+	})
+	return b.String(), err
+}
+
+func TestFakeAsService(t *testing.T) {
+	f := &Fake{Answer: func(id, prompt string) string { return "A short summary." }}
+	got, err := summarize(f, "Report", "Lots of text.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "A short summary." {
+		t.Errorf("answer = %q", got)
+	}
+	list, err := f.ListRecentlyViewedProjects()
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ListRecentlyViewedProjects = %v, %v", list, err)
+	}
+	if srcs := list[0].GetSources(); len(srcs) != 1 || srcs[0].GetTitle() != "input" {
+		t.Errorf("sources = %v", srcs)
+	}
+}
+
+func TestFakeNotebooks(t *testing.T) {
+	f := &Fake{}
+	a, _ := f.CreateProject("A", "📙")
+	b, _ := f.CreateProject("B", "")
+	list, _ := f.ListRecentlyViewedProjects()
+	if len(list) != 2 || list[0].GetProjectId() != b.GetProjectId() {
+		t.Fatalf("list = %v, want B first", list)
+	}
+
+	if _, err := f.MutateProject(a.GetProjectId(), &pb.Project{Title: "A2"}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := f.GetProject(a.GetProjectId())
+	if got.GetTitle() != "A2" || got.GetEmoji() != "📙" {
+		t.Errorf("after mutate: %q %q", got.GetTitle(), got.GetEmoji())
+	}
+	// Callers get copies; changing one does not change the fake.
+	got.Title = "changed"
+	if again, _ := f.GetProject(a.GetProjectId()); again.GetTitle() != "A2" {
+		t.Errorf("title = %q, want A2", again.GetTitle())
+	}
+
+	_, v1, _ := f.GetProjectIfChanged(a.GetProjectId(), "")
+	if nb, v, _ := f.GetProjectIfChanged(a.GetProjectId(), v1); nb != nil || v != v1 {
+		t.Errorf("unchanged notebook returned as %v, %q", nb, v)
+	}
+	f.MutateProject(a.GetProjectId(), &pb.Project{Title: "A3"})
+	if nb, _, _ := f.GetProjectIfChanged(a.GetProjectId(), v1); nb.GetTitle() != "A3" {
+		t.Errorf("changed notebook = %v", nb)
+	}
+
+	if err := f.DeleteProjects([]string{a.GetProjectId()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.GetProject(a.GetProjectId()); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("GetProject after delete: %v, want ErrNotFound", err)
+	}
+	if err := f.DeleteProjects([]string{"missing"}); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("DeleteProjects(missing) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFakeSourcesAndNotes(t *testing.T) {
+	f := &Fake{}
+	nb, _ := f.CreateProject("N", "")
+	id := nb.GetProjectId()
+	s1, _ := f.AddSourceFromURL(id, "https://example.com")
+	s2, _ := f.AddYouTubeSource(id, "abc")
+	if _, err := f.MutateSource(s1, &pb.Source{Title: "Example"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.DeleteSources(id, []string{s2}); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := f.GetProject(id)
+	if srcs := p.GetSources(); len(srcs) != 1 || srcs[0].GetTitle() != "Example" {
+		t.Errorf("sources = %v", srcs)
+	}
+
+	n, _ := f.CreateNote(id, "Ideas", "body")
+	if _, err := f.MutateNote(id, n.GetSourceId().GetSourceId(), "new body", "Plans"); err != nil {
+		t.Fatal(err)
+	}
+	notes, _ := f.GetNotes(id)
+	if len(notes) != 1 || notes[0].GetTitle() != "Plans" {
+		t.Errorf("notes = %v", notes)
+	}
+	if _, err := f.MutateNote(id, "missing", "", "x"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("MutateNote(missing) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFakeArtifacts(t *testing.T) {
+	f := &Fake{}
+	nb, _ := f.CreateProject("N", "")
+	id := nb.GetProjectId()
+	a, err := f.CreateArtifact(id, api.ArtifactFAQ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.StateName() != "ready" {
+		t.Errorf("state = %s, want ready", a.StateName())
+	}
+	if _, err := f.UpdateArtifact(id, a.ID, api.ArtifactUpdate{Content: "# FAQ\n"}); err != nil {
+		t.Fatal(err)
+	}
+	c, _ := f.GetArtifactContent(id, a.ID)
+	if c.Markdown != "# FAQ\n" {
+		t.Errorf("markdown = %q", c.Markdown)
+	}
+	got, err := f.WaitForArtifact(context.Background(), id, a.ID, nil)
+	if err != nil || got.GetState() != pb.ArtifactState_ARTIFACT_STATE_READY {
+		t.Errorf("WaitForArtifact = %v, %v", got, err)
+	}
+	if err := f.DeleteArtifact(a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := f.ListArtifacts(id); len(list) != 0 {
+		t.Errorf("artifacts after delete = %v", list)
+	}
+	if _, err := f.GetQuiz(id, a.ID); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GetQuiz = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestFakeSharing(t *testing.T) {
+	f := &Fake{}
+	nb, _ := f.CreateProject("N", "")
+	id := nb.GetProjectId()
+	f.ShareNotebook(id, []api.ShareInvite{{Email: "a@example.com", Role: api.ShareRoleEditor}, {Email: "b@example.com", Role: api.ShareRoleViewer}})
+	f.SetLinkAccess(id, api.LinkAnyone)
+	info, err := f.RevokeAccess(id, []string{"B@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Access != api.LinkAnyone || len(info.Collaborators) != 1 || info.Collaborators[0].Email != "a@example.com" {
+		t.Errorf("share info = %+v", info)
+	}
+}
+
+func TestFakeFailOn(t *testing.T) {
+	f := &Fake{}
+	boom := errors.New("boom")
+	f.FailOn("CreateProject", boom)
+	if _, err := f.CreateProject("x", ""); err != boom {
+		t.Errorf("CreateProject = %v, want boom", err)
+	}
+	f.FailOn("CreateProject", nil)
+	if _, err := f.CreateProject("x", ""); err != nil {
+		t.Errorf("CreateProject after clearing = %v", err)
+	}
+}
@@ -0,0 +1,402 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/config"
+)
+
+// Option configures a client made with New.
+type Option func(*options)
+
+type options struct {
+	authToken, cookies string
+	profile            string
+	httpClient         *http.Client
+	logf               func(format string, args ...interface{})
+	retry              *RetryPolicy
+	rateLimit          *time.Duration
+	maxConcurrency     int
+	uploadConcurrency  int
+	pageParams         *string // cache path
+	locale             string
+	interceptors       []batchexecute.Interceptor
+	batch              []batchexecute.Option
+}
+
+// RetryPolicy is how requests that fail with a network error or a
+// retryable status, such as 429 or 503, are retried with exponential
+// backoff. Zero fields keep the defaults of 3 retries starting 1s apart
+// and backing off to at most 10s.
+type RetryPolicy struct {
+	MaxRetries int
+	Delay      time.Duration
+	MaxDelay   time.Duration
+}
+
+// WithAuth authorizes the client with an auth token and cookies, as
+// stored by `nlm auth`.
+func WithAuth(authToken, cookies string) Option {
+	return func(o *options) {
+		o.authToken, o.cookies = authToken, cookies
+	}
+}
+
+// WithAuthProfile authorizes the client with the credentials of a
+// profile in the nlm config file, and retries as the profile says.
+// Credentials given with WithAuth take precedence.
+func WithAuthProfile(name string) Option {
+	return func(o *options) {
+		o.profile = name
+	}
+}
+
+// WithHTTPClient sends requests with hc rather than http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = hc
+	}
+}
+
+// WithDebugLogger enables debug output of each request and response,
+// sending it to logf. A client used from several goroutines calls logf
+// from them too.
+func WithDebugLogger(logf func(format string, args ...interface{})) Option {
+	return func(o *options) {
+		o.logf = logf
+	}
+}
+
+// WithRetryPolicy sets how failed requests are retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = &p
+	}
+}
+
+// WithRateLimit spaces the client's requests at least interval apart, and
+// holds them back for a minute after the server answers 429 Too Many
+// Requests. An interval of zero only does the latter.
+func WithRateLimit(interval time.Duration) Option {
+	return func(o *options) {
+		o.rateLimit = &interval
+	}
+}
+
+// WithMaxConcurrency limits the client to n requests in flight at once;
+// further requests wait for one to finish. It bounds the load a client
+// shared by many goroutines puts on the account. n <= 0 means no limit,
+// the default.
+func WithMaxConcurrency(n int) Option {
+	return func(o *options) {
+		o.maxConcurrency = n
+	}
+}
+
+// WithUploadConcurrency sends up to n parts of a large file upload at
+// once, when the upload endpoint accepts them out of order. The default
+// is 4; 1 sends them in order.
+func WithUploadConcurrency(n int) Option {
+	return func(o *options) {
+		o.uploadConcurrency = n
+	}
+}
+
+// WithLocale asks for responses, such as error messages and generated
+// titles, in the language with the given BCP 47 tag, like "de" or "pt-BR".
+func WithLocale(lang string) Option {
+	return func(o *options) {
+		o.locale = lang
+	}
+}
+
+// WithInterceptors runs interceptors around every RPC the client makes,
+// for logging, refreshing credentials, caching or adding headers. The
+// first interceptor is the outermost. Retries happen inside the
+// interceptors, so each sees a call once.
+func WithInterceptors(interceptors ...batchexecute.Interceptor) Option {
+	return func(o *options) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+// WithBatchExecuteOptions passes options to the underlying batchexecute
+// clients, for settings the options here do not cover. They are applied
+// last.
+func WithBatchExecuteOptions(opts ...batchexecute.Option) Option {
+	return func(o *options) {
+		o.batch = append(o.batch, opts...)
+	}
+}
+
+// New returns a NotebookLM client configured by opts. Its requests are
+// made with ctx, so canceling ctx abandons them. Without WithAuth or
+// WithAuthProfile, credentials come from NLM_AUTH_TOKEN and NLM_COOKIES.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	var bopts []batchexecute.Option
+	if o.profile != "" {
+		p, err := loadProfile(o.profile)
+		if err != nil {
+			return nil, err
+		}
+		if o.authToken == "" && o.cookies == "" {
+			o.authToken, o.cookies = p.AuthToken, p.Cookies
+		}
+		if o.retry == nil && (p.MaxRetries != 0 || p.RetryDelay != "") {
+			r := RetryPolicy{MaxRetries: p.MaxRetries}
+			if p.RetryDelay != "" {
+				d, err := time.ParseDuration(p.RetryDelay)
+				if err != nil {
+					return nil, fmt.Errorf("profile %q: invalid retry_delay %q", o.profile, p.RetryDelay)
+				}
+				r.Delay = d
+			}
+			o.retry = &r
+		}
+	}
+	if o.authToken == "" && o.cookies == "" {
+		o.authToken, o.cookies = os.Getenv("NLM_AUTH_TOKEN"), os.Getenv("NLM_COOKIES")
+	}
+
+	bopts = append(bopts, batchexecute.WithContext(ctx))
+	hc := o.httpClient
+	if o.rateLimit != nil || o.maxConcurrency > 0 {
+		if hc == nil {
+			hc = &http.Client{}
+		}
+		limited := *hc
+		next := hc.Transport
+		if next == nil {
+			next = batchexecute.DefaultTransport
+		}
+		if o.rateLimit != nil {
+			next = &rateLimitedTransport{interval: *o.rateLimit, next: next}
+		}
+		if o.maxConcurrency > 0 {
+			// Outside the rate limit, so that requests waiting for a
+			// slot do not hold back the schedule.
+			next = &concurrencyLimitedTransport{slots: make(chan struct{}, o.maxConcurrency), next: next}
+		}
+		limited.Transport = next
+		hc = &limited
+	}
+	if hc != nil {
+		bopts = append(bopts, batchexecute.WithHTTPClient(hc))
+	}
+	if o.retry != nil {
+		bopts = append(bopts, batchexecute.WithRetry(o.retry.MaxRetries, o.retry.Delay, o.retry.MaxDelay))
+	}
+	if o.locale != "" {
+		bopts = append(bopts, localeOptions(o.locale)...)
+	}
+	if o.logf != nil {
+		bopts = append(bopts, batchexecute.WithDebugLogger(o.logf))
+	}
+	var warmer *paramsWarmer
+	if o.pageParams != nil {
+		// Outermost, so that interceptors given with WithInterceptors
+		// see and may change the parameters.
+		warmer = newParamsWarmer(ctx, hc, o.cookies, *o.pageParams, o.logf)
+		bopts = append(bopts, batchexecute.WithInterceptors(warmer.intercept))
+	}
+	if len(o.interceptors) > 0 {
+		bopts = append(bopts, batchexecute.WithInterceptors(o.interceptors...))
+	}
+	bopts = append(bopts, o.batch...)
+	c := newClient(o.authToken, o.cookies, bopts...)
+	c.ctx = ctx
+	c.httpClient = hc
+	c.uploadConcurrency = o.uploadConcurrency
+	c.pageParams = warmer
+	return c, nil
+}
+
+// localeOptions asks for responses in the language lang.
+func localeOptions(lang string) []batchexecute.Option {
+	return []batchexecute.Option{
+		batchexecute.WithURLParams(map[string]string{"hl": lang}),
+		batchexecute.WithHeaders(map[string]string{"accept-language": lang}),
+	}
+}
+
+// CallOption overrides the client's settings for one call of a method
+// that accepts it, such as GenerateFreeFormStreamed.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout   time.Duration
+	sourceIDs []string
+	locale    string
+	logf      func(format string, args ...interface{})
+}
+
+// CallTimeout abandons the call if it has not finished after d,
+// including any retries.
+func CallTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// CallSources limits the call to the sources with the given IDs, when
+// the method's own source IDs argument is empty. Without it, the call
+// uses all of the notebook's sources.
+func CallSources(ids ...string) CallOption {
+	return func(o *callOptions) {
+		o.sourceIDs = ids
+	}
+}
+
+// CallLocale asks for the response in the language with the given BCP 47
+// tag, as WithLocale does for every call.
+func CallLocale(lang string) CallOption {
+	return func(o *callOptions) {
+		o.locale = lang
+	}
+}
+
+// CallDebug sends debug output of the call's requests and responses to
+// logf, as WithDebugLogger does for every call.
+func CallDebug(logf func(format string, args ...interface{})) CallOption {
+	return func(o *callOptions) {
+		o.logf = logf
+	}
+}
+
+// withCall applies opts, returning the client to make the call with and
+// a function to call when it is done. The client is c itself unless opts
+// change how requests are made.
+func (c *Client) withCall(opts []CallOption) (*Client, *callOptions, context.CancelFunc) {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.timeout <= 0 && o.locale == "" && o.logf == nil {
+		return c, o, func() {}
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cancel := context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	}
+	bopts := []batchexecute.Option{batchexecute.WithContext(ctx)}
+	if o.locale != "" {
+		bopts = append(bopts, localeOptions(o.locale)...)
+	}
+	if o.logf != nil {
+		bopts = append(bopts, batchexecute.WithDebugLogger(o.logf))
+	}
+	d := c.derive(bopts...)
+	d.ctx = ctx
+	return d, o, cancel
+}
+
+// withContext returns a client whose requests are made with ctx, so that
+// canceling ctx aborts them.
+func (c *Client) withContext(ctx context.Context) *Client {
+	d := c.derive(batchexecute.WithContext(ctx))
+	d.ctx = ctx
+	return d
+}
+
+func loadProfile(name string) (*config.Profile, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	p := cfg.Lookup(name)
+	if p == nil {
+		return nil, fmt.Errorf("no config profile %q", name)
+	}
+	return p, nil
+}
+
+// rateLimitedTransport spaces out requests, and holds them back after a
+// 429 response.
+type rateLimitedTransport struct {
+	interval time.Duration
+	next     http.RoundTripper
+
+	mu   sync.Mutex
+	slot time.Time // when the next request may be sent
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	start := t.slot
+	if now := time.Now(); start.Before(now) {
+		start = now
+	}
+	t.slot = start.Add(t.interval)
+	t.mu.Unlock()
+	if d := time.Until(start); d > 0 {
+		timer := time.NewTimer(d)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.mu.Lock()
+		if next := time.Now().Add(time.Minute); next.After(t.slot) {
+			t.slot = next
+		}
+		t.mu.Unlock()
+	}
+	return resp, err
+}
+
+// concurrencyLimitedTransport limits the requests in flight, counting a
+// request until its response body is closed.
+type concurrencyLimitedTransport struct {
+	slots chan struct{}
+	next  http.RoundTripper
+}
+
+func (t *concurrencyLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		<-t.slots
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: sync.OnceFunc(func() { <-t.slots })}
+	return resp, nil
+}
+
+// releasingBody calls release when it is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,475 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+// recordingTransport answers every request with status and records it.
+type recordingTransport struct {
+	status int
+	body   string // default: an empty batchexecute response
+	reqs   []*http.Request
+	times  []time.Time
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.reqs = append(rt.reqs, req)
+	rt.times = append(rt.times, time.Now())
+	body := rt.body
+	if body == "" {
+		body = ")]}'\n\n[]"
+	}
+	return &http.Response{
+		StatusCode: rt.status,
+		Status:     fmt.Sprintf("%d %s", rt.status, http.StatusText(rt.status)),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{},
+		Request:    req,
+	}, nil
+}
+
+func TestNewOptions(t *testing.T) {
+	rt := &recordingTransport{status: http.StatusOK}
+	var logged strings.Builder
+	c, err := New(context.Background(),
+		WithAuth("tok", "SID=1"),
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithLocale("de"),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Millisecond}),
+		WithDebugLogger(func(format string, args ...interface{}) {
+			fmt.Fprintf(&logged, format, args...)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ListRecentlyViewedProjects()
+	if len(rt.reqs) == 0 {
+		t.Fatal("no request sent")
+	}
+	req := rt.reqs[0]
+	if hl := req.URL.Query().Get("hl"); hl != "de" {
+		t.Errorf("hl = %q, want de", hl)
+	}
+	if al := req.Header.Get("Accept-Language"); al != "de" {
+		t.Errorf("Accept-Language = %q, want de", al)
+	}
+	if req.Header.Get("Cookie") != "SID=1" {
+		t.Errorf("Cookie = %q", req.Header.Get("Cookie"))
+	}
+	if !strings.Contains(logged.String(), "BatchExecute Request") {
+		t.Errorf("debug logger got %q", logged.String())
+	}
+}
+
+func TestNewRateLimit(t *testing.T) {
+	rt := &recordingTransport{status: http.StatusOK}
+	c, err := New(context.Background(),
+		WithAuth("tok", "SID=1"),
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithRateLimit(50*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ListRecentlyViewedProjects()
+	c.ListRecentlyViewedProjects()
+	if len(rt.times) != 2 {
+		t.Fatalf("sent %d requests, want 2", len(rt.times))
+	}
+	if gap := rt.times[1].Sub(rt.times[0]); gap < 45*time.Millisecond {
+		t.Errorf("requests %v apart, want at least 50ms", gap)
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := New(ctx,
+		WithAuth("tok", "SID=1"),
+		WithHTTPClient(&http.Client{Transport: &recordingTransport{status: http.StatusServiceUnavailable}}),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, Delay: time.Hour}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(10*time.Millisecond, cancel)
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.ListRecentlyViewedProjects()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("canceling the context did not stop the retries")
+	}
+}
+
+func TestNewAuthProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	if _, err := New(context.Background(), WithAuthProfile("missing")); err == nil {
+		t.Error("New with a missing profile succeeded")
+	}
+}
+
+func TestRaw(t *testing.T) {
+	rt := &recordingTransport{status: http.StatusOK, body: ")]}'\n\n[[\"wrb.fr\",\"abc123\",\"[[\\\"x\\\",2]]\",null,null,null,\"generic\"]]"}
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := c.Raw("abc123", "nb1", "arg", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != `[["x",2]]` {
+		t.Errorf("Raw() = %s", raw)
+	}
+	req := rt.reqs[0]
+	if req.URL.Query().Get("rpcids") != "abc123" || req.URL.Query().Get("source-path") != "/notebook/nb1" {
+		t.Errorf("request URL = %s", req.URL)
+	}
+}
+
+func TestWithInterceptors(t *testing.T) {
+	rt := &recordingTransport{status: http.StatusOK}
+	var ids []string
+	record := func(next batchexecute.Invoker) batchexecute.Invoker {
+		return func(ctx context.Context, rpcs []batchexecute.RPC) (*batchexecute.Response, error) {
+			ids = append(ids, rpcs[0].ID)
+			rpcs[0].Headers = map[string]string{"x-embedder": "1"}
+			return next(ctx, rpcs)
+		}
+	}
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}), WithInterceptors(record))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Raw("abc123", "")
+	// Calls with call options use a derived client, which keeps them;
+	// without sources, chat first gets the notebook for its sources.
+	c.GenerateFreeFormStreamed("nb1", "hi", nil, CallLocale("de"))
+	if want := []string{"abc123", "rLM1Ne", "BD"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("intercepted %v, want %v", ids, want)
+	}
+	for _, req := range rt.reqs {
+		if req.Header.Get("x-embedder") != "1" {
+			t.Errorf("request %s lacks the interceptor's header", req.URL)
+		}
+	}
+}
+
+// blockingTransport answers no request, failing each when its context is
+// done.
+type blockingTransport struct{}
+
+func (blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestWithPageParams(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(cache, []byte(`{"bl":"boq_cached","f.sid":"1"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	var (
+		mu  sync.Mutex
+		bls []string
+	)
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := ")]}'\n\n[[\"wrb.fr\",\"wXbhsf\",\"[]\",null,null,null,\"generic\"]]"
+		if req.Method == "GET" {
+			<-release // the page is slow to arrive
+			body = `<script>window.WIZ_global_data = {"FdrFJe":"-42","cfb2h":"boq_fresh"};</script>`
+		} else {
+			mu.Lock()
+			bls = append(bls, req.URL.Query().Get("bl")+" "+req.URL.Query().Get("f.sid"))
+			mu.Unlock()
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+	})
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}), WithPageParams(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Raw("wXbhsf", ""); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	<-c.pageParams.done
+	if _, err := c.Raw("wXbhsf", ""); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"boq_cached 1", "boq_fresh -42"}
+	if !slices.Equal(bls, want) {
+		t.Errorf("RPCs sent with bl and f.sid %q, want %q", bls, want)
+	}
+	data, _ := os.ReadFile(cache)
+	if !strings.Contains(string(data), "boq_fresh") {
+		t.Errorf("cache = %s, want the fetched parameters", data)
+	}
+}
+
+func TestPageParamsSaveConcurrent(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "nlm", "params.json")
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := &paramsWarmer{cachePath: cache}
+			errs <- w.save(&pageParams{BL: fmt.Sprintf("boq_%d", i), FSID: "-1"})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("save() error = %v", err)
+		}
+	}
+	data, err := os.ReadFile(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p pageParams
+	if err := json.Unmarshal(data, &p); err != nil || p.FSID != "-1" {
+		t.Errorf("cache = %s, want one complete save", data)
+	}
+
+	// A cache path that cannot be written is reported.
+	w := &paramsWarmer{cachePath: filepath.Join(cache, "params.json")}
+	if err := w.save(&pageParams{BL: "boq", FSID: "-1"}); err == nil {
+		t.Error("save() under a file succeeded, want an error")
+	}
+}
+
+func TestPageParamsSaveFailureLogged(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `<script>window.WIZ_global_data = {"FdrFJe":"-42","cfb2h":"boq_fresh"};</script>`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+	})
+	var (
+		mu   sync.Mutex
+		logs []string
+	)
+	logf := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}),
+		WithPageParams(filepath.Join(file, "params.json")), WithDebugLogger(logf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-c.pageParams.done
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.ContainsFunc(logs, func(s string) bool { return strings.Contains(s, "page parameters not cached") }) {
+		t.Errorf("debug log = %q, want the failed save reported", logs)
+	}
+}
+
+func TestExtractPageParams(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want pageParams
+	}{
+		{
+			name: "WIZ_global_data fields",
+			page: `<script>window.WIZ_global_data = {"FdrFJe":"-42","cfb2h":"boq_fresh"};</script>`,
+			want: pageParams{BL: "boq_fresh", FSID: "-42"},
+		},
+		{
+			name: "URL parameters",
+			page: `<link href="/_/LabsTailwindUi/data/batchexecute?bl=boq_labs-tailwind-frontend_20251001.05_p0&amp;f.sid=-77&amp;hl=en">`,
+			want: pageParams{BL: "boq_labs-tailwind-frontend_20251001.05_p0", FSID: "-77"},
+		},
+		{
+			name: "WIZ_global_data laid out differently",
+			page: `<script>window.WIZ_global_data = {"FdrFJe": -42, "cfb2h": "boq_spaced", "nested": {"a": [1]}};</script>`,
+			want: pageParams{BL: "boq_spaced", FSID: "-42"},
+		},
+		{
+			name: "not found",
+			page: `<html>Sign in</html>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractPageParams([]byte(tt.page)); *got != tt.want {
+				t.Errorf("extractPageParams() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageParamsStaleDefaults(t *testing.T) {
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := http.StatusBadRequest, "bad request"
+		if req.Method == "GET" {
+			status, body = http.StatusOK, "<html>Sign in</html>"
+		}
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+	})
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}), WithPageParams(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-c.pageParams.done
+	_, err = c.Raw("wXbhsf", "")
+	if err == nil || !strings.Contains(err.Error(), "stale defaults for bl and f.sid: fetch page parameters: not found in page") {
+		t.Errorf("Raw() error = %v, want it to mention the stale defaults", err)
+	}
+	var be *batchexecute.BatchExecuteError
+	if !errors.As(err, &be) || be.StatusCode != http.StatusBadRequest {
+		t.Errorf("Raw() error = %v, want a BatchExecuteError for HTTP 400", err)
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	rt := &recordingTransport{status: http.StatusOK}
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logged strings.Builder
+	c.GenerateFreeFormStreamed("nb1", "why?", nil,
+		CallSources("s9"),
+		CallLocale("de"),
+		CallDebug(func(format string, args ...interface{}) { fmt.Fprintf(&logged, format, args...) }))
+	if len(rt.reqs) != 1 {
+		t.Fatalf("made %d requests, want 1: CallSources should skip listing the sources", len(rt.reqs))
+	}
+	req := rt.reqs[0]
+	body, _ := io.ReadAll(req.Body)
+	if !strings.Contains(string(body), "s9") {
+		t.Errorf("request body %s does not name source s9", body)
+	}
+	if req.URL.Query().Get("hl") != "de" || req.Header.Get("accept-language") != "de" {
+		t.Errorf("request URL = %s, accept-language = %q; want locale de", req.URL, req.Header.Get("accept-language"))
+	}
+	if logged.Len() == 0 {
+		t.Error("CallDebug logged nothing")
+	}
+
+	// The overrides are for that call only.
+	logged.Reset()
+	c.GenerateFreeFormStreamed("nb1", "why?", []string{"s1"})
+	if q := rt.reqs[len(rt.reqs)-1].URL.Query(); q.Get("hl") == "de" {
+		t.Errorf("second call has hl=%s", q.Get("hl"))
+	}
+	if logged.Len() != 0 {
+		t.Errorf("second call logged %q", logged.String())
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	c, err := New(context.Background(),
+		WithAuth("tok", "SID=1"),
+		WithHTTPClient(&http.Client{Transport: blockingTransport{}}),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	err = c.GenerateFreeFormStreamedWithCallback("nb1", "why?", []string{"s1"}, func(string) bool { return true }, CallTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("call took %v despite a 50ms timeout", d)
+	}
+}
+
+// countingTransport answers every request after a pause, recording how
+// many were in flight at once. It is safe for concurrent use.
+type countingTransport struct {
+	mu             sync.Mutex
+	inFlight, peak int
+	total          int
+}
+
+func (ct *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ct.mu.Lock()
+	ct.inFlight++
+	ct.total++
+	ct.peak = max(ct.peak, ct.inFlight)
+	ct.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	ct.mu.Lock()
+	ct.inFlight--
+	ct.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(")]}'\n\n[]")),
+		Header:     http.Header{},
+		Request:    req,
+	}, nil
+}
+
+// TestClientConcurrent exercises a shared client from many goroutines;
+// run it with -race.
+func TestClientConcurrent(t *testing.T) {
+	ct := &countingTransport{}
+	stats := &batchexecute.Stats{}
+	c, err := New(context.Background(),
+		WithAuth("tok", "SID=1"),
+		WithHTTPClient(&http.Client{Transport: ct}),
+		WithMaxConcurrency(3),
+		WithBatchExecuteOptions(batchexecute.WithStats(stats), batchexecute.WithCurl(io.Discard, false)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := fmt.Sprintf("nb%d", i)
+			switch i % 3 {
+			case 0:
+				c.GetProject(id)
+			case 1:
+				c.ListRecentlyViewedProjects()
+			case 2:
+				c.GenerateFreeFormStreamed(id, "why?", []string{"s1"}, CallTimeout(time.Minute), CallLocale("de"))
+			}
+		}()
+	}
+	wg.Wait()
+	if ct.peak > 3 {
+		t.Errorf("%d requests in flight at once, want at most 3", ct.peak)
+	}
+	if got := stats.Snapshot().Requests; got != ct.total {
+		t.Errorf("stats counted %d requests, transport saw %d", got, ct.total)
+	}
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/filelock"
+)
+
+// pageURL is the page whose WIZ_global_data holds the current build
+// label and session ID.
+const pageURL = "https://notebooklm.google.com/"
+
+// pageParams are the build label and session ID the web app sends with
+// each RPC as the bl and f.sid URL parameters.
+type pageParams struct {
+	BL   string `json:"bl"`
+	FSID string `json:"f.sid"`
+}
+
+// The patterns the parameters are found with, tried in order. The
+// alternatives find them in URLs the page embeds, for pages whose
+// WIZ_global_data is laid out differently.
+var (
+	blPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`"cfb2h":"([^"]+)"`),
+		regexp.MustCompile(`[?&;]bl=(boq_[\w.-]+)`),
+	}
+	fsidPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`"FdrFJe":"(-?\d+)"`),
+		regexp.MustCompile(`[?&;]f\.sid=(-?\d+)`),
+	}
+)
+
+// DefaultPageParamsCache returns ~/.nlm/params.json, where the CLI keeps
+// the parameters fetched by WithPageParams.
+func DefaultPageParamsCache() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "params.json"), nil
+}
+
+// WithPageParams fetches the current bl and f.sid URL parameters from the
+// NotebookLM page in the background when the client is created, so that
+// the first RPC does not wait for a page download. Until the fetch is
+// done, RPCs use the parameters an earlier fetch saved in cachePath, or
+// built-in ones if there are none. An empty cachePath saves nothing.
+func WithPageParams(cachePath string) Option {
+	return func(o *options) {
+		o.pageParams = &cachePath
+	}
+}
+
+// paramsWarmer holds the page parameters RPCs are sent with, and fetches
+// newer ones.
+type paramsWarmer struct {
+	cachePath string
+	logf      func(format string, args ...interface{}) // debug logger, or nil
+	done      chan struct{}                            // closed when the fetch has finished
+
+	mu       sync.Mutex
+	params   pageParams
+	fetchErr error // why the fetch failed, if it has
+}
+
+// newParamsWarmer starts fetching the page parameters with hc, using the
+// cached ones meanwhile. A failure to cache them is reported to logf, if
+// it is not nil.
+func newParamsWarmer(ctx context.Context, hc *http.Client, cookies, cachePath string, logf func(format string, args ...interface{})) *paramsWarmer {
+	w := &paramsWarmer{cachePath: cachePath, logf: logf, done: make(chan struct{})}
+	if cachePath != "" {
+		if data, err := os.ReadFile(cachePath); err == nil {
+			json.Unmarshal(data, &w.params)
+		}
+	}
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	go func() {
+		defer close(w.done)
+		p, err := fetchPageParams(ctx, hc, cookies)
+		if err != nil {
+			w.mu.Lock()
+			w.fetchErr = err
+			w.mu.Unlock()
+			return
+		}
+		w.mu.Lock()
+		w.params = *p
+		w.mu.Unlock()
+		if err := w.save(p); err != nil && w.logf != nil {
+			w.logf("page parameters not cached in %s: %v", w.cachePath, err)
+		}
+	}()
+	return w
+}
+
+// save writes p to the cache file. Every CLI run fetches the parameters,
+// so the write is locked against other nlm processes doing the same.
+func (w *paramsWarmer) save(p *pageParams) error {
+	if w.cachePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encode page parameters: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(w.cachePath), 0700); err != nil {
+		return fmt.Errorf("create page parameters directory: %w", err)
+	}
+	return filelock.With(w.cachePath, func() error {
+		tmp := w.cachePath + ".tmp"
+		if err := os.WriteFile(tmp, data, 0600); err != nil {
+			return fmt.Errorf("write page parameters: %w", err)
+		}
+		if err := os.Rename(tmp, w.cachePath); err != nil {
+			return fmt.Errorf("write page parameters: %w", err)
+		}
+		return nil
+	})
+}
+
+// intercept sends each RPC with the latest parameters known. It never
+// waits for the fetch.
+func (w *paramsWarmer) intercept(next batchexecute.Invoker) batchexecute.Invoker {
+	return func(ctx context.Context, rpcs []batchexecute.RPC) (*batchexecute.Response, error) {
+		w.mu.Lock()
+		p, fetchErr := w.params, w.fetchErr
+		w.mu.Unlock()
+		if p.BL == "" || p.FSID == "" || len(rpcs) == 0 {
+			resp, err := next(ctx, rpcs)
+			return resp, staleDefaultsError(err, fetchErr)
+		}
+		rpcs = append([]batchexecute.RPC(nil), rpcs...)
+		params := maps.Clone(rpcs[0].URLParams)
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params["bl"], params["f.sid"] = p.BL, p.FSID
+		rpcs[0].URLParams = params
+		return next(ctx, rpcs)
+	}
+}
+
+// fetchPageParams reads the build label and session ID from the
+// NotebookLM page.
+func fetchPageParams(ctx context.Context, hc *http.Client, cookies string) (*pageParams, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Cookie", cookies)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch page parameters: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch page parameters: %s", resp.Status)
+	}
+	page, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("fetch page parameters: %w", err)
+	}
+	p := extractPageParams(page)
+	if p.BL == "" || p.FSID == "" {
+		return nil, fmt.Errorf("fetch page parameters: not found in page (bl found: %t, f.sid found: %t, %d bytes)",
+			p.BL != "", p.FSID != "", len(page))
+	}
+	return p, nil
+}
+
+// extractPageParams finds the parameters in page with the patterns, then,
+// for any still missing, in the WIZ_global_data object decoded as JSON.
+func extractPageParams(page []byte) *pageParams {
+	p := &pageParams{
+		BL:   findFirst(page, blPatterns),
+		FSID: findFirst(page, fsidPatterns),
+	}
+	if p.BL != "" && p.FSID != "" {
+		return p
+	}
+	wiz := wizGlobalData(page)
+	if p.BL == "" {
+		p.BL = jsonScalar(wiz["cfb2h"])
+	}
+	if p.FSID == "" {
+		p.FSID = jsonScalar(wiz["FdrFJe"])
+	}
+	return p
+}
+
+// findFirst returns the first group of the first of patterns that
+// matches page.
+func findFirst(page []byte, patterns []*regexp.Regexp) string {
+	for _, re := range patterns {
+		if m := re.FindSubmatch(page); m != nil {
+			return string(m[1])
+		}
+	}
+	return ""
+}
+
+// wizGlobalData decodes the object assigned to WIZ_global_data in page,
+// or returns nil if there is none.
+func wizGlobalData(page []byte) map[string]json.RawMessage {
+	i := bytes.Index(page, []byte("WIZ_global_data"))
+	if i < 0 {
+		return nil
+	}
+	j := bytes.IndexByte(page[i:], '{')
+	if j < 0 {
+		return nil
+	}
+	var data map[string]json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(page[i+j:])).Decode(&data); err != nil {
+		return nil
+	}
+	return data
+}
+
+// jsonScalar returns a JSON string's value or a number's text, and ""
+// for anything else.
+func jsonScalar(raw json.RawMessage) string {
+	var v interface{}
+	if json.Unmarshal(raw, &v) != nil {
+		return ""
+	}
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return string(raw)
+	}
+	return ""
+}
+
+// staleDefaultsError notes on an HTTP 400 from an RPC sent with the
+// built-in parameters that they may be out of date, which is the usual
+// cause when the page parameters could not be fetched.
+func staleDefaultsError(err, fetchErr error) error {
+	var be *batchexecute.BatchExecuteError
+	if !errors.As(err, &be) || be.StatusCode != http.StatusBadRequest {
+		return err
+	}
+	if fetchErr != nil {
+		return fmt.Errorf("%w (sent with stale defaults for bl and f.sid: %v)", err, fetchErr)
+	}
+	return fmt.Errorf("%w (sent with stale defaults for bl and f.sid)", err)
+}
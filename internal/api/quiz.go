@@ -0,0 +1,132 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuizOption is one answer choice for a quiz question.
+type QuizOption struct {
+	Text      string `json:"text"`
+	Correct   bool   `json:"correct"`
+	Rationale string `json:"rationale,omitempty"`
+}
+
+// QuizQuestion is a multiple-choice quiz question.
+type QuizQuestion struct {
+	Question string       `json:"question"`
+	Hint     string       `json:"hint,omitempty"`
+	Options  []QuizOption `json:"options"`
+}
+
+// Quiz is a decoded quiz artifact.
+type Quiz struct {
+	*Artifact
+	Questions []QuizQuestion
+}
+
+// GetQuiz fetches a quiz artifact and decodes its questions.
+func (c *Client) GetQuiz(projectID, artifactID string) (_ *Quiz, err error) {
+	defer wrapError(&err, "GetQuiz", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	data, err := c.getArtifactData(projectID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	q, err := decodeQuiz(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode quiz %s: %w", artifactID, err)
+	}
+	return q, nil
+}
+
+// decodeQuiz finds the questions in a quiz artifact. They are carried as a
+// JSON document inside the entry holding a "quiz" (or "questions") list;
+// each question has "answerOptions" with "text", "isCorrect" and
+// "rationale" fields.
+func decodeQuiz(data []interface{}) (*Quiz, error) {
+	entry := artifactEntry(data)
+	artifact := parseArtifact(entry)
+	if artifact == nil {
+		return nil, fmt.Errorf("no artifact in response")
+	}
+	for _, doc := range artifactJSONDocs(entry[1:]) {
+		if questions := quizFromJSON(doc); len(questions) > 0 {
+			return &Quiz{Artifact: artifact, Questions: questions}, nil
+		}
+	}
+	return nil, fmt.Errorf("no quiz data found")
+}
+
+func quizFromJSON(doc map[string]interface{}) []QuizQuestion {
+	list, ok := doc["quiz"].([]interface{})
+	if !ok {
+		list, _ = doc["questions"].([]interface{})
+	}
+	var questions []QuizQuestion
+	for _, item := range list {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		q := QuizQuestion{
+			Question: strings.TrimSpace(firstString(obj, "question", "text")),
+			Hint:     strings.TrimSpace(firstString(obj, "hint")),
+		}
+		options, ok := obj["answerOptions"].([]interface{})
+		if !ok {
+			options, _ = obj["options"].([]interface{})
+		}
+		for _, o := range options {
+			opt, ok := o.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			correct, _ := opt["isCorrect"].(bool)
+			if !correct {
+				correct, _ = opt["correct"].(bool)
+			}
+			q.Options = append(q.Options, QuizOption{
+				Text:      strings.TrimSpace(firstString(opt, "text")),
+				Correct:   correct,
+				Rationale: strings.TrimSpace(firstString(opt, "rationale")),
+			})
+		}
+		if q.Question != "" && len(q.Options) > 0 {
+			questions = append(questions, q)
+		}
+	}
+	return questions
+}
+
+// giftEscaper escapes the characters that are special in GIFT text.
+var giftEscaper = strings.NewReplacer(
+	`~`, `\~`, `=`, `\=`, `#`, `\#`, `{`, `\{`, `}`, `\}`, `:`, `\:`, "\n", `\n`,
+)
+
+// GIFT renders the quiz in Moodle's GIFT import format. Option rationales
+// become per-answer feedback.
+func (q *Quiz) GIFT() string {
+	var b strings.Builder
+	if q.Artifact != nil && q.Title != "" {
+		fmt.Fprintf(&b, "// %s\n\n", strings.ReplaceAll(q.Title, "\n", " "))
+	}
+	for i, question := range q.Questions {
+		fmt.Fprintf(&b, "::Q%d:: %s {\n", i+1, giftEscaper.Replace(question.Question))
+		for _, opt := range question.Options {
+			mark := "~"
+			if opt.Correct {
+				mark = "="
+			}
+			fmt.Fprintf(&b, "\t%s%s", mark, giftEscaper.Replace(opt.Text))
+			if opt.Rationale != "" {
+				fmt.Fprintf(&b, " #%s", giftEscaper.Replace(opt.Rationale))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeQuiz(t *testing.T) {
+	input := `[["qz1", "Go Quiz", 7, [], 2, "{\"quiz\":[{\"question\":\"What does go fmt do?\",\"hint\":\"Think style\",\"answerOptions\":[{\"text\":\"Formats code\",\"isCorrect\":true,\"rationale\":\"It rewrites source\"},{\"text\":\"Runs tests\",\"isCorrect\":false}]},{\"question\":\"No options\"}]}"]]`
+	var data []interface{}
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeQuiz(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []QuizQuestion{{
+		Question: "What does go fmt do?",
+		Hint:     "Think style",
+		Options: []QuizOption{
+			{Text: "Formats code", Correct: true, Rationale: "It rewrites source"},
+			{Text: "Runs tests"},
+		},
+	}}
+	if diff := cmp.Diff(want, got.Questions); diff != "" {
+		t.Errorf("decodeQuiz() mismatch (-want +got):\n%s", diff)
+	}
+	if got.Title != "Go Quiz" {
+		t.Errorf("Title = %q, want Go Quiz", got.Title)
+	}
+}
+
+func TestQuizGIFT(t *testing.T) {
+	q := &Quiz{
+		Artifact: &Artifact{Title: "Go Quiz"},
+		Questions: []QuizQuestion{{
+			Question: "What is 1 + 1 = ?",
+			Options: []QuizOption{
+				{Text: "2", Correct: true, Rationale: "Basic math: addition"},
+				{Text: "{3}"},
+			},
+		}},
+	}
+	want := "// Go Quiz\n\n::Q1:: What is 1 + 1 \\= ? {\n\t=2 #Basic math\\: addition\n\t~\\{3\\}\n}\n\n"
+	if got := q.GIFT(); got != want {
+		t.Errorf("GIFT() =\n%q\nwant\n%q", got, want)
+	}
+}
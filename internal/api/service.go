@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"io"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+)
+
+// NotebookService is the NotebookLM API as Client exposes it, so that
+// code using it can be tested against a fake such as nlmtest.Fake rather
+// than the network. It leaves out Client's transport-level methods, such
+// as Raw and the downloads that need its session.
+type NotebookService interface {
+	// Notebooks
+	ListRecentlyViewedProjects() ([]*Notebook, error)
+	CreateProject(title string, emoji string) (*Notebook, error)
+	GetProject(projectID string) (*Notebook, error)
+	GetProjectIfChanged(projectID, version string) (*Notebook, string, error)
+	DeleteProjects(projectIDs []string) error
+	MutateProject(projectID string, updates *pb.Project) (*Notebook, error)
+	RemoveRecentlyViewedProject(projectID string) error
+
+	// Sources
+	AddSources(projectID string, sources []*pb.SourceInput) (*pb.Project, error)
+	AddSourceFromReader(projectID string, r io.Reader, filename string, contentType ...string) (string, error)
+	AddSourceFromText(projectID string, content, title string) (string, error)
+	AddSourceFromBase64(projectID string, content, filename, contentType string) (string, error)
+	AddSourceFromFile(projectID string, filepath string, contentType ...string) (string, error)
+	AddSourceFromURL(projectID string, url string) (string, error)
+	AddYouTubeSource(projectID, videoID string) (string, error)
+	DeleteSources(projectID string, sourceIDs []string) error
+	MutateSource(sourceID string, updates *pb.Source) (*pb.Source, error)
+	RefreshSource(sourceID string) (*pb.Source, error)
+	LoadSource(sourceID string) (*pb.Source, error)
+	CheckSourceFreshness(sourceID string) (*pb.CheckSourceFreshnessResponse, error)
+	ActOnSources(projectID string, action string, sourceIDs []string) error
+
+	// Notes
+	GetNotes(projectID string) ([]*Note, error)
+	CreateNote(projectID string, title string, initialContent string) (*Note, error)
+	MutateNote(projectID string, noteID string, content string, title string) (*Note, error)
+	DeleteNotes(projectID string, noteIDs []string) error
+
+	// Chat and generation
+	GenerateFreeFormStreamed(projectID string, prompt string, sourceIDs []string, opts ...CallOption) (*pb.GenerateFreeFormStreamedResponse, error)
+	GenerateFreeFormStreamedWithCallback(projectID string, prompt string, sourceIDs []string, callback func(chunk string) bool, opts ...CallOption) error
+	GenerateDocumentGuides(projectID string) (*pb.GenerateDocumentGuidesResponse, error)
+	GenerateNotebookGuide(projectID string) (*pb.GenerateNotebookGuideResponse, error)
+	GenerateMagicView(projectID string, sourceIDs []string) (*pb.GenerateMagicViewResponse, error)
+	GenerateOutline(projectID string) (*pb.GenerateOutlineResponse, error)
+	GenerateSection(projectID string) (*pb.GenerateSectionResponse, error)
+	GenerateReportSuggestions(projectID string) (*pb.GenerateReportSuggestionsResponse, error)
+	StartDraft(projectID string) (*pb.StartDraftResponse, error)
+	StartSection(projectID string) (*pb.StartSectionResponse, error)
+
+	// Artifacts
+	ListArtifacts(projectID string) ([]*Artifact, error)
+	CreateArtifact(projectID string, kind ArtifactKind, opts *CreateArtifactOptions) (*Artifact, error)
+	GetArtifact(artifactID string) (*pb.Artifact, error)
+	GetArtifactContent(projectID, artifactID string) (*ArtifactContent, error)
+	RenameArtifact(artifactID, newTitle string) (*Artifact, error)
+	UpdateArtifact(projectID, artifactID string, update ArtifactUpdate) (*Artifact, error)
+	DeleteArtifact(artifactID string) error
+	SaveArtifactAsNote(projectID, artifactID, title string) (*Note, error)
+	SaveArtifactAsSource(projectID, artifactID, title string) (string, error)
+	GetFlashcards(projectID, artifactID string) (*Flashcards, error)
+	GetMindMap(projectID, artifactID string) (*MindMap, error)
+	GetQuiz(projectID, artifactID string) (*Quiz, error)
+	WaitForArtifact(ctx context.Context, notebookID, artifactID string, opts *WaitOptions) (*pb.Artifact, error)
+
+	// Audio and video overviews
+	CreateAudioOverview(projectID string, instructions string) (*AudioOverviewResult, error)
+	CreateAudioOverviewWithOptions(projectID string, opts AudioOverviewOptions) (*AudioOverviewResult, error)
+	GetAudioOverview(projectID string) (*AudioOverviewResult, error)
+	ListAudioOverviews(projectID string) ([]*AudioOverviewResult, error)
+	DownloadAudioOverview(projectID string) (*AudioOverviewResult, error)
+	DeleteAudioOverview(projectID string) error
+	WaitForAudio(ctx context.Context, notebookID, audioID string, opts *WaitOptions) (*AudioOverviewResult, error)
+	CreateVideoOverview(projectID string, instructions string) (*VideoOverviewResult, error)
+	GetVideoOverview(projectID string) (*VideoOverviewResult, error)
+	ListVideoOverviews(projectID string) ([]*VideoOverviewResult, error)
+	DownloadVideoOverview(projectID string) (*VideoOverviewResult, error)
+	WaitForVideo(ctx context.Context, notebookID, videoID string, opts *WaitOptions) (*VideoOverviewResult, error)
+
+	// Sharing
+	ShareProject(projectID string, settings *pb.ShareSettings) (*pb.ShareProjectResponse, error)
+	ShareAudio(projectID string, shareOption ShareOption) (*ShareAudioResult, error)
+	ShareNotebook(projectID string, invites []ShareInvite) (*ShareInfo, error)
+	ShareNotebookWithOptions(projectID string, invites []ShareInvite, opts InviteOptions) (*ShareInfo, error)
+	GetShareInfo(projectID string) (*ShareInfo, error)
+	RevokeAccess(projectID string, emails []string) (*ShareInfo, error)
+	SetLinkAccess(projectID string, access LinkAccess) (*ShareInfo, error)
+
+	// Guidebooks
+	GetGuidebook(guidebookID string) (*pb.Guidebook, error)
+	GetGuidebookDetails(guidebookID string) (*pb.GuidebookDetails, error)
+	PublishGuidebook(guidebookID string, opts PublishGuidebookOptions) (*pb.PublishGuidebookResponse, error)
+	CreateGuidebookFromNotebook(projectID string, opts PublishGuidebookOptions) (*pb.PublishGuidebookResponse, error)
+	AskGuidebook(guidebookID, question string) (*pb.GuidebookGenerateAnswerResponse, error)
+	AskGuidebookStream(guidebookID, question string, fn func(chunk string) bool) (*pb.GuidebookGenerateAnswerResponse, error)
+}
+
+var _ NotebookService = (*Client)(nil)
@@ -0,0 +1,524 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/rpc"
+)
+
+// Notebook sharing
+
+// ShareRole is a collaborator's access level on a shared notebook. The
+// values are the role codes the sharing RPCs use on the wire.
+type ShareRole int
+
+const (
+	ShareRoleUnknown ShareRole = 0
+	ShareRoleOwner   ShareRole = 1
+	ShareRoleEditor  ShareRole = 2
+	ShareRoleViewer  ShareRole = 3
+)
+
+func (r ShareRole) String() string {
+	switch r {
+	case ShareRoleOwner:
+		return "owner"
+	case ShareRoleEditor:
+		return "editor"
+	case ShareRoleViewer:
+		return "viewer"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the role by name, as in JSON output.
+func (r ShareRole) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// ParseShareRole parses a role name as accepted on the command line.
+// Only roles that can be granted are accepted.
+func ParseShareRole(s string) (ShareRole, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "viewer", "reader":
+		return ShareRoleViewer, nil
+	case "editor", "writer":
+		return ShareRoleEditor, nil
+	default:
+		return ShareRoleUnknown, fmt.Errorf("unknown role %q (want viewer or editor)", s)
+	}
+}
+
+// LinkAccess is who can open a notebook from its link without being
+// invited. The values are the access codes used on the wire.
+type LinkAccess int
+
+const (
+	LinkRestricted LinkAccess = 0
+	LinkAnyone     LinkAccess = 1
+	// LinkDomain limits the link to people in the owner's Google
+	// Workspace organization.
+	LinkDomain LinkAccess = 2
+)
+
+func (a LinkAccess) String() string {
+	switch a {
+	case LinkAnyone:
+		return "anyone"
+	case LinkDomain:
+		return "domain"
+	default:
+		return "restricted"
+	}
+}
+
+// MarshalText encodes the link access by name, as in JSON output.
+func (a LinkAccess) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// ErrDomainSharingUnsupported is returned when domain-restricted sharing
+// is requested for an account outside a Google Workspace organization.
+var ErrDomainSharingUnsupported = errors.New("domain sharing requires a Google Workspace account")
+
+// ParseLinkAccess parses a link audience as accepted on the command line.
+func ParseLinkAccess(s string) (LinkAccess, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "anyone", "public":
+		return LinkAnyone, nil
+	case "restricted", "private":
+		return LinkRestricted, nil
+	case "domain", "org", "organization":
+		return LinkDomain, nil
+	default:
+		return LinkRestricted, fmt.Errorf("unknown link access %q (want anyone, domain or restricted)", s)
+	}
+}
+
+// NotebookURL returns the canonical URL of a notebook, which is also its
+// share link.
+func NotebookURL(projectID string) string {
+	return "https://notebooklm.google.com/notebook/" + projectID
+}
+
+// ShareInvite grants one person access to a notebook.
+type ShareInvite struct {
+	Email string
+	Role  ShareRole
+}
+
+// Collaborator is a person with access to a shared notebook.
+type Collaborator struct {
+	Email string
+	Name  string
+	Role  ShareRole
+}
+
+// Person identifies a Google account.
+type Person struct {
+	Email string
+	Name  string
+}
+
+func (p *Person) String() string {
+	switch {
+	case p == nil:
+		return ""
+	case p.Name == "":
+		return p.Email
+	case p.Email == "":
+		return p.Name
+	default:
+		return fmt.Sprintf("%s <%s>", p.Name, p.Email)
+	}
+}
+
+// InviteOptions controls the invitation email sent to new collaborators.
+// The zero value sends the default invitation, as the web UI does.
+type InviteOptions struct {
+	// SkipNotify shares without emailing the invitees.
+	SkipNotify bool
+	// Message is included in the invitation email.
+	Message string
+}
+
+// ShareInfo is the sharing state of a notebook.
+type ShareInfo struct {
+	ProjectID string
+	ShareURL  string
+	Access    LinkAccess
+	// Domain is the organization the link is limited to when Access is
+	// LinkDomain, if the server reports it.
+	Domain        string
+	Collaborators []Collaborator
+	// Owner, CreatedBy and LastModifiedBy are nil when the server does not
+	// report them; ShareProject responses usually do not.
+	Owner          *Person
+	CreatedBy      *Person
+	LastModifiedBy *Person
+	LastModified   time.Time
+}
+
+// ShareNotebook grants the invited people access to a notebook and returns
+// the resulting share state. Invitees are notified by email.
+func (c *Client) ShareNotebook(projectID string, invites []ShareInvite) (_ *ShareInfo, err error) {
+	defer wrapError(&err, "ShareNotebook", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	return c.ShareNotebookWithOptions(projectID, invites, InviteOptions{})
+}
+
+// ShareNotebookWithOptions is like ShareNotebook but controls the
+// invitation email.
+func (c *Client) ShareNotebookWithOptions(projectID string, invites []ShareInvite, opts InviteOptions) (_ *ShareInfo, err error) {
+	defer wrapError(&err, "ShareNotebookWithOptions", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if len(invites) == 0 {
+		return nil, fmt.Errorf("at least one invite required")
+	}
+	normalized := make([]ShareInvite, 0, len(invites))
+	for _, inv := range invites {
+		addr, err := mail.ParseAddress(inv.Email)
+		if err != nil {
+			return nil, fmt.Errorf("invalid email %q", inv.Email)
+		}
+		if inv.Role != ShareRoleViewer && inv.Role != ShareRoleEditor {
+			return nil, fmt.Errorf("invalid role %v for %s", inv.Role, addr.Address)
+		}
+		normalized = append(normalized, ShareInvite{Email: addr.Address, Role: inv.Role})
+	}
+	invites = normalized
+
+	info, err := c.updateSharing(projectID, encodeShareNotebookArgs(projectID, invites, opts))
+	if err != nil {
+		return nil, fmt.Errorf("share notebook: %w", err)
+	}
+	// The response usually carries only what changed, so make sure every
+	// invitee is listed.
+	for _, inv := range invites {
+		if info.collaborator(inv.Email) == nil {
+			info.Collaborators = append(info.Collaborators, Collaborator{Email: inv.Email, Role: inv.Role})
+		}
+	}
+	return info, nil
+}
+
+// GetShareInfo returns a notebook's collaborators and link access.
+func (c *Client) GetShareInfo(projectID string) (_ *ShareInfo, err error) {
+	defer wrapError(&err, "GetShareInfo", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:         rpc.RPCGetProjectDetails,
+		Args:       []interface{}{projectID, []interface{}{2}},
+		NotebookID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get share info: %w", err)
+	}
+	data, err := decodeShareResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("get share info: %w", err)
+	}
+	if c.config.Debug {
+		fmt.Printf("Share info response: %+v\n", data)
+	}
+	info := decodeShareState(data)
+	info.ProjectID = projectID
+	if info.ShareURL == "" {
+		info.ShareURL = NotebookURL(projectID)
+	}
+	return info, nil
+}
+
+// RevokeAccess removes the given people's access to a notebook.
+func (c *Client) RevokeAccess(projectID string, emails []string) (_ *ShareInfo, err error) {
+	defer wrapError(&err, "RevokeAccess", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("at least one email required")
+	}
+	addrs := make([]string, 0, len(emails))
+	for _, e := range emails {
+		addr, err := mail.ParseAddress(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid email %q", e)
+		}
+		addrs = append(addrs, addr.Address)
+	}
+
+	info, err := c.updateSharing(projectID, encodeRevokeAccessArgs(projectID, addrs))
+	if err != nil {
+		return nil, fmt.Errorf("revoke access: %w", err)
+	}
+	kept := info.Collaborators[:0]
+	for _, collab := range info.Collaborators {
+		if !containsFold(addrs, collab.Email) {
+			kept = append(kept, collab)
+		}
+	}
+	info.Collaborators = kept
+	return info, nil
+}
+
+// SetLinkAccess sets who can open a notebook from its link. Anyone let in
+// by the link joins as a viewer; collaborators keep the access they were
+// given.
+func (c *Client) SetLinkAccess(projectID string, access LinkAccess) (_ *ShareInfo, err error) {
+	defer wrapError(&err, "SetLinkAccess", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	info, err := c.updateSharing(projectID, encodeLinkAccessArgs(projectID, access))
+	if err != nil {
+		if access == LinkDomain && isUnsupportedError(err) {
+			return nil, fmt.Errorf("set link access: %w", ErrDomainSharingUnsupported)
+		}
+		return nil, fmt.Errorf("set link access: %w", err)
+	}
+	info.Access = access
+	return info, nil
+}
+
+// updateSharing sends a ShareProject payload and decodes the share state
+// in the response.
+func (c *Client) updateSharing(projectID string, args []interface{}) (*ShareInfo, error) {
+	resp, err := c.rpc.Do(rpc.Call{
+		ID:         rpc.RPCShareProject,
+		Args:       args,
+		NotebookID: projectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := decodeShareResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if c.config.Debug {
+		fmt.Printf("Share project response: %+v\n", data)
+	}
+	info := decodeShareState(data)
+	info.ProjectID = projectID
+	if info.ShareURL == "" {
+		info.ShareURL = NotebookURL(projectID)
+	}
+	return info, nil
+}
+
+func decodeShareResponse(resp []byte) ([]interface{}, error) {
+	var data []interface{}
+	if len(resp) == 0 {
+		return data, nil
+	}
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("parse share response: %w", err)
+	}
+	return data, nil
+}
+
+// shareRoleRemoved is the role code that removes a collaborator.
+const shareRoleRemoved = 4
+
+// encodeShareNotebookArgs builds the ShareProject payload for inviting
+// collaborators:
+//
+//	[[[project-id, [[email, null, role]...], null, [notify, message]]], 1, null, [2]]
+//
+// where notify is 1 to send the invitation email.
+func encodeShareNotebookArgs(projectID string, invites []ShareInvite, opts InviteOptions) []interface{} {
+	users := make([]interface{}, 0, len(invites))
+	for _, inv := range invites {
+		users = append(users, []interface{}{inv.Email, nil, int(inv.Role)})
+	}
+	notify := 1
+	if opts.SkipNotify {
+		notify = 0
+	}
+	return []interface{}{
+		[]interface{}{
+			[]interface{}{projectID, users, nil, []interface{}{notify, opts.Message}},
+		},
+		1,
+		nil,
+		[]interface{}{2},
+	}
+}
+
+// encodeRevokeAccessArgs builds the ShareProject payload for removing
+// collaborators. It is the invite payload with the removal role and no
+// notification:
+//
+//	[[[project-id, [[email, null, 4]...], null, [0, ""]]], 1, null, [2]]
+func encodeRevokeAccessArgs(projectID string, emails []string) []interface{} {
+	users := make([]interface{}, 0, len(emails))
+	for _, e := range emails {
+		users = append(users, []interface{}{e, nil, shareRoleRemoved})
+	}
+	return []interface{}{
+		[]interface{}{
+			[]interface{}{projectID, users, nil, []interface{}{0, ""}},
+		},
+		1,
+		nil,
+		[]interface{}{2},
+	}
+}
+
+// encodeLinkAccessArgs builds the ShareProject payload for changing link
+// access:
+//
+//	[[[project-id, null, [access], [access, ""]]], 1, null, [2]]
+func encodeLinkAccessArgs(projectID string, access LinkAccess) []interface{} {
+	return []interface{}{
+		[]interface{}{
+			[]interface{}{projectID, nil, []interface{}{int(access)}, []interface{}{int(access), ""}},
+		},
+		1,
+		nil,
+		[]interface{}{2},
+	}
+}
+
+// decodeShareState reads a share state payload, laid out as
+//
+//	[[[email, role, null, [name, avatar-url]]...], [access, domain], null,
+//	 [[creator-email, creator-name], [modifier-email, modifier-name], [seconds, nanos]]]
+//
+// where access is a LinkAccess code and domain names the organization a
+// domain-restricted link is limited to. The owner is the collaborator with
+// the owner role. Any notebook URL in the payload is taken as the share
+// URL.
+func decodeShareState(data []interface{}) *ShareInfo {
+	info := &ShareInfo{}
+	if len(data) > 0 {
+		if users, ok := data[0].([]interface{}); ok {
+			for _, u := range users {
+				if collab, ok := decodeCollaborator(u); ok {
+					info.Collaborators = append(info.Collaborators, collab)
+				}
+			}
+		}
+	}
+	if len(data) > 1 {
+		if access, ok := data[1].([]interface{}); ok && len(access) > 0 {
+			if n, ok := access[0].(float64); ok {
+				info.Access = LinkAccess(n)
+			}
+			if len(access) > 1 {
+				info.Domain, _ = access[1].(string)
+			}
+		}
+	}
+	for _, collab := range info.Collaborators {
+		if collab.Role == ShareRoleOwner {
+			info.Owner = &Person{Email: collab.Email, Name: collab.Name}
+			break
+		}
+	}
+	if len(data) > 3 {
+		if audit, ok := data[3].([]interface{}); ok {
+			if len(audit) > 0 {
+				info.CreatedBy = decodePerson(audit[0])
+			}
+			if len(audit) > 1 {
+				info.LastModifiedBy = decodePerson(audit[1])
+			}
+			if len(audit) > 2 {
+				info.LastModified, _ = parseTimestamp(audit[2])
+			}
+		}
+	}
+	info.ShareURL = findShareURL(data)
+	return info
+}
+
+// decodePerson reads an [email, name] pair.
+func decodePerson(v interface{}) *Person {
+	pair, ok := v.([]interface{})
+	if !ok || len(pair) == 0 {
+		return nil
+	}
+	p := &Person{}
+	p.Email, _ = pair[0].(string)
+	if len(pair) > 1 {
+		p.Name, _ = pair[1].(string)
+	}
+	if !strings.Contains(p.Email, "@") {
+		p.Email = ""
+	}
+	if p.Email == "" && p.Name == "" {
+		return nil
+	}
+	return p
+}
+
+func decodeCollaborator(v interface{}) (Collaborator, bool) {
+	entry, ok := v.([]interface{})
+	if !ok || len(entry) == 0 {
+		return Collaborator{}, false
+	}
+	email, ok := entry[0].(string)
+	if !ok || !strings.Contains(email, "@") {
+		return Collaborator{}, false
+	}
+	collab := Collaborator{Email: email}
+	if len(entry) > 1 {
+		if n, ok := entry[1].(float64); ok {
+			collab.Role = ShareRole(n)
+		}
+	}
+	if len(entry) > 3 {
+		if profile, ok := entry[3].([]interface{}); ok && len(profile) > 0 {
+			collab.Name, _ = profile[0].(string)
+		}
+	}
+	return collab, true
+}
+
+// findShareURL returns the first NotebookLM notebook URL in v.
+func findShareURL(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "https://notebooklm.google.com/") {
+			return v
+		}
+	case []interface{}:
+		for _, e := range v {
+			if u := findShareURL(e); u != "" {
+				return u
+			}
+		}
+	}
+	return ""
+}
+
+func (s *ShareInfo) collaborator(email string) *Collaborator {
+	for i := range s.Collaborators {
+		if strings.EqualFold(s.Collaborators[i].Email, email) {
+			return &s.Collaborators[i]
+		}
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, e := range list {
+		if strings.EqualFold(e, s) {
+			return true
+		}
+	}
+	return false
+}
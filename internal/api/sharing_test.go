@@ -0,0 +1,175 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncodeShareNotebookArgs(t *testing.T) {
+	invites := []ShareInvite{
+		{Email: "ada@example.com", Role: ShareRoleEditor},
+		{Email: "bob@example.com", Role: ShareRoleViewer},
+	}
+	tests := []struct {
+		name string
+		opts InviteOptions
+		want string
+	}{
+		{
+			name: "default",
+			want: `[[["nb1",[["ada@example.com",null,2],["bob@example.com",null,3]],null,[1,""]]],1,null,[2]]`,
+		},
+		{
+			name: "message",
+			opts: InviteOptions{Message: "Notes for Monday"},
+			want: `[[["nb1",[["ada@example.com",null,2],["bob@example.com",null,3]],null,[1,"Notes for Monday"]]],1,null,[2]]`,
+		},
+		{
+			name: "skip notify",
+			opts: InviteOptions{SkipNotify: true},
+			want: `[[["nb1",[["ada@example.com",null,2],["bob@example.com",null,3]],null,[0,""]]],1,null,[2]]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(encodeShareNotebookArgs("nb1", invites, tt.opts))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("encodeShareNotebookArgs() = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeRevokeAccessArgs(t *testing.T) {
+	b, err := json.Marshal(encodeRevokeAccessArgs("nb1", []string{"ada@example.com"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[[["nb1",[["ada@example.com",null,4]],null,[0,""]]],1,null,[2]]`
+	if string(b) != want {
+		t.Errorf("encodeRevokeAccessArgs() = %s, want %s", b, want)
+	}
+}
+
+func TestEncodeLinkAccessArgs(t *testing.T) {
+	tests := []struct {
+		access LinkAccess
+		want   string
+	}{
+		{LinkAnyone, `[[["nb1",null,[1],[1,""]]],1,null,[2]]`},
+		{LinkRestricted, `[[["nb1",null,[0],[0,""]]],1,null,[2]]`},
+		{LinkDomain, `[[["nb1",null,[2],[2,""]]],1,null,[2]]`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(encodeLinkAccessArgs("nb1", tt.access))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("encodeLinkAccessArgs(%v) = %s, want %s", tt.access, b, tt.want)
+		}
+	}
+}
+
+func TestDecodeShareState(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *ShareInfo
+	}{
+		{
+			name: "collaborators and public link",
+			input: `[[
+				["owner@example.com", 1, [], ["Owner Name", "https://lh3.googleusercontent.com/a"]],
+				["ada@example.com", 2, [], ["Ada"]],
+				["bob@example.com", 3],
+				["not-an-email", 2],
+				"stray"
+			], [1], "https://notebooklm.google.com/notebook/nb1"]`,
+			want: &ShareInfo{
+				ShareURL: "https://notebooklm.google.com/notebook/nb1",
+				Access:   LinkAnyone,
+				Collaborators: []Collaborator{
+					{Email: "owner@example.com", Name: "Owner Name", Role: ShareRoleOwner},
+					{Email: "ada@example.com", Name: "Ada", Role: ShareRoleEditor},
+					{Email: "bob@example.com", Role: ShareRoleViewer},
+				},
+				Owner: &Person{Email: "owner@example.com", Name: "Owner Name"},
+			},
+		},
+		{
+			name:  "restricted",
+			input: `[[["owner@example.com", 1]], [0]]`,
+			want: &ShareInfo{
+				Collaborators: []Collaborator{{Email: "owner@example.com", Role: ShareRoleOwner}},
+				Owner:         &Person{Email: "owner@example.com"},
+			},
+		},
+		{
+			name:  "domain restricted",
+			input: `[[["owner@corp.example", 1]], [2, "corp.example"]]`,
+			want: &ShareInfo{
+				Access:        LinkDomain,
+				Domain:        "corp.example",
+				Collaborators: []Collaborator{{Email: "owner@corp.example", Role: ShareRoleOwner}},
+				Owner:         &Person{Email: "owner@corp.example"},
+			},
+		},
+		{
+			name: "audit fields",
+			input: `[[["ada@example.com", 2]], [0], null, [
+				["owner@example.com", "Owner Name"],
+				["ada@example.com", "Ada"],
+				[1741824000, 0]
+			]]`,
+			want: &ShareInfo{
+				Collaborators:  []Collaborator{{Email: "ada@example.com", Role: ShareRoleEditor}},
+				CreatedBy:      &Person{Email: "owner@example.com", Name: "Owner Name"},
+				LastModifiedBy: &Person{Email: "ada@example.com", Name: "Ada"},
+				LastModified:   time.Unix(1741824000, 0),
+			},
+		},
+		{
+			name:  "empty",
+			input: `[]`,
+			want:  &ShareInfo{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []interface{}
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, decodeShareState(data)); diff != "" {
+				t.Errorf("decodeShareState() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseShareRole(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ShareRole
+		wantErr bool
+	}{
+		{"viewer", ShareRoleViewer, false},
+		{"Editor", ShareRoleEditor, false},
+		{"writer", ShareRoleEditor, false},
+		{"owner", ShareRoleUnknown, true},
+		{"", ShareRoleUnknown, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseShareRole(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseShareRole(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package api
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -88,11 +89,10 @@ func DebugHTTPRecorder(t *testing.T) {
 	httpClient := httprr.CreateNLMTestClient(t, http.DefaultTransport)
 
 	// Create client
-	client := New(
-		authToken,
-		cookies,
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(true),
+	client := mustNew(t,
+		WithAuth(authToken, cookies),
+		WithHTTPClient(httpClient),
+		WithBatchExecuteOptions(batchexecute.WithDebug(true)),
 	)
 
 	// Make a simple API call
@@ -163,6 +163,16 @@ func DebugDirectRequest(t *testing.T) {
 	}
 }
 
+// mustNew returns New(context.Background(), opts...), failing t on error.
+func mustNew(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+	c, err := New(context.Background(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
 // CreateMockClient creates a client configured for testing with mock responses
 func CreateMockClient(t *testing.T) *Client {
 	t.Helper()
@@ -171,11 +181,9 @@ func CreateMockClient(t *testing.T) *Client {
 	httpClient := httprr.CreateNLMTestClient(t, http.DefaultTransport)
 
 	// Use test credentials that will be scrubbed
-	return New(
-		"test-auth-token",
-		"test-cookies",
-		batchexecute.WithHTTPClient(httpClient),
-		batchexecute.WithDebug(false),
+	return mustNew(t,
+		WithAuth("test-auth-token", "test-cookies"),
+		WithHTTPClient(httpClient),
 	)
 }
 
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tmc/nlm/internal/redact"
+)
+
+// Limits Client methods check before sending a request, so that input
+// NotebookLM would refuse fails with a clear message rather than an
+// opaque server error.
+const (
+	// MaxSourceSize is the largest file a source may be made from.
+	MaxSourceSize = 200 << 20
+	// MaxSourceWords is the most words a text source may hold.
+	MaxSourceWords = 500000
+	// MaxPromptLength is the longest chat prompt, in characters.
+	MaxPromptLength = 100000
+)
+
+// maxIDLength is far longer than any real ID, which are UUIDs.
+const maxIDLength = 128
+
+var (
+	idPattern          = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	notebookURLPattern = regexp.MustCompile(`/notebook/([A-Za-z0-9_-]+)`)
+)
+
+// sourceMIMETypes are the types of files NotebookLM makes sources of,
+// besides text, which is sent as text. Entries ending in "/" or "." are
+// prefixes.
+var sourceMIMETypes = []string{
+	"application/pdf",
+	"application/epub+zip",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.",
+	"application/vnd.oasis.opendocument.",
+	"audio/",
+	"image/",
+	"video/",
+}
+
+// ValidationError reports input a Client method refused before sending
+// a request. It matches ErrInvalidArgument.
+type ValidationError struct {
+	What   string // what was refused, such as "notebook ID"
+	Value  string // the input, shortened if long; empty if not shown
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("invalid %s: %s", e.What, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s %q: %s", e.What, e.Value, e.Reason)
+}
+
+// validateIDs checks that each of ids looks like an ID of the given
+// kind, such as "notebook", and says how to fix one that does not.
+func validateIDs(kind string, ids ...string) error {
+	for _, id := range ids {
+		e := &ValidationError{What: kind + " ID", Value: redact.Truncate(id, 80)}
+		switch {
+		case id == "":
+			e.Reason = "it is empty"
+		case urlPattern.MatchString(id):
+			e.Reason = "it is a URL; pass only the ID"
+			if m := notebookURLPattern.FindStringSubmatch(id); m != nil && kind == "notebook" {
+				e.Reason = fmt.Sprintf("it is a URL; pass only the ID, %q", m[1])
+			}
+		case len(id) > maxIDLength:
+			e.Reason = fmt.Sprintf("it is longer than %d characters", maxIDLength)
+		case !idPattern.MatchString(id):
+			e.Reason = "IDs hold only letters, digits, '-' and '_'"
+		default:
+			continue
+		}
+		return e
+	}
+	return nil
+}
+
+// validatePrompt checks that a chat prompt is neither blank nor too long.
+func validatePrompt(prompt string) error {
+	if strings.TrimSpace(prompt) == "" {
+		return &ValidationError{What: "prompt", Reason: "it is empty"}
+	}
+	if n := utf8.RuneCountInString(prompt); n > MaxPromptLength {
+		return &ValidationError{What: "prompt", Reason: fmt.Sprintf("it is %d characters; the limit is %d", n, MaxPromptLength)}
+	}
+	return nil
+}
+
+// validateSourceFile checks that a file of size bytes and the given MIME
+// type can be made a source. An empty mimeType is not checked.
+func validateSourceFile(filename string, size int64, mimeType string) error {
+	if size > MaxSourceSize {
+		return &ValidationError{What: "source file", Value: filename, Reason: fmt.Sprintf("it is %d MB; the limit is %d MB", size>>20, MaxSourceSize>>20)}
+	}
+	if mimeType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return &ValidationError{What: "MIME type", Value: mimeType, Reason: "it cannot be parsed"}
+	}
+	if mediaType == "application/octet-stream" {
+		return &ValidationError{What: "source file", Value: filename, Reason: "its type is not recognized; give its MIME type"}
+	}
+	for _, t := range sourceMIMETypes {
+		prefix := strings.HasSuffix(t, "/") || strings.HasSuffix(t, ".")
+		if mediaType == t || prefix && strings.HasPrefix(mediaType, t) {
+			return nil
+		}
+	}
+	return &ValidationError{What: "source file", Value: filename, Reason: fmt.Sprintf("NotebookLM does not accept %s files", mediaType)}
+}
+
+// validateSourceText checks that text is not too long to be a source.
+func validateSourceText(title, text string) error {
+	if n := len(strings.Fields(text)); n > MaxSourceWords {
+		return &ValidationError{What: "text source", Value: title, Reason: fmt.Sprintf("it has %d words; the limit is %d", n, MaxSourceWords)}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateIDs(t *testing.T) {
+	tests := []struct {
+		kind, id string
+		want     string // substring of the error; empty for none
+	}{
+		{"notebook", "3a1b2c3d-0000-4000-8000-00000000abcd", ""},
+		{"source", "src_1", ""},
+		{"notebook", "", "it is empty"},
+		{"notebook", "https://notebooklm.google.com/notebook/abc-123?authuser=1", `pass only the ID, "abc-123"`},
+		{"source", "https://example.com/doc", "it is a URL; pass only the ID"},
+		{"notebook", "my notebook", "only letters, digits"},
+		{"notebook", strings.Repeat("a", 129), "longer than 128"},
+	}
+	for _, tt := range tests {
+		err := validateIDs(tt.kind, tt.id)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("validateIDs(%q, %q) = %v", tt.kind, tt.id, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("validateIDs(%q, %q) = %v, want error containing %q", tt.kind, tt.id, err, tt.want)
+		}
+	}
+}
+
+func TestValidatePrompt(t *testing.T) {
+	for _, p := range []string{"", "  \n"} {
+		if err := validatePrompt(p); err == nil {
+			t.Errorf("validatePrompt(%q) = nil, want error", p)
+		}
+	}
+	if err := validatePrompt(strings.Repeat("é", MaxPromptLength)); err != nil {
+		t.Errorf("prompt at the limit: %v", err)
+	}
+	if err := validatePrompt(strings.Repeat("é", MaxPromptLength+1)); err == nil {
+		t.Error("prompt over the limit: no error")
+	}
+}
+
+func TestValidateSourceFile(t *testing.T) {
+	tests := []struct {
+		size     int64
+		mimeType string
+		ok       bool
+	}{
+		{1000, "application/pdf", true},
+		{1000, "audio/mpeg", true},
+		{1000, "image/png", true},
+		{1000, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", true},
+		{1000, "", true},
+		{MaxSourceSize + 1, "application/pdf", false},
+		{1000, "application/octet-stream", false},
+		{1000, "application/zip", false},
+		{1000, "not a type", false},
+	}
+	for _, tt := range tests {
+		err := validateSourceFile("f", tt.size, tt.mimeType)
+		if (err == nil) != tt.ok {
+			t.Errorf("validateSourceFile(%d, %q) = %v, want ok=%v", tt.size, tt.mimeType, err, tt.ok)
+		}
+	}
+}
+
+func TestValidationBeforeRequest(t *testing.T) {
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("request sent to %s", req.URL)
+		return nil, errors.New("no requests expected")
+	})
+	c, err := New(context.Background(), WithAuth("tok", "SID=1"), WithHTTPClient(&http.Client{Transport: rt}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zip := filepath.Join(t.TempDir(), "a.zip")
+	if err := os.WriteFile(zip, []byte("PK\x03\x04 not a source"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := map[string]func() error{
+		"GetProject": func() error {
+			_, err := c.GetProject("https://notebooklm.google.com/notebook/nb1")
+			return err
+		},
+		"DeleteSources": func() error { return c.DeleteSources("nb1", []string{"src 1"}) },
+		"GetSourceText": func() error {
+			_, err := c.GetSourceText("")
+			return err
+		},
+		"Ask": func() error {
+			_, err := c.Ask("nb1", " ", []string{"src1"}, nil)
+			return err
+		},
+		"AddSourceFromFile": func() error {
+			_, err := c.AddSourceFromFile("nb1", zip)
+			return err
+		},
+		"WaitForAudio": func() error {
+			_, err := c.WaitForAudio(context.Background(), "", "", nil)
+			return err
+		},
+		"WaitForVideo": func() error {
+			_, err := c.WaitForVideo(context.Background(), "nb 1", "", nil)
+			return err
+		},
+		"GetGuidebook": func() error {
+			_, err := c.GetGuidebook("")
+			return err
+		},
+		"GetGuidebookDetails": func() error {
+			_, err := c.GetGuidebookDetails("https://notebooklm.google.com/notebook/nb1")
+			return err
+		},
+		"PublishGuidebook": func() error {
+			_, err := c.PublishGuidebook("", PublishGuidebookOptions{})
+			return err
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("%s: error = %v, want a ValidationError matching ErrInvalidArgument", name, err)
+		}
+	}
+}
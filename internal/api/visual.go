@@ -0,0 +1,141 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+// ErrArtifactUnsupported is returned by CreateArtifact when the account
+// cannot generate the requested kind. Visual kinds such as slide decks
+// and infographics are rolling out gradually.
+var ErrArtifactUnsupported = errors.New("artifact type not available on this account")
+
+// isVisual reports whether k is rendered to images or documents rather
+// than text.
+func (k ArtifactKind) isVisual() bool {
+	return k == ArtifactSlideDeck || k == ArtifactInfographic
+}
+
+// isUnsupportedError reports whether err is the server refusing an
+// operation outright rather than failing it. This is the capability probe
+// for visual kinds: accounts without them reject the create request
+// before anything is generated.
+func isUnsupportedError(err error) bool {
+	var apiErr *batchexecute.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.ErrorCode != nil {
+		switch apiErr.ErrorCode.Code {
+		case 6, 7, 9, 12: // invalid argument, permission denied, failed precondition, unimplemented
+			return true
+		}
+	}
+	return apiErr.HTTPStatus == http.StatusForbidden || apiErr.HTTPStatus == http.StatusNotImplemented
+}
+
+// ArtifactAsset is a rendered file belonging to a visual artifact, such as
+// a slide image or an infographic.
+type ArtifactAsset struct {
+	URL string
+	// Ext is the file extension from the URL, including the dot, or ""
+	// when the URL does not have one.
+	Ext string
+}
+
+// assetExts are the extensions of files NotebookLM renders visual
+// artifacts to.
+var assetExts = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".webp": true, ".gif": true,
+	".svg": true, ".pdf": true, ".pptx": true,
+}
+
+// GetArtifactAssets returns the rendered files of a visual artifact in
+// the order they appear, which for slide decks is slide order.
+func (c *Client) GetArtifactAssets(projectID, artifactID string) (_ []ArtifactAsset, err error) {
+	defer wrapError(&err, "GetArtifactAssets", projectID)
+	if err := validateIDs("notebook", projectID); err != nil {
+		return nil, err
+	}
+	data, err := c.getArtifactData(projectID, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	assets := decodeArtifactAssets(data)
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("artifact %s has no rendered assets", artifactID)
+	}
+	return assets, nil
+}
+
+// decodeArtifactAssets collects the asset URLs in an artifact entry. Asset
+// URLs point at Google's content hosts or end in a known file extension;
+// other links, such as citations, are skipped.
+func decodeArtifactAssets(data []interface{}) []ArtifactAsset {
+	entry := artifactEntry(data)
+	if entry == nil {
+		return nil
+	}
+	var assets []ArtifactAsset
+	seen := make(map[string]bool)
+	walkStrings(entry[1:], func(s string) {
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || seen[s] {
+			return
+		}
+		ext := strings.ToLower(path.Ext(u.Path))
+		if !assetExts[ext] {
+			ext = ""
+		}
+		host := strings.ToLower(u.Hostname())
+		if ext == "" && !strings.HasSuffix(host, ".googleusercontent.com") && !strings.HasSuffix(host, ".googleapis.com") {
+			return
+		}
+		seen[s] = true
+		assets = append(assets, ArtifactAsset{URL: s, Ext: ext})
+	})
+	return assets
+}
+
+// DownloadArtifactAsset writes an asset to w using the session's cookies
+// and returns the response's content type.
+func (c *Client) DownloadArtifactAsset(asset ArtifactAsset, w io.Writer) (_ string, err error) {
+	defer wrapError(&err, "DownloadArtifactAsset", "")
+	req, err := http.NewRequest("GET", asset.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create asset download request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Referer", "https://notebooklm.google.com/")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/140.0.0.0 Safari/537.36")
+	if cookies := os.Getenv("NLM_COOKIES"); cookies != "" {
+		req.Header.Set("Cookie", cookies)
+	}
+	if c.config.Debug {
+		fmt.Printf("Downloading asset from: %s\n", asset.URL)
+	}
+	c.rpc.WriteCurl(req, nil)
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("download asset: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download failed with status: %s (check authentication)", resp.Status)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return "", fmt.Errorf("write asset: %w", err)
+	}
+	return resp.Header.Get("Content-Type"), nil
+}
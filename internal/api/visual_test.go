@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+func TestDecodeArtifactAssets(t *testing.T) {
+	input := `[["deck1", "Slides", 8, [["src1"]], 2, [
+		[1, "https://lh3.googleusercontent.com/slide-1"],
+		[2, "https://lh3.googleusercontent.com/slide-2"],
+		[3, "https://lh3.googleusercontent.com/slide-1"]
+	], "https://example.com/deck.PDF", "https://en.wikipedia.org/wiki/Go", "not a url"]]`
+	var data []interface{}
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatal(err)
+	}
+	want := []ArtifactAsset{
+		{URL: "https://lh3.googleusercontent.com/slide-1"},
+		{URL: "https://lh3.googleusercontent.com/slide-2"},
+		{URL: "https://example.com/deck.PDF", Ext: ".pdf"},
+	}
+	if diff := cmp.Diff(want, decodeArtifactAssets(data)); diff != "" {
+		t.Errorf("decodeArtifactAssets() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsUnsupportedError(t *testing.T) {
+	code := func(n int) error {
+		ec, _ := batchexecute.GetErrorCode(n)
+		return fmt.Errorf("rpc: %w", &batchexecute.APIError{ErrorCode: ec})
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unimplemented", code(12), true},
+		{"permission denied", code(7), true},
+		{"failed precondition", code(9), true},
+		{"unavailable", code(3), false},
+		{"forbidden", &batchexecute.APIError{HTTPStatus: 403}, true},
+		{"rate limited", &batchexecute.APIError{HTTPStatus: 429}, false},
+		{"plain error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnsupportedError(tt.err); got != tt.want {
+				t.Errorf("isUnsupportedError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
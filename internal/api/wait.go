@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+)
+
+// ErrGenerationFailed is returned by the Wait helpers when the server
+// reports that a generation did not succeed.
+var ErrGenerationFailed = errors.New("generation failed")
+
+// WaitOptions controls how the Wait helpers poll. The delay between polls
+// starts at Interval and grows by Multiplier after each attempt, up to
+// MaxInterval. Zero fields take the defaults below. How long to wait
+// overall is governed by the context's deadline.
+type WaitOptions struct {
+	Interval    time.Duration // default 2s
+	MaxInterval time.Duration // default 30s
+	Multiplier  float64       // default 1.5
+
+	// OnPoll, if set, is called after each unsuccessful poll with the
+	// attempt number and the delay before the next one.
+	OnPoll func(attempt int, next time.Duration)
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	var opts WaitOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = 30 * time.Second
+	}
+	if opts.MaxInterval < opts.Interval {
+		opts.MaxInterval = opts.Interval
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = 1.5
+	}
+	return opts
+}
+
+// delay returns the wait before poll attempt+1.
+func (o WaitOptions) delay(attempt int) time.Duration {
+	d := float64(o.Interval)
+	for i := 0; i < attempt; i++ {
+		d *= o.Multiplier
+		if d >= float64(o.MaxInterval) {
+			return o.MaxInterval
+		}
+	}
+	return time.Duration(d)
+}
+
+// poll calls check until it reports done, returns an error that is not
+// transient (see IsTransient), or ctx expires. Transient errors are
+// retried; the last one is reported if ctx expires.
+func poll(ctx context.Context, o *WaitOptions, check func() (bool, error)) error {
+	opts := o.withDefaults()
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		done, err := check()
+		if err == nil && done {
+			return nil
+		}
+		if err != nil {
+			if !IsTransient(err) {
+				return err
+			}
+			lastErr = err
+		}
+
+		d := opts.delay(attempt)
+		if opts.OnPoll != nil {
+			opts.OnPoll(attempt+1, d)
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			if lastErr != nil {
+				return fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
+			}
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// WaitForArtifact polls until the artifact is ready and returns it.
+func (c *Client) WaitForArtifact(ctx context.Context, notebookID, artifactID string, opts *WaitOptions) (_ *pb.Artifact, err error) {
+	defer wrapError(&err, "WaitForArtifact", notebookID)
+	if err := validateIDs("artifact", artifactID); err != nil {
+		return nil, err
+	}
+	var artifact *pb.Artifact
+	err = poll(ctx, opts, func() (bool, error) {
+		a, err := c.GetArtifact(artifactID)
+		if err != nil {
+			return false, err
+		}
+		switch a.GetState() {
+		case pb.ArtifactState_ARTIFACT_STATE_READY:
+			artifact = a
+			return true, nil
+		case pb.ArtifactState_ARTIFACT_STATE_FAILED:
+			return false, fmt.Errorf("artifact %s: %w", artifactID, ErrGenerationFailed)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wait for artifact: %w", err)
+	}
+	return artifact, nil
+}
+
+// WaitForAudio polls until the notebook's audio overview is ready and
+// returns it. audioID may be empty; notebooks have a single audio overview.
+func (c *Client) WaitForAudio(ctx context.Context, notebookID, audioID string, opts *WaitOptions) (_ *AudioOverviewResult, err error) {
+	defer wrapError(&err, "WaitForAudio", notebookID)
+	if err := validateIDs("notebook", notebookID); err != nil {
+		return nil, err
+	}
+	var audio *AudioOverviewResult
+	err = poll(ctx, opts, func() (bool, error) {
+		a, err := c.GetAudioOverview(notebookID)
+		if err != nil {
+			return false, err
+		}
+		if !a.IsReady {
+			return false, nil
+		}
+		audio = a
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wait for audio: %w", err)
+	}
+	return audio, nil
+}
+
+// WaitForVideo polls until the notebook's video overview is ready and
+// returns it. videoID may be empty.
+func (c *Client) WaitForVideo(ctx context.Context, notebookID, videoID string, opts *WaitOptions) (_ *VideoOverviewResult, err error) {
+	defer wrapError(&err, "WaitForVideo", notebookID)
+	if err := validateIDs("notebook", notebookID); err != nil {
+		return nil, err
+	}
+	var video *VideoOverviewResult
+	err = poll(ctx, opts, func() (bool, error) {
+		v, err := c.GetVideoOverview(notebookID)
+		if err != nil {
+			return false, err
+		}
+		if !v.IsReady {
+			return false, nil
+		}
+		video = v
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wait for video: %w", err)
+	}
+	return video, nil
+}
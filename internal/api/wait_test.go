@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+)
+
+func TestWaitOptionsDelay(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *WaitOptions
+		want []time.Duration
+	}{
+		{
+			name: "defaults",
+			opts: nil,
+			want: []time.Duration{2 * time.Second, 3 * time.Second, 4500 * time.Millisecond},
+		},
+		{
+			name: "doubling with cap",
+			opts: &WaitOptions{Interval: time.Second, MaxInterval: 5 * time.Second, Multiplier: 2},
+			want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name: "fixed interval",
+			opts: &WaitOptions{Interval: time.Second, MaxInterval: time.Second, Multiplier: 1},
+			want: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name: "max below interval",
+			opts: &WaitOptions{Interval: 3 * time.Second, MaxInterval: time.Second},
+			want: []time.Duration{3 * time.Second, 3 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts.withDefaults()
+			for attempt, want := range tt.want {
+				if got := opts.delay(attempt); got != want {
+					t.Errorf("delay(%d) = %v, want %v", attempt, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPoll(t *testing.T) {
+	fast := &WaitOptions{Interval: time.Millisecond, MaxInterval: time.Millisecond}
+	transient := fmt.Errorf("get artifact: %w", ErrUnavailable)
+	notFound := &Error{Op: "GetArtifact", Err: &batchexecute.BatchExecuteError{StatusCode: 404, Message: "gone"}}
+
+	tests := []struct {
+		name      string
+		results   []error // nil means done; errNotYet means keep polling
+		timeout   time.Duration
+		wantErr   error
+		wantMsg   string
+		wantCalls int // if not 0, the number of checks made
+	}{
+		{name: "ready immediately", results: []error{nil}},
+		{name: "ready after polling", results: []error{errNotYet, errNotYet, nil}},
+		{name: "transient errors are retried", results: []error{transient, nil}},
+		{name: "failure stops polling", results: []error{errNotYet, ErrGenerationFailed}, wantErr: ErrGenerationFailed},
+		{name: "rate limit is retried", results: []error{ErrRateLimited, nil}},
+		{name: "deadline", results: []error{transient}, timeout: 20 * time.Millisecond, wantErr: context.DeadlineExceeded, wantMsg: "service unavailable"},
+		{name: "unauthorized stops polling", results: []error{ErrUnauthorized, nil}, wantErr: ErrUnauthorized, wantCalls: 1},
+		{name: "not found stops polling", results: []error{notFound, nil}, wantErr: ErrNotFound, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			calls := 0
+			err := poll(ctx, fast, func() (bool, error) {
+				r := tt.results[min(calls, len(tt.results)-1)]
+				calls++
+				if r == errNotYet {
+					return false, nil
+				}
+				return r == nil, r
+			})
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("poll() error = %v", err)
+				}
+				if calls != len(tt.results) {
+					t.Errorf("poll() made %d calls, want %d", calls, len(tt.results))
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("poll() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantMsg != "" && !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("poll() error = %q, want it to mention %q", err, tt.wantMsg)
+			}
+			if tt.wantCalls != 0 && calls != tt.wantCalls {
+				t.Errorf("poll() made %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+var errNotYet = fmt.Errorf("not yet")
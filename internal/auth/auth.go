@@ -48,6 +48,7 @@ type Option func(*Options)
 func WithProfileName(p string) Option { return func(o *Options) { o.ProfileName = p } }
 func WithTryAllProfiles() Option      { return func(o *Options) { o.TryAllProfiles = true } }
 func WithScanBeforeAuth() Option      { return func(o *Options) { o.ScanBeforeAuth = true } }
+func WithoutScan() Option             { return func(o *Options) { o.ScanBeforeAuth = false } }
 func WithTargetURL(url string) Option { return func(o *Options) { o.TargetURL = url } }
 func WithPreferredBrowsers(browsers []string) Option {
 	return func(o *Options) { o.PreferredBrowsers = browsers }
@@ -89,6 +90,17 @@ func (ba *BrowserAuth) tryMultipleProfiles(targetURL string) (token, cookies str
 			fmt.Printf("Trying profile: %s [%s]\n", profile.Name, profile.Browser)
 		}
 
+		if profile.Browser == firefoxBrowserName {
+			token, cookies, err = ba.firefoxAuth(profile.Path, targetURL)
+			if err == nil {
+				return token, cookies, nil
+			}
+			if ba.debug {
+				fmt.Printf("Profile %s [%s] could not authenticate: %v\n", profile.Name, profile.Browser, err)
+			}
+			continue
+		}
+
 		// Clean up previous attempts
 		ba.cleanup()
 
@@ -208,6 +220,12 @@ type ProfileInfo struct {
 	AuthCookies      string
 }
 
+// ListProfiles returns the browser profiles that can be used to sign in,
+// those that look signed in to NotebookLM first, then by most recent use.
+func ListProfiles() ([]ProfileInfo, error) {
+	return (&BrowserAuth{}).scanProfilesForDomain("notebooklm.google.com")
+}
+
 // scanProfiles finds all available Chrome profiles across different browsers
 func (ba *BrowserAuth) scanProfiles() ([]ProfileInfo, error) {
 	return ba.scanProfilesForDomain("")
@@ -238,6 +256,19 @@ func (ba *BrowserAuth) scanProfilesForDomain(targetDomain string) ([]ProfileInfo
 		allProfiles = append(allProfiles, braveProfiles...)
 	}
 
+	// Check Microsoft Edge profiles
+	edgePath := getEdgeProfilePath()
+	edgeProfiles, err := scanBrowserProfiles(edgePath, edgeBrowserName, targetDomain)
+	if err == nil {
+		allProfiles = append(allProfiles, edgeProfiles...)
+	}
+
+	// Check Firefox profiles
+	firefoxProfiles, err := scanFirefoxProfiles(targetDomain)
+	if err == nil {
+		allProfiles = append(allProfiles, firefoxProfiles...)
+	}
+
 	// First sort by whether they have target cookies (if a target domain was specified)
 	if targetDomain != "" {
 		sort.Slice(allProfiles, func(i, j int) bool {
@@ -468,6 +499,21 @@ func (ba *BrowserAuth) GetAuth(opts ...Option) (token, cookies string, err error
 				if shouldCheck {
 					fmt.Printf("  Checking notebooks for %s [%s]...", p.Name, p.Browser)
 
+					if p.Browser == firefoxBrowserName {
+						profile := p
+						token, cookies, err := ba.firefoxAuth(p.Path, o.TargetURL)
+						if err != nil {
+							fmt.Println(" Not authenticated")
+						} else if profile.NotebookCount, err = countNotebooks(token, cookies); err != nil {
+							fmt.Println(" Error counting notebooks")
+						} else {
+							profile.AuthToken, profile.AuthCookies = token, cookies
+							fmt.Printf(" Found %d notebooks\n", profile.NotebookCount)
+						}
+						updatedProfiles = append(updatedProfiles, profile)
+						continue
+					}
+
 					// Set up a temporary Chrome instance to authenticate
 					tempDir, err := os.MkdirTemp("", "nlm-notebook-check-*")
 					if err != nil {
@@ -615,6 +661,11 @@ func (ba *BrowserAuth) GetAuth(opts ...Option) (token, cookies string, err error
 		return "", "", fmt.Errorf("no valid profiles found")
 	}
 
+	// Firefox profiles are read directly rather than in a browser
+	if selectedProfile.Browser == firefoxBrowserName {
+		return ba.firefoxAuth(selectedProfile.Path, o.TargetURL)
+	}
+
 	// Create a temporary directory and copy profile data to preserve encryption keys
 	tempDir, err := os.MkdirTemp("", "nlm-chrome-*")
 	if err != nil {
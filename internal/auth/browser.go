@@ -10,8 +10,13 @@ const (
 	BrowserUnknown BrowserType = iota
 	BrowserChrome
 	BrowserSafari
+	BrowserFirefox
 )
 
+// edgeBrowserName is the Browser of Microsoft Edge profiles. Edge is
+// Chromium-based, so its profiles are read like Chrome's.
+const edgeBrowserName = "Microsoft Edge"
+
 type Browser struct {
 	Type    BrowserType
 	Path    string
@@ -34,5 +39,9 @@ func detectBrowsers(debug bool) []Browser {
 		browsers = append(browsers, safari)
 	}
 
+	if firefox := detectFirefox(debug); firefox.Path != "" {
+		browsers = append(browsers, firefox)
+	}
+
 	return browsers
 }
@@ -25,6 +25,7 @@ var macOSBrowserPaths = []BrowserPriority{
 	{"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge", "Microsoft Edge", BrowserChrome, ""},
 	{"/Applications/Brave Browser.app/Contents/MacOS/Brave Browser", "Brave", BrowserChrome, ""},
 	{"/Applications/Safari.app/Contents/MacOS/Safari", "Safari", BrowserSafari, ""},
+	{"/Applications/Firefox.app/Contents/MacOS/firefox", "Firefox", BrowserFirefox, ""},
 }
 
 func getChromePath() string {
@@ -81,6 +82,14 @@ func getBrowserPathForProfile(browserName string) string {
 		if path := findBrowserViaMDFind("com.google.Chrome.canary"); path != "" {
 			return filepath.Join(path, "Contents/MacOS/Google Chrome Canary")
 		}
+	case edgeBrowserName:
+		edgePath := "/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge"
+		if _, err := os.Stat(edgePath); err == nil {
+			return edgePath
+		}
+		if path := findBrowserViaMDFind("com.microsoft.edgemac"); path != "" {
+			return filepath.Join(path, "Contents/MacOS/Microsoft Edge")
+		}
 	}
 
 	// Fallback to any Chrome-based browser
@@ -217,6 +226,11 @@ func getBraveProfilePath() string {
 	return filepath.Join(home, "Library", "Application Support", "BraveSoftware", "Brave-Browser")
 }
 
+func getEdgeProfilePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "Application Support", "Microsoft Edge")
+}
+
 func checkBrowserInstallation() string {
 	var messages []string
 	var found bool
@@ -235,6 +249,7 @@ func checkBrowserInstallation() string {
 		messages = append(messages, "- Chromium (https://www.chromium.org/)")
 		messages = append(messages, "- Microsoft Edge (https://www.microsoft.com/edge)")
 		messages = append(messages, "- Brave Browser (https://brave.com/)")
+		messages = append(messages, "- Firefox (https://www.mozilla.org/firefox/)")
 		messages = append(messages, "Or use Safari (pre-installed)")
 	}
 
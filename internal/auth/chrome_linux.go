@@ -32,6 +32,17 @@ func detectChrome(debug bool) Browser {
 		}
 	}
 
+	// Then Microsoft Edge
+	if path := getEdgePath(); path != "" {
+		version := getChromeVersion(path)
+		return Browser{
+			Type:    BrowserChrome,
+			Path:    path,
+			Name:    edgeBrowserName,
+			Version: version,
+		}
+	}
+
 	return Browser{Type: BrowserUnknown}
 }
 
@@ -73,6 +84,10 @@ func getBrowserPathForProfile(browserName string) string {
 		// Chrome Canary is typically not available on Linux
 		// Fall back to regular Chrome
 		return getChromePath()
+	case edgeBrowserName:
+		if path := getEdgePath(); path != "" {
+			return path
+		}
 	}
 
 	// Fallback to any Chrome-based browser
@@ -89,3 +104,17 @@ func getBraveProfilePath() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".config", "BraveSoftware", "Brave-Browser")
 }
+
+func getEdgePath() string {
+	for _, name := range []string{"microsoft-edge", "microsoft-edge-stable", "microsoft-edge-beta", "microsoft-edge-dev"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func getEdgeProfilePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "microsoft-edge")
+}
@@ -10,7 +10,11 @@ import (
 )
 
 func detectChrome(debug bool) Browser {
-	path := getChromePath()
+	path, name := getChromePath(), "Google Chrome"
+	if path == "" {
+		// Managed Windows machines often have only Edge
+		path, name = getEdgePath(), edgeBrowserName
+	}
 	if path == "" {
 		return Browser{Type: BrowserUnknown}
 	}
@@ -19,7 +23,7 @@ func detectChrome(debug bool) Browser {
 	return Browser{
 		Type:    BrowserChrome,
 		Path:    path,
-		Name:    "Google Chrome",
+		Name:    name,
 		Version: version,
 	}
 }
@@ -30,7 +34,9 @@ func getChromeVersion(path string) string {
 	if err != nil {
 		return "unknown"
 	}
-	return strings.TrimSpace(strings.TrimPrefix(string(out), "Google Chrome "))
+	version := strings.TrimSpace(string(out))
+	version = strings.TrimPrefix(version, "Google Chrome ")
+	return strings.TrimPrefix(version, "Microsoft Edge ")
 }
 
 func getProfilePath() string {
@@ -92,6 +98,10 @@ func getBrowserPathForProfile(browserName string) string {
 				return path
 			}
 		}
+	case edgeBrowserName:
+		if path := getEdgePath(); path != "" {
+			return path
+		}
 	}
 
 	// Fallback to any Chrome-based browser
@@ -115,3 +125,29 @@ func getBraveProfilePath() string {
 	}
 	return filepath.Join(localAppData, "BraveSoftware", "Brave-Browser", "User Data")
 }
+
+func getEdgePath() string {
+	paths := []string{
+		filepath.Join(os.Getenv("PROGRAMFILES(X86)"), "Microsoft", "Edge", "Application", "msedge.exe"),
+		filepath.Join(os.Getenv("PROGRAMFILES"), "Microsoft", "Edge", "Application", "msedge.exe"),
+		filepath.Join(os.Getenv("LOCALAPPDATA"), "Microsoft", "Edge", "Application", "msedge.exe"),
+	}
+	if os.Getenv("PROGRAMFILES(X86)") == "" {
+		paths = append(paths, filepath.Join("C:\\Program Files (x86)", "Microsoft", "Edge", "Application", "msedge.exe"))
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func getEdgeProfilePath() string {
+	localAppData := os.Getenv("LOCALAPPDATA")
+	if localAppData == "" {
+		home, _ := os.UserHomeDir()
+		localAppData = filepath.Join(home, "AppData", "Local")
+	}
+	return filepath.Join(localAppData, "Microsoft", "Edge", "User Data")
+}
@@ -0,0 +1,328 @@
+package auth
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// Firefox keeps its cookies unencrypted in each profile's cookies.sqlite,
+// so unlike the Chromium browsers its profiles are read directly, without
+// launching the browser: the cookies come from the database and the
+// token from the page they unlock.
+
+const firefoxBrowserName = "Firefox"
+
+func detectFirefox(debug bool) Browser {
+	path := getFirefoxPath()
+	if path == "" {
+		return Browser{Type: BrowserUnknown}
+	}
+	version := "unknown"
+	if out, err := exec.Command(path, "--version").Output(); err == nil {
+		version = strings.TrimSpace(strings.TrimPrefix(string(out), "Mozilla Firefox "))
+	}
+	if debug {
+		fmt.Printf("Found Firefox at %s (version: %s)\n", path, version)
+	}
+	return Browser{
+		Type:    BrowserFirefox,
+		Path:    path,
+		Name:    firefoxBrowserName,
+		Version: version,
+	}
+}
+
+// firefoxProfile is a profile listed in a Firefox profiles.ini.
+type firefoxProfile struct {
+	Name    string
+	Path    string // absolute
+	Default bool
+}
+
+// readFirefoxProfiles lists the profiles in the profiles.ini of a Firefox
+// data directory. The default profile, the one the install section names
+// or else the one marked Default, comes first.
+func readFirefoxProfiles(dir string) ([]firefoxProfile, error) {
+	f, err := os.Open(filepath.Join(dir, "profiles.ini"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var profiles []firefoxProfile
+	var installDefault string
+	var section string
+	var cur *firefoxProfile
+	relative := true
+	flush := func() {
+		if cur == nil || cur.Path == "" {
+			return
+		}
+		if relative {
+			cur.Path = filepath.Join(dir, filepath.FromSlash(cur.Path))
+		}
+		profiles = append(profiles, *cur)
+	}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			flush()
+			section, cur, relative = line[1:len(line)-1], nil, true
+			if strings.HasPrefix(section, "Profile") {
+				cur = &firefoxProfile{}
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(section, "Install") && key == "Default":
+			installDefault = value
+		case cur == nil:
+		case key == "Name":
+			cur.Name = value
+		case key == "Path":
+			cur.Path = value
+		case key == "IsRelative":
+			relative = value != "0"
+		case key == "Default":
+			cur.Default = value == "1"
+		}
+	}
+	flush()
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if installDefault != "" {
+		want := filepath.Join(dir, filepath.FromSlash(installDefault))
+		for i := range profiles {
+			profiles[i].Default = profiles[i].Path == want || profiles[i].Path == installDefault
+		}
+	}
+	for i, p := range profiles {
+		if p.Default {
+			profiles[0], profiles[i] = profiles[i], profiles[0]
+			break
+		}
+	}
+	return profiles, nil
+}
+
+// scanFirefoxProfiles finds the Firefox profiles that hold cookies and
+// checks them for cookies of the target domain.
+func scanFirefoxProfiles(targetDomain string) ([]ProfileInfo, error) {
+	var profiles []ProfileInfo
+	for _, dir := range getFirefoxProfileDirs() {
+		ffProfiles, err := readFirefoxProfiles(dir)
+		if err != nil {
+			continue
+		}
+		for _, p := range ffProfiles {
+			cookiesInfo, err := os.Stat(filepath.Join(p.Path, "cookies.sqlite"))
+			if err != nil {
+				continue
+			}
+			profile := ProfileInfo{
+				Name:     p.Name,
+				Path:     p.Path,
+				LastUsed: cookiesInfo.ModTime(),
+				Files:    []string{"cookies.sqlite"},
+				Size:     cookiesInfo.Size(),
+				Browser:  firefoxBrowserName,
+			}
+			if info, err := os.Stat(filepath.Join(p.Path, "places.sqlite")); err == nil {
+				profile.Files = append(profile.Files, "places.sqlite")
+				profile.Size += info.Size()
+			}
+			if targetDomain != "" {
+				cookies, err := readFirefoxCookies(p.Path, targetDomain)
+				profile.HasTargetCookies = err == nil && hasSessionCookies(cookies)
+				profile.TargetDomain = targetDomain
+			}
+			profiles = append(profiles, profile)
+		}
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no Firefox profiles found")
+	}
+	return profiles, nil
+}
+
+// readFirefoxCookies returns, as a Cookie header, the unexpired cookies in
+// a Firefox profile that would be sent to host. Cookies in container tabs
+// and private windows are left out. The database is copied first, with
+// its write-ahead log, because Firefox holds it locked while running and
+// keeps recent writes in the log.
+func readFirefoxCookies(profileDir, host string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "nlm-firefox-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+	dbPath := filepath.Join(tempDir, "cookies.sqlite")
+	if err := copyFile(filepath.Join(profileDir, "cookies.sqlite"), dbPath); err != nil {
+		return "", fmt.Errorf("copy cookies: %w", err)
+	}
+	if err := copyFile(filepath.Join(profileDir, "cookies.sqlite-wal"), dbPath+"-wal"); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("copy cookies: %w", err)
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(dbPath)}
+	db, err := sql.Open("sqlite", u.String())
+	if err != nil {
+		return "", fmt.Errorf("open Firefox cookies: %w", err)
+	}
+	defer db.Close()
+
+	hosts := cookieHosts(host)
+	query := `SELECT name, value, host, expiry FROM moz_cookies
+		WHERE originAttributes = '' AND host IN (?` + strings.Repeat(", ?", len(hosts)-1) + `)
+		ORDER BY length(host) DESC, creationTime`
+	args := make([]any, len(hosts))
+	for i, h := range hosts {
+		args[i] = h
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return "", fmt.Errorf("read Firefox cookies: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	var pairs []string
+	for rows.Next() {
+		var name, value, cookieHost string
+		var expiry int64
+		if err := rows.Scan(&name, &value, &cookieHost, &expiry); err != nil {
+			return "", fmt.Errorf("read Firefox cookies: %w", err)
+		}
+		if firefoxExpiry(expiry).Before(now) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		pairs = append(pairs, name+"="+value)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("read Firefox cookies: %w", err)
+	}
+	return strings.Join(pairs, "; "), nil
+}
+
+// cookieHosts returns the values of moz_cookies.host whose cookies are
+// sent to host: the host itself and, with a leading dot, each of its
+// parent domains.
+func cookieHosts(host string) []string {
+	hosts := []string{host}
+	labels := strings.Split(host, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		hosts = append(hosts, "."+strings.Join(labels[i:], "."))
+	}
+	return hosts
+}
+
+// firefoxExpiry converts a moz_cookies expiry, which recent Firefox
+// versions store in milliseconds and older ones in seconds.
+func firefoxExpiry(expiry int64) time.Time {
+	if expiry > 1e11 {
+		return time.UnixMilli(expiry)
+	}
+	return time.Unix(expiry, 0)
+}
+
+// hasSessionCookies reports whether a Cookie header holds any of the
+// cookies Google sets when signed in.
+func hasSessionCookies(cookies string) bool {
+	for _, name := range []string{"SID", "HSID", "SSID", "APISID"} {
+		if extractCookieValue(cookies, name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// firefoxAuth reads the credentials of a Firefox profile.
+func (ba *BrowserAuth) firefoxAuth(profileDir, targetURL string) (token, cookies string, err error) {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return "", "", fmt.Errorf("parse target URL: %w", err)
+	}
+	cookies, err = readFirefoxCookies(profileDir, u.Hostname())
+	if err != nil {
+		return "", "", err
+	}
+	if ba.debug {
+		fmt.Printf("Read %d cookies from Firefox profile %s\n", len(strings.Split(cookies, "; ")), profileDir)
+	}
+	if !hasSessionCookies(cookies) {
+		return "", "", fmt.Errorf("missing essential authentication cookies: Firefox profile is not signed in to Google")
+	}
+	token, err = fetchPageToken(targetURL, cookies)
+	if err != nil {
+		return "", "", err
+	}
+	return token, cookies, nil
+}
+
+// pageTokenRE matches the token in the WIZ_global_data of a page.
+var pageTokenRE = regexp.MustCompile(`"SNlM0e":"([^"]+)"`)
+
+// fetchPageToken loads the page at targetURL with cookies and returns the
+// token the page embeds for its RPCs.
+func fetchPageToken(targetURL, cookies string) (string, error) {
+	req, err := http.NewRequest("GET", targetURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Cookie", cookies)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0")
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to load page: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.Request.URL.Hostname() == "accounts.google.com" {
+		return "", fmt.Errorf("redirected to authentication page - not logged in")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to load page: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read page: %w", err)
+	}
+	return parsePageToken(body)
+}
+
+// parsePageToken extracts the token from the HTML of a NotebookLM page.
+func parsePageToken(page []byte) (string, error) {
+	m := pageTokenRE.FindSubmatch(page)
+	if m == nil {
+		return "", fmt.Errorf("token not found or invalid")
+	}
+	token := string(m[1])
+	if len(token) < 20 {
+		return "", fmt.Errorf("invalid token format (too short): %s", token)
+	}
+	return token, nil
+}
@@ -0,0 +1,28 @@
+//go:build darwin
+
+package auth
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func getFirefoxPath() string {
+	for _, browser := range macOSBrowserPaths {
+		if browser.Type != BrowserFirefox {
+			continue
+		}
+		if _, err := os.Stat(browser.Path); err == nil {
+			return browser.Path
+		}
+	}
+	if path := findBrowserViaMDFind("org.mozilla.firefox"); path != "" {
+		return filepath.Join(path, "Contents", "MacOS", "firefox")
+	}
+	return ""
+}
+
+func getFirefoxProfileDirs() []string {
+	home, _ := os.UserHomeDir()
+	return []string{filepath.Join(home, "Library", "Application Support", "Firefox")}
+}
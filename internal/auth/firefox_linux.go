@@ -0,0 +1,29 @@
+//go:build linux
+
+package auth
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+func getFirefoxPath() string {
+	for _, name := range []string{"firefox", "firefox-esr"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// getFirefoxProfileDirs returns the directories holding profiles.ini for
+// the distribution, Snap and Flatpak packages of Firefox.
+func getFirefoxProfileDirs() []string {
+	home, _ := os.UserHomeDir()
+	return []string{
+		filepath.Join(home, ".mozilla", "firefox"),
+		filepath.Join(home, "snap", "firefox", "common", ".mozilla", "firefox"),
+		filepath.Join(home, ".var", "app", "org.mozilla.firefox", ".mozilla", "firefox"),
+	}
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadFirefoxProfiles(t *testing.T) {
+	dir := t.TempDir()
+	ini := `[Profile1]
+Name=default
+IsRelative=1
+Path=Profiles/abcd.default
+Default=1
+
+[Profile0]
+Name=default-release
+IsRelative=1
+Path=Profiles/efgh.default-release
+
+[Profile2]
+Name=work
+IsRelative=0
+Path=/srv/firefox/work
+
+[Install4F96D1932A9F858E]
+Default=Profiles/efgh.default-release
+Locked=1
+
+[General]
+StartWithLastProfile=1
+`
+	if err := os.WriteFile(filepath.Join(dir, "profiles.ini"), []byte(ini), 0644); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := readFirefoxProfiles(dir)
+	if err != nil {
+		t.Fatalf("readFirefoxProfiles: %v", err)
+	}
+	if len(profiles) != 3 {
+		t.Fatalf("got %d profiles, want 3: %+v", len(profiles), profiles)
+	}
+	if p := profiles[0]; p.Name != "default-release" || !p.Default || p.Path != filepath.Join(dir, "Profiles", "efgh.default-release") {
+		t.Errorf("first profile = %+v, want the install's default, default-release", p)
+	}
+	for _, p := range profiles[1:] {
+		if p.Default {
+			t.Errorf("profile %s is marked default; the install section overrides it", p.Name)
+		}
+		if p.Name == "work" && p.Path != "/srv/firefox/work" {
+			t.Errorf("absolute profile path = %q, want /srv/firefox/work", p.Path)
+		}
+	}
+}
+
+func TestReadFirefoxCookies(t *testing.T) {
+	dir := t.TempDir()
+	db, err := sql.Open("sqlite", filepath.Join(dir, "cookies.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE moz_cookies (id INTEGER PRIMARY KEY, originAttributes TEXT NOT NULL DEFAULT '',
+		name TEXT, value TEXT, host TEXT, path TEXT, expiry INTEGER, creationTime INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Hour)
+	rows := []struct {
+		attrs, name, value, host string
+		expiry                   int64
+	}{
+		{"", "SID", "sid", ".google.com", future.Unix()},
+		{"", "HSID", "hsid", ".google.com", future.UnixMilli()},
+		{"", "OSID", "broad", ".google.com", future.Unix()},
+		{"", "OSID", "specific", "notebooklm.google.com", future.Unix()},
+		{"", "OLD", "expired", ".google.com", past.Unix()},
+		{"", "NID", "other", "www.google.com", future.Unix()},
+		{"^userContextId=1", "SSID", "container", ".google.com", future.Unix()},
+	}
+	for i, r := range rows {
+		if _, err := db.Exec(`INSERT INTO moz_cookies (originAttributes, name, value, host, path, expiry, creationTime) VALUES (?, ?, ?, ?, '/', ?, ?)`,
+			r.attrs, r.name, r.value, r.host, r.expiry, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	got, err := readFirefoxCookies(dir, "notebooklm.google.com")
+	if err != nil {
+		t.Fatalf("readFirefoxCookies: %v", err)
+	}
+	want := "OSID=specific; SID=sid; HSID=hsid"
+	if got != want {
+		t.Errorf("readFirefoxCookies() = %q, want %q", got, want)
+	}
+	if !hasSessionCookies(got) {
+		t.Errorf("hasSessionCookies(%q) = false", got)
+	}
+}
+
+func TestParsePageToken(t *testing.T) {
+	token := "AJpMio3Xyz_0123456789:1757337921000"
+	page := `<script>window.WIZ_global_data = {"FdrFJe":"123","SNlM0e":"` + token + `","qwAQke":"x"};</script>`
+	got, err := parsePageToken([]byte(page))
+	if err != nil || got != token {
+		t.Errorf("parsePageToken() = %q, %v, want %q", got, err, token)
+	}
+	if _, err := parsePageToken([]byte(`<form action="/signin">`)); err == nil || !strings.Contains(err.Error(), "token not found") {
+		t.Errorf("parsePageToken(sign-in page) = %v, want token not found", err)
+	}
+}
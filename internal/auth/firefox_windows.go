@@ -0,0 +1,34 @@
+//go:build windows
+
+package auth
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func getFirefoxPath() string {
+	paths := []string{
+		filepath.Join(os.Getenv("PROGRAMFILES"), "Mozilla Firefox", "firefox.exe"),
+		filepath.Join(os.Getenv("PROGRAMFILES(X86)"), "Mozilla Firefox", "firefox.exe"),
+		filepath.Join(os.Getenv("LOCALAPPDATA"), "Mozilla Firefox", "firefox.exe"),
+	}
+	if os.Getenv("PROGRAMFILES") == "" {
+		paths = append(paths, filepath.Join("C:\\Program Files", "Mozilla Firefox", "firefox.exe"))
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func getFirefoxProfileDirs() []string {
+	appData := os.Getenv("APPDATA")
+	if appData == "" {
+		home, _ := os.UserHomeDir()
+		appData = filepath.Join(home, "AppData", "Roaming")
+	}
+	return []string{filepath.Join(appData, "Mozilla", "Firefox")}
+}
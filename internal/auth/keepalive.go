@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Source returns the current credentials, such as by reading them from a
+// browser profile.
+type Source func() (token, cookies string, err error)
+
+// ProfileSource reads credentials from a browser profile, as `nlm auth`
+// does, in a headless browser. It works while the user's own browser is
+// running because the profile is copied first.
+func ProfileSource(profileName string, debug bool) Source {
+	return func() (string, string, error) {
+		return New(debug).GetAuth(WithProfileName(profileName), WithoutScan())
+	}
+}
+
+// KeepFresh reads credentials from src every interval and calls save
+// when they differ from the last ones read, so that a credential store
+// stays current for as long as the browser stays signed in. It reads once
+// immediately and returns the error if that read or save fails; later
+// failures are passed to logf and retried at the next tick. It returns
+// nil when ctx is done.
+func KeepFresh(ctx context.Context, src Source, interval time.Duration, save func(token, cookies string) error, logf func(format string, args ...any)) error {
+	if interval <= 0 {
+		return fmt.Errorf("refresh interval must be positive")
+	}
+	var last string
+	refresh := func() error {
+		token, cookies, err := src()
+		if err != nil {
+			return fmt.Errorf("read credentials: %w", err)
+		}
+		if token == "" || cookies == "" {
+			return fmt.Errorf("read credentials: browser profile is not signed in")
+		}
+		if token+"\x00"+cookies == last {
+			return nil
+		}
+		if err := save(token, cookies); err != nil {
+			return fmt.Errorf("save credentials: %w", err)
+		}
+		last = token + "\x00" + cookies
+		return nil
+	}
+	if err := refresh(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := refresh(); err != nil {
+				logf("%v; retrying in %v", err, interval)
+			}
+		}
+	}
+}
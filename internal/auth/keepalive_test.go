@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeepFresh(t *testing.T) {
+	var mu sync.Mutex
+	reads := 0
+	src := func() (string, string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reads++
+		switch {
+		case reads == 3:
+			return "", "", errors.New("browser busy")
+		case reads < 4:
+			return "token:1", "SAPISID=a", nil
+		}
+		return "token:2", "SAPISID=b", nil
+	}
+	var saved []string
+	var logged []string
+	ctx, cancel := context.WithCancel(context.Background())
+	save := func(token, cookies string) error {
+		saved = append(saved, token)
+		if len(saved) == 2 {
+			cancel()
+		}
+		return nil
+	}
+	logf := func(format string, args ...any) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+	if err := KeepFresh(ctx, src, time.Millisecond, save, logf); err != nil {
+		t.Fatalf("KeepFresh: %v", err)
+	}
+	if len(saved) != 2 || saved[0] != "token:1" || saved[1] != "token:2" {
+		t.Errorf("saved %q, want only changed credentials [token:1 token:2]", saved)
+	}
+	if len(logged) != 1 {
+		t.Errorf("logged %q, want the one failed read", logged)
+	}
+}
+
+func TestKeepFreshFirstReadFails(t *testing.T) {
+	src := func() (string, string, error) { return "", "", nil }
+	save := func(token, cookies string) error {
+		t.Error("save called without credentials")
+		return nil
+	}
+	err := KeepFresh(context.Background(), src, time.Minute, save, t.Logf)
+	if err == nil {
+		t.Fatal("KeepFresh succeeded with a signed-out profile")
+	}
+}
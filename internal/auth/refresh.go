@@ -16,6 +16,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/tmc/nlm/internal/redact"
 )
 
 const (
@@ -95,7 +97,7 @@ func (r *RefreshClient) RefreshCredentials(gsessionID string) error {
 	if r.debug {
 		fmt.Printf("=== Credential Refresh Request ===\n")
 		fmt.Printf("URL: %s\n", fullURL)
-		fmt.Printf("Authorization: SAPISIDHASH %d_%s\n", timestamp, authHash)
+		fmt.Printf("Authorization: %s\n", redact.Header("Authorization", req.Header.Get("Authorization")))
 		fmt.Printf("Body: %s\n", string(bodyJSON))
 	}
 
@@ -134,14 +136,29 @@ func (r *RefreshClient) RefreshCredentials(gsessionID string) error {
 // generateSAPISIDHASH generates the authorization hash
 // Format: SHA1(timestamp + " " + SAPISID + " " + origin)
 func (r *RefreshClient) generateSAPISIDHASH(timestamp int64) string {
-	origin := "https://notebooklm.google.com"
-	data := fmt.Sprintf("%d %s %s", timestamp, r.sapisid, origin)
+	return sapisidHash(timestamp, r.sapisid, "https://notebooklm.google.com")
+}
+
+func sapisidHash(timestamp int64, sapisid, origin string) string {
+	data := fmt.Sprintf("%d %s %s", timestamp, sapisid, origin)
 
 	hash := sha1.New()
 	hash.Write([]byte(data))
 	return fmt.Sprintf("%x", hash.Sum(nil))
 }
 
+// SAPISIDAuthorization returns the Authorization header with which
+// Google's web apps call Google APIs from origin using the browser
+// session in cookies.
+func SAPISIDAuthorization(cookies, origin string, now time.Time) (string, error) {
+	sapisid := extractCookieValue(cookies, "SAPISID")
+	if sapisid == "" {
+		return "", fmt.Errorf("SAPISID not found in cookies")
+	}
+	ts := now.Unix()
+	return fmt.Sprintf("SAPISIDHASH %d_%s", ts, sapisidHash(ts, sapisid, origin)), nil
+}
+
 // extractCookieValue extracts a specific cookie value from a cookie string
 func extractCookieValue(cookies, name string) string {
 	// Split cookies by semicolon
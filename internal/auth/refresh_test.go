@@ -2,6 +2,7 @@ package auth
 
 import (
 	"testing"
+	"time"
 )
 
 func TestGenerateSAPISIDHASH(t *testing.T) {
@@ -56,3 +57,17 @@ func TestExtractCookieValue(t *testing.T) {
 		})
 	}
 }
+
+func TestSAPISIDAuthorization(t *testing.T) {
+	cookies := "HSID=x; SAPISID=ehxTF4-jACAOIp6k/Ax2l7oysalHiZneAB"
+	got, err := SAPISIDAuthorization(cookies, "https://notebooklm.google.com", time.Unix(1757337921, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SAPISIDHASH 1757337921_61ce8d584412c85e2a0a1adebcd9e2c54bc3223f"; got != want {
+		t.Errorf("SAPISIDAuthorization() = %q, want %q", got, want)
+	}
+	if _, err := SAPISIDAuthorization("HSID=x", "https://notebooklm.google.com", time.Now()); err == nil {
+		t.Error("SAPISIDAuthorization without SAPISID succeeded")
+	}
+}
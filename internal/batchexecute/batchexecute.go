@@ -1,6 +1,8 @@
 package batchexecute
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +15,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/tmc/nlm/internal/redact"
 )
 
 // ErrUnauthorized represent an unauthorized request.
@@ -24,6 +28,7 @@ type RPC struct {
 	Args      []interface{}     // Arguments for the call
 	Index     string            // "generic" or numeric index
 	URLParams map[string]string // Request-specific URL parameters
+	Headers   map[string]string // Request-specific headers, including cookie
 }
 
 // Response represents a decoded RPC response
@@ -57,40 +62,6 @@ func (c *Client) Do(rpc RPC) (*Response, error) {
 	return c.Execute([]RPC{rpc})
 }
 
-// maskSensitiveValue masks sensitive values like tokens for debug output
-func maskSensitiveValue(value string) string {
-	if len(value) <= 8 {
-		return strings.Repeat("*", len(value))
-	} else if len(value) <= 16 {
-		start := value[:2]
-		end := value[len(value)-2:]
-		return start + strings.Repeat("*", len(value)-4) + end
-	} else {
-		start := value[:3]
-		end := value[len(value)-3:]
-		return start + strings.Repeat("*", len(value)-6) + end
-	}
-}
-
-// maskCookieValues masks cookie values in cookie header for debug output
-func maskCookieValues(cookies string) string {
-	// Split cookies by semicolon
-	parts := strings.Split(cookies, ";")
-	var masked []string
-
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if name, value, found := strings.Cut(part, "="); found {
-			maskedValue := maskSensitiveValue(value)
-			masked = append(masked, name+"="+maskedValue)
-		} else {
-			masked = append(masked, part) // Keep parts without = as-is
-		}
-	}
-
-	return strings.Join(masked, "; ")
-}
-
 func buildRPCData(rpc RPC) []interface{} {
 	// Convert args to JSON string
 	argsJSON, _ := json.Marshal(rpc.Args)
@@ -105,6 +76,15 @@ func buildRPCData(rpc RPC) []interface{} {
 
 // Execute performs the batch execute request
 func (c *Client) Execute(rpcs []RPC) (*Response, error) {
+	c.stats.update(func(s *StatsSnapshot) { s.RPCs += len(rpcs) })
+	resp, err := c.invoker()(c.ctx, rpcs)
+	if err != nil {
+		c.stats.update(func(s *StatsSnapshot) { s.Failures++ })
+	}
+	return resp, err
+}
+
+func (c *Client) execute(ctx context.Context, rpcs []RPC) (*Response, error) {
 	u, err := url.Parse(fmt.Sprintf("https://%s/_/%s/data/batchexecute", c.config.Host, c.config.App))
 	if err != nil {
 		return nil, fmt.Errorf("parse url: %w", err)
@@ -132,8 +112,8 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 	u.RawQuery = q.Encode()
 
 	if c.config.Debug {
-		fmt.Printf("\n=== BatchExecute Request ===\n")
-		fmt.Printf("URL: %s\n", u.String())
+		c.debugf("\n=== BatchExecute Request ===\n")
+		c.debugf("URL: %s\n", redact.URL(u.String()))
 	}
 
 	// Build request body
@@ -152,42 +132,13 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 	form.Set("at", c.config.AuthToken)
 
 	if c.config.Debug {
-		// Safely display auth token with conservative masking
-		token := c.config.AuthToken
-		var tokenDisplay string
-		if len(token) <= 8 {
-			// For very short tokens, mask completely
-			tokenDisplay = strings.Repeat("*", len(token))
-		} else if len(token) <= 16 {
-			// For short tokens, show first 2 and last 2 chars
-			start := token[:2]
-			end := token[len(token)-2:]
-			tokenDisplay = start + strings.Repeat("*", len(token)-4) + end
-		} else {
-			// For long tokens, show first 3 and last 3 chars
-			start := token[:3]
-			end := token[len(token)-3:]
-			tokenDisplay = start + strings.Repeat("*", len(token)-6) + end
-		}
-		fmt.Printf("\nAuth Token: %s\n", tokenDisplay)
-
-		// Mask auth token in request body display
-		maskedForm := url.Values{}
-		for k, v := range form {
-			if k == "at" && len(v) > 0 {
-				// Mask the auth token value
-				maskedValue := maskSensitiveValue(v[0])
-				maskedForm.Set(k, maskedValue)
-			} else {
-				maskedForm[k] = v
-			}
-		}
-		fmt.Printf("\nRequest Body:\n%s\n", maskedForm.Encode())
-		fmt.Printf("\nDecoded Request Body:\n%s\n", string(reqBody))
+		c.debugf("\nAuth Token: %s\n", redact.Value(c.config.AuthToken))
+		c.debugf("\nRequest Body:\n%s\n", redact.Form(form))
+		c.debugf("\nDecoded Request Body:\n%s\n", string(reqBody))
 	}
 
 	// Create request
-	req, err := http.NewRequest("POST", u.String(), strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -198,16 +149,19 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 		req.Header.Set(k, v)
 	}
 	req.Header.Set("cookie", c.config.Cookies)
+	if len(rpcs) > 0 {
+		for k, v := range rpcs[0].Headers {
+			req.Header.Set(k, v)
+		}
+	}
+
+	c.WriteCurl(req, form)
 
 	if c.config.Debug {
-		fmt.Printf("\nRequest Headers:\n")
+		c.debugf("\nRequest Headers:\n")
 		for k, v := range req.Header {
-			if strings.ToLower(k) == "cookie" && len(v) > 0 {
-				// Mask cookie values for security
-				maskedCookies := maskCookieValues(v[0])
-				fmt.Printf("%s: [%s]\n", k, maskedCookies)
-			} else {
-				fmt.Printf("%s: %v\n", k, v)
+			for _, value := range v {
+				c.debugf("%s: %s\n", k, redact.Header(k, value))
 			}
 		}
 	}
@@ -215,6 +169,17 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 	// Execute request with retry logic
 	var resp *http.Response
 	var lastErr error
+	var start time.Time
+	encoded := form.Encode()
+	gzipped := c.gzipBody(encoded)
+	// attemptDone records an attempt's cost once it has finished.
+	attemptDone := func(received int) {
+		elapsed := time.Since(start)
+		c.stats.update(func(s *StatsSnapshot) {
+			s.Latency += elapsed
+			s.BytesReceived += int64(received)
+		})
+	}
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		if attempt > 0 {
@@ -226,19 +191,40 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 			}
 
 			if c.config.Debug {
-				fmt.Printf("\nRetrying request (attempt %d/%d) after %v...\n", attempt, c.config.MaxRetries, delay)
+				c.debugf("\nRetrying request (attempt %d/%d) after %v...\n", attempt, c.config.MaxRetries, delay)
+			}
+			t := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return nil, fmt.Errorf("execute request: %w", ctx.Err())
+			case <-t.C:
 			}
-			time.Sleep(delay)
 		}
 
 		// Clone the request for each attempt
 		reqClone := req.Clone(req.Context())
-		if req.Body != nil {
-			reqClone.Body = io.NopCloser(strings.NewReader(form.Encode()))
-		}
-
+		sent := len(encoded)
+		if gzipped != nil {
+			reqClone.Body = io.NopCloser(bytes.NewReader(gzipped))
+			reqClone.ContentLength = int64(len(gzipped))
+			reqClone.Header.Set("Content-Encoding", "gzip")
+			sent = len(gzipped)
+		} else if req.Body != nil {
+			reqClone.Body = io.NopCloser(strings.NewReader(encoded))
+		}
+
+		c.stats.update(func(s *StatsSnapshot) {
+			s.Requests++
+			s.BytesSent += int64(sent)
+			if attempt > 0 {
+				s.Retries++
+			}
+		})
+		start = time.Now()
 		resp, err = c.httpClient.Do(reqClone)
 		if err != nil {
+			attemptDone(0)
 			lastErr = err
 			// Check for common network errors and provide more helpful messages
 			if strings.Contains(err.Error(), "dial tcp") {
@@ -258,8 +244,19 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 			return nil, lastErr
 		}
 
+		// Send the body as is if the server does not take it gzipped
+		if gzipped != nil && resp.StatusCode == http.StatusUnsupportedMediaType {
+			attemptDone(0)
+			resp.Body.Close()
+			c.compression.refused.Store(true)
+			gzipped = nil
+			attempt--
+			continue
+		}
+
 		// Check if response status is retryable
 		if isRetryableStatus(resp.StatusCode) && attempt < c.config.MaxRetries {
+			attemptDone(0)
 			resp.Body.Close()
 			lastErr = fmt.Errorf("server returned status %d", resp.StatusCode)
 			continue
@@ -274,15 +271,19 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	buf := getBodyBuffer()
+	defer putBodyBuffer(buf)
+	_, err = buf.ReadFrom(resp.Body)
+	body := buf.Bytes()
+	attemptDone(len(body))
 	if err != nil {
 		return nil, fmt.Errorf("read response: %w", err)
 	}
 
 	if c.config.Debug {
-		fmt.Printf("\nResponse Status: %s\n", resp.Status)
-		fmt.Printf("Raw Response Body:\n%q\n", string(body))
-		fmt.Printf("Response Body:\n%s\n", string(body))
+		c.debugf("\nResponse Status: %s\n", resp.Status)
+		c.debugf("Raw Response Body:\n%q\n", string(body))
+		c.debugf("Response Body:\n%s\n", string(body))
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -294,15 +295,15 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 	}
 
 	// Try to parse the response
-	responses, err := decodeResponse(string(body))
+	responses, err := decodeResponse(body)
 	if err != nil {
 		if c.config.Debug {
-			fmt.Printf("Failed to decode response: %v\n", err)
-			fmt.Printf("Raw response: %s\n", string(body))
+			c.debugf("Failed to decode response: %v\n", err)
+			c.debugf("Raw response: %s\n", redact.Truncate(string(body), maxDebugBody))
 		}
 
 		// Special handling for certain responses
-		if strings.Contains(string(body), "\"error\"") {
+		if bytes.Contains(body, []byte(`"error"`)) {
 			// It contains an error field, let's try to extract it
 			var errorResp struct {
 				Error string `json:"error"`
@@ -317,16 +318,16 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 
 	if len(responses) == 0 {
 		if c.config.Debug {
-			fmt.Printf("No valid responses found in: %s\n", string(body))
+			c.debugf("No valid responses found in: %s\n", redact.Truncate(string(body), maxDebugBody))
 		}
 		return nil, fmt.Errorf("no valid responses found")
 	}
 
-	// Check the first response for API errors
-	firstResponse := &responses[0]
+	// Check the response to the first RPC for API errors
+	firstResponse := responseFor(responses, rpcs[0])
 	if apiError, isError := IsErrorResponse(firstResponse); isError {
 		if c.config.Debug {
-			fmt.Printf("Detected API error: %s\n", apiError.Error())
+			c.debugf("Detected API error: %s\n", apiError.Error())
 		}
 		return nil, apiError
 	}
@@ -340,25 +341,53 @@ func (c *Client) Execute(rpcs []RPC) (*Response, error) {
 	return firstResponse, nil
 }
 
-// decodeResponse decodes the batchexecute response
-func decodeResponse(raw string) ([]Response, error) {
-	raw = strings.TrimSpace(strings.TrimPrefix(raw, ")]}'"))
-	if raw == "" {
+// maxDebugBody is how much of a response body is repeated in debug
+// output about failing to decode it, after the body has been printed in
+// full.
+const maxDebugBody = 1000
+
+// bodyPool holds the buffers response bodies are read into, so that a
+// session of large responses does not allocate a new one for each.
+var bodyPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// maxPooledBody is the largest buffer returned to bodyPool; larger ones
+// are left to the garbage collector rather than kept alive.
+const maxPooledBody = 16 << 20
+
+func getBodyBuffer() *bytes.Buffer {
+	return bodyPool.Get().(*bytes.Buffer)
+}
+
+// putBodyBuffer returns buf to bodyPool. Nothing decoded from it may
+// refer to its bytes afterwards.
+func putBodyBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBody {
+		return
+	}
+	buf.Reset()
+	bodyPool.Put(buf)
+}
+
+// decodeResponse decodes the batchexecute response. Only the outer
+// arrays are decoded: the payload of each RPC is kept as raw JSON rather
+// than decoded into interface values and marshaled again, which matters
+// for responses of several megabytes.
+func decodeResponse(raw []byte) ([]Response, error) {
+	raw = bytes.TrimSpace(bytes.TrimPrefix(raw, []byte(")]}'")))
+	if len(raw) == 0 {
 		return nil, fmt.Errorf("empty response after trimming prefix")
 	}
 
 	// Try to parse as a chunked response first
 	if isDigit(rune(raw[0])) {
-		reader := strings.NewReader(raw)
-		return decodeChunkedResponse(reader)
+		return decodeChunkedResponse(bytes.NewReader(raw))
 	}
 
 	// Try to parse as a regular response
-	var responses [][]interface{}
-	if err := json.NewDecoder(strings.NewReader(raw)).Decode(&responses); err != nil {
+	responses, err := decodeRows(raw)
+	if err != nil {
 		// Check if this might be a numeric response (happens with API errors)
-		trimmedRaw := strings.TrimSpace(raw)
-		if code, parseErr := strconv.Atoi(trimmedRaw); parseErr == nil {
+		if code, parseErr := strconv.Atoi(string(raw)); parseErr == nil {
 			// This is a numeric response, potentially an error code
 			return []Response{
 				{
@@ -367,15 +396,7 @@ func decodeResponse(raw string) ([]Response, error) {
 				},
 			}, nil
 		}
-
-		// Try to parse as a single array
-		var singleArray []interface{}
-		if err := json.NewDecoder(strings.NewReader(raw)).Decode(&singleArray); err == nil {
-			// Convert it to our expected format
-			responses = [][]interface{}{singleArray}
-		} else {
-			return nil, fmt.Errorf("decode response: %w", err)
-		}
+		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
 	var result []Response
@@ -383,46 +404,35 @@ func decodeResponse(raw string) ([]Response, error) {
 		if len(rpcData) < 7 {
 			continue
 		}
-		rpcType, ok := rpcData[0].(string)
-		if !ok || rpcType != "wrb.fr" {
+		var rpcType string
+		if json.Unmarshal(rpcData[0], &rpcType) != nil || rpcType != "wrb.fr" {
 			continue
 		}
 
-		id, _ := rpcData[1].(string)
+		var id string
+		json.Unmarshal(rpcData[1], &id)
 		resp := Response{
 			ID: id,
 		}
 
 		// Intelligently parse response data from multiple possible positions
 		// Format: ["wrb.fr", "rpcId", response_data, null, null, actual_data, "generic"]
-		var responseData interface{}
-
-		// Try position 2 first (traditional location)
-		if rpcData[2] != nil {
-			if dataStr, ok := rpcData[2].(string); ok {
+		// Position 2 (traditional location) holds the data encoded as a
+		// JSON string; anything else there is used as is.
+		if !isNullJSON(rpcData[2]) {
+			var dataStr string
+			if json.Unmarshal(rpcData[2], &dataStr) == nil {
 				resp.Data = json.RawMessage(dataStr)
-				responseData = dataStr
 			} else {
-				// If position 2 is not a string, use it directly
-				responseData = rpcData[2]
-			}
-		}
-
-		// If position 2 is null/empty, try position 5 (actual data)
-		if responseData == nil && len(rpcData) > 5 && rpcData[5] != nil {
-			responseData = rpcData[5]
-		}
-
-		// Convert responseData to JSON if it's not already a string
-		if responseData != nil && resp.Data == nil {
-			if dataBytes, err := json.Marshal(responseData); err == nil {
-				resp.Data = json.RawMessage(dataBytes)
+				resp.Data = rpcData[2]
 			}
+		} else if len(rpcData) > 5 && !isNullJSON(rpcData[5]) {
+			// If position 2 is null/empty, try position 5 (actual data)
+			resp.Data = rpcData[5]
 		}
 
-		if rpcData[6] == "generic" {
-			resp.Index = 0
-		} else if indexStr, ok := rpcData[6].(string); ok {
+		var indexStr string
+		if json.Unmarshal(rpcData[6], &indexStr) == nil && indexStr != "generic" {
 			resp.Index, _ = strconv.Atoi(indexStr)
 		}
 
@@ -433,7 +443,102 @@ func decodeResponse(raw string) ([]Response, error) {
 		return nil, fmt.Errorf("no valid responses found")
 	}
 
-	return result, nil
+	return mergeResponses(result), nil
+}
+
+// decodeRows decodes the rows of every frame in raw, such as
+// ["wrb.fr", ...] and ["di", ...]. A body without length lines may still
+// hold several frames one after another. The whole of raw is decoded in
+// place when it is a single frame, which spares json.Decoder's copy of
+// it.
+func decodeRows(raw []byte) ([][]json.RawMessage, error) {
+	if json.Valid(raw) {
+		return frameRows(raw)
+	}
+	var rows [][]json.RawMessage
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	for {
+		var frame json.RawMessage
+		if err := dec.Decode(&frame); err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			// Keep the frames before anything that is not JSON.
+			if len(rows) > 0 {
+				return rows, nil
+			}
+			return nil, err
+		}
+		r, err := frameRows(frame)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r...)
+	}
+}
+
+// frameRows decodes the rows of a frame, which is usually an array of
+// rows but may be a single row.
+func frameRows(frame []byte) ([][]json.RawMessage, error) {
+	var rows [][]json.RawMessage
+	if err := json.Unmarshal(frame, &rows); err == nil {
+		return rows, nil
+	}
+	var row []json.RawMessage
+	if err := json.Unmarshal(frame, &row); err != nil {
+		return nil, err
+	}
+	return [][]json.RawMessage{row}, nil
+}
+
+// mergeResponses joins the parts of RPC responses split across frames,
+// telling RPCs apart by ID and index. A payload that is not yet valid
+// JSON is continued by the next part for the same RPC; a complete one is
+// replaced by a later one, as the server resends a growing payload in
+// full.
+func mergeResponses(responses []Response) []Response {
+	type key struct {
+		id    string
+		index int
+	}
+	seen := make(map[key]int)
+	var merged []Response
+	for _, r := range responses {
+		k := key{r.ID, r.Index}
+		i, ok := seen[k]
+		if !ok {
+			seen[k] = len(merged)
+			merged = append(merged, r)
+			continue
+		}
+		m := &merged[i]
+		if len(m.Data) > 0 && !json.Valid(m.Data) {
+			m.Data = append(m.Data[:len(m.Data):len(m.Data)], r.Data...)
+		} else if r.Data != nil {
+			m.Data = r.Data
+		}
+		if r.Error != "" {
+			m.Error = r.Error
+		}
+	}
+	return merged
+}
+
+// responseFor returns the response to rpc, which is found by ID and, for
+// an RPC sent with a numeric index, by index. Responses not marked as
+// any RPC's, such as bare error codes, stand for the first RPC's.
+func responseFor(responses []Response, rpc RPC) *Response {
+	index, err := strconv.Atoi(rpc.Index)
+	for i, r := range responses {
+		if r.ID == rpc.ID && (err != nil || r.Index == index) {
+			return &responses[i]
+		}
+	}
+	return &responses[0]
+}
+
+// isNullJSON reports whether raw is absent or the JSON null.
+func isNullJSON(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
 }
 
 // decodeChunkedResponse decodes the batchexecute response
@@ -462,6 +567,23 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithContext makes the client's requests with ctx, so that canceling it
+// abandons them, including any retries.
+func WithContext(ctx context.Context) Option {
+	return func(c *Client) {
+		c.ctx = ctx
+	}
+}
+
+// WithDebugLogger enables debug output, sending it to logf rather than
+// standard output.
+func WithDebugLogger(logf func(format string, args ...interface{})) Option {
+	return func(c *Client) {
+		c.config.Debug = true
+		c.logf = logf
+	}
+}
+
 // WithDebug enables debug output
 func WithDebug(debug bool) Option {
 	return func(c *Client) {
@@ -483,12 +605,28 @@ func WithDebugDumpPayload(debugDumpPayload bool) Option {
 // WithTimeout sets the HTTP client timeout
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
-		if c.httpClient == http.DefaultClient {
-			c.httpClient = &http.Client{
-				Timeout: timeout,
+		// Copy the HTTP client rather than set its timeout: it may be
+		// shared with other clients, and in use.
+		hc := *c.httpClient
+		hc.Timeout = timeout
+		c.httpClient = &hc
+	}
+}
+
+// WithRetry sets the retry policy. Zero values keep the defaults.
+func WithRetry(maxRetries int, delay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		if maxRetries > 0 {
+			c.config.MaxRetries = maxRetries
+		}
+		if delay > 0 {
+			c.config.RetryDelay = delay
+			if c.config.RetryMaxDelay < delay {
+				c.config.RetryMaxDelay = delay
 			}
-		} else {
-			c.httpClient.Timeout = timeout
+		}
+		if maxDelay > 0 {
+			c.config.RetryMaxDelay = maxDelay
 		}
 	}
 }
@@ -547,9 +685,18 @@ type Config struct {
 // Client handles batchexecute operations
 type Client struct {
 	config     Config
+	ctx        context.Context
 	httpClient *http.Client
 	debug      func(format string, args ...interface{})
+	logf       func(format string, args ...interface{}) // receives debug output; see WithDebugLogger
 	reqid      *ReqIDGenerator
+
+	curl        io.Writer // receives a curl command per request; see WithCurl
+	curlSecrets bool
+	stats       *Stats // records request costs; see WithStats
+
+	interceptors []Interceptor // wrap each RPC; see WithInterceptors
+	compression  *compression  // nil unless WithCompression
 }
 
 // NewClient creates a new batchexecute client
@@ -567,7 +714,8 @@ func NewClient(config Config, opts ...Option) *Client {
 
 	c := &Client{
 		config:     config,
-		httpClient: http.DefaultClient,
+		ctx:        context.Background(),
+		httpClient: defaultHTTPClient,
 		debug:      func(format string, args ...interface{}) {}, // noop by default
 		reqid:      NewReqIDGenerator(),
 	}
@@ -581,6 +729,16 @@ func (c *Client) Config() Config {
 	return c.config
 }
 
+// debugf writes debug output to the WithDebugLogger function, or else to
+// standard output.
+func (c *Client) debugf(format string, args ...interface{}) {
+	if c.logf != nil {
+		c.logf(format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
 // ReqIDGenerator generates sequential request IDs
 type ReqIDGenerator struct {
 	base     int // Initial 4-digit number
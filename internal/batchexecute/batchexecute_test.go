@@ -209,7 +209,7 @@ func TestDecodeResponse(t *testing.T) {
 			if tc.chunked {
 				actual, err = decodeChunkedResponse(strings.NewReader(")]}'\n" + tc.input))
 			} else {
-				actual, err = decodeResponse(tc.input)
+				actual, err = decodeResponse([]byte(tc.input))
 			}
 
 			// Check error
@@ -234,6 +234,123 @@ func TestDecodeResponse(t *testing.T) {
 	}
 }
 
+func TestDecodeResponseDoesNotAliasInput(t *testing.T) {
+	raw := []byte(`)]}'
+[["wrb.fr","abc123","[1,\"x\"]",null,null,null,"generic"],["wrb.fr","def456",null,null,null,[2,"y"],"1"]]`)
+	got, err := decodeResponse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Response bodies are read into pooled buffers, reused once decoded.
+	for i := range raw {
+		raw[i] = 'z'
+	}
+	want := []Response{
+		{ID: "abc123", Data: json.RawMessage(`[1,"x"]`)},
+		{ID: "def456", Index: 1, Data: json.RawMessage(`[2,"y"]`)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("decodeResponse mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeResponseMultipleFrames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Response
+	}{
+		{
+			name: "bookkeeping frames between data frames",
+			input: `)]}'
+
+[["wrb.fr","abc123","[1]",null,null,null,"1"],["di",119]]
+[["af.httprm",118,"-6842696168044955425",7]]
+[["wrb.fr","def456","[2]",null,null,null,"2"]]
+[["e",4,null,null,143]]`,
+			want: []Response{
+				{ID: "abc123", Index: 1, Data: json.RawMessage(`[1]`)},
+				{ID: "def456", Index: 2, Data: json.RawMessage(`[2]`)},
+			},
+		},
+		{
+			name: "payload split across frames",
+			input: `)]}'
+[["wrb.fr","abc123","[[\"first\",",null,null,null,"1"]]
+[["wrb.fr","def456","[2]",null,null,null,"2"]]
+[["wrb.fr","abc123","\"second\"]]",null,null,null,"1"]]`,
+			want: []Response{
+				{ID: "abc123", Index: 1, Data: json.RawMessage(`[["first","second"]]`)},
+				{ID: "def456", Index: 2, Data: json.RawMessage(`[2]`)},
+			},
+		},
+		{
+			name: "payload resent in full",
+			input: `)]}'
+[["wrb.fr","abc123","[\"partial\"]",null,null,null,"generic"]]
+[["wrb.fr","abc123","[\"partial answer\"]",null,null,null,"generic"]]`,
+			want: []Response{
+				{ID: "abc123", Data: json.RawMessage(`["partial answer"]`)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeResponse([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("decodeResponse mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResponseFor(t *testing.T) {
+	responses := []Response{
+		{ID: "abc123", Index: 1, Data: json.RawMessage(`[1]`)},
+		{ID: "def456", Index: 2, Data: json.RawMessage(`[2]`)},
+	}
+	tests := []struct {
+		rpc  RPC
+		want string
+	}{
+		{RPC{ID: "def456", Index: "generic"}, `[2]`},
+		{RPC{ID: "def456", Index: "2"}, `[2]`},
+		{RPC{ID: "abc123", Index: "1"}, `[1]`},
+		{RPC{ID: "abc123", Index: "3"}, `[1]`},
+		{RPC{ID: "other"}, `[1]`},
+	}
+	for _, tt := range tests {
+		if got := responseFor(responses, tt.rpc); string(got.Data) != tt.want {
+			t.Errorf("responseFor(%s, %q) = %s, want %s", tt.rpc.ID, tt.rpc.Index, got.Data, tt.want)
+		}
+	}
+}
+
+// largeResponse returns a response carrying a payload of about n bytes.
+func largeResponse(n int) []byte {
+	var items []string
+	for size := 0; size < n; size += 64 {
+		items = append(items, `["source","A fairly ordinary sentence of text.",[1700000000,0]]`)
+	}
+	payload, _ := json.Marshal("[" + strings.Join(items, ",") + "]")
+	return []byte(`)]}'
+[["wrb.fr","rLM1Ne",` + string(payload) + `,null,null,null,"generic"]]`)
+}
+
+func BenchmarkDecodeResponse(b *testing.B) {
+	raw := largeResponse(4 << 20)
+	b.SetBytes(int64(len(raw)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeResponse(raw); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestExecute(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received request")
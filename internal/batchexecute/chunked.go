@@ -2,6 +2,7 @@ package batchexecute
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,156 +10,124 @@ import (
 	"strings"
 )
 
-// parseChunkedResponse parses a chunked response from the batchexecute API.
-// The response format is:
-// <chunk-length>
-// <chunk-data>
-// <chunk-length>
-// <chunk-data>
-// ...
-func parseChunkedResponse(r io.Reader) ([]Response, error) {
-	// First, strip the prefix if present
-	br := bufio.NewReader(r)
-
-	// The response format is )]}'\n\n or )]}'\n
-	// We need to consume the entire prefix including newlines
-	prefix, err := br.Peek(6) // Peek enough to see )]}'\n
-	if err != nil && err != io.EOF {
-		// If we can't peek 6, try 4
-		prefix, err = br.Peek(4)
-		if err != nil && err != io.EOF {
-			return nil, fmt.Errorf("peek response prefix: %w", err)
-		}
-	}
-
-	// Debug: print what we see
-	if len(prefix) > 0 {
-		fmt.Printf("DEBUG: Response starts with: %q\n", prefix)
-	}
+// FrameReader reads the frames of a chunked batchexecute response, as
+// sent with rt=c. Each frame is preceded by a line giving its length,
+// counted from the newline that ends the length line through the newline
+// that ends the frame, so two more than the frame's own bytes:
+//
+//	)]}'
+//
+//	64
+//	[["wrb.fr","wXbhsf",null,null,null,[16],"generic"],["di",119]]
+//	25
+//	[["e",4,null,null,143]]
+//
+// A frame may span several lines, so its end is found from the count,
+// not from line breaks.
+type FrameReader struct {
+	br      *bufio.Reader
+	started bool
+}
 
-	// Check for and discard the )]}' prefix with newlines
-	if len(prefix) >= 4 && string(prefix[:4]) == ")]}''" {
-		// Read the first line ()]}')
-		line, err := br.ReadString('\n')
-		if err != nil && err != io.EOF {
-			return nil, fmt.Errorf("read prefix line: %w", err)
-		}
-		fmt.Printf("DEBUG: Discarded prefix line: %q\n", line)
+// NewFrameReader returns a FrameReader reading from r.
+func NewFrameReader(r io.Reader) *FrameReader {
+	return &FrameReader{br: bufio.NewReader(r)}
+}
 
-		// Check if there's an additional empty line and consume it
-		nextByte, err := br.Peek(1)
-		if err == nil && len(nextByte) > 0 && nextByte[0] == '\n' {
-			br.ReadByte() // Consume the extra newline
-			fmt.Printf("DEBUG: Discarded extra newline after prefix\n")
-		}
+// Next returns the next frame, without surrounding whitespace, or io.EOF
+// when there are no more. A length line with no frame after it is
+// returned as a frame of its own, since the server answers some failures
+// with a bare number. A line that is not a length is returned alone.
+func (fr *FrameReader) Next() ([]byte, error) {
+	if err := fr.skipSpace(); err != nil {
+		return nil, err
 	}
-
-	var (
-		chunks     []string
-		scanner    = bufio.NewScanner(br)
-		chunkData  strings.Builder
-		collecting bool
-		chunkSize  int
-		allLines   []string
-	)
-
-	// Increase scanner buffer size to handle large chunks (up to 10MB)
-	const maxScanTokenSize = 10 * 1024 * 1024 // 10MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
-
-	// Process each line
-	for scanner.Scan() {
-		line := scanner.Text()
-		allLines = append(allLines, line)
-
-		// Only debug small lines to avoid flooding
-		if len(line) < 200 {
-			fmt.Printf("DEBUG: Processing line: %q\n", line)
-		} else {
-			fmt.Printf("DEBUG: Processing large line (%d bytes)\n", len(line))
+	if !fr.started {
+		fr.started = true
+		if p, _ := fr.br.Peek(4); string(p) == ")]}'" {
+			fr.br.Discard(4)
+			if err := fr.skipSpace(); err != nil {
+				return nil, err
+			}
 		}
+	}
 
-		// Skip empty lines only if not collecting
-		if !collecting && strings.TrimSpace(line) == "" {
-			fmt.Printf("DEBUG: Skipping empty line\n")
-			continue
+	var digits []byte
+	for {
+		c, err := fr.br.ReadByte()
+		if err != nil {
+			break
 		}
-
-		// If we're not currently collecting a chunk, this line should be a chunk length
-		if !collecting {
-			size, err := strconv.Atoi(strings.TrimSpace(line))
-			if err != nil {
-				// If not a number, it might be direct JSON data
-				// Check if it looks like JSON
-				if strings.HasPrefix(strings.TrimSpace(line), "{") || strings.HasPrefix(strings.TrimSpace(line), "[") {
-					chunks = append(chunks, line)
-				} else if strings.HasPrefix(strings.TrimSpace(line), "wrb.fr") {
-					// It might be a direct RPC response without proper JSON format
-					chunks = append(chunks, "["+line+"]")
-				} else {
-					// Fallback: treat as a potential response chunk anyway
-					chunks = append(chunks, line)
-				}
-				continue
-			}
-
-			chunkSize = size
-			collecting = true
-			chunkData.Reset()
-			fmt.Printf("DEBUG: Expecting chunk of %d bytes\n", chunkSize)
-			continue
+		if !isDigit(rune(c)) {
+			fr.br.UnreadByte()
+			break
 		}
-
-		// If we're collecting a chunk, add this line to the current chunk
-		if chunkData.Len() > 0 {
-			chunkData.WriteString("\n")
+		digits = append(digits, c)
+	}
+	if len(digits) == 0 {
+		line, err := fr.br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
 		}
-		chunkData.WriteString(line)
+		return bytes.TrimSpace(line), nil
+	}
+	n, err := strconv.Atoi(string(digits))
+	if err != nil {
+		return nil, fmt.Errorf("invalid frame length %q", digits)
+	}
 
-		// If we've collected enough data, add the chunk and reset
-		if chunkData.Len() >= chunkSize {
-			fmt.Printf("DEBUG: Collected full chunk (%d bytes)\n", chunkData.Len())
-			chunks = append(chunks, chunkData.String())
-			collecting = false
+	frame := make([]byte, n)
+	read, err := io.ReadFull(fr.br, frame)
+	frame = frame[:read]
+	switch {
+	case err == io.EOF || err == nil && len(bytes.TrimSpace(frame)) == 0:
+		return digits, nil
+	case err == io.ErrUnexpectedEOF:
+		return bytes.TrimSpace(frame), nil
+	case err != nil:
+		return nil, err
+	}
+	// For text outside ASCII the server's count can fall short of the
+	// bytes sent, as it counts characters. A short count ends within the
+	// frame's last line, so read on to the end of the line until the
+	// frame is complete.
+	for !json.Valid(frame) {
+		rest, err := fr.br.ReadBytes('\n')
+		frame = append(frame, rest...)
+		if err != nil {
+			break
 		}
 	}
+	return bytes.TrimSpace(frame), nil
+}
 
-	// Check if we have any partial chunk data remaining
-	if collecting && chunkData.Len() > 0 {
-		// We have partial data, add it as a chunk
-		fmt.Printf("DEBUG: Adding partial chunk (%d of %d bytes)\n", chunkData.Len(), chunkSize)
-		chunks = append(chunks, chunkData.String())
-	} else if collecting && chunkData.Len() == 0 {
-		// We were expecting data but got none
-		// Only treat small numbers as potential error codes
-		if chunkSize < 1000 {
-			// Small number, might be an error code
-			possibleError := strconv.Itoa(chunkSize)
-			fmt.Printf("DEBUG: Expected %d bytes but got 0, treating %s as potential error response\n", chunkSize, possibleError)
-			chunks = append(chunks, possibleError)
-		} else {
-			// Large number, probably a real chunk size but we didn't get the data
-			// This might be a parsing issue with the scanner
-			fmt.Printf("DEBUG: Expected large chunk (%d bytes) but got 0, scanner may have hit limit\n", chunkSize)
-			// Try to use all lines as the chunk data
-			if len(allLines) > 1 {
-				// Skip the first line (chunk size) and use the rest
-				chunks = append(chunks, strings.Join(allLines[1:], "\n"))
-			}
+func (fr *FrameReader) skipSpace() error {
+	for {
+		c, err := fr.br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return fr.br.UnreadByte()
 		}
 	}
+}
 
-	// If we still have no chunks but we processed lines, this might be a different response format
-	if len(chunks) == 0 && len(allLines) > 0 {
-		// Treat all the lines as a single response
-		allData := strings.Join(allLines, "\n")
-		if strings.TrimSpace(allData) != "" {
-			chunks = append(chunks, allData)
+// parseChunkedResponse parses a chunked response from the batchexecute API
+// and returns the RPC responses of all its frames.
+func parseChunkedResponse(r io.Reader) ([]Response, error) {
+	fr := NewFrameReader(r)
+	var chunks []string
+	for {
+		frame, err := fr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read response frame: %w", err)
 		}
+		chunks = append(chunks, string(frame))
 	}
-
-	// Process all collected chunks
 	return processChunks(chunks)
 }
 
@@ -293,15 +262,6 @@ func isNumeric(s string) bool {
 }
 
 func processChunks(chunks []string) ([]Response, error) {
-	fmt.Printf("DEBUG: processChunks called with %d chunks\n", len(chunks))
-	for i, chunk := range chunks {
-		fmt.Printf("DEBUG: Chunk %d: %q\n", i, chunk)
-	}
-
-	if len(chunks) == 0 {
-		return nil, fmt.Errorf("no chunks found")
-	}
-
 	// Check for numeric responses (potential error codes)
 	// These need to be converted to synthetic Response objects so our error handling can process them
 	for _, chunk := range chunks {
@@ -375,7 +335,7 @@ func processChunks(chunks []string) ([]Response, error) {
 		return nil, fmt.Errorf("no valid responses found")
 	}
 
-	return allResponses, nil
+	return mergeResponses(allResponses), nil
 }
 
 // extractResponses extracts Response objects from RPC data
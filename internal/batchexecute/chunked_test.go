@@ -0,0 +1,106 @@
+package batchexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// frame returns payload with the length line the server puts before it.
+func frame(payload string) string {
+	return fmt.Sprintf("%d\n%s\n", len(payload)+2, payload)
+}
+
+func readFrames(t *testing.T, input string) []string {
+	t.Helper()
+	fr := NewFrameReader(strings.NewReader(input))
+	var frames []string
+	for {
+		f, err := fr.Next()
+		if err == io.EOF {
+			return frames
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		frames = append(frames, string(f))
+	}
+}
+
+func TestFrameReader(t *testing.T) {
+	multiline := "[[\"wrb.fr\",\"a\",\"[1]\"\n,null,null,null,\"generic\"]]"
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "frames",
+			input: ")]}'\n\n" + frame(`[["wrb.fr","a","[1]"]]`) + frame(`[["e",4,null,null,143]]`),
+			want:  []string{`[["wrb.fr","a","[1]"]]`, `[["e",4,null,null,143]]`},
+		},
+		{
+			name:  "frame spanning lines",
+			input: ")]}'\n\n" + frame(multiline) + frame(`[["di",9]]`),
+			want:  []string{multiline, `[["di",9]]`},
+		},
+		{
+			name:  "frame containing digits on a line",
+			input: frame("[\n25\n]") + frame(`[["di",9]]`),
+			want:  []string{"[\n25\n]", `[["di",9]]`},
+		},
+		{
+			name: "count short of the bytes sent",
+			// "é" is one character but two bytes.
+			input: "22\n[[\"wrb.fr\",\"a\",\"é\"]]\n" + frame(`[["di",9]]`),
+			want:  []string{`[["wrb.fr","a","é"]]`, `[["di",9]]`},
+		},
+		{
+			name:  "bare number",
+			input: ")]}'\n277567",
+			want:  []string{"277567"},
+		},
+		{
+			name:  "truncated frame",
+			input: "100\n[[\"wrb.fr\",\"test\",\"",
+			want:  []string{`[["wrb.fr","test","`},
+		},
+		{
+			name:  "empty",
+			input: ")]}'\n",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, readFrames(t, tt.input)); diff != "" {
+				t.Errorf("frames mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeChunkedResponseFrames(t *testing.T) {
+	// The responses to a batch of RPCs arrive in separate frames, one of
+	// them spread over several lines.
+	input := ")]}'\n\n" +
+		frame(`[["wrb.fr","abc123","[1,\"x\"]",null,null,null,"generic"]]`) +
+		frame("[[\"wrb.fr\",\"def456\",\"[2,\\\"y\\\"]\",\nnull,null,null,\"generic\"]]") +
+		frame(`[["di",125],["af.httprm",124,"6343297907846200142",27]]`) +
+		frame(`[["e",4,null,null,237]]`)
+	got, err := decodeResponse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Response{
+		{ID: "abc123", Data: json.RawMessage(`[1,"x"]`)},
+		{ID: "def456", Data: json.RawMessage(`[2,"y"]`)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("decodeResponse mismatch (-want +got):\n%s", diff)
+	}
+}
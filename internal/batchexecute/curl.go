@@ -0,0 +1,125 @@
+package batchexecute
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tmc/nlm/internal/redact"
+)
+
+// curlMu serializes curl output, since clients used from several
+// goroutines, or several clients, may share one writer.
+var curlMu sync.Mutex
+
+// WithCurl writes an equivalent curl command to w for every request the
+// client sends. Unless secrets is true, the auth token and cookies are
+// replaced by references to $NLM_AUTH_TOKEN and $NLM_COOKIES, so the
+// command can be shared in a bug report and still run where those
+// variables are set, and session IDs in the URL, such as f.sid, are
+// masked.
+func WithCurl(w io.Writer, secrets bool) Option {
+	return func(c *Client) {
+		c.curl = w
+		c.curlSecrets = secrets
+	}
+}
+
+// WriteCurl writes the curl command for req, sent with the given form
+// body, if the client was made WithCurl. Requests that other packages
+// build with the client's credentials, such as uploads and streamed
+// chats, go through it so that they are redacted the same way.
+func (c *Client) WriteCurl(req *http.Request, form url.Values) {
+	if c.curl == nil {
+		return
+	}
+	cmd := CurlCommand(req, form, c.curlSecrets)
+	curlMu.Lock()
+	fmt.Fprintf(c.curl, "%s\n", cmd)
+	curlMu.Unlock()
+}
+
+// maxCurlBody is the largest body other than a form that CurlCommand
+// writes out; larger ones are left to be read from stdin.
+const maxCurlBody = 4 << 10
+
+// CurlCommand returns a curl command line that sends req with the given
+// form body, or with req's own body if form is nil. Credentials are
+// written as shell variables, and session IDs masked, unless secrets is
+// true.
+func CurlCommand(req *http.Request, form url.Values, secrets bool) string {
+	var b strings.Builder
+	u := req.URL.String()
+	if !secrets {
+		u = redact.URL(u)
+	}
+	fmt.Fprintf(&b, "curl %s", shellQuote(u))
+	// curl sends a POST only when there is data to send.
+	bodyless := form == nil && req.ContentLength == 0
+	if req.Method != "" && req.Method != "GET" && (req.Method != "POST" || bodyless) {
+		fmt.Fprintf(&b, " \\\n  -X %s", req.Method)
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := strings.ToLower(name)
+		for _, v := range req.Header[name] {
+			if key == "cookie" && !secrets {
+				fmt.Fprintf(&b, " \\\n  -H \"%s: $NLM_COOKIES\"", key)
+				continue
+			}
+			fmt.Fprintf(&b, " \\\n  -H %s", shellQuote(key+": "+v))
+		}
+	}
+
+	keys := make([]string, 0, len(form))
+	for k := range form {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range form[k] {
+			if k == "at" && !secrets {
+				fmt.Fprintf(&b, " \\\n  --data-urlencode \"at=$NLM_AUTH_TOKEN\"")
+				continue
+			}
+			fmt.Fprintf(&b, " \\\n  --data-urlencode %s", shellQuote(k+"="+v))
+		}
+	}
+	if form == nil && req.ContentLength != 0 {
+		fmt.Fprintf(&b, " \\\n  --data-binary %s", curlBody(req))
+	}
+	return b.String()
+}
+
+// curlBody returns the argument to --data-binary for req's body: the body
+// itself if it is short and can be read again, else @- to read it from
+// stdin.
+func curlBody(req *http.Request) string {
+	if req.GetBody == nil || req.ContentLength < 0 || req.ContentLength > maxCurlBody {
+		return "@-"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "@-"
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "@-"
+	}
+	return shellQuote(string(data))
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
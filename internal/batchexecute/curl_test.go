@@ -0,0 +1,95 @@
+package batchexecute
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestWithCurl(t *testing.T) {
+	type request struct{ freq, at, cookie string }
+	var got []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		got = append(got, request{r.Form.Get("f.req"), r.Form.Get("at"), r.Header.Get("Cookie")})
+		fmt.Fprint(w, `)]}'
+[["wrb.fr","wXbhsf","[1]",null,null,null,"generic"]]`)
+	}))
+	defer server.Close()
+
+	const token, cookies = "secret-token", "SID=it's-secret; HSID=x"
+	config := Config{
+		Host:      strings.TrimPrefix(server.URL, "http://"),
+		App:       "notebooklm",
+		AuthToken: token,
+		Cookies:   cookies,
+		URLParams: map[string]string{"f.sid": "-7121977511756781186"},
+		UseHTTP:   true,
+	}
+	for _, secrets := range []bool{false, true} {
+		got = nil
+		var out bytes.Buffer
+		client := NewClient(config, WithHTTPClient(server.Client()), WithCurl(&out, secrets))
+		if _, err := client.Do(RPC{ID: "wXbhsf", Args: []interface{}{nil, 1}}); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		cmd := out.String()
+		if !strings.HasPrefix(cmd, "curl '"+server.URL) || !strings.Contains(cmd, `--data-urlencode 'f.req=`) {
+			t.Errorf("curl command = %s", cmd)
+		}
+		if leaked := strings.Contains(cmd, "secret"); leaked != secrets {
+			t.Errorf("with secrets=%v, command contains credentials: %v\n%s", secrets, leaked, cmd)
+		}
+		if leaked := strings.Contains(cmd, "7121977511756781186"); leaked != secrets {
+			t.Errorf("with secrets=%v, command contains the session ID: %v\n%s", secrets, leaked, cmd)
+		}
+
+		// The command reproduces the request, reading redacted
+		// credentials from the environment.
+		if _, err := exec.LookPath("curl"); err != nil {
+			continue
+		}
+		run := exec.Command("sh", "-c", cmd)
+		run.Env = append(os.Environ(), "NLM_AUTH_TOKEN="+token, "NLM_COOKIES="+cookies)
+		if out, err := run.CombinedOutput(); err != nil {
+			t.Fatalf("running curl command: %v\n%s", err, out)
+		}
+		if len(got) != 2 || got[0] != got[1] {
+			t.Errorf("curl sent %+v, want the client's request %+v", got[len(got)-1], got[0])
+		}
+		if got[0].at != token || got[0].cookie != cookies {
+			t.Errorf("client sent at=%q cookie=%q", got[0].at, got[0].cookie)
+		}
+	}
+}
+
+func TestCurlCommandBody(t *testing.T) {
+	req, _ := http.NewRequest("POST", "https://example.com/upload?upload_id=ABCDEFGH12345678xyz", strings.NewReader(`{"PROJECT_ID":"nb1"}`))
+	req.Header.Set("Cookie", "SID=secret")
+	cmd := CurlCommand(req, nil, false)
+	for _, want := range []string{`--data-binary '{"PROJECT_ID":"nb1"}'`, `"cookie: $NLM_COOKIES"`, "upload_id=ABC***"} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("CurlCommand() = %s\nwant it to contain %s", cmd, want)
+		}
+	}
+
+	// A body that cannot be read again is left to stdin, and a POST
+	// without a body says so.
+	req, _ = http.NewRequest("POST", "https://example.com/upload", io.NopCloser(strings.NewReader("part")))
+	req.ContentLength = 4
+	if cmd := CurlCommand(req, nil, false); !strings.Contains(cmd, "--data-binary @-") {
+		t.Errorf("CurlCommand() = %s, want the body read from stdin", cmd)
+	}
+	req, _ = http.NewRequest("POST", "https://example.com/upload", http.NoBody)
+	if cmd := CurlCommand(req, nil, false); !strings.Contains(cmd, "-X POST") {
+		t.Errorf("CurlCommand() = %s, want -X POST", cmd)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // ErrorType represents different categories of API errors
@@ -93,6 +94,10 @@ func (e *APIError) IsRetryable() bool {
 	}
 }
 
+// errorCodesMu guards errorCodeDictionary, which AddErrorCode may change
+// while requests are being decoded.
+var errorCodesMu sync.RWMutex
+
 // errorCodeDictionary maps numeric error codes to their definitions
 var errorCodeDictionary = map[int]ErrorCode{
 	// Authentication errors
@@ -320,6 +325,8 @@ var errorCodeDictionary = map[int]ErrorCode{
 
 // GetErrorCode returns the ErrorCode for a given numeric code
 func GetErrorCode(code int) (*ErrorCode, bool) {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
 	if errorCode, exists := errorCodeDictionary[code]; exists {
 		return &errorCode, true
 	}
@@ -505,11 +512,15 @@ func ParseAPIError(rawResponse string, httpStatus int) *APIError {
 
 // AddErrorCode allows adding custom error codes to the dictionary at runtime
 func AddErrorCode(code int, errorCode ErrorCode) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
 	errorCodeDictionary[code] = errorCode
 }
 
 // ListErrorCodes returns all registered error codes
 func ListErrorCodes() map[int]ErrorCode {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
 	result := make(map[int]ErrorCode)
 	for k, v := range errorCodeDictionary {
 		result[k] = v
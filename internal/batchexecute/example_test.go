@@ -3,6 +3,7 @@ package batchexecute
 import (
 	"fmt"
 	"log"
+	"strings"
 )
 
 // ExampleAPIError demonstrates how API errors are detected and handled
@@ -119,3 +120,22 @@ func ExampleIsErrorResponse() {
 		log.Println("Success response detected")
 	}
 }
+
+// ExampleFrameReader reads the response shown in the FrameReader
+// documentation.
+func ExampleFrameReader() {
+	resp := ")]}'\n\n" +
+		"64\n[[\"wrb.fr\",\"wXbhsf\",null,null,null,[16],\"generic\"],[\"di\",119]]\n" +
+		"25\n[[\"e\",4,null,null,143]]\n"
+	fr := NewFrameReader(strings.NewReader(resp))
+	for {
+		frame, err := fr.Next()
+		if err != nil {
+			break
+		}
+		fmt.Printf("%s\n", frame)
+	}
+	// Output:
+	// [["wrb.fr","wXbhsf",null,null,null,[16],"generic"],["di",119]]
+	// [["e",4,null,null,143]]
+}
@@ -0,0 +1,42 @@
+package batchexecute
+
+import "context"
+
+// Invoker executes a batch of RPCs, as Client.Execute does, with ctx.
+type Invoker func(ctx context.Context, rpcs []RPC) (*Response, error)
+
+// Interceptor wraps the Invoker that executes a client's RPCs, to run
+// code around every call, much like a gRPC interceptor: it may log the
+// call, change its URL parameters or headers, answer it from a cache
+// without calling next, or call next again after refreshing credentials.
+//
+//	logging := func(next batchexecute.Invoker) batchexecute.Invoker {
+//		return func(ctx context.Context, rpcs []batchexecute.RPC) (*batchexecute.Response, error) {
+//			start := time.Now()
+//			resp, err := next(ctx, rpcs)
+//			log.Printf("%s: %v (%v)", rpcs[0].ID, err, time.Since(start))
+//			return resp, err
+//		}
+//	}
+//
+// Interceptors may be called from several goroutines at once.
+type Interceptor func(next Invoker) Invoker
+
+// WithInterceptors wraps the client's RPCs in interceptors. The first
+// interceptor is the outermost, seeing each call first and its result
+// last. Interceptors from several WithInterceptors options are chained
+// in order.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *Client) {
+		c.interceptors = append(c.interceptors[:len(c.interceptors):len(c.interceptors)], interceptors...)
+	}
+}
+
+// invoker returns the client's Invoker, wrapped in its interceptors.
+func (c *Client) invoker() Invoker {
+	invoke := Invoker(c.execute)
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		invoke = c.interceptors[i](invoke)
+	}
+	return invoke
+}
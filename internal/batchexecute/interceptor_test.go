@@ -0,0 +1,103 @@
+package batchexecute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithInterceptors(t *testing.T) {
+	const reply = `)]}'
+[["wrb.fr","wXbhsf","[1]",null,null,null,"generic"]]`
+	var cookies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookies = append(cookies, r.Header.Get("cookie"))
+		if r.Header.Get("cookie") != "SID=fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("x-trace") != "t1" {
+			t.Errorf("x-trace = %q, want t1", r.Header.Get("x-trace"))
+		}
+		fmt.Fprint(w, reply)
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Interceptor {
+		return func(next Invoker) Invoker {
+			return func(ctx context.Context, rpcs []RPC) (*Response, error) {
+				order = append(order, name)
+				rpcs[0].Headers = map[string]string{"x-trace": "t1"}
+				resp, err := next(ctx, rpcs)
+				order = append(order, name+" done")
+				return resp, err
+			}
+		}
+	}
+	// refresh retries a call rejected as unauthorized with new cookies.
+	refresh := func(next Invoker) Invoker {
+		return func(ctx context.Context, rpcs []RPC) (*Response, error) {
+			resp, err := next(ctx, rpcs)
+			if errors.Is(err, ErrUnauthorized) {
+				rpcs[0].Headers["cookie"] = "SID=fresh"
+				return next(ctx, rpcs)
+			}
+			return resp, err
+		}
+	}
+	cached := map[string]*Response{}
+	cache := func(next Invoker) Invoker {
+		return func(ctx context.Context, rpcs []RPC) (*Response, error) {
+			if resp, ok := cached[rpcs[0].ID]; ok {
+				return resp, nil
+			}
+			resp, err := next(ctx, rpcs)
+			if err == nil {
+				cached[rpcs[0].ID] = resp
+			}
+			return resp, err
+		}
+	}
+
+	config := Config{
+		Host:      strings.TrimPrefix(server.URL, "http://"),
+		App:       "notebooklm",
+		AuthToken: "token",
+		Cookies:   "SID=stale",
+		UseHTTP:   true,
+	}
+	stats := new(Stats)
+	client := NewClient(config,
+		WithHTTPClient(server.Client()),
+		WithRetry(1, time.Millisecond, time.Millisecond),
+		WithStats(stats),
+		WithInterceptors(cache, trace("outer")),
+		WithInterceptors(trace("inner"), refresh),
+	)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Do(RPC{ID: "wXbhsf"})
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		if string(resp.Data) != "[1]" {
+			t.Errorf("Do() = %s, want [1]", resp.Data)
+		}
+	}
+
+	// The second call is answered from the cache.
+	if want := "outer inner inner done outer done"; strings.Join(order, " ") != want {
+		t.Errorf("order = %q, want %q", strings.Join(order, " "), want)
+	}
+	if want := "SID=stale SID=fresh"; strings.Join(cookies, " ") != want {
+		t.Errorf("cookies = %q, want %q", strings.Join(cookies, " "), want)
+	}
+	if got := stats.Snapshot(); got.RPCs != 2 || got.Requests != 2 || got.Failures != 0 {
+		t.Errorf("Snapshot() = %+v, want 2 RPCs, 2 requests, no failures", got)
+	}
+}
@@ -0,0 +1,48 @@
+package batchexecute
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats accumulates the cost of the requests made by one or more clients.
+// It is safe for concurrent use.
+type Stats struct {
+	mu sync.Mutex
+	s  StatsSnapshot
+}
+
+// StatsSnapshot is a copy of the counters in Stats.
+type StatsSnapshot struct {
+	RPCs          int           // RPC calls made
+	Requests      int           // HTTP requests sent, including retries
+	Retries       int           // requests repeated after a retryable failure
+	Failures      int           // RPC calls that returned an error
+	BytesSent     int64         // request bodies
+	BytesReceived int64         // response bodies
+	Latency       time.Duration // time spent waiting on requests
+}
+
+// WithStats records the client's requests in s. Several clients may
+// share one Stats.
+func WithStats(s *Stats) Option {
+	return func(c *Client) {
+		c.stats = s
+	}
+}
+
+// Snapshot returns the current counters.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s
+}
+
+func (s *Stats) update(fn func(*StatsSnapshot)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.s)
+}
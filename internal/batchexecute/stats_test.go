@@ -0,0 +1,55 @@
+package batchexecute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithStats(t *testing.T) {
+	const reply = `)]}'
+[["wrb.fr","wXbhsf","[1]",null,null,null,"generic"]]`
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if strings.Contains(r.URL.RawQuery, "rpcids=fail") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, reply)
+	}))
+	defer server.Close()
+
+	config := Config{
+		Host:      strings.TrimPrefix(server.URL, "http://"),
+		App:       "notebooklm",
+		AuthToken: "token",
+		Cookies:   "SID=x",
+		UseHTTP:   true,
+	}
+	stats := new(Stats)
+	client := NewClient(config, WithHTTPClient(server.Client()), WithRetry(1, time.Millisecond, time.Millisecond), WithStats(stats))
+	if _, err := client.Do(RPC{ID: "wXbhsf", Args: []interface{}{nil, 1}}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	// A second client can share the same counters.
+	other := NewClient(config, WithHTTPClient(server.Client()), WithRetry(0, 0, 0), WithStats(stats))
+	if _, err := other.Do(RPC{ID: "fail"}); err == nil {
+		t.Fatalf("Do() of a failing RPC succeeded")
+	}
+
+	got := stats.Snapshot()
+	if got.RPCs != 2 || got.Requests != 3 || got.Retries != 1 || got.Failures != 1 {
+		t.Errorf("Snapshot() = %+v, want 2 RPCs, 3 requests, 1 retry, 1 failure", got)
+	}
+	if got.BytesSent == 0 || got.BytesReceived != int64(len(reply)) || got.Latency <= 0 {
+		t.Errorf("Snapshot() = %+v, want bytes sent, %d bytes received and latency", got, len(reply))
+	}
+}
@@ -0,0 +1,62 @@
+package batchexecute
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"sync/atomic"
+)
+
+// DefaultTransport is the transport of clients not given an HTTP client
+// with WithHTTPClient. It negotiates HTTP/2, so that concurrent RPCs
+// share one connection, and keeps more idle connections per host than
+// http.DefaultTransport for batch jobs on servers that only speak
+// HTTP/1.1.
+var DefaultTransport http.RoundTripper = newDefaultTransport()
+
+func newDefaultTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = 16
+	return t
+}
+
+var defaultHTTPClient = &http.Client{Transport: DefaultTransport}
+
+// compressMinSize is the smallest request body WithCompression gzips;
+// smaller ones gain less than the gzip header costs.
+const compressMinSize = 1024
+
+// compression is the state of WithCompression, shared by the clients
+// made with one such option.
+type compression struct {
+	refused atomic.Bool // the server answered a gzipped body with 415
+}
+
+// WithCompression gzips request bodies of 1KB or more when on. If the
+// server refuses one with 415 Unsupported Media Type, the request is
+// sent again as is, and so are all later ones.
+func WithCompression(on bool) Option {
+	var state *compression
+	if on {
+		state = new(compression)
+	}
+	return func(c *Client) {
+		c.compression = state
+	}
+}
+
+// gzipBody returns body gzipped, or nil if it is to be sent as is.
+func (c *Client) gzipBody(body string) []byte {
+	if c.compression == nil || c.compression.refused.Load() || len(body) < compressMinSize {
+		return nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(body))
+	if err := zw.Close(); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,76 @@
+package batchexecute
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestWithCompression(t *testing.T) {
+	const reply = `)]}'
+[["wrb.fr","wXbhsf","[1]",null,null,null,"generic"]]`
+	tests := []struct {
+		name       string
+		args       string
+		acceptGzip bool
+		want       []string // Content-Encoding of each request
+	}{
+		{"small body", "short", true, []string{"", ""}},
+		{"large body", strings.Repeat("a", 4096), true, []string{"gzip", "gzip"}},
+		{"refused", strings.Repeat("a", 4096), false, []string{"gzip", "", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var encodings []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				enc := r.Header.Get("Content-Encoding")
+				encodings = append(encodings, enc)
+				body := io.Reader(r.Body)
+				if enc == "gzip" {
+					if !tt.acceptGzip {
+						w.WriteHeader(http.StatusUnsupportedMediaType)
+						return
+					}
+					zr, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					body = zr
+				}
+				data, _ := io.ReadAll(body)
+				form, err := url.ParseQuery(string(data))
+				if err != nil || !strings.Contains(form.Get("f.req"), tt.args) {
+					t.Errorf("request body does not hold the RPC: %.100q", data)
+				}
+				fmt.Fprint(w, reply)
+			}))
+			defer server.Close()
+
+			c := NewClient(Config{Host: strings.TrimPrefix(server.URL, "http://"), App: "test", UseHTTP: true},
+				WithHTTPClient(server.Client()), WithCompression(true))
+			for i := 0; i < 2; i++ {
+				if _, err := c.Execute([]RPC{{ID: "wXbhsf", Args: []interface{}{tt.args}}}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if fmt.Sprint(encodings) != fmt.Sprint(tt.want) {
+				t.Errorf("Content-Encoding of requests = %q, want %q", encodings, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultTransport(t *testing.T) {
+	tr := DefaultTransport.(*http.Transport)
+	if !tr.ForceAttemptHTTP2 || tr.MaxIdleConnsPerHost < 16 {
+		t.Errorf("DefaultTransport: ForceAttemptHTTP2 = %v, MaxIdleConnsPerHost = %d", tr.ForceAttemptHTTP2, tr.MaxIdleConnsPerHost)
+	}
+	if NewClient(Config{}).httpClient.Transport != DefaultTransport {
+		t.Error("NewClient does not use DefaultTransport")
+	}
+}
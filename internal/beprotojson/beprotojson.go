@@ -577,6 +577,15 @@ func (o UnmarshalOptions) setMessageField(m protoreflect.Message, fd protoreflec
 		m.Set(fd, protoreflect.ValueOfMessage(msgReflect))
 		return nil
 	case float64:
+		// A timestamp may be sent as a number of microseconds.
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			t := epochTime(int64(v))
+			fields := msgReflect.Descriptor().Fields()
+			msgReflect.Set(fields.ByNumber(1), protoreflect.ValueOfInt64(t.Unix()))
+			msgReflect.Set(fields.ByNumber(2), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+			m.Set(fd, protoreflect.ValueOfMessage(msgReflect))
+			return nil
+		}
 		// Handle numeric values that might be intended for message fields
 		// This can happen when the API returns a number instead of a nested object
 		// Set the first field of the message to this numeric value
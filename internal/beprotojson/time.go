@@ -0,0 +1,42 @@
+package beprotojson
+
+import "time"
+
+// Time decodes a timestamp as the protocol sends it: a [seconds, nanos]
+// array, as for google.protobuf.Timestamp fields, or a number of
+// microseconds since the Unix epoch. Numbers too small to be microseconds
+// since 1973 are taken as milliseconds or seconds. The time is in UTC.
+func Time(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case []interface{}:
+		if len(v) == 0 || len(v) > 2 {
+			return time.Time{}, false
+		}
+		sec, ok := v[0].(float64)
+		if !ok {
+			return time.Time{}, false
+		}
+		var nsec float64
+		if len(v) == 2 && v[1] != nil {
+			if nsec, ok = v[1].(float64); !ok || nsec < 0 || nsec >= 1e9 {
+				return time.Time{}, false
+			}
+		}
+		return time.Unix(int64(sec), int64(nsec)).UTC(), true
+	case float64:
+		return epochTime(int64(v)), true
+	}
+	return time.Time{}, false
+}
+
+// epochTime returns the time n microseconds, or for smaller numbers
+// milliseconds or seconds, after the Unix epoch.
+func epochTime(n int64) time.Time {
+	switch {
+	case n >= 1e14:
+		return time.UnixMicro(n).UTC()
+	case n >= 1e11:
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
@@ -0,0 +1,55 @@
+package beprotojson
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+)
+
+func TestTime(t *testing.T) {
+	want := time.Date(2024, 11, 17, 7, 17, 17, 76688000, time.UTC)
+	tests := []struct {
+		name string
+		v    interface{}
+		want time.Time
+		ok   bool
+	}{
+		{"seconds and nanos", []interface{}{1731827837.0, 76688000.0}, want, true},
+		{"seconds only", []interface{}{1731827837.0}, want.Truncate(time.Second), true},
+		{"null nanos", []interface{}{1731827837.0, nil}, want.Truncate(time.Second), true},
+		{"epoch micros", 1731827837076688.0, want, true},
+		{"epoch millis", 1731827837076.0, want.Truncate(time.Millisecond), true},
+		{"epoch seconds", 1731827837.0, want.Truncate(time.Second), true},
+		{"nanos out of range", []interface{}{1731827837.0, 1e9}, time.Time{}, false},
+		{"too many fields", []interface{}{1.0, 2.0, 3.0}, time.Time{}, false},
+		{"string", "1731827837", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Time(tt.v)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("Time(%v) = %v, %v, want %v, %v", tt.v, got, ok, tt.want, tt.ok)
+			}
+			if ok && got.Location() != time.UTC {
+				t.Errorf("Time(%v) is in %v, want UTC", tt.v, got.Location())
+			}
+		})
+	}
+}
+
+func TestUnmarshalTimestampMicros(t *testing.T) {
+	// The same time as a [seconds, nanos] pair and as epoch micros.
+	got := &pb.Project{}
+	err := Unmarshal([]byte(`["nb", null, "id", null, null, [1, false, true, null, null, 1731910459665561, 1, false, [1731827837, 76688000]]]`), got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := got.GetMetadata()
+	if s, n := md.GetModifiedTime().GetSeconds(), md.GetModifiedTime().GetNanos(); s != 1731910459 || n != 665561000 {
+		t.Errorf("ModifiedTime = %d, %d, want 1731910459, 665561000", s, n)
+	}
+	if s, n := md.GetCreateTime().GetSeconds(), md.GetCreateTime().GetNanos(); s != 1731827837 || n != 76688000 {
+		t.Errorf("CreateTime = %d, %d, want 1731827837, 76688000", s, n)
+	}
+}
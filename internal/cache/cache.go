@@ -0,0 +1,108 @@
+// Package cache keeps local copies of notebook data fetched from
+// NotebookLM, so that it can be shown again without the network.
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/tmc/nlm/internal/filelock"
+)
+
+// ErrNotCached is returned when nothing is cached under a key.
+var ErrNotCached = errors.New("not cached")
+
+// Lookups made by every Cache in the process, for metrics.
+var hits, misses atomic.Int64
+
+// Lookups returns how many Get calls in the process found an entry and
+// how many did not.
+func Lookups() (hit, miss int64) {
+	return hits.Load(), misses.Load()
+}
+
+// Cache is a directory of files, one per key. A key is a slash-separated
+// path such as "notebooks/<id>/notes"; each entry is replaced whole.
+type Cache struct {
+	dir string
+}
+
+// DefaultDir returns ~/.nlm/cache.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "cache"), nil
+}
+
+// Open returns a cache backed by dir. The directory is created on first
+// write.
+func Open(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// OpenDefault opens the cache at DefaultDir.
+func OpenDefault() (*Cache, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return Open(dir), nil
+}
+
+// Put stores data under key, replacing what was cached before.
+func (c *Cache) Put(key string, data []byte) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+	return filelock.With(path, func() error {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0600); err != nil {
+			return fmt.Errorf("write cache: %w", err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("write cache: %w", err)
+		}
+		return nil
+	})
+}
+
+// Get returns the data cached under key and when it was stored, or
+// ErrNotCached.
+func (c *Cache) Get(key string) ([]byte, time.Time, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	fi, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		misses.Add(1)
+		return nil, time.Time{}, fmt.Errorf("%s: %w", key, ErrNotCached)
+	}
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("read cache: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("read cache: %w", err)
+	}
+	hits.Add(1)
+	return data, fi.ModTime(), nil
+}
+
+// path returns the file for key. Keys may not leave the cache directory.
+func (c *Cache) path(key string) (string, error) {
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `\:`) {
+			return "", fmt.Errorf("invalid cache key %q", key)
+		}
+	}
+	return filepath.Join(c.dir, filepath.FromSlash(key)), nil
+}
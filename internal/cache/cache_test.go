@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	c := Open(t.TempDir())
+	hit0, miss0 := Lookups()
+
+	if _, _, err := c.Get("notebooks"); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("Get() on empty cache error = %v, want ErrNotCached", err)
+	}
+
+	before := time.Now().Add(-time.Second)
+	if err := c.Put("notebooks/nb1/notes", []byte("first")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := c.Put("notebooks/nb1/notes", []byte("second")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	data, stored, err := c.Get("notebooks/nb1/notes")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("Get() = %q, want %q", data, "second")
+	}
+	if stored.Before(before) {
+		t.Errorf("Get() stored time = %v, want after %v", stored, before)
+	}
+	if hit, miss := Lookups(); hit-hit0 != 1 || miss-miss0 != 1 {
+		t.Errorf("Lookups() counted %d hits and %d misses, want 1 and 1", hit-hit0, miss-miss0)
+	}
+}
+
+func TestCacheInvalidKey(t *testing.T) {
+	c := Open(t.TempDir())
+	for _, key := range []string{"", "../env", "notebooks//notes", "a/./b", `a\b`} {
+		if err := c.Put(key, nil); err == nil {
+			t.Errorf("Put(%q) succeeded, want an error", key)
+		}
+		if _, _, err := c.Get(key); err == nil || errors.Is(err, ErrNotCached) {
+			t.Errorf("Get(%q) error = %v, want an invalid key error", key, err)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Package config reads and writes the nlm configuration file, which holds
+// named profiles of credentials and defaults.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/filelock"
+	"github.com/tmc/nlm/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile is the profile used when none is selected.
+const DefaultProfile = "default"
+
+// Profile is a named set of credentials and defaults.
+type Profile struct {
+	AuthToken      string `yaml:"auth_token,omitempty"`
+	Cookies        string `yaml:"cookies,omitempty"`
+	BrowserProfile string `yaml:"browser_profile,omitempty"` // Chrome profile used by `nlm auth`
+	Notebook       string `yaml:"notebook,omitempty"`        // default notebook ID
+	Output         string `yaml:"output,omitempty"`          // default output format
+	ErrorFormat    string `yaml:"error_format,omitempty"`    // error output format: text or json
+	Language       string `yaml:"language,omitempty"`        // default language for generated content
+	Locale         string `yaml:"locale,omitempty"`          // language of nlm's own messages
+	MaxRetries     int    `yaml:"max_retries,omitempty"`     // retries for failed or rate-limited requests
+	RetryDelay     string `yaml:"retry_delay,omitempty"`     // initial backoff between retries, e.g. "2s"
+
+	// Aliases maps short names to notebook IDs.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+
+	// Personas are named sets of chat instructions. AliasPersonas
+	// attaches them to aliases, by name.
+	Personas      map[string]*Persona `yaml:"personas,omitempty"`
+	AliasPersonas map[string]string   `yaml:"alias_personas,omitempty"`
+}
+
+// Persona is a reusable set of instructions sent ahead of every question
+// asked of the notebooks it is attached to.
+type Persona struct {
+	Preamble string   `yaml:"preamble,omitempty"` // standing instructions, e.g. "You are a patent examiner."
+	Length   string   `yaml:"length,omitempty"`   // answer length, e.g. "one paragraph"
+	Tone     string   `yaml:"tone,omitempty"`     // e.g. "formal"
+	Avoid    []string `yaml:"avoid,omitempty"`    // topics not to discuss
+}
+
+// Config is the contents of the configuration file.
+type Config struct {
+	// Profile is the active profile; empty means DefaultProfile.
+	Profile  string              `yaml:"profile,omitempty"`
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Key describes a profile setting.
+type Key struct {
+	Name   string
+	Env    string // environment variable that overrides the setting
+	Secret bool   // mask the value when listing
+	Help   string
+}
+
+// Keys lists the profile settings in display order.
+var Keys = []Key{
+	{Name: "auth_token", Env: "NLM_AUTH_TOKEN", Secret: true, Help: "auth token"},
+	{Name: "cookies", Env: "NLM_COOKIES", Secret: true, Help: "session cookies"},
+	{Name: "browser_profile", Env: "NLM_BROWSER_PROFILE", Help: "Chrome profile used by `nlm auth`"},
+	{Name: "notebook", Env: "NLM_NOTEBOOK", Help: "default notebook ID"},
+	{Name: "output", Env: "NLM_OUTPUT", Help: "default output format"},
+	{Name: "error_format", Env: "NLM_ERROR_FORMAT", Help: "error output format: text or json"},
+	{Name: "language", Env: "NLM_LANGUAGE", Help: "default language for generated content"},
+	{Name: "locale", Env: "NLM_LOCALE", Help: "language of nlm's own messages: en, de, es or ja"},
+	{Name: "max_retries", Env: "NLM_MAX_RETRIES", Help: "retries for failed or rate-limited requests"},
+	{Name: "retry_delay", Env: "NLM_RETRY_DELAY", Help: "initial backoff between retries, e.g. 2s"},
+}
+
+// LookupKey returns the setting with the given name.
+func LookupKey(name string) (Key, bool) {
+	name = strings.ReplaceAll(name, "-", "_")
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// DefaultPath returns the configuration file path: $NLM_CONFIG if set,
+// otherwise config.yaml under $XDG_CONFIG_HOME/nlm or ~/.config/nlm.
+func DefaultPath() (string, error) {
+	if p := os.Getenv("NLM_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("get home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "nlm", "config.yaml"), nil
+}
+
+// Load reads the configuration file at path. A missing file is an empty
+// configuration.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	c, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Parse decodes a configuration file's contents.
+func Parse(data []byte) (*Config, error) {
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Marshal encodes the configuration as the file holds it.
+func (c *Config) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	return data, nil
+}
+
+// Save writes the configuration to path. The file holds credentials, so it
+// is readable only by its owner.
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// Update loads the configuration at path, applies fn and saves the result
+// while holding a lock, so concurrent updates from other processes are not
+// lost. Nothing is saved if fn fails.
+func Update(path string, fn func(*Config) error) error {
+	return filelock.With(path, func() error {
+		c, err := Load(path)
+		if err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+		return c.Save(path)
+	})
+}
+
+// ActiveName returns the name of the profile in use: name if it is not
+// empty, otherwise the configured profile or DefaultProfile.
+func (c *Config) ActiveName(name string) string {
+	switch {
+	case name != "":
+		return name
+	case c.Profile != "":
+		return c.Profile
+	default:
+		return DefaultProfile
+	}
+}
+
+// Lookup returns the named profile, or nil if it does not exist.
+func (c *Config) Lookup(name string) *Profile {
+	return c.Profiles[name]
+}
+
+// Ensure returns the named profile, creating it if needed.
+func (c *Config) Ensure(name string) *Profile {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+	p := c.Profiles[name]
+	if p == nil {
+		p = &Profile{}
+		c.Profiles[name] = p
+	}
+	return p
+}
+
+// Names returns the profile names in sorted order.
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Alias returns the notebook ID for an alias, or "" if it is not defined.
+func (p *Profile) Alias(name string) string {
+	if p == nil {
+		return ""
+	}
+	return p.Aliases[name]
+}
+
+// SetAlias defines an alias for a notebook ID; an empty ID removes it.
+func (p *Profile) SetAlias(name, notebookID string) {
+	if notebookID == "" {
+		delete(p.Aliases, name)
+		return
+	}
+	if p.Aliases == nil {
+		p.Aliases = make(map[string]string)
+	}
+	p.Aliases[name] = notebookID
+}
+
+// SetAliasPersona attaches a persona to an alias; an empty persona
+// detaches it.
+func (p *Profile) SetAliasPersona(alias, persona string) {
+	if persona == "" {
+		delete(p.AliasPersonas, alias)
+		return
+	}
+	if p.AliasPersonas == nil {
+		p.AliasPersonas = make(map[string]string)
+	}
+	p.AliasPersonas[alias] = persona
+}
+
+// PersonaFor returns the persona attached to an alias of a notebook, and
+// its name. It returns a nil persona if none is attached, and an error if
+// the attached persona is not defined. When several aliases of a notebook
+// have personas, the alias first in sorted order wins.
+func (p *Profile) PersonaFor(notebookID string) (string, *Persona, error) {
+	if p == nil || notebookID == "" {
+		return "", nil, nil
+	}
+	aliases := make([]string, 0, len(p.AliasPersonas))
+	for alias := range p.AliasPersonas {
+		if p.Aliases[alias] == notebookID {
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) == 0 {
+		return "", nil, nil
+	}
+	sort.Strings(aliases)
+	name := p.AliasPersonas[aliases[0]]
+	persona := p.Personas[name]
+	if persona == nil {
+		return "", nil, fmt.Errorf("persona %q attached to alias %q is not defined", name, aliases[0])
+	}
+	return name, persona, nil
+}
+
+// Get returns the value of a setting, or "" if it is unset.
+func (p *Profile) Get(key string) (string, error) {
+	k, ok := LookupKey(key)
+	if !ok {
+		return "", fmt.Errorf("unknown config key %q", key)
+	}
+	if p == nil {
+		return "", nil
+	}
+	switch k.Name {
+	case "max_retries":
+		if p.MaxRetries == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(p.MaxRetries), nil
+	default:
+		return *p.field(k.Name), nil
+	}
+}
+
+// Set changes a setting; an empty value unsets it.
+func (p *Profile) Set(key, value string) error {
+	k, ok := LookupKey(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	value = strings.TrimSpace(value)
+	switch k.Name {
+	case "max_retries":
+		if value == "" {
+			p.MaxRetries = 0
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("max_retries: want a non-negative integer, got %q", value)
+		}
+		p.MaxRetries = n
+		return nil
+	case "retry_delay":
+		if value != "" {
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("retry_delay: %w", err)
+			}
+		}
+	case "locale":
+		if !i18n.Supported(value) {
+			return fmt.Errorf("locale: no translations for %q (want en, %s)", value, strings.Join(i18n.Languages(), ", "))
+		}
+	}
+	*p.field(k.Name) = value
+	return nil
+}
+
+func (p *Profile) field(name string) *string {
+	switch name {
+	case "auth_token":
+		return &p.AuthToken
+	case "cookies":
+		return &p.Cookies
+	case "browser_profile":
+		return &p.BrowserProfile
+	case "notebook":
+		return &p.Notebook
+	case "output":
+		return &p.Output
+	case "error_format":
+		return &p.ErrorFormat
+	case "language":
+		return &p.Language
+	case "locale":
+		return &p.Locale
+	case "retry_delay":
+		return &p.RetryDelay
+	}
+	panic("config: no string field for " + name)
+}
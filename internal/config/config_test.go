@@ -0,0 +1,170 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nlm", "config.yaml")
+	want := &Config{
+		Profile: "work",
+		Profiles: map[string]*Profile{
+			"default": {AuthToken: "tok", Cookies: "SID=1"},
+			"work":    {Notebook: "nb1", Language: "de", MaxRetries: 5, RetryDelay: "2s"},
+		},
+	}
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Errorf("config file mode = %v, want 0600", perm)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	got, err := Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ActiveName("") != DefaultProfile || got.Lookup(DefaultProfile) != nil {
+		t.Errorf("Load() of missing file = %+v, want empty config", got)
+	}
+}
+
+func TestActiveName(t *testing.T) {
+	tests := []struct {
+		configured, selected, want string
+	}{
+		{"", "", DefaultProfile},
+		{"work", "", "work"},
+		{"work", "home", "home"},
+	}
+	for _, tt := range tests {
+		c := &Config{Profile: tt.configured}
+		if got := c.ActiveName(tt.selected); got != tt.want {
+			t.Errorf("ActiveName(%q) with profile %q = %q, want %q", tt.selected, tt.configured, got, tt.want)
+		}
+	}
+}
+
+func TestProfileSetGet(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       string
+		wantErr    bool
+	}{
+		{key: "notebook", value: "nb1", want: "nb1"},
+		{key: "browser-profile", value: "Profile 1", want: "Profile 1"},
+		{key: "max_retries", value: "5", want: "5"},
+		{key: "max_retries", value: "", want: ""},
+		{key: "max_retries", value: "-1", wantErr: true},
+		{key: "retry_delay", value: "1.5s", want: "1.5s"},
+		{key: "retry_delay", value: "soon", wantErr: true},
+		{key: "locale", value: "de", want: "de"},
+		{key: "locale", value: "fr", wantErr: true},
+		{key: "colour", value: "red", wantErr: true},
+	}
+	for _, tt := range tests {
+		p := &Profile{}
+		err := p.Set(tt.key, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Set(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		got, err := p.Get(tt.key)
+		if err != nil || got != tt.want {
+			t.Errorf("Get(%q) after Set(%q) = %q, %v; want %q", tt.key, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestProfileAliases(t *testing.T) {
+	var p *Profile
+	if got := p.Alias("book"); got != "" {
+		t.Errorf("nil profile Alias() = %q, want empty", got)
+	}
+	p = &Profile{}
+	p.SetAlias("book", "nb1")
+	if got := p.Alias("book"); got != "nb1" {
+		t.Errorf("Alias(book) = %q, want nb1", got)
+	}
+	p.SetAlias("book", "")
+	if got := p.Alias("book"); got != "" || len(p.Aliases) != 0 {
+		t.Errorf("after removal Alias(book) = %q, aliases = %v", got, p.Aliases)
+	}
+}
+
+func TestPersonaFor(t *testing.T) {
+	var p *Profile
+	if name, persona, err := p.PersonaFor("nb1"); name != "" || persona != nil || err != nil {
+		t.Errorf("nil profile PersonaFor() = %q, %v, %v; want none", name, persona, err)
+	}
+	p = &Profile{
+		Aliases:  map[string]string{"book": "nb1", "draft": "nb1", "other": "nb2"},
+		Personas: map[string]*Persona{"reviewer": {Tone: "formal"}, "tutor": {Length: "short"}},
+	}
+	if _, persona, err := p.PersonaFor("nb1"); persona != nil || err != nil {
+		t.Errorf("PersonaFor() with none attached = %v, %v; want none", persona, err)
+	}
+	p.SetAliasPersona("draft", "tutor")
+	p.SetAliasPersona("book", "reviewer")
+	if name, persona, err := p.PersonaFor("nb1"); name != "reviewer" || persona == nil || persona.Tone != "formal" || err != nil {
+		t.Errorf("PersonaFor(nb1) = %q, %v, %v; want reviewer", name, persona, err)
+	}
+	if _, persona, err := p.PersonaFor("nb2"); persona != nil || err != nil {
+		t.Errorf("PersonaFor(nb2) = %v, %v; want none", persona, err)
+	}
+	p.SetAliasPersona("other", "missing")
+	if _, _, err := p.PersonaFor("nb2"); err == nil {
+		t.Error("PersonaFor() with an undefined persona: want error")
+	}
+	p.SetAliasPersona("book", "")
+	if name, _, _ := p.PersonaFor("nb1"); name != "tutor" {
+		t.Errorf("after detaching book PersonaFor(nb1) = %q, want tutor", name)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nlm", "config.yaml")
+
+	if err := Update(path, func(c *Config) error {
+		return c.Ensure("work").Set("language", "de")
+	}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	// A failing update leaves the file as it was.
+	errStop := errors.New("stop")
+	if err := Update(path, func(c *Config) error {
+		c.Ensure("work").Language = "fr"
+		return errStop
+	}); !errors.Is(err, errStop) {
+		t.Fatalf("Update() error = %v, want %v", err, errStop)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := c.Lookup("work").Language; got != "de" {
+		t.Errorf("language = %q, want de", got)
+	}
+}
@@ -0,0 +1,313 @@
+// Package daemon keeps connections to NotebookLM open between CLI
+// invocations. A Server, run by `nlm daemon`, forwards the requests sent
+// to it over a unix socket with one long-lived HTTP transport, so that
+// each command skips the DNS lookup and TLS handshake of a new
+// connection. Transport sends a command's requests to the daemon, and
+// falls back to connecting directly when none is running.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// forwardHeader carries the scheme and host of a forwarded request, such
+// as "https://notebooklm.google.com".
+const forwardHeader = "X-Nlm-Forward"
+
+// daemonHost is the host name requests to the daemon are addressed to.
+const daemonHost = "nlm-daemon"
+
+// DefaultSocket returns ~/.nlm/daemon.sock.
+func DefaultSocket() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "daemon.sock"), nil
+}
+
+// Status describes a running daemon.
+type Status struct {
+	PID      int       `json:"pid"`
+	Started  time.Time `json:"started"`
+	Requests int       `json:"requests"` // requests forwarded
+	LastUsed time.Time `json:"last_used,omitempty"`
+}
+
+// Server forwards requests received on a unix socket to the Google
+// hosts they were meant for.
+type Server struct {
+	// Transport forwards requests. The default keeps idle connections,
+	// HTTP/2 ones included, open for as long as the server runs.
+	Transport http.RoundTripper
+	// IdleTimeout stops the server after it has forwarded no request for
+	// this long. Zero means never.
+	IdleTimeout time.Duration
+
+	mu     sync.Mutex
+	status Status
+	active int // requests being forwarded
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// Listen listens on the unix socket at path, replacing a socket left by
+// a daemon that is no longer running. Only the current user may connect.
+func Listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("create socket directory: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		if _, err := Ping(path); err == nil {
+			return nil, fmt.Errorf("a daemon is already running on %s", path)
+		}
+		os.Remove(path)
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	return l, nil
+}
+
+// Serve forwards the requests received on l until ctx is done, the
+// server is stopped with a request from Stop, or it has been idle for
+// IdleTimeout.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	s.mu.Lock()
+	s.status = Status{PID: os.Getpid(), Started: time.Now()}
+	s.stop = make(chan struct{})
+	s.mu.Unlock()
+
+	hs := &http.Server{Handler: s}
+	errc := make(chan error, 1)
+	go func() { errc <- hs.Serve(l) }()
+
+	var idle <-chan time.Time
+	if s.IdleTimeout > 0 {
+		t := time.NewTicker(s.IdleTimeout / 10)
+		defer t.Stop()
+		idle = t.C
+	}
+wait:
+	for {
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+			break wait
+		case <-s.stop:
+			break wait
+		case <-idle:
+			if s.idle() {
+				break wait
+			}
+		}
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return hs.Shutdown(shutdown)
+}
+
+// idle reports whether the server has forwarded nothing for IdleTimeout.
+func (s *Server) idle() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last := s.status.LastUsed
+	if last.IsZero() {
+		last = s.status.Started
+	}
+	return s.active == 0 && time.Since(last) >= s.IdleTimeout
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Header.Get(forwardHeader) != "":
+		s.forward(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/status":
+		s.mu.Lock()
+		st := s.status
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(st)
+	case r.Method == http.MethodPost && r.URL.Path == "/stop":
+		w.WriteHeader(http.StatusNoContent)
+		s.once.Do(func() { close(s.stop) })
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// forward sends r on to the host named by its forward header, and copies
+// back the response as it arrives, so streamed answers stay streamed.
+func (s *Server) forward(w http.ResponseWriter, r *http.Request) {
+	scheme, host, ok := strings.Cut(r.Header.Get(forwardHeader), "://")
+	if !ok || scheme != "https" && scheme != "http" || !allowedHost(host) {
+		http.Error(w, "nlm daemon: refusing to forward to "+r.Header.Get(forwardHeader), http.StatusForbidden)
+		return
+	}
+	s.mu.Lock()
+	s.active++
+	s.status.Requests++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.status.LastUsed = time.Now()
+		s.mu.Unlock()
+	}()
+
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.URL.Scheme, out.URL.Host, out.Host = scheme, host, host
+	out.Header.Del(forwardHeader)
+	out.Header.Del("Connection")
+	resp, err := s.transport().RoundTrip(out)
+	if err != nil {
+		http.Error(w, "nlm daemon: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	rc := http.NewResponseController(w)
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return
+			}
+			rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+var defaultTransport = &http.Transport{
+	Proxy:               http.ProxyFromEnvironment,
+	ForceAttemptHTTP2:   true,
+	MaxIdleConnsPerHost: 16,
+	TLSHandshakeTimeout: 10 * time.Second,
+}
+
+func (s *Server) transport() http.RoundTripper {
+	if s.Transport != nil {
+		return s.Transport
+	}
+	return defaultTransport
+}
+
+// allowedHost reports whether the daemon forwards requests to host: the
+// Google hosts the CLI talks to, and the loopback hosts of tests.
+func allowedHost(host string) bool {
+	name := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		name = h
+	}
+	for _, domain := range []string{"google.com", "googleusercontent.com", "googleapis.com"} {
+		if name == domain || strings.HasSuffix(name, "."+domain) {
+			return true
+		}
+	}
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
+
+// Transport returns a RoundTripper that sends requests through the
+// daemon listening on socket. When no daemon is running it sends them
+// with fallback, or http.DefaultTransport if fallback is nil.
+func Transport(socket string, fallback http.RoundTripper) http.RoundTripper {
+	if fallback == nil {
+		fallback = http.DefaultTransport
+	}
+	return &clientTransport{fallback: fallback, unix: unixTransport(socket)}
+}
+
+type clientTransport struct {
+	fallback http.RoundTripper
+	unix     *http.Transport
+}
+
+func (t *clientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" && req.URL.Scheme != "http" || !allowedHost(req.URL.Host) {
+		return t.fallback.RoundTrip(req)
+	}
+	out := req.Clone(req.Context())
+	out.URL.Scheme, out.URL.Host, out.Host = "http", daemonHost, daemonHost
+	out.Header.Set(forwardHeader, req.URL.Scheme+"://"+req.URL.Host)
+	if req.Body != nil {
+		// Keep the body open for the fallback, closing it here instead.
+		out.Body = io.NopCloser(req.Body)
+	}
+	resp, err := t.unix.RoundTrip(out)
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		// No daemon, or it stopped: nothing was sent, so send the
+		// request directly.
+		return t.fallback.RoundTrip(req)
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+	return resp, nil
+}
+
+// unixTransport returns a transport that connects to socket.
+func unixTransport(socket string) *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}
+}
+
+// Ping returns the status of the daemon listening on socket.
+func Ping(socket string) (*Status, error) {
+	c := &http.Client{Transport: unixTransport(socket), Timeout: 2 * time.Second}
+	resp, err := c.Get("http://" + daemonHost + "/status")
+	if err != nil {
+		return nil, fmt.Errorf("no daemon on %s: %w", socket, err)
+	}
+	defer resp.Body.Close()
+	var st Status
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		return nil, fmt.Errorf("daemon status: %w", err)
+	}
+	return &st, nil
+}
+
+// Stop asks the daemon listening on socket to exit.
+func Stop(socket string) error {
+	c := &http.Client{Transport: unixTransport(socket), Timeout: 2 * time.Second}
+	resp, err := c.Post("http://"+daemonHost+"/stop", "", nil)
+	if err != nil {
+		return fmt.Errorf("no daemon on %s: %w", socket, err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestForward(t *testing.T) {
+	var got []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.Method+" "+r.URL.RequestURI()+" "+r.Header.Get("cookie")+" "+string(body))
+		if r.Header.Get(forwardHeader) != "" {
+			t.Errorf("backend got the forward header")
+		}
+		w.Header().Set("X-Backend", "1")
+		io.WriteString(w, "answer")
+	}))
+	defer backend.Close()
+
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	l, err := Listen(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &Server{}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(context.Background(), l) }()
+
+	c := &http.Client{Transport: Transport(socket, nil)}
+	req, _ := http.NewRequest("POST", backend.URL+"/_/data/batchexecute?rpcids=wXbhsf", strings.NewReader("f.req=x"))
+	req.Header.Set("cookie", "SID=1")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "answer" || resp.Header.Get("X-Backend") != "1" {
+		t.Errorf("response = %q, header %v", body, resp.Header)
+	}
+	if want := "POST /_/data/batchexecute?rpcids=wXbhsf SID=1 f.req=x"; len(got) != 1 || got[0] != want {
+		t.Errorf("backend got %q, want %q", got, want)
+	}
+	st, err := Ping(socket)
+	if err != nil || st.Requests != 1 {
+		t.Errorf("Ping() = %+v, %v, want 1 request", st, err)
+	}
+	if _, err := Listen(socket); err == nil {
+		t.Error("Listen() on a running daemon's socket succeeded")
+	}
+
+	// The daemon forwards only to the hosts the CLI talks to.
+	unix := &http.Client{Transport: unixTransport(socket)}
+	req, _ = http.NewRequest("GET", "http://"+daemonHost+"/", nil)
+	req.Header.Set(forwardHeader, "https://example.com")
+	if resp, err := unix.Do(req); err != nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("forward to example.com = %v, %v, want 403", resp, err)
+	}
+
+	if err := Stop(socket); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Serve() = %v", err)
+	}
+	// Without a daemon, requests go directly.
+	resp, err = c.Post(backend.URL+"/direct", "text/plain", strings.NewReader("again"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(got) != 2 || got[1] != "POST /direct  again" {
+		t.Errorf("backend got %q after stop", got)
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	l, err := Listen(filepath.Join(t.TempDir(), "d.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &Server{IdleTimeout: 50 * time.Millisecond}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(context.Background(), l) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle server did not stop")
+	}
+}
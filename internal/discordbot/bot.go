@@ -0,0 +1,322 @@
+// Package discordbot answers questions about NotebookLM notebooks in
+// Discord. Each channel is linked to a notebook; members ask it questions
+// with the /nlm ask command or by mentioning the bot, and answers stream
+// into an embed that ends with the sources they were grounded in.
+// Members with the Manage Server permission link channels and add sources.
+package discordbot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/tmc/nlm/internal/api"
+	"github.com/tmc/nlm/internal/filelock"
+)
+
+// Backend is the part of the NotebookLM client the bot uses.
+// *api.Client implements it.
+type Backend interface {
+	GetProject(projectID string) (*api.Notebook, error)
+	AddSourceFromURL(projectID, url string) (string, error)
+	GenerateFreeFormStreamedWithCallback(projectID, prompt string, sourceIDs []string, callback func(chunk string) bool, opts ...api.CallOption) error
+}
+
+// ErrNoNotebook is returned for channels not linked to a notebook.
+var ErrNoNotebook = errors.New("no notebook is linked to this channel; an admin can link one with /nlm notebook")
+
+// ErrNotAdmin is returned when a member without admin rights runs an
+// admin command.
+var ErrNotAdmin = errors.New("only members with the Manage Server permission can do that")
+
+// Channels maps Discord channel IDs to notebook IDs, kept in a JSON file.
+type Channels struct {
+	path string
+}
+
+// NewChannels returns the channel map stored at path.
+func NewChannels(path string) *Channels {
+	return &Channels{path: path}
+}
+
+// DefaultChannelsPath returns ~/.nlm/discord-channels.json.
+func DefaultChannelsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "discord-channels.json"), nil
+}
+
+// Get returns the notebook linked to a channel, or "" if there is none.
+func (c *Channels) Get(channelID string) (string, error) {
+	m, err := c.load()
+	if err != nil {
+		return "", err
+	}
+	return m[channelID], nil
+}
+
+// Set links a channel to a notebook. An empty notebookID unlinks it.
+func (c *Channels) Set(channelID, notebookID string) error {
+	return filelock.With(c.path, func() error {
+		m, err := c.load()
+		if err != nil {
+			return err
+		}
+		if notebookID == "" {
+			delete(m, channelID)
+		} else {
+			m[channelID] = notebookID
+		}
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode channels: %w", err)
+		}
+		tmp := c.path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0600); err != nil {
+			return fmt.Errorf("write channels: %w", err)
+		}
+		if err := os.Rename(tmp, c.path); err != nil {
+			return fmt.Errorf("write channels: %w", err)
+		}
+		return nil
+	})
+}
+
+func (c *Channels) load() (map[string]string, error) {
+	m := make(map[string]string)
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read channels: %w", err)
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse channels: %w", err)
+	}
+	return m, nil
+}
+
+// Bot answers questions with a backend. Backend calls are made one at a
+// time, so the backend need not be safe for concurrent use.
+type Bot struct {
+	backend  Backend
+	channels *Channels
+
+	// ResolveNotebook maps the notebook given to /nlm notebook to a
+	// notebook ID. If nil, it must be a notebook ID.
+	ResolveNotebook func(ref string) (string, error)
+
+	// Admins are the user IDs allowed to run admin commands in addition
+	// to members with the Manage Server permission.
+	Admins []string
+
+	// EditInterval is the least time between edits of a streaming
+	// answer, which keeps the bot under Discord's rate limits. If zero,
+	// answers are edited at most once a second.
+	EditInterval time.Duration
+
+	// Logf, if set, reports connections and the errors that could not
+	// be shown in Discord.
+	Logf func(format string, args ...any)
+
+	mu sync.Mutex
+}
+
+// New returns a bot answering from backend in the channels linked by
+// channels.
+func New(backend Backend, channels *Channels) *Bot {
+	return &Bot{backend: backend, channels: channels}
+}
+
+func (b *Bot) logf(format string, args ...any) {
+	if b.Logf != nil {
+		b.Logf(format, args...)
+	}
+}
+
+// isAdmin reports whether a user with the given guild permissions may run
+// admin commands.
+func (b *Bot) isAdmin(userID string, permissions int64) bool {
+	if permissions&discordgo.PermissionManageGuild != 0 || permissions&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+	for _, id := range b.Admins {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Notebook returns the notebook linked to a channel.
+func (b *Bot) Notebook(channelID string) (*api.Notebook, error) {
+	id, err := b.channels.Get(channelID)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, ErrNoNotebook
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.backend.GetProject(id)
+}
+
+// SetNotebook links a channel to the notebook ref names.
+func (b *Bot) SetNotebook(channelID, ref string) (*api.Notebook, error) {
+	id := strings.TrimSpace(ref)
+	if b.ResolveNotebook != nil {
+		b.mu.Lock()
+		resolved, err := b.ResolveNotebook(id)
+		b.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		id = resolved
+	}
+	b.mu.Lock()
+	nb, err := b.backend.GetProject(id)
+	b.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.channels.Set(channelID, id); err != nil {
+		return nil, err
+	}
+	return nb, nil
+}
+
+// AddSource adds a URL source to the channel's notebook and returns the
+// new source's ID.
+func (b *Bot) AddSource(channelID, url string) (string, error) {
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return "", fmt.Errorf("%q is not an http or https URL", url)
+	}
+	id, err := b.channels.Get(channelID)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", ErrNoNotebook
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.backend.AddSourceFromURL(id, url)
+}
+
+// Ask answers a question with the channel's notebook, calling update with
+// the answer so far as it streams and with the final answer and its
+// citations at the end. If update fails, streaming stops.
+func (b *Bot) Ask(channelID, question string, update func(*discordgo.MessageEmbed) error) error {
+	nb, err := b.Notebook(channelID)
+	if err != nil {
+		return err
+	}
+	interval := b.EditInterval
+	if interval == 0 {
+		interval = time.Second
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var answer strings.Builder
+	var updateErr error
+	last := time.Now()
+	err = b.backend.GenerateFreeFormStreamedWithCallback(nb.GetProjectId(), question, nil, func(chunk string) bool {
+		answer.WriteString(chunk)
+		if time.Since(last) < interval {
+			return true
+		}
+		last = time.Now()
+		updateErr = update(answerEmbed(nb, question, answer.String()+" ▌", false))
+		return updateErr == nil
+	})
+	if updateErr != nil {
+		return updateErr
+	}
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(answer.String()) == "" {
+		return errors.New("the notebook returned no answer")
+	}
+	return update(answerEmbed(nb, question, answer.String(), true))
+}
+
+// Embed colors.
+const (
+	colorAnswer = 0x4285f4
+	colorError  = 0xd93025
+)
+
+// Discord's embed limits.
+const (
+	maxTitle       = 256
+	maxDescription = 4096
+	maxFieldValue  = 1024
+)
+
+// answerEmbed renders an answer. A final answer lists the notebook's
+// sources as its citations: NotebookLM does not say which passages an
+// answer drew on, only that it is grounded in the sources.
+func answerEmbed(nb *api.Notebook, question, answer string, final bool) *discordgo.MessageEmbed {
+	e := &discordgo.MessageEmbed{
+		Title:       truncate(question, maxTitle),
+		Description: truncate(answer, maxDescription),
+		Color:       colorAnswer,
+		Footer:      &discordgo.MessageEmbedFooter{Text: strings.TrimSpace(nb.GetEmoji() + " " + nb.GetTitle())},
+	}
+	if final && len(nb.GetSources()) > 0 {
+		var lines []string
+		for i, src := range nb.GetSources() {
+			title := strings.TrimSpace(src.GetTitle())
+			if url := src.GetMetadata().GetYoutube().GetYoutubeUrl(); url != "" {
+				title = fmt.Sprintf("[%s](%s)", title, url)
+			}
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, title))
+		}
+		e.Fields = []*discordgo.MessageEmbedField{{Name: "Citations", Value: truncateLines(lines, maxFieldValue)}}
+	}
+	return e
+}
+
+// errorEmbed renders an error.
+func errorEmbed(err error) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{Description: truncate("⚠️ "+err.Error(), maxDescription), Color: colorError}
+}
+
+// truncate shortens s to at most n characters, ending it with … if it was
+// cut.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
+
+// truncateLines joins as many lines as fit in n characters, noting how
+// many were left out.
+func truncateLines(lines []string, n int) string {
+	out := strings.Join(lines, "\n")
+	if utf8.RuneCountInString(out) <= n {
+		return out
+	}
+	for k := len(lines) - 1; k > 0; k-- {
+		out = strings.Join(lines[:k], "\n") + fmt.Sprintf("\n…and %d more", len(lines)-k)
+		if utf8.RuneCountInString(out) <= n {
+			return out
+		}
+	}
+	return truncate(lines[0], n)
+}
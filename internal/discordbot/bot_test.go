@@ -0,0 +1,206 @@
+package discordbot
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+type fakeBackend struct {
+	chunks  []string
+	err     error
+	added   []string
+	project *api.Notebook
+}
+
+func (f *fakeBackend) GetProject(id string) (*api.Notebook, error) {
+	if id != f.project.ProjectId {
+		return nil, errors.New("not found")
+	}
+	return f.project, nil
+}
+
+func (f *fakeBackend) AddSourceFromURL(id, url string) (string, error) {
+	f.added = append(f.added, id+" "+url)
+	return "src-new", nil
+}
+
+func (f *fakeBackend) GenerateFreeFormStreamedWithCallback(id, prompt string, sourceIDs []string, fn func(string) bool, opts ...api.CallOption) error {
+	for _, c := range f.chunks {
+		if !fn(c) {
+			break
+		}
+	}
+	return f.err
+}
+
+func newTestBot(t *testing.T) (*Bot, *fakeBackend) {
+	t.Helper()
+	backend := &fakeBackend{
+		chunks: []string{"Entanglement", " links", " particles."},
+		project: &api.Notebook{ProjectId: "nb1", Title: "Physics", Emoji: "🔭", Sources: []*pb.Source{
+			{Title: "Lecture notes"},
+			{Title: "Talk", Metadata: &pb.SourceMetadata{MetadataType: &pb.SourceMetadata_Youtube{
+				Youtube: &pb.YoutubeSourceMetadata{YoutubeUrl: "https://youtube.com/watch?v=x"},
+			}}},
+		}},
+	}
+	b := New(backend, NewChannels(filepath.Join(t.TempDir(), "nlm", "channels.json")))
+	b.EditInterval = -1 // edit on every chunk
+	return b, backend
+}
+
+func TestAsk(t *testing.T) {
+	b, backend := newTestBot(t)
+	update := func(*discordgo.MessageEmbed) error { return nil }
+	if err := b.Ask("c1", "what?", update); !errors.Is(err, ErrNoNotebook) {
+		t.Fatalf("Ask in unlinked channel err = %v, want ErrNoNotebook", err)
+	}
+	if _, err := b.SetNotebook("c1", "nb1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var embeds []*discordgo.MessageEmbed
+	err := b.Ask("c1", "What is entanglement?", func(e *discordgo.MessageEmbed) error {
+		embeds = append(embeds, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(embeds) != 4 {
+		t.Fatalf("got %d updates, want 3 streaming and 1 final", len(embeds))
+	}
+	if got := embeds[1].Description; got != "Entanglement links ▌" {
+		t.Errorf("streaming update = %q", got)
+	}
+	if len(embeds[1].Fields) != 0 {
+		t.Error("streaming update has citations")
+	}
+	final := embeds[3]
+	if final.Title != "What is entanglement?" || final.Description != "Entanglement links particles." || final.Footer.Text != "🔭 Physics" {
+		t.Errorf("final answer = %+v", final)
+	}
+	if len(final.Fields) != 1 || final.Fields[0].Value != "1. Lecture notes\n2. [Talk](https://youtube.com/watch?v=x)" {
+		t.Errorf("citations = %+v", final.Fields)
+	}
+
+	backend.err = errors.New("rate limited")
+	if err := b.Ask("c1", "again?", update); err == nil || err.Error() != "rate limited" {
+		t.Errorf("Ask with backend error = %v", err)
+	}
+	backend.err = nil
+	broken := errors.New("discord down")
+	calls := 0
+	err = b.Ask("c1", "again?", func(*discordgo.MessageEmbed) error { calls++; return broken })
+	if !errors.Is(err, broken) || calls != 1 {
+		t.Errorf("Ask with failing update = %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
+func TestSetNotebookAndAddSource(t *testing.T) {
+	b, backend := newTestBot(t)
+	b.ResolveNotebook = func(ref string) (string, error) {
+		if ref == "physics" {
+			return "nb1", nil
+		}
+		return "", errors.New("no notebook matches")
+	}
+	if _, err := b.AddSource("c1", "https://example.com"); !errors.Is(err, ErrNoNotebook) {
+		t.Errorf("AddSource in unlinked channel err = %v", err)
+	}
+	if _, err := b.SetNotebook("c1", "chemistry"); err == nil {
+		t.Error("SetNotebook of unknown notebook succeeded")
+	}
+	nb, err := b.SetNotebook("c1", " physics ")
+	if err != nil || nb.Title != "Physics" {
+		t.Fatalf("SetNotebook = %v, %v", nb, err)
+	}
+	if id, err := b.channels.Get("c1"); err != nil || id != "nb1" {
+		t.Errorf("channel c1 linked to %q, %v", id, err)
+	}
+	if id, err := b.channels.Get("c2"); err != nil || id != "" {
+		t.Errorf("channel c2 linked to %q, %v", id, err)
+	}
+
+	if _, err := b.AddSource("c1", "ftp://example.com/x"); err == nil {
+		t.Error("AddSource of ftp URL succeeded")
+	}
+	if id, err := b.AddSource("c1", "https://example.com/paper"); err != nil || id != "src-new" {
+		t.Errorf("AddSource = %q, %v", id, err)
+	}
+	if len(backend.added) != 1 || backend.added[0] != "nb1 https://example.com/paper" {
+		t.Errorf("added = %v", backend.added)
+	}
+
+	if err := b.channels.Set("c1", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Notebook("c1"); !errors.Is(err, ErrNoNotebook) {
+		t.Errorf("Notebook after unlinking err = %v", err)
+	}
+}
+
+func TestIsAdmin(t *testing.T) {
+	b := &Bot{Admins: []string{"u2"}}
+	for _, tt := range []struct {
+		user  string
+		perms int64
+		want  bool
+	}{
+		{"u1", 0, false},
+		{"u1", discordgo.PermissionSendMessages, false},
+		{"u1", discordgo.PermissionManageGuild, true},
+		{"u1", discordgo.PermissionAdministrator, true},
+		{"u2", 0, true},
+	} {
+		if got := b.isAdmin(tt.user, tt.perms); got != tt.want {
+			t.Errorf("isAdmin(%q, %d) = %v, want %v", tt.user, tt.perms, got, tt.want)
+		}
+	}
+}
+
+func TestMentionQuestion(t *testing.T) {
+	for _, tt := range []struct {
+		content string
+		want    string
+		ok      bool
+	}{
+		{"<@42> what is entanglement?", "what is entanglement?", true},
+		{"  <@!42>   why?  ", "why?", true},
+		{"<@42>", "", false},
+		{"<@43> hello", "", false},
+		{"hello <@42>", "", false},
+	} {
+		got, ok := mentionQuestion(tt.content, "42")
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("mentionQuestion(%q) = %q, %v; want %q, %v", tt.content, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("héllo wörld", 6); got != "héllo…" {
+		t.Errorf("truncate = %q", got)
+	}
+	if got := truncate("short", 6); got != "short" {
+		t.Errorf("truncate = %q", got)
+	}
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, strings.Repeat("é", 20))
+	}
+	got := truncateLines(lines, maxFieldValue)
+	if n := utf8.RuneCountInString(got); n > maxFieldValue {
+		t.Errorf("truncateLines gave %d characters", n)
+	}
+	if !strings.HasSuffix(got, "more") {
+		t.Errorf("truncateLines = %q, want a count of lines left out", got)
+	}
+}
@@ -0,0 +1,202 @@
+package discordbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/tmc/nlm/internal/api"
+)
+
+// commands are the slash commands the bot registers.
+var commands = []*discordgo.ApplicationCommand{{
+	Name:        "nlm",
+	Description: "Ask this channel's NotebookLM notebook",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "ask",
+			Description: "Ask the notebook a question",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "question", Description: "Your question", Required: true},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "notebook",
+			Description: "Show this channel's notebook, or link another (admins)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "notebook", Description: "Notebook ID, alias or title"},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add-source",
+			Description: "Add a web page or YouTube video to the notebook (admins)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "url", Description: "URL of the source", Required: true},
+			},
+		},
+	},
+}}
+
+// Run connects to the Discord gateway with a bot token and answers until
+// ctx is done. Commands are registered in guildID, where they are
+// available at once, or globally if guildID is empty.
+func (b *Bot) Run(ctx context.Context, token, guildID string) error {
+	s, err := discordgo.New("Bot " + token)
+	if err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	// Messages that mention the bot carry their content without the
+	// privileged message content intent.
+	s.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages | discordgo.IntentsDirectMessages
+	s.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		b.handleInteraction(s, i)
+	})
+	s.AddHandler(func(s *discordgo.Session, m *discordgo.MessageCreate) {
+		b.handleMessage(s, m)
+	})
+	if err := s.Open(); err != nil {
+		return fmt.Errorf("discord: connect: %w", err)
+	}
+	defer s.Close()
+	if _, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, guildID, commands); err != nil {
+		return fmt.Errorf("discord: register commands: %w", err)
+	}
+	b.logf("connected as %s", s.State.User.String())
+	<-ctx.Done()
+	return nil
+}
+
+func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+	data := i.ApplicationCommandData()
+	if data.Name != "nlm" || len(data.Options) == 0 {
+		return
+	}
+	sub := data.Options[0]
+	option := func(name string) string {
+		for _, o := range sub.Options {
+			if o.Name == name {
+				return strings.TrimSpace(o.StringValue())
+			}
+		}
+		return ""
+	}
+	userID, permissions := interactionUser(i.Interaction)
+
+	// Every command may take longer than the three seconds Discord waits
+	// for a response, so all are deferred and their replies edited in.
+	var flags discordgo.MessageFlags
+	if sub.Name != "ask" {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: flags},
+	}); err != nil {
+		b.logf("respond: %v", err)
+		return
+	}
+	edit := func(e *discordgo.MessageEmbed) error {
+		_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Embeds: &[]*discordgo.MessageEmbed{e}})
+		return err
+	}
+	reply := func(format string, args ...any) error {
+		return edit(&discordgo.MessageEmbed{Description: fmt.Sprintf(format, args...), Color: colorAnswer})
+	}
+
+	var err error
+	switch sub.Name {
+	case "ask":
+		err = b.Ask(i.ChannelID, option("question"), edit)
+	case "notebook":
+		ref := option("notebook")
+		var p *api.Notebook
+		switch {
+		case ref == "":
+			if p, err = b.Notebook(i.ChannelID); err == nil {
+				err = reply("This channel asks %s %s (%d sources).", p.GetEmoji(), p.GetTitle(), len(p.GetSources()))
+			}
+		case !b.isAdmin(userID, permissions):
+			err = ErrNotAdmin
+		default:
+			if p, err = b.SetNotebook(i.ChannelID, ref); err == nil {
+				err = reply("Linked this channel to %s %s.", p.GetEmoji(), p.GetTitle())
+			}
+		}
+	case "add-source":
+		if !b.isAdmin(userID, permissions) {
+			err = ErrNotAdmin
+			break
+		}
+		var id string
+		if id, err = b.AddSource(i.ChannelID, option("url")); err == nil {
+			err = reply("Added source `%s`.", id)
+		}
+	}
+	if err != nil {
+		if eerr := edit(errorEmbed(err)); eerr != nil {
+			b.logf("/nlm %s: %v", sub.Name, err)
+		}
+	}
+}
+
+// interactionUser returns who ran an interaction and their permissions in
+// the channel. Direct messages carry no permissions.
+func interactionUser(i *discordgo.Interaction) (string, int64) {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID, i.Member.Permissions
+	}
+	if i.User != nil {
+		return i.User.ID, 0
+	}
+	return "", 0
+}
+
+// handleMessage answers messages that mention the bot.
+func (b *Bot) handleMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if m.Author == nil || m.Author.Bot || s.State.User == nil {
+		return
+	}
+	question, ok := mentionQuestion(m.Content, s.State.User.ID)
+	if !ok {
+		return
+	}
+	msg, err := s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Embed:     &discordgo.MessageEmbed{Title: truncate(question, maxTitle), Description: "Thinking…", Color: colorAnswer},
+		Reference: m.Reference(),
+	})
+	if err != nil {
+		b.logf("reply: %v", err)
+		return
+	}
+	edit := func(e *discordgo.MessageEmbed) error {
+		_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID: msg.ID, Channel: m.ChannelID, Embeds: &[]*discordgo.MessageEmbed{e},
+		})
+		return err
+	}
+	if err := b.Ask(m.ChannelID, question, edit); err != nil {
+		if eerr := edit(errorEmbed(err)); eerr != nil {
+			b.logf("answer: %v", err)
+		}
+	}
+}
+
+// mentionQuestion returns the question asked in a message that starts by
+// mentioning the bot.
+func mentionQuestion(content, botID string) (string, bool) {
+	content = strings.TrimSpace(content)
+	for _, mention := range []string{"<@" + botID + ">", "<@!" + botID + ">"} {
+		if rest, ok := strings.CutPrefix(content, mention); ok {
+			rest = strings.TrimSpace(rest)
+			return rest, rest != ""
+		}
+	}
+	return "", false
+}
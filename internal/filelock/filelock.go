@@ -0,0 +1,55 @@
+// Package filelock serializes changes to the files nlm keeps on disk, so
+// that concurrent nlm processes do not overwrite each other's updates.
+//
+// Locks are advisory and held on a separate file next to the guarded one,
+// path + ".lock", which lets the guarded file be replaced by rename while
+// locked. The operating system releases a lock when its process exits, so
+// a crashed process cannot leave a file locked.
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// A Lock is an exclusive lock on a file.
+type Lock struct {
+	f *os.File
+}
+
+// Acquire takes the lock for path, waiting for other processes to release
+// it. The directory of path is created if needed.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("lock %s: %w", path, err)
+	}
+	f, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("lock %s: %w", path, err)
+	}
+	if err := lock(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("lock %s: %w", path, err)
+	}
+	return &Lock{f: f}, nil
+}
+
+// Release releases the lock.
+func (l *Lock) Release() error {
+	err := unlock(l.f)
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// With runs fn while holding the lock for path.
+func With(path string, fn func() error) error {
+	l, err := Acquire(path)
+	if err != nil {
+		return err
+	}
+	defer l.Release()
+	return fn()
+}
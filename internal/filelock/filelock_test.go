@@ -0,0 +1,50 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestWithSerializesUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "counter")
+
+	// Each worker reads, increments and rewrites the counter. Without the
+	// lock concurrent read-modify-write cycles would lose increments.
+	const workers, rounds = 8, 25
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				err := With(path, func() error {
+					data, err := os.ReadFile(path)
+					if err != nil && !os.IsNotExist(err) {
+						return err
+					}
+					n, _ := strconv.Atoi(string(data))
+					return os.WriteFile(path, []byte(strconv.Itoa(n+1)), 0600)
+				})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), strconv.Itoa(workers*rounds); got != want {
+		t.Errorf("counter = %s, want %s", got, want)
+	}
+	if _, err := os.Stat(path + ".lock"); err != nil {
+		t.Errorf("lock file: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+// Package gdrive creates Google Docs with the Drive API, authorized by an
+// OAuth access token or by the browser session nlm already uses.
+package gdrive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"time"
+
+	"github.com/tmc/nlm/internal/auth"
+)
+
+// UploadURL is the Drive API's upload endpoint.
+const UploadURL = "https://www.googleapis.com/upload/drive/v3/files"
+
+// origin is the web app whose session authorizes cookie requests.
+const origin = "https://drive.google.com"
+
+// docMimeType is the type Drive converts uploads to for a Google Doc.
+const docMimeType = "application/vnd.google-apps.document"
+
+// Client creates files in a user's Drive.
+type Client struct {
+	token      string
+	cookies    string
+	httpClient *http.Client
+
+	// UploadURL overrides the upload endpoint, for tests.
+	UploadURL string
+}
+
+// New returns a client authorized by token, an OAuth access token with
+// the drive.file scope, or if token is empty by the session in cookies.
+func New(token, cookies string) *Client {
+	return &Client{
+		token:      token,
+		cookies:    cookies,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+		UploadURL:  UploadURL,
+	}
+}
+
+// File is a file in Drive.
+type File struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	WebViewLink string `json:"webViewLink"`
+}
+
+// Error is an error response from the Drive API.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("drive: %s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// CreateDoc creates a Google Doc named title from an HTML document,
+// which Drive converts keeping headings, lists, tables and links. If
+// folderID is not empty, the doc is created in that folder.
+func (c *Client) CreateDoc(title string, html []byte, folderID string) (*File, error) {
+	meta := map[string]any{"name": title, "mimeType": docMimeType}
+	if folderID != "" {
+		meta["parents"] = []string{folderID}
+	}
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		data        func(io.Writer) error
+	}{
+		{"application/json; charset=UTF-8", func(w io.Writer) error { return json.NewEncoder(w).Encode(meta) }},
+		{"text/html; charset=UTF-8", func(w io.Writer) error { _, err := w.Write(html); return err }},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if err := part.data(w); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{
+		"uploadType":        {"multipart"},
+		"fields":            {"id,name,webViewLink"},
+		"supportsAllDrives": {"true"},
+	}
+	req, err := http.NewRequest("POST", c.UploadURL+"?"+q.Encode(), &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "multipart/related; boundary="+mw.Boundary())
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("drive: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("drive: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp.StatusCode, data)
+	}
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("drive: decode response: %w", err)
+	}
+	if f.WebViewLink == "" {
+		f.WebViewLink = "https://docs.google.com/document/d/" + f.ID + "/edit"
+	}
+	return &f, nil
+}
+
+func (c *Client) authorize(req *http.Request) error {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		return nil
+	}
+	if c.cookies == "" {
+		return fmt.Errorf("drive: no access token or cookies")
+	}
+	authz, err := auth.SAPISIDAuthorization(c.cookies, origin, time.Now())
+	if err != nil {
+		return fmt.Errorf("drive: %w", err)
+	}
+	req.Header.Set("Authorization", authz)
+	req.Header.Set("Cookie", c.cookies)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("X-Origin", origin)
+	req.Header.Set("X-Goog-AuthUser", "0")
+	return nil
+}
+
+// responseError returns the error in a Drive error response, which is
+// {"error": {"code": ..., "message": ...}} when it is JSON at all.
+func responseError(status int, data []byte) error {
+	var r struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	msg := http.StatusText(status)
+	if json.Unmarshal(data, &r) == nil && r.Error.Message != "" {
+		msg = r.Error.Message
+	}
+	return &Error{StatusCode: status, Message: msg}
+}
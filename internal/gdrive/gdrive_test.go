@@ -0,0 +1,102 @@
+package gdrive
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateDoc(t *testing.T) {
+	var meta map[string]any
+	var html string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if q := r.URL.Query(); q.Get("uploadType") != "multipart" {
+			t.Errorf("query = %v", q)
+		}
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		p, err := mr.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		json.NewDecoder(p).Decode(&meta)
+		p, err = mr.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(p)
+		html = string(b)
+		w.Write([]byte(`{"id": "doc1", "name": "Study Guide", "webViewLink": "https://docs.google.com/document/d/doc1/edit"}`))
+	}))
+	defer srv.Close()
+
+	c := New("tok", "")
+	c.UploadURL = srv.URL
+	f, err := c.CreateDoc("Study Guide", []byte("<h1>Hi</h1>"), "folder1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.ID != "doc1" || f.WebViewLink != "https://docs.google.com/document/d/doc1/edit" {
+		t.Errorf("file = %+v", f)
+	}
+	if meta["name"] != "Study Guide" || meta["mimeType"] != docMimeType || meta["parents"].([]any)[0] != "folder1" {
+		t.Errorf("metadata = %v", meta)
+	}
+	if html != "<h1>Hi</h1>" {
+		t.Errorf("content = %q", html)
+	}
+}
+
+func TestCreateDocCookies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); !strings.HasPrefix(got, "SAPISIDHASH ") {
+			t.Errorf("Authorization = %q", got)
+		}
+		if r.Header.Get("Cookie") != "SAPISID=abc" || r.Header.Get("X-Origin") != origin {
+			t.Errorf("headers = %v", r.Header)
+		}
+		w.Write([]byte(`{"id": "doc2"}`))
+	}))
+	defer srv.Close()
+
+	c := New("", "SAPISID=abc")
+	c.UploadURL = srv.URL
+	f, err := c.CreateDoc("Notes", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.WebViewLink != "https://docs.google.com/document/d/doc2/edit" {
+		t.Errorf("WebViewLink = %q", f.WebViewLink)
+	}
+}
+
+func TestCreateDocError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": {"code": 403, "message": "Insufficient Permission"}}`))
+	}))
+	defer srv.Close()
+
+	c := New("tok", "")
+	c.UploadURL = srv.URL
+	_, err := c.CreateDoc("Notes", nil, "")
+	var de *Error
+	if !errors.As(err, &de) || de.StatusCode != 403 || de.Message != "Insufficient Permission" {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := New("", "").CreateDoc("Notes", nil, ""); err == nil {
+		t.Error("CreateDoc without credentials succeeded")
+	}
+}
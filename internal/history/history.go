@@ -0,0 +1,116 @@
+// Package history keeps an append-only log of the changes the CLI made to
+// notebooks, so that what a script or user did can be reconstructed later.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Result values of an entry.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// Entry records one mutating command.
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args,omitempty"`
+	NotebookID string    `json:"notebook_id,omitempty"`
+	IDs        []string  `json:"ids,omitempty"` // sources, notes or artifacts created or changed
+	Profile    string    `json:"profile,omitempty"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Log is a file of JSON entries, one per line. Entries are only ever
+// appended, so the file can also be inspected with standard tools.
+type Log struct {
+	path string
+}
+
+// DefaultPath returns ~/.nlm/history.jsonl.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "history.jsonl"), nil
+}
+
+// Open returns a log backed by the file at path. The file is created on
+// first write.
+func Open(path string) *Log {
+	return &Log{path: path}
+}
+
+// OpenDefault opens the log at DefaultPath.
+func OpenDefault() (*Log, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path), nil
+}
+
+// Append adds e to the end of the log, stamping it with the current time
+// if it has none.
+func (l *Log) Append(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode history entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("create history directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	// A single write of one line keeps concurrent appends from interleaving.
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write history: %w", err)
+	}
+	return f.Close()
+}
+
+// List returns the entries for a notebook, or all entries if notebookID is
+// empty, oldest first. Lines that cannot be parsed are skipped.
+func (l *Log) List(notebookID string) ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			continue
+		}
+		if notebookID == "" || e.NotebookID == notebookID {
+			entries = append(entries, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	return entries, nil
+}
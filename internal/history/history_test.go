@@ -0,0 +1,65 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	l := Open(path)
+
+	if got, err := l.List(""); err != nil || got != nil {
+		t.Fatalf("List() on empty log = %v, %v; want nil, nil", got, err)
+	}
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []Entry{
+		{Time: at, Command: "add", Args: []string{"nb1", "paper.pdf"}, NotebookID: "nb1", IDs: []string{"src1"}, Result: ResultOK},
+		{Time: at, Command: "rm-note", Args: []string{"nb2", "note1"}, NotebookID: "nb2", Result: ResultError, Error: "not found"},
+		{Time: at, Command: "rm-source", Args: []string{"nb1", "src1"}, NotebookID: "nb1", Result: ResultOK},
+	}
+	for _, e := range entries {
+		if err := l.Append(e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	all, err := l.List("")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if diff := cmp.Diff(entries, all); diff != "" {
+		t.Errorf("List() mismatch (-want +got):\n%s", diff)
+	}
+
+	nb1, err := l.List("nb1")
+	if err != nil {
+		t.Fatalf("List(nb1) error = %v", err)
+	}
+	if diff := cmp.Diff([]Entry{entries[0], entries[2]}, nb1); diff != "" {
+		t.Errorf("List(nb1) mismatch (-want +got):\n%s", diff)
+	}
+
+	// Damaged lines are skipped and entries without a time get one.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{not json\n")
+	f.Close()
+	if err := l.Append(Entry{Command: "create", Result: ResultOK}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	all, err = l.List("")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 4 || all[3].Time.IsZero() {
+		t.Errorf("List() after damaged line = %+v, want 4 entries with the last one timed", all)
+	}
+}
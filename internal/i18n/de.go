@@ -0,0 +1,42 @@
+package i18n
+
+// de holds the German translations.
+var de = map[string]string{
+	// Status messages
+	"nlm: checksum verified; this build has no release key to check the signature\n": "nlm: Prüfsumme verifiziert; dieser Build hat keinen Release-Schlüssel, um die Signatur zu prüfen\n",
+	"nlm: checksum and signature verified\n":                                         "nlm: Prüfsumme und Signatur verifiziert\n",
+	"Reading from stdin...\n":                                                        "Lese von der Standardeingabe...\n",
+	"Processing %d notebooks (concurrency %d)...\n":                                  "Verarbeite %d Notizbücher (Parallelität %d)...\n",
+	"Opening %s\n":                               "Öffne %s\n",
+	"Copied to clipboard\n":                      "In die Zwischenablage kopiert\n",
+	"Waiting for job %s (%s for %s)":             "Warte auf Job %s (%s für %s)",
+	"Waiting for %s":                             "Warte auf %s",
+	"Uploading %s (%s)":                          "Lade %s hoch (%s)",
+	"Downloading video overview for notebook %s": "Lade Video-Zusammenfassung für Notizbuch %s herunter",
+	"Downloading audio overview for notebook %s": "Lade Audio-Zusammenfassung für Notizbuch %s herunter",
+	"Downloading %s":                             "Lade %s herunter",
+	"Checking for a newer release":               "Suche nach einer neueren Version",
+	"Adding text content as source":              "Füge Textinhalt als Quelle hinzu",
+	"Adding source from URL: %s":                 "Füge Quelle von URL hinzu: %s",
+	"Loading sources":                            "Lade Quellen",
+	"Sources":                                    "Quellen",
+	"Tab selects, Enter accepts, Esc cancels":    "Tab wählt aus, Enter übernimmt, Esc bricht ab",
+	"no matches":                                 "keine Treffer",
+	"Downloading":                                "Herunterladen",
+	"Notebooks":                                  "Notizbücher",
+
+	// Confirmations
+	"Are you sure you want to %s? [y/N] ":      "Möchten Sie wirklich %s? [j/N] ",
+	"Would %s (dry run)":                       "Würde %s (Probelauf)",
+	"y":                                        "j",
+	"delete notebook %s":                       "Notizbuch %s löschen",
+	"turn off link access for %s":              "den Linkzugriff auf %s deaktivieren",
+	"revoke access to %s for %s":               "den Zugriff auf %s für %s entziehen",
+	"remove source %s from notebook %s":        "Quelle %s aus Notizbuch %s entfernen",
+	"remove note %s":                           "Notiz %s entfernen",
+	"delete the audio overview of notebook %s": "die Audio-Zusammenfassung von Notizbuch %s löschen",
+	"delete artifact %s":                       "Artefakt %s löschen",
+	"replace artifact %s":                      "Artefakt %s ersetzen",
+	"delete %d artifact(s)":                    "%d Artefakt(e) löschen",
+	"cancel job %s (%s for %s)":                "Job %s (%s für %s) abbrechen",
+}
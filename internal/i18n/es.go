@@ -0,0 +1,42 @@
+package i18n
+
+// es holds the Spanish translations.
+var es = map[string]string{
+	// Status messages
+	"nlm: checksum verified; this build has no release key to check the signature\n": "nlm: suma de comprobación verificada; esta compilación no tiene clave de versión para comprobar la firma\n",
+	"nlm: checksum and signature verified\n":                                         "nlm: suma de comprobación y firma verificadas\n",
+	"Reading from stdin...\n":                                                        "Leyendo de la entrada estándar...\n",
+	"Processing %d notebooks (concurrency %d)...\n":                                  "Procesando %d cuadernos (concurrencia %d)...\n",
+	"Opening %s\n":                               "Abriendo %s\n",
+	"Copied to clipboard\n":                      "Copiado al portapapeles\n",
+	"Waiting for job %s (%s for %s)":             "Esperando el trabajo %s (%s para %s)",
+	"Waiting for %s":                             "Esperando %s",
+	"Uploading %s (%s)":                          "Subiendo %s (%s)",
+	"Downloading video overview for notebook %s": "Descargando el resumen en vídeo del cuaderno %s",
+	"Downloading audio overview for notebook %s": "Descargando el resumen en audio del cuaderno %s",
+	"Downloading %s":                             "Descargando %s",
+	"Checking for a newer release":               "Buscando una versión más reciente",
+	"Adding text content as source":              "Añadiendo el texto como fuente",
+	"Adding source from URL: %s":                 "Añadiendo fuente desde la URL: %s",
+	"Loading sources":                            "Cargando fuentes",
+	"Sources":                                    "Fuentes",
+	"Tab selects, Enter accepts, Esc cancels":    "Tab selecciona, Intro acepta, Esc cancela",
+	"no matches":                                 "sin coincidencias",
+	"Downloading":                                "Descargando",
+	"Notebooks":                                  "Cuadernos",
+
+	// Confirmations
+	"Are you sure you want to %s? [y/N] ":      "¿Seguro que quieres %s? [s/N] ",
+	"Would %s (dry run)":                       "Se haría: %s (simulación)",
+	"y":                                        "s",
+	"delete notebook %s":                       "eliminar el cuaderno %s",
+	"turn off link access for %s":              "desactivar el acceso por enlace de %s",
+	"revoke access to %s for %s":               "revocar el acceso a %s para %s",
+	"remove source %s from notebook %s":        "quitar la fuente %s del cuaderno %s",
+	"remove note %s":                           "quitar la nota %s",
+	"delete the audio overview of notebook %s": "eliminar el resumen en audio del cuaderno %s",
+	"delete artifact %s":                       "eliminar el artefacto %s",
+	"replace artifact %s":                      "reemplazar el artefacto %s",
+	"delete %d artifact(s)":                    "eliminar %d artefacto(s)",
+	"cancel job %s (%s for %s)":                "cancelar el trabajo %s (%s para %s)",
+}
@@ -0,0 +1,99 @@
+// Package i18n translates nlm's user-facing messages. Messages are looked
+// up by their English format string, so a message without a translation
+// is printed in English.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// catalogs maps a language to its translations, keyed by English format
+// string. Translations may reorder arguments with explicit indexes such as
+// %[2]s.
+var catalogs = map[string]map[string]string{
+	"de": de,
+	"es": es,
+	"ja": ja,
+}
+
+var (
+	mu      sync.RWMutex
+	current map[string]string
+)
+
+// Languages returns the languages messages can be translated into, in
+// addition to English.
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Normalize returns the language of a locale name such as "de_DE.UTF-8"
+// or "pt-BR", or "" for the C and POSIX locales.
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	if i := strings.IndexAny(locale, "_-"); i >= 0 {
+		locale = locale[:i]
+	}
+	if locale == "c" || locale == "posix" {
+		return ""
+	}
+	return locale
+}
+
+// Detect returns the language selected by NLM_LOCALE or, failing that, the
+// system locale in LC_ALL, LC_MESSAGES or LANG, using getenv to read them.
+func Detect(getenv func(string) string) string {
+	for _, name := range []string{"NLM_LOCALE", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := getenv(name); v != "" {
+			return Normalize(v)
+		}
+	}
+	return ""
+}
+
+// Supported reports whether messages can be shown in the language of
+// locale. English always is.
+func Supported(locale string) bool {
+	lang := Normalize(locale)
+	_, ok := catalogs[lang]
+	return ok || lang == "" || lang == "en"
+}
+
+// Set selects the language messages are translated into. The empty string
+// and "en" select English.
+func Set(lang string) error {
+	lang = Normalize(lang)
+	if !Supported(lang) {
+		return fmt.Errorf("no translations for language %q (have en, %s)", lang, strings.Join(Languages(), ", "))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = catalogs[lang]
+	return nil
+}
+
+// T returns the translation of msg in the selected language, or msg.
+func T(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if s, ok := current[msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Sprintf formats the translation of format.
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
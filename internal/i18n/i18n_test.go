@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"de_DE.UTF-8": "de",
+		"es":          "es",
+		"ja_JP.eucJP": "ja",
+		"pt-BR":       "pt",
+		"sr_RS@latin": "sr",
+		"C":           "",
+		"POSIX":       "",
+		"C.UTF-8":     "",
+		"":            "",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	env := map[string]string{"LANG": "de_DE.UTF-8", "LC_MESSAGES": "ja_JP.UTF-8"}
+	if got := Detect(func(k string) string { return env[k] }); got != "ja" {
+		t.Errorf("Detect() = %q, want LC_MESSAGES to win over LANG", got)
+	}
+	env["NLM_LOCALE"] = "es"
+	if got := Detect(func(k string) string { return env[k] }); got != "es" {
+		t.Errorf("Detect() = %q, want NLM_LOCALE to win", got)
+	}
+}
+
+func TestSet(t *testing.T) {
+	defer Set("")
+	if err := Set("de_DE.UTF-8"); err != nil {
+		t.Fatal(err)
+	}
+	if got := Sprintf("delete notebook %s", "nb1"); got != "Notizbuch nb1 löschen" {
+		t.Errorf("Sprintf() = %q", got)
+	}
+	if got := T("an untranslated message"); got != "an untranslated message" {
+		t.Errorf("T() of an untranslated message = %q", got)
+	}
+	if err := Set("ja"); err != nil {
+		t.Fatal(err)
+	}
+	if got := Sprintf("remove source %s from notebook %s", "src1", "nb1"); got != "ノートブック nb1 からソース src1 を削除" {
+		t.Errorf("Sprintf() with reordered arguments = %q", got)
+	}
+	if err := Set("fr"); err == nil {
+		t.Errorf("Set(fr) succeeded without French translations")
+	}
+	if err := Set("en"); err != nil || T("y") != "y" {
+		t.Errorf("Set(en) = %v, T(y) = %q", err, T("y"))
+	}
+}
+
+var verb = regexp.MustCompile(`%[a-z]`)
+
+// TestCatalogs checks that every translation uses each of its message's
+// arguments, with the right verbs.
+func TestCatalogs(t *testing.T) {
+	for _, lang := range Languages() {
+		for msg, tr := range catalogs[lang] {
+			var args []interface{}
+			for i, v := range verb.FindAllString(msg, -1) {
+				if v == "%d" {
+					args = append(args, 100+i)
+				} else {
+					args = append(args, fmt.Sprintf("ARG%d", i))
+				}
+			}
+			got := fmt.Sprintf(tr, args...)
+			if strings.Contains(got, "%!") {
+				t.Errorf("%s: %q: bad verbs in %q", lang, msg, got)
+				continue
+			}
+			for _, arg := range args {
+				if !strings.Contains(got, fmt.Sprint(arg)) {
+					t.Errorf("%s: %q: translation %q drops argument %v", lang, msg, got, arg)
+				}
+			}
+			if strings.HasSuffix(msg, "\n") != strings.HasSuffix(tr, "\n") {
+				t.Errorf("%s: %q: translation %q changes the trailing newline", lang, msg, tr)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package i18n
+
+// ja holds the Japanese translations. Confirmation actions are written to
+// complete "本当に…しますか".
+var ja = map[string]string{
+	// Status messages
+	"nlm: checksum verified; this build has no release key to check the signature\n": "nlm: チェックサムを検証しました。このビルドには署名を確認するリリース鍵がありません\n",
+	"nlm: checksum and signature verified\n":                                         "nlm: チェックサムと署名を検証しました\n",
+	"Reading from stdin...\n":                                                        "標準入力から読み込んでいます...\n",
+	"Processing %d notebooks (concurrency %d)...\n":                                  "%d 件のノートブックを処理しています (同時実行数 %d)...\n",
+	"Opening %s\n":                               "%s を開いています\n",
+	"Copied to clipboard\n":                      "クリップボードにコピーしました\n",
+	"Waiting for job %s (%s for %s)":             "ジョブ %[1]s を待っています (%[3]s の %[2]s)",
+	"Waiting for %s":                             "%s を待っています",
+	"Uploading %s (%s)":                          "%s をアップロードしています (%s)",
+	"Downloading video overview for notebook %s": "ノートブック %s の動画解説をダウンロードしています",
+	"Downloading audio overview for notebook %s": "ノートブック %s の音声解説をダウンロードしています",
+	"Downloading %s":                             "%s をダウンロードしています",
+	"Checking for a newer release":               "新しいリリースを確認しています",
+	"Adding text content as source":              "テキストをソースとして追加しています",
+	"Adding source from URL: %s":                 "URL からソースを追加しています: %s",
+	"Loading sources":                            "ソースを読み込んでいます",
+	"Sources":                                    "ソース",
+	"Tab selects, Enter accepts, Esc cancels":    "Tab で選択、Enter で決定、Esc でキャンセル",
+	"no matches":                                 "一致するものはありません",
+	"Downloading":                                "ダウンロード",
+	"Notebooks":                                  "ノートブック",
+
+	// Confirmations
+	"Are you sure you want to %s? [y/N] ":      "本当に%sしますか? [y/N] ",
+	"Would %s (dry run)":                       "%sします (ドライラン)",
+	"delete notebook %s":                       "ノートブック %s を削除",
+	"turn off link access for %s":              "%s のリンク共有を無効に",
+	"revoke access to %s for %s":               "%[2]s の %[1]s へのアクセス権を削除",
+	"remove source %s from notebook %s":        "ノートブック %[2]s からソース %[1]s を削除",
+	"remove note %s":                           "ノート %s を削除",
+	"delete the audio overview of notebook %s": "ノートブック %s の音声解説を削除",
+	"delete artifact %s":                       "アーティファクト %s を削除",
+	"replace artifact %s":                      "アーティファクト %s を置き換え",
+	"delete %d artifact(s)":                    "%d 件のアーティファクトを削除",
+	"cancel job %s (%s for %s)":                "ジョブ %[1]s (%[3]s の %[2]s) をキャンセル",
+}
@@ -0,0 +1,294 @@
+// Package index mirrors notebooks, source metadata, notes and artifact text
+// into a local SQLite database. The index answers full-text searches and
+// offline reads, and reports what changed each time it is updated.
+package index
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// ErrNotIndexed is returned when something asked for is not in the index.
+var ErrNotIndexed = errors.New("not indexed")
+
+const schema = `
+CREATE TABLE IF NOT EXISTS notebooks (
+	id          TEXT PRIMARY KEY,
+	title       TEXT NOT NULL,
+	emoji       TEXT NOT NULL,
+	created_at  INTEGER NOT NULL,
+	modified_at INTEGER NOT NULL,
+	synced_at   INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS sources (
+	notebook_id TEXT NOT NULL,
+	id          TEXT NOT NULL,
+	position    INTEGER NOT NULL,
+	title       TEXT NOT NULL,
+	type        TEXT NOT NULL,
+	status      TEXT NOT NULL,
+	url         TEXT NOT NULL,
+	modified_at INTEGER NOT NULL,
+	PRIMARY KEY (notebook_id, id)
+);
+CREATE TABLE IF NOT EXISTS notes (
+	notebook_id TEXT NOT NULL,
+	id          TEXT NOT NULL,
+	position    INTEGER NOT NULL,
+	title       TEXT NOT NULL,
+	modified_at INTEGER NOT NULL,
+	PRIMARY KEY (notebook_id, id)
+);
+CREATE TABLE IF NOT EXISTS artifacts (
+	notebook_id TEXT NOT NULL,
+	id          TEXT NOT NULL,
+	position    INTEGER NOT NULL,
+	title       TEXT NOT NULL,
+	type        TEXT NOT NULL,
+	state       TEXT NOT NULL,
+	updated_at  INTEGER NOT NULL,
+	text        TEXT NOT NULL,
+	PRIMARY KEY (notebook_id, id)
+);
+CREATE VIRTUAL TABLE IF NOT EXISTS search USING fts5(
+	notebook_id UNINDEXED,
+	kind UNINDEXED,
+	item_id UNINDEXED,
+	title,
+	body,
+	tokenize = 'unicode61 remove_diacritics 2'
+);
+`
+
+// Index is a local SQLite mirror of account content. It is safe for
+// concurrent use, including by several processes.
+type Index struct {
+	db *sql.DB
+}
+
+// DefaultPath returns ~/.nlm/index.db.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "index.db"), nil
+}
+
+// Open opens the index at path, creating it if needed.
+func Open(path string) (*Index, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("create index directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("open index: %w", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open index %s: %w", path, err)
+	}
+	return &Index{db: db}, nil
+}
+
+// OpenDefault opens the index at DefaultPath.
+func OpenDefault() (*Index, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path)
+}
+
+// Close closes the database.
+func (ix *Index) Close() error {
+	return ix.db.Close()
+}
+
+// Notebook is an indexed notebook.
+type Notebook struct {
+	ID         string
+	Title      string
+	Emoji      string
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+	SyncedAt   time.Time // when the notebook was last indexed
+	Sources    []Source
+}
+
+// Source is the metadata of an indexed source. Source contents are not
+// indexed.
+type Source struct {
+	ID         string
+	Title      string
+	Type       string
+	Status     string
+	URL        string
+	ModifiedAt time.Time
+}
+
+// Note is an indexed note. NotebookLM returns only the titles of notes.
+type Note struct {
+	ID         string
+	Title      string
+	ModifiedAt time.Time
+}
+
+// Artifact is an indexed artifact, with its text if it has any.
+type Artifact struct {
+	ID        string
+	Title     string
+	Type      string
+	State     string
+	UpdatedAt time.Time
+	Text      string
+}
+
+// Notebooks returns the indexed notebooks with their sources, most
+// recently modified first.
+func (ix *Index) Notebooks() ([]Notebook, error) {
+	rows, err := ix.db.Query(`SELECT id, title, emoji, created_at, modified_at, synced_at FROM notebooks ORDER BY modified_at DESC, title`)
+	if err != nil {
+		return nil, fmt.Errorf("read notebooks: %w", err)
+	}
+	defer rows.Close()
+	var nbs []Notebook
+	for rows.Next() {
+		var nb Notebook
+		var created, modified, synced int64
+		if err := rows.Scan(&nb.ID, &nb.Title, &nb.Emoji, &created, &modified, &synced); err != nil {
+			return nil, fmt.Errorf("read notebooks: %w", err)
+		}
+		nb.CreatedAt, nb.ModifiedAt, nb.SyncedAt = fromUnix(created), fromUnix(modified), fromUnix(synced)
+		nbs = append(nbs, nb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read notebooks: %w", err)
+	}
+	for i := range nbs {
+		if nbs[i].Sources, err = ix.sources(ix.db, nbs[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return nbs, nil
+}
+
+// Notebook returns the indexed notebook with the given ID and its sources.
+func (ix *Index) Notebook(id string) (*Notebook, error) {
+	nbs, err := ix.Notebooks()
+	if err != nil {
+		return nil, err
+	}
+	for _, nb := range nbs {
+		if nb.ID == id {
+			return &nb, nil
+		}
+	}
+	return nil, fmt.Errorf("notebook %s %w", id, ErrNotIndexed)
+}
+
+// querier is a *sql.DB or *sql.Tx.
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func (ix *Index) sources(q querier, notebookID string) ([]Source, error) {
+	rows, err := q.Query(`SELECT id, title, type, status, url, modified_at FROM sources WHERE notebook_id = ? ORDER BY position`, notebookID)
+	if err != nil {
+		return nil, fmt.Errorf("read sources: %w", err)
+	}
+	defer rows.Close()
+	var list []Source
+	for rows.Next() {
+		var s Source
+		var modified int64
+		if err := rows.Scan(&s.ID, &s.Title, &s.Type, &s.Status, &s.URL, &modified); err != nil {
+			return nil, fmt.Errorf("read sources: %w", err)
+		}
+		s.ModifiedAt = fromUnix(modified)
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+// Notes returns the indexed notes of a notebook.
+func (ix *Index) Notes(notebookID string) ([]Note, error) {
+	return ix.notes(ix.db, notebookID)
+}
+
+func (ix *Index) notes(q querier, notebookID string) ([]Note, error) {
+	rows, err := q.Query(`SELECT id, title, modified_at FROM notes WHERE notebook_id = ? ORDER BY position`, notebookID)
+	if err != nil {
+		return nil, fmt.Errorf("read notes: %w", err)
+	}
+	defer rows.Close()
+	var list []Note
+	for rows.Next() {
+		var n Note
+		var modified int64
+		if err := rows.Scan(&n.ID, &n.Title, &modified); err != nil {
+			return nil, fmt.Errorf("read notes: %w", err)
+		}
+		n.ModifiedAt = fromUnix(modified)
+		list = append(list, n)
+	}
+	return list, rows.Err()
+}
+
+// Artifacts returns the indexed artifacts of a notebook.
+func (ix *Index) Artifacts(notebookID string) ([]Artifact, error) {
+	return ix.artifacts(ix.db, notebookID)
+}
+
+func (ix *Index) artifacts(q querier, notebookID string) ([]Artifact, error) {
+	rows, err := q.Query(`SELECT id, title, type, state, updated_at, text FROM artifacts WHERE notebook_id = ? ORDER BY position`, notebookID)
+	if err != nil {
+		return nil, fmt.Errorf("read artifacts: %w", err)
+	}
+	defer rows.Close()
+	var list []Artifact
+	for rows.Next() {
+		var a Artifact
+		var updated int64
+		if err := rows.Scan(&a.ID, &a.Title, &a.Type, &a.State, &updated, &a.Text); err != nil {
+			return nil, fmt.Errorf("read artifacts: %w", err)
+		}
+		a.UpdatedAt = fromUnix(updated)
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+// Artifact returns an indexed artifact.
+func (ix *Index) Artifact(notebookID, id string) (*Artifact, error) {
+	list, err := ix.Artifacts(notebookID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range list {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, fmt.Errorf("artifact %s %w", id, ErrNotIndexed)
+}
+
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnix(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
@@ -0,0 +1,157 @@
+package index
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func openTest(t *testing.T) *Index {
+	t.Helper()
+	ix, err := Open(filepath.Join(t.TempDir(), "sub", "index.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ix.Close() })
+	return ix
+}
+
+func TestPutNotebook(t *testing.T) {
+	ix := openTest(t)
+	when := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	nb := Notebook{ID: "nb1", Title: "Physics", Emoji: "🔭", ModifiedAt: when, Sources: []Source{
+		{ID: "s1", Title: "Lecture notes", Type: "pdf"},
+		{ID: "s2", Title: "Talk", Type: "youtube", URL: "https://youtube.com/watch?v=x"},
+	}}
+	changes, err := ix.PutNotebook(nb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{
+		{Added, KindNotebook, "nb1", "nb1", "Physics"},
+		{Added, KindSource, "nb1", "s1", "Lecture notes"},
+		{Added, KindSource, "nb1", "s2", "Talk"},
+	}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("first PutNotebook changes (-want +got):\n%s", diff)
+	}
+
+	if changes, err = ix.PutNotebook(nb); err != nil || len(changes) != 0 {
+		t.Errorf("unchanged PutNotebook = %v, %v; want no changes", changes, err)
+	}
+
+	nb.Title = "Astrophysics"
+	nb.Sources = []Source{{ID: "s2", Title: "Talk", Type: "youtube", Status: "error", URL: "https://youtube.com/watch?v=x"}, {ID: "s3", Title: "Paper"}}
+	changes, err = ix.PutNotebook(nb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Change{
+		{Changed, KindNotebook, "nb1", "nb1", "Astrophysics"},
+		{Changed, KindSource, "nb1", "s2", "Talk"},
+		{Added, KindSource, "nb1", "s3", "Paper"},
+		{Removed, KindSource, "nb1", "s1", "Lecture notes"},
+	}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("second PutNotebook changes (-want +got):\n%s", diff)
+	}
+
+	got, err := ix.Notebook("nb1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SyncedAt.IsZero() {
+		t.Error("SyncedAt not set")
+	}
+	got.SyncedAt = time.Time{}
+	if diff := cmp.Diff(&nb, got); diff != "" {
+		t.Errorf("Notebook mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := ix.Notebook("missing"); !errors.Is(err, ErrNotIndexed) {
+		t.Errorf("Notebook(missing) err = %v, want ErrNotIndexed", err)
+	}
+}
+
+func TestPutContentsAndSearch(t *testing.T) {
+	ix := openTest(t)
+	for _, nb := range []Notebook{
+		{ID: "nb1", Title: "Physics", Sources: []Source{{ID: "s1", Title: "Quantum mechanics primer"}}},
+		{ID: "nb2", Title: "Cooking"},
+	} {
+		if _, err := ix.PutNotebook(nb); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notes := []Note{{ID: "n1", Title: "Reading list"}}
+	artifacts := []Artifact{
+		{ID: "a1", Title: "Briefing", Type: "report", State: "ready", Text: "Entanglement links particles across distances."},
+		{ID: "a2", Title: "Overview", Type: "audio_overview", State: "ready"},
+	}
+	changes, err := ix.PutContents("nb1", notes, artifacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 {
+		t.Errorf("PutContents changes = %v, want 3 additions", changes)
+	}
+	if _, err := ix.PutContents("nb2", nil, []Artifact{{ID: "a3", Title: "Recipes", Text: "Quantum of salt, entanglement of noodles."}}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := ix.Search("entanglement", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Search(entanglement) = %+v, want 2 results", results)
+	}
+	results, err = ix.Search("ENTANGLE*", "nb1", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Result{{NotebookID: "nb1", NotebookTitle: "Physics", Kind: KindArtifact, ID: "a1", Title: "Briefing",
+		Snippet: "[Entanglement] links particles across distances."}}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("Search in nb1 mismatch (-want +got):\n%s", diff)
+	}
+	// Query syntax in the words is searched for literally.
+	if results, err := ix.Search(`quantum "primer AND`, "", 0); err != nil || len(results) != 0 {
+		t.Errorf(`Search(quantum "primer AND) = %+v, %v; want no results`, results, err)
+	}
+	if results, _ := ix.Search(`quantum "primer`, "", 0); len(results) != 1 || results[0].Kind != KindSource {
+		t.Errorf(`Search(quantum "primer) = %+v, want the source`, results)
+	}
+	if _, err := ix.Search("  ", "", 0); err == nil {
+		t.Error("empty Search succeeded")
+	}
+
+	changes, err = ix.PutContents("nb1", nil, artifacts[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantChanges := []Change{{Removed, KindNote, "nb1", "n1", "Reading list"}, {Removed, KindArtifact, "nb1", "a2", "Overview"}}
+	if diff := cmp.Diff(wantChanges, changes); diff != "" {
+		t.Errorf("PutContents changes (-want +got):\n%s", diff)
+	}
+	a, err := ix.Artifact("nb1", "a1")
+	if err != nil || a.Text != artifacts[0].Text {
+		t.Errorf("Artifact(a1) = %+v, %v", a, err)
+	}
+
+	changes, err = ix.Prune([]string{"nb2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]Change{{Removed, KindNotebook, "nb1", "nb1", "Physics"}}, changes); diff != "" {
+		t.Errorf("Prune changes (-want +got):\n%s", diff)
+	}
+	if results, _ := ix.Search("entanglement", "", 0); len(results) != 1 || results[0].NotebookID != "nb2" {
+		t.Errorf("Search after Prune = %+v, want only nb2", results)
+	}
+	if _, err := ix.Artifact("nb1", "a1"); !errors.Is(err, ErrNotIndexed) {
+		t.Errorf("Artifact after Prune err = %v, want ErrNotIndexed", err)
+	}
+}
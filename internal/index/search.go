@@ -0,0 +1,70 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result is an item matching a search.
+type Result struct {
+	NotebookID    string
+	NotebookTitle string
+	Kind          string
+	ID            string
+	Title         string
+	Snippet       string // the matching text, with matches in [brackets]
+}
+
+// Search finds indexed items containing every word of query, best matches
+// first. With a notebookID only that notebook is searched; a limit of zero
+// or less means no limit.
+func (ix *Index) Search(query, notebookID string, limit int) ([]Result, error) {
+	match := matchQuery(query)
+	if match == "" {
+		return nil, fmt.Errorf("empty search")
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := ix.db.Query(`SELECT s.notebook_id, coalesce(n.title, ''), s.kind, s.item_id, s.title,
+		snippet(search, -1, '[', ']', '…', 16)
+		FROM search s LEFT JOIN notebooks n ON n.id = s.notebook_id
+		WHERE search MATCH ? AND (? = '' OR s.notebook_id = ?)
+		ORDER BY rank LIMIT ?`, match, notebookID, notebookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+	var results []Result
+	for rows.Next() {
+		var r Result
+		if err := rows.Scan(&r.NotebookID, &r.NotebookTitle, &r.Kind, &r.ID, &r.Title, &r.Snippet); err != nil {
+			return nil, fmt.Errorf("search: %w", err)
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	return results, nil
+}
+
+// matchQuery turns words into an FTS5 query matching all of them, quoting
+// each so that punctuation is not read as query syntax. A trailing * on a
+// word keeps it a prefix search.
+func matchQuery(query string) string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		prefix := strings.HasSuffix(word, "*")
+		word = strings.TrimRight(word, "*")
+		if word == "" {
+			continue
+		}
+		term := `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+		if prefix {
+			term += "*"
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, " ")
+}
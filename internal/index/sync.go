@@ -0,0 +1,256 @@
+package index
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Op says how an item changed.
+type Op string
+
+// Ops of a Change.
+const (
+	Added   Op = "added"
+	Removed Op = "removed"
+	Changed Op = "changed"
+)
+
+// Kinds of indexed items.
+const (
+	KindNotebook = "notebook"
+	KindSource   = "source"
+	KindNote     = "note"
+	KindArtifact = "artifact"
+)
+
+// Change is a difference between what was indexed and what replaced it.
+type Change struct {
+	Op         Op
+	Kind       string
+	NotebookID string
+	ID         string
+	Title      string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %s %q", c.Op, c.Kind, c.ID, c.Title)
+}
+
+// item is what diff compares: sig holds every field whose change matters.
+type item struct {
+	id, title, sig string
+}
+
+// diff reports the items added to, removed from and changed in old.
+func diff(kind, notebookID string, old, new []item) []Change {
+	before := make(map[string]item, len(old))
+	for _, it := range old {
+		before[it.id] = it
+	}
+	var changes []Change
+	for _, it := range new {
+		prev, ok := before[it.id]
+		delete(before, it.id)
+		switch {
+		case !ok:
+			changes = append(changes, Change{Added, kind, notebookID, it.id, it.title})
+		case prev.sig != it.sig:
+			changes = append(changes, Change{Changed, kind, notebookID, it.id, it.title})
+		}
+	}
+	for _, it := range old {
+		if _, ok := before[it.id]; ok {
+			changes = append(changes, Change{Removed, kind, notebookID, it.id, it.title})
+		}
+	}
+	return changes
+}
+
+func notebookItem(nb Notebook) item {
+	return item{nb.ID, nb.Title, fmt.Sprint(nb.Title, nb.Emoji, unix(nb.ModifiedAt))}
+}
+
+func sourceItems(list []Source) []item {
+	items := make([]item, len(list))
+	for i, s := range list {
+		items[i] = item{s.ID, s.Title, fmt.Sprint(s.Title, s.Type, s.Status, s.URL, unix(s.ModifiedAt))}
+	}
+	return items
+}
+
+func noteItems(list []Note) []item {
+	items := make([]item, len(list))
+	for i, n := range list {
+		items[i] = item{n.ID, n.Title, fmt.Sprint(n.Title, unix(n.ModifiedAt))}
+	}
+	return items
+}
+
+func artifactItems(list []Artifact) []item {
+	items := make([]item, len(list))
+	for i, a := range list {
+		items[i] = item{a.ID, a.Title, fmt.Sprint(a.Title, a.Type, a.State, unix(a.UpdatedAt), a.Text)}
+	}
+	return items
+}
+
+// update runs fn in a transaction.
+func (ix *Index) update(fn func(tx *sql.Tx) error) error {
+	tx, err := ix.db.Begin()
+	if err != nil {
+		return fmt.Errorf("update index: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("update index: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("update index: %w", err)
+	}
+	return nil
+}
+
+// PutNotebook indexes a notebook and replaces its sources, returning what
+// changed.
+func (ix *Index) PutNotebook(nb Notebook) ([]Change, error) {
+	var changes []Change
+	err := ix.update(func(tx *sql.Tx) error {
+		var old []item
+		var title, emoji string
+		var modified int64
+		err := tx.QueryRow(`SELECT title, emoji, modified_at FROM notebooks WHERE id = ?`, nb.ID).Scan(&title, &emoji, &modified)
+		switch {
+		case err == nil:
+			old = append(old, notebookItem(Notebook{ID: nb.ID, Title: title, Emoji: emoji, ModifiedAt: fromUnix(modified)}))
+		case err != sql.ErrNoRows:
+			return err
+		}
+		changes = diff(KindNotebook, nb.ID, old, []item{notebookItem(nb)})
+
+		oldSources, err := ix.sources(tx, nb.ID)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, diff(KindSource, nb.ID, sourceItems(oldSources), sourceItems(nb.Sources))...)
+
+		if _, err := tx.Exec(`INSERT INTO notebooks (id, title, emoji, created_at, modified_at, synced_at) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET title = excluded.title, emoji = excluded.emoji, created_at = excluded.created_at,
+			modified_at = excluded.modified_at, synced_at = excluded.synced_at`,
+			nb.ID, nb.Title, nb.Emoji, unix(nb.CreatedAt), unix(nb.ModifiedAt), time.Now().UnixNano()); err != nil {
+			return err
+		}
+		if err := clearItems(tx, nb.ID, KindNotebook, KindSource); err != nil {
+			return err
+		}
+		if err := addSearch(tx, nb.ID, KindNotebook, nb.ID, nb.Title, ""); err != nil {
+			return err
+		}
+		for i, s := range nb.Sources {
+			if _, err := tx.Exec(`INSERT INTO sources (notebook_id, id, position, title, type, status, url, modified_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				nb.ID, s.ID, i, s.Title, s.Type, s.Status, s.URL, unix(s.ModifiedAt)); err != nil {
+				return err
+			}
+			if err := addSearch(tx, nb.ID, KindSource, s.ID, s.Title, s.URL); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return changes, err
+}
+
+// PutContents replaces the notes and artifacts indexed for a notebook,
+// returning what changed.
+func (ix *Index) PutContents(notebookID string, notes []Note, artifacts []Artifact) ([]Change, error) {
+	var changes []Change
+	err := ix.update(func(tx *sql.Tx) error {
+		oldNotes, err := ix.notes(tx, notebookID)
+		if err != nil {
+			return err
+		}
+		oldArtifacts, err := ix.artifacts(tx, notebookID)
+		if err != nil {
+			return err
+		}
+		changes = append(diff(KindNote, notebookID, noteItems(oldNotes), noteItems(notes)),
+			diff(KindArtifact, notebookID, artifactItems(oldArtifacts), artifactItems(artifacts))...)
+
+		if err := clearItems(tx, notebookID, KindNote, KindArtifact); err != nil {
+			return err
+		}
+		for i, n := range notes {
+			if _, err := tx.Exec(`INSERT INTO notes (notebook_id, id, position, title, modified_at) VALUES (?, ?, ?, ?, ?)`,
+				notebookID, n.ID, i, n.Title, unix(n.ModifiedAt)); err != nil {
+				return err
+			}
+			if err := addSearch(tx, notebookID, KindNote, n.ID, n.Title, ""); err != nil {
+				return err
+			}
+		}
+		for i, a := range artifacts {
+			if _, err := tx.Exec(`INSERT INTO artifacts (notebook_id, id, position, title, type, state, updated_at, text) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				notebookID, a.ID, i, a.Title, a.Type, a.State, unix(a.UpdatedAt), a.Text); err != nil {
+				return err
+			}
+			if err := addSearch(tx, notebookID, KindArtifact, a.ID, a.Title, a.Text); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return changes, err
+}
+
+// Prune removes the notebooks not in keep, and everything in them,
+// returning the notebooks removed.
+func (ix *Index) Prune(keep []string) ([]Change, error) {
+	nbs, err := ix.Notebooks()
+	if err != nil {
+		return nil, err
+	}
+	kept := make(map[string]bool, len(keep))
+	for _, id := range keep {
+		kept[id] = true
+	}
+	var changes []Change
+	err = ix.update(func(tx *sql.Tx) error {
+		for _, nb := range nbs {
+			if kept[nb.ID] {
+				continue
+			}
+			changes = append(changes, Change{Removed, KindNotebook, nb.ID, nb.ID, nb.Title})
+			if _, err := tx.Exec(`DELETE FROM notebooks WHERE id = ?`, nb.ID); err != nil {
+				return err
+			}
+			if err := clearItems(tx, nb.ID, KindNotebook, KindSource, KindNote, KindArtifact); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return changes, err
+}
+
+// clearItems deletes a notebook's items of the given kinds and their search
+// entries. The notebook row itself is left alone.
+func clearItems(tx *sql.Tx, notebookID string, kinds ...string) error {
+	tables := map[string]string{KindSource: "sources", KindNote: "notes", KindArtifact: "artifacts"}
+	for _, kind := range kinds {
+		if table, ok := tables[kind]; ok {
+			if _, err := tx.Exec(`DELETE FROM `+table+` WHERE notebook_id = ?`, notebookID); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(`DELETE FROM search WHERE notebook_id = ? AND kind = ?`, notebookID, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addSearch(tx *sql.Tx, notebookID, kind, id, title, body string) error {
+	_, err := tx.Exec(`INSERT INTO search (notebook_id, kind, item_id, title, body) VALUES (?, ?, ?, ?, ?)`,
+		notebookID, kind, id, title, body)
+	return err
+}
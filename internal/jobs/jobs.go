@@ -0,0 +1,180 @@
+// Package jobs records long-running NotebookLM generations so they can be
+// tracked across CLI invocations.
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/jsonfile"
+)
+
+// ErrNotFound is returned when no job matches an ID.
+var ErrNotFound = errors.New("job not found")
+
+// ErrAmbiguous is returned when an ID prefix matches more than one job.
+var ErrAmbiguous = errors.New("ambiguous job ID")
+
+// Kind identifies what a job is generating.
+type Kind string
+
+const (
+	KindAudio    Kind = "audio"
+	KindVideo    Kind = "video"
+	KindArtifact Kind = "artifact"
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusDone     Status = "done"
+	StatusFailed   Status = "failed"
+	StatusCanceled Status = "canceled"
+)
+
+// Job is a single recorded generation request.
+type Job struct {
+	ID         string    `json:"id"`
+	Kind       Kind      `json:"kind"`
+	NotebookID string    `json:"notebook_id"`
+	ResourceID string    `json:"resource_id,omitempty"` // artifact, audio or video ID when known
+	Status     Status    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	Notify     string    `json:"notify,omitempty"`   // notification target fired on completion
+	Notified   bool      `json:"notified,omitempty"` // whether Notify has been delivered
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Finished reports whether the job has reached a terminal state.
+func (j Job) Finished() bool {
+	return j.Status != StatusPending
+}
+
+// Store is the list of jobs, kept in a JSON file that separate invocations
+// of the CLI share.
+type Store struct {
+	file *jsonfile.File[[]Job]
+}
+
+// DefaultPath returns ~/.nlm/jobs.json.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "jobs.json"), nil
+}
+
+// Open returns the job store kept at path.
+func Open(path string) *Store {
+	return &Store{file: jsonfile.Open[[]Job](path, "jobs")}
+}
+
+// OpenDefault opens the store at DefaultPath.
+func OpenDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path), nil
+}
+
+// Add records a new pending job and returns it with its ID assigned.
+func (s *Store) Add(j Job) (Job, error) {
+	if j.ID == "" {
+		j.ID = newID()
+	}
+	if j.Status == "" {
+		j.Status = StatusPending
+	}
+	now := time.Now()
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = now
+	}
+	j.UpdatedAt = now
+	err := s.file.Update(func(jobs *[]Job) error {
+		*jobs = append(*jobs, j)
+		return nil
+	})
+	if err != nil {
+		return Job{}, err
+	}
+	return j, nil
+}
+
+// List returns all jobs, newest first.
+func (s *Store) List() ([]Job, error) {
+	jobs, err := s.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(jobs, func(a, b int) bool {
+		return jobs[a].CreatedAt.After(jobs[b].CreatedAt)
+	})
+	return jobs, nil
+}
+
+// Get returns the job whose ID is id or uniquely starts with id.
+func (s *Store) Get(id string) (Job, error) {
+	jobs, err := s.file.Load()
+	if err != nil {
+		return Job{}, err
+	}
+	i, err := find(jobs, id)
+	if err != nil {
+		return Job{}, err
+	}
+	return jobs[i], nil
+}
+
+// Update applies fn to the job matching id and saves the result.
+func (s *Store) Update(id string, fn func(*Job)) (Job, error) {
+	var job Job
+	err := s.file.Update(func(jobs *[]Job) error {
+		i, err := find(*jobs, id)
+		if err != nil {
+			return err
+		}
+		j := &(*jobs)[i]
+		fn(j)
+		j.UpdatedAt = time.Now()
+		job = *j
+		return nil
+	})
+	return job, err
+}
+
+func find(jobs []Job, id string) (int, error) {
+	match := -1
+	for i, j := range jobs {
+		if j.ID == id {
+			return i, nil
+		}
+		if id != "" && strings.HasPrefix(j.ID, id) {
+			if match >= 0 {
+				return -1, fmt.Errorf("%w: %s", ErrAmbiguous, id)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return match, nil
+}
+
+func newID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,107 @@
+package jobs
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	s := Open(path)
+
+	jobs, err := s.List()
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("List() on empty store = %v, %v; want no jobs", jobs, err)
+	}
+
+	first, err := s.Add(Job{ID: "aaaa1111", Kind: KindAudio, NotebookID: "nb1", CreatedAt: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if first.Status != StatusPending {
+		t.Errorf("Add() status = %q, want %q", first.Status, StatusPending)
+	}
+	second, err := s.Add(Job{ID: "aaaa2222", Kind: KindArtifact, NotebookID: "nb1", ResourceID: "art1"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	jobs, err = s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != second.ID {
+		t.Errorf("List() = %v, want newest job %s first", jobs, second.ID)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		want    string
+		wantErr error
+	}{
+		{name: "exact", id: "aaaa1111", want: "aaaa1111"},
+		{name: "unique prefix", id: "aaaa2", want: "aaaa2222"},
+		{name: "ambiguous prefix", id: "aaaa", wantErr: ErrAmbiguous},
+		{name: "missing", id: "bbbb", wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Get(tt.id)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Get(%q) error = %v, want %v", tt.id, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get(%q) error = %v", tt.id, err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("Get(%q) = %s, want %s", tt.id, got.ID, tt.want)
+			}
+		})
+	}
+
+	updated, err := s.Update("aaaa1", func(j *Job) { j.Status = StatusDone })
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !updated.Finished() {
+		t.Errorf("Update() job not finished: %+v", updated)
+	}
+	reloaded, err := Open(path).Get("aaaa1111")
+	if err != nil || reloaded.Status != StatusDone {
+		t.Errorf("reloaded job = %+v, %v; want status %q", reloaded, err, StatusDone)
+	}
+}
+
+func TestStoreConcurrentAdd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	s := Open(path)
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A separate Store per goroutine stands in for separate processes.
+			if _, err := Open(path).Add(Job{Kind: KindAudio, NotebookID: "nb1"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	list, err := s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != n {
+		t.Errorf("List() returned %d jobs, want %d", len(list), n)
+	}
+}
@@ -0,0 +1,78 @@
+// Package jsonfile keeps a value in a JSON file that separate invocations
+// of the CLI read and update. Updates hold the file's lock (see filelock)
+// from reading the value to writing it back, and replace the file by
+// rename, so no update is lost and no reader sees a partial write.
+package jsonfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tmc/nlm/internal/filelock"
+)
+
+// File is a JSON file holding a value of type T.
+type File[T any] struct {
+	path string
+	what string // what the file holds, for messages, such as "jobs"
+}
+
+// Open returns the file at path, which holds what. The file is created on
+// first update.
+func Open[T any](path, what string) *File[T] {
+	return &File[T]{path: path, what: what}
+}
+
+// Load returns the value in the file, or the zero value if there is no
+// file yet.
+func (f *File[T]) Load() (T, error) {
+	var v T
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return v, nil
+	}
+	if err != nil {
+		return v, fmt.Errorf("read %s: %w", f.what, err)
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("parse %s: %w", f.what, err)
+	}
+	return v, nil
+}
+
+// Update loads the value, passes it to fn to change and writes the result
+// back, holding the file's lock throughout. If fn fails nothing is
+// written and its error is returned.
+func (f *File[T]) Update(fn func(v *T) error) error {
+	return filelock.With(f.path, func() error {
+		v, err := f.Load()
+		if err != nil {
+			return err
+		}
+		if err := fn(&v); err != nil {
+			return err
+		}
+		return f.save(v)
+	})
+}
+
+func (f *File[T]) save(v T) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("create %s directory: %w", f.what, err)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", f.what, err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", f.what, err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("write %s: %w", f.what, err)
+	}
+	return nil
+}
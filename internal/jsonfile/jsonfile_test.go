@@ -0,0 +1,67 @@
+package jsonfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "counts.json")
+	f := Open[map[string]int](path, "counts")
+
+	v, err := f.Load()
+	if err != nil || v != nil {
+		t.Fatalf("Load() with no file = %v, %v; want nil", v, err)
+	}
+
+	// Separate Files stand in for separate processes; the lock keeps
+	// their read-modify-write cycles from losing updates.
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := Open[map[string]int](path, "counts").Update(func(m *map[string]int) error {
+				if *m == nil {
+					*m = make(map[string]int)
+				}
+				(*m)["n"]++
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if v, err := f.Load(); err != nil || v["n"] != n {
+		t.Errorf("Load() after %d updates = %v, %v", n, v, err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	// A failed update writes nothing.
+	boom := errors.New("boom")
+	err = f.Update(func(m *map[string]int) error {
+		(*m)["n"] = 0
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("Update() error = %v, want %v", err, boom)
+	}
+	if v, _ := f.Load(); v["n"] != n {
+		t.Errorf("Load() after a failed update = %v, want n=%d", v, n)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Load(); err == nil {
+		t.Error("Load() of a corrupt file succeeded")
+	}
+}
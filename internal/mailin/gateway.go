@@ -0,0 +1,241 @@
+package mailin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// Backend is the part of the NotebookLM client the gateway uses.
+// *api.Client implements it.
+type Backend interface {
+	AddSourceFromURL(projectID, url string) (string, error)
+	AddSourceFromText(projectID, content, title string) (string, error)
+	AddSourceFromReader(projectID string, r io.Reader, filename string, contentType ...string) (string, error)
+	GenerateFreeFormStreamedWithCallback(projectID, prompt string, sourceIDs []string, callback func(chunk string) bool, opts ...api.CallOption) error
+}
+
+// ErrNotAllowed is returned for messages from senders not allowed to use
+// the gateway.
+var ErrNotAllowed = errors.New("sender not allowed")
+
+// ErrNoNotebook is returned for messages that name no notebook.
+var ErrNoNotebook = errors.New("no notebook tag in the address or subject")
+
+// summaryPrompt asks for the summary sent back to the sender.
+const summaryPrompt = "Summarize the new sources in a few short paragraphs."
+
+// Gateway adds emailed links and documents to notebooks.
+type Gateway struct {
+	backend Backend
+	allow   []string
+
+	// Address is the gateway's own address. If set, only its
+	// plus-addresses route messages, so that other recipients' tags are
+	// not mistaken for notebooks.
+	Address string
+
+	// Resolve maps a tag to a notebook ID. If nil, tags must be notebook
+	// IDs.
+	Resolve func(tag string) (string, error)
+
+	// DefaultNotebook receives messages without a tag. If empty, they
+	// are rejected.
+	DefaultNotebook string
+
+	// Mailer sends the replies. If nil, none are sent.
+	Mailer *Mailer
+}
+
+// New returns a gateway adding sources with backend for the senders in
+// allow, given as addresses or as domains written @example.com.
+func New(backend Backend, allow []string) *Gateway {
+	g := &Gateway{backend: backend}
+	for _, a := range allow {
+		g.allow = append(g.allow, strings.ToLower(strings.TrimSpace(a)))
+	}
+	return g
+}
+
+// Allowed reports whether mail from addr is accepted.
+func (g *Gateway) Allowed(addr string) bool {
+	addr = strings.ToLower(addr)
+	for _, a := range g.allow {
+		if a == addr || strings.HasPrefix(a, "@") && strings.HasSuffix(addr, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Result is what the gateway did with a message.
+type Result struct {
+	NotebookID string   `json:"notebook_id"`
+	Sources    []Source `json:"sources"`
+	Summary    string   `json:"summary,omitempty"`
+	Replied    bool     `json:"replied"`
+}
+
+// Source is a source the gateway tried to add.
+type Source struct {
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title"`
+	Error string `json:"error,omitempty"`
+}
+
+// Handle adds a message's links, attachments or, if it has neither, its
+// text to the notebook it names, and replies with a summary of them.
+func (g *Gateway) Handle(m *Message) (*Result, error) {
+	if !g.Allowed(m.From) {
+		return nil, fmt.Errorf("%w: %s", ErrNotAllowed, m.From)
+	}
+	tag, recipient := m.Tag(g.Address)
+	res := &Result{NotebookID: g.DefaultNotebook}
+	if tag != "" {
+		res.NotebookID = tag
+		if g.Resolve != nil {
+			id, err := g.Resolve(tag)
+			if err != nil {
+				return nil, err
+			}
+			res.NotebookID = id
+		}
+	}
+	if res.NotebookID == "" {
+		return nil, ErrNoNotebook
+	}
+
+	add := func(title string, fn func() (string, error)) {
+		s := Source{Title: title}
+		id, err := fn()
+		if err != nil {
+			s.Error = err.Error()
+		}
+		s.ID = id
+		res.Sources = append(res.Sources, s)
+	}
+	for _, link := range m.Links() {
+		add(link, func() (string, error) { return g.backend.AddSourceFromURL(res.NotebookID, link) })
+	}
+	for _, a := range m.Attachments {
+		add(a.Filename, func() (string, error) {
+			return g.backend.AddSourceFromReader(res.NotebookID, bytes.NewReader(a.Data), a.Filename, a.ContentType)
+		})
+	}
+	if len(res.Sources) == 0 {
+		if m.Text == "" {
+			return nil, errors.New("the message has no links, attachments or text to add")
+		}
+		add(m.Title(), func() (string, error) { return g.backend.AddSourceFromText(res.NotebookID, m.Text, m.Title()) })
+	}
+
+	var ids, failed []string
+	for _, s := range res.Sources {
+		if s.Error == "" {
+			ids = append(ids, s.ID)
+		} else {
+			failed = append(failed, s.Title+": "+s.Error)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("add sources: %s", strings.Join(failed, "; "))
+	}
+
+	var summary strings.Builder
+	err := g.backend.GenerateFreeFormStreamedWithCallback(res.NotebookID, summaryPrompt, ids, func(chunk string) bool {
+		summary.WriteString(chunk)
+		return true
+	})
+	if err == nil {
+		res.Summary = strings.TrimSpace(summary.String())
+	}
+
+	// Automatic mail is not answered, so that two gateways or an
+	// out-of-office reply cannot keep mailing each other.
+	if g.Mailer != nil && !m.AutoReply {
+		from := g.Mailer.From
+		if from == "" {
+			from = recipient
+		}
+		if from == "" && len(m.To) > 0 {
+			from = m.To[0]
+		}
+		if err := g.Mailer.Reply(m, from, replyBody(res)); err != nil {
+			return res, fmt.Errorf("reply: %w", err)
+		}
+		res.Replied = true
+	}
+	return res, nil
+}
+
+// replyBody is the text of the reply to a handled message.
+func replyBody(res *Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Added to %s\n\n", api.NotebookURL(res.NotebookID))
+	for _, s := range res.Sources {
+		if s.Error != "" {
+			fmt.Fprintf(&b, "- %s (failed: %s)\n", s.Title, s.Error)
+		} else {
+			fmt.Fprintf(&b, "- %s\n", s.Title)
+		}
+	}
+	if res.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", res.Summary)
+	} else {
+		fmt.Fprintf(&b, "\nNo summary yet; NotebookLM may still be processing the sources.\n")
+	}
+	return b.String()
+}
+
+// ServeHTTP accepts a message posted as the raw request body, or in the
+// body-mime (Mailgun) or email (SendGrid) field of a form, and answers
+// with the Result as JSON. Messages that are refused are answered 406 Not
+// Acceptable, which mail services take as a reason not to retry.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "multipart/form-data" || mt == "application/x-www-form-urlencoded" {
+		if err := r.ParseMultipartForm(maxSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		raw := r.FormValue("body-mime")
+		if raw == "" {
+			raw = r.FormValue("email")
+		}
+		body = strings.NewReader(raw)
+	}
+	m, err := Parse(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	res, err := g.Handle(m)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, res)
+	case res != nil:
+		// The sources were added but the reply failed; retrying would
+		// add them again.
+		writeJSON(w, http.StatusOK, struct {
+			*Result
+			Error string `json:"error"`
+		}{res, err.Error()})
+	case errors.Is(err, ErrNotAllowed), errors.Is(err, ErrNoNotebook):
+		writeJSON(w, http.StatusNotAcceptable, map[string]string{"error": err.Error()})
+	default:
+		writeJSON(w, http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,264 @@
+package mailin
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+const multipartEmail = "From: Ada <Ada@Example.com>\r\n" +
+	"To: nlm+research@example.org\r\n" +
+	"Subject: =?utf-8?q?Papers_=E2=9C=93?=\r\n" +
+	"Message-ID: <m1@example.com>\r\n" +
+	"Content-Type: multipart/mixed; boundary=b1\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: multipart/alternative; boundary=b2\r\n" +
+	"\r\n" +
+	"--b2\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"See https://example.com/a.html, and https://example.com/b.\r\n" +
+	"Again: https://example.com/a.html=\r\n" +
+	"\r\n" +
+	"--b2\r\n" +
+	"Content-Type: text/html\r\n" +
+	"\r\n" +
+	"<p>ignored</p>\r\n" +
+	"--b2--\r\n" +
+	"--b1\r\n" +
+	"Content-Type: application/pdf; name=\"paper.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"paper.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0x\r\n" +
+	"LjQK\r\n" +
+	"--b1--\r\n"
+
+func TestParse(t *testing.T) {
+	m, err := Parse(strings.NewReader(multipartEmail))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.From != "ada@example.com" || m.Subject != "Papers ✓" || m.MessageID != "<m1@example.com>" {
+		t.Errorf("headers = %q %q %q", m.From, m.Subject, m.MessageID)
+	}
+	if !reflect.DeepEqual(m.To, []string{"nlm+research@example.org"}) {
+		t.Errorf("To = %q", m.To)
+	}
+	want := []string{"https://example.com/a.html", "https://example.com/b"}
+	if got := m.Links(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Links() = %q, want %q", got, want)
+	}
+	if len(m.Attachments) != 1 {
+		t.Fatalf("got %d attachments, want 1", len(m.Attachments))
+	}
+	if a := m.Attachments[0]; a.Filename != "paper.pdf" || a.ContentType != "application/pdf" || string(a.Data) != "%PDF-1.4\n" {
+		t.Errorf("attachment = %q %q %q", a.Filename, a.ContentType, a.Data)
+	}
+}
+
+func TestParseHTML(t *testing.T) {
+	m, err := Parse(strings.NewReader("From: a@example.com\r\nAuto-Submitted: auto-replied\r\nContent-Type: text/html\r\n\r\n" +
+		`<p>Read <a href="https://example.com/x">this</a> &amp; that</p>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.AutoReply {
+		t.Error("AutoReply = false, want true")
+	}
+	if got := m.Links(); !reflect.DeepEqual(got, []string{"https://example.com/x"}) {
+		t.Errorf("Links() = %q", got)
+	}
+	if !strings.Contains(m.Text, "this & that") {
+		t.Errorf("Text = %q", m.Text)
+	}
+}
+
+func TestTag(t *testing.T) {
+	tests := []struct {
+		to      []string
+		subject string
+		gateway string
+		tag     string
+		title   string
+	}{
+		{[]string{"nlm+research@example.org"}, "Papers", "", "research", "Papers"},
+		{[]string{"bob+x@example.com", "nlm+research@example.org"}, "", "nlm@example.org", "research", "Email from a@example.com"},
+		{[]string{"bob+x@example.com"}, "[ reading ] Later", "nlm@example.org", "reading", "Later"},
+		{[]string{"nlm@example.org"}, "Hello", "", "", "Hello"},
+	}
+	for _, tt := range tests {
+		m := &Message{From: "a@example.com", To: tt.to, Subject: tt.subject}
+		if tag, _ := m.Tag(tt.gateway); tag != tt.tag {
+			t.Errorf("Tag(%q) for %q %q = %q, want %q", tt.gateway, tt.to, tt.subject, tag, tt.tag)
+		}
+		if title := m.Title(); title != tt.title {
+			t.Errorf("Title() for %q = %q, want %q", tt.subject, title, tt.title)
+		}
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	g := New(nil, []string{"Ada@example.com", " @corp.example "})
+	for addr, want := range map[string]bool{
+		"ada@example.com":      true,
+		"bob@example.com":      false,
+		"bob@corp.example":     true,
+		"bob@evilcorp.example": false,
+	} {
+		if got := g.Allowed(addr); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+type fakeBackend struct {
+	added  []string
+	failOn string
+}
+
+func (f *fakeBackend) add(projectID, what string) (string, error) {
+	if what == f.failOn {
+		return "", errors.New("unsupported")
+	}
+	f.added = append(f.added, projectID+" "+what)
+	return "src" + string(rune('0'+len(f.added))), nil
+}
+
+func (f *fakeBackend) AddSourceFromURL(projectID, url string) (string, error) {
+	return f.add(projectID, url)
+}
+
+func (f *fakeBackend) AddSourceFromText(projectID, content, title string) (string, error) {
+	return f.add(projectID, "text:"+title)
+}
+
+func (f *fakeBackend) AddSourceFromReader(projectID string, r io.Reader, filename string, contentType ...string) (string, error) {
+	return f.add(projectID, "file:"+filename)
+}
+
+func (f *fakeBackend) GenerateFreeFormStreamedWithCallback(projectID, prompt string, sourceIDs []string, fn func(string) bool, opts ...api.CallOption) error {
+	fn("Two papers ")
+	fn("on " + strings.Join(sourceIDs, ","))
+	return nil
+}
+
+func TestHandle(t *testing.T) {
+	backend := &fakeBackend{failOn: "https://example.com/b"}
+	g := New(backend, []string{"ada@example.com"})
+	g.Resolve = func(tag string) (string, error) { return "nb-" + tag, nil }
+	m, err := Parse(strings.NewReader(multipartEmail))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.Handle(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"nb-research https://example.com/a.html", "nb-research file:paper.pdf"}
+	if !reflect.DeepEqual(backend.added, want) {
+		t.Errorf("added %q, want %q", backend.added, want)
+	}
+	if res.NotebookID != "nb-research" || res.Summary != "Two papers on src1,src2" || res.Replied {
+		t.Errorf("result = %+v", res)
+	}
+	if len(res.Sources) != 3 || res.Sources[1].Error != "unsupported" {
+		t.Errorf("sources = %+v", res.Sources)
+	}
+	body := replyBody(res)
+	for _, s := range []string{"nb-research", "- https://example.com/b (failed: unsupported)", "Two papers"} {
+		if !strings.Contains(body, s) {
+			t.Errorf("reply body lacks %q:\n%s", s, body)
+		}
+	}
+}
+
+func TestHandleText(t *testing.T) {
+	backend := &fakeBackend{}
+	g := New(backend, []string{"@example.com"})
+	g.DefaultNotebook = "inbox"
+	if _, err := g.Handle(&Message{From: "a@example.com", Subject: "Idea", Text: "No links here."}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"inbox text:Idea"}; !reflect.DeepEqual(backend.added, want) {
+		t.Errorf("added %q, want %q", backend.added, want)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	g := New(&fakeBackend{}, []string{"ada@example.com"})
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"raw", "message/rfc822", multipartEmail, http.StatusOK},
+		{"form", "application/x-www-form-urlencoded", url.Values{"body-mime": {multipartEmail}}.Encode(), http.StatusOK},
+		{"not allowed", "message/rfc822", strings.Replace(multipartEmail, "Ada@", "Bob@", 1), http.StatusNotAcceptable},
+		{"no tag", "message/rfc822", strings.Replace(multipartEmail, "nlm+research", "nlm", 1), http.StatusNotAcceptable},
+		{"malformed", "message/rfc822", "not an email", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status == http.StatusOK {
+				var res Result
+				if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || res.NotebookID != "research" {
+					t.Errorf("result = %s (%v)", rec.Body, err)
+				}
+			}
+		})
+	}
+}
+
+func TestReplyMessage(t *testing.T) {
+	m := &Message{From: "ada@example.com", Subject: "[research] Papers ✓", MessageID: "<m1@example.com>", References: "<m0@example.com>"}
+	raw := replyMessage(m, "nlm+research@example.org", "Added.\nSummary = short\n", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	reply, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := reply.Header
+	subject, _ := wordDecoder.DecodeHeader(h.Get("Subject"))
+	for k, want := range map[string]string{
+		"From":           "nlm+research@example.org",
+		"To":             "ada@example.com",
+		"In-Reply-To":    "<m1@example.com>",
+		"References":     "<m0@example.com> <m1@example.com>",
+		"Auto-Submitted": "auto-replied",
+		"Date":           "Wed, 01 May 2024 12:00:00 +0000",
+	} {
+		if got := h.Get(k); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+	if subject != "Re: [research] Papers ✓" {
+		t.Errorf("Subject = %q", subject)
+	}
+	if !strings.HasSuffix(h.Get("Message-Id"), "@example.org>") {
+		t.Errorf("Message-ID = %q", h.Get("Message-Id"))
+	}
+	body, _ := io.ReadAll(decode(h.Get("Content-Transfer-Encoding"), reply.Body))
+	if string(body) != "Added.\r\nSummary = short\r\n" {
+		t.Errorf("body = %q", body)
+	}
+}
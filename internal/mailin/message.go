@@ -0,0 +1,228 @@
+// Package mailin turns emails into notebook sources. A message is routed
+// to a notebook by the tag in its plus-address (nlm+research@example.com)
+// or subject ("[research] Paper to read"); its links, attachments or, if
+// it has neither, its text are added as sources, and the sender gets a
+// NotebookLM summary of them by reply.
+package mailin
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// maxSize is the largest message Parse reads.
+const maxSize = 32 << 20
+
+// Message is an email, reduced to what the gateway uses.
+type Message struct {
+	From        string   // sender address
+	To          []string // To and Cc addresses
+	Subject     string
+	MessageID   string
+	References  string
+	AutoReply   bool // the message says it was sent automatically
+	Text        string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: func(charset string, r io.Reader) (io.Reader, error) {
+	// Go decodes UTF-8 and ISO-8859-1 itself; other charsets are passed
+	// through rather than rejected.
+	return r, nil
+}}
+
+// Parse reads an RFC 5322 message.
+func Parse(r io.Reader) (*Message, error) {
+	raw, err := mail.ReadMessage(io.LimitReader(r, maxSize))
+	if err != nil {
+		return nil, fmt.Errorf("parse email: %w", err)
+	}
+	h := raw.Header
+	from, err := mail.ParseAddress(h.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("parse email sender: %w", err)
+	}
+	m := &Message{
+		From:       strings.ToLower(from.Address),
+		MessageID:  strings.TrimSpace(h.Get("Message-Id")),
+		References: strings.TrimSpace(h.Get("References")),
+	}
+	if auto := strings.ToLower(strings.TrimSpace(h.Get("Auto-Submitted"))); auto != "" && auto != "no" {
+		m.AutoReply = true
+	}
+	if m.Subject, err = wordDecoder.DecodeHeader(h.Get("Subject")); err != nil {
+		m.Subject = h.Get("Subject")
+	}
+	m.Subject = strings.TrimSpace(m.Subject)
+	for _, field := range []string{"To", "Cc", "Delivered-To"} {
+		list, _ := h.AddressList(field)
+		for _, a := range list {
+			m.To = append(m.To, strings.ToLower(a.Address))
+		}
+	}
+	var html string
+	if err := m.readPart(h.Get("Content-Type"), h.Get("Content-Transfer-Encoding"), "", raw.Body, &html); err != nil {
+		return nil, err
+	}
+	if m.Text == "" && html != "" {
+		m.Text = htmlText(html)
+	}
+	m.Text = strings.TrimSpace(m.Text)
+	return m, nil
+}
+
+// readPart reads a MIME part into m, recursing into multiparts. The first
+// plain text part is the message text; the first HTML part is kept in
+// html in case there is none.
+func (m *Message) readPart(contentType, encoding, disposition string, body io.Reader, html *string) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("parse email: %w", err)
+			}
+			if err := m.readPart(p.Header.Get("Content-Type"), p.Header.Get("Content-Transfer-Encoding"), p.Header.Get("Content-Disposition"), p, html); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decode(encoding, body))
+	if err != nil {
+		return fmt.Errorf("parse email: %w", err)
+	}
+	filename := params["name"]
+	if _, dparams, err := mime.ParseMediaType(disposition); err == nil && dparams["filename"] != "" {
+		filename = dparams["filename"]
+	}
+	if decoded, err := wordDecoder.DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+	switch {
+	case filename != "" || strings.HasPrefix(disposition, "attachment"):
+		if filename == "" {
+			filename = "attachment"
+		}
+		m.Attachments = append(m.Attachments, Attachment{Filename: filename, ContentType: mediaType, Data: data})
+	case mediaType == "text/plain" && m.Text == "":
+		m.Text = string(data)
+	case mediaType == "text/html" && *html == "":
+		*html = string(data)
+	}
+	return nil
+}
+
+func decode(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &newlineSkipper{r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// newlineSkipper drops the line breaks of base64 bodies, which the
+// decoder does not accept.
+type newlineSkipper struct{ r io.Reader }
+
+func (n *newlineSkipper) Read(p []byte) (int, error) {
+	for {
+		c, err := n.r.Read(p)
+		k := 0
+		for _, b := range p[:c] {
+			if b != '\r' && b != '\n' {
+				p[k] = b
+				k++
+			}
+		}
+		if k > 0 || err != nil {
+			return k, err
+		}
+	}
+}
+
+var (
+	tagPattern  = regexp.MustCompile(`<[^>]*>`)
+	linkPattern = regexp.MustCompile(`https?://[^\s<>"'\]\)]+`)
+	subjectTag  = regexp.MustCompile(`^\s*\[([^\]]+)\]\s*`)
+	hrefPattern = regexp.MustCompile(`(?i)<a\s[^>]*href="([^"]+)"[^>]*>`)
+	lineBreaks  = regexp.MustCompile(`(?i)<(br|/p|/div|/li)[^>]*>`)
+)
+
+// htmlText reduces HTML to its text, well enough to find links and read
+// short messages.
+func htmlText(html string) string {
+	html = hrefPattern.ReplaceAllString(html, " $1 ")
+	html = lineBreaks.ReplaceAllString(html, "\n")
+	text := tagPattern.ReplaceAllString(html, "")
+	for _, r := range [][2]string{{"&nbsp;", " "}, {"&lt;", "<"}, {"&gt;", ">"}, {"&quot;", `"`}, {"&#39;", "'"}, {"&amp;", "&"}} {
+		text = strings.ReplaceAll(text, r[0], r[1])
+	}
+	return text
+}
+
+// Tag returns the tag naming the message's notebook and the recipient
+// address it was found in. The tag is the part after the + of the first
+// plus-address of gateway (any plus-address if gateway is ""), otherwise
+// a [tag] at the start of the subject. It returns "" if there is neither.
+func (m *Message) Tag(gateway string) (tag, recipient string) {
+	gwLocal, gwDomain, _ := strings.Cut(strings.ToLower(gateway), "@")
+	for _, addr := range m.To {
+		local, domain, _ := strings.Cut(addr, "@")
+		base, tag, ok := strings.Cut(local, "+")
+		if !ok || tag == "" || gateway != "" && (base != gwLocal || domain != gwDomain) {
+			continue
+		}
+		return tag, addr
+	}
+	if sm := subjectTag.FindStringSubmatch(m.Subject); sm != nil {
+		return strings.TrimSpace(sm[1]), ""
+	}
+	return "", ""
+}
+
+// Title returns the subject without its tag, or "Email from <sender>" if
+// it is empty.
+func (m *Message) Title() string {
+	if title := strings.TrimSpace(subjectTag.ReplaceAllString(m.Subject, "")); title != "" {
+		return title
+	}
+	return "Email from " + m.From
+}
+
+// Links returns the distinct web links in the message text, in order.
+func (m *Message) Links() []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, l := range linkPattern.FindAllString(m.Text, -1) {
+		l = strings.TrimRight(l, ".,;:!?")
+		if !seen[l] {
+			seen[l] = true
+			links = append(links, l)
+		}
+	}
+	return links
+}
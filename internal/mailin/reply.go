@@ -0,0 +1,71 @@
+package mailin
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Mailer sends replies through an SMTP server, using STARTTLS when the
+// server offers it.
+type Mailer struct {
+	Addr     string // host:port of the server
+	Username string // if set, authenticate with Username and Password
+	Password string
+	From     string // if set, the sender of replies instead of the address mailed
+}
+
+// Reply sends body to the sender of m, from the address from.
+func (mm *Mailer) Reply(m *Message, from, body string) error {
+	var auth smtp.Auth
+	if mm.Username != "" {
+		host, _, err := net.SplitHostPort(mm.Addr)
+		if err != nil {
+			return fmt.Errorf("smtp address: %w", err)
+		}
+		auth = smtp.PlainAuth("", mm.Username, mm.Password, host)
+	}
+	return smtp.SendMail(mm.Addr, auth, from, []string{m.From}, replyMessage(m, from, body, time.Now()))
+}
+
+// replyMessage composes the reply to m.
+func replyMessage(m *Message, from, body string, now time.Time) []byte {
+	subject := m.Subject
+	if !strings.HasPrefix(strings.ToLower(subject), "re:") {
+		subject = "Re: " + subject
+	}
+	id := make([]byte, 12)
+	rand.Read(id)
+	_, domain, _ := strings.Cut(from, "@")
+	if domain == "" {
+		domain = "nlm.invalid"
+	}
+
+	var b bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&b, "%s: %s\r\n", k, v) }
+	header("From", from)
+	header("To", m.From)
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", hex.EncodeToString(id), domain))
+	if m.MessageID != "" {
+		header("In-Reply-To", m.MessageID)
+		header("References", strings.TrimSpace(m.References+" "+m.MessageID))
+	}
+	header("Auto-Submitted", "auto-replied")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	b.WriteString("\r\n")
+	qp := quotedprintable.NewWriter(&b)
+	qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	qp.Close()
+	return b.Bytes()
+}
@@ -0,0 +1,225 @@
+// Package mcp implements a Model Context Protocol server, which lets
+// agents call a set of tools over stdio or HTTP with server-sent events.
+//
+// Only the parts of the protocol a tool server needs are implemented:
+// initialization, ping, and listing and calling tools.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ProtocolVersion is the protocol revision the server speaks.
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is a function an agent can call.
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+
+	// Handler runs the tool with the call's arguments, a JSON object,
+	// and returns its text result. An error is reported to the agent as
+	// a failed call rather than a protocol error.
+	Handler func(ctx context.Context, args json.RawMessage) (string, error) `json:"-"`
+}
+
+// Param describes a tool argument.
+type Param struct {
+	Name        string
+	Description string
+	Required    bool
+	List        bool // a list of strings rather than a string
+}
+
+// Schema returns the JSON schema of an object with the given arguments.
+func Schema(params ...Param) json.RawMessage {
+	props := make(map[string]interface{})
+	required := []string{}
+	for _, p := range params {
+		prop := map[string]interface{}{"type": "string", "description": p.Description}
+		if p.List {
+			prop = map[string]interface{}{"type": "array", "items": map[string]string{"type": "string"}, "description": p.Description}
+		}
+		props[p.Name] = prop
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+	b, _ := json.Marshal(map[string]interface{}{"type": "object", "properties": props, "required": required})
+	return b
+}
+
+// Server answers protocol requests with its tools. Tool handlers are
+// called one at a time, so they may share a client that is not safe for
+// concurrent use.
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+
+	callMu sync.Mutex
+
+	mu       sync.Mutex
+	sessions map[string]*session // SSE sessions by ID
+}
+
+// NewServer returns a server that identifies itself with name and
+// version and offers tools.
+func NewServer(name, version string, tools ...Tool) *Server {
+	return &Server{name: name, version: version, tools: tools, sessions: make(map[string]*session)}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Handle processes one JSON-RPC message, or a batch of them, and returns
+// the reply to send, or nil if there is none because the message only
+// held notifications.
+func (s *Server) Handle(ctx context.Context, msg []byte) []byte {
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err == nil {
+		var replies []json.RawMessage
+		for _, m := range batch {
+			if r := s.handle(ctx, m); r != nil {
+				replies = append(replies, r)
+			}
+		}
+		if len(replies) == 0 {
+			return nil
+		}
+		b, _ := json.Marshal(replies)
+		return b
+	}
+	return s.handle(ctx, msg)
+}
+
+func (s *Server) handle(ctx context.Context, msg []byte) []byte {
+	var req request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return reply(nil, nil, &rpcError{codeParseError, "parse error: " + err.Error()})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return reply(req.ID, nil, &rpcError{codeInvalidRequest, "invalid request"})
+	}
+	result, rerr := s.dispatch(ctx, &req)
+	if len(req.ID) == 0 {
+		return nil // a notification
+	}
+	return reply(req.ID, result, rerr)
+}
+
+func reply(id json.RawMessage, result interface{}, rerr *rpcError) []byte {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	b, _ := json.Marshal(response{JSONRPC: "2.0", ID: id, Result: result, Error: rerr})
+	return b
+}
+
+func (s *Server) dispatch(ctx context.Context, req *request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": s.tools}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &rpcError{codeInvalidParams, "invalid params: " + err.Error()}
+		}
+		for _, t := range s.tools {
+			if t.Name == p.Name {
+				return s.call(ctx, t, p.Arguments), nil
+			}
+		}
+		return nil, &rpcError{codeInvalidParams, fmt.Sprintf("unknown tool %q", p.Name)}
+	}
+	if len(req.ID) == 0 {
+		return nil, nil // notifications such as notifications/initialized
+	}
+	return nil, &rpcError{codeMethodNotFound, fmt.Sprintf("method %q not found", req.Method)}
+}
+
+func (s *Server) call(ctx context.Context, t Tool, args json.RawMessage) callResult {
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+	s.callMu.Lock()
+	defer s.callMu.Unlock()
+	text, err := t.Handler(ctx, args)
+	if err != nil {
+		return callResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	return callResult{Content: []content{{Type: "text", Text: text}}}
+}
+
+// ServeStdio answers newline-delimited messages read from r, writing
+// replies to w, until r ends or ctx is done.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if out := s.Handle(ctx, line); out != nil {
+			if _, err := fmt.Fprintf(w, "%s\n", out); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testServer() *Server {
+	echo := Tool{
+		Name:        "echo",
+		Description: "Echo the text",
+		InputSchema: Schema(Param{Name: "text", Description: "text to echo", Required: true}, Param{Name: "tags", List: true}),
+		Handler: func(_ context.Context, args json.RawMessage) (string, error) {
+			var a struct{ Text string }
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", err
+			}
+			if a.Text == "" {
+				return "", errors.New("text is required")
+			}
+			return a.Text, nil
+		},
+	}
+	return NewServer("test", "v1", echo)
+}
+
+func TestServeStdio(t *testing.T) {
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"t","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope"}}`,
+		`{"jsonrpc":"2.0","id":"six","method":"resources/list"}`,
+		`not json`,
+		`[{"jsonrpc":"2.0","id":7,"method":"ping"},{"jsonrpc":"2.0","method":"notifications/cancelled"}]`,
+	}, "\n")
+	var out strings.Builder
+	if err := testServer().ServeStdio(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatalf("ServeStdio() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		`"protocolVersion":"2024-11-05"`,
+		`"inputSchema":{"properties":{"tags":{"description":"","items":{"type":"string"},"type":"array"},"text":{"description":"text to echo","type":"string"}},"required":["text"],"type":"object"}`,
+		`"result":{"content":[{"type":"text","text":"hi"}]}`,
+		`"result":{"content":[{"type":"text","text":"text is required"}],"isError":true}`,
+		`"error":{"code":-32602,"message":"unknown tool \"nope\""}`,
+		`"id":"six","error":{"code":-32601`,
+		`"id":null,"error":{"code":-32700`,
+		`[{"jsonrpc":"2.0","id":7,"result":{}}]`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d replies, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i, w := range want {
+		if !strings.Contains(lines[i], w) {
+			t.Errorf("reply %d = %s\nwant it to contain %s", i, lines[i], w)
+		}
+	}
+}
+
+func TestHandlerSSE(t *testing.T) {
+	srv := httptest.NewServer(testServer().Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/sse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+	readEvent := func() (event, data string) {
+		t.Helper()
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read event: %v", err)
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "":
+				return event, data
+			}
+		}
+	}
+	event, endpoint := readEvent()
+	if event != "endpoint" || !strings.HasPrefix(endpoint, "/message?sessionId=") {
+		t.Fatalf("first event = %q %q, want the message endpoint", event, endpoint)
+	}
+
+	post, err := http.Post(srv.URL+endpoint, "application/json",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"text":"over sse"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	post.Body.Close()
+	if post.StatusCode != http.StatusAccepted {
+		t.Errorf("POST status = %d, want 202", post.StatusCode)
+	}
+	if event, data := readEvent(); event != "message" || !strings.Contains(data, `"text":"over sse"`) {
+		t.Errorf("reply event = %q %q", event, data)
+	}
+
+	if r, _ := http.Post(srv.URL+"/message?sessionId=nope", "application/json", strings.NewReader("{}")); r.StatusCode != http.StatusNotFound {
+		t.Errorf("POST to an unknown session status = %d, want 404", r.StatusCode)
+	}
+	req, _ := http.NewRequest("GET", srv.URL+"/sse", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	if r, err := http.DefaultClient.Do(req); err != nil || r.StatusCode != http.StatusForbidden {
+		t.Errorf("request from another origin = %v, %v; want 403", r.StatusCode, err)
+	}
+}
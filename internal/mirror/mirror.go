@@ -0,0 +1,338 @@
+// Package mirror exports a notebook into a git working tree and commits
+// each export, so that the history of its sources, notes and artifacts is
+// kept in git.
+//
+// A mirror holds a README.md describing the notebook, a notebook.json
+// manifest that later exports are compared with, and the text of each
+// artifact that has text as artifacts/<id>.md. Files are named by ID so
+// that renaming an item changes its content rather than its path.
+package mirror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Manifest is the name of the file recording what a mirror holds.
+const Manifest = "notebook.json"
+
+// Notebook is the content of a notebook as mirrored.
+type Notebook struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Emoji     string     `json:"emoji,omitempty"`
+	Sources   []Source   `json:"sources"`
+	Notes     []Note     `json:"notes"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Source is the metadata of a source. Source contents are not mirrored.
+type Source struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Type  string `json:"type,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Note is a note. NotebookLM returns only the titles of notes.
+type Note struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Artifact is an artifact, with its Markdown text if it has any. The text
+// is written to its own file rather than the manifest.
+type Artifact struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Type     string `json:"type,omitempty"`
+	State    string `json:"state,omitempty"`
+	Markdown string `json:"-"`
+}
+
+// File returns the path of the artifact's text within the mirror, or "" if
+// it has none.
+func (a Artifact) File() string {
+	if a.Markdown == "" || strings.ContainsAny(a.ID, `/\.`) {
+		return ""
+	}
+	return "artifacts/" + a.ID + ".md"
+}
+
+// Change is a difference between the previous export and this one.
+type Change struct {
+	Op    string // "added", "removed" or "changed"
+	Kind  string // "notebook", "source", "note" or "artifact"
+	ID    string
+	Title string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %q", c.Op, c.Kind, c.Title)
+}
+
+// Export writes nb into dir, creating it if needed, and returns how it
+// differs from what dir held before. It fails if dir mirrors another
+// notebook.
+func Export(dir string, nb *Notebook) ([]Change, error) {
+	prev, err := read(dir)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil && prev.ID != nb.ID {
+		return nil, fmt.Errorf("%s mirrors notebook %s, not %s", dir, prev.ID, nb.ID)
+	}
+	if prev == nil {
+		prev = &Notebook{ID: nb.ID}
+	}
+	changes := diff(prev, nb, func(a Artifact) string {
+		data, _ := os.ReadFile(filepath.Join(dir, "artifacts", a.ID+".md"))
+		return string(data)
+	})
+
+	if err := os.MkdirAll(filepath.Join(dir, "artifacts"), 0755); err != nil {
+		return nil, fmt.Errorf("create mirror: %w", err)
+	}
+	keep := make(map[string]bool)
+	for _, a := range nb.Artifacts {
+		if a.File() == "" {
+			continue
+		}
+		keep[filepath.Base(a.File())] = true
+		if err := writeFile(dir, a.File(), []byte(strings.TrimRight(a.Markdown, "\n")+"\n")); err != nil {
+			return nil, err
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "artifacts"))
+	if err != nil {
+		return nil, fmt.Errorf("read mirror: %w", err)
+	}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") && !keep[e.Name()] {
+			if err := os.Remove(filepath.Join(dir, "artifacts", e.Name())); err != nil {
+				return nil, fmt.Errorf("remove stale artifact: %w", err)
+			}
+		}
+	}
+
+	manifest, err := json.MarshalIndent(nb, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := writeFile(dir, Manifest, append(manifest, '\n')); err != nil {
+		return nil, err
+	}
+	if err := writeFile(dir, "README.md", readme(nb)); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// read returns the notebook recorded in dir's manifest, or nil if there is
+// none.
+func read(dir string) (*Notebook, error) {
+	data, err := os.ReadFile(filepath.Join(dir, Manifest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read mirror: %w", err)
+	}
+	var nb Notebook
+	if err := json.Unmarshal(data, &nb); err != nil {
+		return nil, fmt.Errorf("read mirror %s: %w", Manifest, err)
+	}
+	return &nb, nil
+}
+
+func writeFile(dir, name string, data []byte) error {
+	if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), data, 0644); err != nil {
+		return fmt.Errorf("write mirror: %w", err)
+	}
+	return nil
+}
+
+// diff compares two exports. oldText returns the text previously exported
+// for an artifact.
+func diff(old, new *Notebook, oldText func(Artifact) string) []Change {
+	var changes []Change
+	if old.Title != new.Title || old.Emoji != new.Emoji {
+		op := "changed"
+		if old.Title == "" && old.Emoji == "" {
+			op = "added"
+		}
+		changes = append(changes, Change{op, "notebook", new.ID, new.Title})
+	}
+	type item struct{ id, title, sig string }
+	compare := func(kind string, before, after []item) {
+		prev := make(map[string]item, len(before))
+		for _, it := range before {
+			prev[it.id] = it
+		}
+		for _, it := range after {
+			p, ok := prev[it.id]
+			delete(prev, it.id)
+			switch {
+			case !ok:
+				changes = append(changes, Change{"added", kind, it.id, it.title})
+			case p.sig != it.sig:
+				changes = append(changes, Change{"changed", kind, it.id, it.title})
+			}
+		}
+		for _, it := range before {
+			if _, ok := prev[it.id]; ok {
+				changes = append(changes, Change{"removed", kind, it.id, it.title})
+			}
+		}
+	}
+	sources := func(list []Source) []item {
+		items := make([]item, len(list))
+		for i, s := range list {
+			items[i] = item{s.ID, s.Title, s.Title + "\x00" + s.Type + "\x00" + s.URL}
+		}
+		return items
+	}
+	notes := func(list []Note) []item {
+		items := make([]item, len(list))
+		for i, n := range list {
+			items[i] = item{n.ID, n.Title, n.Title}
+		}
+		return items
+	}
+	artifacts := func(list []Artifact, text func(Artifact) string) []item {
+		items := make([]item, len(list))
+		for i, a := range list {
+			items[i] = item{a.ID, a.Title, strings.Join([]string{a.Title, a.Type, a.State, text(a)}, "\x00")}
+		}
+		return items
+	}
+	compare("source", sources(old.Sources), sources(new.Sources))
+	compare("note", notes(old.Notes), notes(new.Notes))
+	newText := func(a Artifact) string {
+		if a.Markdown == "" {
+			return ""
+		}
+		return strings.TrimRight(a.Markdown, "\n") + "\n"
+	}
+	compare("artifact", artifacts(old.Artifacts, oldText), artifacts(new.Artifacts, newText))
+	return changes
+}
+
+// readme renders the notebook's README.md.
+func readme(nb *Notebook) []byte {
+	var b bytes.Buffer
+	title := nb.Title
+	if nb.Emoji != "" {
+		title = nb.Emoji + " " + title
+	}
+	fmt.Fprintf(&b, "# %s\n\nMirror of NotebookLM notebook `%s`.\n", title, nb.ID)
+
+	fmt.Fprintf(&b, "\n## Sources\n\n")
+	if len(nb.Sources) == 0 {
+		fmt.Fprintf(&b, "None.\n")
+	}
+	for _, s := range nb.Sources {
+		fmt.Fprintf(&b, "- %s", s.Title)
+		if s.Type != "" {
+			fmt.Fprintf(&b, " (%s)", s.Type)
+		}
+		if s.URL != "" {
+			fmt.Fprintf(&b, " <%s>", s.URL)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\n## Notes\n\n")
+	if len(nb.Notes) == 0 {
+		fmt.Fprintf(&b, "None.\n")
+	}
+	for _, n := range nb.Notes {
+		fmt.Fprintf(&b, "- %s\n", n.Title)
+	}
+
+	fmt.Fprintf(&b, "\n## Artifacts\n\n")
+	if len(nb.Artifacts) == 0 {
+		fmt.Fprintf(&b, "None.\n")
+	}
+	for _, a := range nb.Artifacts {
+		if f := a.File(); f != "" {
+			fmt.Fprintf(&b, "- [%s](%s)", a.Title, f)
+		} else {
+			fmt.Fprintf(&b, "- %s", a.Title)
+		}
+		if kind := strings.Trim(a.Type+", "+a.State, ", "); kind != "" {
+			fmt.Fprintf(&b, " (%s)", kind)
+		}
+		b.WriteString("\n")
+	}
+	return b.Bytes()
+}
+
+// Message returns a commit message summarizing changes.
+func Message(nb *Notebook, changes []Change) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Mirror %s", nb.Title)
+	if len(changes) > 0 {
+		b.WriteString("\n\n")
+		for _, c := range changes {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+	return b.String()
+}
+
+// Commit commits everything in dir with message, making dir a git
+// repository first if it is not in one. It returns the abbreviated hash of
+// the new commit, or "" if there was nothing to commit.
+func Commit(dir, message string) (string, error) {
+	if _, err := git(dir, "rev-parse", "--git-dir"); err != nil {
+		if _, err := git(dir, "init", "--quiet"); err != nil {
+			return "", err
+		}
+	}
+	if _, err := git(dir, "add", "--all", "--", "."); err != nil {
+		return "", err
+	}
+	if _, err := git(dir, "diff", "--cached", "--quiet", "--", "."); err == nil {
+		return "", nil
+	}
+	var env []string
+	if email, _ := git(dir, "config", "user.email"); email == "" {
+		// Let mirrors be committed on machines without a git identity.
+		env = []string{"GIT_AUTHOR_NAME=nlm", "GIT_AUTHOR_EMAIL=nlm@localhost", "GIT_COMMITTER_NAME=nlm", "GIT_COMMITTER_EMAIL=nlm@localhost"}
+	}
+	if _, err := gitEnv(dir, env, "commit", "--quiet", "--message", message, "--", "."); err != nil {
+		return "", err
+	}
+	return git(dir, "rev-parse", "--short", "HEAD")
+}
+
+// git runs a git command in dir and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	return gitEnv(dir, nil, args...)
+}
+
+// gitEnv is like git but adds env to the environment of the command.
+func gitEnv(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,134 @@
+package mirror
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testNotebook() *Notebook {
+	return &Notebook{
+		ID:    "nb1",
+		Title: "Physics",
+		Emoji: "🔭",
+		Sources: []Source{
+			{ID: "s1", Title: "Lecture notes", Type: "pdf"},
+			{ID: "s2", Title: "Talk", Type: "youtube_video", URL: "https://youtube.com/watch?v=x"},
+		},
+		Notes: []Note{{ID: "n1", Title: "Reading list"}},
+		Artifacts: []Artifact{
+			{ID: "a1", Title: "Briefing", Type: "report", State: "ready", Markdown: "# Briefing\n\nEntanglement.\n"},
+			{ID: "a2", Title: "Overview", Type: "audio_overview", State: "ready"},
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mirror")
+	nb := testNotebook()
+	changes, err := Export(dir, nb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 6 {
+		t.Errorf("first Export changes = %v, want 6 additions", changes)
+	}
+	readme, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# 🔭 Physics", "- Talk (youtube_video) <https://youtube.com/watch?v=x>", "- Reading list", "- [Briefing](artifacts/a1.md) (report, ready)", "- Overview (audio_overview, ready)"} {
+		if !strings.Contains(string(readme), want) {
+			t.Errorf("README.md lacks %q:\n%s", want, readme)
+		}
+	}
+
+	if changes, err = Export(dir, testNotebook()); err != nil || len(changes) != 0 {
+		t.Errorf("unchanged Export = %v, %v; want no changes", changes, err)
+	}
+
+	nb = testNotebook()
+	nb.Title = "Astrophysics"
+	nb.Sources = nb.Sources[1:]
+	nb.Notes = append(nb.Notes, Note{ID: "n2", Title: "Questions"})
+	nb.Artifacts[0].Markdown = "# Briefing\n\nEntanglement, revised."
+	nb.Artifacts = nb.Artifacts[:1]
+	changes, err = Export(dir, nb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{
+		{"changed", "notebook", "nb1", "Astrophysics"},
+		{"removed", "source", "s1", "Lecture notes"},
+		{"added", "note", "n2", "Questions"},
+		{"changed", "artifact", "a1", "Briefing"},
+		{"removed", "artifact", "a2", "Overview"},
+	}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("Export changes (-want +got):\n%s", diff)
+	}
+
+	nb.Artifacts = nil
+	if _, err := Export(dir, nb); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "artifacts", "a1.md")); !os.IsNotExist(err) {
+		t.Errorf("removed artifact's file remains: %v", err)
+	}
+
+	if _, err := Export(dir, &Notebook{ID: "nb2"}); err == nil || !strings.Contains(err.Error(), "mirrors notebook nb1") {
+		t.Errorf("Export of another notebook err = %v", err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	got := Message(testNotebook(), []Change{{"added", "source", "s1", "Lecture notes"}, {"removed", "note", "n1", "Reading list"}})
+	want := "Mirror Physics\n\n- added source \"Lecture notes\"\n- removed note \"Reading list\"\n"
+	if got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	dir := filepath.Join(t.TempDir(), "mirror")
+	nb := testNotebook()
+	changes, err := Export(dir, nb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := Commit(dir, Message(nb, changes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == "" {
+		t.Fatal("first Commit made no commit")
+	}
+	if hash, err := Commit(dir, "Mirror Physics"); err != nil || hash != "" {
+		t.Errorf("Commit without changes = %q, %v; want no commit", hash, err)
+	}
+
+	nb.Notes = nil
+	if changes, err = Export(dir, nb); err != nil {
+		t.Fatal(err)
+	}
+	second, err := Commit(dir, Message(nb, changes))
+	if err != nil || second == "" || second == first {
+		t.Fatalf("second Commit = %q, %v", second, err)
+	}
+	log, err := git(dir, "log", "--format=%an %s%n%b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(log, "nlm Mirror Physics\n- removed note \"Reading list\"") {
+		t.Errorf("git log = %q", log)
+	}
+}
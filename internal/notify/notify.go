@@ -0,0 +1,107 @@
+// Package notify delivers completion notifications for long-running
+// generations, either by running a local command or by POSTing to a webhook.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Event describes a finished generation.
+type Event struct {
+	JobID      string    `json:"job_id"`
+	Kind       string    `json:"kind"`
+	NotebookID string    `json:"notebook_id"`
+	ResourceID string    `json:"resource_id,omitempty"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+const cmdScheme = "cmd://"
+
+// Validate reports whether target is a supported notification target:
+// cmd://<command line>, or an http:// or https:// webhook URL.
+func Validate(target string) error {
+	if cmdline, ok := strings.CutPrefix(target, cmdScheme); ok {
+		if strings.TrimSpace(cmdline) == "" {
+			return fmt.Errorf("notify target %q: empty command", target)
+		}
+		return nil
+	}
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("notify target %q: want cmd://<command> or an http(s) URL", target)
+	}
+	return nil
+}
+
+// Send delivers ev to target.
+//
+// Commands run through the system shell with the event in NLM_JOB_*
+// environment variables. Webhooks receive the event as a JSON POST body.
+func Send(ctx context.Context, target string, ev Event) error {
+	if err := Validate(target); err != nil {
+		return err
+	}
+	if cmdline, ok := strings.CutPrefix(target, cmdScheme); ok {
+		return runCommand(ctx, cmdline, ev)
+	}
+	return postWebhook(ctx, target, ev)
+}
+
+func runCommand(ctx context.Context, cmdline string, ev Event) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", cmdline)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", cmdline)
+	}
+	cmd.Env = append(os.Environ(),
+		"NLM_JOB_ID="+ev.JobID,
+		"NLM_JOB_KIND="+ev.Kind,
+		"NLM_JOB_STATUS="+ev.Status,
+		"NLM_JOB_ERROR="+ev.Error,
+		"NLM_NOTEBOOK_ID="+ev.NotebookID,
+		"NLM_RESOURCE_ID="+ev.ResourceID,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run notify command: %w", err)
+	}
+	return nil
+}
+
+func postWebhook(ctx context.Context, target string, ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encode notification: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nlm")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("post webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
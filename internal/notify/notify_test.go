@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		target  string
+		wantErr bool
+	}{
+		{target: "cmd://say done"},
+		{target: "https://example.com/hook"},
+		{target: "http://localhost:8080/hook"},
+		{target: "cmd://", wantErr: true},
+		{target: "cmd://   ", wantErr: true},
+		{target: "ftp://example.com", wantErr: true},
+		{target: "https://", wantErr: true},
+		{target: "notify-send done", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			err := Validate(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.target, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	var got Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	ev := Event{JobID: "job1", Kind: "audio", NotebookID: "nb1", Status: "done"}
+	if err := Send(context.Background(), srv.URL, ev); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got.JobID != "job1" || got.Status != "done" {
+		t.Errorf("webhook received %+v, want job1/done", got)
+	}
+}
+
+func TestSendWebhookError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := Send(context.Background(), srv.URL, Event{}); err == nil {
+		t.Error("Send() to failing webhook succeeded, want error")
+	}
+}
+
+func TestSendCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	ev := Event{JobID: "job1", Status: "done"}
+	if err := Send(context.Background(), `cmd://echo "$NLM_JOB_ID $NLM_JOB_STATUS" > `+out, ev); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(data)); got != "job1 done" {
+		t.Errorf("command saw %q, want %q", got, "job1 done")
+	}
+}
@@ -0,0 +1,87 @@
+// Package provenance records the parameters artifacts were generated with
+// so they can be regenerated later.
+package provenance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tmc/nlm/internal/jsonfile"
+)
+
+// ErrNotFound is returned when no record exists for an artifact.
+var ErrNotFound = errors.New("no provenance recorded")
+
+// Record describes how an artifact was generated.
+type Record struct {
+	ArtifactID    string    `json:"artifact_id"`
+	NotebookID    string    `json:"notebook_id"`
+	Kind          string    `json:"kind"`                     // artifact kind such as "briefing-doc"
+	SourceIDs     []string  `json:"source_ids,omitempty"`     // empty means all of the notebook's sources
+	Language      string    `json:"language,omitempty"`       // output language code
+	Instructions  string    `json:"instructions,omitempty"`   // custom generation instructions
+	RefreshedFrom string    `json:"refreshed_from,omitempty"` // artifact this one regenerated
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Store holds records keyed by artifact ID in a JSON file that separate
+// invocations of the CLI share.
+type Store struct {
+	file *jsonfile.File[map[string]Record]
+}
+
+// DefaultPath returns ~/.nlm/provenance.json.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "provenance.json"), nil
+}
+
+// Open returns the provenance store kept at path.
+func Open(path string) *Store {
+	return &Store{file: jsonfile.Open[map[string]Record](path, "provenance")}
+}
+
+// OpenDefault opens the store at DefaultPath.
+func OpenDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path), nil
+}
+
+// Add saves r, replacing any earlier record for the same artifact.
+func (s *Store) Add(r Record) error {
+	if r.ArtifactID == "" {
+		return fmt.Errorf("artifact ID required")
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+	return s.file.Update(func(records *map[string]Record) error {
+		if *records == nil {
+			*records = make(map[string]Record)
+		}
+		(*records)[r.ArtifactID] = r
+		return nil
+	})
+}
+
+// Get returns the record for an artifact.
+func (s *Store) Get(artifactID string) (Record, error) {
+	records, err := s.file.Load()
+	if err != nil {
+		return Record{}, err
+	}
+	r, ok := records[artifactID]
+	if !ok {
+		return Record{}, fmt.Errorf("%w for artifact %s", ErrNotFound, artifactID)
+	}
+	return r, nil
+}
@@ -0,0 +1,51 @@
+package provenance
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStore(t *testing.T) {
+	s := Open(filepath.Join(t.TempDir(), "provenance.json"))
+
+	if _, err := s.Get("art1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() on empty store error = %v, want ErrNotFound", err)
+	}
+
+	first := Record{ArtifactID: "art1", NotebookID: "nb1", Kind: "faq", Instructions: "focus on chapter 3"}
+	if err := s.Add(first); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	second := Record{ArtifactID: "art2", NotebookID: "nb1", Kind: "faq", SourceIDs: []string{"s1"}, RefreshedFrom: "art1"}
+	if err := s.Add(second); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	got, err := s.Get("art2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Errorf("Get() CreatedAt is zero, want it set by Add")
+	}
+	got.CreatedAt = second.CreatedAt
+	if diff := cmp.Diff(second, got); diff != "" {
+		t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Adding a record for the same artifact replaces it.
+	first.Language = "de"
+	if err := s.Add(first); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if got, err := s.Get("art1"); err != nil || got.Language != "de" {
+		t.Errorf("Get() after replace = %+v, %v; want language de", got, err)
+	}
+
+	if err := s.Add(Record{}); err == nil {
+		t.Errorf("Add() without artifact ID succeeded")
+	}
+}
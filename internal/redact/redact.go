@@ -0,0 +1,145 @@
+// Package redact prepares values for debug output: it masks credentials
+// and shortens long text without cutting a character in two.
+package redact
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Truncate returns s cut to at most n bytes, followed by "..." if it was
+// cut. The cut falls on a character boundary, so that the result is
+// still valid UTF-8.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	if n < 0 {
+		n = 0
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+// Value masks a secret such as a token, keeping a few characters at each
+// end of long ones so that different values can still be told apart.
+func Value(v string) string {
+	switch {
+	case len(v) <= 8:
+		return strings.Repeat("*", len(v))
+	case len(v) <= 16:
+		return v[:2] + strings.Repeat("*", len(v)-4) + v[len(v)-2:]
+	default:
+		return v[:3] + strings.Repeat("*", len(v)-6) + v[len(v)-3:]
+	}
+}
+
+// Cookies masks the values in a Cookie header, keeping the names.
+func Cookies(header string) string {
+	var masked []string
+	for _, part := range strings.Split(header, ";") {
+		part = strings.TrimSpace(part)
+		if name, value, ok := strings.Cut(part, "="); ok {
+			part = name + "=" + Value(value)
+		}
+		masked = append(masked, part)
+	}
+	return strings.Join(masked, "; ")
+}
+
+// Header returns the value of the named header masked if the header
+// carries credentials.
+func Header(name, value string) string {
+	switch strings.ToLower(name) {
+	case "cookie":
+		return Cookies(value)
+	case "authorization", "proxy-authorization":
+		// Keep the scheme, as in "SAPISIDHASH 1700000000_***".
+		if scheme, cred, ok := strings.Cut(value, " "); ok {
+			return scheme + " " + Value(cred)
+		}
+		return Value(value)
+	case "set-cookie", "x-goog-api-key":
+		return Value(value)
+	}
+	return value
+}
+
+// Headers returns a copy of h with the values of headers that carry
+// credentials masked.
+func Headers(h map[string]string) map[string]string {
+	if h == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(h))
+	for k, v := range h {
+		masked[k] = Header(k, v)
+	}
+	return masked
+}
+
+// sessionParams are the URL query parameters that identify a session:
+// batchexecute's f.sid and a resumable upload's upload_id.
+var sessionParams = map[string]bool{"f.sid": true, "upload_id": true}
+
+// URL returns rawURL with the values of query parameters that identify a
+// session masked. The other parameters keep their order and encoding.
+func URL(rawURL string) string {
+	base, query, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return rawURL
+	}
+	query, fragment, hasFragment := strings.Cut(query, "#")
+	parts := strings.Split(query, "&")
+	for i, part := range parts {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		if key, err := url.QueryUnescape(k); err != nil || !sessionParams[key] {
+			continue
+		}
+		if dv, err := url.QueryUnescape(v); err == nil {
+			v = dv
+		}
+		// Keep the mask readable rather than percent-encoded.
+		parts[i] = k + "=" + strings.ReplaceAll(url.QueryEscape(Value(v)), "%2A", "*")
+	}
+	masked := base + "?" + strings.Join(parts, "&")
+	if hasFragment {
+		masked += "#" + fragment
+	}
+	return masked
+}
+
+// Params returns a copy of the URL query parameters p with the values of
+// those that identify a session masked.
+func Params(p map[string]string) map[string]string {
+	if p == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(p))
+	for k, v := range p {
+		if sessionParams[k] {
+			v = Value(v)
+		}
+		masked[k] = v
+	}
+	return masked
+}
+
+// Form returns the encoding of a request form with the auth token, sent
+// as "at", masked.
+func Form(form url.Values) string {
+	masked := make(url.Values, len(form))
+	for k, v := range form {
+		if k == "at" {
+			v = []string{Value(strings.Join(v, ""))}
+		}
+		masked[k] = v
+	}
+	return masked.Encode()
+}
@@ -0,0 +1,108 @@
+package redact
+
+import (
+	"net/url"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"abcdefgh", 3, "abc..."},
+		{"héllo", 2, "h..."}, // é is two bytes; the cut falls before it
+		{"héllo", 3, "hé..."},
+		{"日本語", 4, "日..."},
+		{"日本語", 2, "..."},
+		{"abc", 0, "..."},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.n)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q, not valid UTF-8", tt.s, tt.n, got)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		v    string
+		want string
+	}{
+		{"", ""},
+		{"secret", "******"},
+		{"0123456789", "01******89"},
+		{"AJpMio3c1234567890xyz", "AJp***************xyz"},
+	}
+	for _, tt := range tests {
+		if got := Value(tt.v); got != tt.want {
+			t.Errorf("Value(%q) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestHeader(t *testing.T) {
+	tests := []struct {
+		name, value string
+		want        string
+	}{
+		{"Cookie", "SID=abcdefghijklmnopq; HSID=xyz", "SID=abc***********opq; HSID=***"},
+		{"Authorization", "SAPISIDHASH 1700000000_0123456789abcdef", "SAPISIDHASH 170*********************def"},
+		{"X-Goog-Api-Key", "key", "***"},
+		{"Content-Type", "application/json", "application/json"},
+	}
+	for _, tt := range tests {
+		if got := Header(tt.name, tt.value); got != tt.want {
+			t.Errorf("Header(%q, %q) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+	h := map[string]string{"cookie": "SID=abcdefghijklmnopq", "origin": "https://notebooklm.google.com"}
+	got := Headers(h)
+	if got["cookie"] != "SID=abc***********opq" || got["origin"] != h["origin"] {
+		t.Errorf("Headers() = %v", got)
+	}
+	if h["cookie"] != "SID=abcdefghijklmnopq" {
+		t.Errorf("Headers() changed its argument")
+	}
+}
+
+func TestForm(t *testing.T) {
+	form := url.Values{"at": {"AJpMio3c1234567890xyz"}, "f.req": {"[]"}}
+	want := "at=AJp%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2Axyz&f.req=%5B%5D"
+	if got := Form(form); got != want {
+		t.Errorf("Form() = %q, want %q", got, want)
+	}
+}
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://example.com/data", "https://example.com/data"},
+		{
+			"https://example.com/batchexecute?bl=boq_1&f.sid=-7121977511756781186&hl=en",
+			"https://example.com/batchexecute?bl=boq_1&f.sid=-71**************186&hl=en",
+		},
+		{"https://example.com/upload?upload_id=ABCDEFGH12345678xyz#top", "https://example.com/upload?upload_id=ABC*************xyz#top"},
+	}
+	for _, tt := range tests {
+		if got := URL(tt.in); got != tt.want {
+			t.Errorf("URL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParams(t *testing.T) {
+	got := Params(map[string]string{"f.sid": "-7121977511756781186", "hl": "en"})
+	if got["f.sid"] != "-71**************186" || got["hl"] != "en" {
+		t.Errorf("Params() = %v", got)
+	}
+}
@@ -0,0 +1,244 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DefaultPollInterval is how often a notebook with event subscribers is
+// polled when Server.PollInterval is zero.
+const DefaultPollInterval = 30 * time.Second
+
+// Event is a change to a notebook, sent by GET /v1/events as a
+// server-sent event named by its Type.
+//
+// Types are source_added, source_changed and source_removed; note_added,
+// note_changed and note_removed; artifact_added, artifact_ready and
+// artifact_removed. An error event reports a poll that failed; polling
+// goes on.
+type Event struct {
+	Type     string    `json:"type"`
+	Notebook string    `json:"notebook"`
+	ID       string    `json:"id,omitempty"`
+	Title    string    `json:"title,omitempty"`
+	State    string    `json:"state,omitempty"` // artifacts only
+	Error    string    `json:"error,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// watcher polls one notebook for its subscribers.
+type watcher struct {
+	subs   map[chan Event]bool
+	cancel context.CancelFunc
+}
+
+// events streams the changes to the notebook named by the notebook query
+// parameter until the client goes away.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("notebook")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, errors.New("notebook is required"))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+	ch, unsubscribe := s.subscribe(id)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-ch:
+			if ev.Type == "" {
+				// A poll with no changes; keep idle proxies from closing
+				// the connection.
+				w.Write([]byte(": ping\n\n"))
+			} else {
+				writeEvent(w, ev.Type, ev)
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// subscribe returns a channel receiving the events of notebook id, and a
+// function to stop receiving them. Subscribers of a notebook share one
+// poller, which stops with the last of them.
+func (s *Server) subscribe(id string) (<-chan Event, func()) {
+	ch := make(chan Event, 64)
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	if s.watchers == nil {
+		s.watchers = make(map[string]*watcher)
+	}
+	wt := s.watchers[id]
+	if wt == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		wt = &watcher{subs: make(map[chan Event]bool), cancel: cancel}
+		s.watchers[id] = wt
+		go s.poll(ctx, id, wt)
+	}
+	wt.subs[ch] = true
+	return ch, func() {
+		s.watchMu.Lock()
+		defer s.watchMu.Unlock()
+		delete(wt.subs, ch)
+		if len(wt.subs) == 0 {
+			wt.cancel()
+			delete(s.watchers, id)
+		}
+	}
+}
+
+// poll compares snapshots of notebook id every poll interval and sends
+// the differences to wt's subscribers until ctx is done.
+func (s *Server) poll(ctx context.Context, id string, wt *watcher) {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	prev, _ := s.snapshot(id, nil)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		cur, err := s.snapshot(id, prev)
+		var events []Event
+		switch {
+		case err != nil:
+			events = []Event{{Type: "error", Notebook: id, Error: err.Error()}}
+		case prev == nil:
+			// The first snapshot failed; this one is the baseline.
+			prev = cur
+		default:
+			events = changes(id, prev, cur)
+			prev = cur
+		}
+		if len(events) == 0 {
+			events = []Event{{}}
+		}
+		now := time.Now().UTC()
+		s.watchMu.Lock()
+		for _, ev := range events {
+			if ev.Type != "" {
+				ev.Time = now
+			}
+			for ch := range wt.subs {
+				select {
+				case ch <- ev:
+				default: // a stalled client misses events rather than stalling the rest
+				}
+			}
+		}
+		s.watchMu.Unlock()
+	}
+}
+
+// notebookState is what the watcher compares between polls: the title
+// of each source and note, or state of each artifact, with a signature
+// that changes when the item does.
+type notebookState struct {
+	version                   string // of the notebook's sources; see GetProjectIfChanged
+	sources, notes, artifacts map[string]item
+	order                     struct{ sources, notes, artifacts []string }
+}
+
+type item struct {
+	title, state, sig string
+}
+
+// snapshot returns the state of notebook id. Its sources are taken from
+// prev, if not nil, when the notebook has not changed since.
+func (s *Server) snapshot(id string, prev *notebookState) (*notebookState, error) {
+	var version string
+	if prev != nil {
+		version = prev.version
+	}
+	nb, version, err := s.backend.GetProjectIfChanged(id, version)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := s.backend.GetNotes(id)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := s.backend.ListArtifacts(id)
+	if err != nil {
+		return nil, err
+	}
+	st := &notebookState{version: version, sources: map[string]item{}, notes: map[string]item{}, artifacts: map[string]item{}}
+	if nb == nil {
+		st.sources, st.order.sources = prev.sources, prev.order.sources
+	}
+	for _, src := range nb.GetSources() {
+		sid := src.GetSourceId().GetSourceId()
+		st.sources[sid] = item{title: src.GetTitle(), sig: src.GetTitle()}
+		st.order.sources = append(st.order.sources, sid)
+	}
+	for _, n := range notes {
+		out := note(n)
+		sig := out.Title
+		if out.UpdatedAt != nil {
+			sig += "\x00" + out.UpdatedAt.String()
+		}
+		st.notes[out.ID] = item{title: out.Title, sig: sig}
+		st.order.notes = append(st.order.notes, out.ID)
+	}
+	for _, a := range artifacts {
+		st.artifacts[a.ID] = item{title: a.Title, state: a.StateName(), sig: a.Title + "\x00" + a.StateName()}
+		st.order.artifacts = append(st.order.artifacts, a.ID)
+	}
+	return st, nil
+}
+
+// changes returns the events that turn prev into cur.
+func changes(id string, prev, cur *notebookState) []Event {
+	var events []Event
+	compare := func(kind string, before, after map[string]item, beforeOrder, afterOrder []string) {
+		for _, key := range afterOrder {
+			it := after[key]
+			old, ok := before[key]
+			ev := Event{Notebook: id, ID: key, Title: it.title, State: it.state}
+			switch {
+			case !ok:
+				ev.Type = kind + "_added"
+				events = append(events, ev)
+				if kind == "artifact" && it.state == "ready" {
+					ev.Type = "artifact_ready"
+					events = append(events, ev)
+				}
+			case old.sig == it.sig:
+			case kind == "artifact" && it.state == "ready" && old.state != "ready":
+				ev.Type = "artifact_ready"
+				events = append(events, ev)
+			case kind != "artifact":
+				ev.Type = kind + "_changed"
+				events = append(events, ev)
+			}
+		}
+		for _, key := range beforeOrder {
+			if _, ok := after[key]; !ok {
+				it := before[key]
+				events = append(events, Event{Type: kind + "_removed", Notebook: id, ID: key, Title: it.title})
+			}
+		}
+	}
+	compare("source", prev.sources, cur.sources, prev.order.sources, cur.order.sources)
+	compare("note", prev.notes, cur.notes, prev.order.notes, cur.order.notes)
+	compare("artifact", prev.artifacts, cur.artifacts, prev.order.artifacts, cur.order.artifacts)
+	return events
+}
@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+// changingBackend is a notebook whose sources and artifacts the test
+// changes between polls.
+type changingBackend struct {
+	fakeBackend
+	mu        sync.Mutex
+	sources   []*pb.Source
+	artifacts []*api.Artifact
+	decoded   int
+}
+
+func (b *changingBackend) GetProject(id string) (*api.Notebook, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &pb.Project{ProjectId: id, Sources: b.sources}, nil
+}
+
+// GetProjectIfChanged versions the notebook by its sources, counting the
+// times it is returned.
+func (b *changingBackend) GetProjectIfChanged(id, version string) (*api.Notebook, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v := fmt.Sprint(b.sources)
+	if v == version {
+		return nil, version, nil
+	}
+	b.decoded++
+	return &pb.Project{ProjectId: id, Sources: b.sources}, v, nil
+}
+
+func (b *changingBackend) ListArtifacts(id string) ([]*api.Artifact, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.artifacts, nil
+}
+
+func TestEvents(t *testing.T) {
+	b := &changingBackend{}
+	s := NewServer(b, "secret")
+	s.PollInterval = 10 * time.Millisecond
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL+"/v1/events?notebook=nb1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	// Change the notebook after the baseline poll.
+	time.Sleep(30 * time.Millisecond)
+	b.mu.Lock()
+	b.sources = []*pb.Source{{SourceId: &pb.SourceId{SourceId: "s1"}, Title: "Paper"}}
+	b.artifacts = []*api.Artifact{{ID: "a1", Title: "Guide", State: pb.ArtifactState_ARTIFACT_STATE_CREATING}}
+	b.mu.Unlock()
+
+	sc := bufio.NewScanner(resp.Body)
+	want := []string{"source_added", "artifact_added", "artifact_ready"}
+	var got []string
+	deadline := time.AfterFunc(5*time.Second, func() { resp.Body.Close() })
+	defer deadline.Stop()
+	for len(got) < len(want) && sc.Scan() {
+		name, ok := strings.CutPrefix(sc.Text(), "event: ")
+		if !ok {
+			continue
+		}
+		got = append(got, name)
+		if name == "artifact_added" {
+			b.mu.Lock()
+			b.artifacts = []*api.Artifact{{ID: "a1", Title: "Guide", State: pb.ArtifactState_ARTIFACT_STATE_READY}}
+			b.mu.Unlock()
+		}
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	// Polls of the unchanged notebook reuse its sources.
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.decoded != 2 {
+		t.Errorf("notebook returned %d times, want 2", b.decoded)
+	}
+}
+
+func TestEventsNeedsNotebook(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/v1/events", nil)
+	r.Header.Set("X-API-Key", "secret")
+	NewServer(&fakeBackend{}, "secret").Handler().ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	state := func(notes map[string]item, order ...string) *notebookState {
+		st := &notebookState{notes: notes}
+		st.order.notes = order
+		return st
+	}
+	prev := state(map[string]item{"n1": {title: "Ideas", sig: "Ideas"}, "n2": {title: "Old", sig: "Old"}}, "n1", "n2")
+	cur := state(map[string]item{"n1": {title: "Ideas", sig: "Ideas\x00later"}, "n3": {title: "New", sig: "New"}}, "n1", "n3")
+	var got []string
+	for _, ev := range changes("nb1", prev, cur) {
+		got = append(got, ev.Type+" "+ev.ID)
+	}
+	want := "note_changed n1,note_added n3,note_removed n2"
+	if strings.Join(got, ",") != want {
+		t.Errorf("changes = %v, want %s", got, want)
+	}
+}
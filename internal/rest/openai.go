@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The OpenAI-compatible routes let OpenAI SDKs use a notebook as a
+// grounded model: the model name is a notebook, and the conversation is
+// asked of its sources.
+//
+//	GET  /v1/models
+//	POST /v1/chat/completions
+
+// maxContextMessages is how many earlier turns of a conversation are
+// passed along with the latest message.
+const maxContextMessages = 4
+
+type chatMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// text returns the message's content, which is either a string or a list
+// of parts of which only text parts are kept.
+func (m chatMessage) text() string {
+	var s string
+	if json.Unmarshal(m.Content, &s) == nil {
+		return s
+	}
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	json.Unmarshal(m.Content, &parts)
+	var texts []string
+	for _, p := range parts {
+		if p.Type == "text" {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+type completionRequest struct {
+	Model     string        `json:"model"`
+	Messages  []chatMessage `json:"messages"`
+	Stream    bool          `json:"stream"`
+	SourceIDs []string      `json:"source_ids"` // an extension: sources to ask about
+}
+
+// completionMessage is a whole reply, or in a stream a piece of one.
+type completionMessage struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+type completionChoice struct {
+	Index        int                `json:"index"`
+	Message      *completionMessage `json:"message,omitempty"`
+	Delta        *completionMessage `json:"delta,omitempty"`
+	FinishReason *string            `json:"finish_reason"`
+}
+
+type completion struct {
+	ID      string             `json:"id"`
+	Object  string             `json:"object"`
+	Created int64              `json:"created"`
+	Model   string             `json:"model"`
+	Choices []completionChoice `json:"choices"`
+}
+
+// conversationPrompt turns chat messages into one prompt: system messages
+// become instructions, and the last few turns before the final user
+// message are given as context.
+func conversationPrompt(msgs []chatMessage) (string, error) {
+	var system []string
+	var turns []chatMessage
+	for _, m := range msgs {
+		switch m.Role {
+		case "system", "developer":
+			system = append(system, m.text())
+		case "user", "assistant":
+			turns = append(turns, m)
+		}
+	}
+	if len(turns) == 0 || turns[len(turns)-1].Role != "user" {
+		return "", errors.New("the last message must be from the user")
+	}
+	question := turns[len(turns)-1].text()
+	turns = turns[:len(turns)-1]
+	if len(turns) > maxContextMessages {
+		turns = turns[len(turns)-maxContextMessages:]
+	}
+
+	var b strings.Builder
+	if len(system) > 0 {
+		fmt.Fprintf(&b, "Instructions:\n%s\n\n", strings.Join(system, "\n"))
+	}
+	if len(turns) == 0 {
+		b.WriteString(question)
+		return b.String(), nil
+	}
+	b.WriteString("Previous conversation:\n")
+	for _, m := range turns {
+		who := "User"
+		if m.Role == "assistant" {
+			who = "Assistant"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", who, m.text())
+	}
+	fmt.Fprintf(&b, "User: %s\n\nPlease respond to the latest message, considering the conversation context.", question)
+	return b.String(), nil
+}
+
+func (s *Server) listModels(w http.ResponseWriter, r *http.Request) {
+	list, err := s.backend.ListRecentlyViewedProjects()
+	if err != nil {
+		s.openAIError(w, s.status(err), err)
+		return
+	}
+	type model struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Created int64  `json:"created"`
+		OwnedBy string `json:"owned_by"`
+	}
+	models := []model{}
+	for _, nb := range list {
+		models = append(models, model{ID: nb.GetProjectId(), Object: "model", OwnedBy: "notebooklm"})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"object": "list", "data": models})
+}
+
+func (s *Server) chatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req completionRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 16<<20)).Decode(&req); err != nil {
+		s.openAIError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Model == "" {
+		s.openAIError(w, http.StatusBadRequest, errors.New("model is required: give a notebook ID or alias"))
+		return
+	}
+	prompt, err := conversationPrompt(req.Messages)
+	if err != nil {
+		s.openAIError(w, http.StatusBadRequest, err)
+		return
+	}
+	flusher, canStream := w.(http.Flusher)
+	if req.Stream && !canStream {
+		s.openAIError(w, http.StatusInternalServerError, errors.New("streaming unsupported"))
+		return
+	}
+
+	notebookID := req.Model
+	if s.ResolveModel != nil {
+		if notebookID, err = s.ResolveModel(req.Model); err != nil {
+			s.openAIError(w, s.status(err), err)
+			return
+		}
+	}
+	c := completion{ID: "chatcmpl-" + randomID(), Created: time.Now().Unix(), Model: req.Model}
+	stop := "stop"
+
+	if !req.Stream {
+		var answer strings.Builder
+		err := s.backend.GenerateFreeFormStreamedWithCallback(notebookID, prompt, req.SourceIDs, func(chunk string) bool {
+			answer.WriteString(chunk)
+			return true
+		})
+		if err != nil {
+			s.openAIError(w, s.status(err), err)
+			return
+		}
+		c.Object = "chat.completion"
+		c.Choices = []completionChoice{{Message: &completionMessage{Role: "assistant", Content: answer.String()}, FinishReason: &stop}}
+		writeJSON(w, http.StatusOK, c)
+		return
+	}
+
+	c.Object = "chat.completion.chunk"
+	send := func(delta completionMessage, finish *string) {
+		c.Choices = []completionChoice{{Delta: &delta, FinishReason: finish}}
+		b, _ := json.Marshal(c)
+		fmt.Fprintf(w, "data: %s\n\n", b)
+		flusher.Flush()
+	}
+	started := false
+	err = s.backend.GenerateFreeFormStreamedWithCallback(notebookID, prompt, req.SourceIDs, func(chunk string) bool {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			send(completionMessage{Role: "assistant"}, nil)
+		}
+		send(completionMessage{Content: chunk}, nil)
+		return r.Context().Err() == nil
+	})
+	if err != nil && !started {
+		s.openAIError(w, s.status(err), err)
+		return
+	}
+	if !started {
+		w.Header().Set("Content-Type", "text/event-stream")
+		send(completionMessage{Role: "assistant"}, nil)
+	}
+	if err != nil {
+		b, _ := json.Marshal(openAIErrorBody(err))
+		fmt.Fprintf(w, "data: %s\n\n", b)
+	} else {
+		send(completionMessage{}, &stop)
+	}
+	fmt.Fprintf(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+func openAIErrorBody(err error) map[string]interface{} {
+	return map[string]interface{}{"error": map[string]interface{}{"message": err.Error(), "type": "invalid_request_error", "code": nil}}
+}
+
+// openAIError answers with an error in the form OpenAI clients expect.
+func (s *Server) openAIError(w http.ResponseWriter, status int, err error) {
+	body := openAIErrorBody(err)
+	if status >= 500 {
+		body["error"].(map[string]interface{})["type"] = "api_error"
+	}
+	writeJSON(w, status, body)
+}
+
+func randomID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
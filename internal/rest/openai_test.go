@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestConversationPrompt(t *testing.T) {
+	msg := func(role, content string) chatMessage {
+		b, _ := json.Marshal(content)
+		return chatMessage{Role: role, Content: b}
+	}
+	tests := []struct {
+		name    string
+		msgs    []chatMessage
+		want    string
+		wantErr bool
+	}{
+		{"single question", []chatMessage{msg("user", "Why?")}, "Why?", false},
+		{"system instructions", []chatMessage{msg("system", "Be brief."), msg("user", "Why?")},
+			"Instructions:\nBe brief.\n\nWhy?", false},
+		{"conversation", []chatMessage{msg("user", "Who?"), msg("assistant", "Ada."), msg("user", "When?")},
+			"Previous conversation:\nUser: Who?\nAssistant: Ada.\nUser: When?\n\nPlease respond to the latest message, considering the conversation context.", false},
+		{"old turns dropped", []chatMessage{msg("user", "1"), msg("assistant", "2"), msg("user", "3"), msg("assistant", "4"), msg("user", "5"), msg("assistant", "6"), msg("user", "7")},
+			"Previous conversation:\nUser: 3\nAssistant: 4\nUser: 5\nAssistant: 6\nUser: 7\n\nPlease respond to the latest message, considering the conversation context.", false},
+		{"content parts", []chatMessage{{Role: "user", Content: json.RawMessage(`[{"type":"text","text":"Look"},{"type":"image_url","image_url":{}},{"type":"text","text":"here"}]`)}},
+			"Look\nhere", false},
+		{"ends with assistant", []chatMessage{msg("user", "Hi"), msg("assistant", "Hello")}, "", true},
+		{"no messages", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conversationPrompt(tt.msgs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("conversationPrompt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("conversationPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatCompletions(t *testing.T) {
+	backend := &fakeBackend{}
+	s := NewServer(backend, "secret")
+	s.ResolveModel = func(model string) (string, error) {
+		if model == "research" {
+			return "nb1", nil
+		}
+		return model, nil
+	}
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+	post := func(body string) (int, string) {
+		t.Helper()
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/chat/completions", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+	ids := regexp.MustCompile(`"id":"chatcmpl-[0-9a-f]+","object":"([a-z.]+)","created":\d+`)
+
+	status, body := post(`{"model":"research","messages":[{"role":"system","content":"Be brief."},{"role":"user","content":"Why?"}]}`)
+	want := `{"id":"ID","object":"chat.completion","model":"research","choices":[{"index":0,"message":{"role":"assistant","content":"It is 42."},"finish_reason":"stop"}]}`
+	if got := ids.ReplaceAllString(strings.TrimSpace(body), `"id":"ID","object":"$1"`); status != 200 || got != want {
+		t.Errorf("completion = %d %s\nwant 200 %s", status, got, want)
+	}
+	if len(backend.prompts) != 1 || backend.prompts[0] != "Instructions:\nBe brief.\n\nWhy?" {
+		t.Errorf("prompts = %q", backend.prompts)
+	}
+
+	status, body = post(`{"model":"nb1","stream":true,"messages":[{"role":"user","content":"Why?"}]}`)
+	body = ids.ReplaceAllString(body, `"id":"ID","object":"$1"`)
+	chunk := func(delta, finish string) string {
+		return `data: {"id":"ID","object":"chat.completion.chunk","model":"nb1","choices":[{"index":0,"delta":` + delta + `,"finish_reason":` + finish + `}]}` + "\n\n"
+	}
+	want = chunk(`{"role":"assistant"}`, "null") + chunk(`{"content":"It"}`, "null") + chunk(`{"content":" is"}`, "null") +
+		chunk(`{"content":" 42."}`, "null") + chunk(`{}`, `"stop"`) + "data: [DONE]\n\n"
+	if status != 200 || body != want {
+		t.Errorf("stream = %d\n%s\nwant\n%s", status, body, want)
+	}
+
+	for _, tt := range []struct {
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{`{"messages":[{"role":"user","content":"Why?"}]}`, 400, `"message":"model is required`},
+		{`{"model":"nb1","messages":[{"role":"assistant","content":"Hi"}]}`, 400, `"type":"invalid_request_error"`},
+		{`{"model":"unknown","stream":true,"messages":[{"role":"user","content":"Why?"}]}`, 502, `{"error":{"code":null,"message":"notebook not found","type":"api_error"}}`},
+		{`{`, 400, `invalid request body`},
+	} {
+		if status, body := post(tt.body); status != tt.wantStatus || !strings.Contains(body, tt.wantBody) {
+			t.Errorf("POST %s = %d %s, want %d containing %s", tt.body, status, body, tt.wantStatus, tt.wantBody)
+		}
+	}
+}
+
+func TestListModels(t *testing.T) {
+	srv := httptest.NewServer(NewServer(&fakeBackend{}, "secret").Handler())
+	defer srv.Close()
+	req, _ := http.NewRequest("GET", srv.URL+"/v1/models", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	want := `{"data":[{"id":"nb1","object":"model","created":0,"owned_by":"notebooklm"}],"object":"list"}`
+	if got := strings.TrimSpace(string(b)); got != want {
+		t.Errorf("models = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,462 @@
+// Package rest serves NotebookLM notebooks as a plain JSON over HTTP API,
+// so services in any language can use them without speaking batchexecute.
+//
+// Routes, all under /v1 and all requiring the API key:
+//
+//	GET    /v1/notebooks
+//	POST   /v1/notebooks                        {"title", "emoji"}
+//	GET    /v1/notebooks/{id}
+//	DELETE /v1/notebooks/{id}
+//	GET    /v1/notebooks/{id}/sources
+//	POST   /v1/notebooks/{id}/sources           {"url"} or {"text", "title"}
+//	DELETE /v1/notebooks/{id}/sources/{source}
+//	GET    /v1/notebooks/{id}/notes
+//	POST   /v1/notebooks/{id}/notes             {"title", "content"}
+//	DELETE /v1/notebooks/{id}/notes/{note}
+//	POST   /v1/notebooks/{id}/chat              {"question", "source_ids"}
+//	GET    /v1/notebooks/{id}/artifacts
+//	GET    /v1/notebooks/{id}/artifacts/{artifact}
+//	GET    /v1/events?notebook={id}
+//
+// Chat answers stream as server-sent events when the request accepts
+// text/event-stream. GET /v1/models and POST /v1/chat/completions speak
+// the OpenAI chat completions API, with notebooks as models. /v1/events
+// streams a notebook's changes, found by polling it, as server-sent events.
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// Backend is the part of the NotebookLM client the server uses.
+// *api.Client implements it. Requests are served concurrently, so a
+// Backend must be safe for concurrent use.
+type Backend interface {
+	ListRecentlyViewedProjects() ([]*api.Notebook, error)
+	CreateProject(title, emoji string) (*api.Notebook, error)
+	GetProject(projectID string) (*api.Notebook, error)
+	GetProjectIfChanged(projectID, version string) (*api.Notebook, string, error)
+	DeleteProjects(projectIDs []string) error
+	AddSourceFromURL(projectID, url string) (string, error)
+	AddSourceFromText(projectID, content, title string) (string, error)
+	DeleteSources(projectID string, sourceIDs []string) error
+	GetNotes(projectID string) ([]*api.Note, error)
+	CreateNote(projectID, title, content string) (*api.Note, error)
+	DeleteNotes(projectID string, noteIDs []string) error
+	GenerateFreeFormStreamedWithCallback(projectID, prompt string, sourceIDs []string, callback func(chunk string) bool, opts ...api.CallOption) error
+	ListArtifacts(projectID string) ([]*api.Artifact, error)
+	GetArtifactContent(projectID, artifactID string) (*api.ArtifactContent, error)
+}
+
+// Server answers API requests with a backend. Each request calls the
+// backend on its own, so a slow one, such as a streamed chat, does not
+// hold up the others.
+type Server struct {
+	backend Backend
+	apiKey  string
+
+	// ErrorStatus maps a backend error to an HTTP status. If nil, or if
+	// it returns 0, backend errors are 502 Bad Gateway.
+	ErrorStatus func(error) int
+
+	// ResolveModel maps the model named in a chat completion request to a
+	// notebook ID. If nil, the model must be a notebook ID.
+	ResolveModel func(model string) (string, error)
+
+	// PollInterval is how often notebooks with event subscribers are
+	// polled. If zero, DefaultPollInterval is used.
+	PollInterval time.Duration
+
+	extra []route
+
+	watchMu  sync.Mutex
+	watchers map[string]*watcher
+}
+
+type route struct {
+	pattern string
+	handler http.Handler
+}
+
+// Handle adds a route served behind the API key, like the server's own.
+// Like them, its handler may run concurrently with other requests. Handle
+// must be called before Handler.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.extra = append(s.extra, route{pattern, h})
+}
+
+// NewServer returns a server for backend that accepts requests carrying
+// apiKey, as "Authorization: Bearer <key>", "X-API-Key: <key>" or the
+// password of basic authentication.
+func NewServer(backend Backend, apiKey string) *Server {
+	return &Server{backend: backend, apiKey: apiKey}
+}
+
+// Handler returns the server's HTTP handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/notebooks", s.listNotebooks)
+	mux.HandleFunc("POST /v1/notebooks", s.createNotebook)
+	mux.HandleFunc("GET /v1/notebooks/{id}", s.getNotebook)
+	mux.HandleFunc("DELETE /v1/notebooks/{id}", s.deleteNotebook)
+	mux.HandleFunc("GET /v1/notebooks/{id}/sources", s.listSources)
+	mux.HandleFunc("POST /v1/notebooks/{id}/sources", s.addSource)
+	mux.HandleFunc("DELETE /v1/notebooks/{id}/sources/{source}", s.deleteSource)
+	mux.HandleFunc("GET /v1/notebooks/{id}/notes", s.listNotes)
+	mux.HandleFunc("POST /v1/notebooks/{id}/notes", s.createNote)
+	mux.HandleFunc("DELETE /v1/notebooks/{id}/notes/{note}", s.deleteNote)
+	mux.HandleFunc("POST /v1/notebooks/{id}/chat", s.chat)
+	mux.HandleFunc("GET /v1/notebooks/{id}/artifacts", s.listArtifacts)
+	mux.HandleFunc("GET /v1/notebooks/{id}/artifacts/{artifact}", s.getArtifact)
+	mux.HandleFunc("GET /v1/models", s.listModels)
+	mux.HandleFunc("POST /v1/chat/completions", s.chatCompletions)
+	mux.HandleFunc("GET /v1/events", s.events)
+	for _, rt := range s.extra {
+		mux.Handle(rt.pattern, rt.handler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid API key"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	return Authorized(r, s.apiKey)
+}
+
+// Authorized reports whether r carries apiKey in one of the ways NewServer
+// accepts. An empty apiKey authorizes nothing.
+func Authorized(r *http.Request, apiKey string) bool {
+	key := r.Header.Get("X-API-Key")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	} else if _, password, ok := r.BasicAuth(); ok {
+		// Webhooks that cannot set headers can put the key in the URL
+		// as https://nlm:<key>@host/...
+		key = password
+	}
+	return apiKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1
+}
+
+// Notebook is a notebook in API responses.
+type Notebook struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Emoji       string   `json:"emoji,omitempty"`
+	SourceCount int      `json:"source_count"`
+	Sources     []Source `json:"sources,omitempty"`
+}
+
+// Source is a source in API responses.
+type Source struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Note is a note in API responses.
+type Note struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ArtifactContent is an artifact with its content in API responses.
+type ArtifactContent struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Markdown string `json:"markdown"`
+}
+
+func notebook(nb *api.Notebook) Notebook {
+	return Notebook{ID: nb.GetProjectId(), Title: nb.GetTitle(), Emoji: nb.GetEmoji(), SourceCount: len(nb.GetSources())}
+}
+
+func note(n *api.Note) Note {
+	out := Note{ID: n.GetSourceId().GetSourceId(), Title: n.GetTitle()}
+	if ts := n.GetMetadata().GetLastModifiedTime(); ts != nil {
+		t := ts.AsTime()
+		out.UpdatedAt = &t
+	}
+	return out
+}
+
+func (s *Server) listNotebooks(w http.ResponseWriter, r *http.Request) {
+	list, err := s.backend.ListRecentlyViewedProjects()
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	out := []Notebook{}
+	for _, nb := range list {
+		out = append(out, notebook(nb))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createNotebook(w http.ResponseWriter, r *http.Request) {
+	var req struct{ Title, Emoji string }
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Title == "" {
+		writeError(w, http.StatusBadRequest, errors.New("title is required"))
+		return
+	}
+	if req.Emoji == "" {
+		req.Emoji = "📙"
+	}
+	nb, err := s.backend.CreateProject(req.Title, req.Emoji)
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, notebook(nb))
+}
+
+func (s *Server) getNotebook(w http.ResponseWriter, r *http.Request) {
+	nb, err := s.backend.GetProject(r.PathValue("id"))
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	out := notebook(nb)
+	out.Sources = sources(nb)
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) deleteNotebook(w http.ResponseWriter, r *http.Request) {
+	err := s.backend.DeleteProjects([]string{r.PathValue("id")})
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sources(nb *api.Notebook) []Source {
+	out := []Source{}
+	for _, src := range nb.GetSources() {
+		out = append(out, Source{ID: src.GetSourceId().GetSourceId(), Title: src.GetTitle()})
+	}
+	return out
+}
+
+func (s *Server) listSources(w http.ResponseWriter, r *http.Request) {
+	nb, err := s.backend.GetProject(r.PathValue("id"))
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sources(nb))
+}
+
+func (s *Server) addSource(w http.ResponseWriter, r *http.Request) {
+	var req struct{ URL, Text, Title string }
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if (req.URL == "") == (req.Text == "") {
+		writeError(w, http.StatusBadRequest, errors.New("give exactly one of url or text"))
+		return
+	}
+	var id string
+	var err error
+	if req.URL != "" {
+		id, err = s.backend.AddSourceFromURL(r.PathValue("id"), req.URL)
+	} else {
+		if req.Title == "" {
+			req.Title = "Pasted Text"
+		}
+		id, err = s.backend.AddSourceFromText(r.PathValue("id"), req.Text, req.Title)
+	}
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	title := req.Title
+	if req.URL != "" {
+		title = req.URL
+	}
+	writeJSON(w, http.StatusCreated, Source{ID: id, Title: title})
+}
+
+func (s *Server) deleteSource(w http.ResponseWriter, r *http.Request) {
+	err := s.backend.DeleteSources(r.PathValue("id"), []string{r.PathValue("source")})
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listNotes(w http.ResponseWriter, r *http.Request) {
+	notes, err := s.backend.GetNotes(r.PathValue("id"))
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	out := []Note{}
+	for _, n := range notes {
+		out = append(out, note(n))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createNote(w http.ResponseWriter, r *http.Request) {
+	var req struct{ Title, Content string }
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Title == "" {
+		writeError(w, http.StatusBadRequest, errors.New("title is required"))
+		return
+	}
+	n, err := s.backend.CreateNote(r.PathValue("id"), req.Title, req.Content)
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, note(n))
+}
+
+func (s *Server) deleteNote(w http.ResponseWriter, r *http.Request) {
+	err := s.backend.DeleteNotes(r.PathValue("id"), []string{r.PathValue("note")})
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) chat(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Question  string   `json:"question"`
+		SourceIDs []string `json:"source_ids"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Question) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("question is required"))
+		return
+	}
+	flusher, stream := w.(http.Flusher)
+	stream = stream && strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+
+	if !stream {
+		var answer strings.Builder
+		err := s.backend.GenerateFreeFormStreamedWithCallback(r.PathValue("id"), req.Question, req.SourceIDs, func(chunk string) bool {
+			answer.WriteString(chunk)
+			return true
+		})
+		if err != nil {
+			s.backendError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"answer": answer.String()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	started := false
+	err := s.backend.GenerateFreeFormStreamedWithCallback(r.PathValue("id"), req.Question, req.SourceIDs, func(chunk string) bool {
+		started = true
+		writeEvent(w, "chunk", map[string]string{"text": chunk})
+		flusher.Flush()
+		return r.Context().Err() == nil
+	})
+	if err != nil && !started {
+		w.Header().Set("Content-Type", "application/json")
+		s.backendError(w, err)
+		return
+	}
+	if err != nil {
+		writeEvent(w, "error", map[string]string{"error": err.Error()})
+	} else {
+		writeEvent(w, "done", struct{}{})
+	}
+	flusher.Flush()
+}
+
+func writeEvent(w io.Writer, event string, v interface{}) {
+	b, _ := json.Marshal(v)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+}
+
+func (s *Server) listArtifacts(w http.ResponseWriter, r *http.Request) {
+	artifacts, err := s.backend.ListArtifacts(r.PathValue("id"))
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	if artifacts == nil {
+		artifacts = []*api.Artifact{}
+	}
+	writeJSON(w, http.StatusOK, artifacts)
+}
+
+func (s *Server) getArtifact(w http.ResponseWriter, r *http.Request) {
+	content, err := s.backend.GetArtifactContent(r.PathValue("id"), r.PathValue("artifact"))
+	if err != nil {
+		s.backendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ArtifactContent{
+		ID:       content.ID,
+		Type:     content.TypeName(),
+		Title:    content.Title,
+		Markdown: content.Markdown,
+	})
+}
+
+// readJSON decodes the request body into v, answering 400 Bad Request and
+// returning false if it cannot.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(io.LimitReader(r.Body, 16<<20)).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func (s *Server) backendError(w http.ResponseWriter, err error) {
+	writeError(w, s.status(err), err)
+}
+
+// status returns the HTTP status to answer a backend error with.
+func (s *Server) status(err error) int {
+	status := 0
+	if s.ErrorStatus != nil {
+		status = s.ErrorStatus(err)
+	}
+	if status == 0 {
+		status = http.StatusBadGateway
+	}
+	return status
+}
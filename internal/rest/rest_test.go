@@ -0,0 +1,294 @@
+package rest
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
+	"github.com/tmc/nlm/internal/api"
+)
+
+// fakeBackend serves one notebook and records what it was asked to do.
+type fakeBackend struct {
+	calls   []string
+	prompts []string
+}
+
+var errNotFound = errors.New("notebook not found")
+
+func (f *fakeBackend) project(id string) (*api.Notebook, error) {
+	if id != "nb1" {
+		return nil, errNotFound
+	}
+	return &pb.Project{ProjectId: "nb1", Title: "Research", Emoji: "📙", Sources: []*pb.Source{
+		{SourceId: &pb.SourceId{SourceId: "s1"}, Title: "Paper"},
+	}}, nil
+}
+
+func (f *fakeBackend) ListRecentlyViewedProjects() ([]*api.Notebook, error) {
+	nb, _ := f.project("nb1")
+	return []*api.Notebook{nb}, nil
+}
+
+func (f *fakeBackend) CreateProject(title, emoji string) (*api.Notebook, error) {
+	f.calls = append(f.calls, "create "+title+" "+emoji)
+	return &pb.Project{ProjectId: "nb2", Title: title, Emoji: emoji}, nil
+}
+
+func (f *fakeBackend) GetProject(id string) (*api.Notebook, error) { return f.project(id) }
+
+func (f *fakeBackend) GetProjectIfChanged(id, version string) (*api.Notebook, string, error) {
+	nb, err := f.project(id)
+	if err != nil || version == "v1" {
+		return nil, version, err
+	}
+	return nb, "v1", nil
+}
+
+func (f *fakeBackend) DeleteProjects(ids []string) error {
+	f.calls = append(f.calls, "delete "+strings.Join(ids, ","))
+	return nil
+}
+
+func (f *fakeBackend) AddSourceFromURL(id, url string) (string, error) {
+	f.calls = append(f.calls, "add-url "+id+" "+url)
+	return "s2", nil
+}
+
+func (f *fakeBackend) AddSourceFromText(id, content, title string) (string, error) {
+	f.calls = append(f.calls, "add-text "+id+" "+title)
+	return "s3", nil
+}
+
+func (f *fakeBackend) DeleteSources(id string, ids []string) error {
+	f.calls = append(f.calls, "rm-source "+id+" "+strings.Join(ids, ","))
+	return nil
+}
+
+func (f *fakeBackend) GetNotes(id string) ([]*api.Note, error) {
+	return []*api.Note{{SourceId: &pb.SourceId{SourceId: "n1"}, Title: "Ideas"}}, nil
+}
+
+func (f *fakeBackend) CreateNote(id, title, content string) (*api.Note, error) {
+	f.calls = append(f.calls, "new-note "+id+" "+title)
+	return &pb.Source{SourceId: &pb.SourceId{SourceId: "n2"}, Title: title}, nil
+}
+
+func (f *fakeBackend) DeleteNotes(id string, ids []string) error {
+	f.calls = append(f.calls, "rm-note "+id+" "+strings.Join(ids, ","))
+	return nil
+}
+
+func (f *fakeBackend) GenerateFreeFormStreamedWithCallback(id, prompt string, sourceIDs []string, callback func(string) bool, opts ...api.CallOption) error {
+	if _, err := f.project(id); err != nil {
+		return err
+	}
+	f.prompts = append(f.prompts, prompt)
+	for _, chunk := range []string{"It", " is", " 42."} {
+		if !callback(chunk) {
+			break
+		}
+	}
+	return nil
+}
+
+func (f *fakeBackend) ListArtifacts(id string) ([]*api.Artifact, error) { return nil, nil }
+
+func (f *fakeBackend) GetArtifactContent(id, artifactID string) (*api.ArtifactContent, error) {
+	return &api.ArtifactContent{
+		Artifact: &api.Artifact{ID: artifactID, Title: "Guide", Type: pb.ArtifactType_ARTIFACT_TYPE_REPORT},
+		Markdown: "# Guide\n",
+	}, nil
+}
+
+func TestHandler(t *testing.T) {
+	backend := &fakeBackend{}
+	s := NewServer(backend, "secret")
+	s.ErrorStatus = func(err error) int {
+		if errors.Is(err, errNotFound) {
+			return http.StatusNotFound
+		}
+		return 0
+	}
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	tests := []struct {
+		method, path, body string
+		header             string // Accept header
+		wantStatus         int
+		wantBody           string
+	}{
+		{"GET", "/v1/notebooks", "", "", 200, `[{"id":"nb1","title":"Research","emoji":"📙","source_count":1}]`},
+		{"POST", "/v1/notebooks", `{"title":"New"}`, "", 201, `{"id":"nb2","title":"New","emoji":"📙","source_count":0}`},
+		{"POST", "/v1/notebooks", `{}`, "", 400, `{"error":"title is required"}`},
+		{"POST", "/v1/notebooks", `{`, "", 400, `invalid request body`},
+		{"GET", "/v1/notebooks/nb1", "", "", 200, `"sources":[{"id":"s1","title":"Paper"}]`},
+		{"GET", "/v1/notebooks/nope", "", "", 404, `{"error":"notebook not found"}`},
+		{"DELETE", "/v1/notebooks/nb1", "", "", 204, ``},
+		{"GET", "/v1/notebooks/nb1/sources", "", "", 200, `[{"id":"s1","title":"Paper"}]`},
+		{"POST", "/v1/notebooks/nb1/sources", `{"url":"https://example.com"}`, "", 201, `{"id":"s2","title":"https://example.com"}`},
+		{"POST", "/v1/notebooks/nb1/sources", `{"text":"hello","title":"Greeting"}`, "", 201, `{"id":"s3","title":"Greeting"}`},
+		{"POST", "/v1/notebooks/nb1/sources", `{"url":"u","text":"t"}`, "", 400, `exactly one of url or text`},
+		{"DELETE", "/v1/notebooks/nb1/sources/s1", "", "", 204, ``},
+		{"GET", "/v1/notebooks/nb1/notes", "", "", 200, `[{"id":"n1","title":"Ideas"}]`},
+		{"POST", "/v1/notebooks/nb1/notes", `{"title":"Todo","content":"x"}`, "", 201, `{"id":"n2","title":"Todo"}`},
+		{"DELETE", "/v1/notebooks/nb1/notes/n1", "", "", 204, ``},
+		{"POST", "/v1/notebooks/nb1/chat", `{"question":"why?"}`, "", 200, `{"answer":"It is 42."}`},
+		{"POST", "/v1/notebooks/nb1/chat", `{"question":"why?"}`, "text/event-stream", 200,
+			"event: chunk\ndata: {\"text\":\"It\"}\n\nevent: chunk\ndata: {\"text\":\" is\"}\n\nevent: chunk\ndata: {\"text\":\" 42.\"}\n\nevent: done\ndata: {}\n\n"},
+		{"POST", "/v1/notebooks/nope/chat", `{"question":"why?"}`, "text/event-stream", 404, `{"error":"notebook not found"}`},
+		{"POST", "/v1/notebooks/nb1/chat", `{"question":" "}`, "", 400, `question is required`},
+		{"GET", "/v1/notebooks/nb1/artifacts", "", "", 200, `[]`},
+		{"GET", "/v1/notebooks/nb1/artifacts/a1", "", "", 200, `{"id":"a1","type":"report","title":"Guide","markdown":"# Guide\n"}`},
+		{"GET", "/v1/other", "", "", 404, ``},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Authorization", "Bearer secret")
+		if tt.header != "" {
+			req.Header.Set("Accept", tt.header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.wantStatus || !strings.Contains(string(body), tt.wantBody) {
+			t.Errorf("%s %s = %d %q, want %d containing %q", tt.method, tt.path, resp.StatusCode, body, tt.wantStatus, tt.wantBody)
+		}
+	}
+
+	want := []string{"create New 📙", "delete nb1", "add-url nb1 https://example.com", "add-text nb1 Greeting", "rm-source nb1 s1", "new-note nb1 Todo", "rm-note nb1 n1"}
+	if strings.Join(backend.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("backend calls = %q, want %q", backend.calls, want)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	s := NewServer(&fakeBackend{}, "secret")
+	s.Handle("POST /v1/extra", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+	for key, want := range map[string]int{"secret": 202, "wrong": 401} {
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/extra", nil)
+		req.Header.Set("X-API-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("key %q: status = %d, want %d", key, resp.StatusCode, want)
+		}
+	}
+}
+
+// slowChatBackend streams one chunk of an answer and then waits to be
+// released, like a long answer from the real backend.
+type slowChatBackend struct {
+	*fakeBackend
+	started, release chan struct{}
+}
+
+func (b *slowChatBackend) GenerateFreeFormStreamedWithCallback(id, prompt string, sourceIDs []string, callback func(string) bool, opts ...api.CallOption) error {
+	callback("It")
+	close(b.started)
+	<-b.release
+	callback(" is 42.")
+	return nil
+}
+
+func TestChatDoesNotBlockOtherRequests(t *testing.T) {
+	backend := &slowChatBackend{fakeBackend: &fakeBackend{}, started: make(chan struct{}), release: make(chan struct{})}
+	srv := httptest.NewServer(NewServer(backend, "secret").Handler())
+	defer srv.Close()
+
+	chatDone := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/notebooks/nb1/chat", strings.NewReader(`{"question":"What?"}`))
+		req.Header.Set("X-API-Key", "secret")
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			_, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		chatDone <- err
+	}()
+	<-backend.started
+
+	listed := make(chan int, 1)
+	go func() {
+		req, _ := http.NewRequest("GET", srv.URL+"/v1/notebooks", nil)
+		req.Header.Set("X-API-Key", "secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			listed <- 0
+			return
+		}
+		resp.Body.Close()
+		listed <- resp.StatusCode
+	}()
+	select {
+	case status := <-listed:
+		if status != http.StatusOK {
+			t.Errorf("GET /v1/notebooks during a chat = %d, want 200", status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("GET /v1/notebooks waited for a chat in progress")
+	}
+	close(backend.release)
+	if err := <-chatDone; err != nil {
+		t.Errorf("chat: %v", err)
+	}
+}
+
+func TestHandlerAPIKey(t *testing.T) {
+	srv := httptest.NewServer(NewServer(&fakeBackend{}, "secret").Handler())
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		header, value string
+		want          int
+	}{
+		{"", "", 401},
+		{"Authorization", "Bearer wrong", 401},
+		{"Authorization", "Basic secret", 401},
+		{"Authorization", "Basic " + base64.StdEncoding.EncodeToString([]byte("nlm:wrong")), 401},
+		{"Authorization", "Basic " + base64.StdEncoding.EncodeToString([]byte("nlm:secret")), 200},
+		{"Authorization", "Bearer secret", 200},
+		{"X-API-Key", "secret", 200},
+	} {
+		req, _ := http.NewRequest("GET", srv.URL+"/v1/notebooks", nil)
+		if tt.header != "" {
+			req.Header.Set(tt.header, tt.value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: %q status = %d, want %d", tt.header, tt.value, resp.StatusCode, tt.want)
+		}
+	}
+
+	// An empty key accepts nothing.
+	open := httptest.NewServer(NewServer(&fakeBackend{}, "").Handler())
+	defer open.Close()
+	req, _ := http.NewRequest("GET", open.URL+"/v1/notebooks", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != 401 {
+		t.Errorf("empty key: status = %v, %v; want 401", resp.StatusCode, err)
+	}
+}
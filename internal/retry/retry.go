@@ -0,0 +1,198 @@
+// Package retry keeps the items bulk operations failed on, so they can be
+// attempted again without redoing the ones that succeeded.
+package retry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmc/nlm/internal/jsonfile"
+)
+
+// ErrNotFound is returned when no item matches an ID.
+var ErrNotFound = errors.New("no queued item")
+
+// ErrAmbiguous is returned when an ID prefix matches more than one item.
+var ErrAmbiguous = errors.New("ambiguous item ID")
+
+// Op identifies the operation an item failed in.
+type Op string
+
+const (
+	OpAdd    Op = "add"    // a file or URL given to `nlm add`
+	OpZotero Op = "zotero" // a PDF or URL of a Zotero item, with its title
+)
+
+// Item is an input a bulk operation failed on.
+type Item struct {
+	ID         string    `json:"id"`
+	Op         Op        `json:"op"`
+	NotebookID string    `json:"notebook_id"`
+	Input      string    `json:"input"`               // file path or URL
+	Title      string    `json:"title,omitempty"`     // title to give the source
+	MIMEType   string    `json:"mime_type,omitempty"` // -mime of the original command
+	Pages      string    `json:"pages,omitempty"`     // -pages of the original command
+	OCR        bool      `json:"ocr,omitempty"`       // -ocr of the original command
+	Error      string    `json:"error"`               // the last failure
+	Attempts   int       `json:"attempts"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// same reports whether two items are the same input to the same notebook.
+func (it Item) same(o Item) bool {
+	return it.Op == o.Op && it.NotebookID == o.NotebookID && it.Input == o.Input && it.Title == o.Title
+}
+
+// Store is the retry queue, kept in a JSON file that separate invocations
+// of the CLI share.
+type Store struct {
+	file *jsonfile.File[[]Item]
+}
+
+// DefaultPath returns ~/.nlm/retry.json.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nlm", "retry.json"), nil
+}
+
+// Open returns the retry queue kept at path.
+func Open(path string) *Store {
+	return &Store{file: jsonfile.Open[[]Item](path, "retry queue")}
+}
+
+// OpenDefault opens the store at DefaultPath.
+func OpenDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path), nil
+}
+
+// Add queues items and returns them with their IDs assigned. An item
+// already queued for the same input is updated in place rather than
+// queued twice.
+func (s *Store) Add(items ...Item) ([]Item, error) {
+	added := make([]Item, len(items))
+	err := s.file.Update(func(queue *[]Item) error {
+		now := time.Now()
+	next:
+		for n, it := range items {
+			it.UpdatedAt = now
+			for i, q := range *queue {
+				if q.same(it) {
+					it.ID, it.CreatedAt = q.ID, q.CreatedAt
+					it.Attempts += q.Attempts
+					(*queue)[i], added[n] = it, it
+					continue next
+				}
+			}
+			if it.ID == "" {
+				it.ID = newID()
+			}
+			if it.CreatedAt.IsZero() {
+				it.CreatedAt = now
+			}
+			*queue = append(*queue, it)
+			added[n] = it
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// List returns the queued items, oldest first.
+func (s *Store) List() ([]Item, error) {
+	queue, err := s.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(queue, func(a, b int) bool {
+		return queue[a].CreatedAt.Before(queue[b].CreatedAt)
+	})
+	return queue, nil
+}
+
+// Get returns the item whose ID is id or uniquely starts with id.
+func (s *Store) Get(id string) (Item, error) {
+	queue, err := s.file.Load()
+	if err != nil {
+		return Item{}, err
+	}
+	i, err := find(queue, id)
+	if err != nil {
+		return Item{}, err
+	}
+	return queue[i], nil
+}
+
+// Update applies fn to the item matching id and saves the result.
+func (s *Store) Update(id string, fn func(*Item)) (Item, error) {
+	var it Item
+	err := s.file.Update(func(queue *[]Item) error {
+		i, err := find(*queue, id)
+		if err != nil {
+			return err
+		}
+		q := &(*queue)[i]
+		fn(q)
+		q.UpdatedAt = time.Now()
+		it = *q
+		return nil
+	})
+	return it, err
+}
+
+// Remove drops the items matching ids from the queue.
+func (s *Store) Remove(ids ...string) error {
+	return s.file.Update(func(queue *[]Item) error {
+		for _, id := range ids {
+			i, err := find(*queue, id)
+			if err != nil {
+				return err
+			}
+			*queue = slices.Delete(*queue, i, i+1)
+		}
+		return nil
+	})
+}
+
+func find(queue []Item, id string) (int, error) {
+	match := -1
+	for i, it := range queue {
+		if it.ID == id {
+			return i, nil
+		}
+		if id != "" && strings.HasPrefix(it.ID, id) {
+			if match >= 0 {
+				return -1, fmt.Errorf("%w: %s", ErrAmbiguous, id)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return match, nil
+}
+
+func newID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,81 @@
+package retry
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	s := Open(filepath.Join(t.TempDir(), "retry.json"))
+
+	queue, err := s.List()
+	if err != nil || len(queue) != 0 {
+		t.Fatalf("List() on empty store = %v, %v; want no items", queue, err)
+	}
+
+	added, err := s.Add(
+		Item{ID: "aaaa1111", Op: OpAdd, NotebookID: "nb1", Input: "a.pdf", Error: "timeout", Attempts: 1, CreatedAt: time.Now().Add(-time.Minute)},
+		Item{ID: "aaaa2222", Op: OpZotero, NotebookID: "nb1", Input: "https://doi.org/10.1/x", Title: "Paper", Error: "rate limited", Attempts: 1},
+	)
+	if err != nil || len(added) != 2 {
+		t.Fatalf("Add() = %v, %v", added, err)
+	}
+
+	// Failing on the same input again updates the queued item.
+	again, err := s.Add(Item{Op: OpAdd, NotebookID: "nb1", Input: "a.pdf", Error: "quota", Attempts: 1})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if again[0].ID != "aaaa1111" || again[0].Attempts != 2 || again[0].Error != "quota" {
+		t.Errorf("Add() of a queued input = %+v, want aaaa1111 updated", again[0])
+	}
+
+	queue, err = s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(queue) != 2 || queue[0].ID != "aaaa1111" {
+		t.Errorf("List() = %v, want 2 items, oldest aaaa1111 first", queue)
+	}
+
+	tests := []struct {
+		id      string
+		want    string
+		wantErr error
+	}{
+		{id: "aaaa1111", want: "aaaa1111"},
+		{id: "aaaa2", want: "aaaa2222"},
+		{id: "aaaa", wantErr: ErrAmbiguous},
+		{id: "bbbb", wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		it, err := s.Get(tt.id)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get(%q) error = %v, want %v", tt.id, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || it.ID != tt.want {
+			t.Errorf("Get(%q) = %v, %v; want %s", tt.id, it.ID, err, tt.want)
+		}
+	}
+
+	it, err := s.Update("aaaa2", func(it *Item) { it.Attempts++ })
+	if err != nil || it.Attempts != 2 {
+		t.Errorf("Update() = %+v, %v; want 2 attempts", it, err)
+	}
+
+	if err := s.Remove("aaaa1111"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := s.Remove("aaaa1111"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove() of a removed item error = %v, want %v", err, ErrNotFound)
+	}
+	queue, _ = s.List()
+	if len(queue) != 1 || queue[0].ID != "aaaa2222" {
+		t.Errorf("List() after Remove() = %v, want only aaaa2222", queue)
+	}
+}
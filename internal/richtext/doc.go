@@ -0,0 +1,10 @@
+// Package richtext converts between the rich text NotebookLM stores and
+// CommonMark.
+//
+// Notes, artifact bodies and chat answers arrive either as HTML fragments
+// or as Markdown already. ToMarkdown turns either into tidy Markdown, and
+// ToHTML turns Markdown back into the HTML fragment form, so that every
+// exporter and importer shares one conversion. Converting Markdown that
+// uses headings, paragraphs, lists, block quotes, code, tables, rules,
+// links and emphasis to HTML and back gives the same Markdown.
+package richtext
@@ -0,0 +1,191 @@
+package richtext
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdCode     = regexp.MustCompile("`([^`]+)`")
+	mdBold     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalic   = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*)\*|(^|[^_\w])_([^_\s][^_]*)_`)
+	mdLink     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdBullet   = regexp.MustCompile(`^\s*[-*+]\s+`)
+	mdOrdered  = regexp.MustCompile(`^\s*\d+[.)]\s+`)
+	mdRule     = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdTableSep = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdScheme   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+)
+
+// ToHTML converts Markdown to an HTML fragment: headings, paragraphs,
+// lists, block quotes, code, tables, rules, links and emphasis. Raw HTML
+// in the input is escaped, not passed through.
+func ToHTML(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	var para []string
+	list := "" // the open list element, if any
+	closeBlock := func() {
+		if len(para) > 0 {
+			fmt.Fprintf(&b, "<p>%s</p>\n", inline(strings.Join(para, "\n")))
+			para = nil
+		}
+		if list != "" {
+			fmt.Fprintf(&b, "</%s>\n", list)
+			list = ""
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			closeBlock()
+			class := ""
+			if lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```")); lang != "" {
+				class = fmt.Sprintf(` class="language-%s"`, html.EscapeString(lang))
+			}
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			fmt.Fprintf(&b, "<pre><code%s>%s</code></pre>\n", class, html.EscapeString(strings.Join(code, "\n")))
+		case trimmed == "":
+			// A blank line between items does not end a list.
+			if list != "" && len(para) == 0 && listKind(nextLine(lines, i)) == list {
+				continue
+			}
+			closeBlock()
+		case mdHeading.MatchString(trimmed):
+			closeBlock()
+			m := mdHeading.FindStringSubmatch(trimmed)
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", len(m[1]), inline(m[2]), len(m[1]))
+		case mdRule.MatchString(line):
+			closeBlock()
+			b.WriteString("<hr>\n")
+		case strings.HasPrefix(trimmed, ">"):
+			closeBlock()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			i--
+			fmt.Fprintf(&b, "<blockquote>\n%s</blockquote>\n", ToHTML(strings.Join(quote, "\n")))
+		case strings.Contains(trimmed, "|") && i+1 < len(lines) && mdTableSep.MatchString(lines[i+1]):
+			closeBlock()
+			b.WriteString("<table>\n<thead>\n")
+			writeRow(&b, "th", trimmed)
+			b.WriteString("</thead>\n<tbody>\n")
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				writeRow(&b, "td", strings.TrimSpace(lines[i]))
+			}
+			i--
+			b.WriteString("</tbody>\n</table>\n")
+		case listKind(line) != "":
+			kind := listKind(line)
+			if len(para) > 0 || list != kind {
+				closeBlock()
+				fmt.Fprintf(&b, "<%s>\n", kind)
+				list = kind
+			}
+			marker := mdBullet
+			if kind == "ol" {
+				marker = mdOrdered
+			}
+			fmt.Fprintf(&b, "<li>%s</li>\n", inline(line[len(marker.FindString(line)):]))
+		default:
+			if list != "" {
+				closeBlock()
+			}
+			para = append(para, trimmed)
+		}
+	}
+	closeBlock()
+	return b.String()
+}
+
+// listKind returns "ul" or "ol" if line is a list item, and "" if not.
+func listKind(line string) string {
+	switch {
+	case mdRule.MatchString(line):
+		return ""
+	case mdBullet.MatchString(line):
+		return "ul"
+	case mdOrdered.MatchString(line):
+		return "ol"
+	}
+	return ""
+}
+
+// nextLine returns the first non-blank line after lines[i].
+func nextLine(lines []string, i int) string {
+	for _, line := range lines[i+1:] {
+		if strings.TrimSpace(line) != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func writeRow(b *strings.Builder, cell, row string) {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	b.WriteString("<tr>")
+	for _, c := range strings.Split(row, "|") {
+		fmt.Fprintf(b, "<%s>%s</%s>", cell, inline(strings.TrimSpace(c)), cell)
+	}
+	b.WriteString("</tr>\n")
+}
+
+// inline converts the spans within a block. Links are kept only if they
+// point at the web, mail or a relative path.
+func inline(s string) string {
+	s = html.EscapeString(s)
+	// Code spans and links are set aside first so that their contents and
+	// targets are left alone.
+	var spans []string
+	setAside := func(span string) string {
+		spans = append(spans, span)
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	}
+	s = mdCode.ReplaceAllStringFunc(s, func(m string) string {
+		return setAside("<code>" + mdCode.FindStringSubmatch(m)[1] + "</code>")
+	})
+	s = mdLink.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdLink.FindStringSubmatch(m)
+		href := html.UnescapeString(sub[2])
+		if mdScheme.MatchString(href) && !hasPrefixFold(href, "http:", "https:", "mailto:") {
+			return emphasis(sub[1])
+		}
+		return setAside(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(href), emphasis(sub[1])))
+	})
+	s = emphasis(s)
+	// Later spans, such as links, may contain earlier ones.
+	for i := len(spans) - 1; i >= 0; i-- {
+		s = strings.Replace(s, fmt.Sprintf("\x00%d\x00", i), spans[i], 1)
+	}
+	return s
+}
+
+// emphasis converts bold and italic spans.
+func emphasis(s string) string {
+	s = mdBold.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdBold.FindStringSubmatch(m)
+		return "<strong>" + sub[1] + sub[2] + "</strong>"
+	})
+	return mdItalic.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdItalic.FindStringSubmatch(m)
+		return sub[1] + sub[3] + "<em>" + sub[2] + sub[4] + "</em>"
+	})
+}
+
+func hasPrefixFold(s string, prefixes ...string) bool {
+	for _, p := range prefixes {
+		if len(s) >= len(p) && strings.EqualFold(s[:len(p)], p) {
+			return true
+		}
+	}
+	return false
+}
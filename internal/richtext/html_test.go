@@ -0,0 +1,52 @@
+package richtext
+
+import "testing"
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name, md, want string
+	}{
+		{"paragraph", "Hello **bold** and *it*\nnext", "<p>Hello <strong>bold</strong> and <em>it</em>\nnext</p>\n"},
+		{"heading", "## Key *Findings*", "<h2>Key <em>Findings</em></h2>\n"},
+		{"escaped", "<script>x</script> & more", "<p>&lt;script&gt;x&lt;/script&gt; &amp; more</p>\n"},
+		{"code span", "run `a **b**`", "<p>run <code>a **b**</code></p>\n"},
+		{"link", "see [the `docs`](https://example.com/a_b_c)", `<p>see <a href="https://example.com/a_b_c">the <code>docs</code></a></p>` + "\n"},
+		{"unsafe link", "[click](javascript:void)", "<p>click</p>\n"},
+		{"bullets", "- one\n\n- two\n\nafter", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n<p>after</p>\n"},
+		{"ordered", "intro\n1. first\n2. second", "<p>intro</p>\n<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"quote", "> quoted\n> more", "<blockquote>\n<p>quoted\nmore</p>\n</blockquote>\n"},
+		{"fence", "```go\nx := <-c\n```", "<pre><code class=\"language-go\">x := &lt;-c</code></pre>\n"},
+		{"table", "| A | B |\n|---|:-:|\n| 1 | **2** |", "<table>\n<thead>\n<tr><th>A</th><th>B</th></tr>\n</thead>\n<tbody>\n<tr><td>1</td><td><strong>2</strong></td></tr>\n</tbody>\n</table>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.md); got != tt.want {
+				t.Errorf("ToHTML(%q) =\n%q\nwant\n%q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name, md string
+	}{
+		{"paragraphs", "First **bold** and *italic*.\n\nSecond with `code`.\n"},
+		{"headings", "# Study Guide\n\n## Key Terms\n\nBody\n"},
+		{"lists", "Terms:\n\n- One\n- Two\n\n1. First\n2. Second\n"},
+		{"link", "See [the site](https://example.com/a_b).\n"},
+		{"quote", "> Quoted text\n"},
+		{"rule", "Above\n\n---\n\nBelow\n"},
+		{"fence", "```go\nx := <-c\n```\n"},
+		{"table", "| Term | Meaning |\n|---|---|\n| **Go** | a language |\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := ToHTML(tt.md)
+			if got := ToMarkdown(html); got != tt.md {
+				t.Errorf("ToMarkdown(ToHTML(%q)) =\n%q\nvia\n%q", tt.md, got, html)
+			}
+		})
+	}
+}
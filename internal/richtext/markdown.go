@@ -0,0 +1,334 @@
+package richtext
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlTag matches the block and inline tags NotebookLM uses in rich-text
+// bodies. It is used to tell HTML bodies from Markdown ones.
+var htmlTag = regexp.MustCompile(`(?i)<(p|br|hr|h[1-6]|ul|ol|li|b|strong|i|em|code|pre|a|blockquote|div|span|table)[\s>/]`)
+
+// IsHTML reports whether a rich-text body is an HTML fragment rather than
+// Markdown.
+func IsHTML(body string) bool {
+	return htmlTag.MatchString(body)
+}
+
+// ToMarkdown converts a NotebookLM rich-text body to Markdown. Bodies are
+// delivered either as HTML fragments or as Markdown already; HTML is
+// converted and Markdown is only tidied.
+func ToMarkdown(body string) string {
+	if IsHTML(body) {
+		if md, err := htmlToMarkdown(body); err == nil {
+			return md
+		}
+	}
+	return tidyMarkdown(body)
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// tidyMarkdown normalizes line endings, strips trailing whitespace and
+// collapses runs of blank lines.
+func tidyMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLines.ReplaceAllString(s, "\n\n")
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	return s + "\n"
+}
+
+func htmlToMarkdown(s string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return "", fmt.Errorf("parse html: %w", err)
+	}
+	w := &markdownWriter{}
+	for _, n := range nodes {
+		w.node(n)
+	}
+	return tidyMarkdown(w.b.String()), nil
+}
+
+// markdownWriter renders an HTML tree as Markdown.
+type markdownWriter struct {
+	b      strings.Builder
+	lists  []listState
+	quote  int
+	inPre  bool
+	pendNL bool // a paragraph break is due before the next text
+	bol    bool // at the beginning of a line, after any markers
+}
+
+type listState struct {
+	ordered bool
+	n       int
+}
+
+// block ends the current paragraph.
+func (w *markdownWriter) block() {
+	w.pendNL = true
+}
+
+func (w *markdownWriter) write(s string) {
+	if w.pendNL {
+		if w.b.Len() > 0 && !w.bol {
+			w.paragraphBreak()
+		}
+		w.pendNL = false
+	}
+	w.b.WriteString(s)
+	w.bol = false
+}
+
+// paragraphBreak ends the current line and writes a blank line, keeping
+// any enclosing blockquotes open.
+func (w *markdownWriter) paragraphBreak() {
+	w.b.WriteString("\n")
+	w.b.WriteString(strings.TrimSpace(strings.Repeat("> ", w.quote)))
+	w.b.WriteString("\n")
+	w.writePrefix()
+}
+
+// writePrefix writes the blockquote markers for a new line.
+func (w *markdownWriter) writePrefix() {
+	for i := 0; i < w.quote; i++ {
+		w.b.WriteString("> ")
+	}
+	w.bol = true
+}
+
+func (w *markdownWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+func (w *markdownWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		w.children(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		w.block()
+		w.write(strings.Repeat("#", int(n.Data[1]-'0')) + " ")
+		w.children(n)
+		w.block()
+	case atom.P, atom.Div:
+		if len(w.lists) > 0 {
+			// Keep list items tight.
+			w.children(n)
+			return
+		}
+		w.block()
+		w.children(n)
+		w.block()
+	case atom.Br:
+		w.b.WriteString("\n")
+		w.writePrefix()
+	case atom.Hr:
+		w.block()
+		w.write("---")
+		w.block()
+	case atom.Strong, atom.B:
+		w.wrap(n, "**")
+	case atom.Em, atom.I:
+		w.wrap(n, "*")
+	case atom.Code:
+		if w.inPre {
+			w.children(n)
+			return
+		}
+		w.wrap(n, "`")
+	case atom.Pre:
+		w.block()
+		w.write("```" + codeLanguage(n) + "\n")
+		w.inPre = true
+		w.children(n)
+		w.inPre = false
+		if !strings.HasSuffix(w.b.String(), "\n") {
+			w.b.WriteString("\n")
+		}
+		w.b.WriteString("```")
+		w.block()
+	case atom.A:
+		href := attr(n, "href")
+		if href == "" {
+			w.children(n)
+			return
+		}
+		w.write("[")
+		w.children(n)
+		w.write("](" + href + ")")
+	case atom.Blockquote:
+		if w.b.Len() > 0 {
+			w.b.WriteString("\n" + strings.TrimSpace(strings.Repeat("> ", w.quote)) + "\n")
+		}
+		w.pendNL = false
+		w.quote++
+		w.writePrefix()
+		w.children(n)
+		w.quote--
+		w.block()
+	case atom.Ul, atom.Ol:
+		if len(w.lists) == 0 {
+			w.block()
+		}
+		w.lists = append(w.lists, listState{ordered: n.DataAtom == atom.Ol})
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		if len(w.lists) == 0 {
+			w.block()
+		}
+	case atom.Li:
+		w.item(n)
+	case atom.Table:
+		w.table(n)
+	case atom.Script, atom.Style:
+	default:
+		w.children(n)
+	}
+}
+
+func (w *markdownWriter) item(n *html.Node) {
+	if len(w.lists) == 0 {
+		w.lists = append(w.lists, listState{})
+		defer func() { w.lists = w.lists[:0] }()
+	}
+	l := &w.lists[len(w.lists)-1]
+	l.n++
+	marker := "- "
+	if l.ordered {
+		marker = fmt.Sprintf("%d. ", l.n)
+	}
+	switch {
+	case w.b.Len() == 0:
+		w.writePrefix()
+	case w.pendNL:
+		w.paragraphBreak()
+	default:
+		w.b.WriteString("\n")
+		w.writePrefix()
+	}
+	w.pendNL = false
+	w.b.WriteString(strings.Repeat("  ", len(w.lists)-1) + marker)
+	w.bol = true
+	w.children(n)
+}
+
+// table writes a table as a pipe table. The first row is its header, as
+// Markdown requires one.
+func (w *markdownWriter) table(n *html.Node) {
+	var rows [][]string
+	var visit func(*html.Node)
+	visit = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch c.DataAtom {
+			case atom.Tr:
+				var row []string
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.DataAtom == atom.Th || cell.DataAtom == atom.Td {
+						row = append(row, inlineMarkdown(cell))
+					}
+				}
+				rows = append(rows, row)
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				visit(c)
+			}
+		}
+	}
+	visit(n)
+	if len(rows) == 0 {
+		return
+	}
+	w.block()
+	for i, row := range rows {
+		if i > 0 {
+			w.b.WriteString("\n")
+			w.writePrefix()
+		}
+		w.write("| " + strings.Join(row, " | ") + " |")
+		if i == 0 {
+			w.b.WriteString("\n")
+			w.writePrefix()
+			w.write("|" + strings.Repeat("---|", len(row)))
+		}
+	}
+	w.block()
+}
+
+// inlineMarkdown renders the contents of n on one line, with pipes
+// escaped, for a table cell.
+func inlineMarkdown(n *html.Node) string {
+	w := &markdownWriter{}
+	w.children(n)
+	s := strings.Join(strings.Fields(w.b.String()), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// codeLanguage returns the language of a pre element's code, from a
+// "language-" class on it or its code child, or "".
+func codeLanguage(pre *html.Node) string {
+	for _, n := range []*html.Node{pre, pre.FirstChild} {
+		if n == nil || n.Type != html.ElementNode {
+			continue
+		}
+		for _, class := range strings.Fields(attr(n, "class")) {
+			if lang, ok := strings.CutPrefix(class, "language-"); ok {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+func (w *markdownWriter) wrap(n *html.Node, marker string) {
+	w.write(marker)
+	w.children(n)
+	w.write(marker)
+}
+
+var spaces = regexp.MustCompile(`\s+`)
+
+func (w *markdownWriter) text(s string) {
+	if w.inPre {
+		w.write(s)
+		return
+	}
+	s = spaces.ReplaceAllString(s, " ")
+	if w.pendNL || w.bol || w.b.Len() == 0 || strings.HasSuffix(w.b.String(), " ") {
+		// Leading whitespace in a block carries no meaning.
+		s = strings.TrimLeft(s, " ")
+	}
+	if s != "" {
+		w.write(s)
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
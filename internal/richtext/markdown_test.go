@@ -0,0 +1,64 @@
+package richtext
+
+import "testing"
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "markdown is tidied",
+			in:   "# Title  \r\n\r\n\r\n\r\nBody text\n\n",
+			want: "# Title\n\nBody text\n",
+		},
+		{
+			name: "empty",
+			in:   "  \n",
+			want: "",
+		},
+		{
+			name: "headings and paragraphs",
+			in:   "<h1>Study Guide</h1><p>First <b>bold</b> and <i>italic</i>.</p><h2>Quiz</h2><p>Second</p>",
+			want: "# Study Guide\n\nFirst **bold** and *italic*.\n\n## Quiz\n\nSecond\n",
+		},
+		{
+			name: "lists",
+			in:   "<p>Terms:</p><ul><li>One</li><li>Two<ol><li>Nested</li><li>Again</li></ol></li></ul><p>After</p>",
+			want: "Terms:\n\n- One\n- Two\n  1. Nested\n  2. Again\n\nAfter\n",
+		},
+		{
+			name: "list items with paragraphs stay tight",
+			in:   "<ol><li><p>First</p></li><li><p>Second</p></li></ol>",
+			want: "1. First\n2. Second\n",
+		},
+		{
+			name: "links code and breaks",
+			in:   `<p>See <a href="https://example.com">the site</a> and <code>nlm ls</code>.<br>Next line</p>`,
+			want: "See [the site](https://example.com) and `nlm ls`.\nNext line\n",
+		},
+		{
+			name: "preformatted",
+			in:   "<pre><code>line 1\n  line 2</code></pre>",
+			want: "```\nline 1\n  line 2\n```\n",
+		},
+		{
+			name: "blockquote",
+			in:   "<p>Quote:</p><blockquote><p>One</p><p>Two</p></blockquote>",
+			want: "Quote:\n\n> One\n>\n> Two\n",
+		},
+		{
+			name: "whitespace between blocks",
+			in:   "<p>\n  Hello\n  world\n</p>\n<p>Again</p>",
+			want: "Hello world\n\nAgain\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToMarkdown(tt.in); got != tt.want {
+				t.Errorf("ToMarkdown() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
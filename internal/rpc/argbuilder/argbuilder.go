@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -15,9 +16,11 @@ var (
 	fieldPattern = regexp.MustCompile(`%([a-z_]+)%`)
 )
 
-// ArgumentEncoder handles generic encoding of protobuf messages to RPC arguments
+// ArgumentEncoder handles generic encoding of protobuf messages to RPC arguments.
+// It is safe for concurrent use.
 type ArgumentEncoder struct {
 	// Cache of field accessors for performance
+	mu         sync.RWMutex
 	fieldCache map[string]map[string]protoreflect.FieldDescriptor
 }
 
@@ -181,26 +184,15 @@ func (e *ArgumentEncoder) buildArgs(msg protoreflect.Message, tokens []Token) ([
 // getFieldValue extracts a field value from a protobuf message
 func (e *ArgumentEncoder) getFieldValue(msg protoreflect.Message, fieldName string) (interface{}, error) {
 	descriptor := msg.Descriptor()
-
-	// Cache field descriptors for performance
 	msgName := string(descriptor.FullName())
-	if e.fieldCache[msgName] == nil {
-		e.fieldCache[msgName] = make(map[string]protoreflect.FieldDescriptor)
-		fields := descriptor.Fields()
-		for i := 0; i < fields.Len(); i++ {
-			field := fields.Get(i)
-			// Store by both JSON name and proto name
-			e.fieldCache[msgName][field.JSONName()] = field
-			e.fieldCache[msgName][string(field.Name())] = field
-		}
-	}
+	fields := e.fields(descriptor)
 
 	// Try exact match first (proto field name)
-	field, ok := e.fieldCache[msgName][fieldName]
+	field, ok := fields[fieldName]
 	if !ok {
 		// Try converting to camelCase for JSON name
 		camelName := snakeToCamel(fieldName)
-		field, ok = e.fieldCache[msgName][camelName]
+		field, ok = fields[camelName]
 		if !ok {
 			return nil, fmt.Errorf("field %s not found in %s", fieldName, msgName)
 		}
@@ -292,6 +284,29 @@ func (e *ArgumentEncoder) parseLiteral(s string) interface{} {
 	return strings.Trim(s, `"'`)
 }
 
+// fields returns the fields of the message type, by both JSON and proto
+// name, caching them for performance.
+func (e *ArgumentEncoder) fields(descriptor protoreflect.MessageDescriptor) map[string]protoreflect.FieldDescriptor {
+	msgName := string(descriptor.FullName())
+	e.mu.RLock()
+	cached := e.fieldCache[msgName]
+	e.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+	byName := make(map[string]protoreflect.FieldDescriptor)
+	fields := descriptor.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		byName[field.JSONName()] = field
+		byName[string(field.Name())] = field
+	}
+	e.mu.Lock()
+	e.fieldCache[msgName] = byName
+	e.mu.Unlock()
+	return byName
+}
+
 // snakeToCamel converts snake_case to camelCase
 func snakeToCamel(s string) string {
 	parts := strings.Split(s, "_")
@@ -2,12 +2,16 @@
 package grpcendpoint
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/redact"
 )
 
 // Client handles gRPC-style endpoint requests
@@ -15,16 +19,50 @@ type Client struct {
 	authToken  string
 	cookies    string
 	httpClient *http.Client
+	ctx        context.Context
 	debug      bool
+	onRequest  func(*http.Request, url.Values)
+}
+
+// Option configures a client made with NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sends requests with hc.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithContext makes requests with ctx, so that canceling it abandons
+// them and stops a stream.
+func WithContext(ctx context.Context) Option {
+	return func(c *Client) {
+		c.ctx = ctx
+	}
+}
+
+// WithRequestHook calls fn with each request and its form body before the
+// request is sent, so that the caller can log it, redacted, the way it
+// logs its other requests.
+func WithRequestHook(fn func(req *http.Request, form url.Values)) Option {
+	return func(c *Client) {
+		c.onRequest = fn
+	}
 }
 
 // NewClient creates a new gRPC endpoint client
-func NewClient(authToken, cookies string) *Client {
-	return &Client{
+func NewClient(authToken, cookies string, opts ...Option) *Client {
+	c := &Client{
 		authToken:  authToken,
 		cookies:    cookies,
 		httpClient: &http.Client{},
+		ctx:        context.Background(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Request represents a gRPC-style request
@@ -62,7 +100,7 @@ func (c *Client) Execute(req Request) ([]byte, error) {
 	formData.Set("at", c.authToken)
 
 	// Create the HTTP request
-	httpReq, err := http.NewRequest("POST", fullURL, strings.NewReader(formData.Encode()))
+	httpReq, err := http.NewRequestWithContext(c.ctx, "POST", fullURL, strings.NewReader(formData.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -78,8 +116,11 @@ func (c *Client) Execute(req Request) ([]byte, error) {
 
 	if c.debug {
 		fmt.Printf("=== gRPC Request ===\n")
-		fmt.Printf("URL: %s\n", fullURL)
-		fmt.Printf("Body: %s\n", formData.Encode())
+		fmt.Printf("URL: %s\n", redact.URL(fullURL))
+		fmt.Printf("Body: %s\n", redact.Form(formData))
+	}
+	if c.onRequest != nil {
+		c.onRequest(httpReq, formData)
 	}
 
 	// Send the request
@@ -108,7 +149,8 @@ func (c *Client) Execute(req Request) ([]byte, error) {
 	return body, nil
 }
 
-// StreamResponse handles streaming responses from gRPC endpoints
+// Stream sends req and calls handler with each frame of the streamed
+// response as it arrives, without the length lines between frames.
 func (c *Client) Stream(req Request, handler func(chunk []byte) error) error {
 	baseURL := "https://notebooklm.google.com/_/LabsTailwindUi/data"
 	fullURL := baseURL + req.Endpoint
@@ -135,7 +177,7 @@ func (c *Client) Stream(req Request, handler func(chunk []byte) error) error {
 	formData.Set("at", c.authToken)
 
 	// Create the HTTP request
-	httpReq, err := http.NewRequest("POST", fullURL, strings.NewReader(formData.Encode()))
+	httpReq, err := http.NewRequestWithContext(c.ctx, "POST", fullURL, strings.NewReader(formData.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -146,6 +188,9 @@ func (c *Client) Stream(req Request, handler func(chunk []byte) error) error {
 	httpReq.Header.Set("Origin", "https://notebooklm.google.com")
 	httpReq.Header.Set("Referer", "https://notebooklm.google.com/")
 	httpReq.Header.Set("X-Same-Domain", "1")
+	if c.onRequest != nil {
+		c.onRequest(httpReq, formData)
+	}
 
 	// Send the request
 	resp, err := c.httpClient.Do(httpReq)
@@ -159,21 +204,19 @@ func (c *Client) Stream(req Request, handler func(chunk []byte) error) error {
 		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Read the streaming response
-	buf := make([]byte, 4096)
+	// Read the streaming response a frame at a time
+	fr := batchexecute.NewFrameReader(resp.Body)
 	for {
-		n, err := resp.Body.Read(buf)
-		if n > 0 {
-			if err := handler(buf[:n]); err != nil {
-				return fmt.Errorf("handler error: %w", err)
-			}
-		}
+		frame, err := fr.Next()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
 			return fmt.Errorf("read error: %w", err)
 		}
+		if err := handler(frame); err != nil {
+			return fmt.Errorf("handler error: %w", err)
+		}
 	}
 
 	return nil
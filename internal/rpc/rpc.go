@@ -3,9 +3,14 @@ package rpc
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/davecgh/go-spew/spew"
+	pb "github.com/tmc/nlm/gen/notebooklm/v1alpha1"
 	"github.com/tmc/nlm/internal/batchexecute"
+	"github.com/tmc/nlm/internal/beprotojson"
+	"github.com/tmc/nlm/internal/redact"
 )
 
 // RPC endpoint IDs for NotebookLM services
@@ -27,6 +32,7 @@ const (
 	RPCCheckSourceFreshness = "yR9Yof" // CheckSourceFreshness
 	RPCActOnSources         = "yyryJe" // ActOnSources
 	RPCDiscoverSources      = "qXyaNe" // DiscoverSources
+	RPCRegisterFileSource   = "o4cbdc" // RegisterFileSource - before a resumable upload
 
 	// NotebookLM service - Note operations
 	RPCCreateNote  = "CYK0Xb" // CreateNote
@@ -133,6 +139,22 @@ func New(authToken, cookies string, options ...batchexecute.Option) *Client {
 	}
 }
 
+// Error is a failed RPC call. It names the RPC and, if the call was made
+// for a notebook, the notebook.
+type Error struct {
+	ID         string
+	NotebookID string
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("execute rpc %s: %v", e.ID, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
 // Do executes a NotebookLM RPC call
 func (c *Client) Do(call Call) (json.RawMessage, error) {
 	if c.Config.Debug {
@@ -164,12 +186,15 @@ func (c *Client) Do(call Call) (json.RawMessage, error) {
 
 	if c.Config.Debug {
 		fmt.Printf("\nRPC Request:\n")
-		spew.Dump(rpc)
+		shown := rpc
+		shown.Headers = redact.Headers(rpc.Headers)
+		shown.URLParams = redact.Params(rpc.URLParams)
+		spew.Dump(shown)
 	}
 
 	resp, err := c.client.Do(rpc)
 	if err != nil {
-		return nil, fmt.Errorf("execute rpc: %w", err)
+		return nil, &Error{ID: call.ID, NotebookID: call.NotebookID, Err: err}
 	}
 
 	if c.Config.Debug {
@@ -180,24 +205,31 @@ func (c *Client) Do(call Call) (json.RawMessage, error) {
 	return resp.Data, nil
 }
 
+// WriteCurl writes the curl command for a request built outside the
+// client, with the client's credentials and curl settings; see
+// batchexecute.Client.WriteCurl.
+func (c *Client) WriteCurl(req *http.Request, form url.Values) {
+	c.client.WriteCurl(req, form)
+}
+
 // Heartbeat sends a heartbeat to keep the session alive
 func (c *Client) Heartbeat() error {
 	return nil
 }
 
-// ListNotebooks returns all notebooks
-func (c *Client) ListNotebooks() (json.RawMessage, error) {
-	return c.Do(Call{
+// ListNotebooks returns the notebooks the user has viewed recently.
+func (c *Client) ListNotebooks() ([]*pb.Project, error) {
+	resp, err := c.Do(Call{
 		ID: RPCListRecentlyViewedProjects,
 	})
-}
-
-// CreateNotebook creates a new notebook with the given title
-func (c *Client) CreateNotebook(title string) (json.RawMessage, error) {
-	return nil, fmt.Errorf("not implemented")
-}
-
-// DeleteNotebook deletes a notebook by ID
-func (c *Client) DeleteNotebook(id string) error {
-	return fmt.Errorf("not implemented")
+	if err != nil {
+		return nil, err
+	}
+	// The response is the repeated projects field of
+	// ListRecentlyViewedProjectsResponse on its own.
+	var out pb.ListRecentlyViewedProjectsResponse
+	if err := beprotojson.Unmarshal([]byte("["+string(resp)+"]"), &out); err != nil {
+		return nil, fmt.Errorf("list notebooks: %w", err)
+	}
+	return out.Projects, nil
 }
@@ -0,0 +1,177 @@
+// Package site renders a notebook as a static HTML site, so that research
+// can be shared with people who have no access to NotebookLM.
+package site
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/tmc/nlm/internal/richtext"
+)
+
+// Site is what is published about a notebook.
+type Site struct {
+	NotebookID string
+	Title      string
+	Emoji      string
+	Generated  time.Time
+	Sources    []Source
+	Notes      []Note
+	Artifacts  []Artifact
+	QA         []QA // questions asked of the notebook, oldest first
+}
+
+// Source is a source of the notebook.
+type Source struct {
+	ID        string
+	Title     string
+	Type      string
+	URL       string // where the source can be found, if public
+	UpdatedAt time.Time
+}
+
+// Note is a note in the notebook.
+type Note struct {
+	ID        string
+	Title     string
+	UpdatedAt time.Time
+}
+
+// Artifact is a generated artifact. Artifacts with Markdown get a page of
+// their own.
+type Artifact struct {
+	ID        string
+	Title     string
+	Type      string
+	UpdatedAt time.Time
+	Markdown  string
+}
+
+// Page returns the path of the artifact's page relative to the site root,
+// or "" if it has none.
+func (a Artifact) Page() string {
+	if a.Markdown == "" {
+		return ""
+	}
+	return "artifacts/" + fileName(a.ID) + ".html"
+}
+
+// QA is a question asked of the notebook and its answer.
+type QA struct {
+	Question string
+	Answer   string
+	Time     time.Time
+}
+
+// page is the data each template is executed with.
+type page struct {
+	*Site
+	Root     string // the relative path from the page to the site root
+	Artifact *Artifact
+}
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+// Templates are the templates Write executes. base.html defines the "head"
+// and "foot" templates the pages share.
+var Templates = []string{"base.html", "index.html", "artifact.html", "qa.html", "style.css"}
+
+// Write renders s into dir: index.html, a page per artifact with content
+// under artifacts/, qa.html if s has questions, and style.css. Files named
+// like one of the Templates in templateDir, if it is not empty, replace the
+// default template of that name.
+func Write(dir string, s *Site, templateDir string) error {
+	tmpl, css, err := parse(templateDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "artifacts"), 0755); err != nil {
+		return fmt.Errorf("create site: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "style.css"), css, 0644); err != nil {
+		return fmt.Errorf("write style.css: %w", err)
+	}
+	if err := execute(tmpl, filepath.Join(dir, "index.html"), "index.html", page{Site: s}); err != nil {
+		return err
+	}
+	for i := range s.Artifacts {
+		a := &s.Artifacts[i]
+		if a.Page() == "" {
+			continue
+		}
+		if err := execute(tmpl, filepath.Join(dir, filepath.FromSlash(a.Page())), "artifact.html", page{Site: s, Root: "../", Artifact: a}); err != nil {
+			return err
+		}
+	}
+	if len(s.QA) > 0 {
+		return execute(tmpl, filepath.Join(dir, "qa.html"), "qa.html", page{Site: s})
+	}
+	return nil
+}
+
+// parse reads the default templates and the replacements in templateDir.
+// The stylesheet is copied as is.
+func parse(templateDir string) (*template.Template, []byte, error) {
+	read := func(name string) ([]byte, error) {
+		if templateDir != "" {
+			data, err := os.ReadFile(filepath.Join(templateDir, name))
+			if err == nil || !os.IsNotExist(err) {
+				return data, err
+			}
+		}
+		return fs.ReadFile(defaultTemplates, "templates/"+name)
+	}
+	if templateDir != "" {
+		if _, err := os.Stat(templateDir); err != nil {
+			return nil, nil, fmt.Errorf("read templates: %w", err)
+		}
+	}
+	tmpl := template.New("site").Funcs(template.FuncMap{
+		"markdown": func(md string) template.HTML { return template.HTML(richtext.ToHTML(md)) },
+		"date":     func(t time.Time) string { return t.Local().Format("2 Jan 2006") },
+	})
+	var css []byte
+	for _, name := range Templates {
+		data, err := read(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read template %s: %w", name, err)
+		}
+		if name == "style.css" {
+			css = data
+			continue
+		}
+		if _, err := tmpl.New(name).Parse(string(data)); err != nil {
+			return nil, nil, fmt.Errorf("parse template %s: %w", name, err)
+		}
+	}
+	return tmpl, css, nil
+}
+
+func execute(tmpl *template.Template, path, name string, data page) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	if err := tmpl.ExecuteTemplate(f, name, data); err != nil {
+		f.Close()
+		return fmt.Errorf("render %s: %w", name, err)
+	}
+	return f.Close()
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// fileName makes an ID safe to use as a file name.
+func fileName(id string) string {
+	if s := unsafeFileChars.ReplaceAllString(id, "_"); s != "" {
+		return s
+	}
+	return "_"
+}
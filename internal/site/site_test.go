@@ -0,0 +1,103 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSite() *Site {
+	when := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &Site{
+		NotebookID: "nb1",
+		Title:      "Research <Notes>",
+		Emoji:      "📚",
+		Generated:  when,
+		Sources: []Source{
+			{ID: "s1", Title: "Talk", Type: "youtube", URL: "https://youtube.com/watch?v=x", UpdatedAt: when},
+			{ID: "s2", Title: "Paper", Type: "pdf"},
+		},
+		Notes: []Note{{ID: "n1", Title: "My note", UpdatedAt: when}},
+		Artifacts: []Artifact{
+			{ID: "a/1", Title: "Briefing", Type: "report", Markdown: "# Briefing\n\nAll **good**."},
+			{ID: "a2", Title: "Overview", Type: "audio_overview"},
+		},
+		QA: []QA{{Question: "Why?", Answer: "Because *reasons*."}},
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	if err := Write(dir, testSite(), ""); err != nil {
+		t.Fatal(err)
+	}
+	index := readFile(t, filepath.Join(dir, "index.html"))
+	for _, want := range []string{
+		"<title>Research &lt;Notes&gt;</title>",
+		`<a href="artifacts/a_1.html">Briefing</a>`,
+		"<li>Overview <span",
+		`<a href="qa.html">1 questions and answers</a>`,
+		"<li>My note",
+		`<a href="https://youtube.com/watch?v=x">Talk</a>`,
+		"<td>Paper</td><td>pdf</td>",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.html missing %q:\n%s", want, index)
+		}
+	}
+	artifact := readFile(t, filepath.Join(dir, "artifacts", "a_1.html"))
+	for _, want := range []string{`href="../style.css"`, "<h1>Briefing</h1>", "All <strong>good</strong>."} {
+		if !strings.Contains(artifact, want) {
+			t.Errorf("artifact page missing %q:\n%s", want, artifact)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "artifacts", "a2.html")); !os.IsNotExist(err) {
+		t.Errorf("artifact without content got a page: %v", err)
+	}
+	if qa := readFile(t, filepath.Join(dir, "qa.html")); !strings.Contains(qa, "Because <em>reasons</em>.") {
+		t.Errorf("qa.html missing the answer:\n%s", qa)
+	}
+	if css := readFile(t, filepath.Join(dir, "style.css")); !strings.Contains(css, "body") {
+		t.Errorf("style.css = %q", css)
+	}
+}
+
+func TestWriteCustomTemplates(t *testing.T) {
+	tmpl := t.TempDir()
+	os.WriteFile(filepath.Join(tmpl, "index.html"), []byte(`{{template "head" .}}custom {{len .Sources}}{{template "foot" .}}`), 0644)
+	os.WriteFile(filepath.Join(tmpl, "style.css"), []byte("body{}"), 0644)
+	dir := t.TempDir()
+	s := testSite()
+	s.QA = nil
+	if err := Write(dir, s, tmpl); err != nil {
+		t.Fatal(err)
+	}
+	if index := readFile(t, filepath.Join(dir, "index.html")); !strings.Contains(index, "custom 2") || !strings.Contains(index, "<footer>") {
+		t.Errorf("index.html = %s", index)
+	}
+	if css := readFile(t, filepath.Join(dir, "style.css")); css != "body{}" {
+		t.Errorf("style.css = %q", css)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "qa.html")); !os.IsNotExist(err) {
+		t.Errorf("qa.html written without questions: %v", err)
+	}
+
+	os.WriteFile(filepath.Join(tmpl, "qa.html"), []byte("{{.Missing"), 0644)
+	if err := Write(dir, s, tmpl); err == nil || !strings.Contains(err.Error(), "qa.html") {
+		t.Errorf("Write with a broken template: err = %v", err)
+	}
+	if err := Write(dir, s, filepath.Join(tmpl, "nope")); err == nil {
+		t.Error("Write with a missing template directory succeeded")
+	}
+}
@@ -0,0 +1,176 @@
+//go:build integration
+
+// Package smoke checks, against a real account, that the NotebookLM
+// protocol still works: it creates a throwaway notebook, adds a source,
+// asks about it, writes a note, generates an artifact and deletes them
+// all again. It is built only with the integration tag:
+//
+//	NLM_AUTH_TOKEN=... NLM_COOKIES=... go test -tags=integration -v -timeout 15m ./internal/smoke
+//
+// The credentials are those `nlm auth` stores in ~/.nlm/env. With -short
+// the slow artifact step is skipped.
+package smoke
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmc/nlm/internal/api"
+)
+
+// sourceText is the source the notebook is made from. Its one fact gives
+// the question an answer that must come from it.
+const sourceText = `The lighthouse on Brannoch Point was painted periwinkle blue in 1931,
+after a storm stripped its old paint. The keeper, Ada Morrow, chose the colour
+because it could be seen from the harbour in fog.`
+
+func TestSmoke(t *testing.T) {
+	if os.Getenv("NLM_AUTH_TOKEN") == "" || os.Getenv("NLM_COOKIES") == "" {
+		t.Skip("NLM_AUTH_TOKEN and NLM_COOKIES are not set")
+	}
+	ctx := context.Background()
+	c, err := api.New(ctx, api.WithRetryPolicy(api.RetryPolicy{MaxRetries: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title := "nlm smoke test " + time.Now().Format(time.DateTime)
+	nb, err := c.CreateProject(title, "🧪")
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	nbID := nb.GetProjectId()
+	t.Logf("created notebook %s (%q)", nbID, title)
+	t.Cleanup(func() {
+		if err := c.DeleteProjects([]string{nbID}); err != nil {
+			t.Errorf("DeleteProjects: %v; delete notebook %s by hand", err, nbID)
+		}
+	})
+
+	var sourceID string
+	step(t, "add source", func(t *testing.T) {
+		sourceID, err = c.AddSourceFromText(nbID, sourceText, "Brannoch Point")
+		if err != nil {
+			t.Fatalf("AddSourceFromText: %v", err)
+		}
+		text := waitFor(t, 2*time.Minute, func() (string, error) { return c.GetSourceText(sourceID) })
+		if !strings.Contains(text, "periwinkle") {
+			t.Errorf("GetSourceText() = %q, want the source's text", text)
+		}
+		p, err := c.GetProject(nbID)
+		if err != nil {
+			t.Fatalf("GetProject: %v", err)
+		}
+		if n := len(p.GetSources()); n != 1 {
+			t.Errorf("notebook has %d sources, want 1", n)
+		}
+	})
+
+	step(t, "ask", func(t *testing.T) {
+		a, err := c.Ask(nbID, "What colour was the lighthouse on Brannoch Point painted?", []string{sourceID}, nil)
+		if err != nil {
+			t.Fatalf("Ask: %v", err)
+		}
+		if !a.Final || a.Text == "" {
+			t.Fatalf("Ask() = %+v, want a final answer", a)
+		}
+		if !strings.Contains(strings.ToLower(a.Text), "periwinkle") {
+			t.Errorf("answer does not mention the source's fact: %q", a.Text)
+		}
+		t.Logf("answer has %d citations", len(a.Citations))
+	})
+
+	step(t, "note", func(t *testing.T) {
+		note, err := c.CreateNote(nbID, "Smoke note", "Written by the smoke test.")
+		if err != nil {
+			t.Fatalf("CreateNote: %v", err)
+		}
+		noteID := note.GetSourceId().GetSourceId()
+		notes, err := c.GetNotes(nbID)
+		if err != nil {
+			t.Fatalf("GetNotes: %v", err)
+		}
+		if !hasNote(notes, noteID) {
+			t.Errorf("GetNotes() does not list note %s", noteID)
+		}
+		if err := c.DeleteNotes(nbID, []string{noteID}); err != nil {
+			t.Fatalf("DeleteNotes: %v", err)
+		}
+	})
+
+	step(t, "artifact", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("generating an artifact is slow")
+		}
+		a, err := c.CreateArtifact(nbID, api.ArtifactStudyGuide, nil)
+		if err != nil {
+			t.Fatalf("CreateArtifact: %v", err)
+		}
+		wctx, cancel := context.WithTimeout(ctx, 8*time.Minute)
+		defer cancel()
+		if _, err := c.WaitForArtifact(wctx, nbID, a.ID, nil); err != nil {
+			t.Fatalf("WaitForArtifact: %v", err)
+		}
+		content, err := c.GetArtifactContent(nbID, a.ID)
+		if err != nil {
+			t.Fatalf("GetArtifactContent: %v", err)
+		}
+		if strings.TrimSpace(content.Markdown) == "" {
+			t.Error("study guide is empty")
+		}
+		if err := c.DeleteArtifact(a.ID); err != nil {
+			t.Fatalf("DeleteArtifact: %v", err)
+		}
+	})
+
+	step(t, "delete source", func(t *testing.T) {
+		if err := c.DeleteSources(nbID, []string{sourceID}); err != nil {
+			t.Fatalf("DeleteSources: %v", err)
+		}
+		p, err := c.GetProject(nbID)
+		if err != nil {
+			t.Fatalf("GetProject: %v", err)
+		}
+		if n := len(p.GetSources()); n != 0 {
+			t.Errorf("notebook has %d sources after deleting, want 0", n)
+		}
+	})
+}
+
+// step runs f as a subtest and stops the test if it fails, since each
+// step needs the ones before it.
+func step(t *testing.T, name string, f func(t *testing.T)) {
+	t.Helper()
+	if !t.Run(name, f) {
+		t.FailNow()
+	}
+}
+
+// waitFor calls f until it returns a non-empty result, as sources take a
+// while to be processed after they are added.
+func waitFor(t *testing.T, timeout time.Duration, f func() (string, error)) string {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		s, err := f()
+		if err == nil && s != "" {
+			return s
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("not ready after %v (last error: %v)", timeout, err)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func hasNote(notes []*api.Note, id string) bool {
+	for _, n := range notes {
+		if n.GetSourceId().GetSourceId() == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// Credentials in a bundle are sealed with AES-256-GCM under a key derived
+// from a passphrase with PBKDF2-SHA256. A sealed blob is sealMagic, the
+// salt, the nonce and the ciphertext.
+
+const (
+	sealMagic      = "nlm-sealed-v1\n"
+	sealIterations = 600000
+	saltSize       = 16
+)
+
+// ErrPassphrase is returned by Unseal when the passphrase is wrong or the
+// data was altered.
+var ErrPassphrase = errors.New("wrong passphrase or corrupted credentials")
+
+// Seal encrypts data with a passphrase.
+func Seal(passphrase string, data []byte) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("empty passphrase")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte(sealMagic), salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, []byte(sealMagic)), nil
+}
+
+// Unseal decrypts data sealed by Seal.
+func Unseal(passphrase string, sealed []byte) ([]byte, error) {
+	rest, ok := bytes.CutPrefix(sealed, []byte(sealMagic))
+	if !ok || len(rest) < saltSize {
+		return nil, fmt.Errorf("not sealed credentials")
+	}
+	salt, rest := rest[:saltSize], rest[saltSize:]
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("not sealed credentials")
+	}
+	nonce, ciphertext := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, ciphertext, []byte(sealMagic))
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	return data, nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, sealIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
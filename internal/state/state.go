@@ -0,0 +1,231 @@
+// Package state bundles nlm's local state into one archive, so that a
+// setup can be moved to another machine.
+//
+// A bundle is a gzipped tar archive. Its first entry, manifest.json,
+// describes the bundle; the other entries are files named by
+// slash-separated paths that the caller maps to and from local paths.
+package state
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version is the bundle format written by Write.
+const Version = 1
+
+const manifestName = "manifest.json"
+
+// Manifest describes a bundle.
+type Manifest struct {
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	Host        string    `json:"host,omitempty"`
+	Files       []string  `json:"files"`
+	Credentials bool      `json:"credentials"` // whether the bundle holds encrypted credentials
+}
+
+// Entry is a file to put in a bundle: either the file or directory at
+// Path, or Data. A directory is added with everything under it, its files
+// named below Name. Entries whose Path does not exist are left out.
+type Entry struct {
+	Name string // slash-separated path in the bundle
+	Path string
+	Data []byte
+}
+
+// file is a regular file found for an entry.
+type file struct {
+	name string
+	path string
+	data []byte
+	mode fs.FileMode
+}
+
+// Write writes a bundle of entries to w and returns its manifest.
+// m.Files is filled in with the files written.
+func Write(w io.Writer, m Manifest, entries []Entry) (*Manifest, error) {
+	var files []file
+	for _, e := range entries {
+		if err := checkName(e.Name); err != nil {
+			return nil, err
+		}
+		if e.Data != nil {
+			files = append(files, file{name: e.Name, data: e.Data, mode: 0600})
+			continue
+		}
+		found, err := walk(e)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, found...)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+
+	m.Version = Version
+	m.Files = make([]string, len(files))
+	for i, f := range files {
+		m.Files[i] = f.name
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode manifest: %w", err)
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	if err := writeFile(tw, file{name: manifestName, data: manifest, mode: 0600}); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if err := writeFile(tw, f); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("write bundle: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("write bundle: %w", err)
+	}
+	return &m, nil
+}
+
+// walk lists the regular files at an entry's path.
+func walk(e Entry) ([]file, error) {
+	var files []file
+	err := filepath.WalkDir(e.Path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == e.Path {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(p, ".lock") || strings.HasSuffix(p, ".tmp") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(e.Path, p)
+		if err != nil {
+			return err
+		}
+		name := e.Name
+		if rel != "." {
+			name = path.Join(e.Name, filepath.ToSlash(rel))
+		}
+		files = append(files, file{name: name, path: p, mode: info.Mode().Perm()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", e.Path, err)
+	}
+	return files, nil
+}
+
+func writeFile(tw *tar.Writer, f file) error {
+	if f.path != "" {
+		// Read the file whole so that the header's size matches what is
+		// written even if the file changes meanwhile.
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", f.path, err)
+		}
+		f.data = data
+	}
+	hdr := &tar.Header{
+		Name:    f.name,
+		Mode:    int64(f.mode),
+		Size:    int64(len(f.data)),
+		ModTime: time.Now(),
+		Format:  tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	if _, err := tw.Write(f.data); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return nil
+}
+
+// ReadManifest reads the manifest of the bundle in r. It returns an error
+// if r is not a bundle or is of a newer format.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not an nlm state bundle: %w", err)
+	}
+	defer zr.Close()
+	return readManifest(tar.NewReader(zr))
+}
+
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return nil, fmt.Errorf("not an nlm state bundle: no manifest")
+	}
+	var m Manifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	if m.Version > Version {
+		return nil, fmt.Errorf("bundle format %d is newer than this nlm supports (%d); update nlm", m.Version, Version)
+	}
+	return &m, nil
+}
+
+// Read reads the bundle in r, calling fn for each of its files in turn,
+// and returns its manifest.
+func Read(r io.Reader, fn func(name string, mode fs.FileMode, r io.Reader) error) (*Manifest, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not an nlm state bundle: %w", err)
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+	m, err := readManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := checkName(hdr.Name); err != nil {
+			return nil, err
+		}
+		if err := fn(hdr.Name, fs.FileMode(hdr.Mode).Perm(), tr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// checkName rejects bundle paths that could escape the directory they are
+// extracted to.
+func checkName(name string) error {
+	if name == "" || name == manifestName || !fs.ValidPath(name) {
+		return fmt.Errorf("invalid bundle path %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteRead(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		t.Helper()
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("jobs.json", "[]")
+	write("cache/notebooks/nb1", "cached")
+	write("cache/notebooks/nb1.lock", "")
+
+	entries := []Entry{
+		{Name: "config.yaml", Data: []byte("profile: work\n")},
+		{Name: "nlm/jobs.json", Path: filepath.Join(dir, "jobs.json")},
+		{Name: "nlm/cache", Path: filepath.Join(dir, "cache")},
+		{Name: "nlm/missing.json", Path: filepath.Join(dir, "missing.json")},
+	}
+	var buf bytes.Buffer
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m, err := Write(&buf, Manifest{CreatedAt: created, Credentials: true}, entries)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	wantFiles := []string{"config.yaml", "nlm/cache/notebooks/nb1", "nlm/jobs.json"}
+	if !reflect.DeepEqual(m.Files, wantFiles) {
+		t.Errorf("Write() files = %v, want %v", m.Files, wantFiles)
+	}
+
+	head, err := ReadManifest(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+	if head.Version != Version || !head.CreatedAt.Equal(created) || !head.Credentials {
+		t.Errorf("ReadManifest() = %+v", head)
+	}
+
+	got := make(map[string]string)
+	_, err = Read(bytes.NewReader(buf.Bytes()), func(name string, mode fs.FileMode, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		got[name] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := map[string]string{
+		"config.yaml":             "profile: work\n",
+		"nlm/jobs.json":           "[]",
+		"nlm/cache/notebooks/nb1": "cached",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() files = %v, want %v", got, want)
+	}
+}
+
+func TestWriteRejectsBadNames(t *testing.T) {
+	for _, name := range []string{"", "../etc/passwd", "/abs", "manifest.json"} {
+		_, err := Write(io.Discard, Manifest{}, []Entry{{Name: name, Data: []byte("x")}})
+		if err == nil {
+			t.Errorf("Write() with entry %q: want error", name)
+		}
+	}
+}
+
+func TestReadNotABundle(t *testing.T) {
+	if _, err := ReadManifest(bytes.NewReader([]byte("profile: work\n"))); err == nil {
+		t.Error("ReadManifest() of a YAML file: want error")
+	}
+}
+
+func TestSeal(t *testing.T) {
+	sealed, err := Seal("correct horse", []byte("secret"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if bytes.Contains(sealed, []byte("secret")) {
+		t.Error("Seal() output contains the plaintext")
+	}
+	got, err := Unseal("correct horse", sealed)
+	if err != nil || string(got) != "secret" {
+		t.Errorf("Unseal() = %q, %v; want secret", got, err)
+	}
+	if _, err := Unseal("wrong", sealed); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("Unseal() with the wrong passphrase error = %v, want %v", err, ErrPassphrase)
+	}
+	if _, err := Seal("", []byte("secret")); err == nil {
+		t.Error("Seal() with an empty passphrase: want error")
+	}
+}
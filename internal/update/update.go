@@ -0,0 +1,299 @@
+// Package update finds, verifies and installs nlm releases published on
+// GitHub.
+//
+// Releases carry one archive per platform, named
+// nlm_<version>_<os>_<arch>.tar.gz (.zip on Windows), and a checksums.txt
+// listing the SHA-256 of every archive. When the release also carries
+// checksums.txt.sig, an Ed25519 signature of checksums.txt, and a public
+// key is known, the signature is checked before the checksums are trusted.
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// DefaultRepo is the GitHub repository releases are taken from.
+const DefaultRepo = "tmc/nlm"
+
+// Names of the release assets that describe the archives.
+const (
+	ChecksumsAsset = "checksums.txt"
+	SignatureAsset = "checksums.txt.sig"
+)
+
+// ErrNoSignature is returned by Verify when a public key is set but the
+// release is not signed.
+var ErrNoSignature = errors.New("release is not signed")
+
+// Release is a published GitHub release.
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Asset returns the release's asset with the given name.
+func (r *Release) Asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Updater talks to the GitHub releases API.
+type Updater struct {
+	Repo       string // owner/name; DefaultRepo if empty
+	APIURL     string // API base URL; https://api.github.com if empty
+	HTTPClient *http.Client
+	PublicKey  ed25519.PublicKey // verifies checksums.txt.sig; nil skips the check
+}
+
+// Latest returns the newest non-prerelease release.
+func (u *Updater) Latest() (*Release, error) {
+	return u.release("latest")
+}
+
+// Tagged returns the release with the given tag, such as "v0.4.0".
+func (u *Updater) Tagged(tag string) (*Release, error) {
+	return u.release("tags/" + tag)
+}
+
+func (u *Updater) release(which string) (*Release, error) {
+	repo := u.Repo
+	if repo == "" {
+		repo = DefaultRepo
+	}
+	api := strings.TrimSuffix(u.APIURL, "/")
+	if api == "" {
+		api = "https://api.github.com"
+	}
+	data, err := u.get(api + "/repos/" + repo + "/releases/" + which)
+	if err != nil {
+		return nil, fmt.Errorf("find release: %w", err)
+	}
+	var r Release
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("find release: decode response: %w", err)
+	}
+	if r.Tag == "" {
+		return nil, fmt.Errorf("find release: response has no tag")
+	}
+	return &r, nil
+}
+
+// Download fetches a release asset.
+func (u *Updater) Download(a Asset) ([]byte, error) {
+	data, err := u.get(a.URL)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", a.Name, err)
+	}
+	return data, nil
+}
+
+func (u *Updater) get(url string) ([]byte, error) {
+	client := u.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json, application/octet-stream")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Verify checks archive against the release's checksums and, when the
+// updater has a public key, the checksums against their signature.
+func (u *Updater) Verify(r *Release, name string, archive []byte) error {
+	a, ok := r.Asset(ChecksumsAsset)
+	if !ok {
+		return fmt.Errorf("release %s has no %s", r.Tag, ChecksumsAsset)
+	}
+	sums, err := u.Download(a)
+	if err != nil {
+		return err
+	}
+	if u.PublicKey != nil {
+		a, ok := r.Asset(SignatureAsset)
+		if !ok {
+			return fmt.Errorf("%s: %w", r.Tag, ErrNoSignature)
+		}
+		sig, err := u.Download(a)
+		if err != nil {
+			return err
+		}
+		if err := VerifySignature(u.PublicKey, sums, sig); err != nil {
+			return err
+		}
+	}
+	return VerifyChecksum(sums, name, archive)
+}
+
+// VerifySignature checks an Ed25519 signature of data. The signature may
+// be raw or base64-encoded.
+func VerifySignature(pub ed25519.PublicKey, data, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("bad signature encoding: %w", err)
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(pub, data, sig) {
+		return fmt.Errorf("signature of %s does not match the release key", ChecksumsAsset)
+	}
+	return nil
+}
+
+// VerifyChecksum checks data against its entry in a checksums file in the
+// format written by sha256sum.
+func VerifyChecksum(sums []byte, name string, data []byte) error {
+	for _, line := range strings.Split(string(sums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum of %s does not match %s", name, ChecksumsAsset)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s has no entry for %s", ChecksumsAsset, name)
+}
+
+// ArchiveName returns the name of the release archive for a platform.
+func ArchiveName(tag, goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("nlm_%s_%s_%s%s", strings.TrimPrefix(tag, "v"), goos, goarch, ext)
+}
+
+// Newer reports whether release tag latest is newer than version current.
+// Versions that are not semantic versions, such as development builds,
+// are always older.
+func Newer(current, latest string) bool {
+	if !semver.IsValid(current) {
+		return semver.IsValid(latest)
+	}
+	return semver.Compare(latest, current) > 0
+}
+
+// Extract returns the nlm binary from a release archive.
+func Extract(name string, archive []byte) ([]byte, error) {
+	binary := "nlm"
+	if strings.HasSuffix(name, ".zip") {
+		binary = "nlm.exe"
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", name, err)
+		}
+		for _, f := range zr.File {
+			if path.Base(f.Name) != binary {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("extract %s: %w", binary, err)
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+		return nil, fmt.Errorf("%s does not contain %s", name, binary)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s does not contain %s", name, binary)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", name, err)
+		}
+		if h.Typeflag == tar.TypeReg && path.Base(h.Name) == binary {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// Replace installs binary as the executable at exe. The new file is
+// written next to the old one and renamed over it, so a failed update
+// leaves the old binary in place. Windows cannot replace a running
+// executable, so there the old one is first moved aside to exe + ".old".
+func Replace(exe string, binary []byte) error {
+	fi, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".nlm-update-*")
+	if err != nil {
+		return fmt.Errorf("replace %s: %w", exe, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("replace %s: %w", exe, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("replace %s: %w", exe, err)
+	}
+	if err := os.Chmod(tmp.Name(), fi.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("replace %s: %w", exe, err)
+	}
+	old := exe + ".old"
+	if runtime.GOOS == "windows" {
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return fmt.Errorf("replace %s: %w", exe, err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		if runtime.GOOS == "windows" {
+			os.Rename(old, exe)
+		}
+		return fmt.Errorf("replace %s: %w", exe, err)
+	}
+	return nil
+}
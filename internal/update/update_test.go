@@ -0,0 +1,172 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(body))
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+// releaseServer serves a release with the given assets from a fake
+// GitHub API.
+func releaseServer(t *testing.T, tag string, assets map[string][]byte) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	r := Release{Tag: tag}
+	for name, data := range assets {
+		r.Assets = append(r.Assets, Asset{Name: name, URL: srv.URL + "/download/" + name})
+		mux.HandleFunc("/download/"+name, func(w http.ResponseWriter, _ *http.Request) { w.Write(data) })
+	}
+	mux.HandleFunc("/repos/tmc/nlm/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(r)
+	})
+	return srv
+}
+
+func TestUpdate(t *testing.T) {
+	name := ArchiveName("v1.2.0", "linux", "amd64")
+	if name != "nlm_1.2.0_linux_amd64.tar.gz" {
+		t.Fatalf("ArchiveName() = %q", name)
+	}
+	archive := tarGz(t, map[string]string{"README.md": "docs", "nlm": "new binary"})
+	sum := sha256.Sum256(archive)
+	sums := []byte(fmt.Sprintf("%s  %s\n%s  other.zip\n", hex.EncodeToString(sum[:]), name, strings.Repeat("0", 64)))
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, sums)))
+
+	srv := releaseServer(t, "v1.2.0", map[string][]byte{name: archive, ChecksumsAsset: sums, SignatureAsset: sig})
+	u := &Updater{APIURL: srv.URL, PublicKey: pub}
+	r, err := u.Latest()
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if r.Tag != "v1.2.0" {
+		t.Errorf("Latest() tag = %q, want v1.2.0", r.Tag)
+	}
+	a, ok := r.Asset(name)
+	if !ok {
+		t.Fatalf("release has no asset %s", name)
+	}
+	data, err := u.Download(a)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if err := u.Verify(r, name, data); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if err := u.Verify(r, name, append(data, 0)); err == nil {
+		t.Errorf("Verify() of a tampered archive succeeded")
+	}
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	if err := (&Updater{APIURL: srv.URL, PublicKey: otherPub}).Verify(r, name, data); err == nil {
+		t.Errorf("Verify() with the wrong key succeeded")
+	}
+
+	binary, err := Extract(name, data)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	exe := filepath.Join(t.TempDir(), "nlm")
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replace(exe, binary); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	got, err := os.ReadFile(exe)
+	if err != nil || string(got) != "new binary" {
+		t.Errorf("binary after Replace() = %q, %v; want %q", got, err, "new binary")
+	}
+}
+
+func TestVerifyUnsigned(t *testing.T) {
+	name := ArchiveName("v1.2.0", "darwin", "arm64")
+	archive := tarGz(t, map[string]string{"nlm": "x"})
+	sum := sha256.Sum256(archive)
+	sums := []byte(hex.EncodeToString(sum[:]) + " *" + name + "\n")
+	srv := releaseServer(t, "v1.2.0", map[string][]byte{name: archive, ChecksumsAsset: sums})
+	u := &Updater{APIURL: srv.URL}
+	r, err := u.Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := u.Verify(r, name, archive); err != nil {
+		t.Errorf("Verify() without a key error = %v", err)
+	}
+	pub, _, _ := ed25519.GenerateKey(nil)
+	u.PublicKey = pub
+	if err := u.Verify(r, name, archive); !errors.Is(err, ErrNoSignature) {
+		t.Errorf("Verify() of an unsigned release error = %v, want ErrNoSignature", err)
+	}
+	if err := VerifyChecksum(sums, "nlm_1.2.0_linux_amd64.tar.gz", archive); err == nil {
+		t.Errorf("VerifyChecksum() of a missing entry succeeded")
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("nlm_1.2.0_windows_amd64/nlm.exe")
+	w.Write([]byte("windows binary"))
+	zw.Close()
+	got, err := Extract("nlm_1.2.0_windows_amd64.zip", buf.Bytes())
+	if err != nil || string(got) != "windows binary" {
+		t.Errorf("Extract() = %q, %v", got, err)
+	}
+	if _, err := Extract("nlm.tar.gz", tarGz(t, map[string]string{"README.md": "docs"})); err == nil {
+		t.Errorf("Extract() of an archive without nlm succeeded")
+	}
+}
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"v1.2.0", "v1.3.0", true},
+		{"v1.3.0", "v1.3.0", false},
+		{"v1.4.0", "v1.3.0", false},
+		{"v1.3.0-rc.1", "v1.3.0", true},
+		{"devel", "v1.3.0", true},
+		{"devel", "nightly", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// Package upload sends large files with Google's resumable upload
+// protocol. A file is sent in parts, each carrying a CRC32C checksum and
+// retried on its own when it fails, so that a dropped connection costs
+// one part rather than the whole file. When the server accepts parts out
+// of order, several are sent at once.
+package upload
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults for the Uploader fields left zero.
+const (
+	DefaultPartSize    = 8 << 20
+	DefaultConcurrency = 4
+	DefaultMaxRetries  = 3
+	DefaultRetryDelay  = time.Second
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Uploader sends files to a resumable upload endpoint.
+type Uploader struct {
+	// HTTPClient sends the requests; nil means http.DefaultClient.
+	HTTPClient *http.Client
+	// Header is added to every request, for credentials.
+	Header http.Header
+	// PartSize is the size of each part, rounded up to a multiple of the
+	// granularity the server asks for.
+	PartSize int64
+	// Concurrency is how many parts are sent at once when the server
+	// accepts them out of order.
+	Concurrency int
+	// MaxRetries is how many times a failed part is sent again.
+	MaxRetries int
+	// RetryDelay is the wait before the first retry of a part, doubling
+	// with each retry after it.
+	RetryDelay time.Duration
+	// OnRequest, if set, is called with each request before it is sent,
+	// for logging.
+	OnRequest func(*http.Request)
+}
+
+// Error is an upload request the server refused.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("upload: %s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// Upload starts a session at startURL for size bytes described by
+// metadata, which is sent as JSON, and sends the content of r. It
+// returns the body of the server's final response.
+//
+// The content goes in a single request unless the server gives a part
+// granularity and the content is larger than one part. Parts are sent
+// in parallel only if the server answers the start request with
+// "Accept-Ranges: bytes"; otherwise they are sent in order.
+func (u *Uploader) Upload(ctx context.Context, startURL string, metadata interface{}, r io.ReaderAt, size int64) ([]byte, error) {
+	s, err := u.start(ctx, startURL, metadata, size)
+	if err != nil {
+		return nil, err
+	}
+	partSize := u.partSize(s.granularity)
+	if s.granularity == 0 || size <= partSize {
+		return u.send(ctx, s.url, "upload, finalize", 0, io.NewSectionReader(r, 0, size), size)
+	}
+
+	var offsets []int64
+	for off := int64(0); off < size; off += partSize {
+		offsets = append(offsets, off)
+	}
+	concurrency := 1
+	if s.ranges {
+		concurrency = u.concurrency()
+	}
+	if err := u.sendParts(ctx, s.url, r, size, partSize, offsets, concurrency); err != nil {
+		return nil, err
+	}
+	return u.send(ctx, s.url, "finalize", size, nil, 0)
+}
+
+// session is an upload session the server has started.
+type session struct {
+	url         string
+	granularity int64 // 0 if the content must be sent in one request
+	ranges      bool  // whether parts may be sent out of order
+}
+
+func (u *Uploader) start(ctx context.Context, startURL string, metadata interface{}, size int64) (*session, error) {
+	meta, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("upload: encode metadata: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", startURL, bytes.NewReader(meta))
+	if err != nil {
+		return nil, fmt.Errorf("upload: %w", err)
+	}
+	u.setHeader(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+	req.Header.Set("X-Goog-Upload-Protocol", "resumable")
+	req.Header.Set("X-Goog-Upload-Command", "start")
+	req.Header.Set("X-Goog-Upload-Header-Content-Length", strconv.FormatInt(size, 10))
+	resp, _, err := u.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("upload: start: %w", err)
+	}
+	s := &session{
+		url:    resp.Header.Get("X-Goog-Upload-URL"),
+		ranges: resp.Header.Get("Accept-Ranges") == "bytes",
+	}
+	if s.url == "" {
+		return nil, fmt.Errorf("upload: start: no upload URL in response")
+	}
+	if g := resp.Header.Get("X-Goog-Upload-Chunk-Granularity"); g != "" {
+		s.granularity, err = strconv.ParseInt(g, 10, 64)
+		if err != nil || s.granularity < 0 {
+			return nil, fmt.Errorf("upload: start: invalid chunk granularity %q", g)
+		}
+	}
+	return s, nil
+}
+
+// sendParts sends the parts at offsets with up to concurrency requests
+// at once. The first failure stops parts not yet started.
+func (u *Uploader) sendParts(ctx context.Context, url string, r io.ReaderAt, size, partSize int64, offsets []int64, concurrency int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	work := make(chan int64)
+	errc := make(chan error, len(offsets))
+	for w := 0; w < min(concurrency, len(offsets)); w++ {
+		go func() {
+			for off := range work {
+				n := min(partSize, size-off)
+				_, err := u.send(ctx, url, "upload", off, io.NewSectionReader(r, off, n), n)
+				if err != nil {
+					cancel()
+				}
+				errc <- err
+			}
+		}()
+	}
+	sent := 0
+feed:
+	for _, off := range offsets {
+		select {
+		case work <- off:
+			sent++
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	var first error
+	for i := 0; i < sent; i++ {
+		if err := <-errc; err != nil && (first == nil || errors.Is(first, context.Canceled)) {
+			first = err
+		}
+	}
+	if first == nil {
+		first = ctx.Err()
+	}
+	return first
+}
+
+// send sends n bytes of part at offset with the given upload command,
+// retrying when it fails with a network error or a retryable status.
+func (u *Uploader) send(ctx context.Context, url, command string, offset int64, part io.ReadSeeker, n int64) ([]byte, error) {
+	var sum string
+	if part != nil {
+		h := crc32.New(castagnoli)
+		if _, err := io.Copy(h, part); err != nil {
+			return nil, fmt.Errorf("upload: read part at %d: %w", offset, err)
+		}
+		sum = crc32cHeader(h.Sum32())
+	}
+	delay := u.retryDelay()
+	for attempt := 0; ; attempt++ {
+		var body io.Reader = http.NoBody
+		if part != nil {
+			if _, err := part.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("upload: read part at %d: %w", offset, err)
+			}
+			body = part
+		}
+		req, err := http.NewRequestWithContext(ctx, "POST", url, body)
+		if err != nil {
+			return nil, fmt.Errorf("upload: %w", err)
+		}
+		req.ContentLength = n
+		u.setHeader(req)
+		req.Header.Set("X-Goog-Upload-Command", command)
+		req.Header.Set("X-Goog-Upload-Offset", strconv.FormatInt(offset, 10))
+		if sum != "" {
+			req.Header.Set("X-Goog-Hash", "crc32c="+sum)
+		}
+		_, data, err := u.do(req)
+		if err == nil {
+			return data, nil
+		}
+		if attempt >= u.maxRetries() || !retryable(err) || ctx.Err() != nil {
+			return nil, fmt.Errorf("upload: part at %d: %w", offset, err)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("upload: part at %d: %w", offset, ctx.Err())
+		}
+		delay *= 2
+	}
+}
+
+// do sends req and reads the response, which must be 200 OK.
+func (u *Uploader) do(req *http.Request) (*http.Response, []byte, error) {
+	hc := u.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	if u.OnRequest != nil {
+		u.OnRequest(req)
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := http.StatusText(resp.StatusCode)
+		if len(data) > 0 && len(data) < 200 {
+			msg = string(bytes.TrimSpace(data))
+		}
+		return nil, nil, &Error{StatusCode: resp.StatusCode, Message: msg}
+	}
+	return resp, data, nil
+}
+
+// retryable reports whether a part that failed with err is worth sending
+// again.
+func retryable(err error) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// crc32cHeader returns sum as Google's hash headers give it: the
+// base64 of its big-endian bytes.
+func crc32cHeader(sum uint32) string {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], sum)
+	return base64.StdEncoding.EncodeToString(b[:])
+}
+
+func (u *Uploader) setHeader(req *http.Request) {
+	for k, v := range u.Header {
+		req.Header[k] = v
+	}
+}
+
+func (u *Uploader) partSize(granularity int64) int64 {
+	size := u.PartSize
+	if size <= 0 {
+		size = DefaultPartSize
+	}
+	if granularity > 0 && size%granularity != 0 {
+		size += granularity - size%granularity
+	}
+	return size
+}
+
+func (u *Uploader) concurrency() int {
+	if u.Concurrency > 0 {
+		return u.Concurrency
+	}
+	return DefaultConcurrency
+}
+
+func (u *Uploader) maxRetries() int {
+	if u.MaxRetries > 0 {
+		return u.MaxRetries
+	}
+	return DefaultMaxRetries
+}
+
+func (u *Uploader) retryDelay() time.Duration {
+	if u.RetryDelay > 0 {
+		return u.RetryDelay
+	}
+	return DefaultRetryDelay
+}
@@ -0,0 +1,176 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeServer is a resumable upload endpoint that checks each part's
+// checksum and assembles the parts it receives.
+type fakeServer struct {
+	granularity string
+	ranges      bool
+	failFirst   map[int64]int // offset -> status to fail the first send with
+
+	mu       sync.Mutex
+	content  []byte
+	commands []string
+	inFlight int
+	maxIn    int
+	final    bool
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cmd := r.Header.Get("X-Goog-Upload-Command")
+	if cmd == "start" {
+		if r.Header.Get("Cookie") != "SID=1" {
+			http.Error(w, "no cookie", http.StatusUnauthorized)
+			return
+		}
+		size, _ := strconv.Atoi(r.Header.Get("X-Goog-Upload-Header-Content-Length"))
+		s.mu.Lock()
+		s.content = make([]byte, size)
+		s.mu.Unlock()
+		w.Header().Set("X-Goog-Upload-URL", "http://"+r.Host+"/session")
+		if s.granularity != "" {
+			w.Header().Set("X-Goog-Upload-Chunk-Granularity", s.granularity)
+		}
+		if s.ranges {
+			w.Header().Set("Accept-Ranges", "bytes")
+		}
+		return
+	}
+
+	off, _ := strconv.ParseInt(r.Header.Get("X-Goog-Upload-Offset"), 10, 64)
+	s.mu.Lock()
+	s.commands = append(s.commands, cmd)
+	s.inFlight++
+	s.maxIn = max(s.maxIn, s.inFlight)
+	status := s.failFirst[off]
+	delete(s.failFirst, off)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	time.Sleep(5 * time.Millisecond) // let parallel parts overlap
+	if status != 0 {
+		http.Error(w, "failed", status)
+		return
+	}
+
+	data, _ := io.ReadAll(r.Body)
+	if len(data) > 0 {
+		sum := crc32cHeader(crc32.Checksum(data, castagnoli))
+		if r.Header.Get("X-Goog-Hash") != "crc32c="+sum {
+			http.Error(w, "checksum mismatch", http.StatusBadRequest)
+			return
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copy(s.content[off:], data)
+	if cmd == "finalize" || cmd == "upload, finalize" {
+		s.final = true
+		w.Write([]byte(`{"ok":true}`))
+	}
+}
+
+func testContent(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i * 7)
+	}
+	return b
+}
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name      string
+		srv       *fakeServer
+		wantParts int // upload commands, not counting retries
+		parallel  bool
+	}{
+		{"single request", &fakeServer{}, 1, false},
+		{"parts in order", &fakeServer{granularity: "100"}, 10, false},
+		{"parts in parallel", &fakeServer{granularity: "100", ranges: true}, 10, true},
+		{"retried part", &fakeServer{granularity: "100", ranges: true, failFirst: map[int64]int{300: http.StatusServiceUnavailable}}, 11, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(tt.srv)
+			defer ts.Close()
+			content := testContent(950)
+			var requests atomic.Int32
+			u := &Uploader{
+				Header:     http.Header{"Cookie": {"SID=1"}},
+				PartSize:   100,
+				RetryDelay: time.Millisecond,
+				OnRequest:  func(*http.Request) { requests.Add(1) },
+			}
+			got, err := u.Upload(context.Background(), ts.URL+"/start", map[string]string{"name": "a.pdf"}, bytes.NewReader(content), int64(len(content)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != `{"ok":true}` {
+				t.Errorf("Upload() = %q", got)
+			}
+			s := tt.srv
+			if !s.final || !bytes.Equal(s.content, content) {
+				t.Errorf("server has final=%v, content equal=%v", s.final, bytes.Equal(s.content, content))
+			}
+			parts := 0
+			for _, c := range s.commands {
+				if c != "finalize" {
+					parts++
+				}
+			}
+			if parts != tt.wantParts {
+				t.Errorf("sent %d parts, want %d: %q", parts, tt.wantParts, s.commands)
+			}
+			if n := int(requests.Load()); n != len(s.commands)+1 {
+				t.Errorf("OnRequest saw %d requests, want %d", n, len(s.commands)+1)
+			}
+			if parallel := s.maxIn > 1; parallel != tt.parallel {
+				t.Errorf("parts sent in parallel = %v, want %v", parallel, tt.parallel)
+			}
+		})
+	}
+}
+
+func TestUploadError(t *testing.T) {
+	srv := &fakeServer{granularity: "100", ranges: true, failFirst: map[int64]int{200: http.StatusForbidden}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	content := testContent(950)
+	u := &Uploader{Header: http.Header{"Cookie": {"SID=1"}}, PartSize: 100, RetryDelay: time.Millisecond}
+	_, err := u.Upload(context.Background(), ts.URL, nil, bytes.NewReader(content), int64(len(content)))
+	var e *Error
+	if !errors.As(err, &e) || e.StatusCode != http.StatusForbidden {
+		t.Fatalf("Upload() error = %v, want HTTP 403", err)
+	}
+	if srv.final {
+		t.Error("upload was finalized after a part failed")
+	}
+}
+
+func TestPartSize(t *testing.T) {
+	u := &Uploader{PartSize: 1000}
+	if got := u.partSize(256); got != 1024 {
+		t.Errorf("partSize(256) = %d, want 1024", got)
+	}
+	if got := (&Uploader{}).partSize(0); got != DefaultPartSize {
+		t.Errorf("default partSize = %d, want %d", got, DefaultPartSize)
+	}
+}
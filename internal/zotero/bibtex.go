@@ -0,0 +1,342 @@
+package zotero
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ParseBibTeX reads the entries of a BibTeX file, such as one exported by
+// Zotero or Better BibTeX. PDFs are taken from the file field, with
+// relative paths resolved against dir.
+func ParseBibTeX(r io.Reader, dir string) ([]Item, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &bibParser{s: string(data), macros: map[string]string{}}
+	var items []Item
+	for {
+		e, err := p.next()
+		if err != nil {
+			return nil, fmt.Errorf("parse BibTeX: %w", err)
+		}
+		if e == nil {
+			return items, nil
+		}
+		items = append(items, e.item(dir))
+	}
+}
+
+// bibEntry is a parsed BibTeX entry, with field names lowercased.
+type bibEntry struct {
+	key    string
+	fields map[string]string
+}
+
+func (e *bibEntry) item(dir string) Item {
+	it := Item{
+		Key:   e.key,
+		Title: latexText(e.fields["title"]),
+		URL:   strings.TrimSpace(e.fields["url"]), // verbatim in BibTeX
+		DOI:   strings.TrimSpace(e.fields["doi"]),
+		Year:  year(latexText(e.fields["year"])),
+	}
+	if it.Year == "" {
+		it.Year = year(latexText(e.fields["date"]))
+	}
+	for _, name := range splitTopLevel(e.fields["author"], " and ") {
+		if name = familyName(name); name != "" {
+			it.Authors = append(it.Authors, name)
+		}
+	}
+	for _, f := range splitEscaped(e.fields["file"], ';') {
+		// Zotero writes description:path:type; Better BibTeX writes the
+		// bare path.
+		parts := splitEscaped(f, ':')
+		path := parts[0]
+		if len(parts) >= 3 {
+			path = parts[len(parts)-2]
+		}
+		path = unescapePath.Replace(path)
+		if !strings.EqualFold(filepath.Ext(path), ".pdf") {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		it.PDFs = append(it.PDFs, path)
+	}
+	return it
+}
+
+type bibParser struct {
+	s      string
+	i      int
+	macros map[string]string
+}
+
+// next returns the next entry, or nil at the end of the input. @string
+// definitions are recorded; @comment and @preamble are skipped.
+func (p *bibParser) next() (*bibEntry, error) {
+	for {
+		at := strings.IndexByte(p.s[p.i:], '@')
+		if at < 0 {
+			return nil, nil
+		}
+		p.i += at + 1
+		kind := strings.ToLower(p.word())
+		p.skipSpace()
+		if p.i >= len(p.s) || p.s[p.i] != '{' && p.s[p.i] != '(' {
+			continue // an @ in text between entries
+		}
+		closer := byte('}')
+		if p.s[p.i] == '(' {
+			closer = ')'
+		}
+		p.i++
+		switch kind {
+		case "comment":
+			continue
+		case "preamble":
+			if _, err := p.value(closer); err != nil {
+				return nil, err
+			}
+			continue
+		case "string":
+			fields, err := p.fields(closer)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range fields {
+				p.macros[k] = v
+			}
+			continue
+		}
+		p.skipSpace()
+		start := p.i
+		for p.i < len(p.s) && p.s[p.i] != ',' && p.s[p.i] != closer {
+			p.i++
+		}
+		e := &bibEntry{key: strings.TrimSpace(p.s[start:p.i])}
+		if p.i < len(p.s) && p.s[p.i] == ',' {
+			p.i++
+		}
+		fields, err := p.fields(closer)
+		if err != nil {
+			return nil, fmt.Errorf("entry %s: %w", e.key, err)
+		}
+		e.fields = fields
+		return e, nil
+	}
+}
+
+// fields reads name = value pairs up to closer.
+func (p *bibParser) fields(closer byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	for {
+		p.skipSpace()
+		if p.i >= len(p.s) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if p.s[p.i] == closer {
+			p.i++
+			return fields, nil
+		}
+		if p.s[p.i] == ',' {
+			p.i++
+			continue
+		}
+		name := strings.ToLower(p.word())
+		p.skipSpace()
+		if name == "" || p.i >= len(p.s) || p.s[p.i] != '=' {
+			return nil, fmt.Errorf("expected field at offset %d", p.i)
+		}
+		p.i++
+		v, err := p.value(closer)
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = v
+	}
+}
+
+// value reads a value: braced or quoted strings, numbers and macros
+// joined with #.
+func (p *bibParser) value(closer byte) (string, error) {
+	var b strings.Builder
+	for {
+		p.skipSpace()
+		if p.i >= len(p.s) {
+			return "", io.ErrUnexpectedEOF
+		}
+		switch c := p.s[p.i]; {
+		case c == '{':
+			s, err := p.delimited('{', '}')
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+		case c == '"':
+			s, err := p.delimited('"', '"')
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+		default:
+			w := p.word()
+			if w == "" {
+				return "", fmt.Errorf("expected value at offset %d", p.i)
+			}
+			if m, ok := p.macros[strings.ToLower(w)]; ok {
+				w = m
+			}
+			b.WriteString(w)
+		}
+		p.skipSpace()
+		if p.i < len(p.s) && p.s[p.i] == '#' {
+			p.i++
+			continue
+		}
+		return b.String(), nil
+	}
+}
+
+// delimited reads a string from open to close, keeping nested braces.
+func (p *bibParser) delimited(open, close byte) (string, error) {
+	p.i++
+	start, depth := p.i, 0
+	for ; p.i < len(p.s); p.i++ {
+		switch c := p.s[p.i]; {
+		case c == '\\':
+			p.i++
+		case c == close && depth == 0:
+			p.i++
+			return p.s[start : p.i-1], nil
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		}
+	}
+	return "", io.ErrUnexpectedEOF
+}
+
+func (p *bibParser) word() string {
+	start := p.i
+	for p.i < len(p.s) {
+		c := rune(p.s[p.i])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && !strings.ContainsRune("_-:.+/", c) {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+func (p *bibParser) skipSpace() {
+	for p.i < len(p.s) && unicode.IsSpace(rune(p.s[p.i])) {
+		p.i++
+	}
+	// % starts a comment to the end of the line outside entries.
+	if p.i < len(p.s) && p.s[p.i] == '%' {
+		if nl := strings.IndexByte(p.s[p.i:], '\n'); nl >= 0 {
+			p.i += nl
+			p.skipSpace()
+		} else {
+			p.i = len(p.s)
+		}
+	}
+}
+
+// splitTopLevel splits s on sep outside braces.
+func splitTopLevel(s, sep string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '{':
+			depth++
+		case s[i] == '}':
+			depth--
+		case depth == 0 && strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[start:i])
+			i += len(sep) - 1
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
+
+// splitEscaped splits s on sep where it is not escaped with a backslash.
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			b.WriteByte(s[i])
+			i++
+			b.WriteByte(s[i])
+		case s[i] == sep:
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	if b.Len() > 0 {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
+
+// unescapePath undoes the escaping of colons, semicolons and backslashes
+// in file fields.
+var unescapePath = strings.NewReplacer(`\\`, `\`, `\:`, ":", `\;`, ";")
+
+// familyName returns the family name of a BibTeX name: the part before
+// the comma of "Last, First", the last word of "First Last", or the whole
+// of a braced "{World Health Organization}".
+func familyName(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}") && len(splitTopLevel(name, " ")) == 1 {
+		return latexText(name)
+	}
+	if parts := splitTopLevel(name, ","); len(parts) > 1 {
+		return latexText(parts[0])
+	}
+	words := splitTopLevel(name, " ")
+	if len(words) == 0 {
+		return ""
+	}
+	return latexText(words[len(words)-1])
+}
+
+var (
+	latexAccent = regexp.MustCompile(`\\(?:([` + "`" + `'^"~=.])\s*\{?|([cuvH])(?:\s+|\{))([A-Za-z])\}?`)
+	latexSpace  = regexp.MustCompile(`\s+`)
+	accentMarks = map[string]string{
+		"`": "̀", "'": "́", "^": "̂", "~": "̃", "=": "̄",
+		".": "̇", `"`: "̈", "c": "̧", "u": "̆", "v": "̌", "H": "̋",
+	}
+	latexEscapes = strings.NewReplacer(`\&`, "&", `\%`, "%", `\_`, "_", `\$`, "$", `\#`, "#", "~", " ", "--", "–", "{", "", "}", "")
+)
+
+// latexText turns a BibTeX value into plain text: braces are removed,
+// common escapes and accents decoded, and whitespace collapsed. Accents
+// become combining marks.
+func latexText(s string) string {
+	s = latexAccent.ReplaceAllStringFunc(s, func(m string) string {
+		sm := latexAccent.FindStringSubmatch(m)
+		return sm[3] + accentMarks[sm[1]+sm[2]]
+	})
+	s = latexEscapes.Replace(s)
+	return strings.TrimSpace(latexSpace.ReplaceAllString(s, " "))
+}
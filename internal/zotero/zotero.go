@@ -0,0 +1,342 @@
+// Package zotero reads items and their attached PDFs from a local Zotero
+// library, either its zotero.sqlite database or a BibTeX export.
+package zotero
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// ErrNoCollection is returned when no collection has the name asked for.
+var ErrNoCollection = errors.New("no such collection")
+
+// Item is a library item, such as a paper or a book.
+type Item struct {
+	Key     string
+	Title   string
+	Authors []string // family names, in order
+	Year    string
+	URL     string
+	DOI     string
+	PDFs    []string // paths of attached PDF files
+}
+
+// SourceTitle returns the title a notebook source for the item gets:
+// "Attention Is All You Need (Vaswani et al., 2017)".
+func (it *Item) SourceTitle() string {
+	title := it.Title
+	if title == "" {
+		title = it.Key
+	}
+	var cite []string
+	switch len(it.Authors) {
+	case 0:
+	case 1:
+		cite = append(cite, it.Authors[0])
+	case 2:
+		cite = append(cite, it.Authors[0]+" & "+it.Authors[1])
+	default:
+		cite = append(cite, it.Authors[0]+" et al.")
+	}
+	if it.Year != "" {
+		cite = append(cite, it.Year)
+	}
+	if len(cite) == 0 {
+		return title
+	}
+	return fmt.Sprintf("%s (%s)", title, strings.Join(cite, ", "))
+}
+
+// DefaultPath returns ~/Zotero/zotero.sqlite, where Zotero keeps its
+// library unless told otherwise.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home directory: %w", err)
+	}
+	return filepath.Join(home, "Zotero", "zotero.sqlite"), nil
+}
+
+// Read returns the items of the library at path, a zotero.sqlite database
+// or a .bib export, that are in collection and its subcollections. An
+// empty collection means the whole library; BibTeX exports have no
+// collections, so collection must be empty for them.
+func Read(path, collection string) ([]Item, error) {
+	if strings.EqualFold(filepath.Ext(path), ".bib") {
+		if collection != "" {
+			return nil, fmt.Errorf("%s: BibTeX exports have no collections; export the collection instead", path)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ParseBibTeX(f, filepath.Dir(path))
+	}
+	return readDB(path, collection)
+}
+
+// readDB reads items from a zotero.sqlite database. Zotero locks the
+// database while it runs, so it is opened immutable: read-only and
+// without taking locks.
+func readDB(path, collection string) ([]Item, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs), RawQuery: "mode=ro&immutable=1"}
+	db, err := sql.Open("sqlite", u.String())
+	if err != nil {
+		return nil, fmt.Errorf("open Zotero library: %w", err)
+	}
+	defer db.Close()
+	r := &dbReader{db: db, storage: filepath.Join(filepath.Dir(abs), "storage")}
+	items, err := r.items(collection)
+	if err != nil && !errors.Is(err, ErrNoCollection) {
+		return nil, fmt.Errorf("read Zotero library %s: %w", path, err)
+	}
+	return items, err
+}
+
+type dbReader struct {
+	db      *sql.DB
+	storage string // the directory of stored attachments
+}
+
+// collectionIDs returns the IDs of the collections named name and of all
+// their subcollections.
+func (r *dbReader) collectionIDs(name string) ([]int64, error) {
+	rows, err := r.db.Query(`SELECT collectionID, collectionName, parentCollectionID FROM collections`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	children := make(map[int64][]int64)
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var n string
+		var parent sql.NullInt64
+		if err := rows.Scan(&id, &n, &parent); err != nil {
+			return nil, err
+		}
+		if parent.Valid {
+			children[parent.Int64] = append(children[parent.Int64], id)
+		}
+		if n == name {
+			ids = append(ids, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w named %q", ErrNoCollection, name)
+	}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+	return ids, nil
+}
+
+// items returns the top-level items of collection, or of the library.
+// PDFs that are in the collection on their own become items too.
+func (r *dbReader) items(collection string) ([]Item, error) {
+	query := `SELECT i.itemID, i.key, t.typeName FROM items i
+		JOIN itemTypes t ON t.itemTypeID = i.itemTypeID
+		LEFT JOIN itemAttachments a ON a.itemID = i.itemID
+		WHERE t.typeName NOT IN ('note', 'annotation')
+		AND a.parentItemID IS NULL
+		AND i.itemID NOT IN (SELECT itemID FROM deletedItems)`
+	var args []any
+	if collection != "" {
+		ids, err := r.collectionIDs(collection)
+		if err != nil {
+			return nil, err
+		}
+		query += ` AND i.itemID IN (SELECT itemID FROM collectionItems WHERE collectionID IN (?` + strings.Repeat(",?", len(ids)-1) + `))`
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+	query += ` ORDER BY i.dateAdded, i.itemID`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	type row struct {
+		id        int64
+		key, kind string
+	}
+	var found []row
+	for rows.Next() {
+		var rw row
+		if err := rows.Scan(&rw.id, &rw.key, &rw.kind); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		found = append(found, rw)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, rw := range found {
+		it := Item{Key: rw.key}
+		fields, err := r.fields(rw.id)
+		if err != nil {
+			return nil, err
+		}
+		it.Title, it.URL, it.DOI = fields["title"], fields["url"], fields["DOI"]
+		it.Year = year(fields["date"])
+		if rw.kind == "attachment" {
+			pdf, err := r.attachment(rw.id, rw.key)
+			if err != nil {
+				return nil, err
+			}
+			if pdf == "" {
+				continue
+			}
+			it.PDFs = []string{pdf}
+		} else {
+			if it.Authors, err = r.authors(rw.id); err != nil {
+				return nil, err
+			}
+			if it.PDFs, err = r.pdfs(rw.id); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+func (r *dbReader) fields(itemID int64) (map[string]string, error) {
+	rows, err := r.db.Query(`SELECT f.fieldName, v.value FROM itemData d
+		JOIN fields f ON f.fieldID = d.fieldID
+		JOIN itemDataValues v ON v.valueID = d.valueID
+		WHERE d.itemID = ?`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	fields := make(map[string]string)
+	for rows.Next() {
+		var name string
+		var value sql.NullString // numbers are converted
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		fields[name] = strings.TrimSpace(value.String)
+	}
+	return fields, rows.Err()
+}
+
+func (r *dbReader) authors(itemID int64) ([]string, error) {
+	rows, err := r.db.Query(`SELECT c.firstName, c.lastName FROM itemCreators ic
+		JOIN creators c ON c.creatorID = ic.creatorID
+		WHERE ic.itemID = ? ORDER BY ic.orderIndex`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var authors []string
+	for rows.Next() {
+		var first, last sql.NullString
+		if err := rows.Scan(&first, &last); err != nil {
+			return nil, err
+		}
+		// Single-field names, such as organizations, are kept in lastName.
+		if name := strings.TrimSpace(last.String); name != "" {
+			authors = append(authors, name)
+		} else if name := strings.TrimSpace(first.String); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	return authors, rows.Err()
+}
+
+// pdfs returns the PDF files attached to an item, in the order they were
+// added.
+func (r *dbReader) pdfs(itemID int64) ([]string, error) {
+	rows, err := r.db.Query(`SELECT a.itemID, i.key FROM itemAttachments a
+		JOIN items i ON i.itemID = a.itemID
+		WHERE a.parentItemID = ? AND a.itemID NOT IN (SELECT itemID FROM deletedItems)
+		ORDER BY i.dateAdded, i.itemID`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	type att struct {
+		id  int64
+		key string
+	}
+	var atts []att
+	for rows.Next() {
+		var a att
+		if err := rows.Scan(&a.id, &a.key); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		atts = append(atts, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	var pdfs []string
+	for _, a := range atts {
+		pdf, err := r.attachment(a.id, a.key)
+		if err != nil {
+			return nil, err
+		}
+		if pdf != "" {
+			pdfs = append(pdfs, pdf)
+		}
+	}
+	return pdfs, nil
+}
+
+// attachment returns the path of the attachment with the given ID and
+// key if it is a PDF file, or "" if it is not. Stored files are kept in
+// storage/<key>/; linked files have absolute paths. Files relative to a
+// linked attachment base directory are not supported, as the base
+// directory is a Zotero preference and not in the database.
+func (r *dbReader) attachment(id int64, key string) (string, error) {
+	var contentType, path sql.NullString
+	err := r.db.QueryRow(`SELECT contentType, path FROM itemAttachments WHERE itemID = ?`, id).Scan(&contentType, &path)
+	if err != nil {
+		return "", err
+	}
+	p := path.String
+	if contentType.String != "application/pdf" && !strings.EqualFold(filepath.Ext(p), ".pdf") {
+		return "", nil
+	}
+	switch {
+	case strings.HasPrefix(p, "storage:"):
+		return filepath.Join(r.storage, key, strings.TrimPrefix(p, "storage:")), nil
+	case filepath.IsAbs(p):
+		return p, nil
+	}
+	return "", nil
+}
+
+// year returns the year of a date. Zotero stores dates as "2017-06-12
+// June 12, 2017", with 00 for unknown parts.
+func year(date string) string {
+	if len(date) >= 4 && date[:4] != "0000" && strings.Trim(date[:4], "0123456789") == "" {
+		return date[:4]
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package zotero
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The parts of Zotero's schema the reader uses.
+const testSchema = `
+CREATE TABLE itemTypes (itemTypeID INTEGER PRIMARY KEY, typeName TEXT);
+CREATE TABLE items (itemID INTEGER PRIMARY KEY, itemTypeID INT, dateAdded TEXT, key TEXT);
+CREATE TABLE fields (fieldID INTEGER PRIMARY KEY, fieldName TEXT);
+CREATE TABLE itemDataValues (valueID INTEGER PRIMARY KEY, value);
+CREATE TABLE itemData (itemID INT, fieldID INT, valueID INT);
+CREATE TABLE creators (creatorID INTEGER PRIMARY KEY, firstName TEXT, lastName TEXT, fieldMode INT);
+CREATE TABLE itemCreators (itemID INT, creatorID INT, creatorTypeID INT, orderIndex INT);
+CREATE TABLE itemAttachments (itemID INTEGER PRIMARY KEY, parentItemID INT, linkMode INT, contentType TEXT, path TEXT);
+CREATE TABLE collections (collectionID INTEGER PRIMARY KEY, collectionName TEXT, parentCollectionID INT, key TEXT);
+CREATE TABLE collectionItems (collectionID INT, itemID INT, orderIndex INT);
+CREATE TABLE deletedItems (itemID INTEGER PRIMARY KEY);
+
+INSERT INTO itemTypes VALUES (1, 'journalArticle'), (2, 'attachment'), (3, 'note'), (4, 'webpage');
+INSERT INTO fields VALUES (1, 'title'), (2, 'date'), (3, 'url'), (4, 'DOI');
+INSERT INTO collections VALUES (1, 'PhD', NULL, 'C1'), (2, 'Chapter 2', 1, 'C2'), (3, 'Other', NULL, 'C3');
+
+-- A paper with a stored PDF and a snapshot, in PhD.
+INSERT INTO items VALUES (10, 1, '2024-01-01', 'PAPER1');
+INSERT INTO itemDataValues VALUES (1, 'Attention Is All You Need'), (2, '2017-06-12 June 12, 2017');
+INSERT INTO itemData VALUES (10, 1, 1), (10, 2, 2);
+INSERT INTO creators VALUES (1, 'Ashish', 'Vaswani', 0), (2, 'Noam', 'Shazeer', 0), (3, 'Niki', 'Parmar', 0);
+INSERT INTO itemCreators VALUES (10, 2, 1, 1), (10, 1, 1, 0), (10, 3, 1, 2);
+INSERT INTO items VALUES (11, 2, '2024-01-02', 'ATT1'), (12, 2, '2024-01-03', 'ATT2');
+INSERT INTO itemAttachments VALUES (11, 10, 0, 'application/pdf', 'storage:vaswani.pdf'), (12, 10, 1, 'text/html', 'storage:snapshot.html');
+INSERT INTO collectionItems VALUES (1, 10, 0);
+
+-- A web page without a PDF, in Chapter 2, by an organization.
+INSERT INTO items VALUES (20, 4, '2024-02-01', 'PAGE1');
+INSERT INTO itemDataValues VALUES (3, 'Guidance'), (4, 'https://example.org/guidance'), (5, '0000-00-00 n.d.');
+INSERT INTO itemData VALUES (20, 1, 3), (20, 3, 4), (20, 2, 5);
+INSERT INTO creators VALUES (4, NULL, 'World Health Organization', 1);
+INSERT INTO itemCreators VALUES (20, 4, 1, 0);
+INSERT INTO collectionItems VALUES (2, 20, 0);
+
+-- A standalone linked PDF and a note, in PhD.
+INSERT INTO items VALUES (30, 2, '2024-03-01', 'ATT3'), (31, 3, '2024-03-02', 'NOTE1');
+INSERT INTO itemDataValues VALUES (6, 'Scanned chapter');
+INSERT INTO itemData VALUES (30, 1, 6);
+INSERT INTO itemAttachments VALUES (30, NULL, 2, 'application/pdf', '/papers/chapter.pdf');
+INSERT INTO collectionItems VALUES (1, 30, 1), (1, 31, 2);
+
+-- A deleted paper in PhD, and a paper in another collection.
+INSERT INTO items VALUES (40, 1, '2024-04-01', 'GONE'), (50, 1, '2024-05-01', 'OTHER');
+INSERT INTO deletedItems VALUES (40);
+INSERT INTO collectionItems VALUES (1, 40, 3), (3, 50, 0);
+`
+
+func testLibrary(t *testing.T) (path, storage string) {
+	t.Helper()
+	dir := t.TempDir()
+	path = filepath.Join(dir, "zotero.sqlite")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(testSchema); err != nil {
+		t.Fatal(err)
+	}
+	return path, filepath.Join(dir, "storage")
+}
+
+func TestReadCollection(t *testing.T) {
+	path, storage := testLibrary(t)
+	items, err := Read(path, "PhD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Item{
+		{Key: "PAPER1", Title: "Attention Is All You Need", Year: "2017", Authors: []string{"Vaswani", "Shazeer", "Parmar"},
+			PDFs: []string{filepath.Join(storage, "ATT1", "vaswani.pdf")}},
+		{Key: "PAGE1", Title: "Guidance", URL: "https://example.org/guidance", Authors: []string{"World Health Organization"}},
+		{Key: "ATT3", Title: "Scanned chapter", PDFs: []string{"/papers/chapter.pdf"}},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("Read() =\n%+v\nwant\n%+v", items, want)
+	}
+
+	all, err := Read(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 || all[3].Key != "OTHER" {
+		t.Errorf("Read of the whole library = %+v", all)
+	}
+
+	if _, err := Read(path, "Nope"); !errors.Is(err, ErrNoCollection) {
+		t.Errorf("Read of a missing collection: err = %v, want ErrNoCollection", err)
+	}
+}
+
+func TestSourceTitle(t *testing.T) {
+	tests := []struct {
+		item Item
+		want string
+	}{
+		{Item{Title: "T", Authors: []string{"A", "B", "C"}, Year: "2017"}, "T (A et al., 2017)"},
+		{Item{Title: "T", Authors: []string{"A", "B"}}, "T (A & B)"},
+		{Item{Title: "T", Year: "1999"}, "T (1999)"},
+		{Item{Key: "K"}, "K"},
+	}
+	for _, tt := range tests {
+		if got := tt.item.SourceTitle(); got != tt.want {
+			t.Errorf("SourceTitle() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+const testBib = `% Exported by Zotero
+@string{neurips = "Advances in Neural Information Processing Systems"}
+
+@inproceedings{vaswani_attention_2017,
+	title = {Attention {Is} {All} {You} {Need}},
+	booktitle = neurips # " 30",
+	author = {Vaswani, Ashish and Shazeer, Noam and Parmar, Niki},
+	year = {2017},
+	url = {https://arxiv.org/abs/1706.03762},
+	file = {Full Text PDF:files/12/Vaswani et al. - 2017.pdf:application/pdf;Snapshot:files/13/1706.html:text/html},
+}
+
+@article{godel,
+  title = "{\"U}ber formal unentscheidbare S{\"a}tze \& so on",
+  author = "Kurt G\"{o}del",
+  date = "1931-01",
+  file = {C\:\\papers\\godel.pdf}
+}
+
+@misc{who_2020, title={Guidance}, author={{World Health Organization}}}
+`
+
+func TestParseBibTeX(t *testing.T) {
+	items, err := ParseBibTeX(strings.NewReader(testBib), "/export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Item{
+		{Key: "vaswani_attention_2017", Title: "Attention Is All You Need", Year: "2017", URL: "https://arxiv.org/abs/1706.03762",
+			Authors: []string{"Vaswani", "Shazeer", "Parmar"}, PDFs: []string{filepath.Join("/export", "files/12/Vaswani et al. - 2017.pdf")}},
+		{Key: "godel", Title: "U\u0308ber formal unentscheidbare Sa\u0308tze & so on", Year: "1931",
+			Authors: []string{"Go\u0308del"}, PDFs: []string{filepath.Join("/export", `C:\papers\godel.pdf`)}},
+		{Key: "who_2020", Title: "Guidance", Authors: []string{"World Health Organization"}},
+	}
+	if filepath.IsAbs(`C:\papers\godel.pdf`) {
+		want[1].PDFs[0] = `C:\papers\godel.pdf`
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("ParseBibTeX() =\n%+v\nwant\n%+v", items, want)
+	}
+}
+
+func TestParseBibTeXUnterminated(t *testing.T) {
+	if _, err := ParseBibTeX(strings.NewReader("@article{x, title = {Open"), ""); err == nil {
+		t.Error("ParseBibTeX of an unterminated entry succeeded")
+	}
+}
+
+func TestReadBibTeXCollection(t *testing.T) {
+	if _, err := Read("library.bib", "PhD"); err == nil || !strings.Contains(err.Error(), "no collections") {
+		t.Errorf("Read of a .bib with a collection: err = %v", err)
+	}
+}